@@ -9,15 +9,24 @@ import (
 	"syscall"
 	"time"
 
+	"trading-alchemist/internal/application/alert"
 	"trading-alchemist/internal/application/auth"
+	"trading-alchemist/internal/application/organization"
 	"trading-alchemist/internal/config"
 	"trading-alchemist/internal/infrastructure/database"
 	"trading-alchemist/internal/infrastructure/email"
 	"trading-alchemist/internal/infrastructure/llm/agent"
+	"trading-alchemist/internal/infrastructure/oidc"
+	"trading-alchemist/internal/infrastructure/ratelimit"
+	"trading-alchemist/internal/infrastructure/sandbox"
+	"trading-alchemist/internal/infrastructure/services"
 	server "trading-alchemist/internal/presentation/http"
 )
 
 func main() {
+	// Act as the code sandbox launcher when started as one by the run_code tool
+	sandbox.RunLauncherIfRequested()
+
 	// Load configuration
 	cfg := config.Load()
 	if err := cfg.Validate(); err != nil {
@@ -45,16 +54,38 @@ func main() {
 	}
 
 	// Setup LLM service
-	llmService, err := agent.NewLLMService()
+	llmService, err := agent.NewLLMService(dbService)
 	if err != nil {
 		log.Fatalf("Failed to create LLM service: %v", err)
 	}
 
+	// Setup external sign-in providers
+	identityProviders, err := oidc.NewIdentityProviders(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create sign-in providers: %v", err)
+	}
+
+	rateLimiter, err := ratelimit.New(cfg.RateLimit, dbService)
+	if err != nil {
+		log.Fatalf("Failed to create rate limiter: %v", err)
+	}
+
+	auditLogger := services.NewAuditLogger(dbService)
+
 	// Initialize use cases - repositories are now managed through dbService
-	authUseCase := auth.NewAuthUseCase(emailService, cfg, dbService)
+	authUseCase := auth.NewAuthUseCase(emailService, identityProviders, rateLimiter, auditLogger, cfg, dbService)
+	organizationUseCase := organization.NewOrganizationUseCase(emailService, rateLimiter, auditLogger, cfg, dbService)
+
+	// Start the alert worker; it stops when the context is cancelled on shutdown
+	if cfg.Alerts.WorkerEnabled {
+		alertWorker := alert.NewWorker(dbService, emailService, cfg.Alerts.WorkerInterval)
+		go alertWorker.Run(ctx)
+		log.Printf("Alert worker started, evaluating rules every %s", cfg.Alerts.WorkerInterval)
+	}
 
 	// Initialize HTTP server
-	httpServer := server.NewServer(cfg, authUseCase, dbService, llmService)
+	httpServer := server.NewServer(cfg, authUseCase, organizationUseCase, rateLimiter, auditLogger, emailService, dbService, llmService)
+	httpServer.StartWorkers(ctx)
 
 	// Start server in a goroutine
 	go func() {
@@ -70,6 +101,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	cancel()
 
 	// Shutdown context with a timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
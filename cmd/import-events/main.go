@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"trading-alchemist/internal/application/calendar"
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/infrastructure/database"
+)
+
+// import-events loads an ICS or CSV file into the events calendar shared with
+// all users, e.g. an earnings or economic calendar export.
+func main() {
+	path := flag.String("file", "", "ICS or CSV file to import")
+	flag.Parse()
+	if *path == "" {
+		log.Fatal("Usage: import-events -file <calendar.ics|calendar.csv>")
+	}
+
+	// Load configuration
+	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Configuration validation failed: %v", err)
+	}
+
+	// Create context
+	ctx := context.Background()
+
+	// Setup database connection
+	dbPool, err := database.NewConnection(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close(dbPool)
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", *path, err)
+	}
+	defer file.Close()
+
+	// Import into the shared calendar
+	calendarUseCase := calendar.NewCalendarUseCase(database.NewService(dbPool))
+	result, err := calendarUseCase.ImportSharedEvents(ctx, file)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+	for _, e := range result.Errors {
+		log.Printf("Skipped event at line %d: %s", e.Line, e.Message)
+	}
+
+	log.Printf("Imported %d events from %s (%s).", result.Imported, *path, result.Format)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"trading-alchemist/internal/application/encryption"
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/infrastructure/database"
+)
+
+// rotate-keys re-encrypts the stored secrets (provider API keys, broker
+// credentials and authenticator secrets) with the current encryption key.
+// Once it has run, keys other than ENCRYPTION_KEY_ID can be removed.
+func main() {
+	batchSize := flag.Int("batch-size", 100, "Number of secrets re-encrypted per transaction")
+	flag.Parse()
+
+	// Load configuration
+	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Configuration validation failed: %v", err)
+	}
+	keyring, err := cfg.GetKeyring()
+	if err != nil {
+		log.Fatalf("Failed to load encryption keys: %v", err)
+	}
+
+	// Create context
+	ctx := context.Background()
+
+	// Setup database connection
+	dbPool, err := database.NewConnection(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close(dbPool)
+
+	log.Printf("Re-encrypting secrets with key %s...", keyring.CurrentKeyID())
+	rotationUseCase := encryption.NewKeyRotationUseCase(database.NewService(dbPool), cfg)
+	results, err := rotationUseCase.RotateKeys(ctx, *batchSize)
+	for _, r := range results {
+		log.Printf("%s: re-encrypted %d of %d secrets", r.Kind, r.Reencrypted, r.Scanned)
+	}
+	if err != nil {
+		log.Fatalf("Key rotation failed: %v", err)
+	}
+
+	log.Println("All secrets are encrypted with the current key.")
+}
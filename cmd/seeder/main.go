@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"trading-alchemist/internal/config"
 	"trading-alchemist/internal/infrastructure/database"
@@ -9,6 +10,9 @@ import (
 )
 
 func main() {
+	adminEmail := flag.String("admin", "", "Email of an existing user to grant the admin role")
+	flag.Parse()
+
 	log.Println("Starting database seeder...")
 
 	// Load configuration
@@ -32,6 +36,9 @@ func main() {
 
 	// Run the seeder
 	seeder.Seed(dbService)
+	if *adminEmail != "" {
+		seeder.GrantAdmin(dbService, *adminEmail)
+	}
 
 	log.Println("Seeder finished successfully.")
 } 
\ No newline at end of file
@@ -0,0 +1,46 @@
+package account
+
+import (
+	"time"
+
+	appAuth "trading-alchemist/internal/application/auth"
+	appChat "trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/domain/chat"
+)
+
+// --- Response DTOs ---
+
+// AccountDeletionResponse describes a pending account deletion.
+type AccountDeletionResponse struct {
+	// When the account and its data will be permanently deleted
+	ScheduledFor time.Time `json:"scheduled_for"`
+	// When the deletion was requested
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// --- Export files ---
+
+// exportManifest describes the archive; it is written as manifest.json.
+type exportManifest struct {
+	UserID     string    `json:"user_id"`
+	ExportedAt time.Time `json:"exported_at"`
+	Files      []string  `json:"files"`
+}
+
+// exportSettings holds the user's preferences. Provider settings only tell
+// whether an API key is set; keys are never exported.
+type exportSettings struct {
+	MemoryEnabled    bool                                  `json:"memory_enabled"`
+	ProviderSettings []appChat.UserProviderSettingResponse `json:"provider_settings"`
+	Memories         []*chat.UserMemory                    `json:"memories"`
+}
+
+// export is everything written to the archive, by file name.
+type export struct {
+	Profile       appAuth.UserResponse
+	Conversations []*chat.Conversation
+	Messages      []*chat.Message
+	Artifacts     []*chat.Artifact
+	Settings      exportSettings
+	AuditEvents   []appAuth.AuditEventResponse
+}
@@ -0,0 +1,430 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	appAuth "trading-alchemist/internal/application/auth"
+	appChat "trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/application/document"
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// exportPageSize is the number of rows read per query while exporting.
+	exportPageSize = 500
+	// purgeBatchSize is the number of accounts purged per worker run.
+	purgeBatchSize = 100
+	// hashBatchSize is the number of cached embeddings deleted per query.
+	hashBatchSize = 1000
+)
+
+// AccountUseCase lets users take their data with them and delete their
+// account. Deletions wait for a grace period during which they can be
+// cancelled; the worker then purges the account.
+type AccountUseCase struct {
+	dbService       *database.Service
+	emailService    services.EmailService
+	auditLogger     services.AuditLogger
+	config          *config.Config
+	documentUseCase *document.DocumentUseCase // nil when document retrieval is disabled
+}
+
+// NewAccountUseCase creates a new AccountUseCase instance.
+func NewAccountUseCase(dbService *database.Service, emailService services.EmailService, auditLogger services.AuditLogger, config *config.Config, documentUseCase *document.DocumentUseCase) *AccountUseCase {
+	return &AccountUseCase{
+		dbService:       dbService,
+		emailService:    emailService,
+		auditLogger:     auditLogger,
+		config:          config,
+		documentUseCase: documentUseCase,
+	}
+}
+
+// ExportData returns a zip archive of the user's profile, conversations with
+// their messages and artifacts, settings and audit events, as JSON files.
+func (uc *AccountUseCase) ExportData(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	var data export
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		user, err := provider.User().GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		data.Profile = appAuth.ToUserResponse(user)
+		data.Settings.MemoryEnabled = user.MemoryEnabled
+
+		if err := exportConversations(ctx, provider, userID, &data); err != nil {
+			return err
+		}
+
+		settings, err := provider.UserProviderSetting().ListByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list provider settings: %w", err)
+		}
+		data.Settings.ProviderSettings = make([]appChat.UserProviderSettingResponse, 0, len(settings))
+		for _, setting := range settings {
+			providerInfo, err := provider.Provider().GetByID(ctx, setting.ProviderID)
+			if err != nil {
+				return fmt.Errorf("failed to get provider %s: %w", setting.ProviderID, err)
+			}
+			data.Settings.ProviderSettings = append(data.Settings.ProviderSettings, appChat.ToUserProviderSettingResponse(setting, providerInfo))
+		}
+
+		data.Settings.Memories, err = provider.UserMemory().ListByUserID(ctx, userID, chat.MaxMemoriesPerUser)
+		if err != nil {
+			return fmt.Errorf("failed to list memories: %w", err)
+		}
+
+		filter := audit.EventFilter{InvolvingUserID: &userID}
+		data.AuditEvents = []appAuth.AuditEventResponse{}
+		for offset := 0; ; offset += exportPageSize {
+			events, err := provider.AuditEvent().List(ctx, filter, exportPageSize, offset)
+			if err != nil {
+				return fmt.Errorf("failed to list audit events: %w", err)
+			}
+			for _, event := range events {
+				data.AuditEvents = append(data.AuditEvents, appAuth.ToAuditEventResponse(event))
+			}
+			if len(events) < exportPageSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := writeArchive(userID, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionAccountExported, userID).
+		On(audit.ResourceUser, userID).
+		With("conversations", len(data.Conversations)).
+		With("messages", len(data.Messages)))
+	return archive, nil
+}
+
+// exportConversations adds every conversation the user started, archived ones
+// included, with their messages and artifacts.
+func exportConversations(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID, data *export) error {
+	data.Conversations = []*chat.Conversation{}
+	data.Messages = []*chat.Message{}
+	data.Artifacts = []*chat.Artifact{}
+	return forEachMessage(ctx, provider, userID, func(conversation *chat.Conversation, messages []*chat.Message) error {
+		if conversation != nil {
+			data.Conversations = append(data.Conversations, conversation)
+		}
+		for _, msg := range messages {
+			data.Messages = append(data.Messages, msg)
+			artifacts, err := provider.Artifact().GetByMessageID(ctx, msg.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get artifacts of message %s: %w", msg.ID, err)
+			}
+			data.Artifacts = append(data.Artifacts, artifacts...)
+		}
+		return nil
+	})
+}
+
+// forEachMessage pages through the user's conversations and their messages,
+// oldest first. fn is called with each conversation before its first page of
+// messages, and with a nil conversation for the following pages.
+func forEachMessage(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID, fn func(conversation *chat.Conversation, messages []*chat.Message) error) error {
+	for offset := 0; ; offset += exportPageSize {
+		conversations, err := provider.Conversation().ListAllByUserID(ctx, userID, exportPageSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list conversations: %w", err)
+		}
+		for _, conversation := range conversations {
+			first := conversation
+			for msgOffset := 0; ; msgOffset += exportPageSize {
+				messages, err := provider.Message().GetByConversationID(ctx, conversation.ID, exportPageSize, msgOffset)
+				if err != nil {
+					return fmt.Errorf("failed to get messages of conversation %s: %w", conversation.ID, err)
+				}
+				if err := fn(first, messages); err != nil {
+					return err
+				}
+				first = nil
+				if len(messages) < exportPageSize {
+					break
+				}
+			}
+		}
+		if len(conversations) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// writeArchive writes the export as indented JSON files in a zip archive.
+func writeArchive(userID uuid.UUID, data *export) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", data.Profile},
+		{"conversations.json", data.Conversations},
+		{"messages.json", data.Messages},
+		{"artifacts.json", data.Artifacts},
+		{"settings.json", data.Settings},
+		{"audit_events.json", data.AuditEvents},
+	}
+	manifest := exportManifest{UserID: userID.String(), ExportedAt: time.Now().UTC()}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file.name)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name string, content any) error {
+		w, err := zw.Create(name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to export: %w", name, err)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		return nil
+	}
+	if err := write("manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if err := write(file.name, file.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish export: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ScheduleDeletion schedules the deletion of the user's account after the
+// grace period and emails the user a confirmation. Requesting it again keeps
+// the original date. Last owners of organizations with other members must
+// hand the organization over first.
+func (uc *AccountUseCase) ScheduleDeletion(ctx context.Context, userID uuid.UUID) (*AccountDeletionResponse, error) {
+	var user *auth.User
+	var deletion *auth.AccountDeletion
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		user, err = provider.User().GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		orgs, err := provider.Organization().ListByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list organizations: %w", err)
+		}
+		for _, org := range orgs {
+			if org.Role != organization.RoleOwner {
+				continue
+			}
+			members, err := provider.Organization().ListMembers(ctx, org.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list members of organization %s: %w", org.ID, err)
+			}
+			if len(members) > 1 && countOwners(members) == 1 {
+				return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("You are the last owner of %s; make another member an owner or delete the organization first", org.Name), nil)
+			}
+		}
+
+		deletion, err = provider.AccountDeletion().Schedule(ctx, userID, time.Now().UTC().Add(uc.config.Account.DeletionGracePeriod))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionAccountDeletionScheduled, userID).
+		On(audit.ResourceUser, userID).
+		With("scheduled_for", deletion.ScheduledFor.Format(time.RFC3339)))
+
+	// Send email asynchronously after transaction is committed
+	go func(userToSend *auth.User, scheduledFor time.Time) {
+		if err := uc.emailService.SendAccountDeletionEmail(context.Background(), userToSend, scheduledFor); err != nil {
+			log.Printf("Failed to send account deletion email: %v\n", err)
+		}
+	}(user, deletion.ScheduledFor)
+
+	return toAccountDeletionResponse(deletion), nil
+}
+
+// GetDeletion returns the user's pending account deletion.
+func (uc *AccountUseCase) GetDeletion(ctx context.Context, userID uuid.UUID) (*AccountDeletionResponse, error) {
+	var deletion *auth.AccountDeletion
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		deletion, err = provider.AccountDeletion().GetByUserID(ctx, userID)
+		if err == errors.ErrAccountDeletionNotFound {
+			return errors.NewAppError(errors.CodeNotFound, "No account deletion is scheduled", err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAccountDeletionResponse(deletion), nil
+}
+
+// CancelDeletion cancels the user's pending account deletion.
+func (uc *AccountUseCase) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		err := provider.AccountDeletion().Cancel(ctx, userID)
+		if err == errors.ErrAccountDeletionNotFound {
+			return errors.NewAppError(errors.CodeNotFound, "No account deletion is scheduled", err)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionAccountDeletionCancelled, userID).On(audit.ResourceUser, userID))
+	return nil
+}
+
+// PurgeDueAccounts deletes the accounts whose grace period ended by now and
+// returns how many were deleted. Failures of single accounts are logged and
+// retried on the next run.
+func (uc *AccountUseCase) PurgeDueAccounts(ctx context.Context, now time.Time) (int, error) {
+	var due []*auth.AccountDeletion
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		due, err = provider.AccountDeletion().ListDue(ctx, now, purgeBatchSize)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, deletion := range due {
+		if ctx.Err() != nil {
+			return purged, ctx.Err()
+		}
+		if err := uc.purgeAccount(ctx, deletion); err != nil {
+			log.Printf("Failed to delete account %s: %v", deletion.UserID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeAccount permanently deletes a user. Documents are removed from the
+// vector index and cached embeddings of the user's texts are dropped; the
+// user's rows go with the user by cascade. Organizations the user was the only
+// member of are deleted, and organizations that would be left without an owner
+// pass to their longest-standing member. Audit events are kept.
+func (uc *AccountUseCase) purgeAccount(ctx context.Context, deletion *auth.AccountDeletion) error {
+	userID := deletion.UserID
+	if uc.documentUseCase != nil {
+		if err := uc.documentUseCase.PurgeUserDocuments(ctx, userID); err != nil {
+			return fmt.Errorf("failed to purge documents: %w", err)
+		}
+	}
+
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var hashes []string
+		err := forEachMessage(ctx, provider, userID, func(_ *chat.Conversation, messages []*chat.Message) error {
+			for _, msg := range messages {
+				if msg.Content != "" {
+					hashes = append(hashes, chat.ContentHash(msg.Content))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for start := 0; start < len(hashes); start += hashBatchSize {
+			end := min(start+hashBatchSize, len(hashes))
+			if err := provider.EmbeddingCache().DeleteByContentHashes(ctx, hashes[start:end]); err != nil {
+				return err
+			}
+		}
+
+		orgs, err := provider.Organization().ListByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list organizations: %w", err)
+		}
+		for _, org := range orgs {
+			if err := handOverOrganization(ctx, provider, org, userID); err != nil {
+				return err
+			}
+		}
+
+		return provider.User().Delete(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAccountDeleted).
+		Concerning(userID).
+		On(audit.ResourceUser, userID).
+		With("requested_at", deletion.CreatedAt.Format(time.RFC3339)))
+	return nil
+}
+
+// handOverOrganization makes sure an organization survives the deletion of
+// one of its members: it is deleted when the user is its only member, and its
+// longest-standing other member becomes owner when the user is its last owner.
+func handOverOrganization(ctx context.Context, provider database.RepositoryProvider, org *organization.Organization, userID uuid.UUID) error {
+	members, err := provider.Organization().ListMembers(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list members of organization %s: %w", org.ID, err)
+	}
+	if len(members) <= 1 {
+		return provider.Organization().Delete(ctx, org.ID)
+	}
+	if org.Role != organization.RoleOwner || countOwners(members) > 1 {
+		return nil
+	}
+	for _, member := range members {
+		if member.UserID != userID {
+			member.Role = organization.RoleOwner
+			return provider.Organization().SetMember(ctx, member)
+		}
+	}
+	return nil
+}
+
+func countOwners(members []*organization.Member) int {
+	owners := 0
+	for _, m := range members {
+		if m.Role == organization.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
+
+func toAccountDeletionResponse(deletion *auth.AccountDeletion) *AccountDeletionResponse {
+	return &AccountDeletionResponse{
+		ScheduledFor: deletion.ScheduledFor,
+		RequestedAt:  deletion.CreatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package account
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Worker purges the accounts whose deletion grace period has ended, on a
+// fixed interval.
+type Worker struct {
+	accountUseCase *AccountUseCase
+	interval       time.Duration
+}
+
+// NewWorker creates a new account deletion Worker.
+func NewWorker(accountUseCase *AccountUseCase, interval time.Duration) *Worker {
+	return &Worker{
+		accountUseCase: accountUseCase,
+		interval:       interval,
+	}
+}
+
+// Run purges due accounts immediately and then on every interval until the context is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := w.accountUseCase.PurgeDueAccounts(ctx, time.Now().UTC())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Account deletion worker run failed: %v", err)
+		}
+		if purged > 0 {
+			log.Printf("Account deletion worker deleted %d accounts", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"time"
+
+	appAuth "trading-alchemist/internal/application/auth"
+	"trading-alchemist/internal/domain/chat"
+
+	"github.com/google/uuid"
+)
+
+// --- Response DTOs ---
+
+// ProviderResponse is a provider with all its models, including inactive ones.
+type ProviderResponse struct {
+	ID          uuid.UUID        `json:"id"`
+	Name        string           `json:"name"`
+	DisplayName string           `json:"display_name"`
+	IsActive    bool             `json:"is_active"`
+	Models      []*ModelResponse `json:"models"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// ModelResponse is a model with its capabilities and pricing.
+type ModelResponse struct {
+	ID                 uuid.UUID `json:"id"`
+	ProviderID         uuid.UUID `json:"provider_id"`
+	Name               string    `json:"name"`
+	DisplayName        string    `json:"display_name"`
+	SupportsFunctions  bool      `json:"supports_functions"`
+	SupportsVision     bool      `json:"supports_vision"`
+	SupportsEmbeddings bool      `json:"supports_embeddings"`
+	IsActive           bool      `json:"is_active"`
+	// Prices in USD per million tokens; null when unknown
+	InputPricePerMillion  *float64  `json:"input_price_per_million"`
+	OutputPricePerMillion *float64  `json:"output_price_per_million"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// ToolResponse is a tool the assistant can call.
+type ToolResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	ProviderID  *uuid.UUID `json:"provider_id"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// UserListResponse is a page of users.
+type UserListResponse struct {
+	Users []appAuth.UserResponse `json:"users"`
+	Total int64                  `json:"total"`
+}
+
+// AuditEventQuery filters the audit log. Zero values match everything.
+type AuditEventQuery struct {
+	UserID       *uuid.UUID
+	ActorID      *uuid.UUID
+	Action       string
+	ResourceType string
+	Since        *time.Time
+	Until        *time.Time
+	Limit        int
+	Offset       int
+}
+
+// UserUsageResponse sums up a user's chat usage over a period.
+type UserUsageResponse struct {
+	UserID        uuid.UUID `json:"user_id"`
+	Since         time.Time `json:"since"`
+	Conversations int64     `json:"conversations"`
+	Messages      int64     `json:"messages"` // Assistant messages
+	// Tokens and cost only include messages that recorded them
+	Tokens int64                 `json:"tokens"`
+	Cost   float64               `json:"cost"`
+	Models []*ModelUsageResponse `json:"models"`
+}
+
+// ModelUsageResponse is the part of a user's usage served by one model.
+type ModelUsageResponse struct {
+	ModelID          *uuid.UUID `json:"model_id"`
+	ModelName        string     `json:"model_name,omitempty"`
+	ModelDisplayName string     `json:"model_display_name,omitempty"`
+	Messages         int64      `json:"messages"`
+	Tokens           int64      `json:"tokens"`
+	Cost             float64    `json:"cost"`
+}
+
+// --- Request DTOs ---
+
+// CreateProviderRequest adds a provider. The name is what the LLM service
+// dispatches on, e.g. "openai".
+type CreateProviderRequest struct {
+	Name        string `json:"name" validate:"required"`
+	DisplayName string `json:"display_name" validate:"required"`
+	IsActive    *bool  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// UpdateProviderRequest changes a provider; omitted fields are left unchanged.
+type UpdateProviderRequest struct {
+	DisplayName *string `json:"display_name,omitempty"`
+	IsActive    *bool   `json:"is_active,omitempty"`
+}
+
+// CreateModelRequest adds a model to a provider.
+type CreateModelRequest struct {
+	Name                  string   `json:"name" validate:"required"`
+	DisplayName           string   `json:"display_name" validate:"required"`
+	SupportsFunctions     bool     `json:"supports_functions"`
+	SupportsVision        bool     `json:"supports_vision"`
+	SupportsEmbeddings    bool     `json:"supports_embeddings"`
+	IsActive              *bool    `json:"is_active,omitempty"` // Defaults to true
+	InputPricePerMillion  *float64 `json:"input_price_per_million,omitempty"`
+	OutputPricePerMillion *float64 `json:"output_price_per_million,omitempty"`
+}
+
+// UpdateModelRequest changes a model; omitted fields are left unchanged.
+type UpdateModelRequest struct {
+	DisplayName           *string  `json:"display_name,omitempty"`
+	SupportsFunctions     *bool    `json:"supports_functions,omitempty"`
+	SupportsVision        *bool    `json:"supports_vision,omitempty"`
+	SupportsEmbeddings    *bool    `json:"supports_embeddings,omitempty"`
+	IsActive              *bool    `json:"is_active,omitempty"`
+	InputPricePerMillion  *float64 `json:"input_price_per_million,omitempty"`
+	OutputPricePerMillion *float64 `json:"output_price_per_million,omitempty"`
+	// Removes both prices, e.g. when they are no longer known
+	ClearPricing bool `json:"clear_pricing,omitempty"`
+}
+
+// UpdateToolRequest enables or disables a tool for all users.
+type UpdateToolRequest struct {
+	IsActive *bool `json:"is_active" validate:"required"`
+}
+
+// UpdateUserRoleRequest changes a user's role.
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
+}
+
+// --- Helper Functions ---
+
+// ToProviderResponse converts a provider and its models to a response DTO.
+func ToProviderResponse(p *chat.Provider) *ProviderResponse {
+	response := &ProviderResponse{
+		ID:          p.ID,
+		Name:        p.Name,
+		DisplayName: p.DisplayName,
+		IsActive:    p.IsActive,
+		Models:      make([]*ModelResponse, len(p.Models)),
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+	for i, m := range p.Models {
+		response.Models[i] = ToModelResponse(m)
+	}
+	return response
+}
+
+// ToModelResponse converts a model entity to a response DTO.
+func ToModelResponse(m *chat.Model) *ModelResponse {
+	return &ModelResponse{
+		ID:                    m.ID,
+		ProviderID:            m.ProviderID,
+		Name:                  m.Name,
+		DisplayName:           m.DisplayName,
+		SupportsFunctions:     m.SupportsFunctions,
+		SupportsVision:        m.SupportsVision,
+		SupportsEmbeddings:    m.SupportsEmbeddings,
+		IsActive:              m.IsActive,
+		InputPricePerMillion:  m.InputPricePerMillion,
+		OutputPricePerMillion: m.OutputPricePerMillion,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+	}
+}
+
+// ToToolResponse converts a tool entity to a response DTO.
+func ToToolResponse(t *chat.Tool) *ToolResponse {
+	return &ToolResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		ProviderID:  t.ProviderID,
+		IsActive:    t.IsActive,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
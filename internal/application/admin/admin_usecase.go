@@ -0,0 +1,578 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appAuth "trading-alchemist/internal/application/auth"
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+	defaultUsageDays = 30
+	maxUsageDays     = 365
+)
+
+// AdminUseCase lets admins manage the providers, models and tools offered to
+// all users, and the users themselves.
+type AdminUseCase struct {
+	dbService   *database.Service
+	auditLogger services.AuditLogger
+}
+
+// NewAdminUseCase creates a new AdminUseCase instance.
+func NewAdminUseCase(dbService *database.Service, auditLogger services.AuditLogger) *AdminUseCase {
+	return &AdminUseCase{dbService: dbService, auditLogger: auditLogger}
+}
+
+// ListProviders returns all providers with all their models, including inactive ones.
+func (uc *AdminUseCase) ListProviders(ctx context.Context) ([]*ProviderResponse, error) {
+	var providers []*chat.Provider
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		providers, err = provider.Provider().GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list providers: %w", err)
+		}
+		models, err := provider.Model().GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list models: %w", err)
+		}
+
+		byProvider := make(map[uuid.UUID]*chat.Provider, len(providers))
+		for _, p := range providers {
+			p.Models = []*chat.Model{}
+			byProvider[p.ID] = p
+		}
+		for _, m := range models {
+			if p, ok := byProvider[m.ProviderID]; ok {
+				p.Models = append(p.Models, m)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*ProviderResponse, len(providers))
+	for i, p := range providers {
+		response[i] = ToProviderResponse(p)
+	}
+	return response, nil
+}
+
+// CreateProvider adds a provider.
+func (uc *AdminUseCase) CreateProvider(ctx context.Context, adminID uuid.UUID, req *CreateProviderRequest) (*ProviderResponse, error) {
+	newProvider := &chat.Provider{
+		Name:        strings.TrimSpace(req.Name),
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+	if err := newProvider.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var created *chat.Provider
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		_, err := provider.Provider().GetByName(ctx, newProvider.Name)
+		if err == nil {
+			return errors.NewAppError(errors.CodeConflict, "A provider with this name already exists", nil)
+		}
+		if err != errors.ErrProviderNotFound {
+			return fmt.Errorf("failed to check provider name: %w", err)
+		}
+
+		created, err = provider.Provider().Create(ctx, newProvider)
+		if err != nil {
+			return fmt.Errorf("failed to create provider: %w", err)
+		}
+		created.Models = []*chat.Model{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminProviderCreated).
+		By(adminID).
+		On(audit.ResourceProvider, created.ID).
+		With("name", created.Name))
+	return ToProviderResponse(created), nil
+}
+
+// UpdateProvider renames, activates or deactivates a provider. Users cannot
+// chat with the models of inactive providers.
+func (uc *AdminUseCase) UpdateProvider(ctx context.Context, adminID, providerID uuid.UUID, req *UpdateProviderRequest) (*ProviderResponse, error) {
+	var updated *chat.Provider
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		p, err := loadProvider(ctx, provider, providerID)
+		if err != nil {
+			return err
+		}
+
+		if req.DisplayName != nil {
+			p.DisplayName = strings.TrimSpace(*req.DisplayName)
+		}
+		if req.IsActive != nil {
+			p.IsActive = *req.IsActive
+		}
+		if err := p.Validate(); err != nil {
+			return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+
+		updated, err = provider.Provider().Update(ctx, p)
+		if err != nil {
+			return fmt.Errorf("failed to update provider: %w", err)
+		}
+		updated.Models, err = providerModels(ctx, provider, providerID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminProviderUpdated).
+		By(adminID).
+		On(audit.ResourceProvider, providerID).
+		With("name", updated.Name).
+		With("is_active", updated.IsActive))
+	return ToProviderResponse(updated), nil
+}
+
+// CreateModel adds a model to a provider.
+func (uc *AdminUseCase) CreateModel(ctx context.Context, adminID, providerID uuid.UUID, req *CreateModelRequest) (*ModelResponse, error) {
+	model := &chat.Model{
+		ProviderID:            providerID,
+		Name:                  strings.TrimSpace(req.Name),
+		DisplayName:           strings.TrimSpace(req.DisplayName),
+		SupportsFunctions:     req.SupportsFunctions,
+		SupportsVision:        req.SupportsVision,
+		SupportsEmbeddings:    req.SupportsEmbeddings,
+		IsActive:              req.IsActive == nil || *req.IsActive,
+		InputPricePerMillion:  req.InputPricePerMillion,
+		OutputPricePerMillion: req.OutputPricePerMillion,
+	}
+	if err := model.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var created *chat.Model
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadProvider(ctx, provider, providerID); err != nil {
+			return err
+		}
+		_, err := provider.Model().GetModelByName(ctx, providerID, model.Name)
+		if err == nil {
+			return errors.NewAppError(errors.CodeConflict, "The provider already has a model with this name", nil)
+		}
+		if err != errors.ErrModelNotFound {
+			return fmt.Errorf("failed to check model name: %w", err)
+		}
+
+		created, err = provider.Model().CreateModel(ctx, model)
+		if err != nil {
+			return fmt.Errorf("failed to create model: %w", err)
+		}
+		if model.InputPricePerMillion == nil && model.OutputPricePerMillion == nil {
+			return nil
+		}
+
+		// Models are created without prices, so they are set with an update
+		created.InputPricePerMillion = model.InputPricePerMillion
+		created.OutputPricePerMillion = model.OutputPricePerMillion
+		created, err = provider.Model().Update(ctx, created)
+		if err != nil {
+			return fmt.Errorf("failed to set model pricing: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminModelCreated).
+		By(adminID).
+		On(audit.ResourceModel, created.ID).
+		With("provider_id", providerID.String()).
+		With("name", created.Name))
+	return ToModelResponse(created), nil
+}
+
+// UpdateModel changes a model's capabilities, availability or pricing.
+func (uc *AdminUseCase) UpdateModel(ctx context.Context, adminID, modelID uuid.UUID, req *UpdateModelRequest) (*ModelResponse, error) {
+	var updated *chat.Model
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		model, err := provider.Model().GetByID(ctx, modelID)
+		if err != nil {
+			if err == errors.ErrModelNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Model not found", err)
+			}
+			return fmt.Errorf("failed to get model: %w", err)
+		}
+
+		if req.DisplayName != nil {
+			model.DisplayName = strings.TrimSpace(*req.DisplayName)
+		}
+		if req.SupportsFunctions != nil {
+			model.SupportsFunctions = *req.SupportsFunctions
+		}
+		if req.SupportsVision != nil {
+			model.SupportsVision = *req.SupportsVision
+		}
+		if req.SupportsEmbeddings != nil {
+			model.SupportsEmbeddings = *req.SupportsEmbeddings
+		}
+		if req.IsActive != nil {
+			model.IsActive = *req.IsActive
+		}
+		if req.ClearPricing {
+			model.InputPricePerMillion = nil
+			model.OutputPricePerMillion = nil
+		}
+		if req.InputPricePerMillion != nil {
+			model.InputPricePerMillion = req.InputPricePerMillion
+		}
+		if req.OutputPricePerMillion != nil {
+			model.OutputPricePerMillion = req.OutputPricePerMillion
+		}
+		if err := model.Validate(); err != nil {
+			return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+
+		updated, err = provider.Model().Update(ctx, model)
+		if err != nil {
+			return fmt.Errorf("failed to update model: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminModelUpdated).
+		By(adminID).
+		On(audit.ResourceModel, modelID).
+		With("name", updated.Name).
+		With("is_active", updated.IsActive))
+	return ToModelResponse(updated), nil
+}
+
+// ListTools returns all tools, including inactive ones.
+func (uc *AdminUseCase) ListTools(ctx context.Context) ([]*ToolResponse, error) {
+	var tools []*chat.Tool
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		tools, err = provider.Tool().GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*ToolResponse, len(tools))
+	for i, t := range tools {
+		response[i] = ToToolResponse(t)
+	}
+	return response, nil
+}
+
+// UpdateTool enables or disables a tool. Tools are defined in code, so their
+// descriptions and schemas cannot be changed here.
+func (uc *AdminUseCase) UpdateTool(ctx context.Context, adminID, toolID uuid.UUID, req *UpdateToolRequest) (*ToolResponse, error) {
+	if req.IsActive == nil {
+		return nil, errors.NewAppError(errors.CodeValidation, "is_active is required", nil)
+	}
+
+	var updated *chat.Tool
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		tool, err := provider.Tool().GetByID(ctx, toolID)
+		if err != nil {
+			if err == errors.ErrToolNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Tool not found", err)
+			}
+			return fmt.Errorf("failed to get tool: %w", err)
+		}
+
+		tool.IsActive = *req.IsActive
+		updated, err = provider.Tool().Update(ctx, tool)
+		if err != nil {
+			return fmt.Errorf("failed to update tool: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminToolUpdated).
+		By(adminID).
+		On(audit.ResourceTool, toolID).
+		With("name", updated.Name).
+		With("is_active", updated.IsActive))
+	return ToToolResponse(updated), nil
+}
+
+// ListUsers returns a page of all users, including deactivated ones, newest first.
+func (uc *AdminUseCase) ListUsers(ctx context.Context, limit, offset int) (*UserListResponse, error) {
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	response := &UserListResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		users, err := provider.User().List(ctx, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+		response.Users = make([]appAuth.UserResponse, len(users))
+		for i, u := range users {
+			response.Users[i] = appAuth.ToUserResponse(u)
+		}
+
+		response.Total, err = provider.User().Count(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count users: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetUser returns a user, even if deactivated.
+func (uc *AdminUseCase) GetUser(ctx context.Context, userID uuid.UUID) (*appAuth.UserResponse, error) {
+	var user *auth.User
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		user, err = loadUser(ctx, provider, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := appAuth.ToUserResponse(user)
+	return &response, nil
+}
+
+// GetUserUsage sums up the conversations and assistant messages of a user over
+// the last days, per model.
+func (uc *AdminUseCase) GetUserUsage(ctx context.Context, userID uuid.UUID, days int) (*UserUsageResponse, error) {
+	if days <= 0 {
+		days = defaultUsageDays
+	}
+	if days > maxUsageDays {
+		days = maxUsageDays
+	}
+
+	response := &UserUsageResponse{
+		UserID: userID,
+		Since:  time.Now().AddDate(0, 0, -days),
+		Models: []*ModelUsageResponse{},
+	}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadUser(ctx, provider, userID); err != nil {
+			return err
+		}
+
+		var err error
+		response.Conversations, err = provider.Conversation().CountByUserSince(ctx, userID, response.Since)
+		if err != nil {
+			return fmt.Errorf("failed to count conversations: %w", err)
+		}
+		usage, err := provider.Message().GetUsageByUser(ctx, userID, response.Since)
+		if err != nil {
+			return fmt.Errorf("failed to get usage: %w", err)
+		}
+
+		for _, u := range usage {
+			modelUsage := &ModelUsageResponse{
+				ModelID:  u.ModelID,
+				Messages: u.MessageCount,
+				Tokens:   u.TokenCount,
+				Cost:     u.Cost,
+			}
+			if u.ModelID != nil {
+				model, err := provider.Model().GetByID(ctx, *u.ModelID)
+				if err != nil && err != errors.ErrModelNotFound {
+					return fmt.Errorf("failed to get model: %w", err)
+				}
+				if model != nil {
+					modelUsage.ModelName = model.Name
+					modelUsage.ModelDisplayName = model.DisplayName
+				}
+			}
+
+			response.Messages += u.MessageCount
+			response.Tokens += u.TokenCount
+			response.Cost += u.Cost
+			response.Models = append(response.Models, modelUsage)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// VerifyUserEmail marks a user's email as verified.
+func (uc *AdminUseCase) VerifyUserEmail(ctx context.Context, adminID, userID uuid.UUID) (*appAuth.UserResponse, error) {
+	var user *auth.User
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		user, err = provider.User().VerifyEmail(ctx, userID)
+		if err != nil {
+			if err == errors.ErrUserNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "User not found", err)
+			}
+			return fmt.Errorf("failed to verify email: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminEmailVerified).
+		By(adminID).
+		Concerning(userID))
+
+	response := appAuth.ToUserResponse(user)
+	return &response, nil
+}
+
+// DeactivateUser deactivates a user account. Their tokens stop working right
+// away; admins cannot deactivate themselves.
+func (uc *AdminUseCase) DeactivateUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if adminID == userID {
+		return errors.NewAppError(errors.CodeBadRequest, "You cannot deactivate your own account", nil)
+	}
+
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		user, err := loadUser(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		if !user.IsAccountActive() {
+			return errors.NewAppError(errors.CodeConflict, "User is already deactivated", nil)
+		}
+
+		if err := provider.User().Deactivate(ctx, userID); err != nil {
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminUserDeactivated).
+		By(adminID).
+		Concerning(userID))
+	return nil
+}
+
+// UpdateUserRole grants or revokes the admin role. Admins cannot change their
+// own role, so there is always at least one admin left.
+func (uc *AdminUseCase) UpdateUserRole(ctx context.Context, adminID, userID uuid.UUID, req *UpdateUserRoleRequest) (*appAuth.UserResponse, error) {
+	role := auth.Role(req.Role)
+	if !role.IsValid() {
+		return nil, errors.NewAppError(errors.CodeValidation, "role must be user or admin", nil)
+	}
+	if adminID == userID {
+		return nil, errors.NewAppError(errors.CodeBadRequest, "You cannot change your own role", nil)
+	}
+
+	var user *auth.User
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		user, err = provider.User().UpdateRole(ctx, userID, role)
+		if err != nil {
+			if err == errors.ErrUserNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "User not found", err)
+			}
+			return fmt.Errorf("failed to update user role: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionAdminRoleUpdated).
+		By(adminID).
+		Concerning(userID).
+		With("role", string(role)))
+
+	response := appAuth.ToUserResponse(user)
+	return &response, nil
+}
+
+// ListAuditEvents returns a page of the audit log, newest first.
+func (uc *AdminUseCase) ListAuditEvents(ctx context.Context, query *AuditEventQuery) (*appAuth.AuditEventListResponse, error) {
+	if query.Since != nil && query.Until != nil && !query.Until.After(*query.Since) {
+		return nil, errors.NewAppError(errors.CodeValidation, "until must be after since", nil)
+	}
+	filter := audit.EventFilter{
+		UserID:       query.UserID,
+		ActorID:      query.ActorID,
+		Action:       audit.Action(query.Action),
+		ResourceType: query.ResourceType,
+		Since:        query.Since,
+		Until:        query.Until,
+	}
+	return appAuth.ListAuditEvents(ctx, uc.dbService, filter, query.Limit, query.Offset)
+}
+
+// providerModels returns all models of a provider, including inactive ones.
+func providerModels(ctx context.Context, provider database.RepositoryProvider, providerID uuid.UUID) ([]*chat.Model, error) {
+	models, err := provider.Model().GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	providerModels := []*chat.Model{}
+	for _, m := range models {
+		if m.ProviderID == providerID {
+			providerModels = append(providerModels, m)
+		}
+	}
+	return providerModels, nil
+}
+
+func loadProvider(ctx context.Context, provider database.RepositoryProvider, providerID uuid.UUID) (*chat.Provider, error) {
+	p, err := provider.Provider().GetByID(ctx, providerID)
+	if err != nil {
+		if err == errors.ErrProviderNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Provider not found", err)
+		}
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+	return p, nil
+}
+
+func loadUser(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID) (*auth.User, error) {
+	user, err := provider.User().GetByIDIncludingInactive(ctx, userID)
+	if err != nil {
+		if err == errors.ErrUserNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "User not found", err)
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
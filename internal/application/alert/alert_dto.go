@@ -0,0 +1,168 @@
+package alert
+
+import (
+	"encoding/json"
+	"time"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/domain/strategy"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateWatchlistRequest represents the data needed to create a watchlist.
+type CreateWatchlistRequest struct {
+	Name        string   `json:"name" validate:"required,min=1,max=255"`
+	Description *string  `json:"description,omitempty"`
+	Symbols     []string `json:"symbols,omitempty"` // Initial symbols
+}
+
+// UpdateWatchlistRequest represents the fields of a watchlist that can be changed.
+type UpdateWatchlistRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// AddWatchlistItemRequest adds a symbol to a watchlist or updates its notes.
+type AddWatchlistItemRequest struct {
+	Symbol string  `json:"symbol" validate:"required"`
+	Notes  *string `json:"notes,omitempty"`
+}
+
+// AlertRuleRequest represents an alert rule. It is used both to create a rule
+// and to replace an existing one. Exactly one of Symbol and WatchlistID selects
+// what the rule watches.
+type AlertRuleRequest struct {
+	Name               string          `json:"name" validate:"required,min=1,max=255"`
+	Symbol             *string         `json:"symbol,omitempty"`
+	WatchlistID        *uuid.UUID      `json:"watchlist_id,omitempty"`
+	Timeframe          *string         `json:"timeframe,omitempty"` // Candles the rule is evaluated against, defaults to 1m
+	Type               string          `json:"type" validate:"required,oneof=price_cross percent_move indicator"`
+	Direction          *string         `json:"direction,omitempty"`                                // above or below for price_cross; up, down or any for percent_move
+	Level              *float64        `json:"level,omitempty"`                                    // Price level for price_cross
+	Percent            *float64        `json:"percent,omitempty"`                                  // Minimum move in percent for percent_move
+	WindowSeconds      *int            `json:"window_seconds,omitempty"`                           // Period the move is measured over for percent_move
+	IndicatorCondition json.RawMessage `json:"indicator_condition,omitempty" swaggertype:"object"` // Strategy DSL condition for indicator, e.g. {"lt": [{"indicator": "rsi", "period": 14}, 30]}
+	CooldownSeconds    *int            `json:"cooldown_seconds,omitempty"`                         // Defaults to one hour
+	NotifyEmail        *bool           `json:"notify_email,omitempty"`                             // Defaults to false
+	NotifyInApp        *bool           `json:"notify_in_app,omitempty"`                            // Defaults to true
+	Enabled            *bool           `json:"enabled,omitempty"`                                  // Defaults to true
+}
+
+// --- Response DTOs ---
+
+// WatchlistResponse represents a watchlist with its symbols.
+type WatchlistResponse struct {
+	ID          uuid.UUID                `json:"id"`
+	Name        string                   `json:"name"`
+	Description *string                  `json:"description,omitempty"`
+	Items       []*WatchlistItemResponse `json:"items"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// WatchlistItemResponse represents a symbol on a watchlist.
+type WatchlistItemResponse struct {
+	Symbol    string    `json:"symbol"`
+	Notes     *string   `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AlertRuleResponse represents an alert rule.
+type AlertRuleResponse struct {
+	ID                 uuid.UUID           `json:"id"`
+	Name               string              `json:"name"`
+	Symbol             *string             `json:"symbol,omitempty"`
+	WatchlistID        *uuid.UUID          `json:"watchlist_id,omitempty"`
+	Timeframe          string              `json:"timeframe"`
+	Type               alert.RuleType      `json:"type"`
+	Direction          alert.Direction     `json:"direction"`
+	Level              *float64            `json:"level,omitempty"`
+	Percent            *float64            `json:"percent,omitempty"`
+	WindowSeconds      *int                `json:"window_seconds,omitempty"`
+	IndicatorCondition *strategy.Condition `json:"indicator_condition,omitempty" swaggertype:"object"`
+	CooldownSeconds    int                 `json:"cooldown_seconds"`
+	NotifyEmail        bool                `json:"notify_email"`
+	NotifyInApp        bool                `json:"notify_in_app"`
+	Enabled            bool                `json:"enabled"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+// AlertEventResponse represents a triggered alert in the alert history.
+type AlertEventResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	RuleID      uuid.UUID         `json:"rule_id"`
+	Symbol      string            `json:"symbol"`
+	Timeframe   string            `json:"timeframe"`
+	CandleTime  time.Time         `json:"candle_time"`
+	Price       float64           `json:"price"`
+	Message     string            `json:"message"`
+	EmailStatus alert.EmailStatus `json:"email_status"`
+	TriggeredAt time.Time         `json:"triggered_at"`
+}
+
+// ToWatchlistResponse converts a watchlist and its items to a WatchlistResponse.
+func ToWatchlistResponse(w *alert.Watchlist, items []*alert.WatchlistItem) *WatchlistResponse {
+	response := &WatchlistResponse{
+		ID:          w.ID,
+		Name:        w.Name,
+		Description: w.Description,
+		Items:       make([]*WatchlistItemResponse, len(items)),
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+	for i, item := range items {
+		response.Items[i] = ToWatchlistItemResponse(item)
+	}
+	return response
+}
+
+// ToWatchlistItemResponse converts a watchlist item to a WatchlistItemResponse.
+func ToWatchlistItemResponse(item *alert.WatchlistItem) *WatchlistItemResponse {
+	return &WatchlistItemResponse{
+		Symbol:    item.Symbol,
+		Notes:     item.Notes,
+		CreatedAt: item.CreatedAt,
+	}
+}
+
+// ToAlertRuleResponse converts an alert rule to an AlertRuleResponse.
+func ToAlertRuleResponse(r *alert.Rule) *AlertRuleResponse {
+	return &AlertRuleResponse{
+		ID:                 r.ID,
+		Name:               r.Name,
+		Symbol:             r.Symbol,
+		WatchlistID:        r.WatchlistID,
+		Timeframe:          string(r.Timeframe),
+		Type:               r.Type,
+		Direction:          r.Direction,
+		Level:              r.Level,
+		Percent:            r.Percent,
+		WindowSeconds:      r.WindowSeconds,
+		IndicatorCondition: r.Condition,
+		CooldownSeconds:    r.CooldownSeconds,
+		NotifyEmail:        r.NotifyEmail,
+		NotifyInApp:        r.NotifyInApp,
+		Enabled:            r.Enabled,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+}
+
+// ToAlertEventResponse converts an alert event to an AlertEventResponse.
+func ToAlertEventResponse(e *alert.Event) *AlertEventResponse {
+	return &AlertEventResponse{
+		ID:          e.ID,
+		RuleID:      e.RuleID,
+		Symbol:      e.Symbol,
+		Timeframe:   string(e.Timeframe),
+		CandleTime:  e.CandleTime,
+		Price:       e.Price,
+		Message:     e.Message,
+		EmailStatus: e.EmailStatus,
+		TriggeredAt: e.TriggeredAt,
+	}
+}
@@ -0,0 +1,536 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/strategy"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultRuleTimeframe is the candle timeframe rules are evaluated against unless configured.
+	defaultRuleTimeframe = market.Timeframe1m
+	// defaultCooldownSeconds is the minimum time between two triggers of a rule for the same symbol.
+	defaultCooldownSeconds = 3600
+	// maxWatchlistSymbols bounds a watchlist so the worker can evaluate it every tick.
+	maxWatchlistSymbols = 200
+)
+
+// AlertUseCase handles watchlists, alert rules and the alert history.
+// Rules are evaluated in the background by the Worker.
+type AlertUseCase struct {
+	dbService *database.Service
+}
+
+// NewAlertUseCase creates a new AlertUseCase instance.
+func NewAlertUseCase(dbService *database.Service) *AlertUseCase {
+	return &AlertUseCase{dbService: dbService}
+}
+
+// CreateWatchlist creates a watchlist with optional initial symbols.
+func (uc *AlertUseCase) CreateWatchlist(ctx context.Context, userID uuid.UUID, req *CreateWatchlistRequest) (*WatchlistResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 255 {
+		return nil, errors.NewAppError(errors.CodeValidation, "name must be between 1 and 255 characters", nil)
+	}
+	if len(req.Symbols) > maxWatchlistSymbols {
+		return nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("a watchlist can hold at most %d symbols", maxWatchlistSymbols), nil)
+	}
+	symbols := make([]string, 0, len(req.Symbols))
+	for _, s := range req.Symbols {
+		symbol, err := normalizeSymbol(s)
+		if err != nil {
+			return nil, err
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	var response *WatchlistResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if err := checkWatchlistName(ctx, provider, userID, uuid.Nil, name); err != nil {
+			return err
+		}
+		created, err := provider.Watchlist().Create(ctx, &alert.Watchlist{
+			UserID:      userID,
+			Name:        name,
+			Description: optionalText(req.Description),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create watchlist: %w", err)
+		}
+		for _, symbol := range symbols {
+			if _, err := provider.WatchlistItem().Upsert(ctx, &alert.WatchlistItem{WatchlistID: created.ID, Symbol: symbol}); err != nil {
+				return fmt.Errorf("failed to add watchlist symbol: %w", err)
+			}
+		}
+		response, err = watchlistResponse(ctx, provider, created)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListWatchlists returns the user's watchlists with their symbols.
+func (uc *AlertUseCase) ListWatchlists(ctx context.Context, userID uuid.UUID) ([]*WatchlistResponse, error) {
+	var response []*WatchlistResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		watchlists, err := provider.Watchlist().GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list watchlists: %w", err)
+		}
+		response = make([]*WatchlistResponse, len(watchlists))
+		for i, w := range watchlists {
+			if response[i], err = watchlistResponse(ctx, provider, w); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetWatchlist returns a watchlist with its symbols.
+func (uc *AlertUseCase) GetWatchlist(ctx context.Context, userID, watchlistID uuid.UUID) (*WatchlistResponse, error) {
+	var response *WatchlistResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		w, err := loadWatchlist(ctx, provider, userID, watchlistID)
+		if err != nil {
+			return err
+		}
+		response, err = watchlistResponse(ctx, provider, w)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateWatchlist renames a watchlist or changes its description.
+func (uc *AlertUseCase) UpdateWatchlist(ctx context.Context, userID, watchlistID uuid.UUID, req *UpdateWatchlistRequest) (*WatchlistResponse, error) {
+	var response *WatchlistResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		w, err := loadWatchlist(ctx, provider, userID, watchlistID)
+		if err != nil {
+			return err
+		}
+		if req.Name != nil {
+			name := strings.TrimSpace(*req.Name)
+			if name == "" || len(name) > 255 {
+				return errors.NewAppError(errors.CodeValidation, "name must be between 1 and 255 characters", nil)
+			}
+			if err := checkWatchlistName(ctx, provider, userID, w.ID, name); err != nil {
+				return err
+			}
+			w.Name = name
+		}
+		if req.Description != nil {
+			w.Description = optionalText(req.Description)
+		}
+
+		updated, err := provider.Watchlist().Update(ctx, w)
+		if err != nil {
+			return fmt.Errorf("failed to update watchlist: %w", err)
+		}
+		response, err = watchlistResponse(ctx, provider, updated)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteWatchlist deletes a watchlist together with the alert rules watching it.
+func (uc *AlertUseCase) DeleteWatchlist(ctx context.Context, userID, watchlistID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadWatchlist(ctx, provider, userID, watchlistID); err != nil {
+			return err
+		}
+		if err := provider.Watchlist().Delete(ctx, watchlistID); err != nil {
+			return fmt.Errorf("failed to delete watchlist: %w", err)
+		}
+		return nil
+	})
+}
+
+// AddWatchlistItem adds a symbol to a watchlist, or updates its notes if it is already on it.
+func (uc *AlertUseCase) AddWatchlistItem(ctx context.Context, userID, watchlistID uuid.UUID, req *AddWatchlistItemRequest) (*WatchlistResponse, error) {
+	symbol, err := normalizeSymbol(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *WatchlistResponse
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		w, err := loadWatchlist(ctx, provider, userID, watchlistID)
+		if err != nil {
+			return err
+		}
+		items, err := provider.WatchlistItem().GetByWatchlistID(ctx, watchlistID)
+		if err != nil {
+			return fmt.Errorf("failed to get watchlist items: %w", err)
+		}
+		if len(items) >= maxWatchlistSymbols && !hasSymbol(items, symbol) {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("a watchlist can hold at most %d symbols", maxWatchlistSymbols), nil)
+		}
+
+		if _, err := provider.WatchlistItem().Upsert(ctx, &alert.WatchlistItem{
+			WatchlistID: watchlistID,
+			Symbol:      symbol,
+			Notes:       optionalText(req.Notes),
+		}); err != nil {
+			return fmt.Errorf("failed to add watchlist symbol: %w", err)
+		}
+		response, err = watchlistResponse(ctx, provider, w)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RemoveWatchlistItem removes a symbol from a watchlist.
+func (uc *AlertUseCase) RemoveWatchlistItem(ctx context.Context, userID, watchlistID uuid.UUID, symbol string) error {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadWatchlist(ctx, provider, userID, watchlistID); err != nil {
+			return err
+		}
+		items, err := provider.WatchlistItem().GetByWatchlistID(ctx, watchlistID)
+		if err != nil {
+			return fmt.Errorf("failed to get watchlist items: %w", err)
+		}
+		if !hasSymbol(items, symbol) {
+			return errors.NewAppError(errors.CodeNotFound, "Symbol is not on the watchlist", nil)
+		}
+		if err := provider.WatchlistItem().Delete(ctx, watchlistID, symbol); err != nil {
+			return fmt.Errorf("failed to remove watchlist symbol: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateRule creates an alert rule. The rule starts with the next closed candle,
+// so conditions that already hold do not alert on creation.
+func (uc *AlertUseCase) CreateRule(ctx context.Context, userID uuid.UUID, req *AlertRuleRequest) (*AlertRuleResponse, error) {
+	rule, err := ruleFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	rule.UserID = userID
+
+	var created *alert.Rule
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if rule.WatchlistID != nil {
+			if _, err := loadWatchlist(ctx, provider, userID, *rule.WatchlistID); err != nil {
+				return err
+			}
+		}
+		created, err = provider.AlertRule().Create(ctx, rule)
+		if err != nil {
+			return fmt.Errorf("failed to create alert rule: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToAlertRuleResponse(created), nil
+}
+
+// ListRules returns the user's alert rules, newest first.
+func (uc *AlertUseCase) ListRules(ctx context.Context, userID uuid.UUID) ([]*AlertRuleResponse, error) {
+	var rules []*alert.Rule
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		rules, err = provider.AlertRule().GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list alert rules: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*AlertRuleResponse, len(rules))
+	for i, r := range rules {
+		response[i] = ToAlertRuleResponse(r)
+	}
+	return response, nil
+}
+
+// GetRule returns an alert rule.
+func (uc *AlertUseCase) GetRule(ctx context.Context, userID, ruleID uuid.UUID) (*AlertRuleResponse, error) {
+	var rule *alert.Rule
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		rule, err = loadRule(ctx, provider, userID, ruleID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToAlertRuleResponse(rule), nil
+}
+
+// UpdateRule replaces an alert rule. Its evaluation progress is reset, so like a
+// new rule it starts with the next closed candle.
+func (uc *AlertUseCase) UpdateRule(ctx context.Context, userID, ruleID uuid.UUID, req *AlertRuleRequest) (*AlertRuleResponse, error) {
+	rule, err := ruleFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *alert.Rule
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		existing, err := loadRule(ctx, provider, userID, ruleID)
+		if err != nil {
+			return err
+		}
+		if rule.WatchlistID != nil {
+			if _, err := loadWatchlist(ctx, provider, userID, *rule.WatchlistID); err != nil {
+				return err
+			}
+		}
+		rule.ID, rule.UserID = existing.ID, existing.UserID
+
+		updated, err = provider.AlertRule().Update(ctx, rule)
+		if err != nil {
+			return fmt.Errorf("failed to update alert rule: %w", err)
+		}
+		if err := provider.AlertRuleState().DeleteByRuleID(ctx, ruleID); err != nil {
+			return fmt.Errorf("failed to reset alert rule: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToAlertRuleResponse(updated), nil
+}
+
+// DeleteRule deletes an alert rule and its history.
+func (uc *AlertUseCase) DeleteRule(ctx context.Context, userID, ruleID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadRule(ctx, provider, userID, ruleID); err != nil {
+			return err
+		}
+		if err := provider.AlertRule().Delete(ctx, ruleID); err != nil {
+			return fmt.Errorf("failed to delete alert rule: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListEvents returns the user's alert history, newest first.
+func (uc *AlertUseCase) ListEvents(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*AlertEventResponse, error) {
+	var events []*alert.Event
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		events, err = provider.AlertEvent().GetByUserID(ctx, userID, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list alert events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAlertEventResponses(events), nil
+}
+
+// ListRuleEvents returns the history of a single alert rule, newest first.
+func (uc *AlertUseCase) ListRuleEvents(ctx context.Context, userID, ruleID uuid.UUID, limit, offset int) ([]*AlertEventResponse, error) {
+	var events []*alert.Event
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadRule(ctx, provider, userID, ruleID); err != nil {
+			return err
+		}
+		var err error
+		events, err = provider.AlertEvent().GetByRuleID(ctx, ruleID, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list alert events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAlertEventResponses(events), nil
+}
+
+// ruleFromRequest builds and validates a rule, applying the defaults for omitted fields.
+func ruleFromRequest(req *AlertRuleRequest) (*alert.Rule, error) {
+	rule := &alert.Rule{
+		Name:            strings.TrimSpace(req.Name),
+		WatchlistID:     req.WatchlistID,
+		Timeframe:       defaultRuleTimeframe,
+		Type:            alert.RuleType(req.Type),
+		Direction:       alert.DirectionAny,
+		Level:           req.Level,
+		Percent:         req.Percent,
+		WindowSeconds:   req.WindowSeconds,
+		CooldownSeconds: defaultCooldownSeconds,
+		NotifyInApp:     true,
+		Enabled:         true,
+	}
+	if rule.Name == "" || len(rule.Name) > 255 {
+		return nil, errors.NewAppError(errors.CodeValidation, "name must be between 1 and 255 characters", nil)
+	}
+	if req.Symbol != nil {
+		symbol, err := normalizeSymbol(*req.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		rule.Symbol = &symbol
+	}
+	if req.Timeframe != nil {
+		tf, err := market.ParseTimeframe(*req.Timeframe)
+		if err != nil {
+			return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		rule.Timeframe = tf
+	}
+	if req.Direction != nil {
+		rule.Direction = alert.Direction(strings.ToLower(strings.TrimSpace(*req.Direction)))
+	}
+	if len(req.IndicatorCondition) > 0 && string(req.IndicatorCondition) != "null" {
+		condition, err := strategy.ParseCondition(req.IndicatorCondition)
+		if err != nil {
+			return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		rule.Condition = condition
+	}
+	if req.CooldownSeconds != nil {
+		rule.CooldownSeconds = *req.CooldownSeconds
+	}
+	if req.NotifyEmail != nil {
+		rule.NotifyEmail = *req.NotifyEmail
+	}
+	if req.NotifyInApp != nil {
+		rule.NotifyInApp = *req.NotifyInApp
+	}
+	if req.Enabled != nil {
+		rule.Enabled = *req.Enabled
+	}
+
+	// Fields that do not apply to the rule type are not stored.
+	switch rule.Type {
+	case alert.RuleTypePriceCross:
+		rule.Percent, rule.WindowSeconds, rule.Condition = nil, nil, nil
+	case alert.RuleTypePercentMove:
+		rule.Level, rule.Condition = nil, nil
+	case alert.RuleTypeIndicator:
+		rule.Level, rule.Percent, rule.WindowSeconds = nil, nil, nil
+		rule.Direction = alert.DirectionAny
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	return rule, nil
+}
+
+// loadWatchlist checks that the watchlist exists and belongs to the user.
+func loadWatchlist(ctx context.Context, provider database.RepositoryProvider, userID, watchlistID uuid.UUID) (*alert.Watchlist, error) {
+	w, err := provider.Watchlist().GetByID(ctx, watchlistID)
+	if err != nil {
+		if err == errors.ErrWatchlistNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Watchlist not found", err)
+		}
+		return nil, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	if w.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return w, nil
+}
+
+// loadRule checks that the alert rule exists and belongs to the user.
+func loadRule(ctx context.Context, provider database.RepositoryProvider, userID, ruleID uuid.UUID) (*alert.Rule, error) {
+	rule, err := provider.AlertRule().GetByID(ctx, ruleID)
+	if err != nil {
+		if err == errors.ErrAlertRuleNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Alert rule not found", err)
+		}
+		return nil, fmt.Errorf("failed to get alert rule: %w", err)
+	}
+	if rule.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return rule, nil
+}
+
+// checkWatchlistName rejects a name used by another of the user's watchlists.
+func checkWatchlistName(ctx context.Context, provider database.RepositoryProvider, userID, watchlistID uuid.UUID, name string) error {
+	watchlists, err := provider.Watchlist().GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list watchlists: %w", err)
+	}
+	for _, w := range watchlists {
+		if w.ID != watchlistID && strings.EqualFold(w.Name, name) {
+			return errors.NewAppError(errors.CodeConflict, fmt.Sprintf("A watchlist named %q already exists", name), nil)
+		}
+	}
+	return nil
+}
+
+func watchlistResponse(ctx context.Context, provider database.RepositoryProvider, w *alert.Watchlist) (*WatchlistResponse, error) {
+	items, err := provider.WatchlistItem().GetByWatchlistID(ctx, w.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist items: %w", err)
+	}
+	return ToWatchlistResponse(w, items), nil
+}
+
+func toAlertEventResponses(events []*alert.Event) []*AlertEventResponse {
+	response := make([]*AlertEventResponse, len(events))
+	for i, e := range events {
+		response[i] = ToAlertEventResponse(e)
+	}
+	return response
+}
+
+func hasSymbol(items []*alert.WatchlistItem, symbol string) bool {
+	for _, item := range items {
+		if item.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeSymbol(s string) (string, error) {
+	symbol := strings.ToUpper(strings.TrimSpace(s))
+	if symbol == "" || len(symbol) > alert.MaxSymbolLength {
+		return "", errors.NewAppError(errors.CodeValidation, fmt.Sprintf("symbol must be between 1 and %d characters", alert.MaxSymbolLength), nil)
+	}
+	return symbol, nil
+}
+
+// optionalText trims the value and treats an empty string as unset.
+func optionalText(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,236 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/notification"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+)
+
+// maxScanBars bounds how many candles that closed since the last run are
+// evaluated per rule and symbol, e.g. after the worker was down for a while.
+const maxScanBars = 500
+
+// Worker evaluates enabled alert rules against incoming candles on a fixed
+// interval. Every rule and symbol is evaluated in its own transaction; a
+// trigger is recorded once per candle, delivered to the in-app feed in the same
+// transaction and emailed after it commits.
+type Worker struct {
+	dbService    *database.Service
+	emailService services.EmailService
+	interval     time.Duration
+}
+
+// NewWorker creates a new alert Worker.
+func NewWorker(dbService *database.Service, emailService services.EmailService, interval time.Duration) *Worker {
+	return &Worker{
+		dbService:    dbService,
+		emailService: emailService,
+		interval:     interval,
+	}
+}
+
+// Run evaluates the rules immediately and then on every interval until the context is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.RunOnce(ctx, time.Now().UTC()); err != nil && ctx.Err() == nil {
+			log.Printf("Alert worker run failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce evaluates every enabled rule against the candles closed by now.
+// Failures of single rules are logged and do not stop the run.
+func (w *Worker) RunOnce(ctx context.Context, now time.Time) error {
+	var rules []*alert.Rule
+	err := w.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		rules, err = provider.AlertRule().GetEnabled(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get enabled alert rules: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		symbols, err := w.symbols(ctx, rule)
+		if err != nil {
+			log.Printf("Alert rule %s: %v", rule.ID, err)
+			continue
+		}
+		for _, symbol := range symbols {
+			if err := w.evaluate(ctx, rule, symbol, now); err != nil {
+				log.Printf("Alert rule %s on %s: %v", rule.ID, symbol, err)
+			}
+		}
+	}
+	return nil
+}
+
+// symbols returns the symbols a rule watches.
+func (w *Worker) symbols(ctx context.Context, rule *alert.Rule) ([]string, error) {
+	if rule.Symbol != nil {
+		return []string{*rule.Symbol}, nil
+	}
+
+	var symbols []string
+	err := w.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		items, err := provider.WatchlistItem().GetByWatchlistID(ctx, *rule.WatchlistID)
+		if err != nil {
+			return fmt.Errorf("failed to get watchlist items: %w", err)
+		}
+		for _, item := range items {
+			symbols = append(symbols, item.Symbol)
+		}
+		return nil
+	})
+	return symbols, err
+}
+
+// evaluate scans the candles of one symbol that closed since the last run,
+// records the triggers and sends the alert emails.
+func (w *Worker) evaluate(ctx context.Context, rule *alert.Rule, symbol string, now time.Time) error {
+	var created []*alert.Event
+	err := w.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		state, err := provider.AlertRuleState().Get(ctx, rule.ID, symbol)
+		if err != nil && err != errors.ErrAlertRuleStateNotFound {
+			return fmt.Errorf("failed to get alert rule state: %w", err)
+		}
+
+		bars := rule.Lookback() + 1
+		if state != nil {
+			pending := int(now.Sub(state.LastCandleTime) / rule.Timeframe.Duration())
+			bars += min(max(pending, 0), maxScanBars)
+		}
+		candles, err := provider.Candle().GetLatest(ctx, symbol, rule.Timeframe, bars)
+		if err != nil {
+			return fmt.Errorf("failed to load candles: %w", err)
+		}
+
+		next, triggers := alert.Scan(rule, symbol, state, candles, now)
+		if next == nil {
+			return nil
+		}
+		for _, t := range triggers {
+			event, err := recordTrigger(ctx, provider, rule, t)
+			if err != nil {
+				return err
+			}
+			if event != nil {
+				created = append(created, event)
+			}
+		}
+		if _, err := provider.AlertRuleState().Upsert(ctx, next); err != nil {
+			return fmt.Errorf("failed to save alert rule state: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if rule.NotifyEmail {
+		for _, event := range created {
+			w.sendEmail(ctx, rule, event)
+		}
+	}
+	return nil
+}
+
+// recordTrigger stores the alert event and its in-app notification. It returns
+// nil if the rule already fired on the candle.
+func recordTrigger(ctx context.Context, provider database.RepositoryProvider, rule *alert.Rule, t alert.Trigger) (*alert.Event, error) {
+	emailStatus := alert.EmailStatusSkipped
+	if rule.NotifyEmail {
+		emailStatus = alert.EmailStatusPending
+	}
+	event, err := provider.AlertEvent().Create(ctx, &alert.Event{
+		RuleID:      rule.ID,
+		UserID:      rule.UserID,
+		Symbol:      t.Candle.Symbol,
+		Timeframe:   rule.Timeframe,
+		CandleTime:  t.Candle.OpenTime,
+		Price:       t.Candle.Close,
+		Message:     t.Message,
+		EmailStatus: emailStatus,
+	})
+	if err != nil {
+		if err == errors.ErrDuplicateAlertEvent {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to record alert event: %w", err)
+	}
+
+	if rule.NotifyInApp {
+		_, err := provider.Notification().Create(ctx, &notification.Notification{
+			UserID: rule.UserID,
+			Type:   notification.TypeAlert,
+			Title:  fmt.Sprintf("%s: %s", rule.Name, event.Symbol),
+			Body:   event.Message,
+			Data: shared.JSONB{
+				"rule_id":  rule.ID.String(),
+				"event_id": event.ID.String(),
+				"symbol":   event.Symbol,
+				"price":    event.Price,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create alert notification: %w", err)
+		}
+	}
+	return event, nil
+}
+
+// sendEmail delivers the alert email and records the outcome on the event.
+func (w *Worker) sendEmail(ctx context.Context, rule *alert.Rule, event *alert.Event) {
+	status := alert.EmailStatusSent
+	if err := w.deliver(ctx, rule, event); err != nil {
+		log.Printf("Failed to send alert email for event %s: %v", event.ID, err)
+		status = alert.EmailStatusFailed
+	}
+
+	err := w.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		return provider.AlertEvent().UpdateEmailStatus(ctx, event.ID, status)
+	})
+	if err != nil {
+		log.Printf("Failed to update email status of alert event %s: %v", event.ID, err)
+	}
+}
+
+func (w *Worker) deliver(ctx context.Context, rule *alert.Rule, event *alert.Event) error {
+	var user *auth.User
+	err := w.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		user, err = provider.User().GetByID(ctx, rule.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return w.emailService.SendAlertEmail(ctx, user, rule, event)
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import "github.com/google/uuid"
+
+// --- Request DTOs ---
+
+// RunCodeRequest is a Python script to run on the user's data. ArtifactIDs are
+// CSV artifacts placed read-only in the data directory of the workspace.
+type RunCodeRequest struct {
+	Code           string      `json:"code"`
+	ArtifactIDs    []uuid.UUID `json:"artifact_ids,omitempty"`
+	TimeoutSeconds int         `json:"timeout_seconds,omitempty"` // Capped by the sandbox limit
+}
+
+// --- Response DTOs ---
+
+// InputFileResponse maps an input artifact to its path in the workspace.
+type InputFileResponse struct {
+	ArtifactID uuid.UUID `json:"artifact_id"`
+	Path       string    `json:"path"`
+}
+
+// OutputFileResponse describes a file the script wrote to the output directory.
+type OutputFileResponse struct {
+	Path     string `json:"path"`
+	Size     int    `json:"size"`
+	Attached bool   `json:"attached"`         // Whether it was attached to the answer as an artifact
+	Reason   string `json:"reason,omitempty"` // Why it was not attached
+}
+
+// RunCodeResponse is the outcome of a script run.
+type RunCodeResponse struct {
+	ExitCode        int                  `json:"exit_code"`
+	TimedOut        bool                 `json:"timed_out"`
+	DurationMs      int64                `json:"duration_ms"`
+	Stdout          string               `json:"stdout"`
+	Stderr          string               `json:"stderr"`
+	StdoutTruncated bool                 `json:"stdout_truncated,omitempty"`
+	StderrTruncated bool                 `json:"stderr_truncated,omitempty"`
+	Inputs          []InputFileResponse  `json:"inputs"`
+	Files           []OutputFileResponse `json:"files"`
+}
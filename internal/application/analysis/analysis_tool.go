@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+)
+
+// RunCodeTool exposes AnalysisUseCase.RunCode to the LLM as the run_code tool,
+// so the assistant can compute on the user's data instead of estimating.
+type RunCodeTool struct {
+	useCase *AnalysisUseCase
+}
+
+// NewRunCodeTool creates the run_code tool handler.
+func NewRunCodeTool(useCase *AnalysisUseCase) services.ToolHandler {
+	return &RunCodeTool{useCase: useCase}
+}
+
+// Definition describes the run_code tool.
+func (t *RunCodeTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "run_code",
+		Description: "Run a Python script in an isolated sandbox to analyze data, e.g. compute statistics or plot results. The sandbox has no network access and limited time and memory; only the standard library and preinstalled packages are available. CSV artifacts passed in artifact_ids are readable under data/ (see inputs in the result for their paths). Print results to stdout; files written to output/ are attached to the answer (PNG, JPEG, GIF and WebP images, SVG, HTML, CSV, JSON, Markdown and text). The script, its output and the files are recorded as artifacts.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"code": map[string]interface{}{"type": "string", "description": "Python 3 source to run"},
+				"artifact_ids": map[string]interface{}{
+					"type":        "array",
+					"maxItems":    maxInputArtifacts,
+					"items":       map[string]interface{}{"type": "string", "format": "uuid"},
+					"description": "IDs of the user's CSV artifacts to make available under data/",
+				},
+				"timeout_seconds": map[string]interface{}{"type": "integer", "minimum": 1, "description": "Time limit; capped by the sandbox limit"},
+			},
+			"required": []string{"code"},
+		},
+	}
+}
+
+// RequiredScope reports that scripts need chat:write, as they read and create chat artifacts.
+func (t *RunCodeTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourceChat, true
+}
+
+// Execute runs the script and attaches its report and output files.
+func (t *RunCodeTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var req RunCodeRequest
+	if err := json.Unmarshal(invocation.Arguments, &req); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	result, artifacts, err := t.useCase.RunCode(ctx, invocation.UserID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	output := shared.JSONB{
+		"exit_code":   result.ExitCode,
+		"timed_out":   result.TimedOut,
+		"duration_ms": result.DurationMs,
+		"stdout":      result.Stdout,
+		"stderr":      result.Stderr,
+		"inputs":      result.Inputs,
+		"files":       result.Files,
+	}
+	if result.StdoutTruncated {
+		output["stdout_truncated"] = true
+	}
+	if result.StderrTruncated {
+		output["stderr_truncated"] = true
+	}
+	return &services.ToolResult{
+		Output:    output,
+		Artifacts: artifacts,
+	}, nil
+}
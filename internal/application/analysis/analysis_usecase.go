@@ -0,0 +1,299 @@
+package analysis
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxCodeLength caps the size of a script.
+	maxCodeLength = 100 * 1024
+	// maxInputArtifacts caps the CSV artifacts passed to a single run.
+	maxInputArtifacts = 10
+	// maxInputNameLength caps the file names derived from artifact titles.
+	maxInputNameLength = 100
+	// maxReportOutput caps stdout and stderr in the run report artifact.
+	maxReportOutput = 16 * 1024
+)
+
+// imageTypes maps the image extensions attached as image artifacts to their MIME types.
+var imageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+}
+
+// AnalysisUseCase runs model-written scripts on the user's data in a sandbox.
+type AnalysisUseCase struct {
+	dbService *database.Service
+	runner    services.CodeRunner
+}
+
+// NewAnalysisUseCase creates a new AnalysisUseCase.
+func NewAnalysisUseCase(dbService *database.Service, runner services.CodeRunner) *AnalysisUseCase {
+	return &AnalysisUseCase{
+		dbService: dbService,
+		runner:    runner,
+	}
+}
+
+// RunCode runs the script with the requested CSV artifacts as inputs. It returns
+// the outcome along with unsaved artifacts: a report with the script and its
+// output, followed by the files the script wrote that can be attached.
+// A script that fails is not an error; its exit code and stderr are reported.
+func (uc *AnalysisUseCase) RunCode(ctx context.Context, userID uuid.UUID, req *RunCodeRequest) (*RunCodeResponse, []*chat.Artifact, error) {
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, nil, errors.NewAppError(errors.CodeValidation, "code is required", nil)
+	}
+	if len(req.Code) > maxCodeLength {
+		return nil, nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("code must be at most %d bytes", maxCodeLength), nil)
+	}
+	if len(req.ArtifactIDs) > maxInputArtifacts {
+		return nil, nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("at most %d artifacts can be passed to a run", maxInputArtifacts), nil)
+	}
+	if req.TimeoutSeconds < 0 {
+		return nil, nil, errors.NewAppError(errors.CodeValidation, "timeout_seconds must be positive", nil)
+	}
+
+	var artifacts []*chat.Artifact
+	if len(req.ArtifactIDs) > 0 {
+		err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+			for _, id := range req.ArtifactIDs {
+				artifact, err := loadCSVArtifact(ctx, provider, userID, id)
+				if err != nil {
+					return err
+				}
+				artifacts = append(artifacts, artifact)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	run := &services.CodeRun{
+		Code:    req.Code,
+		Timeout: time.Duration(req.TimeoutSeconds) * time.Second,
+	}
+	resp := &RunCodeResponse{Inputs: []InputFileResponse{}, Files: []OutputFileResponse{}}
+	used := make(map[string]bool)
+	for _, artifact := range artifacts {
+		name := inputFileName(artifact.Title, used)
+		run.Inputs = append(run.Inputs, services.CodeFile{Name: name, Content: []byte(artifact.Content)})
+		resp.Inputs = append(resp.Inputs, InputFileResponse{
+			ArtifactID: artifact.ID,
+			Path:       path.Join(services.CodeRunDataDir, name),
+		})
+	}
+
+	result, err := uc.runner.Run(ctx, run)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to run code: %w", err)
+	}
+
+	resp.ExitCode = result.ExitCode
+	resp.TimedOut = result.TimedOut
+	resp.DurationMs = result.Duration.Milliseconds()
+	resp.Stdout = result.Stdout
+	resp.Stderr = result.Stderr
+	resp.StdoutTruncated = result.StdoutTruncated
+	resp.StderrTruncated = result.StderrTruncated
+
+	attachments := []*chat.Artifact{runReport(uc.runner.Language(), req.Code, resp)}
+	for _, file := range result.Outputs {
+		filePath := path.Join(services.CodeRunOutputDir, file.Name)
+		artifact, reason := outputArtifact(file)
+		resp.Files = append(resp.Files, OutputFileResponse{
+			Path:     filePath,
+			Size:     len(file.Content),
+			Attached: artifact != nil,
+			Reason:   reason,
+		})
+		if artifact != nil {
+			attachments = append(attachments, artifact)
+		}
+	}
+	for _, name := range result.SkippedOutputs {
+		resp.Files = append(resp.Files, OutputFileResponse{
+			Path:   path.Join(services.CodeRunOutputDir, name),
+			Reason: "exceeds the size or file count limit",
+		})
+	}
+	return resp, attachments, nil
+}
+
+// loadCSVArtifact fetches an artifact holding CSV data and checks that the user owns it.
+func loadCSVArtifact(ctx context.Context, provider database.RepositoryProvider, userID, id uuid.UUID) (*chat.Artifact, error) {
+	artifact, err := provider.Artifact().GetByID(ctx, id)
+	if err != nil {
+		if err == errors.ErrArtifactNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, fmt.Sprintf("Artifact %s not found", id), err)
+		}
+		return nil, fmt.Errorf("failed to get artifact: %w", err)
+	}
+	if !isCSV(artifact) {
+		return nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("Artifact %s is not a CSV file", id), nil)
+	}
+
+	ownerID, err := provider.Artifact().GetOwnerID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact owner: %w", err)
+	}
+	if ownerID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return artifact, nil
+}
+
+// isCSV reports whether an artifact holds CSV data, by its language or title.
+func isCSV(artifact *chat.Artifact) bool {
+	if artifact.Language != nil && strings.EqualFold(*artifact.Language, "csv") {
+		return true
+	}
+	return strings.HasSuffix(strings.ToLower(artifact.Title), ".csv")
+}
+
+// inputFileName derives a unique, safe file name with a .csv extension from an
+// artifact title.
+func inputFileName(title string, used map[string]bool) string {
+	base := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(title)), ".csv")
+	var b strings.Builder
+	for _, r := range base {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	base = strings.Trim(b.String(), "._-")
+	if len(base) > maxInputNameLength {
+		base = base[:maxInputNameLength]
+	}
+	if base == "" {
+		base = "data"
+	}
+
+	name := base + ".csv"
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d.csv", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// outputArtifact converts an output file into an artifact by its extension:
+// images become image artifacts holding a data URL, text files documents, SVG
+// and HTML files their own types. Other binary files cannot be attached.
+func outputArtifact(file services.CodeFile) (*chat.Artifact, string) {
+	ext := strings.ToLower(path.Ext(file.Name))
+	if mimeType, ok := imageTypes[ext]; ok {
+		content := "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(file.Content)
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeImage, nil, content), ""
+	}
+
+	if !utf8.Valid(file.Content) || strings.ContainsRune(string(file.Content), 0) {
+		return nil, "binary files other than PNG, JPEG, GIF and WebP images cannot be attached"
+	}
+	content := string(file.Content)
+	switch ext {
+	case ".svg":
+		language := "svg"
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeSVG, &language, content), ""
+	case ".html", ".htm":
+		language := "html"
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeHTML, &language, content), ""
+	case ".csv":
+		language := "csv"
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeDocument, &language, content), ""
+	case ".json":
+		language := "json"
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeDocument, &language, content), ""
+	case ".md":
+		language := "markdown"
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeDocument, &language, content), ""
+	default:
+		return chat.NewArtifact(file.Name, shared.ArtifactTypeDocument, nil, content), ""
+	}
+}
+
+// runReport builds the markdown document recording the script and its output.
+func runReport(scriptLanguage, code string, resp *RunCodeResponse) *chat.Artifact {
+	var b strings.Builder
+	b.WriteString("## Script\n\n")
+	writeFenced(&b, scriptLanguage, code)
+
+	b.WriteString("\n## Result\n\n")
+	switch {
+	case resp.TimedOut:
+		fmt.Fprintf(&b, "Stopped after the time limit (%d ms).\n", resp.DurationMs)
+	case resp.ExitCode == 0:
+		fmt.Fprintf(&b, "Completed in %d ms.\n", resp.DurationMs)
+	default:
+		fmt.Fprintf(&b, "Failed with exit code %d after %d ms.\n", resp.ExitCode, resp.DurationMs)
+	}
+	if len(resp.Inputs) > 0 {
+		b.WriteString("\nInputs:\n")
+		for _, input := range resp.Inputs {
+			fmt.Fprintf(&b, "- `%s` (artifact %s)\n", input.Path, input.ArtifactID)
+		}
+	}
+
+	for _, stream := range []struct {
+		name      string
+		output    string
+		truncated bool
+	}{
+		{"Standard output", resp.Stdout, resp.StdoutTruncated},
+		{"Standard error", resp.Stderr, resp.StderrTruncated},
+	} {
+		if stream.output == "" {
+			continue
+		}
+		output := stream.output
+		truncated := stream.truncated
+		if len(output) > maxReportOutput {
+			output = strings.ToValidUTF8(output[:maxReportOutput], "")
+			truncated = true
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", stream.name)
+		writeFenced(&b, "", output)
+		if truncated {
+			b.WriteString("\n_Output truncated._\n")
+		}
+	}
+
+	language := "markdown"
+	return chat.NewArtifact("Code run", shared.ArtifactTypeDocument, &language, b.String())
+}
+
+// writeFenced writes a fenced code block, with a fence longer than any backtick
+// run in the content.
+func writeFenced(b *strings.Builder, language, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	b.WriteString(fence + language + "\n")
+	b.WriteString(content)
+	if !strings.HasSuffix(content, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(fence + "\n")
+}
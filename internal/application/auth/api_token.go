@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// apiTokenDisplayLength is the number of leading characters of a token kept to
+// tell tokens apart in listings.
+const apiTokenDisplayLength = 12
+
+// CreateAPIToken creates a personal access token for the user. The token value
+// is returned only here; afterwards only its hash is known.
+func (uc *AuthUseCase) CreateAPIToken(ctx context.Context, userID uuid.UUID, req *CreateAPITokenRequest) (*CreateAPITokenResponse, error) {
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	value := auth.APITokenPrefix + secret
+
+	token := &auth.APIToken{
+		UserID:      userID,
+		Name:        req.Name,
+		TokenPrefix: value[:apiTokenDisplayLength],
+		TokenHash:   utils.HashToken(value),
+		Scopes:      req.Scopes,
+	}
+	if err := token.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	if req.ExpiresInDays != nil {
+		if *req.ExpiresInDays < 1 {
+			return nil, errors.NewAppError(errors.CodeValidation, "expires_in_days must be at least 1", nil)
+		}
+		expiresAt := time.Now().AddDate(0, 0, *req.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	var created *auth.APIToken
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		count, err := provider.APIToken().CountByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if count >= auth.MaxAPITokensPerUser {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("A user can have at most %d API tokens", auth.MaxAPITokensPerUser), nil)
+		}
+		created, err = provider.APIToken().Create(ctx, token)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionAPITokenCreated, userID).
+		On(audit.ResourceAPIToken, created.ID).
+		With("name", created.Name).
+		With("scopes", created.Scopes))
+
+	return &CreateAPITokenResponse{
+		APITokenResponse: toAPITokenResponse(created),
+		Token:            value,
+	}, nil
+}
+
+// ListAPITokens returns the user's personal access tokens, newest first.
+func (uc *AuthUseCase) ListAPITokens(ctx context.Context, userID uuid.UUID) ([]APITokenResponse, error) {
+	var tokens []*auth.APIToken
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		tokens, err = provider.APIToken().ListByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]APITokenResponse, len(tokens))
+	for i, t := range tokens {
+		responses[i] = toAPITokenResponse(t)
+	}
+	return responses, nil
+}
+
+// DeleteAPIToken revokes one of the user's personal access tokens.
+func (uc *AuthUseCase) DeleteAPIToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	var name string
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		token, err := provider.APIToken().GetByID(ctx, tokenID)
+		if err != nil {
+			if err == errors.ErrAPITokenNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "API token not found", err)
+			}
+			return fmt.Errorf("failed to get API token: %w", err)
+		}
+		if token.UserID != userID {
+			return errors.ErrForbidden
+		}
+		name = token.Name
+		return provider.APIToken().Delete(ctx, tokenID)
+	})
+	if err != nil {
+		return err
+	}
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionAPITokenDeleted, userID).
+		On(audit.ResourceAPIToken, tokenID).
+		With("name", name))
+	return nil
+}
+
+// IsAPIToken reports whether a bearer token is a personal access token rather than a JWT.
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, auth.APITokenPrefix)
+}
+
+// ValidateAPIToken validates a personal access token and returns claims for its
+// user, restricted to the token's scopes.
+func (uc *AuthUseCase) ValidateAPIToken(ctx context.Context, value string) (*utils.Claims, error) {
+	var token *auth.APIToken
+	var user *auth.User
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		token, err = provider.APIToken().GetByHash(ctx, utils.HashToken(value))
+		if err != nil {
+			if err == errors.ErrAPITokenNotFound {
+				return errors.NewAppError(errors.CodeUnauthorized, "Invalid API token", errors.ErrInvalidToken)
+			}
+			return fmt.Errorf("failed to get API token: %w", err)
+		}
+		if token.IsExpired() {
+			return errors.NewAppError(errors.CodeUnauthorized, "API token has expired", errors.ErrTokenExpired)
+		}
+
+		user, err = provider.User().GetByID(ctx, token.UserID)
+		if err != nil {
+			if err == errors.ErrUserNotFound {
+				return errors.NewAppError(errors.CodeUnauthorized, "User not found", err)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		return provider.APIToken().Touch(ctx, token.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAccountActive() {
+		return nil, errors.NewAppError(errors.CodeForbidden, "Account is inactive", errors.ErrForbidden)
+	}
+
+	return &utils.Claims{
+		Email:            user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: user.ID.String()},
+		APITokenID:       token.ID.String(),
+		Scopes:           token.Scopes,
+		Role:             user.Role,
+	}, nil
+}
+
+func toAPITokenResponse(t *auth.APIToken) APITokenResponse {
+	return APITokenResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		Scopes:      t.Scopes,
+		ExpiresAt:   t.ExpiresAt,
+		LastUsedAt:  t.LastUsedAt,
+		CreatedAt:   t.CreatedAt,
+	}
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// AuditEventResponse represents an audit log entry in API responses
+type AuditEventResponse struct {
+	// Event ID
+	ID uuid.UUID `json:"id"`
+	// Action, e.g. user.login or api_token.created
+	Action string `json:"action"`
+	// User who performed the action; null for anonymous requests
+	ActorID *uuid.UUID `json:"actor_id"`
+	// Account the action concerns
+	UserID *uuid.UUID `json:"user_id"`
+	// Type of the resource the action was taken on
+	ResourceType string `json:"resource_type,omitempty"`
+	// ID of the resource the action was taken on
+	ResourceID *uuid.UUID `json:"resource_id"`
+	// IP address of the client
+	IPAddress *string `json:"ip_address"`
+	// User agent of the client
+	UserAgent *string `json:"user_agent"`
+	// Details of the action
+	Metadata map[string]any `json:"metadata"`
+	// When the action happened
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditEventListResponse is a page of audit events
+type AuditEventListResponse struct {
+	Events []AuditEventResponse `json:"events"`
+	Total  int64                `json:"total"`
+}
+
+// ToAuditEventResponse converts a domain audit Event to AuditEventResponse DTO
+func ToAuditEventResponse(event *audit.Event) AuditEventResponse {
+	metadata := event.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	return AuditEventResponse{
+		ID:           event.ID,
+		Action:       string(event.Action),
+		ActorID:      event.ActorID,
+		UserID:       event.UserID,
+		ResourceType: event.ResourceType,
+		ResourceID:   event.ResourceID,
+		IPAddress:    event.IPAddress,
+		UserAgent:    event.UserAgent,
+		Metadata:     metadata,
+		CreatedAt:    event.CreatedAt,
+	}
+}
+
+// ListAuditEvents returns a page of the events the user performed or that
+// concern their account, newest first.
+func (uc *UserUseCase) ListAuditEvents(ctx context.Context, userID uuid.UUID, limit, offset int) (*AuditEventListResponse, error) {
+	return ListAuditEvents(ctx, uc.dbService, audit.EventFilter{InvolvingUserID: &userID}, limit, offset)
+}
+
+// ListAuditEvents returns a page of the events matching filter, newest first.
+func ListAuditEvents(ctx context.Context, dbService *database.Service, filter audit.EventFilter, limit, offset int) (*AuditEventListResponse, error) {
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	response := &AuditEventListResponse{}
+	err := dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		events, err := provider.AuditEvent().List(ctx, filter, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list audit events: %w", err)
+		}
+		response.Events = make([]AuditEventResponse, len(events))
+		for i, e := range events {
+			response.Events[i] = ToAuditEventResponse(e)
+		}
+
+		response.Total, err = provider.AuditEvent().Count(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count audit events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
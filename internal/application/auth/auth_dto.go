@@ -1,5 +1,13 @@
 package auth
 
+import (
+	"time"
+
+	"trading-alchemist/internal/infrastructure/webauthn"
+
+	"github.com/google/uuid"
+)
+
 // SendMagicLinkRequest represents the request to send a magic link
 type SendMagicLinkRequest struct {
 	// Email address to send the magic link to
@@ -22,18 +30,32 @@ type SendMagicLinkResponse struct {
 type VerifyMagicLinkRequest struct {
 	// Magic link token to verify
 	Token string `json:"token" validate:"required"`
+	// Name of the device signing in, shown in the list of sessions
+	DeviceName *string `json:"device_name,omitempty" validate:"omitempty,max=255"`
+	IPAddress  string  `json:"-"` // Set by middleware
+	UserAgent  string  `json:"-"` // Set by middleware
 }
 
-// VerifyMagicLinkResponse represents the response after verifying a magic link
+// VerifyMagicLinkResponse represents the response after verifying a magic link.
+// When the user has two-factor authentication enabled, it holds a challenge to
+// complete at /auth/2fa/verify instead of the tokens.
 type VerifyMagicLinkResponse struct {
 	// User information
 	User UserResponse `json:"user"`
 	// JWT access token
-	AccessToken string `json:"access_token"`
+	AccessToken string `json:"access_token,omitempty"`
 	// Token type
-	TokenType string `json:"token_type"`
+	TokenType string `json:"token_type,omitempty"`
 	// Token expiration in seconds
-	ExpiresIn int64 `json:"expires_in"` // seconds
+	ExpiresIn int64 `json:"expires_in,omitempty"` // seconds
+	// Refresh token used to obtain new access tokens
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// Refresh token expiration in seconds
+	RefreshExpiresIn int64 `json:"refresh_expires_in,omitempty"`
+	// Whether a second factor must be verified to get the tokens
+	TwoFactorRequired bool `json:"two_factor_required"`
+	// Challenge to answer with a second factor
+	TwoFactorChallenge *TwoFactorChallengeResponse `json:"two_factor_challenge,omitempty"`
 }
 
 // LoginRequest represents a login request
@@ -47,6 +69,12 @@ type LoginResponse struct {
 	Sent    bool   `json:"sent"`
 }
 
+// LogoutRequest represents a logout request
+type LogoutRequest struct {
+	// Refresh token of the session to sign out
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 // LogoutResponse represents a logout response
 type LogoutResponse struct {
 	Message string `json:"message"`
@@ -55,12 +83,188 @@ type LogoutResponse struct {
 // RefreshTokenRequest represents a refresh token request
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
+	IPAddress    string `json:"-"` // Set by middleware
+	UserAgent    string `json:"-"` // Set by middleware
 }
 
-// RefreshTokenResponse represents a refresh token response
+// RefreshTokenResponse represents a refresh token response. The refresh token
+// in the request can no longer be used.
 type RefreshTokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int64  `json:"expires_in"`
-} 
\ No newline at end of file
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	RefreshExpiresIn int64  `json:"refresh_expires_in"`
+}
+
+// SessionResponse represents a signed-in device
+type SessionResponse struct {
+	ID              uuid.UUID `json:"id"`
+	DeviceName      *string   `json:"device_name,omitempty"`
+	IPAddress       *string   `json:"ip_address,omitempty"`
+	UserAgent       *string   `json:"user_agent,omitempty"`
+	AuthenticatedAt time.Time `json:"authenticated_at"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	// Whether this is the session of the access token making the request
+	Current bool `json:"current"`
+}
+
+// CreateAPITokenRequest represents the request to create a personal access token
+type CreateAPITokenRequest struct {
+	// Name of the token, e.g. the script that uses it
+	Name string `json:"name" validate:"required,max=100"`
+	// Scopes granted to the token, e.g. chat:read, chat:write, providers:write, market:read
+	Scopes []string `json:"scopes" validate:"required,min=1"`
+	// Days until the token expires; omit for a token that never expires
+	ExpiresInDays *int `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=3650"`
+}
+
+// APITokenResponse represents a personal access token without its value
+type APITokenResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreateAPITokenResponse represents a newly created personal access token
+type CreateAPITokenResponse struct {
+	APITokenResponse
+	// The token, sent as "Authorization: Bearer <token>". It is shown only once.
+	Token string `json:"token"`
+}
+
+// OIDCProviderResponse represents an external sign-in provider
+type OIDCProviderResponse struct {
+	// Name used in the authorize URL, e.g. google or github
+	Name string `json:"name"`
+}
+
+// StartOIDCLoginRequest represents the request to start signing in with an external provider
+type StartOIDCLoginRequest struct {
+	Provider string `json:"-"` // Set from the path
+}
+
+// StartOIDCLoginResponse represents a sign-in started with an external provider
+type StartOIDCLoginResponse struct {
+	// Provider page to send the user to
+	AuthorizationURL string `json:"authorization_url"`
+	// State the provider sends back to the redirect URL; keep it to check the callback
+	State string `json:"state"`
+	// Seconds left to complete the sign-in
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// CompleteOIDCLoginRequest represents the parameters the provider sent back to the redirect URL
+type CompleteOIDCLoginRequest struct {
+	// Authorization code from the callback
+	Code string `json:"code" validate:"required"`
+	// State from the callback
+	State string `json:"state" validate:"required"`
+	// Name of the device signing in, shown in the list of sessions
+	DeviceName *string `json:"device_name,omitempty" validate:"omitempty,max=255"`
+	IPAddress  string  `json:"-"` // Set by middleware
+	UserAgent  string  `json:"-"` // Set by middleware
+}
+
+// TwoFactorChallengeResponse represents a pending second factor check
+type TwoFactorChallengeResponse struct {
+	// Short-lived token identifying the challenge
+	ChallengeToken string `json:"challenge_token"`
+	// Methods the user can answer with: totp, webauthn, recovery_code
+	Methods []string `json:"methods"`
+	// Options for navigator.credentials.get(), when the user has security keys or passkeys
+	WebAuthn *webauthn.RequestOptions `json:"webauthn,omitempty"`
+	// Seconds left to answer the challenge
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// VerifyTwoFactorRequest represents the answer to a second factor challenge
+type VerifyTwoFactorRequest struct {
+	// Token of the challenge
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Method used: totp, webauthn or recovery_code
+	Method string `json:"method" validate:"required,oneof=totp webauthn recovery_code"`
+	// Code from the authenticator app, or a recovery code
+	Code string `json:"code,omitempty"`
+	// Credential returned by navigator.credentials.get(), for webauthn
+	Credential *webauthn.AssertionResponse `json:"credential,omitempty"`
+	IPAddress  string                      `json:"-"` // Set by middleware
+	UserAgent  string                      `json:"-"` // Set by middleware
+}
+
+// StepUpResponse represents a session whose second factor has just been verified
+type StepUpResponse struct {
+	// Until when sensitive actions are allowed without another check
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+// TwoFactorStatusResponse represents the user's second factors
+type TwoFactorStatusResponse struct {
+	// Whether a second factor is required to sign in
+	Enabled bool `json:"enabled"`
+	// Whether an authenticator app is set up
+	TOTPEnabled bool `json:"totp_enabled"`
+	// Registered security keys and passkeys
+	WebAuthnCredentials []WebAuthnCredentialResponse `json:"webauthn_credentials"`
+	// Recovery codes that have not been used
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+// WebAuthnCredentialResponse represents a security key or passkey
+type WebAuthnCredentialResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// EnrollTOTPResponse represents an authenticator app secret waiting for confirmation
+type EnrollTOTPResponse struct {
+	// Base32 secret, for entering by hand
+	Secret string `json:"secret"`
+	// otpauth:// URL, usually shown as a QR code
+	OTPAuthURL string `json:"otpauth_url"`
+}
+
+// ConfirmTOTPRequest represents the first code of a newly added authenticator app
+type ConfirmTOTPRequest struct {
+	// Code shown by the authenticator app
+	Code string `json:"code" validate:"required"`
+}
+
+// RecoveryCodesResponse represents newly generated recovery codes
+type RecoveryCodesResponse struct {
+	// Single-use codes for when no other factor is at hand. They are shown only once.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
+
+// WebAuthnRegistrationOptionsResponse represents the start of a security key or passkey registration
+type WebAuthnRegistrationOptionsResponse struct {
+	// Token to send back with the new credential
+	ChallengeToken string `json:"challenge_token"`
+	// Options for navigator.credentials.create()
+	PublicKey *webauthn.CreationOptions `json:"public_key"`
+}
+
+// RegisterWebAuthnRequest represents a newly created security key or passkey
+type RegisterWebAuthnRequest struct {
+	// Token from the registration options
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	// Name of the credential, e.g. the device it is on
+	Name string `json:"name" validate:"required,max=100"`
+	// Credential returned by navigator.credentials.create()
+	Credential *webauthn.RegistrationResponse `json:"credential" validate:"required"`
+}
+
+// RegisterWebAuthnResponse represents a registered security key or passkey
+type RegisterWebAuthnResponse struct {
+	Credential WebAuthnCredentialResponse `json:"credential"`
+	// Generated when this is the user's first second factor. They are shown only once.
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+}
@@ -7,9 +7,13 @@ import (
 	"time"
 
 	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/audit"
 	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/domain/ratelimit"
 	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/internal/infrastructure/webauthn"
 	"trading-alchemist/pkg/errors"
 	"trading-alchemist/pkg/utils"
 
@@ -17,21 +21,32 @@ import (
 )
 
 type AuthUseCase struct {
-	emailService  services.EmailService
-	config        *config.Config
-	dbService     *database.Service
+	emailService      services.EmailService
+	identityProviders map[string]services.IdentityProvider
+	rateLimiter       ratelimit.Limiter
+	auditLogger       services.AuditLogger
+	relyingParty      *webauthn.RelyingParty
+	config            *config.Config
+	dbService         *database.Service
 }
 
 // NewAuthUseCase creates a new authentication use case
 func NewAuthUseCase(
 	emailService services.EmailService,
+	identityProviders map[string]services.IdentityProvider,
+	rateLimiter ratelimit.Limiter,
+	auditLogger services.AuditLogger,
 	config *config.Config,
 	dbService *database.Service,
 ) *AuthUseCase {
 	return &AuthUseCase{
-		emailService:  emailService,
-		config:        config,
-		dbService:     dbService,
+		emailService:      emailService,
+		identityProviders: identityProviders,
+		rateLimiter:       rateLimiter,
+		auditLogger:       auditLogger,
+		relyingParty:      newRelyingParty(config),
+		config:            config,
+		dbService:         dbService,
 	}
 }
 
@@ -46,11 +61,24 @@ func (uc *AuthUseCase) SendMagicLink(ctx context.Context, req *SendMagicLinkRequ
 	if req.Purpose != "" {
 		purpose = auth.MagicLinkPurpose(req.Purpose)
 	}
+	// Invitation links are only sent by inviting the user to an organization
+	if purpose == auth.MagicLinkPurposeOrganizationInvite {
+		return nil, errors.NewAppError(errors.CodeValidation, "Invalid magic link purpose", nil)
+	}
+
+	// Requests per client IP are limited by the route; this stops one address
+	// from being flooded with mail from many clients
+	decision, err := uc.rateLimiter.Allow(ctx, ratelimit.Key("magic_link", "email", email), ratelimit.Limit(uc.config.RateLimit.MagicLinkPerEmail))
+	if err != nil {
+		log.Printf("Rate limiter failed, allowing the magic link: %v", err)
+	} else if !decision.Allowed {
+		return nil, errors.NewRateLimitError("Too many magic links have been requested for this email address, please try again later", decision.RetryAfter)
+	}
 
 	var user *auth.User
 	var createdLink *auth.MagicLink
 
-	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
 		var err error
 		userRepo := provider.User()
 		magicLinkRepo := provider.MagicLink()
@@ -118,6 +146,12 @@ func (uc *AuthUseCase) SendMagicLink(ctx context.Context, req *SendMagicLinkRequ
 		return nil, err // The error from ExecuteInTx is already descriptive
 	}
 
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionMagicLinkIssued).
+		Concerning(user.ID).
+		On(audit.ResourceMagicLink, createdLink.ID).
+		With("purpose", string(purpose)).
+		From(req.IPAddress, req.UserAgent))
+
 	// Send email asynchronously after transaction is committed
 	go func(userToSend *auth.User, linkToSend *auth.MagicLink) {
 		var emailErr error
@@ -138,7 +172,8 @@ func (uc *AuthUseCase) SendMagicLink(ctx context.Context, req *SendMagicLinkRequ
 	}, nil
 }
 
-// VerifyMagicLink verifies a magic link and returns authentication tokens
+// VerifyMagicLink verifies a magic link and returns authentication tokens, or a
+// second factor challenge when the user has 2FA enabled
 func (uc *AuthUseCase) VerifyMagicLink(ctx context.Context, req *VerifyMagicLinkRequest) (*VerifyMagicLinkResponse, error) {
 	var magicLink *auth.MagicLink
 	var user *auth.User
@@ -173,7 +208,12 @@ func (uc *AuthUseCase) VerifyMagicLink(ctx context.Context, req *VerifyMagicLink
 		if _, err := provider.MagicLink().MarkAsUsed(ctx, magicLink.ID); err != nil {
 			return fmt.Errorf("failed to mark magic link as used: %w", err)
 		}
-		if magicLink.Purpose == auth.MagicLinkPurposeEmailVerification && !user.EmailVerified {
+		if magicLink.Purpose == auth.MagicLinkPurposeOrganizationInvite {
+			if err := acceptOrganizationInvitation(ctx, provider, magicLink); err != nil {
+				return err
+			}
+		}
+		if (magicLink.Purpose == auth.MagicLinkPurposeEmailVerification || magicLink.Purpose == auth.MagicLinkPurposeOrganizationInvite) && !user.EmailVerified {
 			if _, err := provider.User().VerifyEmail(ctx, user.ID); err != nil {
 				return fmt.Errorf("failed to verify user email: %w", err)
 			}
@@ -186,24 +226,242 @@ func (uc *AuthUseCase) VerifyMagicLink(ctx context.Context, req *VerifyMagicLink
 		return nil, err
 	}
 
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionMagicLinkUsed, user.ID).
+		On(audit.ResourceMagicLink, magicLink.ID).
+		With("purpose", string(magicLink.Purpose)).
+		From(req.IPAddress, req.UserAgent))
+
+	return uc.signIn(ctx, user, "magic_link", req.DeviceName, req.IPAddress, req.UserAgent)
+}
+
+// acceptOrganizationInvitation adds the user to the organization they were
+// invited to with the magic link. An invitation that was revoked in the
+// meantime is ignored, and the user is only signed in.
+func acceptOrganizationInvitation(ctx context.Context, provider database.RepositoryProvider, magicLink *auth.MagicLink) error {
+	invitation, err := provider.OrganizationInvitation().GetByMagicLinkID(ctx, magicLink.ID)
+	if err != nil {
+		if err == errors.ErrOrganizationInvitationNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to get organization invitation: %w", err)
+	}
+	err = provider.Organization().SetMember(ctx, &organization.Member{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         invitation.UserID,
+		Role:           invitation.Role,
+	})
+	if err != nil {
+		return err
+	}
+	return provider.OrganizationInvitation().Delete(ctx, invitation.ID)
+}
+
+// startSession creates a new session family for a user who just signed in and
+// issues its first access and refresh tokens.
+func (uc *AuthUseCase) startSession(ctx context.Context, user *auth.User, deviceName *string, ipAddress, userAgent string) (*RefreshTokenResponse, error) {
+	session := &auth.Session{
+		UserID:          user.ID,
+		FamilyID:        uuid.New(),
+		DeviceName:      deviceName,
+		AuthenticatedAt: time.Now(),
+	}
+	if ipAddress != "" {
+		session.IPAddress = &ipAddress
+	}
+	if userAgent != "" {
+		session.UserAgent = &userAgent
+	}
+
+	var tokens *RefreshTokenResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		tokens, err = uc.issueTokens(ctx, provider, user, session)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// issueTokens stores the session with a new refresh token and signs an access
+// token for it.
+func (uc *AuthUseCase) issueTokens(ctx context.Context, provider database.RepositoryProvider, user *auth.User, session *auth.Session) (*RefreshTokenResponse, error) {
 	jwtTTL, err := time.ParseDuration(uc.config.JWT.TTL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT_TTL configuration: %w", err)
 	}
+	refreshTTL, err := time.ParseDuration(uc.config.JWT.RefreshTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_REFRESH_TTL configuration: %w", err)
+	}
+
+	refreshToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	session.RefreshTokenHash = utils.HashToken(refreshToken)
+	session.ExpiresAt = time.Now().Add(refreshTTL)
+
+	created, err := provider.Session().Create(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
 
-	token, err := utils.GenerateJWT(user, uc.config.JWT.Secret, jwtTTL, uc.config.App.Name)
+	accessToken, err := utils.GenerateJWT(user, created.ID, uc.config.JWT.Secret, jwtTTL, uc.config.App.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
-	return &VerifyMagicLinkResponse{
-		User:        ToUserResponse(user),
-		AccessToken: token,
-		TokenType:   "Bearer",
-		ExpiresIn:   int64(jwtTTL.Seconds()),
+	return &RefreshTokenResponse{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(jwtTTL.Seconds()),
+		RefreshExpiresIn: int64(refreshTTL.Seconds()),
 	}, nil
 }
 
+// RefreshToken exchanges a refresh token for a new access token and a new
+// refresh token. Each refresh token can be used once: presenting one that has
+// already been exchanged means it leaked, so every session descending from the
+// same sign-in is revoked.
+func (uc *AuthUseCase) RefreshToken(ctx context.Context, req *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, errors.NewAppError(errors.CodeValidation, "Refresh token is required", nil)
+	}
+
+	var tokens *RefreshTokenResponse
+	reused := false
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		session, err := provider.Session().GetByRefreshTokenHash(ctx, utils.HashToken(req.RefreshToken))
+		if err != nil {
+			if err == errors.ErrSessionNotFound {
+				return errors.NewAppError(errors.CodeUnauthorized, "Invalid refresh token", errors.ErrInvalidToken)
+			}
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session.IsRevoked() {
+			return errors.NewAppError(errors.CodeUnauthorized, "Session has been revoked", errors.ErrInvalidToken)
+		}
+		if session.IsRotated() {
+			// Commit the revocation instead of rolling it back with an error
+			reused = true
+			log.Printf("Refresh token reuse detected for user %s, revoking session family %s", session.UserID, session.FamilyID)
+			return provider.Session().RevokeFamily(ctx, session.FamilyID)
+		}
+		if session.IsExpired() {
+			return errors.NewAppError(errors.CodeUnauthorized, "Refresh token has expired", errors.ErrTokenExpired)
+		}
+
+		user, err := provider.User().GetByID(ctx, session.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsAccountActive() {
+			return errors.NewAppError(errors.CodeForbidden, "Account is inactive", errors.ErrForbidden)
+		}
+
+		if err := provider.Session().MarkRotated(ctx, session.ID); err != nil {
+			return err
+		}
+		next := &auth.Session{
+			UserID:          session.UserID,
+			FamilyID:        session.FamilyID,
+			DeviceName:      session.DeviceName,
+			IPAddress:       session.IPAddress,
+			UserAgent:       session.UserAgent,
+			AuthenticatedAt: session.AuthenticatedAt,
+			SecondFactorAt:  session.SecondFactorAt,
+		}
+		if req.IPAddress != "" {
+			next.IPAddress = &req.IPAddress
+		}
+		if req.UserAgent != "" {
+			next.UserAgent = &req.UserAgent
+		}
+		tokens, err = uc.issueTokens(ctx, provider, user, next)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, errors.NewAppError(errors.CodeUnauthorized, "Refresh token has already been used; the session has been revoked", errors.ErrInvalidToken)
+	}
+	return tokens, nil
+}
+
+// Logout revokes the session of a refresh token, along with the access tokens
+// issued for it.
+func (uc *AuthUseCase) Logout(ctx context.Context, req *LogoutRequest) (*LogoutResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, errors.NewAppError(errors.CodeValidation, "Refresh token is required", nil)
+	}
+
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		session, err := provider.Session().GetByRefreshTokenHash(ctx, utils.HashToken(req.RefreshToken))
+		if err != nil {
+			if err == errors.ErrSessionNotFound {
+				return errors.NewAppError(errors.CodeUnauthorized, "Invalid refresh token", errors.ErrInvalidToken)
+			}
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		return provider.Session().RevokeFamily(ctx, session.FamilyID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogoutResponse{Message: "Logged out successfully"}, nil
+}
+
+// ListSessions returns the user's signed-in devices. currentSessionID is the
+// session of the access token making the request, or uuid.Nil.
+func (uc *AuthUseCase) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]SessionResponse, error) {
+	var sessions []*auth.Session
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		sessions, err = provider.Session().ListActiveByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		responses[i] = SessionResponse{
+			ID:              s.ID,
+			DeviceName:      s.DeviceName,
+			IPAddress:       s.IPAddress,
+			UserAgent:       s.UserAgent,
+			AuthenticatedAt: s.AuthenticatedAt,
+			LastSeenAt:      s.LastSeenAt,
+			ExpiresAt:       s.ExpiresAt,
+			Current:         s.ID == currentSessionID,
+		}
+	}
+	return responses, nil
+}
+
+// RevokeSession signs out one of the user's devices.
+func (uc *AuthUseCase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		session, err := provider.Session().GetByID(ctx, sessionID)
+		if err != nil {
+			if err == errors.ErrSessionNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Session not found", err)
+			}
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session.UserID != userID {
+			return errors.ErrForbidden
+		}
+		return provider.Session().RevokeFamily(ctx, session.FamilyID)
+	})
+}
+
 // ValidateToken validates a JWT token and returns the user claims
 func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*utils.Claims, error) {
 	// Validate JWT using utility function
@@ -227,7 +485,26 @@ func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*utils.
 			}
 			return fmt.Errorf("failed to get user: %w", err)
 		}
-		return nil
+
+		// Tokens issued for a session stop working as soon as it is revoked
+		if claims.SessionID == "" {
+			return nil
+		}
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return errors.NewAppError(errors.CodeUnauthorized, "Invalid session ID in token", err)
+		}
+		session, err := provider.Session().GetByID(ctx, sessionID)
+		if err != nil {
+			if err == errors.ErrSessionNotFound {
+				return errors.NewAppError(errors.CodeUnauthorized, "Session not found", err)
+			}
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session.UserID != userID || session.IsRevoked() {
+			return errors.NewAppError(errors.CodeUnauthorized, "Session has been revoked", errors.ErrInvalidToken)
+		}
+		return provider.Session().Touch(ctx, sessionID)
 	})
 
 	if err != nil {
@@ -240,5 +517,6 @@ func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*utils.
 
 	// The email in claims might be stale, so we prefer the one from the database.
 	claims.Email = user.Email
+	claims.Role = user.Role
 	return claims, nil
 } 
\ No newline at end of file
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// ListOIDCProviders returns the external sign-in providers, in configuration order.
+func (uc *AuthUseCase) ListOIDCProviders() []OIDCProviderResponse {
+	providers := make([]OIDCProviderResponse, 0, len(uc.config.OIDC.Providers))
+	for _, p := range uc.config.OIDC.Providers {
+		if _, ok := uc.identityProviders[p.Name]; ok {
+			providers = append(providers, OIDCProviderResponse{Name: p.Name})
+		}
+	}
+	return providers
+}
+
+// StartOIDCLogin creates an authorization request for an external provider and
+// returns the URL of its sign-in page. The PKCE verifier and the nonce stay on
+// the server until the callback.
+func (uc *AuthUseCase) StartOIDCLogin(ctx context.Context, req *StartOIDCLoginRequest) (*StartOIDCLoginResponse, error) {
+	idp, ok := uc.identityProviders[req.Provider]
+	if !ok {
+		return nil, errors.NewAppError(errors.CodeNotFound, "Sign-in provider not found", nil)
+	}
+	providerConfig := uc.oidcProviderConfig(req.Provider)
+
+	state, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	codeVerifier, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	authURL, err := idp.AuthCodeURL(ctx, state, nonce, utils.PKCEChallenge(codeVerifier), providerConfig.RedirectURL)
+	if err != nil {
+		log.Printf("Failed to start sign-in with %s: %v", req.Provider, err)
+		return nil, errors.NewAppError(errors.CodeInternalServer, "Sign-in provider is unavailable", err)
+	}
+
+	loginTTL := uc.config.OIDC.LoginTTL
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		_, err := provider.OIDCLoginRequest().Create(ctx, &auth.OIDCLoginRequest{
+			Provider:     req.Provider,
+			StateHash:    utils.HashToken(state),
+			Nonce:        nonce,
+			CodeVerifier: codeVerifier,
+			RedirectURL:  providerConfig.RedirectURL,
+			ExpiresAt:    time.Now().Add(loginTTL),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &StartOIDCLoginResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+		ExpiresIn:        int64(loginTTL.Seconds()),
+	}, nil
+}
+
+// CompleteOIDCLogin finishes a sign-in with an external provider from the code
+// and state it sent back, and returns the same response as VerifyMagicLink. The
+// identity is linked to the user with the same verified email, who is created
+// if needed.
+func (uc *AuthUseCase) CompleteOIDCLogin(ctx context.Context, req *CompleteOIDCLoginRequest) (*VerifyMagicLinkResponse, error) {
+	if req.Code == "" || req.State == "" {
+		return nil, errors.NewAppError(errors.CodeValidation, "Code and state are required", nil)
+	}
+
+	// The request is deleted whatever the outcome, so a state can be used once
+	var loginRequest *auth.OIDCLoginRequest
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		loginRequest, err = provider.OIDCLoginRequest().Consume(ctx, utils.HashToken(req.State))
+		return err
+	})
+	if err != nil {
+		if err == errors.ErrOIDCLoginRequestNotFound {
+			return nil, errors.NewAppError(errors.CodeUnauthorized, "Invalid or expired sign-in request", errors.ErrInvalidToken)
+		}
+		return nil, fmt.Errorf("failed to get sign-in request: %w", err)
+	}
+	if loginRequest.IsExpired() {
+		return nil, errors.NewAppError(errors.CodeUnauthorized, "Sign-in request has expired", errors.ErrTokenExpired)
+	}
+
+	idp, ok := uc.identityProviders[loginRequest.Provider]
+	if !ok {
+		return nil, errors.NewAppError(errors.CodeUnauthorized, "Sign-in provider is no longer available", nil)
+	}
+	external, err := idp.Exchange(ctx, req.Code, loginRequest.CodeVerifier, loginRequest.Nonce, loginRequest.RedirectURL)
+	if err != nil {
+		log.Printf("Failed to complete sign-in with %s: %v", loginRequest.Provider, err)
+		return nil, errors.NewAppError(errors.CodeUnauthorized, "Sign-in with the provider failed", err)
+	}
+
+	user, err := uc.signInWithIdentity(ctx, loginRequest.Provider, external)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAccountActive() {
+		return nil, errors.NewAppError(errors.CodeForbidden, "Account is inactive", errors.ErrForbidden)
+	}
+
+	return uc.signIn(ctx, user, "oidc:"+loginRequest.Provider, req.DeviceName, req.IPAddress, req.UserAgent)
+}
+
+// signInWithIdentity returns the user of an external identity. An identity
+// seen for the first time is linked to the user with the same email, which the
+// provider must have verified, or to a new user.
+func (uc *AuthUseCase) signInWithIdentity(ctx context.Context, providerName string, external *services.ExternalIdentity) (*auth.User, error) {
+	var email *string
+	if external.Email != "" {
+		normalized := utils.NormalizeEmail(external.Email)
+		email = &normalized
+	}
+
+	var user *auth.User
+	created := false
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		identity, err := provider.Identity().GetByProviderSubject(ctx, providerName, external.Subject)
+		if err == nil {
+			user, err = provider.User().GetByID(ctx, identity.UserID)
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+			return provider.Identity().RecordLogin(ctx, identity.ID, email)
+		}
+		if err != errors.ErrIdentityNotFound {
+			return fmt.Errorf("failed to get identity: %w", err)
+		}
+
+		if email == nil || !external.EmailVerified || !utils.IsValidEmail(*email) {
+			return errors.NewAppError(errors.CodeUnauthorized, "The sign-in provider did not confirm a verified email address", errors.ErrInvalidEmail)
+		}
+
+		user, err = provider.User().GetByEmail(ctx, *email)
+		switch {
+		case err == errors.ErrUserNotFound:
+			user, err = provider.User().Create(ctx, &auth.User{
+				ID:            uuid.New(),
+				Email:         *email,
+				EmailVerified: true,
+				FirstName:     external.FirstName,
+				LastName:      external.LastName,
+				IsActive:      true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create user: %w", err)
+			}
+			created = true
+		case err != nil:
+			return fmt.Errorf("failed to get user: %w", err)
+		case !user.EmailVerified:
+			// The provider has just proven that the user owns the email
+			if user, err = provider.User().VerifyEmail(ctx, user.ID); err != nil {
+				return fmt.Errorf("failed to verify user email: %w", err)
+			}
+		}
+
+		_, err = provider.Identity().Create(ctx, &auth.Identity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  external.Subject,
+			Email:    email,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		go func(userToSend *auth.User) {
+			if err := uc.emailService.SendWelcomeEmail(context.Background(), userToSend); err != nil {
+				log.Printf("Failed to send welcome email: %v\n", err)
+			}
+		}(user)
+	}
+	return user, nil
+}
+
+// oidcProviderConfig returns the configuration of a provider by name.
+func (uc *AuthUseCase) oidcProviderConfig(name string) config.OIDCProviderConfig {
+	for _, p := range uc.config.OIDC.Providers {
+		if p.Name == name {
+			return p
+		}
+	}
+	return config.OIDCProviderConfig{}
+}
@@ -0,0 +1,757 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/internal/infrastructure/webauthn"
+	"trading-alchemist/pkg/errors"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// GetTwoFactorStatus returns the user's second factors.
+func (uc *AuthUseCase) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*TwoFactorStatusResponse, error) {
+	response := &TwoFactorStatusResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		methods, credentials, err := uc.twoFactorMethods(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		response.Enabled = len(methods) > 0
+		for _, m := range methods {
+			if m == auth.TwoFactorMethodTOTP {
+				response.TOTPEnabled = true
+			}
+		}
+		response.WebAuthnCredentials = make([]WebAuthnCredentialResponse, len(credentials))
+		for i, c := range credentials {
+			response.WebAuthnCredentials[i] = toWebAuthnCredentialResponse(c)
+		}
+		response.RecoveryCodesRemaining, err = provider.TwoFactor().CountUnusedRecoveryCodes(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// EnrollTOTP generates a secret for an authenticator app. It is not used until
+// ConfirmTOTP proves that the app has it.
+func (uc *AuthUseCase) EnrollTOTP(ctx context.Context, userID uuid.UUID) (*EnrollTOTPResponse, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	encryptedSecret, err := keyring.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+
+	var user *auth.User
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		user, err = provider.User().GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		existing, err := provider.TwoFactor().GetTOTPSecret(ctx, userID)
+		if err != nil && err != errors.ErrTOTPSecretNotFound {
+			return err
+		}
+		if existing != nil && existing.IsConfirmed() {
+			return errors.NewAppError(errors.CodeConflict, "An authenticator app is already set up; remove it first", nil)
+		}
+		_, err = provider.TwoFactor().UpsertTOTPSecret(ctx, userID, encryptedSecret)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EnrollTOTPResponse{
+		Secret:     secret,
+		OTPAuthURL: utils.TOTPURL(uc.config.App.Name, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP activates the authenticator app with its first code. Recovery
+// codes are returned when this is the user's first second factor.
+func (uc *AuthUseCase) ConfirmTOTP(ctx context.Context, userID uuid.UUID, req *ConfirmTOTPRequest) (*RecoveryCodesResponse, error) {
+	response := &RecoveryCodesResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		secret, err := provider.TwoFactor().GetTOTPSecret(ctx, userID)
+		if err != nil {
+			if err == errors.ErrTOTPSecretNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "No authenticator app is being set up", err)
+			}
+			return err
+		}
+		if secret.IsConfirmed() {
+			return errors.NewAppError(errors.CodeConflict, "The authenticator app is already confirmed", nil)
+		}
+
+		plainSecret, err := uc.decryptTOTPSecret(secret)
+		if err != nil {
+			return err
+		}
+		step, ok := utils.ValidateTOTP(plainSecret, req.Code, time.Now())
+		if !ok {
+			return errors.NewAppError(errors.CodeValidation, "Invalid code", nil)
+		}
+
+		methods, _, err := uc.twoFactorMethods(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		if err := provider.TwoFactor().ConfirmTOTPSecret(ctx, userID); err != nil {
+			return err
+		}
+		if err := provider.TwoFactor().UpdateTOTPLastUsedStep(ctx, userID, step); err != nil {
+			return err
+		}
+		if len(methods) == 0 {
+			response.RecoveryCodes, err = uc.replaceRecoveryCodes(ctx, provider, userID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DisableTOTP removes the user's authenticator app.
+func (uc *AuthUseCase) DisableTOTP(ctx context.Context, userID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := provider.TwoFactor().GetTOTPSecret(ctx, userID); err != nil {
+			if err == errors.ErrTOTPSecretNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "No authenticator app is set up", err)
+			}
+			return err
+		}
+		if err := provider.TwoFactor().DeleteTOTPSecret(ctx, userID); err != nil {
+			return err
+		}
+		return uc.dropRecoveryCodesIfDisabled(ctx, provider, userID)
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes with new ones.
+func (uc *AuthUseCase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID) (*RecoveryCodesResponse, error) {
+	response := &RecoveryCodesResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		methods, _, err := uc.twoFactorMethods(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		if len(methods) == 0 {
+			return errors.NewAppError(errors.CodeValidation, "Two-factor authentication is not enabled", nil)
+		}
+		response.RecoveryCodes, err = uc.replaceRecoveryCodes(ctx, provider, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// StartWebAuthnRegistration returns the options to create a security key or
+// passkey credential in the browser.
+func (uc *AuthUseCase) StartWebAuthnRegistration(ctx context.Context, userID uuid.UUID) (*WebAuthnRegistrationOptionsResponse, error) {
+	challenge, err := newWebAuthnChallenge()
+	if err != nil {
+		return nil, err
+	}
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+
+	var options *webauthn.CreationOptions
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		user, err := provider.User().GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		credentials, err := provider.TwoFactor().ListWebAuthnCredentials(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(credentials) >= auth.MaxWebAuthnCredentialsPerUser {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("A user can have at most %d security keys and passkeys", auth.MaxWebAuthnCredentialsPerUser), nil)
+		}
+
+		_, err = provider.TwoFactorChallenge().Create(ctx, &auth.TwoFactorChallenge{
+			UserID:            userID,
+			Purpose:           auth.TwoFactorPurposeWebAuthnRegistration,
+			TokenHash:         utils.HashToken(token),
+			WebAuthnChallenge: challenge,
+			ExpiresAt:         time.Now().Add(uc.config.TwoFactor.ChallengeTTL),
+		})
+		if err != nil {
+			return err
+		}
+		options = uc.relyingParty.CreationOptions(challenge, user.ID[:], user.Email, user.DisplayName(), toWebAuthnCredentials(credentials))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRegistrationOptionsResponse{
+		ChallengeToken: token,
+		PublicKey:      options,
+	}, nil
+}
+
+// RegisterWebAuthn verifies and stores a credential created with the options of
+// StartWebAuthnRegistration. Recovery codes are returned when this is the
+// user's first second factor.
+func (uc *AuthUseCase) RegisterWebAuthn(ctx context.Context, userID uuid.UUID, req *RegisterWebAuthnRequest) (*RegisterWebAuthnResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len([]rune(name)) > auth.MaxWebAuthnCredentialNameLength {
+		return nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("name is required and must be at most %d characters", auth.MaxWebAuthnCredentialNameLength), nil)
+	}
+	if req.Credential == nil {
+		return nil, errors.NewAppError(errors.CodeValidation, "credential is required", nil)
+	}
+
+	response := &RegisterWebAuthnResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		challenge, err := provider.TwoFactorChallenge().GetByTokenHash(ctx, utils.HashToken(req.ChallengeToken))
+		if err != nil && err != errors.ErrTwoFactorChallengeNotFound {
+			return err
+		}
+		if challenge == nil || challenge.Purpose != auth.TwoFactorPurposeWebAuthnRegistration || challenge.UserID != userID || challenge.IsExpired() {
+			return errors.NewAppError(errors.CodeUnauthorized, "Invalid or expired registration", errors.ErrInvalidToken)
+		}
+		if err := provider.TwoFactorChallenge().Delete(ctx, challenge.ID); err != nil {
+			return err
+		}
+
+		verified, err := uc.relyingParty.VerifyRegistration(req.Credential, challenge.WebAuthnChallenge)
+		if err != nil {
+			return errors.NewAppError(errors.CodeValidation, "The credential could not be verified", err)
+		}
+		if _, err := provider.TwoFactor().GetWebAuthnCredentialByCredentialID(ctx, verified.ID); err == nil {
+			return errors.NewAppError(errors.CodeConflict, "This credential is already registered", nil)
+		} else if err != errors.ErrWebAuthnCredentialNotFound {
+			return err
+		}
+
+		methods, credentials, err := uc.twoFactorMethods(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		if len(credentials) >= auth.MaxWebAuthnCredentialsPerUser {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("A user can have at most %d security keys and passkeys", auth.MaxWebAuthnCredentialsPerUser), nil)
+		}
+
+		created, err := provider.TwoFactor().CreateWebAuthnCredential(ctx, &auth.WebAuthnCredential{
+			UserID:       userID,
+			Name:         name,
+			CredentialID: verified.ID,
+			PublicKey:    verified.PublicKey,
+			SignCount:    verified.SignCount,
+			Transports:   verified.Transports,
+		})
+		if err != nil {
+			return err
+		}
+		response.Credential = toWebAuthnCredentialResponse(created)
+		if len(methods) == 0 {
+			response.RecoveryCodes, err = uc.replaceRecoveryCodes(ctx, provider, userID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteWebAuthnCredential removes one of the user's security keys or passkeys.
+func (uc *AuthUseCase) DeleteWebAuthnCredential(ctx context.Context, userID, credentialID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		credential, err := provider.TwoFactor().GetWebAuthnCredential(ctx, credentialID)
+		if err != nil {
+			if err == errors.ErrWebAuthnCredentialNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Credential not found", err)
+			}
+			return err
+		}
+		if credential.UserID != userID {
+			return errors.ErrForbidden
+		}
+		if err := provider.TwoFactor().DeleteWebAuthnCredential(ctx, credentialID); err != nil {
+			return err
+		}
+		return uc.dropRecoveryCodesIfDisabled(ctx, provider, userID)
+	})
+}
+
+// signIn starts a session for a user who has proven their email with method,
+// or returns a challenge to complete at VerifyTwoFactor when they have 2FA
+// enabled.
+func (uc *AuthUseCase) signIn(ctx context.Context, user *auth.User, method string, deviceName *string, ipAddress, userAgent string) (*VerifyMagicLinkResponse, error) {
+	var challenge *TwoFactorChallengeResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		pending := &auth.TwoFactorChallenge{
+			UserID:     user.ID,
+			Purpose:    auth.TwoFactorPurposeLogin,
+			DeviceName: deviceName,
+		}
+		if ipAddress != "" {
+			pending.IPAddress = &ipAddress
+		}
+		if userAgent != "" {
+			pending.UserAgent = &userAgent
+		}
+		var err error
+		challenge, err = uc.createTwoFactorChallenge(ctx, provider, pending)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &VerifyMagicLinkResponse{
+			User:               ToUserResponse(user),
+			TwoFactorRequired:  true,
+			TwoFactorChallenge: challenge,
+		}, nil
+	}
+
+	tokens, err := uc.startSession(ctx, user, deviceName, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionLogin, user.ID).
+		With("method", method).
+		From(ipAddress, userAgent))
+	return &VerifyMagicLinkResponse{
+		User:             ToUserResponse(user),
+		AccessToken:      tokens.AccessToken,
+		TokenType:        tokens.TokenType,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
+	}, nil
+}
+
+// VerifyTwoFactor completes a sign-in with the second factor and returns the
+// tokens of the new session.
+func (uc *AuthUseCase) VerifyTwoFactor(ctx context.Context, req *VerifyTwoFactorRequest) (*VerifyMagicLinkResponse, error) {
+	var user *auth.User
+	var tokens *RefreshTokenResponse
+	err := uc.answerChallenge(ctx, req, auth.TwoFactorPurposeLogin, uuid.Nil, func(provider database.RepositoryProvider, challenge *auth.TwoFactorChallenge) error {
+		var err error
+		user, err = provider.User().GetByID(ctx, challenge.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.IsAccountActive() {
+			return errors.NewAppError(errors.CodeForbidden, "Account is inactive", errors.ErrForbidden)
+		}
+
+		now := time.Now()
+		session := &auth.Session{
+			UserID:          user.ID,
+			FamilyID:        uuid.New(),
+			DeviceName:      challenge.DeviceName,
+			IPAddress:       challenge.IPAddress,
+			UserAgent:       challenge.UserAgent,
+			AuthenticatedAt: now,
+			SecondFactorAt:  &now,
+		}
+		if req.IPAddress != "" {
+			session.IPAddress = &req.IPAddress
+		}
+		if req.UserAgent != "" {
+			session.UserAgent = &req.UserAgent
+		}
+		tokens, err = uc.issueTokens(ctx, provider, user, session)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionLogin, user.ID).
+		With("method", "two_factor").
+		With("second_factor", req.Method).
+		From(req.IPAddress, req.UserAgent))
+
+	return &VerifyMagicLinkResponse{
+		User:             ToUserResponse(user),
+		AccessToken:      tokens.AccessToken,
+		TokenType:        tokens.TokenType,
+		ExpiresIn:        tokens.ExpiresIn,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresIn: tokens.RefreshExpiresIn,
+	}, nil
+}
+
+// StartStepUp returns a challenge to re-verify the user of a session before a
+// sensitive action.
+func (uc *AuthUseCase) StartStepUp(ctx context.Context, userID, sessionID uuid.UUID) (*TwoFactorChallengeResponse, error) {
+	if sessionID == uuid.Nil {
+		return nil, errors.NewAppError(errors.CodeForbidden, "A signed-in session is required", errors.ErrForbidden)
+	}
+
+	var challenge *TwoFactorChallengeResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		challenge, err = uc.createTwoFactorChallenge(ctx, provider, &auth.TwoFactorChallenge{
+			UserID:    userID,
+			Purpose:   auth.TwoFactorPurposeStepUp,
+			SessionID: &sessionID,
+		})
+		if err == nil && challenge == nil {
+			return errors.NewAppError(errors.CodeValidation, "Two-factor authentication is not enabled", nil)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// VerifyStepUp answers a step-up challenge, allowing sensitive actions in the
+// session for the configured time.
+func (uc *AuthUseCase) VerifyStepUp(ctx context.Context, sessionID uuid.UUID, req *VerifyTwoFactorRequest) (*StepUpResponse, error) {
+	if sessionID == uuid.Nil {
+		return nil, errors.NewAppError(errors.CodeForbidden, "A signed-in session is required", errors.ErrForbidden)
+	}
+
+	err := uc.answerChallenge(ctx, req, auth.TwoFactorPurposeStepUp, sessionID, func(provider database.RepositoryProvider, challenge *auth.TwoFactorChallenge) error {
+		return provider.Session().MarkSecondFactor(ctx, sessionID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &StepUpResponse{ValidUntil: time.Now().Add(uc.config.TwoFactor.StepUpMaxAge)}, nil
+}
+
+// HasRecentSecondFactor reports whether the request may perform a sensitive
+// action: either the user has no second factor, or the session verified one
+// recently. Personal access tokens never qualify once 2FA is enabled.
+func (uc *AuthUseCase) HasRecentSecondFactor(ctx context.Context, claims *utils.Claims) (bool, error) {
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return false, errors.NewAppError(errors.CodeUnauthorized, "Invalid user ID in token", err)
+	}
+
+	allowed := false
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		methods, _, err := uc.twoFactorMethods(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		if len(methods) == 0 {
+			allowed = true
+			return nil
+		}
+		if claims.APITokenID != "" || claims.SessionID == "" {
+			return nil
+		}
+
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return errors.NewAppError(errors.CodeUnauthorized, "Invalid session ID in token", err)
+		}
+		session, err := provider.Session().GetByID(ctx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		allowed = session.HasRecentSecondFactor(uc.config.TwoFactor.StepUpMaxAge)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return allowed, nil
+}
+
+// createTwoFactorChallenge stores a challenge for the user's second factors and
+// returns what the client needs to answer it, or nil if 2FA is not enabled.
+func (uc *AuthUseCase) createTwoFactorChallenge(ctx context.Context, provider database.RepositoryProvider, challenge *auth.TwoFactorChallenge) (*TwoFactorChallengeResponse, error) {
+	methods, credentials, err := uc.twoFactorMethods(ctx, provider, challenge.UserID)
+	if err != nil || len(methods) == 0 {
+		return nil, err
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+	challenge.TokenHash = utils.HashToken(token)
+	challenge.ExpiresAt = time.Now().Add(uc.config.TwoFactor.ChallengeTTL)
+
+	response := &TwoFactorChallengeResponse{
+		ChallengeToken: token,
+		Methods:        methods,
+		ExpiresIn:      int64(uc.config.TwoFactor.ChallengeTTL.Seconds()),
+	}
+	if len(credentials) > 0 {
+		challenge.WebAuthnChallenge, err = newWebAuthnChallenge()
+		if err != nil {
+			return nil, err
+		}
+		response.WebAuthn = uc.relyingParty.RequestOptions(challenge.WebAuthnChallenge, toWebAuthnCredentials(credentials))
+	}
+
+	if _, err := provider.TwoFactorChallenge().Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// answerChallenge checks the second factor answering a challenge and runs
+// onSuccess in the same transaction. Failed attempts are counted, and the
+// challenge is dropped once it has expired or had too many of them.
+func (uc *AuthUseCase) answerChallenge(ctx context.Context, req *VerifyTwoFactorRequest, purpose auth.TwoFactorPurpose, sessionID uuid.UUID, onSuccess func(database.RepositoryProvider, *auth.TwoFactorChallenge) error) error {
+	// Set when the outcome must be committed before the request is rejected
+	var rejection error
+	var failedUserID uuid.UUID
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		challenge, err := provider.TwoFactorChallenge().GetByTokenHash(ctx, utils.HashToken(req.ChallengeToken))
+		if err != nil {
+			if err == errors.ErrTwoFactorChallengeNotFound {
+				return errors.NewAppError(errors.CodeUnauthorized, "Invalid or expired two-factor challenge", errors.ErrInvalidToken)
+			}
+			return err
+		}
+		if challenge.Purpose != purpose || (sessionID != uuid.Nil && (challenge.SessionID == nil || *challenge.SessionID != sessionID)) {
+			return errors.NewAppError(errors.CodeUnauthorized, "Invalid or expired two-factor challenge", errors.ErrInvalidToken)
+		}
+		if challenge.IsExpired() || challenge.Attempts >= auth.MaxTwoFactorAttempts {
+			rejection = errors.NewAppError(errors.CodeUnauthorized, "Two-factor challenge has expired or had too many failed attempts", errors.ErrTokenExpired)
+			return provider.TwoFactorChallenge().Delete(ctx, challenge.ID)
+		}
+
+		ok, err := uc.verifySecondFactor(ctx, provider, challenge, req)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			rejection = errors.NewAppError(errors.CodeUnauthorized, "Invalid two-factor code", errors.ErrInvalidCredentials)
+			failedUserID = challenge.UserID
+			return provider.TwoFactorChallenge().IncrementAttempts(ctx, challenge.ID)
+		}
+
+		if err := provider.TwoFactorChallenge().Delete(ctx, challenge.ID); err != nil {
+			return err
+		}
+		return onSuccess(provider, challenge)
+	})
+	if err != nil {
+		return err
+	}
+	if failedUserID != uuid.Nil {
+		uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionSecondFactorFailed, failedUserID).
+			With("purpose", string(purpose)).
+			With("method", req.Method).
+			From(req.IPAddress, req.UserAgent))
+	}
+	return rejection
+}
+
+// verifySecondFactor reports whether the request answers the challenge with one
+// of the user's second factors. Codes and credential counters are consumed.
+func (uc *AuthUseCase) verifySecondFactor(ctx context.Context, provider database.RepositoryProvider, challenge *auth.TwoFactorChallenge, req *VerifyTwoFactorRequest) (bool, error) {
+	switch req.Method {
+	case auth.TwoFactorMethodTOTP:
+		secret, err := provider.TwoFactor().GetTOTPSecret(ctx, challenge.UserID)
+		if err == errors.ErrTOTPSecretNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if !secret.IsConfirmed() {
+			return false, nil
+		}
+		plainSecret, err := uc.decryptTOTPSecret(secret)
+		if err != nil {
+			return false, err
+		}
+		// A code is accepted once, even though it is valid for a while
+		step, ok := utils.ValidateTOTP(plainSecret, req.Code, time.Now())
+		if !ok || step <= secret.LastUsedStep {
+			return false, nil
+		}
+		return true, provider.TwoFactor().UpdateTOTPLastUsedStep(ctx, challenge.UserID, step)
+
+	case auth.TwoFactorMethodRecoveryCode:
+		code := normalizeRecoveryCode(req.Code)
+		if code == "" {
+			return false, nil
+		}
+		return provider.TwoFactor().UseRecoveryCode(ctx, challenge.UserID, utils.HashToken(code))
+
+	case auth.TwoFactorMethodWebAuthn:
+		if req.Credential == nil || challenge.WebAuthnChallenge == nil {
+			return false, nil
+		}
+		credential, err := provider.TwoFactor().GetWebAuthnCredentialByCredentialID(ctx, req.Credential.RawID)
+		if err == errors.ErrWebAuthnCredentialNotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if credential.UserID != challenge.UserID {
+			return false, nil
+		}
+		signCount, err := uc.relyingParty.VerifyAssertion(req.Credential, challenge.WebAuthnChallenge, toWebAuthnCredential(credential))
+		if err != nil {
+			log.Printf("WebAuthn assertion rejected for user %s: %v", challenge.UserID, err)
+			return false, nil
+		}
+		return true, provider.TwoFactor().RecordWebAuthnUsage(ctx, credential.ID, signCount)
+	}
+	return false, nil
+}
+
+// twoFactorMethods returns the second factors the user can answer a challenge
+// with, and their WebAuthn credentials. 2FA is enabled when there are any.
+func (uc *AuthUseCase) twoFactorMethods(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID) ([]string, []*auth.WebAuthnCredential, error) {
+	var methods []string
+	credentials, err := provider.TwoFactor().ListWebAuthnCredentials(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(credentials) > 0 {
+		methods = append(methods, auth.TwoFactorMethodWebAuthn)
+	}
+
+	secret, err := provider.TwoFactor().GetTOTPSecret(ctx, userID)
+	if err != nil && err != errors.ErrTOTPSecretNotFound {
+		return nil, nil, err
+	}
+	if secret != nil && secret.IsConfirmed() {
+		methods = append(methods, auth.TwoFactorMethodTOTP)
+	}
+
+	if len(methods) > 0 {
+		remaining, err := provider.TwoFactor().CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if remaining > 0 {
+			methods = append(methods, auth.TwoFactorMethodRecoveryCode)
+		}
+	}
+	return methods, credentials, nil
+}
+
+// replaceRecoveryCodes generates a new set of recovery codes for the user and
+// returns them; only their hashes are stored.
+func (uc *AuthUseCase) replaceRecoveryCodes(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, auth.RecoveryCodeCount)
+	hashes := make([]string, auth.RecoveryCodeCount)
+	for i := range codes {
+		random, err := utils.GenerateSecureToken(5)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		codes[i] = random[:5] + "-" + random[5:]
+		hashes[i] = utils.HashToken(normalizeRecoveryCode(codes[i]))
+	}
+	if err := provider.TwoFactor().ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// dropRecoveryCodesIfDisabled deletes the recovery codes once the user has
+// removed their last second factor.
+func (uc *AuthUseCase) dropRecoveryCodesIfDisabled(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID) error {
+	methods, _, err := uc.twoFactorMethods(ctx, provider, userID)
+	if err != nil {
+		return err
+	}
+	if len(methods) == 0 {
+		return provider.TwoFactor().DeleteRecoveryCodes(ctx, userID)
+	}
+	return nil
+}
+
+func (uc *AuthUseCase) decryptTOTPSecret(secret *auth.TOTPSecret) (string, error) {
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return "", fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	plainSecret, err := keyring.Decrypt(secret.EncryptedSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	return plainSecret, nil
+}
+
+// normalizeRecoveryCode ignores case, dashes and spaces, as users retype the codes.
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+// newRelyingParty creates the WebAuthn relying party of the frontend.
+func newRelyingParty(cfg *config.Config) *webauthn.RelyingParty {
+	return webauthn.NewRelyingParty(
+		cfg.TwoFactor.WebAuthnRPID,
+		cfg.TwoFactor.WebAuthnRPName,
+		cfg.TwoFactor.WebAuthnOrigins,
+		cfg.TwoFactor.ChallengeTTL,
+	)
+}
+
+func newWebAuthnChallenge() ([]byte, error) {
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("failed to generate WebAuthn challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+func toWebAuthnCredential(c *auth.WebAuthnCredential) *webauthn.Credential {
+	return &webauthn.Credential{
+		ID:         c.CredentialID,
+		PublicKey:  c.PublicKey,
+		SignCount:  c.SignCount,
+		Transports: c.Transports,
+	}
+}
+
+func toWebAuthnCredentials(credentials []*auth.WebAuthnCredential) []webauthn.Credential {
+	list := make([]webauthn.Credential, len(credentials))
+	for i, c := range credentials {
+		list[i] = *toWebAuthnCredential(c)
+	}
+	return list
+}
+
+func toWebAuthnCredentialResponse(c *auth.WebAuthnCredential) WebAuthnCredentialResponse {
+	return WebAuthnCredentialResponse{
+		ID:         c.ID,
+		Name:       c.Name,
+		Transports: c.Transports,
+		LastUsedAt: c.LastUsedAt,
+		CreatedAt:  c.CreatedAt,
+	}
+}
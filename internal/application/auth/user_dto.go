@@ -27,6 +27,10 @@ type UserResponse struct {
 	DisplayName string `json:"display_name"`
 	// Whether user is active
 	IsActive bool `json:"is_active"`
+	// Whether the assistant remembers facts about the user across conversations
+	MemoryEnabled bool `json:"memory_enabled"`
+	// Role: user or admin
+	Role string `json:"role"`
 	// Account creation timestamp
 	CreatedAt time.Time `json:"created_at"`
 	// Last update timestamp
@@ -48,6 +52,8 @@ type UpdateUserRequest struct {
 	LastName *string `json:"last_name,omitempty" validate:"omitempty,min=1,max=100"`
 	// Avatar URL
 	AvatarURL *string `json:"avatar_url,omitempty" validate:"omitempty,url"`
+	// Whether the assistant remembers facts about the user; false stops extracting and using memories
+	MemoryEnabled *bool `json:"memory_enabled,omitempty"`
 }
 
 // GetUserResponse represents the response for getting user information
@@ -74,6 +80,8 @@ func ToUserResponse(user *auth.User) UserResponse {
 		FullName:      user.FullName(),
 		DisplayName:   user.DisplayName(),
 		IsActive:      user.IsActive,
+		MemoryEnabled: user.MemoryEnabled,
+		Role:          string(user.Role),
 		CreatedAt:     user.CreatedAt,
 		UpdatedAt:     user.UpdatedAt,
 	}
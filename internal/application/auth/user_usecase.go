@@ -59,6 +59,9 @@ func (uc *UserUseCase) UpdateUserProfile(ctx context.Context, userID uuid.UUID,
 		if req.AvatarURL != nil {
 			user.AvatarURL = req.AvatarURL
 		}
+		if req.MemoryEnabled != nil {
+			user.MemoryEnabled = *req.MemoryEnabled
+		}
 
 		// Update the user in the database
 		updatedUser, err = provider.User().Update(ctx, user)
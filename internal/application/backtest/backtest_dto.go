@@ -0,0 +1,119 @@
+package backtest
+
+import (
+	"encoding/json"
+	"time"
+
+	"trading-alchemist/internal/domain/backtest"
+	"trading-alchemist/internal/domain/strategy"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// BacktestWindowRequest selects the market data and execution settings of a backtest.
+type BacktestWindowRequest struct {
+	Symbol          string    `json:"symbol" validate:"required"`
+	Timeframe       string    `json:"timeframe" validate:"required"`
+	Start           time.Time `json:"start" validate:"required"`
+	End             time.Time `json:"end" validate:"required"`
+	InitialCapital  *float64  `json:"initial_capital,omitempty"`
+	CommissionRate  *float64  `json:"commission_rate,omitempty"`
+	CommissionFixed *float64  `json:"commission_fixed,omitempty"`
+	SlippageBps     *float64  `json:"slippage_bps,omitempty"`
+	Sizing          *string   `json:"sizing,omitempty"`
+	SizeValue       *float64  `json:"size_value,omitempty"`
+	AllowShort      *bool     `json:"allow_short,omitempty"`
+	Adjustment      string    `json:"adjustment,omitempty"` // Corporate actions to adjust prices for: none, splits or all; defaults to none
+}
+
+// Config returns the engine configuration, applying defaults for omitted fields.
+func (r *BacktestWindowRequest) Config() backtest.Config {
+	cfg := backtest.DefaultConfig()
+	if r.InitialCapital != nil {
+		cfg.InitialCapital = *r.InitialCapital
+	}
+	if r.CommissionRate != nil {
+		cfg.CommissionRate = *r.CommissionRate
+	}
+	if r.CommissionFixed != nil {
+		cfg.CommissionFixed = *r.CommissionFixed
+	}
+	if r.SlippageBps != nil {
+		cfg.SlippageBps = *r.SlippageBps
+	}
+	if r.Sizing != nil {
+		cfg.Sizing = backtest.SizingMode(*r.Sizing)
+	}
+	if r.SizeValue != nil {
+		cfg.SizeValue = *r.SizeValue
+	}
+	if r.AllowShort != nil {
+		cfg.AllowShort = *r.AllowShort
+	}
+	return cfg
+}
+
+// RunBacktestRequest describes a backtest over stored candles.
+// Exactly one of Strategy, StrategyID and StrategyDefinition selects what to run.
+type RunBacktestRequest struct {
+	BacktestWindowRequest
+	Strategy           string             `json:"strategy,omitempty"`            // Name of a built-in strategy
+	Params             map[string]float64 `json:"params,omitempty"`              // Parameters of the built-in strategy
+	StrategyID         *uuid.UUID         `json:"strategy_id,omitempty"`         // Stored strategy artifact
+	StrategyDefinition json.RawMessage    `json:"strategy_definition,omitempty"` // Inline strategy DSL
+}
+
+// ValidateStrategyRequest carries a strategy source in JSON or YAML.
+type ValidateStrategyRequest struct {
+	Source string `json:"source" validate:"required"`
+}
+
+// UpdateStrategyRequest creates a new version of a stored strategy.
+type UpdateStrategyRequest struct {
+	Title  *string `json:"title,omitempty"`
+	Source string  `json:"source" validate:"required"`
+}
+
+// --- Response DTOs ---
+
+// ValidateStrategyResponse reports whether a strategy source is valid.
+type ValidateStrategyResponse struct {
+	Valid      bool                   `json:"valid"`
+	Errors     []*strategy.FieldError `json:"errors,omitempty"`
+	Definition *strategy.Definition   `json:"definition,omitempty"`
+}
+
+// StrategyResponse represents one version of a stored strategy.
+type StrategyResponse struct {
+	ID         uuid.UUID            `json:"id"`
+	LineageID  uuid.UUID            `json:"lineage_id"`
+	MessageID  uuid.UUID            `json:"message_id"`
+	Title      string               `json:"title"`
+	Version    int                  `json:"version"`
+	Format     string               `json:"format"`
+	Source     string               `json:"source"`
+	Definition *strategy.Definition `json:"definition,omitempty"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
+// EquityChartSpec is the chart artifact payload: an equity curve with a drawdown series.
+type EquityChartSpec struct {
+	Type   string             `json:"type"`
+	Title  string             `json:"title"`
+	Series []EquityChartSerie `json:"series"`
+}
+
+// EquityChartSerie is a single named series of an equity chart.
+type EquityChartSerie struct {
+	Name   string             `json:"name"`
+	Axis   string             `json:"axis"`
+	Points []EquityChartPoint `json:"points"`
+}
+
+// EquityChartPoint is one point of a chart series.
+type EquityChartPoint struct {
+	Time  time.Time `json:"t"`
+	Value float64   `json:"v"`
+}
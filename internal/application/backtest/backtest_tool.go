@@ -0,0 +1,236 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/backtest"
+	"trading-alchemist/internal/domain/chart"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/domain/strategy"
+)
+
+const (
+	// maxChartPoints caps the number of equity points embedded in the chart artifact.
+	maxChartPoints = 2000
+	// maxReportTrades caps the number of trades listed in the report artifact.
+	maxReportTrades = 50
+)
+
+// RunBacktestTool exposes BacktestUseCase to the LLM as the run_backtest tool.
+type RunBacktestTool struct {
+	useCase *BacktestUseCase
+}
+
+// NewRunBacktestTool creates the run_backtest tool handler.
+func NewRunBacktestTool(useCase *BacktestUseCase) services.ToolHandler {
+	return &RunBacktestTool{useCase: useCase}
+}
+
+// Definition describes the run_backtest tool.
+func (t *RunBacktestTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "run_backtest",
+		Description: "Backtest a trading strategy on stored candles. Provide exactly one of strategy (a built-in), strategy_id (a saved strategy) or strategy_definition (an inline strategy). Returns performance statistics and attaches an equity curve chart and a report document.",
+		Schema:      runBacktestSchema(),
+	}
+}
+
+// RequiredScope reports that backtests need strategies:read.
+func (t *RunBacktestTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourceStrategy, false
+}
+
+// Execute runs the backtest described by the tool arguments.
+func (t *RunBacktestTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var req RunBacktestRequest
+	if err := json.Unmarshal(invocation.Arguments, &req); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	result, err := t.useCase.RunBacktest(ctx, invocation.UserID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	chartArtifact, err := equityChartArtifact(result)
+	if err != nil {
+		return nil, err
+	}
+	svgArtifact, err := equitySVGArtifact(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.ToolResult{
+		Output:    resultSummary(result),
+		Artifacts: []*chat.Artifact{chartArtifact, svgArtifact, reportArtifact(result)},
+	}, nil
+}
+
+// resultSummary is the compact view of a result returned to the model.
+func resultSummary(result *backtest.Result) shared.JSONB {
+	lastTrades := result.Trades
+	if len(lastTrades) > 10 {
+		lastTrades = lastTrades[len(lastTrades)-10:]
+	}
+	return shared.JSONB{
+		"strategy":    result.Strategy,
+		"symbol":      result.Symbol,
+		"start":       result.Start,
+		"end":         result.End,
+		"bars":        result.Bars,
+		"config":      result.Config,
+		"stats":       result.Stats,
+		"last_trades": lastTrades,
+	}
+}
+
+func equityChartArtifact(result *backtest.Result) (*chat.Artifact, error) {
+	points := downsampleEquity(result.Equity, maxChartPoints)
+	equity := make([]EquityChartPoint, len(points))
+	drawdown := make([]EquityChartPoint, len(points))
+	for i, p := range points {
+		equity[i] = EquityChartPoint{Time: p.Time, Value: p.Equity}
+		drawdown[i] = EquityChartPoint{Time: p.Time, Value: -p.Drawdown * 100}
+	}
+
+	spec := EquityChartSpec{
+		Type:  "equity_curve",
+		Title: fmt.Sprintf("%s %s equity", result.Symbol, result.Strategy),
+		Series: []EquityChartSerie{
+			{Name: "Equity", Axis: "left", Points: equity},
+			{Name: "Drawdown %", Axis: "right", Points: drawdown},
+		},
+	}
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal equity chart: %w", err)
+	}
+
+	language := "json"
+	return chat.NewArtifact(spec.Title, shared.ArtifactTypeChart, &language, string(content)), nil
+}
+
+// equitySVGArtifact renders the equity curve with its drawdown shaded, so the
+// chart displays without client-side charting.
+func equitySVGArtifact(result *backtest.Result) (*chat.Artifact, error) {
+	points := downsampleEquity(result.Equity, maxChartPoints)
+	equity := make([]chart.Point, len(points))
+	for i, p := range points {
+		equity[i] = chart.Point{Time: p.Time, Value: p.Equity}
+	}
+
+	spec := &chart.Spec{
+		Type:   chart.TypeEquityCurve,
+		Title:  fmt.Sprintf("%s %s equity", result.Symbol, result.Strategy),
+		Series: []chart.Series{{Name: "Equity", Points: equity}},
+	}
+	svg, err := chart.Render(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render equity chart: %w", err)
+	}
+
+	language := "svg"
+	return chat.NewArtifact(spec.Title, shared.ArtifactTypeChart, &language, svg), nil
+}
+
+// downsampleEquity keeps every n-th point so that at most limit points remain,
+// always including the final point.
+func downsampleEquity(points []backtest.EquityPoint, limit int) []backtest.EquityPoint {
+	if len(points) <= limit {
+		return points
+	}
+	step := (len(points) + limit - 1) / limit
+	sampled := make([]backtest.EquityPoint, 0, limit+1)
+	for i := 0; i < len(points); i += step {
+		sampled = append(sampled, points[i])
+	}
+	if last := points[len(points)-1]; sampled[len(sampled)-1].Time != last.Time {
+		sampled = append(sampled, last)
+	}
+	return sampled
+}
+
+func reportArtifact(result *backtest.Result) *chat.Artifact {
+	s := result.Stats
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Backtest: %s on %s\n\n", result.Strategy, result.Symbol)
+	fmt.Fprintf(&b, "Period: %s to %s (%d bars)\n\n", result.Start.Format("2006-01-02 15:04"), result.End.Format("2006-01-02 15:04"), result.Bars)
+
+	b.WriteString("## Assumptions\n\n")
+	fmt.Fprintf(&b, "- Initial capital: %.2f\n", result.Config.InitialCapital)
+	fmt.Fprintf(&b, "- Commission: %.4f%% + %.2f per fill\n", result.Config.CommissionRate*100, result.Config.CommissionFixed)
+	fmt.Fprintf(&b, "- Slippage: %.1f bps\n", result.Config.SlippageBps)
+	fmt.Fprintf(&b, "- Sizing: %s (%g)\n", result.Config.Sizing, result.Config.SizeValue)
+	fmt.Fprintf(&b, "- Short selling: %t\n\n", result.Config.AllowShort)
+
+	b.WriteString("## Performance\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Final equity | %.2f |\n", s.FinalEquity)
+	fmt.Fprintf(&b, "| Total return | %.2f%% |\n", s.TotalReturn*100)
+	fmt.Fprintf(&b, "| CAGR | %.2f%% |\n", s.CAGR*100)
+	fmt.Fprintf(&b, "| Sharpe | %.2f |\n", s.Sharpe)
+	fmt.Fprintf(&b, "| Sortino | %.2f |\n", s.Sortino)
+	fmt.Fprintf(&b, "| Max drawdown | %.2f%% |\n", s.MaxDrawdown*100)
+	fmt.Fprintf(&b, "| Trades | %d |\n", s.TradeCount)
+	fmt.Fprintf(&b, "| Win rate | %.2f%% |\n", s.WinRate*100)
+	fmt.Fprintf(&b, "| Profit factor | %.2f |\n", s.ProfitFactor)
+	fmt.Fprintf(&b, "| Exposure | %.2f%% |\n", s.Exposure*100)
+	fmt.Fprintf(&b, "| Commission paid | %.2f |\n\n", s.TotalCommission)
+
+	if len(result.Trades) > 0 {
+		b.WriteString("## Trades\n\n")
+		b.WriteString("| Side | Entry | Entry price | Exit | Exit price | Qty | P&L | Return | Exit reason |\n")
+		b.WriteString("|---|---|---|---|---|---|---|---|---|\n")
+		trades := result.Trades
+		if len(trades) > maxReportTrades {
+			fmt.Fprintf(&b, "_Showing the last %d of %d trades._\n\n", maxReportTrades, len(trades))
+			trades = trades[len(trades)-maxReportTrades:]
+		}
+		for _, tr := range trades {
+			fmt.Fprintf(&b, "| %s | %s | %.4f | %s | %.4f | %.4f | %.2f | %.2f%% | %s |\n",
+				tr.Side, tr.EntryTime.Format("2006-01-02 15:04"), tr.EntryPrice,
+				tr.ExitTime.Format("2006-01-02 15:04"), tr.ExitPrice, tr.Quantity, tr.PnL, tr.ReturnPct, tr.ExitReason)
+		}
+	}
+
+	language := "markdown"
+	return chat.NewArtifact(fmt.Sprintf("Backtest report: %s %s", result.Symbol, result.Strategy), shared.ArtifactTypeDocument, &language, b.String())
+}
+
+func runBacktestSchema() shared.JSONB {
+	return shared.JSONB{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"symbol":              map[string]interface{}{"type": "string", "description": "Instrument symbol, e.g. BTCUSDT"},
+			"timeframe":           map[string]interface{}{"type": "string", "enum": []string{"1m", "5m", "15m", "30m", "1h", "4h", "1d", "1w"}},
+			"start":               map[string]interface{}{"type": "string", "format": "date-time", "description": "RFC 3339 start of the test window"},
+			"end":                 map[string]interface{}{"type": "string", "format": "date-time", "description": "RFC 3339 end of the test window (exclusive)"},
+			"strategy":            map[string]interface{}{"type": "string", "enum": backtest.BuiltinStrategyNames(), "description": "Built-in strategy name"},
+			"strategy_id":         map[string]interface{}{"type": "string", "format": "uuid", "description": "ID of a strategy saved with save_strategy"},
+			"strategy_definition": map[string]interface{}{"$ref": "#/$defs/strategy"},
+			"params": map[string]interface{}{
+				"type":                 "object",
+				"description":          "Strategy parameters: sma_crossover {fast, slow}; rsi_reversion {period, oversold, overbought}",
+				"additionalProperties": map[string]interface{}{"type": "number"},
+			},
+			"initial_capital":  map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "default": 10000},
+			"commission_rate":  map[string]interface{}{"type": "number", "minimum": 0, "description": "Fraction of notional per fill, e.g. 0.001"},
+			"commission_fixed": map[string]interface{}{"type": "number", "minimum": 0, "description": "Flat fee per fill"},
+			"slippage_bps":     map[string]interface{}{"type": "number", "minimum": 0},
+			"sizing":           map[string]interface{}{"type": "string", "enum": []string{"percent_equity", "fixed_notional", "fixed_quantity"}, "default": "percent_equity"},
+			"size_value":       map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "default": 100},
+			"allow_short":      map[string]interface{}{"type": "boolean", "default": false},
+			"adjustment":       map[string]interface{}{"type": "string", "enum": []string{"none", "splits", "all"}, "default": "none", "description": "Adjust prices for splits, or for splits and dividends, recorded in the events calendar"},
+		},
+		"required": []string{"symbol", "timeframe", "start", "end"},
+		"$defs":    strategy.SchemaDefinitions(),
+	}
+}
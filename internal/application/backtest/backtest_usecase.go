@@ -0,0 +1,144 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"trading-alchemist/internal/domain/backtest"
+	"trading-alchemist/internal/domain/calendar"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/strategy"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// maxBacktestBars caps the number of candles loaded for a single run.
+const maxBacktestBars = 100000
+
+// BacktestUseCase runs strategies against stored market data.
+type BacktestUseCase struct {
+	dbService *database.Service
+}
+
+// NewBacktestUseCase creates a new BacktestUseCase instance.
+func NewBacktestUseCase(dbService *database.Service) *BacktestUseCase {
+	return &BacktestUseCase{
+		dbService: dbService,
+	}
+}
+
+// RunBacktest resolves the requested strategy and simulates it over stored candles.
+func (uc *BacktestUseCase) RunBacktest(ctx context.Context, userID uuid.UUID, req *RunBacktestRequest) (*backtest.Result, error) {
+	s, err := uc.resolveStrategy(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	return uc.RunStrategy(ctx, userID, &req.BacktestWindowRequest, s)
+}
+
+// RunStrategy simulates an already constructed strategy over the requested
+// candles, adjusted for the corporate actions in the user's calendar if requested.
+func (uc *BacktestUseCase) RunStrategy(ctx context.Context, userID uuid.UUID, req *BacktestWindowRequest, s backtest.Strategy) (*backtest.Result, error) {
+	timeframe, err := market.ParseTimeframe(req.Timeframe)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	if !req.End.After(req.Start) {
+		return nil, errors.NewAppError(errors.CodeValidation, "end must be after start", nil)
+	}
+	cfg := req.Config()
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	adjustment, err := calendar.ParseAdjustment(req.Adjustment)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var candles []*market.Candle
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		candles, err = provider.Candle().GetRange(ctx, req.Symbol, timeframe, req.Start, req.End, maxBacktestBars)
+		if err != nil {
+			return fmt.Errorf("failed to load candles: %w", err)
+		}
+		candles, err = adjustCandles(ctx, provider, userID, req.Symbol, candles, adjustment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := backtest.Run(candles, s, cfg)
+	if err != nil {
+		if err == backtest.ErrInsufficientData {
+			return nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("not enough %s candles for %s between %s and %s", timeframe, req.Symbol, req.Start.Format("2006-01-02"), req.End.Format("2006-01-02")), err)
+		}
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	return result, nil
+}
+
+// adjustCandles applies the symbol's splits and, for AdjustmentAll, dividends
+// from the calendar to the candles.
+func adjustCandles(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID, symbol string, candles []*market.Candle, adjustment calendar.Adjustment) ([]*market.Candle, error) {
+	if adjustment == calendar.AdjustmentNone || len(candles) == 0 {
+		return candles, nil
+	}
+	actions, err := provider.CalendarEvent().List(ctx, userID, calendar.EventFilter{
+		Symbols: []string{strings.ToUpper(symbol)},
+		Types:   []calendar.EventType{calendar.EventTypeSplit, calendar.EventTypeDividend},
+	}, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load corporate actions: %w", err)
+	}
+	return calendar.AdjustCandles(candles, actions, adjustment), nil
+}
+
+// resolveStrategy builds the strategy selected by the request: a built-in by name,
+// a stored strategy artifact owned by the user, or an inline DSL definition.
+func (uc *BacktestUseCase) resolveStrategy(ctx context.Context, userID uuid.UUID, req *RunBacktestRequest) (backtest.Strategy, error) {
+	selected := 0
+	for _, set := range []bool{req.Strategy != "", req.StrategyID != nil, len(req.StrategyDefinition) > 0} {
+		if set {
+			selected++
+		}
+	}
+	if selected != 1 {
+		return nil, errors.NewAppError(errors.CodeValidation, "exactly one of strategy, strategy_id or strategy_definition must be provided", nil)
+	}
+
+	switch {
+	case req.Strategy != "":
+		s, err := backtest.NewBuiltinStrategy(req.Strategy, req.Params)
+		if err != nil {
+			return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		return s, nil
+
+	case req.StrategyID != nil:
+		var def *strategy.Definition
+		err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+			artifact, err := loadStrategyArtifact(ctx, provider, userID, *req.StrategyID)
+			if err != nil {
+				return err
+			}
+			def, err = parseStrategy(artifact.Content)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return strategy.Compile(def), nil
+
+	default:
+		def, err := parseStrategy(string(req.StrategyDefinition))
+		if err != nil {
+			return nil, err
+		}
+		return strategy.Compile(def), nil
+	}
+}
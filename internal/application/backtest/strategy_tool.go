@@ -0,0 +1,104 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/domain/strategy"
+	"trading-alchemist/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+)
+
+// saveStrategyArgs are the arguments of the save_strategy tool.
+type saveStrategyArgs struct {
+	StrategyID *uuid.UUID      `json:"strategy_id,omitempty"`
+	Definition json.RawMessage `json:"definition"`
+}
+
+// SaveStrategyTool lets the LLM store a strategy DSL definition as a versioned artifact.
+type SaveStrategyTool struct {
+	dbService *database.Service
+}
+
+// NewSaveStrategyTool creates the save_strategy tool handler.
+func NewSaveStrategyTool(dbService *database.Service) services.ToolHandler {
+	return &SaveStrategyTool{dbService: dbService}
+}
+
+// Definition describes the save_strategy tool.
+func (t *SaveStrategyTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "save_strategy",
+		Description: "Validate and save a declarative trading strategy. Pass strategy_id to store the definition as a new version of an existing strategy. Returns the saved strategy_id for use with run_backtest.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"strategy_id": map[string]interface{}{"type": "string", "format": "uuid", "description": "Existing strategy to create a new version of"},
+				"definition":  map[string]interface{}{"$ref": "#/$defs/strategy"},
+			},
+			"required": []string{"definition"},
+			"$defs":    strategy.SchemaDefinitions(),
+		},
+	}
+}
+
+// RequiredScope reports that saving strategies needs strategies:write.
+func (t *SaveStrategyTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourceStrategy, true
+}
+
+// Execute validates the definition and stores it on the requesting assistant message.
+func (t *SaveStrategyTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var args saveStrategyArgs
+	if err := json.Unmarshal(invocation.Arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(args.Definition) == 0 {
+		return nil, fmt.Errorf("definition is required")
+	}
+
+	def, err := strategy.Parse(args.Definition)
+	if err != nil {
+		return nil, err
+	}
+	// Store the canonical form so later edits start from a normalized document.
+	source, err := json.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode strategy: %w", err)
+	}
+
+	var saved *chat.Artifact
+	err = t.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if args.StrategyID != nil {
+			saved, err = saveStrategyVersion(ctx, provider, invocation.UserID, *args.StrategyID, &def.Name, string(source))
+			return err
+		}
+
+		format := strategy.FormatJSON
+		artifact := chat.NewArtifact(def.Name, shared.ArtifactTypeStrategy, &format, string(source))
+		artifact.MessageID = invocation.MessageID
+		saved, err = provider.Artifact().Create(ctx, artifact)
+		if err != nil {
+			return fmt.Errorf("failed to save strategy: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.ToolResult{
+		Output: shared.JSONB{
+			"strategy_id": saved.ID,
+			"lineage_id":  saved.LineageID(),
+			"version":     saved.Version,
+			"name":        def.Name,
+		},
+	}, nil
+}
@@ -0,0 +1,205 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/backtest"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/domain/strategy"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// StrategyUseCase manages strategies stored as versioned artifacts.
+type StrategyUseCase struct {
+	dbService       *database.Service
+	backtestUseCase *BacktestUseCase
+}
+
+// NewStrategyUseCase creates a new StrategyUseCase instance.
+func NewStrategyUseCase(dbService *database.Service, backtestUseCase *BacktestUseCase) *StrategyUseCase {
+	return &StrategyUseCase{
+		dbService:       dbService,
+		backtestUseCase: backtestUseCase,
+	}
+}
+
+// ValidateStrategy parses a strategy source and reports every problem found.
+func (uc *StrategyUseCase) ValidateStrategy(ctx context.Context, req *ValidateStrategyRequest) (*ValidateStrategyResponse, error) {
+	def, err := strategy.Parse([]byte(req.Source))
+	if err != nil {
+		if verr, ok := err.(*strategy.ValidationError); ok {
+			return &ValidateStrategyResponse{Valid: false, Errors: verr.Errors}, nil
+		}
+		return nil, err
+	}
+	return &ValidateStrategyResponse{Valid: true, Definition: def}, nil
+}
+
+// ListStrategies returns the latest version of each of the user's strategies.
+func (uc *StrategyUseCase) ListStrategies(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*StrategyResponse, error) {
+	var artifacts []*chat.Artifact
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		artifacts, err = provider.Artifact().ListLatestByUserAndType(ctx, userID, shared.ArtifactTypeStrategy, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list strategies: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*StrategyResponse, len(artifacts))
+	for i, a := range artifacts {
+		response[i] = toStrategyResponse(a)
+	}
+	return response, nil
+}
+
+// GetStrategy returns a single strategy version.
+func (uc *StrategyUseCase) GetStrategy(ctx context.Context, userID, strategyID uuid.UUID) (*StrategyResponse, error) {
+	var artifact *chat.Artifact
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		artifact, err = loadStrategyArtifact(ctx, provider, userID, strategyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toStrategyResponse(artifact), nil
+}
+
+// GetStrategyVersions returns every version of the strategy, oldest first.
+func (uc *StrategyUseCase) GetStrategyVersions(ctx context.Context, userID, strategyID uuid.UUID) ([]*StrategyResponse, error) {
+	var versions []*chat.Artifact
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		artifact, err := loadStrategyArtifact(ctx, provider, userID, strategyID)
+		if err != nil {
+			return err
+		}
+		versions, err = provider.Artifact().GetVersions(ctx, artifact.LineageID())
+		if err != nil {
+			return fmt.Errorf("failed to get strategy versions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*StrategyResponse, len(versions))
+	for i, v := range versions {
+		response[i] = toStrategyResponse(v)
+	}
+	return response, nil
+}
+
+// UpdateStrategy validates the new source and stores it as the next version.
+// Earlier versions are kept so past backtests remain reproducible.
+func (uc *StrategyUseCase) UpdateStrategy(ctx context.Context, userID, strategyID uuid.UUID, req *UpdateStrategyRequest) (*StrategyResponse, error) {
+	if _, err := parseStrategy(req.Source); err != nil {
+		return nil, err
+	}
+
+	var created *chat.Artifact
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		created, err = saveStrategyVersion(ctx, provider, userID, strategyID, req.Title, req.Source)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toStrategyResponse(created), nil
+}
+
+// RunStrategy backtests a stored strategy version.
+func (uc *StrategyUseCase) RunStrategy(ctx context.Context, userID, strategyID uuid.UUID, req *BacktestWindowRequest) (*backtest.Result, error) {
+	return uc.backtestUseCase.RunBacktest(ctx, userID, &RunBacktestRequest{
+		BacktestWindowRequest: *req,
+		StrategyID:            &strategyID,
+	})
+}
+
+// saveStrategyVersion appends a new version to the lineage of strategyID.
+func saveStrategyVersion(ctx context.Context, provider database.RepositoryProvider, userID, strategyID uuid.UUID, title *string, source string) (*chat.Artifact, error) {
+	base, err := loadStrategyArtifact(ctx, provider, userID, strategyID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := provider.Artifact().GetVersions(ctx, base.LineageID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strategy versions: %w", err)
+	}
+
+	newTitle := versions[len(versions)-1].Title
+	if title != nil && *title != "" {
+		newTitle = *title
+	}
+	next := base.NewVersion(versions[len(versions)-1], newTitle, source)
+	format := strategy.DetectFormat([]byte(source))
+	next.Language = &format
+
+	created, err := provider.Artifact().Create(ctx, next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save strategy version: %w", err)
+	}
+	return created, nil
+}
+
+// loadStrategyArtifact fetches a strategy artifact and checks that the user owns it.
+func loadStrategyArtifact(ctx context.Context, provider database.RepositoryProvider, userID, id uuid.UUID) (*chat.Artifact, error) {
+	artifact, err := provider.Artifact().GetByID(ctx, id)
+	if err != nil {
+		if err == errors.ErrArtifactNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Strategy not found", err)
+		}
+		return nil, fmt.Errorf("failed to get strategy: %w", err)
+	}
+	if artifact.Type != shared.ArtifactTypeStrategy {
+		return nil, errors.NewAppError(errors.CodeNotFound, "Strategy not found", nil)
+	}
+
+	ownerID, err := provider.Artifact().GetOwnerID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get strategy owner: %w", err)
+	}
+	if ownerID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return artifact, nil
+}
+
+// parseStrategy parses a DSL source, converting validation failures into application errors.
+func parseStrategy(source string) (*strategy.Definition, error) {
+	def, err := strategy.Parse([]byte(source))
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	return def, nil
+}
+
+func toStrategyResponse(a *chat.Artifact) *StrategyResponse {
+	resp := &StrategyResponse{
+		ID:        a.ID,
+		LineageID: a.LineageID(),
+		MessageID: a.MessageID,
+		Title:     a.Title,
+		Version:   a.Version,
+		Format:    strategy.DetectFormat([]byte(a.Content)),
+		Source:    a.Content,
+		CreatedAt: a.CreatedAt,
+	}
+	// Stored sources were validated on save, but the DSL may have evolved since.
+	if def, err := strategy.Parse([]byte(a.Content)); err == nil {
+		resp.Definition = def
+	}
+	return resp
+}
@@ -0,0 +1,113 @@
+package broker
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/broker"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateConnectionRequest represents the data needed to connect a broker account.
+// Simulator connections take the optional initial cash, commission rate and
+// currency; REST connections require an API key and api_base_override.
+type CreateConnectionRequest struct {
+	Name            string   `json:"name" validate:"required,min=1,max=255"`
+	Kind            string   `json:"kind" validate:"required,oneof=simulator rest"`
+	APIKey          string   `json:"api_key,omitempty"`
+	APISecret       string   `json:"api_secret,omitempty"`
+	APIBaseOverride *string  `json:"api_base_override,omitempty" validate:"omitempty,url"`
+	InitialCash     *float64 `json:"initial_cash,omitempty"`
+	CommissionRate  *float64 `json:"commission_rate,omitempty"`
+	Currency        *string  `json:"currency,omitempty"`
+}
+
+// UpdateConnectionRequest updates a broker connection. Credentials are only
+// replaced when a new API key is given. Changing a simulator's settings resets it.
+type UpdateConnectionRequest struct {
+	Name            *string  `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	APIKey          string   `json:"api_key,omitempty"`
+	APISecret       string   `json:"api_secret,omitempty"`
+	APIBaseOverride *string  `json:"api_base_override,omitempty" validate:"omitempty,url"`
+	InitialCash     *float64 `json:"initial_cash,omitempty"`
+	CommissionRate  *float64 `json:"commission_rate,omitempty"`
+	Currency        *string  `json:"currency,omitempty"`
+	IsActive        *bool    `json:"is_active,omitempty"`
+}
+
+// PlaceOrderRequest represents an order to route to a broker.
+type PlaceOrderRequest struct {
+	ClientOrderID string   `json:"client_order_id,omitempty"` // Makes retries idempotent
+	Symbol        string   `json:"symbol" validate:"required"`
+	Side          string   `json:"side" validate:"required,oneof=buy sell"`
+	Type          string   `json:"type" validate:"required,oneof=market limit stop"`
+	Quantity      float64  `json:"quantity" validate:"required,gt=0"`
+	LimitPrice    *float64 `json:"limit_price,omitempty"`
+	StopPrice     *float64 `json:"stop_price,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// ConnectionResponse represents a broker connection. Credentials are never returned.
+type ConnectionResponse struct {
+	ID              uuid.UUID    `json:"id"`
+	Name            string       `json:"name"`
+	Kind            broker.Kind  `json:"kind"`
+	HasCredentials  bool         `json:"has_credentials"`
+	APIBaseOverride *string      `json:"api_base_override,omitempty"`
+	Settings        shared.JSONB `json:"settings"`
+	IsActive        bool         `json:"is_active"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// ToConnectionResponse converts a broker connection to its response.
+func ToConnectionResponse(c *broker.Connection) *ConnectionResponse {
+	return &ConnectionResponse{
+		ID:              c.ID,
+		Name:            c.Name,
+		Kind:            c.Kind,
+		HasCredentials:  c.EncryptedCredentials != nil,
+		APIBaseOverride: c.APIBaseOverride,
+		Settings:        c.Settings,
+		IsActive:        c.IsActive,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+// AccountResponse represents the account balance reported by a broker.
+type AccountResponse struct {
+	broker.Account
+}
+
+// PositionResponse represents a position reported by a broker.
+type PositionResponse struct {
+	broker.Position
+}
+
+// OrderResponse represents an order as reported by a broker.
+type OrderResponse struct {
+	broker.Order
+}
+
+// ToPositionResponses converts broker positions to their responses.
+func ToPositionResponses(positions []*broker.Position) []*PositionResponse {
+	responses := make([]*PositionResponse, len(positions))
+	for i, p := range positions {
+		responses[i] = &PositionResponse{Position: *p}
+	}
+	return responses
+}
+
+// ToOrderResponses converts broker orders to their responses.
+func ToOrderResponses(orders []*broker.Order) []*OrderResponse {
+	responses := make([]*OrderResponse, len(orders))
+	for i, o := range orders {
+		responses[i] = &OrderResponse{Order: *o}
+	}
+	return responses
+}
@@ -0,0 +1,447 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/broker"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/brokerage"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultOrderLimit = 50
+	maxOrderLimit     = 500
+)
+
+// BrokerUseCase manages a user's broker connections and routes orders to them.
+// Brokers are created lazily and kept for the lifetime of the process, so the
+// state of simulator connections persists until the connection is changed.
+type BrokerUseCase struct {
+	dbService *database.Service
+	config    *config.Config
+	prices    broker.PriceSource
+
+	mu      sync.Mutex
+	brokers map[uuid.UUID]broker.Broker
+}
+
+// NewBrokerUseCase creates a new BrokerUseCase instance.
+func NewBrokerUseCase(dbService *database.Service, config *config.Config) *BrokerUseCase {
+	return &BrokerUseCase{
+		dbService: dbService,
+		config:    config,
+		prices:    &candlePriceSource{dbService: dbService},
+		brokers:   make(map[uuid.UUID]broker.Broker),
+	}
+}
+
+// CreateConnection stores a new broker connection with its credentials encrypted.
+func (uc *BrokerUseCase) CreateConnection(ctx context.Context, userID uuid.UUID, req *CreateConnectionRequest) (*ConnectionResponse, error) {
+	conn := &broker.Connection{
+		UserID:          userID,
+		Name:            strings.TrimSpace(req.Name),
+		Kind:            broker.Kind(req.Kind),
+		APIBaseOverride: req.APIBaseOverride,
+		Settings:        shared.JSONB{},
+		IsActive:        true,
+	}
+	if conn.Kind == broker.KindSimulator {
+		applySimulatorSettings(conn, req.Currency, req.InitialCash, req.CommissionRate)
+	}
+	if req.APIKey != "" {
+		encrypted, err := uc.encryptCredentials(req.APIKey, req.APISecret)
+		if err != nil {
+			return nil, err
+		}
+		conn.EncryptedCredentials = &encrypted
+	}
+	if err := conn.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var created *broker.Connection
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if err := checkConnectionName(ctx, provider, userID, uuid.Nil, conn.Name); err != nil {
+			return err
+		}
+		var err error
+		created, err = provider.BrokerConnection().Create(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("failed to create broker connection: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToConnectionResponse(created), nil
+}
+
+// ListConnections returns the user's broker connections.
+func (uc *BrokerUseCase) ListConnections(ctx context.Context, userID uuid.UUID) ([]*ConnectionResponse, error) {
+	var conns []*broker.Connection
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		conns, err = provider.BrokerConnection().GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list broker connections: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*ConnectionResponse, len(conns))
+	for i, c := range conns {
+		responses[i] = ToConnectionResponse(c)
+	}
+	return responses, nil
+}
+
+// GetConnection returns one of the user's broker connections.
+func (uc *BrokerUseCase) GetConnection(ctx context.Context, userID, connectionID uuid.UUID) (*ConnectionResponse, error) {
+	var conn *broker.Connection
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		conn, err = loadConnection(ctx, provider, userID, connectionID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToConnectionResponse(conn), nil
+}
+
+// UpdateConnection changes a broker connection. The cached broker is dropped,
+// which resets the state of a simulator connection.
+func (uc *BrokerUseCase) UpdateConnection(ctx context.Context, userID, connectionID uuid.UUID, req *UpdateConnectionRequest) (*ConnectionResponse, error) {
+	var updated *broker.Connection
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		conn, err := loadConnection(ctx, provider, userID, connectionID)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			conn.Name = strings.TrimSpace(*req.Name)
+			if err := checkConnectionName(ctx, provider, userID, conn.ID, conn.Name); err != nil {
+				return err
+			}
+		}
+		if req.APIKey != "" {
+			// Only replace the credentials if a new key is provided
+			encrypted, err := uc.encryptCredentials(req.APIKey, req.APISecret)
+			if err != nil {
+				return err
+			}
+			conn.EncryptedCredentials = &encrypted
+		}
+		if req.APIBaseOverride != nil {
+			conn.APIBaseOverride = req.APIBaseOverride
+		}
+		if conn.Kind == broker.KindSimulator {
+			applySimulatorSettings(conn, req.Currency, req.InitialCash, req.CommissionRate)
+		}
+		if req.IsActive != nil {
+			conn.IsActive = *req.IsActive
+		}
+		if err := conn.Validate(); err != nil {
+			return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+
+		updated, err = provider.BrokerConnection().Update(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("failed to update broker connection: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.evict(connectionID)
+	return ToConnectionResponse(updated), nil
+}
+
+// DeleteConnection removes a broker connection. Orders already at a real
+// broker are not cancelled.
+func (uc *BrokerUseCase) DeleteConnection(ctx context.Context, userID, connectionID uuid.UUID) error {
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadConnection(ctx, provider, userID, connectionID); err != nil {
+			return err
+		}
+		if err := provider.BrokerConnection().Delete(ctx, connectionID); err != nil {
+			return fmt.Errorf("failed to delete broker connection: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.evict(connectionID)
+	return nil
+}
+
+// GetAccount returns the account balance at the broker.
+func (uc *BrokerUseCase) GetAccount(ctx context.Context, userID, connectionID uuid.UUID) (*AccountResponse, error) {
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := b.GetAccount(ctx)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return &AccountResponse{Account: *account}, nil
+}
+
+// GetPositions returns the positions held at the broker.
+func (uc *BrokerUseCase) GetPositions(ctx context.Context, userID, connectionID uuid.UUID) ([]*PositionResponse, error) {
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := b.GetPositions(ctx)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return ToPositionResponses(positions), nil
+}
+
+// ListOrders returns the orders known to the broker, newest first.
+func (uc *BrokerUseCase) ListOrders(ctx context.Context, userID, connectionID uuid.UUID, scope, symbol string, limit int) ([]*OrderResponse, error) {
+	filter := broker.OrderFilter{Scope: broker.OrderScope(scope), Symbol: strings.ToUpper(symbol), Limit: limit}
+	switch filter.Scope {
+	case "":
+		filter.Scope = broker.OrderScopeAll
+	case broker.OrderScopeOpen, broker.OrderScopeClosed, broker.OrderScopeAll:
+	default:
+		return nil, errors.NewAppError(errors.CodeValidation, "scope must be open, closed or all", nil)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrderLimit
+	}
+	if filter.Limit > maxOrderLimit {
+		filter.Limit = maxOrderLimit
+	}
+
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := b.ListOrders(ctx, filter)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return ToOrderResponses(orders), nil
+}
+
+// GetOrder returns one order from the broker.
+func (uc *BrokerUseCase) GetOrder(ctx context.Context, userID, connectionID uuid.UUID, orderID string) (*OrderResponse, error) {
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := b.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return &OrderResponse{Order: *order}, nil
+}
+
+// PlaceOrder submits an order to the broker. Orders the broker refuses are
+// returned with status rejected.
+func (uc *BrokerUseCase) PlaceOrder(ctx context.Context, userID, connectionID uuid.UUID, req *PlaceOrderRequest) (*OrderResponse, error) {
+	orderReq := &broker.OrderRequest{
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        strings.ToUpper(strings.TrimSpace(req.Symbol)),
+		Side:          broker.Side(req.Side),
+		Type:          broker.OrderType(req.Type),
+		Quantity:      req.Quantity,
+		LimitPrice:    req.LimitPrice,
+		StopPrice:     req.StopPrice,
+	}
+	if err := orderReq.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := b.PlaceOrder(ctx, orderReq)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return &OrderResponse{Order: *order}, nil
+}
+
+// CancelOrder cancels an open order at the broker.
+func (uc *BrokerUseCase) CancelOrder(ctx context.Context, userID, connectionID uuid.UUID, orderID string) (*OrderResponse, error) {
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := b.CancelOrder(ctx, orderID)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return &OrderResponse{Order: *order}, nil
+}
+
+// StreamOrderUpdates delivers order status changes until ctx is done.
+func (uc *BrokerUseCase) StreamOrderUpdates(ctx context.Context, userID, connectionID uuid.UUID) (<-chan broker.OrderUpdate, error) {
+	b, err := uc.brokerFor(ctx, userID, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := b.StreamOrderUpdates(ctx)
+	if err != nil {
+		return nil, mapBrokerError(err)
+	}
+	return updates, nil
+}
+
+// brokerFor checks the connection belongs to the user and returns its broker,
+// creating it on first use.
+func (uc *BrokerUseCase) brokerFor(ctx context.Context, userID, connectionID uuid.UUID) (broker.Broker, error) {
+	var conn *broker.Connection
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		conn, err = loadConnection(ctx, provider, userID, connectionID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !conn.IsActive {
+		return nil, errors.NewAppError(errors.CodeValidation, "Broker connection is inactive", nil)
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if b, ok := uc.brokers[conn.ID]; ok {
+		return b, nil
+	}
+
+	credentials, err := uc.decryptCredentials(conn)
+	if err != nil {
+		return nil, err
+	}
+	b, err := brokerage.NewBroker(conn, credentials, uc.prices)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("Broker connection is misconfigured: %v", err), err)
+	}
+	uc.brokers[conn.ID] = b
+	return b, nil
+}
+
+func (uc *BrokerUseCase) evict(connectionID uuid.UUID) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	delete(uc.brokers, connectionID)
+}
+
+func (uc *BrokerUseCase) encryptCredentials(apiKey, apiSecret string) (string, error) {
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return "", fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	plaintext, err := json.Marshal(&broker.Credentials{APIKey: apiKey, APISecret: apiSecret})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	encrypted, err := keyring.Encrypt(string(plaintext))
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	return encrypted, nil
+}
+
+func (uc *BrokerUseCase) decryptCredentials(conn *broker.Connection) (*broker.Credentials, error) {
+	if conn.EncryptedCredentials == nil {
+		return nil, nil
+	}
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	plaintext, err := keyring.Decrypt(*conn.EncryptedCredentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials: %w", err)
+	}
+	var credentials broker.Credentials
+	if err := json.Unmarshal([]byte(plaintext), &credentials); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+	return &credentials, nil
+}
+
+// applySimulatorSettings copies the given simulator settings onto the connection.
+func applySimulatorSettings(conn *broker.Connection, currency *string, initialCash, commissionRate *float64) {
+	if conn.Settings == nil {
+		conn.Settings = shared.JSONB{}
+	}
+	if currency != nil {
+		conn.Settings["currency"] = strings.ToUpper(*currency)
+	}
+	if initialCash != nil {
+		conn.Settings["initial_cash"] = *initialCash
+	}
+	if commissionRate != nil {
+		conn.Settings["commission_rate"] = *commissionRate
+	}
+}
+
+// loadConnection checks that the broker connection exists and belongs to the user.
+func loadConnection(ctx context.Context, provider database.RepositoryProvider, userID, connectionID uuid.UUID) (*broker.Connection, error) {
+	conn, err := provider.BrokerConnection().GetByID(ctx, connectionID)
+	if err != nil {
+		if err == errors.ErrBrokerConnectionNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Broker connection not found", err)
+		}
+		return nil, fmt.Errorf("failed to get broker connection: %w", err)
+	}
+	if conn.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return conn, nil
+}
+
+// checkConnectionName rejects a name already used by another of the user's connections.
+func checkConnectionName(ctx context.Context, provider database.RepositoryProvider, userID, connectionID uuid.UUID, name string) error {
+	conns, err := provider.BrokerConnection().GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list broker connections: %w", err)
+	}
+	for _, c := range conns {
+		if c.ID != connectionID && strings.EqualFold(c.Name, name) {
+			return errors.NewAppError(errors.CodeConflict, fmt.Sprintf("A broker connection named %q already exists", name), nil)
+		}
+	}
+	return nil
+}
+
+// mapBrokerError converts the broker's sentinel errors to application errors.
+func mapBrokerError(err error) error {
+	switch err {
+	case broker.ErrOrderNotFound:
+		return errors.NewAppError(errors.CodeNotFound, "Order not found", err)
+	case broker.ErrOrderNotCancellable:
+		return errors.NewAppError(errors.CodeConflict, "Order is no longer open", err)
+	case broker.ErrNoPrice:
+		return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	return fmt.Errorf("broker request failed: %w", err)
+}
@@ -0,0 +1,44 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/broker"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/infrastructure/database"
+)
+
+// priceTimeframes are searched for the latest close, finest first. A coarser
+// timeframe is used only if its close is more recent.
+var priceTimeframes = []market.Timeframe{market.Timeframe1m, market.Timeframe1h, market.Timeframe1d}
+
+// candlePriceSource prices symbols at the close of the most recent stored candle.
+type candlePriceSource struct {
+	dbService *database.Service
+}
+
+func (s *candlePriceSource) LastPrice(ctx context.Context, symbol string) (float64, error) {
+	var price float64
+	var latest time.Time
+	err := s.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		for _, tf := range priceTimeframes {
+			candles, err := provider.Candle().GetLatest(ctx, symbol, tf, 1)
+			if err != nil {
+				return fmt.Errorf("failed to load %s candles: %w", symbol, err)
+			}
+			if len(candles) == 1 && candles[0].CloseTime().After(latest) {
+				price, latest = candles[0].Close, candles[0].CloseTime()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if latest.IsZero() {
+		return 0, broker.ErrNoPrice
+	}
+	return price, nil
+}
@@ -0,0 +1,98 @@
+package calendar
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/calendar"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateEventRequest represents an event the user adds to their calendar.
+// Dividends need an amount per share and splits a ratio of new to old shares.
+type CreateEventRequest struct {
+	Type        string    `json:"type" validate:"required,oneof=earnings macro dividend split"`
+	Symbol      *string   `json:"symbol,omitempty"`
+	Title       string    `json:"title" validate:"required,max=255"`
+	Description *string   `json:"description,omitempty"`
+	ScheduledAt time.Time `json:"scheduled_at" validate:"required"`
+	AllDay      bool      `json:"all_day,omitempty"`
+	Importance  string    `json:"importance,omitempty" validate:"omitempty,oneof=low medium high"`
+	Amount      *float64  `json:"amount,omitempty"`
+	Ratio       *float64  `json:"ratio,omitempty"`
+}
+
+// ListEventsRequest filters the calendar. All fields are optional.
+type ListEventsRequest struct {
+	Start   *time.Time
+	End     *time.Time
+	Symbols []string
+	Types   []string
+	Limit   int
+	Offset  int
+}
+
+// UpcomingEventsRequest selects the events of the next days.
+type UpcomingEventsRequest struct {
+	Symbols []string `json:"symbols,omitempty"`
+	Types   []string `json:"types,omitempty"`
+	Days    int      `json:"days,omitempty"` // Defaults to 14
+}
+
+// --- Response DTOs ---
+
+// EventResponse represents a calendar event. Shared events come from the
+// platform's calendar and cannot be deleted by the user.
+type EventResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Type        string    `json:"type"`
+	Symbol      *string   `json:"symbol,omitempty"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description,omitempty"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	AllDay      bool      `json:"all_day"`
+	Importance  string    `json:"importance"`
+	Amount      *float64  `json:"amount,omitempty"`
+	Ratio       *float64  `json:"ratio,omitempty"`
+	Source      string    `json:"source"`
+	Shared      bool      `json:"shared"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToEventResponse converts a calendar event to its response.
+func ToEventResponse(e *calendar.Event) *EventResponse {
+	return &EventResponse{
+		ID:          e.ID,
+		Type:        string(e.Type),
+		Symbol:      e.Symbol,
+		Title:       e.Title,
+		Description: e.Description,
+		ScheduledAt: e.ScheduledAt,
+		AllDay:      e.AllDay,
+		Importance:  string(e.Importance),
+		Amount:      e.Amount,
+		Ratio:       e.Ratio,
+		Source:      string(e.Source),
+		Shared:      e.UserID == nil,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// ImportRowError describes an event in an import file that could not be imported.
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportResponse reports the outcome of an ICS or CSV import. Events imported
+// before are matched by their UID, or by their content when the file has no
+// IDs, and updated in place, so a calendar can be re-imported safely.
+type ImportResponse struct {
+	Format   string           `json:"format"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
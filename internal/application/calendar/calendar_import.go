@@ -0,0 +1,562 @@
+package calendar
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/calendar"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxImportEvents bounds the size of a single calendar import.
+	maxImportEvents = 10000
+	// maxExternalIDLength is the longest UID stored as is; longer ones are replaced by a fingerprint.
+	maxExternalIDLength = 255
+)
+
+// csvColumns maps the column names used by common calendar exports to event fields.
+var csvColumns = map[string]string{
+	"date":         "date",
+	"ex_date":      "date",
+	"ex-date":      "date",
+	"ex date":      "date",
+	"scheduled_at": "date",
+	"datetime":     "date",
+	"time":         "time",
+	"type":         "type",
+	"event_type":   "type",
+	"category":     "type",
+	"symbol":       "symbol",
+	"ticker":       "symbol",
+	"title":        "title",
+	"event":        "title",
+	"name":         "title",
+	"summary":      "title",
+	"description":  "description",
+	"notes":        "description",
+	"importance":   "importance",
+	"impact":       "importance",
+	"amount":       "amount",
+	"dividend":     "amount",
+	"ratio":        "ratio",
+	"split_ratio":  "ratio",
+	"split ratio":  "ratio",
+	"id":           "id",
+	"uid":          "id",
+}
+
+// eventKeywords infers the event type from categories and titles, checked in order.
+var eventKeywords = []struct {
+	keyword   string
+	eventType calendar.EventType
+}{
+	{"earnings", calendar.EventTypeEarnings},
+	{"dividend", calendar.EventTypeDividend},
+	{"split", calendar.EventTypeSplit},
+	{"macro", calendar.EventTypeMacro},
+	{"economic", calendar.EventTypeMacro},
+}
+
+// csvDateLayouts are tried in order; layouts without a time of day mark all-day events.
+var csvDateLayouts = []struct {
+	layout string
+	allDay bool
+}{
+	{time.RFC3339, false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02 15:04", false},
+	{"2006-01-02", true},
+	{"01/02/2006", true},
+	{"2006/01/02", true},
+}
+
+// tickerPattern matches a leading ticker in titles such as "AAPL Q3 earnings".
+var tickerPattern = regexp.MustCompile(`^[A-Z][A-Z0-9.\-]{0,9}$`)
+
+// ImportEvents imports an iCalendar (ICS) or CSV file into the user's own
+// calendar. The format is detected from the content. Events that cannot be
+// parsed are reported and skipped.
+func (uc *CalendarUseCase) ImportEvents(ctx context.Context, userID uuid.UUID, r io.Reader) (*ImportResponse, error) {
+	return uc.importEvents(ctx, &userID, r)
+}
+
+// ImportSharedEvents imports an ICS or CSV file into the calendar shared with all users.
+func (uc *CalendarUseCase) ImportSharedEvents(ctx context.Context, r io.Reader) (*ImportResponse, error) {
+	return uc.importEvents(ctx, nil, r)
+}
+
+func (uc *CalendarUseCase) importEvents(ctx context.Context, owner *uuid.UUID, r io.Reader) (*ImportResponse, error) {
+	buffered := bufio.NewReader(r)
+	head, err := buffered.Peek(512)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	response := &ImportResponse{Format: "csv"}
+	var events []*calendar.Event
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(string(head), "\ufeff"))), "BEGIN:VCALENDAR") {
+		response.Format = "ics"
+		events, response.Errors, err = parseICS(buffered)
+	} else {
+		events, response.Errors, err = parseEventsCSV(buffered)
+	}
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		for _, e := range events {
+			e.UserID = owner
+			if _, err := provider.CalendarEvent().Upsert(ctx, e); err != nil {
+				return fmt.Errorf("failed to import calendar event: %w", err)
+			}
+			response.Imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// icsProperty is a content line of an iCalendar file: NAME;PARAM=VALUE:value.
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseICS reads the VEVENT components of an iCalendar file. Besides the
+// standard UID, DTSTART, SUMMARY, DESCRIPTION, CATEGORIES and PRIORITY
+// properties, X-SYMBOL, X-EVENT-TYPE, X-AMOUNT and X-RATIO are recognized.
+func parseICS(r io.Reader) ([]*calendar.Event, []ImportRowError, error) {
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var events []*calendar.Event
+	rowErrors := []ImportRowError{}
+	var current []icsProperty
+	start := 0
+	inEvent := false
+	for _, l := range lines {
+		upper := strings.ToUpper(l.text)
+		switch {
+		case upper == "BEGIN:VEVENT":
+			inEvent, current, start = true, nil, l.number
+		case upper == "END:VEVENT":
+			if !inEvent {
+				continue
+			}
+			inEvent = false
+			if len(events)+len(rowErrors) >= maxImportEvents {
+				return nil, nil, fmt.Errorf("calendar has more than %d events", maxImportEvents)
+			}
+			e, err := icsEvent(current)
+			if err != nil {
+				rowErrors = append(rowErrors, ImportRowError{Line: start, Message: err.Error()})
+				continue
+			}
+			events = append(events, e)
+		case inEvent:
+			current = append(current, parseICSProperty(l.text))
+		}
+	}
+	if len(events) == 0 && len(rowErrors) == 0 {
+		return nil, nil, fmt.Errorf("calendar has no events")
+	}
+	return events, rowErrors, nil
+}
+
+type icsLine struct {
+	number int
+	text   string
+}
+
+// unfoldICS joins continuation lines, which start with a space or tab, to the line before.
+func unfoldICS(r io.Reader) ([]icsLine, error) {
+	var lines []icsLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, icsLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+func parseICSProperty(line string) icsProperty {
+	// The value starts at the first colon outside a quoted parameter value.
+	split := len(line)
+	quoted := false
+	for i, ch := range line {
+		if ch == '"' {
+			quoted = !quoted
+		} else if ch == ':' && !quoted {
+			split = i
+			break
+		}
+	}
+	prop := icsProperty{params: make(map[string]string)}
+	if split < len(line) {
+		prop.value = line[split+1:]
+	}
+	parts := strings.Split(line[:split], ";")
+	prop.name = strings.ToUpper(parts[0])
+	for _, p := range parts[1:] {
+		if key, value, ok := strings.Cut(p, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop
+}
+
+func icsEvent(props []icsProperty) (*calendar.Event, error) {
+	e := &calendar.Event{Source: calendar.EventSourceICS, Importance: calendar.ImportanceMedium}
+	var categories []string
+	var eventType, symbol, amount, ratio string
+	var hasStart bool
+	for _, p := range props {
+		switch p.name {
+		case "UID":
+			e.ExternalID = p.value
+		case "SUMMARY":
+			e.Title = unescapeICS(p.value)
+		case "DESCRIPTION":
+			description := unescapeICS(p.value)
+			e.Description = emptyToNil(&description)
+		case "DTSTART":
+			scheduledAt, allDay, err := parseICSTime(p)
+			if err != nil {
+				return nil, err
+			}
+			e.ScheduledAt, e.AllDay, hasStart = scheduledAt, allDay, true
+		case "CATEGORIES":
+			for _, c := range strings.Split(unescapeICS(p.value), ",") {
+				categories = append(categories, strings.TrimSpace(c))
+			}
+		case "PRIORITY":
+			// RFC 5545: 1-4 high, 5 medium, 6-9 low, 0 undefined.
+			switch n, _ := strconv.Atoi(p.value); {
+			case n >= 1 && n <= 4:
+				e.Importance = calendar.ImportanceHigh
+			case n >= 6:
+				e.Importance = calendar.ImportanceLow
+			}
+		case "X-EVENT-TYPE":
+			eventType = p.value
+		case "X-SYMBOL":
+			symbol = p.value
+		case "X-AMOUNT":
+			amount = p.value
+		case "X-RATIO":
+			ratio = p.value
+		}
+	}
+	if !hasStart {
+		return nil, fmt.Errorf("event has no DTSTART")
+	}
+
+	if err := fillEvent(e, eventType, categories, symbol, amount, ratio); err != nil {
+		return nil, err
+	}
+	if e.ExternalID == "" || len(e.ExternalID) > maxExternalIDLength {
+		e.ExternalID = eventFingerprint(e)
+	}
+	return e, nil
+}
+
+// parseICSTime reads a DTSTART value: a date for all-day events, a UTC time
+// ending in Z, a local time in the TZID parameter's zone, or a floating time,
+// which is read as UTC.
+func parseICSTime(p icsProperty) (time.Time, bool, error) {
+	if p.params["VALUE"] == "DATE" || len(p.value) == len("20060102") {
+		t, err := time.Parse("20060102", p.value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid DTSTART %q", p.value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(p.value, "Z") {
+		t, err := time.Parse("20060102T150405Z", p.value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid DTSTART %q", p.value)
+		}
+		return t, false, nil
+	}
+	location := time.UTC
+	if tzid := p.params["TZID"]; tzid != "" {
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		location = loc
+	}
+	t, err := time.ParseInLocation("20060102T150405", p.value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid DTSTART %q", p.value)
+	}
+	return t.UTC(), false, nil
+}
+
+func unescapeICS(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// parseEventsCSV reads a CSV calendar. The header row names the columns; a
+// date column and a type or title column are required.
+func parseEventsCSV(r io.Reader) ([]*calendar.Event, []ImportRowError, error) {
+	buffered := bufio.NewReader(r)
+	firstLine, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if header, _, _ := strings.Cut(string(firstLine), "\n"); strings.Count(header, ";") > strings.Count(header, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := csvColumns[name]; ok {
+			if _, dup := columns[field]; !dup {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["date"]; !ok {
+		return nil, nil, fmt.Errorf("CSV header has no date column")
+	}
+	_, hasType := columns["type"]
+	_, hasTitle := columns["title"]
+	if !hasType && !hasTitle {
+		return nil, nil, fmt.Errorf("CSV header has no type or title column")
+	}
+
+	var events []*calendar.Event
+	rowErrors := []ImportRowError{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(events)+len(rowErrors) >= maxImportEvents {
+			return nil, nil, fmt.Errorf("CSV has more than %d rows", maxImportEvents)
+		}
+
+		e, err := parseEventRecord(record, columns)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, rowErrors, nil
+}
+
+func parseEventRecord(record []string, columns map[string]int) (*calendar.Event, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	date := field("date")
+	if clock := field("time"); clock != "" {
+		date += " " + clock
+	}
+	scheduledAt, allDay, err := parseCSVDate(date)
+	if err != nil {
+		return nil, err
+	}
+	e := &calendar.Event{
+		Title:       field("title"),
+		ScheduledAt: scheduledAt,
+		AllDay:      allDay,
+		Importance:  calendar.Importance(strings.ToLower(field("importance"))),
+		Source:      calendar.EventSourceCSV,
+		ExternalID:  field("id"),
+	}
+	description := field("description")
+	e.Description = emptyToNil(&description)
+
+	if err := fillEvent(e, field("type"), nil, field("symbol"), field("amount"), field("ratio")); err != nil {
+		return nil, err
+	}
+	if e.ExternalID == "" || len(e.ExternalID) > maxExternalIDLength {
+		e.ExternalID = eventFingerprint(e)
+	}
+	return e, nil
+}
+
+// fillEvent completes an imported event: it resolves the type from the explicit
+// type, the categories or the title, takes the symbol from the leading ticker of
+// the title when none is given, parses the dividend amount and split ratio, and
+// validates the result.
+func fillEvent(e *calendar.Event, eventType string, categories []string, symbol, amount, ratio string) error {
+	e.Type = calendar.EventType(strings.ToLower(strings.TrimSpace(eventType)))
+	if e.Type == "" {
+		e.Type = inferEventType(append(categories, e.Title))
+	}
+	if !e.Type.IsValid() {
+		return fmt.Errorf("unsupported event type %q", eventType)
+	}
+
+	if symbol == "" && e.Type != calendar.EventTypeMacro {
+		if first, _, _ := strings.Cut(e.Title, " "); tickerPattern.MatchString(first) {
+			symbol = first
+		}
+	}
+	if symbol != "" {
+		e.Symbol = &symbol
+	}
+	if e.Title == "" {
+		e.Title = defaultTitle(e.Type, symbol)
+	}
+
+	if amount != "" {
+		v, err := strconv.ParseFloat(strings.NewReplacer("$", "", "€", "", "£", "", ",", "").Replace(amount), 64)
+		if err != nil {
+			return fmt.Errorf("invalid amount %q", amount)
+		}
+		e.Amount = &v
+	}
+	if ratio == "" && e.Type == calendar.EventTypeSplit {
+		ratio = splitRatioPattern.FindString(e.Title)
+	}
+	if ratio != "" {
+		v, err := parseSplitRatio(ratio)
+		if err != nil {
+			return err
+		}
+		e.Ratio = &v
+	}
+	return e.Validate()
+}
+
+func inferEventType(texts []string) calendar.EventType {
+	for _, k := range eventKeywords {
+		for _, text := range texts {
+			if strings.Contains(strings.ToLower(text), k.keyword) {
+				return k.eventType
+			}
+		}
+	}
+	return calendar.EventTypeMacro
+}
+
+func defaultTitle(eventType calendar.EventType, symbol string) string {
+	title := strings.ToUpper(string(eventType[:1])) + string(eventType[1:])
+	if symbol != "" {
+		return strings.ToUpper(symbol) + " " + strings.ToLower(title)
+	}
+	return title
+}
+
+// splitRatioPattern finds ratios such as "4-for-1", "4 for 1", "3:2" or "1/10" in a title.
+var splitRatioPattern = regexp.MustCompile(`\d+(\.\d+)?\s*(-for-|\s+for\s+|:|/)\s*\d+(\.\d+)?`)
+
+// parseSplitRatio reads a split ratio as new shares per old share. A bare number is the ratio itself.
+func parseSplitRatio(s string) (float64, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	for _, sep := range []string{"-for-", " for ", ":", "/"} {
+		if newShares, oldShares, ok := strings.Cut(normalized, sep); ok {
+			n, err1 := strconv.ParseFloat(strings.TrimSpace(newShares), 64)
+			o, err2 := strconv.ParseFloat(strings.TrimSpace(oldShares), 64)
+			if err1 != nil || err2 != nil || o == 0 {
+				return 0, fmt.Errorf("invalid split ratio %q", s)
+			}
+			return n / o, nil
+		}
+	}
+	v, err := strconv.ParseFloat(normalized, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid split ratio %q", s)
+	}
+	return v, nil
+}
+
+func parseCSVDate(s string) (time.Time, bool, error) {
+	for _, l := range csvDateLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return t, l.allDay, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q", s)
+}
+
+// eventFingerprint identifies an event without an ID by its content, so
+// re-importing the same file updates the events instead of duplicating them.
+func eventFingerprint(e *calendar.Event) string {
+	symbol := ""
+	if e.Symbol != nil {
+		symbol = strings.ToUpper(*e.Symbol)
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		string(e.Type),
+		symbol,
+		e.ScheduledAt.UTC().Format(time.RFC3339),
+		e.Title,
+	}, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+)
+
+// GetUpcomingEventsTool exposes CalendarUseCase.GetUpcomingEvents to the LLM as
+// the get_upcoming_events tool, so the assistant can factor known catalysts
+// into its analysis.
+type GetUpcomingEventsTool struct {
+	useCase *CalendarUseCase
+}
+
+// NewGetUpcomingEventsTool creates the get_upcoming_events tool handler.
+func NewGetUpcomingEventsTool(useCase *CalendarUseCase) services.ToolHandler {
+	return &GetUpcomingEventsTool{useCase: useCase}
+}
+
+// Definition describes the get_upcoming_events tool.
+func (t *GetUpcomingEventsTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "get_upcoming_events",
+		Description: "List scheduled market events in the coming days: earnings dates, macro releases (central bank decisions, economic data), dividend ex-dates with the amount per share, and splits with the ratio of new to old shares. Macro events apply to all symbols and are included whenever symbols are given, unless excluded by type. Times are UTC; all-day events have no specific time.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"symbols": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "Only events for these symbols, e.g. [\"AAPL\", \"MSFT\"]; omit for all symbols",
+				},
+				"types": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string", "enum": []string{"earnings", "macro", "dividend", "split"}},
+					"description": "Only events of these types; omit for all types",
+				},
+				"days": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxUpcomingDays, "default": defaultUpcomingDays, "description": "Number of days ahead to look"},
+			},
+		},
+	}
+}
+
+// RequiredScope reports that the calendar needs market:read.
+func (t *GetUpcomingEventsTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourceMarket, false
+}
+
+// Execute returns the upcoming events.
+func (t *GetUpcomingEventsTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var req UpcomingEventsRequest
+	if len(invocation.Arguments) > 0 {
+		if err := json.Unmarshal(invocation.Arguments, &req); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	events, err := t.useCase.GetUpcomingEvents(ctx, invocation.UserID, &req)
+	if err != nil {
+		return nil, err
+	}
+	return &services.ToolResult{Output: shared.JSONB{"events": events, "count": len(events)}}, nil
+}
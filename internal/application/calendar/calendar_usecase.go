@@ -0,0 +1,179 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/calendar"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit    = 100
+	maxListLimit        = 1000
+	defaultUpcomingDays = 14
+	maxUpcomingDays     = 90
+)
+
+// CalendarUseCase handles the events calendar: earnings dates, macro releases
+// and corporate actions.
+type CalendarUseCase struct {
+	dbService *database.Service
+}
+
+// NewCalendarUseCase creates a new CalendarUseCase instance.
+func NewCalendarUseCase(dbService *database.Service) *CalendarUseCase {
+	return &CalendarUseCase{
+		dbService: dbService,
+	}
+}
+
+// CreateEvent adds an event to the user's own calendar.
+func (uc *CalendarUseCase) CreateEvent(ctx context.Context, userID uuid.UUID, req *CreateEventRequest) (*EventResponse, error) {
+	event := &calendar.Event{
+		UserID:      &userID,
+		Type:        calendar.EventType(req.Type),
+		Symbol:      req.Symbol,
+		Title:       req.Title,
+		Description: emptyToNil(req.Description),
+		ScheduledAt: req.ScheduledAt,
+		AllDay:      req.AllDay,
+		Importance:  calendar.Importance(req.Importance),
+		Amount:      req.Amount,
+		Ratio:       req.Ratio,
+		Source:      calendar.EventSourceManual,
+		ExternalID:  uuid.NewString(),
+	}
+	if err := event.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var created *calendar.Event
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		created, err = provider.CalendarEvent().Upsert(ctx, event)
+		if err != nil {
+			return fmt.Errorf("failed to create calendar event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToEventResponse(created), nil
+}
+
+// ListEvents returns the shared events and the user's own events in chronological order.
+func (uc *CalendarUseCase) ListEvents(ctx context.Context, userID uuid.UUID, req *ListEventsRequest) ([]*EventResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	if req.Start != nil && req.End != nil && !req.End.After(*req.Start) {
+		return nil, errors.NewAppError(errors.CodeValidation, "end must be after start", nil)
+	}
+	filter, err := toFilter(req.Start, req.End, req.Symbols, req.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*calendar.Event
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		events, err = provider.CalendarEvent().List(ctx, userID, filter, limit, req.Offset)
+		if err != nil {
+			return fmt.Errorf("failed to list calendar events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*EventResponse, len(events))
+	for i, e := range events {
+		responses[i] = ToEventResponse(e)
+	}
+	return responses, nil
+}
+
+// GetUpcomingEvents returns the events scheduled from now until the requested number of days ahead.
+func (uc *CalendarUseCase) GetUpcomingEvents(ctx context.Context, userID uuid.UUID, req *UpcomingEventsRequest) ([]*EventResponse, error) {
+	days := req.Days
+	if days <= 0 {
+		days = defaultUpcomingDays
+	}
+	if days > maxUpcomingDays {
+		days = maxUpcomingDays
+	}
+	// Start at the beginning of the day so all-day events of today are included.
+	start := time.Now().UTC().Truncate(24 * time.Hour)
+	end := start.AddDate(0, 0, days+1)
+	return uc.ListEvents(ctx, userID, &ListEventsRequest{
+		Start:   &start,
+		End:     &end,
+		Symbols: req.Symbols,
+		Types:   req.Types,
+		Limit:   maxListLimit,
+	})
+}
+
+// DeleteEvent removes one of the user's own events. Shared events cannot be deleted.
+func (uc *CalendarUseCase) DeleteEvent(ctx context.Context, userID, eventID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		event, err := provider.CalendarEvent().GetByID(ctx, eventID)
+		if err != nil {
+			if err == errors.ErrCalendarEventNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Calendar event not found", err)
+			}
+			return fmt.Errorf("failed to get calendar event: %w", err)
+		}
+		if event.UserID == nil || *event.UserID != userID {
+			return errors.ErrForbidden
+		}
+		if err := provider.CalendarEvent().Delete(ctx, eventID); err != nil {
+			return fmt.Errorf("failed to delete calendar event: %w", err)
+		}
+		return nil
+	})
+}
+
+// toFilter validates the event types and normalizes the symbols of a listing.
+func toFilter(start, end *time.Time, symbols, types []string) (calendar.EventFilter, error) {
+	filter := calendar.EventFilter{Start: start, End: end}
+	for _, s := range symbols {
+		if s = strings.ToUpper(strings.TrimSpace(s)); s != "" {
+			filter.Symbols = append(filter.Symbols, s)
+		}
+	}
+	for _, t := range types {
+		eventType := calendar.EventType(strings.ToLower(strings.TrimSpace(t)))
+		if eventType == "" {
+			continue
+		}
+		if !eventType.IsValid() {
+			return filter, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("unsupported event type %q", t), nil)
+		}
+		filter.Types = append(filter.Types, eventType)
+	}
+	return filter, nil
+}
+
+func emptyToNil(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*v)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
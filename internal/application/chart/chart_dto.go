@@ -0,0 +1,26 @@
+package chart
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/chart"
+)
+
+// --- Request DTOs ---
+
+// RenderChartRequest describes a chart to render. The data comes from the stored
+// candles of Symbol or from inline Series.
+type RenderChartRequest struct {
+	Type       string          `json:"type,omitempty"` // Defaults to candlestick for a symbol and line otherwise
+	Title      string          `json:"title,omitempty"`
+	Symbol     string          `json:"symbol,omitempty"`
+	Timeframe  string          `json:"timeframe,omitempty"`
+	Start      *time.Time      `json:"start,omitempty"`
+	End        *time.Time      `json:"end,omitempty"`
+	Limit      int             `json:"limit,omitempty"`      // Number of most recent candles when no start is given
+	Adjustment string          `json:"adjustment,omitempty"` // Corporate actions to adjust prices for: none, splits or all
+	Series     []chart.Series  `json:"series,omitempty"`
+	Overlays   []chart.Overlay `json:"overlays,omitempty"`
+	Width      int             `json:"width,omitempty"`
+	Height     int             `json:"height,omitempty"`
+}
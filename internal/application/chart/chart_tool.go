@@ -0,0 +1,122 @@
+package chart
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+)
+
+// RenderChartTool exposes ChartUseCase.RenderChart to the LLM as the render_chart
+// tool, so the assistant can attach charts to its answers.
+type RenderChartTool struct {
+	useCase *ChartUseCase
+}
+
+// NewRenderChartTool creates the render_chart tool handler.
+func NewRenderChartTool(useCase *ChartUseCase) services.ToolHandler {
+	return &RenderChartTool{useCase: useCase}
+}
+
+// Definition describes the render_chart tool.
+func (t *RenderChartTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "render_chart",
+		Description: "Render a chart as an SVG image and attach it to the answer. Chart stored candles of a symbol (candlestick, line or bar of the closes) with optional indicator overlays, or plot your own series (line, bar, or equity_curve, which shades the drawdown of the first series from its running peak).",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":       map[string]interface{}{"type": "string", "enum": []string{"candlestick", "line", "bar", "equity_curve"}, "description": "Defaults to candlestick when a symbol is given and line otherwise"},
+				"title":      map[string]interface{}{"type": "string"},
+				"symbol":     map[string]interface{}{"type": "string", "description": "Chart the stored candles of this symbol, e.g. BTCUSDT"},
+				"timeframe":  map[string]interface{}{"type": "string", "enum": []string{"1m", "5m", "15m", "30m", "1h", "4h", "1d", "1w"}, "default": "1d"},
+				"start":      map[string]interface{}{"type": "string", "format": "date-time", "description": "RFC 3339 start of the candle range"},
+				"end":        map[string]interface{}{"type": "string", "format": "date-time", "description": "RFC 3339 end of the candle range (exclusive); defaults to now"},
+				"limit":      map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxChartCandles, "default": defaultChartCandles, "description": "Number of most recent candles when no start is given"},
+				"adjustment": map[string]interface{}{"type": "string", "enum": []string{"none", "splits", "all"}, "default": "none", "description": "Adjust the candles for splits, or for splits and dividends, recorded in the events calendar"},
+				"series": map[string]interface{}{
+					"type":     "array",
+					"maxItems": 8,
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"name": map[string]interface{}{"type": "string"},
+							"points": map[string]interface{}{
+								"type": "array",
+								"items": map[string]interface{}{
+									"type": "object",
+									"properties": map[string]interface{}{
+										"t": map[string]interface{}{"type": "string", "format": "date-time"},
+										"v": map[string]interface{}{"type": "number"},
+									},
+									"required": []string{"t", "v"},
+								},
+							},
+						},
+						"required": []string{"name", "points"},
+					},
+				},
+				"overlays": map[string]interface{}{
+					"type":        "array",
+					"maxItems":    6,
+					"description": "Indicators computed from the candle closes; rsi is drawn in a pane below the price",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"indicator": map[string]interface{}{"type": "string", "enum": []string{"sma", "ema", "highest", "lowest", "rsi"}},
+							"period":    map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 500},
+						},
+						"required": []string{"indicator", "period"},
+					},
+				},
+				"width":  map[string]interface{}{"type": "integer", "minimum": 320, "maximum": 2400, "default": 960},
+				"height": map[string]interface{}{"type": "integer", "minimum": 200, "maximum": 1600, "default": 540},
+			},
+		},
+	}
+}
+
+// RequiredScope reports that charts need market:read.
+func (t *RenderChartTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourceMarket, false
+}
+
+// Execute renders the chart and attaches it as an artifact.
+func (t *RenderChartTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var req RenderChartRequest
+	if err := json.Unmarshal(invocation.Arguments, &req); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	artifact, spec, err := t.useCase.RenderChart(ctx, invocation.UserID, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]string, 0, len(spec.Series))
+	for _, s := range spec.Series {
+		series = append(series, s.Name)
+	}
+	output := shared.JSONB{
+		"title":    spec.Title,
+		"type":     spec.Type,
+		"series":   series,
+		"overlays": spec.Overlays,
+		"attached": true,
+	}
+	if len(spec.Candles) > 0 {
+		first, last := spec.Candles[0], spec.Candles[len(spec.Candles)-1]
+		output["candles"] = len(spec.Candles)
+		output["start"] = first.OpenTime
+		output["end"] = last.CloseTime()
+		output["last_close"] = last.Close
+	}
+	return &services.ToolResult{
+		Output:    output,
+		Artifacts: []*chat.Artifact{artifact},
+	}, nil
+}
@@ -0,0 +1,151 @@
+package chart
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/calendar"
+	"trading-alchemist/internal/domain/chart"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultChartTimeframe is used when a symbol is charted without a timeframe.
+	defaultChartTimeframe = market.Timeframe1d
+	// defaultChartCandles is how many recent candles are charted without a start.
+	defaultChartCandles = 200
+	// maxChartCandles caps the candles loaded for a single chart.
+	maxChartCandles = 1000
+)
+
+// ChartUseCase renders charts server-side as SVG.
+type ChartUseCase struct {
+	dbService *database.Service
+}
+
+// NewChartUseCase creates a new ChartUseCase.
+func NewChartUseCase(dbService *database.Service) *ChartUseCase {
+	return &ChartUseCase{
+		dbService: dbService,
+	}
+}
+
+// RenderChart renders the requested chart and returns it as an unsaved chart
+// artifact with SVG content, along with the spec it was drawn from.
+func (uc *ChartUseCase) RenderChart(ctx context.Context, userID uuid.UUID, req *RenderChartRequest) (*chat.Artifact, *chart.Spec, error) {
+	spec := &chart.Spec{
+		Type:     chart.Type(req.Type),
+		Title:    req.Title,
+		Width:    req.Width,
+		Height:   req.Height,
+		Series:   req.Series,
+		Overlays: req.Overlays,
+	}
+
+	if req.Symbol != "" {
+		err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+			candles, err := loadCandles(ctx, provider, userID, req)
+			if err != nil {
+				return err
+			}
+			spec.Candles = candles
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if spec.Type == "" {
+		spec.Type = chart.TypeLine
+		if len(spec.Candles) > 0 {
+			spec.Type = chart.TypeCandlestick
+		}
+	}
+	if spec.Title == "" {
+		spec.Title = defaultTitle(spec)
+	}
+
+	svg, err := chart.Render(spec)
+	if err != nil {
+		return nil, nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	language := "svg"
+	return chat.NewArtifact(spec.Title, shared.ArtifactTypeChart, &language, svg), spec, nil
+}
+
+// loadCandles loads the candles of the requested symbol: the range between start
+// and end, or the most recent candles when no start is given, adjusted for the
+// corporate actions in the user's calendar if requested.
+func loadCandles(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID, req *RenderChartRequest) ([]*market.Candle, error) {
+	timeframe := defaultChartTimeframe
+	if req.Timeframe != "" {
+		tf, err := market.ParseTimeframe(req.Timeframe)
+		if err != nil {
+			return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		timeframe = tf
+	}
+	adjustment, err := calendar.ParseAdjustment(req.Adjustment)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	symbol := strings.ToUpper(strings.TrimSpace(req.Symbol))
+
+	var candles []*market.Candle
+	if req.Start != nil {
+		end := time.Now().UTC()
+		if req.End != nil {
+			end = *req.End
+		}
+		if !end.After(*req.Start) {
+			return nil, errors.NewAppError(errors.CodeValidation, "end must be after start", nil)
+		}
+		candles, err = provider.Candle().GetRange(ctx, symbol, timeframe, *req.Start, end, maxChartCandles)
+	} else {
+		limit := req.Limit
+		if limit <= 0 {
+			limit = defaultChartCandles
+		}
+		if limit > maxChartCandles {
+			limit = maxChartCandles
+		}
+		candles, err = provider.Candle().GetLatest(ctx, symbol, timeframe, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load candles: %w", err)
+	}
+	if len(candles) == 0 {
+		return nil, errors.NewAppError(errors.CodeNotFound, fmt.Sprintf("no %s candles stored for %s in the requested period", timeframe, symbol), nil)
+	}
+	if adjustment == calendar.AdjustmentNone {
+		return candles, nil
+	}
+
+	actions, err := provider.CalendarEvent().List(ctx, userID, calendar.EventFilter{
+		Symbols: []string{symbol},
+		Types:   []calendar.EventType{calendar.EventTypeSplit, calendar.EventTypeDividend},
+	}, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load corporate actions: %w", err)
+	}
+	return calendar.AdjustCandles(candles, actions, adjustment), nil
+}
+
+func defaultTitle(spec *chart.Spec) string {
+	if len(spec.Candles) > 0 {
+		return fmt.Sprintf("%s %s", spec.Candles[0].Symbol, spec.Candles[0].Timeframe)
+	}
+	if len(spec.Series) == 1 && spec.Series[0].Name != "" {
+		return spec.Series[0].Name
+	}
+	return "Chart"
+}
@@ -13,6 +13,11 @@ type CreateConversationRequest struct {
 	Title     string    `json:"title" validate:"required,min=1,max=255"`
 	ModelName *string   `json:"model_name,omitempty"` // e.g., "gpt-4o-mini". Defaults to a system-wide default if not provided.
 	UserID    uuid.UUID `json:"-"`                    // This will be set from the context, not the request body.
+
+	// OrganizationID creates the conversation in one of the user's organizations
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	// OrganizationPermission is what the organization's members may do: read or write. Defaults to write.
+	OrganizationPermission string `json:"organization_permission,omitempty"`
 }
 
 // UpdateConversationTitleRequest represents the request to update a conversation's title.
@@ -20,6 +25,20 @@ type UpdateConversationTitleRequest struct {
 	Title string `json:"title" validate:"required,min=1,max=255"`
 }
 
+// UpdateConversationSettingsRequest sets the retrieval settings of a conversation.
+// Omitted settings fall back to the server defaults.
+type UpdateConversationSettingsRequest struct {
+	RetrievalTopK          *int     `json:"retrieval_top_k,omitempty"`          // Number of document passages given to the model, 1 to 50
+	RetrievalMinSimilarity *float64 `json:"retrieval_min_similarity,omitempty"` // Least similarity of a passage to the message, 0 to 1
+}
+
+// ShareConversationRequest moves a conversation into an organization, or back
+// out of it when the organization ID is omitted.
+type ShareConversationRequest struct {
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	Permission     string     `json:"permission,omitempty"` // read or write; defaults to write
+}
+
 // GenerateTitleRequest represents internal request to generate conversation title.
 type GenerateTitleRequest struct {
 	UserMessage      string `json:"user_message"`
@@ -41,6 +60,23 @@ type CreateArtifactRequest struct {
 	Content  string `json:"content" validate:"required"`
 }
 
+// ConfirmToolCallRequest carries the user's decision on a tool call awaiting confirmation.
+type ConfirmToolCallRequest struct {
+	Approved bool `json:"approved"`
+}
+
+// SemanticSearchRequest searches the user's past messages by meaning.
+type SemanticSearchRequest struct {
+	Query string `json:"q"`
+	Limit int    `json:"limit"` // Defaults to 10, at most 50
+}
+
+// UpdateMemoryRequest edits something the assistant remembers about the user.
+type UpdateMemoryRequest struct {
+	Category string `json:"category" validate:"required"` // risk_tolerance, markets, time_zone, trading_style, goals or other
+	Content  string `json:"content" validate:"required,max=500"`
+}
+
 // --- Response DTOs ---
 
 // ConversationSummaryResponse represents a single conversation in a list.
@@ -49,6 +85,10 @@ type ConversationSummaryResponse struct {
 	Title         string     `json:"title"`
 	LastMessageAt *time.Time `json:"last_message_at"`
 	ModelID       uuid.UUID  `json:"model_id"`
+
+	OrganizationID         *uuid.UUID `json:"organization_id,omitempty"`
+	OrganizationPermission string     `json:"organization_permission,omitempty"`
+	Permission             string     `json:"permission"` // What the user may do: read, write or manage
 }
 
 // MessageResponse represents a single message in a conversation.
@@ -75,7 +115,12 @@ type ConversationDetailResponse struct {
 	Title        string            `json:"title"`
 	ModelID      uuid.UUID         `json:"model_id"`
 	SystemPrompt *string           `json:"system_prompt"`
+	Settings     JSONB             `json:"settings,omitempty"`
 	Messages     []MessageResponse `json:"messages"`
+
+	OrganizationID         *uuid.UUID `json:"organization_id,omitempty"`
+	OrganizationPermission string     `json:"organization_permission,omitempty"`
+	Permission             string     `json:"permission"` // What the user may do: read, write or manage
 }
 
 // JSONB is a local alias for map[string]interface{} for DTOs.
@@ -87,4 +132,25 @@ type ToolResponse struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Schema      JSONB     `json:"schema,omitempty"`
-} 
\ No newline at end of file
+} 
+
+// MessageSearchResultResponse is a past message found by semantic search.
+type MessageSearchResultResponse struct {
+	MessageID         uuid.UUID `json:"message_id"`
+	ConversationID    uuid.UUID `json:"conversation_id"`
+	ConversationTitle string    `json:"conversation_title"`
+	Role              string    `json:"role"`
+	Content           string    `json:"content"`
+	Similarity        float64   `json:"similarity"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// MemoryResponse is something the assistant remembers about the user.
+type MemoryResponse struct {
+	ID                   uuid.UUID  `json:"id"`
+	Category             string     `json:"category"`
+	Content              string     `json:"content"`
+	SourceConversationID *uuid.UUID `json:"source_conversation_id,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
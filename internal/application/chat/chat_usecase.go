@@ -2,16 +2,18 @@ package chat
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 	"trading-alchemist/internal/config"
 	"trading-alchemist/internal/domain/chat"
 	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/domain/strategy"
 	"trading-alchemist/internal/infrastructure/database"
 	"trading-alchemist/pkg/errors"
-	"trading-alchemist/pkg/utils"
 
 	"github.com/google/uuid"
 )
@@ -24,12 +26,25 @@ func min(a, b int) int {
 	return b
 }
 
+const (
+	// maxToolIterations bounds how many rounds of tool calls a single user message can trigger.
+	maxToolIterations = 5
+	// toolExecutionTimeout bounds the runtime of a single tool call.
+	toolExecutionTimeout = 2 * time.Minute
+	// defaultSemanticSearchLimit is the number of messages a semantic search returns by default.
+	defaultSemanticSearchLimit = 10
+)
+
 // ChatUseCase handles the business logic for chat operations.
 type ChatUseCase struct {
 	dbService           *database.Service
 	config              *config.Config
 	llmService          services.LLMService
 	conversationUseCase *ConversationUseCase
+	toolRegistry        *ToolRegistry
+	retriever           services.Retriever       // nil when retrieval is disabled
+	messageSearcher     services.MessageSearcher // nil when semantic search is disabled
+	memoryUseCase       *MemoryUseCase
 }
 
 // NewChatUseCase creates a new ChatUseCase instance.
@@ -38,60 +53,65 @@ func NewChatUseCase(
 	config *config.Config,
 	llmService services.LLMService,
 	conversationUseCase *ConversationUseCase,
+	toolRegistry *ToolRegistry,
+	retriever services.Retriever,
+	messageSearcher services.MessageSearcher,
+	memoryUseCase *MemoryUseCase,
 ) *ChatUseCase {
 	return &ChatUseCase{
 		dbService:           dbService,
 		config:              config,
 		llmService:          llmService,
 		conversationUseCase: conversationUseCase,
+		toolRegistry:        toolRegistry,
+		retriever:           retriever,
+		messageSearcher:     messageSearcher,
+		memoryUseCase:       memoryUseCase,
 	}
 }
 
+// llmStreamRequest bundles everything processLLMStream needs to run a completion.
+type llmStreamRequest struct {
+	provider        *chat.Provider
+	model           *chat.Model
+	conversationID  uuid.UUID
+	userID          uuid.UUID
+	scopes          []string // Scopes of the caller's API token; nil for user sessions
+	messages        []*chat.Message
+	tools           []*chat.Tool
+	apiKey          string
+	apiBaseOverride string
+	citations       []*services.Citation // Document excerpts given to the model, stored on the response
+	userMessage     *chat.Message        // Message that started the turn; nil when resuming after a tool confirmation
+	memoryEnabled   bool                 // Whether facts about the user are extracted from the exchange
+}
+
 // PostMessage adds a new message to a conversation and starts a streaming LLM response.
 // It returns a channel that the handler can use to stream events to the client.
-func (uc *ChatUseCase) PostMessage(ctx context.Context, conversationID, userID uuid.UUID, req *PostMessageRequest) (<-chan services.ChatStreamEvent, error) {
-	var conversationHistory []*chat.Message
-	var convProvider *chat.Provider
-	var convModel *chat.Model
+// scopes are those of the caller's API token and limit the tools the model may
+// run; they are nil for user sessions.
+func (uc *ChatUseCase) PostMessage(ctx context.Context, conversationID, userID uuid.UUID, scopes []string, req *PostMessageRequest) (<-chan services.ChatStreamEvent, error) {
+	var streamReq *llmStreamRequest
 	var userSetting *chat.UserProviderSetting
 
 	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
-		// 1. Get conversation and verify ownership
-		conversation, err := provider.Conversation().GetByID(ctx, conversationID)
+		// 1. Get conversation and verify the user may write to it
+		conversation, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionWrite)
 		if err != nil {
-			return fmt.Errorf("failed to get conversation: %w", err)
-		}
-		if conversation.UserID != userID {
-			return errors.ErrForbidden
+			return err
 		}
 
-		// 1a. Get the model and provider for this message
+		// 1a. Resolve the model, provider, user settings and tools for this message
 		// If a specific model is requested in the message, use that; otherwise use conversation's default model
 		modelID := conversation.ModelID
 		if req.ModelID != nil {
 			modelID = *req.ModelID
 		}
-		
-		convModel, err = provider.Model().GetByID(ctx, modelID)
-		if err != nil {
-			return fmt.Errorf("failed to get model: %w", err)
-		}
-		convProvider, err = provider.Provider().GetByID(ctx, convModel.ProviderID)
+		streamReq, userSetting, err = uc.prepareLLMStream(ctx, provider, conversation, userID, modelID)
 		if err != nil {
-			return fmt.Errorf("failed to get provider for model: %w", err)
-		}
-
-		// 1b. Get User Provider Settings
-		userSetting, err = provider.UserProviderSetting().GetByUserIDAndProviderID(ctx, userID, convProvider.ID)
-		if err != nil {
-			if err == errors.ErrUserProviderSettingNotFound {
-				return errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not configured. Please add it in settings.", convProvider.DisplayName), err)
-			}
-			return fmt.Errorf("failed to get user provider settings: %w", err)
-		}
-		if !userSetting.IsActive || userSetting.EncryptedAPIKey == nil || *userSetting.EncryptedAPIKey == "" {
-			return errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not active or not set.", convProvider.DisplayName), nil)
+			return err
 		}
+		streamReq.scopes = scopes
 
 		// 2. Create the new user message
 		newMessage := &chat.Message{
@@ -104,18 +124,22 @@ func (uc *ChatUseCase) PostMessage(ctx context.Context, conversationID, userID u
 		if err != nil {
 			return fmt.Errorf("failed to create message: %w", err)
 		}
+		streamReq.userMessage = createdMessage
 
 		// 3. Create any associated artifacts for the user message
 		// Note: The response to the client won't include these in the initial POST response,
 		// but they will be part of the conversation history for future gets.
 		for _, artifactReq := range req.Artifacts {
-			newArtifact := &chat.Artifact{
-				MessageID: createdMessage.ID,
-				Title:     artifactReq.Title,
-				Type:      shared.ArtifactType(artifactReq.Type),
-				Language:  artifactReq.Language,
-				Content:   artifactReq.Content,
+			artifactType := shared.ArtifactType(artifactReq.Type)
+			// Strategies are executed later by the backtester, so reject invalid ones up front
+			if artifactType == shared.ArtifactTypeStrategy {
+				if _, err := strategy.Parse([]byte(artifactReq.Content)); err != nil {
+					return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("invalid strategy artifact '%s': %s", artifactReq.Title, err.Error()), err)
+				}
 			}
+
+			newArtifact := chat.NewArtifact(artifactReq.Title, artifactType, artifactReq.Language, artifactReq.Content)
+			newArtifact.MessageID = createdMessage.ID
 			if _, err := provider.Artifact().Create(ctx, newArtifact); err != nil {
 				return fmt.Errorf("failed to create artifact: %w", err)
 			}
@@ -129,11 +153,19 @@ func (uc *ChatUseCase) PostMessage(ctx context.Context, conversationID, userID u
 
 		// 5. Get conversation history for LLM
 		// Fetch last 20 messages for context, should be configurable
-		conversationHistory, err = provider.Message().GetByConversationID(ctx, conversationID, 20, 0)
+		streamReq.messages, err = provider.Message().GetByConversationID(ctx, conversationID, 20, 0)
 		if err != nil {
 			return fmt.Errorf("failed to get conversation history: %w", err)
 		}
 
+		// 5a. Build the system prompt from the conversation's prompt and what is remembered about the user
+		memories, memoryEnabled, err := uc.memoryUseCase.recallMemoriesWithProvider(ctx, provider, userID)
+		if err != nil {
+			return fmt.Errorf("failed to recall user memories: %w", err)
+		}
+		streamReq.memoryEnabled = memoryEnabled
+		streamReq.messages = withMemories(streamReq.messages, conversation.SystemPrompt, memories)
+
 		return nil
 	})
 
@@ -141,83 +173,394 @@ func (uc *ChatUseCase) PostMessage(ctx context.Context, conversationID, userID u
 		return nil, err
 	}
 
-	// This channel will be returned to the handler for streaming to the client.
-	clientEventChannel := make(chan services.ChatStreamEvent)
+	// This part happens outside the transaction
+	// 6. Look up document passages relevant to the message; the answer goes on without them on failure
+	var preamble []services.ChatStreamEvent
+	if uc.retriever != nil {
+		citations, err := uc.retriever.Retrieve(ctx, userID, conversationID, req.Content)
+		if err != nil {
+			log.Printf("Failed to retrieve documents for conversation %s: %v", conversationID, err)
+		} else if len(citations) > 0 {
+			streamReq.citations = citations
+			streamReq.messages = withCitations(streamReq.messages, citations)
+			preamble = append(preamble, services.ChatStreamEvent{Citations: citations})
+		}
+	}
+
+	// 7. Start LLM stream and process response in a separate goroutine
+	return uc.startLLMStream(streamReq, userSetting, preamble...), nil
+}
+
+// withCitations returns the history with a system message holding the numbered
+// excerpts inserted before the last user message. It is not persisted.
+func withCitations(messages []*chat.Message, citations []*services.Citation) []*chat.Message {
+	var b strings.Builder
+	b.WriteString("The following excerpts from the user's documents may be relevant to their message. ")
+	b.WriteString("Use them where they help, cite them as [n], and say so when they do not answer the question.\n")
+	for _, c := range citations {
+		fmt.Fprintf(&b, "\n[%d] %s", c.Index, c.DocumentTitle)
+		if c.Page != nil {
+			fmt.Fprintf(&b, ", page %d", *c.Page)
+		}
+		if c.Heading != nil {
+			fmt.Fprintf(&b, ", %s", *c.Heading)
+		}
+		fmt.Fprintf(&b, "\n%s\n", c.Content)
+	}
+	excerpts := &chat.Message{Role: shared.MessageRoleSystem, Content: b.String()}
+
+	last := len(messages) - 1
+	for last >= 0 && messages[last].Role != shared.MessageRoleUser {
+		last--
+	}
+	if last < 0 {
+		last = len(messages)
+	}
+	result := make([]*chat.Message, 0, len(messages)+1)
+	result = append(result, messages[:last]...)
+	result = append(result, excerpts)
+	return append(result, messages[last:]...)
+}
+
+// ConfirmToolCall approves or declines a tool call that is waiting for user confirmation.
+// An approved call is executed; a declined one is answered with an error for the model.
+// Once no call of the same turn is pending anymore, the model continues the conversation
+// and the returned channel streams its response like PostMessage. API tokens can
+// only approve calls to tools their scopes allow.
+func (uc *ChatUseCase) ConfirmToolCall(ctx context.Context, conversationID, userID uuid.UUID, scopes []string, toolCallID string, req *ConfirmToolCallRequest) (<-chan services.ChatStreamEvent, error) {
+	var streamReq *llmStreamRequest
+	var userSetting *chat.UserProviderSetting
+	var toolMessage *chat.Message
+	var call *services.ToolCall
+
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		conversation, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionWrite)
+		if err != nil {
+			return err
+		}
+
+		// The row stays locked until commit, so a call can only be confirmed once.
+		toolMessage, err = provider.Message().GetToolMessageByCallID(ctx, conversationID, toolCallID)
+		if err != nil {
+			if err == errors.ErrMessageNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Tool call not found", err)
+			}
+			return fmt.Errorf("failed to get tool call: %w", err)
+		}
+		if status, _ := toolMessage.Metadata[services.MetadataKeyToolStatus].(string); status != services.ToolStatusPendingConfirmation {
+			return errors.NewAppError(errors.CodeConflict, "Tool call is not awaiting confirmation", nil)
+		}
+		if toolMessage.ParentID == nil {
+			return fmt.Errorf("tool message %s has no assistant message", toolMessage.ID)
+		}
+
+		assistantMessage, err := provider.Message().GetByID(ctx, *toolMessage.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to get assistant message: %w", err)
+		}
+		for _, c := range services.ToolCallsFromMetadata(assistantMessage.Metadata) {
+			if c.ID == toolCallID {
+				call = c
+				break
+			}
+		}
+		if call == nil {
+			return fmt.Errorf("tool call %s not found on assistant message %s", toolCallID, assistantMessage.ID)
+		}
+		if req.Approved {
+			if scope := uc.toolRegistry.MissingScope(call.Name, scopes); scope != "" {
+				return errors.NewAppError(errors.CodeForbidden, "API token lacks the "+scope+" scope", nil)
+			}
+		}
+
+		modelID := conversation.ModelID
+		if assistantMessage.ModelID != nil {
+			modelID = *assistantMessage.ModelID
+		}
+		streamReq, userSetting, err = uc.prepareLLMStream(ctx, provider, conversation, userID, modelID)
+		if err != nil {
+			return err
+		}
+		streamReq.scopes = scopes
+
+		// Record the decision before running anything
+		toolMessage.Metadata[services.MetadataKeyToolStatus] = services.ToolStatusDeclined
+		if req.Approved {
+			toolMessage.Metadata[services.MetadataKeyToolStatus] = services.ToolStatusConfirmed
+		} else {
+			toolMessage.Content = `{"error":"The user declined this tool call."}`
+		}
+		toolMessage, err = provider.Message().Update(ctx, toolMessage)
+		if err != nil {
+			return fmt.Errorf("failed to update tool message: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &services.ToolCallResult{ToolCallID: call.ID, Name: call.Name, Error: "declined by user"}
+	if req.Approved {
+		_, result = uc.executeToolCall(ctx, streamReq, *toolMessage.ParentID, call, toolMessage)
+	}
+	resultEvent := services.ChatStreamEvent{ToolResult: result}
+
+	// Continue only once every call of the turn has been answered
+	awaiting := false
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		thread, err := provider.Message().GetThread(ctx, *toolMessage.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to get tool calls of the turn: %w", err)
+		}
+		for _, m := range thread {
+			if status, _ := m.Metadata[services.MetadataKeyToolStatus].(string); status == services.ToolStatusPendingConfirmation {
+				awaiting = true
+				return nil
+			}
+		}
+
+		streamReq.messages, err = provider.Message().GetByConversationID(ctx, conversationID, 20, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get conversation history: %w", err)
+		}
+		return nil
+	})
+	if err != nil || awaiting {
+		events := make(chan services.ChatStreamEvent, 2)
+		events <- resultEvent
+		events <- services.ChatStreamEvent{Error: err, IsLast: true}
+		close(events)
+		return events, nil
+	}
+
+	return uc.startLLMStream(streamReq, userSetting, resultEvent), nil
+}
+
+// prepareLLMStream resolves the model, provider, user settings and tools a completion
+// runs with. The caller fills in the message history.
+func (uc *ChatUseCase) prepareLLMStream(ctx context.Context, provider database.RepositoryProvider, conversation *chat.Conversation, userID, modelID uuid.UUID) (*llmStreamRequest, *chat.UserProviderSetting, error) {
+	convModel, err := provider.Model().GetByID(ctx, modelID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get model: %w", err)
+	}
+	if convModel.SupportsEmbeddings {
+		return nil, nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("Model '%s' is an embedding model and cannot be used for chat", convModel.Name), nil)
+	}
+	convProvider, err := provider.Provider().GetByID(ctx, convModel.ProviderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get provider for model: %w", err)
+	}
+
+	// Get the user's provider settings, or those of the conversation's organization
+	userSetting, err := provider.UserProviderSetting().GetForUser(ctx, userID, convProvider.ID, conversation.OrganizationID)
+	if err != nil {
+		if err == errors.ErrUserProviderSettingNotFound {
+			return nil, nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not configured. Please add it in settings.", convProvider.DisplayName), err)
+		}
+		return nil, nil, fmt.Errorf("failed to get user provider settings: %w", err)
+	}
+	if !userSetting.IsActive || userSetting.EncryptedAPIKey == nil || *userSetting.EncryptedAPIKey == "" {
+		return nil, nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not active or not set.", convProvider.DisplayName), nil)
+	}
+
+	streamReq := &llmStreamRequest{
+		provider:       convProvider,
+		model:          convModel,
+		conversationID: conversation.ID,
+		userID:         userID,
+	}
+
+	// Offer tools only to models that support function calling
+	if convModel.SupportsFunctions {
+		tools, err := provider.Tool().GetAvailableTools(ctx, &convProvider.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get available tools: %w", err)
+		}
+		streamReq.tools = uc.toolRegistry.Filter(tools)
+	}
+
+	return streamReq, userSetting, nil
+}
 
+// startLLMStream decrypts the user's API key and runs processLLMStream in the background.
+// The preamble events are sent to the client before the completion starts.
+func (uc *ChatUseCase) startLLMStream(req *llmStreamRequest, userSetting *chat.UserProviderSetting, preamble ...services.ChatStreamEvent) <-chan services.ChatStreamEvent {
 	// Decrypt API Key before starting goroutine
-	encryptionKey, err := uc.config.GetEncryptionKey()
+	keyring, err := uc.config.GetKeyring()
 	if err != nil {
 		// Create a channel to send a single error event and then close it.
 		errorChan := make(chan services.ChatStreamEvent, 1)
 		errorChan <- services.ChatStreamEvent{Error: fmt.Errorf("failed to get encryption key: %w", err), IsLast: true}
 		close(errorChan)
-		return errorChan, nil
+		return errorChan
 	}
-	
-	decryptedAPIKey, err := utils.Decrypt(*userSetting.EncryptedAPIKey, encryptionKey)
+
+	decryptedAPIKey, err := keyring.Decrypt(*userSetting.EncryptedAPIKey)
 	if err != nil {
 		// Create a channel to send a single error event and then close it.
 		errorChan := make(chan services.ChatStreamEvent, 1)
 		errorChan <- services.ChatStreamEvent{Error: fmt.Errorf("failed to decrypt API key: %w", err), IsLast: true}
 		close(errorChan)
-		return errorChan, nil
+		return errorChan
 	}
 
-	apiBaseOverride := ""
+	req.apiKey = decryptedAPIKey
 	if userSetting.APIBaseOverride != nil {
-		apiBaseOverride = *userSetting.APIBaseOverride
+		req.apiBaseOverride = *userSetting.APIBaseOverride
 	}
 
-	// This part happens outside the transaction
-	// 6. Start LLM stream and process response in a separate goroutine
-	go uc.processLLMStream(context.Background(), convProvider, convModel, conversationID, conversationHistory, clientEventChannel, decryptedAPIKey, apiBaseOverride)
+	// This channel will be returned to the handler for streaming to the client.
+	clientEventChannel := make(chan services.ChatStreamEvent, len(preamble))
+	for _, event := range preamble {
+		clientEventChannel <- event
+	}
+	go uc.processLLMStream(context.Background(), req, clientEventChannel)
 
-	return clientEventChannel, nil
+	return clientEventChannel
 }
 
-func (uc *ChatUseCase) processLLMStream(ctx context.Context, llmProvider *chat.Provider, llmModel *chat.Model, conversationID uuid.UUID, messages []*chat.Message, clientEventChannel chan<- services.ChatStreamEvent, apiKey, apiBaseOverride string) {
+// processLLMStream streams the completion to the client and persists the result.
+// When the model requests tool calls, they are executed and their results fed back
+// to the model until it produces a final answer or maxToolIterations is reached.
+func (uc *ChatUseCase) processLLMStream(ctx context.Context, req *llmStreamRequest, clientEventChannel chan<- services.ChatStreamEvent) {
 	defer close(clientEventChannel)
 
-	llmEventCh, err := uc.llmService.StreamChatCompletion(ctx, llmProvider, llmModel, messages, apiKey, apiBaseOverride)
-	if err != nil {
-		log.Printf("Error starting LLM stream for conversation %s: %v", conversationID, err)
-		clientEventChannel <- services.ChatStreamEvent{Error: err, IsLast: true}
-		return
-	}
+	conversationID := req.conversationID
+	messages := req.messages
+	titleChecked := false
 
-	var responseContent strings.Builder
+	for iteration := 0; ; iteration++ {
+		// On the last allowed round, withhold tools so the model has to answer.
+		tools := req.tools
+		if iteration >= maxToolIterations {
+			tools = nil
+		}
 
-	for event := range llmEventCh {
-		// Forward the event to the client-facing channel
-		clientEventChannel <- event
+		llmEventCh, err := uc.llmService.StreamChatCompletion(ctx, req.provider, req.model, messages, tools, req.apiKey, req.apiBaseOverride)
+		if err != nil {
+			log.Printf("Error starting LLM stream for conversation %s: %v", conversationID, err)
+			clientEventChannel <- services.ChatStreamEvent{Error: err, IsLast: true}
+			return
+		}
+
+		var responseContent strings.Builder
+		var toolCalls []*services.ToolCall
+
+		for event := range llmEventCh {
+			if event.IsLast && len(event.ToolCalls) > 0 {
+				// More work to do: tell the client which tools are running, but keep the stream open.
+				toolCalls = event.ToolCalls
+				event.IsLast = false
+			}
+
+			// Forward the event to the client-facing channel
+			clientEventChannel <- event
+
+			if event.Error != nil {
+				log.Printf("Error during LLM stream for conversation %s: %v", conversationID, event.Error)
+				// The error has been forwarded, so we just stop processing.
+				return
+			}
+
+			responseContent.WriteString(event.ContentDelta)
+
+			if event.IsLast || toolCalls != nil {
+				break // Exit the loop cleanly after the last event
+			}
+		}
+
+		log.Printf("LLM stream finished for conversation %s. Full response: %s", conversationID, responseContent.String())
 
-		if event.Error != nil {
-			log.Printf("Error during LLM stream for conversation %s: %v", conversationID, event.Error)
-			// The error has been forwarded, so we just stop processing.
+		// Save the assistant's message
+		assistantMessage := &chat.Message{
+			ID:             uuid.New(), // Generate ID upfront to potentially send it with the stream
+			ConversationID: conversationID,
+			Role:           shared.MessageRoleAssistant,
+			Content:        responseContent.String(),
+			ModelID:        &req.model.ID,
+		}
+		if len(toolCalls) > 0 {
+			assistantMessage.Metadata = shared.JSONB{services.MetadataKeyToolCalls: toolCalls}
+		}
+		if len(req.citations) > 0 {
+			if assistantMessage.Metadata == nil {
+				assistantMessage.Metadata = shared.JSONB{}
+			}
+			assistantMessage.Metadata[services.MetadataKeyCitations] = req.citations
+		}
+
+		createdMsg, err := uc.saveAssistantMessage(ctx, assistantMessage, !titleChecked)
+		titleChecked = true
+		if err != nil {
+			log.Printf("Failed to save assistant's response for conversation %s: %v", conversationID, err)
+			if len(toolCalls) > 0 {
+				clientEventChannel <- services.ChatStreamEvent{Error: err, IsLast: true}
+			}
 			return
 		}
-		
-		responseContent.WriteString(event.ContentDelta)
 
-		if event.IsLast {
-			break // Exit the loop cleanly after the last event
+		if len(toolCalls) == 0 {
+			uc.indexExchange(req, createdMsg)
+			if req.memoryEnabled {
+				uc.memoryUseCase.ExtractMemories(req, createdMsg)
+			}
+			return
+		}
+
+		// Keep the in-memory history in sync so the next round sees the tool results.
+		createdMsg.Metadata = assistantMessage.Metadata
+		messages = append(messages, createdMsg)
+		awaitingConfirmation := false
+		for _, call := range toolCalls {
+			var toolMessage *chat.Message
+			var result *services.ToolCallResult
+			// Calls the caller's token may not make are answered right away instead
+			// of being held for a confirmation it could not give.
+			if uc.toolRegistry.RequiresConfirmation(call.Name) && uc.toolRegistry.MissingScope(call.Name, req.scopes) == "" {
+				toolMessage, result = uc.holdToolCall(ctx, req, createdMsg.ID, call)
+				awaitingConfirmation = true
+			} else {
+				toolMessage, result = uc.executeToolCall(ctx, req, createdMsg.ID, call, nil)
+			}
+			clientEventChannel <- services.ChatStreamEvent{ToolResult: result}
+			if toolMessage != nil {
+				messages = append(messages, toolMessage)
+			}
+		}
+
+		// The turn resumes from ConfirmToolCall once the user has decided.
+		if awaitingConfirmation {
+			clientEventChannel <- services.ChatStreamEvent{IsLast: true}
+			return
 		}
 	}
-	
-	log.Printf("LLM stream finished for conversation %s. Full response: %s", conversationID, responseContent.String())
-	
-	// Save the assistant's message
-	assistantMessage := &chat.Message{
-		ID:             uuid.New(), // Generate ID upfront to potentially send it with the stream
-		ConversationID: conversationID,
-		Role:           shared.MessageRoleAssistant,
-		Content:        responseContent.String(),
+}
+
+// indexExchange embeds the user message that started the turn and the final answer
+// for semantic search.
+func (uc *ChatUseCase) indexExchange(req *llmStreamRequest, answer *chat.Message) {
+	if uc.messageSearcher == nil {
+		return
+	}
+	messages := []*chat.Message{answer}
+	if req.userMessage != nil {
+		messages = append(messages, req.userMessage)
 	}
+	uc.messageSearcher.IndexMessages(req.userID, messages)
+}
+
+// saveAssistantMessage persists an assistant message and, on the first exchange
+// of a conversation, triggers title generation.
+func (uc *ChatUseCase) saveAssistantMessage(ctx context.Context, assistantMessage *chat.Message, checkTitle bool) (*chat.Message, error) {
+	conversationID := assistantMessage.ConversationID
 
+	var createdMsg *chat.Message
 	var shouldGenerateTitle bool
 	var userMessage string
-	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
-		createdMsg, err := provider.Message().Create(ctx, assistantMessage)
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		createdMsg, err = provider.Message().Create(ctx, assistantMessage)
 		if err != nil {
 			return fmt.Errorf("failed to save assistant message: %w", err)
 		}
@@ -226,7 +569,11 @@ func (uc *ChatUseCase) processLLMStream(ctx context.Context, llmProvider *chat.P
 			// Log this error but don't fail the whole operation, as the message is already saved.
 			log.Printf("Failed to update conversation timestamp after assistant message for conversation %s: %v", conversationID, err)
 		}
-		
+
+		if !checkTitle {
+			return nil
+		}
+
 		// Check if we should generate a title (first exchange complete)
 		log.Printf("Checking if should generate title for conversation %s", conversationID)
 		shouldGenerate, err := uc.conversationUseCase.CheckShouldGenerateTitleWithProvider(provider, ctx, conversationID)
@@ -234,12 +581,12 @@ func (uc *ChatUseCase) processLLMStream(ctx context.Context, llmProvider *chat.P
 			log.Printf("Failed to check if should generate title for conversation %s: %v", conversationID, err)
 			return nil
 		}
-		
+
 		log.Printf("Should generate title for conversation %s: %v", conversationID, shouldGenerate)
 		shouldGenerateTitle = shouldGenerate
 		if shouldGenerate {
 			log.Printf("First exchange complete for conversation %s, will generate title", conversationID)
-			// Get the conversation messages - since we just added the assistant message, 
+			// Get the conversation messages - since we just added the assistant message,
 			// the conversation now has 2 messages total (user + assistant)
 			allMessages, err := provider.Message().GetByConversationID(ctx, conversationID, 10, 0)
 			if err != nil {
@@ -259,19 +606,213 @@ func (uc *ChatUseCase) processLLMStream(ctx context.Context, llmProvider *chat.P
 				log.Printf("No user message found for title generation")
 			}
 		}
-		
+
 		return nil
 	})
 
 	if err != nil {
-		log.Printf("Failed to save assistant's response for conversation %s: %v", conversationID, err)
-	} else if shouldGenerateTitle && userMessage != "" {
+		return nil, err
+	}
+	if shouldGenerateTitle && userMessage != "" {
 		log.Printf("Triggering title generation for conversation %s", conversationID)
 		// Trigger title generation asynchronously
-		uc.conversationUseCase.GenerateConversationTitle(ctx, conversationID, userMessage, responseContent.String())
-	} else {
+		uc.conversationUseCase.GenerateConversationTitle(ctx, conversationID, userMessage, assistantMessage.Content)
+	} else if checkTitle {
 		log.Printf("Not triggering title generation - shouldGenerateTitle: %v, userMessage empty: %v", shouldGenerateTitle, userMessage == "")
 	}
+	return createdMsg, nil
+}
+
+// holdToolCall answers a call to a tool that needs user confirmation with a placeholder
+// tool message. The call runs once the user approves it through ConfirmToolCall.
+func (uc *ChatUseCase) holdToolCall(ctx context.Context, req *llmStreamRequest, assistantMessageID uuid.UUID, call *services.ToolCall) (*chat.Message, *services.ToolCallResult) {
+	result := &services.ToolCallResult{ToolCallID: call.ID, Name: call.Name, PendingConfirmation: true}
+	placeholder := &chat.Message{
+		ConversationID: req.conversationID,
+		ParentID:       &assistantMessageID,
+		Role:           shared.MessageRoleTool,
+		Content:        `{"status":"pending_confirmation","message":"The call has not run yet. The user has been asked to approve it."}`,
+		Metadata: shared.JSONB{
+			services.MetadataKeyToolCallID: call.ID,
+			services.MetadataKeyToolName:   call.Name,
+			services.MetadataKeyToolStatus: services.ToolStatusPendingConfirmation,
+		},
+	}
+
+	var toolMessage *chat.Message
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		toolMessage, err = provider.Message().Create(ctx, placeholder)
+		if err != nil {
+			return fmt.Errorf("failed to save tool message: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to hold tool %s for confirmation in conversation %s: %v", call.Name, req.conversationID, err)
+		result.PendingConfirmation = false
+		result.Error = err.Error()
+		return placeholder, result
+	}
+	return toolMessage, result
+}
+
+// executeToolCall runs a single tool call, records it in message_tools, stores the
+// result as a tool message and attaches any produced artifacts to the assistant message.
+// When answer is set, the result is written to that existing tool message instead.
+func (uc *ChatUseCase) executeToolCall(ctx context.Context, req *llmStreamRequest, assistantMessageID uuid.UUID, call *services.ToolCall, answer *chat.Message) (*chat.Message, *services.ToolCallResult) {
+	result := &services.ToolCallResult{ToolCallID: call.ID, Name: call.Name}
+
+	var toolDef *chat.Tool
+	for _, t := range req.tools {
+		if t.Name == call.Name {
+			toolDef = t
+			break
+		}
+	}
+	handler, ok := uc.toolRegistry.Get(call.Name)
+
+	var output shared.JSONB
+	var toolResult *services.ToolResult
+	var execErr error
+	startedAt := time.Now()
+	if toolDef == nil || !ok {
+		execErr = fmt.Errorf("tool %s is not available", call.Name)
+	} else if scope := uc.toolRegistry.MissingScope(call.Name, req.scopes); scope != "" {
+		execErr = fmt.Errorf("API token lacks the %s scope", scope)
+	} else {
+		toolCtx, cancel := context.WithTimeout(ctx, toolExecutionTimeout)
+		toolResult, execErr = handler.Execute(toolCtx, &services.ToolInvocation{
+			UserID:         req.userID,
+			ConversationID: req.conversationID,
+			MessageID:      assistantMessageID,
+			Arguments:      json.RawMessage(call.Arguments),
+			Scopes:         req.scopes,
+		})
+		cancel()
+	}
+	duration := time.Since(startedAt)
+
+	if execErr != nil {
+		log.Printf("Tool %s failed for conversation %s: %v", call.Name, req.conversationID, execErr)
+		result.Error = execErr.Error()
+		output = shared.JSONB{"error": execErr.Error()}
+	} else {
+		result.Success = true
+		output = toolResult.Output
+	}
+
+	content, err := json.Marshal(output)
+	if err != nil {
+		content = []byte(fmt.Sprintf(`{"error":%q}`, err.Error()))
+	}
+
+	var toolMessage *chat.Message
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if toolDef != nil {
+			var input shared.JSONB
+			if err := json.Unmarshal([]byte(call.Arguments), &input); err != nil {
+				input = shared.JSONB{"raw": call.Arguments}
+			}
+			messageTool := &chat.MessageTool{
+				MessageID:  assistantMessageID,
+				ToolID:     toolDef.ID,
+				Input:      input,
+				Output:     output,
+				ExecutedAt: startedAt,
+				Duration:   duration.Milliseconds(),
+				Success:    execErr == nil,
+			}
+			if execErr != nil {
+				errMsg := execErr.Error()
+				messageTool.Error = &errMsg
+			}
+			if err := provider.Tool().LogToolUsage(ctx, messageTool); err != nil {
+				return fmt.Errorf("failed to log tool usage: %w", err)
+			}
+		}
+
+		if toolResult != nil {
+			for _, artifact := range toolResult.Artifacts {
+				artifact.MessageID = assistantMessageID
+				created, err := provider.Artifact().Create(ctx, artifact)
+				if err != nil {
+					return fmt.Errorf("failed to create tool artifact: %w", err)
+				}
+				result.ArtifactIDs = append(result.ArtifactIDs, created.ID.String())
+			}
+		}
+
+		var err error
+		if answer != nil {
+			answer.Content = string(content)
+			toolMessage, err = provider.Message().Update(ctx, answer)
+			if err != nil {
+				return fmt.Errorf("failed to update tool message: %w", err)
+			}
+			return nil
+		}
+		toolMessage, err = provider.Message().Create(ctx, &chat.Message{
+			ConversationID: req.conversationID,
+			ParentID:       &assistantMessageID,
+			Role:           shared.MessageRoleTool,
+			Content:        string(content),
+			Metadata: shared.JSONB{
+				services.MetadataKeyToolCallID: call.ID,
+				services.MetadataKeyToolName:   call.Name,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save tool message: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to persist result of tool %s for conversation %s: %v", call.Name, req.conversationID, err)
+		result.ArtifactIDs = nil
+		// Still answer the call in memory so the model sees a consistent history.
+		toolMessage = &chat.Message{
+			ConversationID: req.conversationID,
+			Role:           shared.MessageRoleTool,
+			Content:        string(content),
+			Metadata:       shared.JSONB{services.MetadataKeyToolCallID: call.ID},
+		}
+	}
+
+	return toolMessage, result
+}
+
+// SemanticSearch returns the user's past messages most similar in meaning to the query.
+func (uc *ChatUseCase) SemanticSearch(ctx context.Context, userID uuid.UUID, req *SemanticSearchRequest) ([]*MessageSearchResultResponse, error) {
+	if uc.messageSearcher == nil {
+		return nil, errors.NewAppError(errors.CodeConfiguration, "Semantic search is not enabled", nil)
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errors.NewAppError(errors.CodeValidation, "Query parameter 'q' is required", nil)
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSemanticSearchLimit
+	}
+
+	matches, err := uc.messageSearcher.SearchMessages(ctx, userID, req.Query, uuid.Nil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*MessageSearchResultResponse, len(matches))
+	for i, m := range matches {
+		response[i] = &MessageSearchResultResponse{
+			MessageID:         m.MessageID,
+			ConversationID:    m.ConversationID,
+			ConversationTitle: m.ConversationTitle,
+			Role:              string(m.Role),
+			Content:           m.Content,
+			Similarity:        m.Similarity,
+			CreatedAt:         m.CreatedAt,
+		}
+	}
+	return response, nil
 }
 
 // GetAvailableTools retrieves all available tools, optionally filtered by a provider.
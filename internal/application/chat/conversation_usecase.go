@@ -6,13 +6,14 @@ import (
 	"log"
 	"strings"
 	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/audit"
 	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/organization"
 	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/domain/shared"
 	"trading-alchemist/internal/infrastructure/database"
 	"trading-alchemist/internal/infrastructure/llm/prompts"
 	"trading-alchemist/pkg/errors"
-	"trading-alchemist/pkg/utils"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +23,7 @@ type ConversationUseCase struct {
 	dbService     *database.Service
 	config        *config.Config
 	llmService    services.LLMService
+	auditLogger   services.AuditLogger
 	promptManager *prompts.PromptManager
 }
 
@@ -30,23 +32,40 @@ func NewConversationUseCase(
 	dbService *database.Service,
 	config *config.Config,
 	llmService services.LLMService,
+	auditLogger services.AuditLogger,
 ) *ConversationUseCase {
 	return &ConversationUseCase{
 		dbService:     dbService,
 		config:        config,
 		llmService:    llmService,
+		auditLogger:   auditLogger,
 		promptManager: prompts.NewPromptManager(),
 	}
 }
 
-// CreateConversation creates a new chat conversation.
+// CreateConversation creates a new chat conversation, optionally in one of the
+// user's organizations.
 func (uc *ConversationUseCase) CreateConversation(ctx context.Context, req *CreateConversationRequest) (*ConversationDetailResponse, error) {
+	permission := chat.ConversationPermissionWrite
+	if req.OrganizationPermission != "" {
+		permission = chat.ConversationPermission(req.OrganizationPermission)
+	}
+	if !chat.IsValidOrganizationPermission(permission) {
+		return nil, errors.NewAppError(errors.CodeValidation, "organization_permission must be read or write", nil)
+	}
+
 	var createdConv *chat.Conversation
 
 	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
 		var targetModel *chat.Model
 		var err error
 
+		if req.OrganizationID != nil {
+			if err := checkOrganizationRole(ctx, provider, req.UserID, *req.OrganizationID, organization.RoleMember); err != nil {
+				return err
+			}
+		}
+
 		if req.ModelName != nil && *req.ModelName != "" {
 			// Use the specific model requested
 			parts := strings.Split(*req.ModelName, "/")
@@ -97,12 +116,20 @@ func (uc *ConversationUseCase) CreateConversation(ctx context.Context, req *Crea
 			}
 		}
 
+		if targetModel.SupportsEmbeddings {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("Model '%s' is an embedding model and cannot be used for chat", targetModel.Name), nil)
+		}
+
 		// Create Conversation
 		newConv := &chat.Conversation{
 			UserID:  req.UserID,
 			Title:   req.Title,
 			ModelID: targetModel.ID,
 		}
+		if req.OrganizationID != nil {
+			newConv.OrganizationID = req.OrganizationID
+			newConv.OrganizationPermission = permission
+		}
 		createdConv, err = provider.Conversation().Create(ctx, newConv)
 		if err != nil {
 			return fmt.Errorf("failed to create conversation: %w", err)
@@ -116,22 +143,42 @@ func (uc *ConversationUseCase) CreateConversation(ctx context.Context, req *Crea
 	}
 
 	return &ConversationDetailResponse{
-		ID:      createdConv.ID,
-		Title:   createdConv.Title,
-		ModelID: createdConv.ModelID,
+		ID:                     createdConv.ID,
+		Title:                  createdConv.Title,
+		ModelID:                createdConv.ModelID,
+		OrganizationID:         createdConv.OrganizationID,
+		OrganizationPermission: organizationPermission(createdConv),
+		Permission:             string(chat.ConversationPermissionManage),
 	}, nil
 }
 
-// GetUserConversations retrieves a paginated list of conversations for a specific user.
-func (uc *ConversationUseCase) GetUserConversations(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*ConversationSummaryResponse, error) {
+// GetUserConversations retrieves a paginated list of the conversations a user
+// started or can access through an organization. With an organization, only
+// the conversations of that organization are returned.
+func (uc *ConversationUseCase) GetUserConversations(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, limit, offset int) ([]*ConversationSummaryResponse, error) {
 	var conversations []*chat.Conversation
+	roles := make(map[uuid.UUID]organization.Role)
 
 	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if organizationID != nil {
+			if err := checkOrganizationRole(ctx, provider, userID, *organizationID, organization.RoleMember); err != nil {
+				return err
+			}
+		}
+
 		var err error
-		conversations, err = provider.Conversation().GetByUserID(ctx, userID, limit, offset)
+		conversations, err = provider.Conversation().ListAccessibleByUserID(ctx, userID, organizationID, limit, offset)
 		if err != nil {
 			return fmt.Errorf("failed to get user conversations: %w", err)
 		}
+
+		organizations, err := provider.Organization().ListByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user organizations: %w", err)
+		}
+		for _, org := range organizations {
+			roles[org.ID] = org.Role
+		}
 		return nil
 	})
 
@@ -142,11 +189,18 @@ func (uc *ConversationUseCase) GetUserConversations(ctx context.Context, userID
 	// Convert to DTOs
 	response := make([]*ConversationSummaryResponse, len(conversations))
 	for i, conv := range conversations {
+		var role organization.Role
+		if conv.OrganizationID != nil {
+			role = roles[*conv.OrganizationID]
+		}
 		response[i] = &ConversationSummaryResponse{
-			ID:            conv.ID,
-			Title:         conv.Title,
-			LastMessageAt: conv.LastMessageAt,
-			ModelID:       conv.ModelID,
+			ID:                     conv.ID,
+			Title:                  conv.Title,
+			LastMessageAt:          conv.LastMessageAt,
+			ModelID:                conv.ModelID,
+			OrganizationID:         conv.OrganizationID,
+			OrganizationPermission: organizationPermission(conv),
+			Permission:             string(permissionFor(conv, userID, role)),
 		}
 	}
 
@@ -156,19 +210,15 @@ func (uc *ConversationUseCase) GetUserConversations(ctx context.Context, userID
 // GetConversationDetails retrieves the full details of a single conversation, including its messages.
 func (uc *ConversationUseCase) GetConversationDetails(ctx context.Context, conversationID, userID uuid.UUID) (*ConversationDetailResponse, error) {
 	var conversation *chat.Conversation
+	var permission chat.ConversationPermission
 	var messages []*chat.Message
 	var artifactsMap map[uuid.UUID][]*chat.Artifact
 
 	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
 		var err error
-		conversation, err = provider.Conversation().GetByID(ctx, conversationID)
+		conversation, permission, err = uc.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionRead)
 		if err != nil {
-			return fmt.Errorf("failed to get conversation: %w", err)
-		}
-
-		// Security check: ensure the user owns the conversation
-		if conversation.UserID != userID {
-			return errors.ErrForbidden
+			return err
 		}
 
 		// Fetch last 100 messages for now (should be paginated in production)
@@ -213,29 +263,25 @@ func (uc *ConversationUseCase) GetConversationDetails(ctx context.Context, conve
 		Title:        conversation.Title,
 		ModelID:      conversation.ModelID,
 		SystemPrompt: conversation.SystemPrompt,
+		Settings:     JSONB(conversation.Settings),
 		Messages:     messageDTOs,
+
+		OrganizationID:         conversation.OrganizationID,
+		OrganizationPermission: organizationPermission(conversation),
+		Permission:             string(permission),
 	}, nil
 }
 
 // UpdateConversationTitle updates the title of a conversation.
 func (uc *ConversationUseCase) UpdateConversationTitle(ctx context.Context, conversationID, userID uuid.UUID, req *UpdateConversationTitleRequest) error {
 	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
-		// First verify the conversation exists and user owns it
-		conversation, err := provider.Conversation().GetByID(ctx, conversationID)
-		if err != nil {
-			if err == errors.ErrConversationNotFound {
-				return errors.NewAppError(errors.CodeNotFound, "Conversation not found", err)
-			}
-			return fmt.Errorf("failed to get conversation: %w", err)
-		}
-
-		// Security check: ensure the user owns the conversation
-		if conversation.UserID != userID {
-			return errors.ErrForbidden
+		// First verify the conversation exists and the user may write to it
+		if _, _, err := uc.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionWrite); err != nil {
+			return err
 		}
 
 		// Update the title
-		err = provider.Conversation().UpdateTitle(ctx, conversationID, req.Title)
+		err := provider.Conversation().UpdateTitle(ctx, conversationID, req.Title)
 		if err != nil {
 			return fmt.Errorf("failed to update conversation title: %w", err)
 		}
@@ -244,25 +290,57 @@ func (uc *ConversationUseCase) UpdateConversationTitle(ctx context.Context, conv
 	})
 }
 
-// ArchiveConversation archives (soft deletes) a conversation.
-func (uc *ConversationUseCase) ArchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
-	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
-		// First verify the conversation exists and user owns it
-		conversation, err := provider.Conversation().GetByID(ctx, conversationID)
+// UpdateConversationSettings replaces the retrieval settings of a conversation and
+// returns its settings. Other settings are kept.
+func (uc *ConversationUseCase) UpdateConversationSettings(ctx context.Context, conversationID, userID uuid.UUID, req *UpdateConversationSettingsRequest) (JSONB, error) {
+	if req.RetrievalTopK != nil && (*req.RetrievalTopK < 1 || *req.RetrievalTopK > chat.MaxRetrievalTopK) {
+		return nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("retrieval_top_k must be between 1 and %d", chat.MaxRetrievalTopK), nil)
+	}
+	if req.RetrievalMinSimilarity != nil && (*req.RetrievalMinSimilarity < 0 || *req.RetrievalMinSimilarity > 1) {
+		return nil, errors.NewAppError(errors.CodeValidation, "retrieval_min_similarity must be between 0 and 1", nil)
+	}
+
+	var updated *chat.Conversation
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		conversation, _, err := uc.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionManage)
 		if err != nil {
-			if err == errors.ErrConversationNotFound {
-				return errors.NewAppError(errors.CodeNotFound, "Conversation not found", err)
-			}
-			return fmt.Errorf("failed to get conversation: %w", err)
+			return err
 		}
 
-		// Security check: ensure the user owns the conversation
-		if conversation.UserID != userID {
-			return errors.ErrForbidden
+		if conversation.Settings == nil {
+			conversation.Settings = shared.JSONB{}
+		}
+		delete(conversation.Settings, chat.SettingRetrievalTopK)
+		delete(conversation.Settings, chat.SettingRetrievalMinSimilarity)
+		if req.RetrievalTopK != nil {
+			conversation.Settings[chat.SettingRetrievalTopK] = *req.RetrievalTopK
+		}
+		if req.RetrievalMinSimilarity != nil {
+			conversation.Settings[chat.SettingRetrievalMinSimilarity] = *req.RetrievalMinSimilarity
+		}
+
+		updated, err = provider.Conversation().Update(ctx, conversation)
+		if err != nil {
+			return fmt.Errorf("failed to update conversation settings: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return JSONB(updated.Settings), nil
+}
+
+// ArchiveConversation archives (soft deletes) a conversation.
+func (uc *ConversationUseCase) ArchiveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		// First verify the conversation exists and the user may manage it
+		if _, _, err := uc.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionManage); err != nil {
+			return err
 		}
 
 		// Archive the conversation
-		err = provider.Conversation().Archive(ctx, conversationID)
+		err := provider.Conversation().Archive(ctx, conversationID)
 		if err != nil {
 			return fmt.Errorf("failed to archive conversation: %w", err)
 		}
@@ -271,6 +349,143 @@ func (uc *ConversationUseCase) ArchiveConversation(ctx context.Context, conversa
 	})
 }
 
+// ShareConversation moves a conversation into one of the user's organizations,
+// whose members then get the given permission, or back out of it. Only users
+// who can manage the conversation can share it.
+func (uc *ConversationUseCase) ShareConversation(ctx context.Context, conversationID, userID uuid.UUID, req *ShareConversationRequest) (*ConversationSummaryResponse, error) {
+	permission := chat.ConversationPermissionWrite
+	if req.Permission != "" {
+		permission = chat.ConversationPermission(req.Permission)
+	}
+	if !chat.IsValidOrganizationPermission(permission) {
+		return nil, errors.NewAppError(errors.CodeValidation, "permission must be read or write", nil)
+	}
+
+	var conversation *chat.Conversation
+	var current chat.ConversationPermission
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		conversation, _, err = uc.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionManage)
+		if err != nil {
+			return err
+		}
+		if req.OrganizationID != nil {
+			if err := checkOrganizationRole(ctx, provider, userID, *req.OrganizationID, organization.RoleMember); err != nil {
+				return err
+			}
+		}
+		if err := provider.Conversation().SetOrganization(ctx, conversationID, req.OrganizationID, permission); err != nil {
+			return err
+		}
+
+		// Moving a conversation out of an organization can take away the
+		// access of an admin who shared it, so report what is left.
+		conversation.OrganizationID = req.OrganizationID
+		conversation.OrganizationPermission = permission
+		current, err = conversationPermission(ctx, provider, conversation, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	event := audit.UserEvent(audit.ActionConversationShared, userID).
+		On(audit.ResourceConversation, conversationID).
+		With("permission", string(permission))
+	if req.OrganizationID != nil {
+		event.With("organization_id", req.OrganizationID.String())
+	}
+	uc.auditLogger.Log(ctx, event)
+
+	return &ConversationSummaryResponse{
+		ID:                     conversation.ID,
+		Title:                  conversation.Title,
+		LastMessageAt:          conversation.LastMessageAt,
+		ModelID:                conversation.ModelID,
+		OrganizationID:         conversation.OrganizationID,
+		OrganizationPermission: organizationPermission(conversation),
+		Permission:             string(current),
+	}, nil
+}
+
+// LoadConversationWithProvider checks that the conversation exists and that the
+// user has at least the given permission on it, and returns it with the user's
+// permission. It uses an existing provider to run inside the caller's transaction.
+func (uc *ConversationUseCase) LoadConversationWithProvider(provider database.RepositoryProvider, ctx context.Context, conversationID, userID uuid.UUID, required chat.ConversationPermission) (*chat.Conversation, chat.ConversationPermission, error) {
+	conversation, err := provider.Conversation().GetByID(ctx, conversationID)
+	if err != nil {
+		if err == errors.ErrConversationNotFound {
+			return nil, "", errors.NewAppError(errors.CodeNotFound, "Conversation not found", err)
+		}
+		return nil, "", fmt.Errorf("failed to get conversation: %w", err)
+	}
+	permission, err := conversationPermission(ctx, provider, conversation, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !permission.Allows(required) {
+		return nil, "", errors.ErrForbidden
+	}
+	return conversation, permission, nil
+}
+
+// conversationPermission returns what the user may do with a conversation. The
+// user who started it and the admins of its organization manage it; the other
+// members of the organization get the permission it was shared with.
+func conversationPermission(ctx context.Context, provider database.RepositoryProvider, conversation *chat.Conversation, userID uuid.UUID) (chat.ConversationPermission, error) {
+	var role organization.Role
+	if conversation.UserID != userID && conversation.OrganizationID != nil {
+		org, err := provider.Organization().GetByID(ctx, *conversation.OrganizationID, userID)
+		if err != nil && err != errors.ErrOrganizationNotFound {
+			return "", fmt.Errorf("failed to get conversation organization: %w", err)
+		}
+		if org != nil {
+			role = org.Role
+		}
+	}
+	return permissionFor(conversation, userID, role), nil
+}
+
+// permissionFor returns what a user with the given role in the conversation's
+// organization may do with it.
+func permissionFor(conversation *chat.Conversation, userID uuid.UUID, role organization.Role) chat.ConversationPermission {
+	switch {
+	case conversation.UserID == userID:
+		return chat.ConversationPermissionManage
+	case conversation.OrganizationID == nil:
+		return ""
+	case role.Allows(organization.RoleAdmin):
+		return chat.ConversationPermissionManage
+	case role.Allows(organization.RoleMember):
+		return conversation.OrganizationPermission
+	}
+	return ""
+}
+
+// organizationPermission returns the permission the conversation's organization
+// members have, or an empty string for a personal conversation.
+func organizationPermission(conversation *chat.Conversation) string {
+	if conversation.OrganizationID == nil {
+		return ""
+	}
+	return string(conversation.OrganizationPermission)
+}
+
+// checkOrganizationRole checks that the organization exists and that the user
+// has at least the given role in it.
+func checkOrganizationRole(ctx context.Context, provider database.RepositoryProvider, userID, organizationID uuid.UUID, role organization.Role) error {
+	org, err := provider.Organization().GetByID(ctx, organizationID, userID)
+	if err != nil {
+		if err == errors.ErrOrganizationNotFound {
+			return errors.NewAppError(errors.CodeNotFound, "Organization not found", err)
+		}
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	if !org.Role.Allows(role) {
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
 // GenerateConversationTitle generates a descriptive title for a conversation based on the first exchange.
 // This method is called asynchronously after the first assistant response.
 func (uc *ConversationUseCase) GenerateConversationTitle(ctx context.Context, conversationID uuid.UUID, userMessage, assistantMessage string) {
@@ -434,8 +649,8 @@ func (uc *ConversationUseCase) generateTitleWithLLM(ctx context.Context, convers
 			return fmt.Errorf("gpt-4o-mini model not found")
 		}
 
-		// Get user's API key for OpenAI
-		userSetting, err = provider.UserProviderSetting().GetByUserIDAndProviderID(ctx, conversation.UserID, titleProvider.ID)
+		// Get the API key for OpenAI of the user who started the conversation or its organization
+		userSetting, err = provider.UserProviderSetting().GetForUser(ctx, conversation.UserID, titleProvider.ID, conversation.OrganizationID)
 		if err != nil {
 			return fmt.Errorf("failed to get user API key for OpenAI: %w", err)
 		}
@@ -453,13 +668,13 @@ func (uc *ConversationUseCase) generateTitleWithLLM(ctx context.Context, convers
 	}
 
 	// Decrypt the API key
-	encryptionKey, err := uc.config.GetEncryptionKey()
+	keyring, err := uc.config.GetKeyring()
 	if err != nil {
 		log.Printf("Failed to get encryption key: %v", err)
 		return uc.generateFallbackTitle(userMessage), nil
 	}
 	
-	decryptedAPIKey, err := utils.Decrypt(*userSetting.EncryptedAPIKey, encryptionKey)
+	decryptedAPIKey, err := keyring.Decrypt(*userSetting.EncryptedAPIKey)
 	if err != nil {
 		log.Printf("Failed to decrypt API key: %v", err)
 		return uc.generateFallbackTitle(userMessage), nil
@@ -485,7 +700,7 @@ func (uc *ConversationUseCase) generateTitleWithLLM(ctx context.Context, convers
 
 	// Call LLM service for title generation
 	log.Printf("Making LLM call for title generation using user's API key")
-	llmEventCh, err := uc.llmService.StreamChatCompletion(ctx, titleProvider, titleModel, messages, decryptedAPIKey, apiBaseOverride)
+	llmEventCh, err := uc.llmService.StreamChatCompletion(ctx, titleProvider, titleModel, messages, nil, decryptedAPIKey, apiBaseOverride)
 	if err != nil {
 		log.Printf("Failed to start LLM stream for title generation: %v", err)
 		return uc.generateFallbackTitle(userMessage), nil
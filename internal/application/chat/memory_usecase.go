@@ -0,0 +1,312 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/internal/infrastructure/llm/prompts"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	memoryExtractionPromptName = "memory_extraction"
+	// memoryExtractionTimeout bounds the background extraction after an exchange.
+	memoryExtractionTimeout = 2 * time.Minute
+	// maxExtractionMessageLength is the length in characters of each message of
+	// the exchange shown to the extraction prompt.
+	maxExtractionMessageLength = 4000
+)
+
+// MemoryUseCase manages what the assistant remembers about users across
+// conversations: facts are extracted from each exchange, recalled in the system
+// prompt of later ones, and can be reviewed and edited by the user.
+type MemoryUseCase struct {
+	dbService     *database.Service
+	llmService    services.LLMService
+	promptManager *prompts.PromptManager
+}
+
+// NewMemoryUseCase creates a new MemoryUseCase instance.
+func NewMemoryUseCase(dbService *database.Service, llmService services.LLMService) *MemoryUseCase {
+	return &MemoryUseCase{
+		dbService:     dbService,
+		llmService:    llmService,
+		promptManager: prompts.NewPromptManager(),
+	}
+}
+
+// ListMemories returns everything remembered about the user, most recently updated first.
+func (uc *MemoryUseCase) ListMemories(ctx context.Context, userID uuid.UUID) ([]*MemoryResponse, error) {
+	var memories []*chat.UserMemory
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		memories, err = provider.UserMemory().ListByUserID(ctx, userID, chat.MaxMemoriesPerUser)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*MemoryResponse, len(memories))
+	for i, m := range memories {
+		responses[i] = toMemoryResponse(m)
+	}
+	return responses, nil
+}
+
+// UpdateMemory corrects a memory of the user.
+func (uc *MemoryUseCase) UpdateMemory(ctx context.Context, userID, memoryID uuid.UUID, req *UpdateMemoryRequest) (*MemoryResponse, error) {
+	var updated *chat.UserMemory
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		memory, err := loadMemory(ctx, provider, userID, memoryID)
+		if err != nil {
+			return err
+		}
+		memory.Category = chat.MemoryCategory(req.Category)
+		memory.Content = req.Content
+		if err := memory.Validate(); err != nil {
+			return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		updated, err = provider.UserMemory().Update(ctx, memory)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toMemoryResponse(updated), nil
+}
+
+// DeleteMemory makes the assistant forget a memory of the user.
+func (uc *MemoryUseCase) DeleteMemory(ctx context.Context, userID, memoryID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadMemory(ctx, provider, userID, memoryID); err != nil {
+			return err
+		}
+		return provider.UserMemory().Delete(ctx, memoryID)
+	})
+}
+
+// recallMemoriesWithProvider returns the memories to add to the system prompt and
+// whether the user has memory turned on. Nothing is recalled when it is off.
+func (uc *MemoryUseCase) recallMemoriesWithProvider(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID) ([]*chat.UserMemory, bool, error) {
+	user, err := provider.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.MemoryEnabled {
+		return nil, false, nil
+	}
+	memories, err := provider.UserMemory().ListByUserID(ctx, userID, chat.MaxMemoriesInPrompt)
+	if err != nil {
+		return nil, false, err
+	}
+	return memories, true, nil
+}
+
+// ExtractMemories asks the model of the conversation for lasting facts about the
+// user in an exchange and stores them in the background. Failures are logged.
+func (uc *MemoryUseCase) ExtractMemories(req *llmStreamRequest, answer *chat.Message) {
+	if req.userMessage == nil || strings.TrimSpace(answer.Content) == "" {
+		return
+	}
+	go uc.extractMemories(req, answer)
+}
+
+func (uc *MemoryUseCase) extractMemories(req *llmStreamRequest, answer *chat.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), memoryExtractionTimeout)
+	defer cancel()
+
+	var existing []*chat.UserMemory
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		existing, err = provider.UserMemory().ListByUserID(ctx, req.userID, chat.MaxMemoriesPerUser)
+		return err
+	})
+	if err != nil {
+		log.Printf("Failed to load memories of user %s: %v", req.userID, err)
+		return
+	}
+
+	data := prompts.MemoryExtractionData{
+		Memories:         make([]prompts.ExtractedMemory, len(existing)),
+		UserMessage:      truncateMessage(req.userMessage.Content),
+		AssistantMessage: truncateMessage(answer.Content),
+	}
+	for i, m := range existing {
+		data.Memories[i] = prompts.ExtractedMemory{Index: i + 1, Category: string(m.Category), Content: m.Content}
+	}
+	systemPrompt, err := uc.promptManager.GetSystemPrompt(memoryExtractionPromptName)
+	if err != nil {
+		log.Printf("Failed to get memory extraction prompt: %v", err)
+		return
+	}
+	userPrompt, err := uc.promptManager.RenderUserPrompt(memoryExtractionPromptName, data)
+	if err != nil {
+		log.Printf("Failed to render memory extraction prompt: %v", err)
+		return
+	}
+
+	messages := []*chat.Message{
+		{Role: shared.MessageRoleSystem, Content: systemPrompt},
+		{Role: shared.MessageRoleUser, Content: userPrompt},
+	}
+	events, err := uc.llmService.StreamChatCompletion(ctx, req.provider, req.model, messages, nil, req.apiKey, req.apiBaseOverride)
+	if err != nil {
+		log.Printf("Failed to start memory extraction for conversation %s: %v", req.conversationID, err)
+		return
+	}
+	var content strings.Builder
+	for event := range events {
+		if event.Error != nil {
+			log.Printf("Error during memory extraction for conversation %s: %v", req.conversationID, event.Error)
+			return
+		}
+		content.WriteString(event.ContentDelta)
+		if event.IsLast {
+			break
+		}
+	}
+
+	extracted, err := parseExtractedMemories(content.String())
+	if err != nil {
+		log.Printf("Failed to parse memories extracted from conversation %s: %v", req.conversationID, err)
+		return
+	}
+	if len(extracted) == 0 {
+		return
+	}
+
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		count := len(existing)
+		for _, e := range extracted {
+			memory := &chat.UserMemory{
+				UserID:               req.userID,
+				Category:             chat.MemoryCategory(e.Category),
+				Content:              e.Content,
+				SourceConversationID: &req.conversationID,
+			}
+			if err := memory.Validate(); err != nil {
+				log.Printf("Skipping memory extracted from conversation %s: %v", req.conversationID, err)
+				continue
+			}
+
+			if e.Replaces > 0 && e.Replaces <= len(existing) {
+				replaced := existing[e.Replaces-1]
+				if replaced.Category == memory.Category && replaced.Content == memory.Content {
+					continue
+				}
+				memory.ID = replaced.ID
+				if _, err := provider.UserMemory().Update(ctx, memory); err != nil && err != errors.ErrUserMemoryNotFound {
+					return err
+				}
+				continue
+			}
+
+			if count >= chat.MaxMemoriesPerUser {
+				log.Printf("User %s has reached the limit of %d memories", req.userID, chat.MaxMemoriesPerUser)
+				return nil
+			}
+			if _, err := provider.UserMemory().Create(ctx, memory); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to store memories extracted from conversation %s: %v", req.conversationID, err)
+	}
+}
+
+// extractedMemory is one fact returned by the memory extraction prompt.
+type extractedMemory struct {
+	Category string `json:"category"`
+	Content  string `json:"content"`
+	Replaces int    `json:"replaces"` // Number of the remembered fact it replaces, 0 for a new one
+}
+
+// parseExtractedMemories reads the JSON answer of the memory extraction prompt,
+// tolerating text or a code fence around the object.
+func parseExtractedMemories(answer string) ([]extractedMemory, error) {
+	start := strings.Index(answer, "{")
+	end := strings.LastIndex(answer, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object in answer")
+	}
+	var result struct {
+		Memories []extractedMemory `json:"memories"`
+	}
+	if err := json.Unmarshal([]byte(answer[start:end+1]), &result); err != nil {
+		return nil, err
+	}
+	return result.Memories, nil
+}
+
+// withMemories returns the history with a system message holding the conversation's
+// system prompt and what is remembered about the user. It is not persisted.
+func withMemories(messages []*chat.Message, systemPrompt *string, memories []*chat.UserMemory) []*chat.Message {
+	var b strings.Builder
+	if systemPrompt != nil && strings.TrimSpace(*systemPrompt) != "" {
+		b.WriteString(strings.TrimSpace(*systemPrompt))
+		b.WriteString("\n\n")
+	}
+	if len(memories) > 0 {
+		b.WriteString("What you know about the user from earlier conversations. ")
+		b.WriteString("Use it to tailor your answers without repeating it back, and prefer what the user says now when it differs:\n")
+		for _, m := range memories {
+			fmt.Fprintf(&b, "- [%s] %s\n", m.Category, m.Content)
+		}
+	}
+	if b.Len() == 0 {
+		return messages
+	}
+
+	result := make([]*chat.Message, 0, len(messages)+1)
+	result = append(result, &chat.Message{Role: shared.MessageRoleSystem, Content: strings.TrimSpace(b.String())})
+	return append(result, messages...)
+}
+
+// loadMemory checks that the memory exists and belongs to the user.
+func loadMemory(ctx context.Context, provider database.RepositoryProvider, userID, memoryID uuid.UUID) (*chat.UserMemory, error) {
+	memory, err := provider.UserMemory().GetByID(ctx, memoryID)
+	if err != nil {
+		if err == errors.ErrUserMemoryNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Memory not found", err)
+		}
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+	if memory.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return memory, nil
+}
+
+// truncateMessage cuts a message to maxExtractionMessageLength characters.
+func truncateMessage(content string) string {
+	content = strings.TrimSpace(content)
+	runes := []rune(content)
+	if len(runes) <= maxExtractionMessageLength {
+		return content
+	}
+	return string(runes[:maxExtractionMessageLength]) + "…"
+}
+
+func toMemoryResponse(m *chat.UserMemory) *MemoryResponse {
+	return &MemoryResponse{
+		ID:                   m.ID,
+		Category:             string(m.Category),
+		Content:              m.Content,
+		SourceConversationID: m.SourceConversationID,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+	}
+}
@@ -55,6 +55,9 @@ func (uc *ModelAvailabilityUseCase) GetAvailableModelsWithAPIKeyStatus(ctx conte
 			
 			// Create tags based on model capabilities and API key status
 			tags := []string{"LLM", "CHAT"}
+			if model.SupportsEmbeddings {
+				tags = []string{"EMBEDDING"}
+			}
 			if model.SupportsVision {
 				tags = append(tags, "VISION")
 			}
@@ -30,6 +30,8 @@ type UserProviderSettingResponse struct {
 	APIBaseOverride     *string   `json:"api_base_override,omitempty"`
 	IsActive            bool      `json:"is_active"`
 	UpdatedAt           time.Time `json:"updated_at"`
+
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"` // Set for an organization's setting
 }
 
 // ModelResponse represents a single model for a provider.
@@ -75,6 +77,7 @@ func ToUserProviderSettingResponse(setting *chat.UserProviderSetting, provider *
 		APIBaseOverride:     setting.APIBaseOverride,
 		IsActive:            setting.IsActive,
 		UpdatedAt:           setting.UpdatedAt,
+		OrganizationID:      setting.OrganizationID,
 	}
 }
 
@@ -82,6 +85,9 @@ func ToUserProviderSettingResponse(setting *chat.UserProviderSetting, provider *
 func ToModelResponse(m *chat.Model) ModelResponse {
 	// Logic to create tags can be more sophisticated based on model properties
 	tags := []string{"LLM", "CHAT"}
+	if m.SupportsEmbeddings {
+		tags = []string{"EMBEDDING"}
+	}
 	if m.SupportsVision {
 		tags = append(tags, "VISION")
 	}
@@ -0,0 +1,120 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+)
+
+// ToolRegistry holds the tool handlers the application can execute on behalf of the LLM.
+type ToolRegistry struct {
+	handlers map[string]services.ToolHandler
+	order    []string
+}
+
+// NewToolRegistry creates a registry containing the given handlers.
+func NewToolRegistry(handlers ...services.ToolHandler) *ToolRegistry {
+	r := &ToolRegistry{handlers: make(map[string]services.ToolHandler)}
+	for _, h := range handlers {
+		r.Register(h)
+	}
+	return r
+}
+
+// Register adds a handler, replacing any existing handler with the same name.
+func (r *ToolRegistry) Register(handler services.ToolHandler) {
+	name := handler.Definition().Name
+	if _, exists := r.handlers[name]; !exists {
+		r.order = append(r.order, name)
+	}
+	r.handlers[name] = handler
+}
+
+// Get returns the handler for a tool name.
+func (r *ToolRegistry) Get(name string) (services.ToolHandler, bool) {
+	h, ok := r.handlers[name]
+	return h, ok
+}
+
+// RequiresConfirmation reports whether calls to the tool must be approved by the user first.
+func (r *ToolRegistry) RequiresConfirmation(name string) bool {
+	h, ok := r.handlers[name].(services.ConfirmableToolHandler)
+	return ok && h.RequiresConfirmation()
+}
+
+// MissingScope returns the scope, e.g. "paper:write", that an API token granted
+// scopes lacks to call the tool, or "" when the call is allowed. nil scopes stand
+// for a user session, which may call every tool.
+func (r *ToolRegistry) MissingScope(name string, scopes []string) string {
+	h, ok := r.handlers[name]
+	if !ok || scopes == nil {
+		return ""
+	}
+	resource, write := h.RequiredScope()
+	if auth.AllowsScope(scopes, resource, write) {
+		return ""
+	}
+	if write {
+		return resource + ":write"
+	}
+	return resource + ":read"
+}
+
+// Definitions returns the definitions of all registered tools in registration order.
+func (r *ToolRegistry) Definitions() []*chat.Tool {
+	defs := make([]*chat.Tool, 0, len(r.order))
+	for _, name := range r.order {
+		defs = append(defs, r.handlers[name].Definition())
+	}
+	return defs
+}
+
+// Filter returns the subset of tools that also have a registered handler.
+// Tools present in the database but not implemented by this binary are dropped.
+func (r *ToolRegistry) Filter(tools []*chat.Tool) []*chat.Tool {
+	var executable []*chat.Tool
+	for _, t := range tools {
+		if _, ok := r.handlers[t.Name]; ok {
+			executable = append(executable, t)
+		}
+	}
+	return executable
+}
+
+// SyncDefinitions makes sure every registered tool has a row in the tools table.
+// Descriptions and schemas are refreshed from code; the is_active flag is left
+// untouched for existing rows so tools can be disabled without a deploy.
+func (r *ToolRegistry) SyncDefinitions(ctx context.Context, dbService *database.Service) error {
+	return dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		for _, def := range r.Definitions() {
+			existing, err := provider.Tool().GetByName(ctx, def.Name)
+			if err != nil {
+				if err != errors.ErrToolNotFound {
+					return fmt.Errorf("failed to look up tool %s: %w", def.Name, err)
+				}
+				if _, err := provider.Tool().Create(ctx, &chat.Tool{
+					Name:        def.Name,
+					Description: def.Description,
+					Schema:      def.Schema,
+					IsActive:    true,
+				}); err != nil {
+					return fmt.Errorf("failed to create tool %s: %w", def.Name, err)
+				}
+				log.Printf("Registered tool %s", def.Name)
+				continue
+			}
+
+			existing.Description = def.Description
+			existing.Schema = def.Schema
+			if _, err := provider.Tool().Update(ctx, existing); err != nil {
+				return fmt.Errorf("failed to update tool %s: %w", def.Name, err)
+			}
+		}
+		return nil
+	})
+}
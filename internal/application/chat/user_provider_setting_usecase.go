@@ -4,25 +4,29 @@ import (
 	"context"
 	"fmt"
 	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/audit"
 	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/infrastructure/database"
 	"trading-alchemist/pkg/errors"
-	"trading-alchemist/pkg/utils"
 
 	"github.com/google/uuid"
 )
 
 // UserProviderSettingUseCase handles business logic for user provider settings.
 type UserProviderSettingUseCase struct {
-	dbService *database.Service
-	config    *config.Config
+	dbService   *database.Service
+	config      *config.Config
+	auditLogger services.AuditLogger
 }
 
 // NewUserProviderSettingUseCase creates a new UserProviderSettingUseCase.
-func NewUserProviderSettingUseCase(dbService *database.Service, config *config.Config) *UserProviderSettingUseCase {
+func NewUserProviderSettingUseCase(dbService *database.Service, config *config.Config, auditLogger services.AuditLogger) *UserProviderSettingUseCase {
 	return &UserProviderSettingUseCase{
-		dbService: dbService,
-		config:    config,
+		dbService:   dbService,
+		config:      config,
+		auditLogger: auditLogger,
 	}
 }
 
@@ -61,13 +65,34 @@ func (uc *UserProviderSettingUseCase) ListProviders(ctx context.Context) ([]Prov
 
 // ListUserSettings returns all of a user's configured provider settings.
 func (uc *UserProviderSettingUseCase) ListUserSettings(ctx context.Context, userID uuid.UUID) ([]UserProviderSettingResponse, error) {
+	return uc.listSettings(ctx, func(provider database.RepositoryProvider) ([]*chat.UserProviderSetting, error) {
+		return provider.UserProviderSetting().ListByUserID(ctx, userID)
+	})
+}
+
+// ListOrganizationSettings returns the provider settings of one of the user's
+// organizations, whose keys serve all its members.
+func (uc *UserProviderSettingUseCase) ListOrganizationSettings(ctx context.Context, userID, organizationID uuid.UUID) ([]UserProviderSettingResponse, error) {
+	return uc.listSettings(ctx, func(provider database.RepositoryProvider) ([]*chat.UserProviderSetting, error) {
+		if err := checkOrganizationRole(ctx, provider, userID, organizationID, organization.RoleMember); err != nil {
+			return nil, err
+		}
+		return provider.UserProviderSetting().ListByOrganizationID(ctx, organizationID)
+	})
+}
+
+// listSettings loads provider settings with the given function and adds their providers.
+func (uc *UserProviderSettingUseCase) listSettings(ctx context.Context, load func(provider database.RepositoryProvider) ([]*chat.UserProviderSetting, error)) ([]UserProviderSettingResponse, error) {
 	var settings []*chat.UserProviderSetting
 	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
 		var err error
-		settings, err = provider.UserProviderSetting().ListByUserID(ctx, userID)
+		settings, err = load(provider)
 		return err
 	})
 	if err != nil {
+		if _, ok := err.(*errors.AppError); ok || err == errors.ErrForbidden {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to list user provider settings: %w", err)
 	}
 
@@ -94,10 +119,30 @@ func (uc *UserProviderSettingUseCase) ListUserSettings(ctx context.Context, user
 
 // UpsertUserProviderSetting creates or updates a user's provider setting.
 func (uc *UserProviderSettingUseCase) UpsertUserProviderSetting(ctx context.Context, userID uuid.UUID, req *UpsertUserProviderSettingRequest) (*UserProviderSettingResponse, error) {
+	return uc.upsertSetting(ctx, userID, &chat.UserProviderSetting{UserID: userID}, req, func(provider database.RepositoryProvider) (*chat.UserProviderSetting, error) {
+		return provider.UserProviderSetting().GetByUserIDAndProviderID(ctx, userID, req.ProviderID)
+	})
+}
+
+// UpsertOrganizationProviderSetting creates or updates the provider setting of an
+// organization, whose key serves all its members. Only admins can change it.
+func (uc *UserProviderSettingUseCase) UpsertOrganizationProviderSetting(ctx context.Context, userID, organizationID uuid.UUID, req *UpsertUserProviderSettingRequest) (*UserProviderSettingResponse, error) {
+	return uc.upsertSetting(ctx, userID, &chat.UserProviderSetting{OrganizationID: &organizationID}, req, func(provider database.RepositoryProvider) (*chat.UserProviderSetting, error) {
+		if err := checkOrganizationRole(ctx, provider, userID, organizationID, organization.RoleAdmin); err != nil {
+			return nil, err
+		}
+		return provider.UserProviderSetting().GetByOrganizationIDAndProviderID(ctx, organizationID, req.ProviderID)
+	})
+}
+
+// upsertSetting updates the setting found with the given function, or creates one
+// for the owner set on the given setting, on behalf of userID.
+func (uc *UserProviderSettingUseCase) upsertSetting(ctx context.Context, userID uuid.UUID, owner *chat.UserProviderSetting, req *UpsertUserProviderSettingRequest, find func(provider database.RepositoryProvider) (*chat.UserProviderSetting, error)) (*UserProviderSettingResponse, error) {
 	var setting *chat.UserProviderSetting
 	var providerInfo *chat.Provider
+	action := audit.ActionProviderKeyCreated
 
-	encryptionKey, err := uc.config.GetEncryptionKey()
+	keyring, err := uc.config.GetKeyring()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get encryption key: %w", err)
 	}
@@ -114,16 +159,20 @@ func (uc *UserProviderSettingUseCase) UpsertUserProviderSetting(ctx context.Cont
 		}
 
 		// Try to get existing setting
-		existingSetting, errTx := provider.UserProviderSetting().GetByUserIDAndProviderID(ctx, userID, req.ProviderID)
+		existingSetting, errTx := find(provider)
 		if errTx != nil && errTx != errors.ErrUserProviderSettingNotFound {
+			if _, ok := errTx.(*errors.AppError); ok || errTx == errors.ErrForbidden {
+				return errTx
+			}
 			return fmt.Errorf("failed to check for existing setting: %w", errTx)
 		}
 
 		if existingSetting != nil {
 			// Update existing setting
+			action = audit.ActionProviderKeyUpdated
 			if req.APIKey != "" {
 				// Only update API key if a new one is provided
-				encryptedAPIKey, err := utils.Encrypt(req.APIKey, encryptionKey)
+				encryptedAPIKey, err := keyring.Encrypt(req.APIKey)
 				if err != nil {
 					return fmt.Errorf("failed to encrypt API key: %w", err)
 				}
@@ -141,13 +190,14 @@ func (uc *UserProviderSettingUseCase) UpsertUserProviderSetting(ctx context.Cont
 				return errors.NewAppError(errors.CodeValidation, "API key is required for new provider settings", nil)
 			}
 			
-			encryptedAPIKey, err := utils.Encrypt(req.APIKey, encryptionKey)
+			encryptedAPIKey, err := keyring.Encrypt(req.APIKey)
 			if err != nil {
 				return fmt.Errorf("failed to encrypt API key: %w", err)
 			}
 			
 			newSetting := &chat.UserProviderSetting{
-				UserID:          userID,
+				UserID:          owner.UserID,
+				OrganizationID:  owner.OrganizationID,
 				ProviderID:      req.ProviderID,
 				EncryptedAPIKey: &encryptedAPIKey,
 				APIBaseOverride: req.APIBaseOverride,
@@ -165,6 +215,14 @@ func (uc *UserProviderSettingUseCase) UpsertUserProviderSetting(ctx context.Cont
 	if err != nil {
 		return nil, err
 	}
+	event := audit.UserEvent(action, userID).
+		On(audit.ResourceProviderKey, setting.ID).
+		With("provider", providerInfo.Name).
+		With("key_changed", req.APIKey != "")
+	if owner.OrganizationID != nil {
+		event.With("organization_id", owner.OrganizationID.String())
+	}
+	uc.auditLogger.Log(ctx, event)
 
 	response := ToUserProviderSettingResponse(setting, providerInfo)
 	return &response, nil
@@ -0,0 +1,77 @@
+package document
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/document"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// UploadDocumentRequest carries an uploaded file. The title defaults to the one
+// found in the file, then to the file name.
+type UploadDocumentRequest struct {
+	Title       string
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// ListDocumentsRequest pages through the user's documents.
+type ListDocumentsRequest struct {
+	Limit  int
+	Offset int
+}
+
+// --- Response DTOs ---
+
+// DocumentResponse represents an uploaded document. Status is processing until
+// its chunks are embedded, then ready, or failed with an error.
+type DocumentResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Title          string    `json:"title"`
+	Filename       string    `json:"filename"`
+	Format         string    `json:"format"`
+	Size           int64     `json:"size"`
+	Status         string    `json:"status"`
+	Error          *string   `json:"error,omitempty"`
+	ChunkCount     int       `json:"chunk_count"`
+	EmbeddingModel *string   `json:"embedding_model,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ToDocumentResponse converts a document to its response.
+func ToDocumentResponse(d *document.Document) *DocumentResponse {
+	return &DocumentResponse{
+		ID:             d.ID,
+		Title:          d.Title,
+		Filename:       d.Filename,
+		Format:         string(d.Format),
+		Size:           d.Size,
+		Status:         string(d.Status),
+		Error:          d.Error,
+		ChunkCount:     d.ChunkCount,
+		EmbeddingModel: d.EmbeddingModel,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+// ChunkResponse represents a passage of a document.
+type ChunkResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Index    int       `json:"index"`
+	Content  string    `json:"content"`
+	Page     *int      `json:"page,omitempty"`
+	Heading  *string   `json:"heading,omitempty"`
+	Embedded bool      `json:"embedded"`
+}
+
+// DocumentDetailResponse represents a document with its chunks.
+type DocumentDetailResponse struct {
+	*DocumentResponse
+	Chunks []*ChunkResponse `json:"chunks"`
+}
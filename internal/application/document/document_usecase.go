@@ -0,0 +1,592 @@
+package document
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+
+	// maxFilenameLength matches the filename column.
+	maxFilenameLength = 255
+	// embedBatchSize is the number of chunks embedded per request.
+	embedBatchSize = 64
+	// indexTimeout bounds the background embedding of a document.
+	indexTimeout = 10 * time.Minute
+	// excerptLength is the length in characters of the excerpts shown with citations.
+	excerptLength = 240
+)
+
+// DocumentUseCase handles uploaded documents: text extraction, chunking,
+// embedding, and retrieval of the passages relevant to a chat message.
+type DocumentUseCase struct {
+	dbService  *database.Service
+	config     *config.Config
+	llmService services.LLMService
+	index      document.VectorIndex
+}
+
+// NewDocumentUseCase creates a new DocumentUseCase instance.
+func NewDocumentUseCase(dbService *database.Service, config *config.Config, llmService services.LLMService, index document.VectorIndex) *DocumentUseCase {
+	return &DocumentUseCase{
+		dbService:  dbService,
+		config:     config,
+		llmService: llmService,
+		index:      index,
+	}
+}
+
+// embedder holds what is needed to call the embedding model on behalf of a user.
+type embedder struct {
+	provider        *chat.Provider
+	model           *chat.Model
+	apiKey          string
+	apiBaseOverride string
+}
+
+// upload is an uploaded file with its text extracted and split into chunks.
+type upload struct {
+	title    string // Title found in the file, if any
+	filename string
+	format   document.Format
+	size     int64
+	hash     string
+	chunks   []*document.Chunk
+}
+
+// UploadDocument extracts and chunks the text of a file, stores the chunks and
+// embeds them in the background. The document is returned while processing.
+func (uc *DocumentUseCase) UploadDocument(ctx context.Context, userID uuid.UUID, req *UploadDocumentRequest) (*DocumentResponse, error) {
+	file, err := uc.extractUpload(req)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = file.title
+	}
+	if title == "" {
+		title = strings.TrimSuffix(file.filename, path.Ext(file.filename))
+	}
+
+	var created *document.Document
+	var emb *embedder
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		// Fail before storing anything when the document cannot be embedded.
+		var err error
+		emb, err = uc.embedderFor(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+
+		created, err = provider.Document().Create(ctx, &document.Document{
+			UserID:      userID,
+			Title:       truncate(title, document.MaxTitleLength),
+			Filename:    file.filename,
+			Format:      file.format,
+			Size:        file.size,
+			ContentHash: file.hash,
+			Status:      document.StatusProcessing,
+			ChunkCount:  len(file.chunks),
+		})
+		if err != nil {
+			return err
+		}
+		for _, chunk := range file.chunks {
+			chunk.DocumentID = created.ID
+		}
+		return provider.DocumentChunk().CreateBatch(ctx, file.chunks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go uc.indexDocument(created, emb)
+	return ToDocumentResponse(created), nil
+}
+
+// ReplaceDocument replaces the file of a user's document. When its text changed,
+// the document is chunked and embedded again, and stays in its knowledge bases.
+func (uc *DocumentUseCase) ReplaceDocument(ctx context.Context, userID, documentID uuid.UUID, req *UploadDocumentRequest) (*DocumentResponse, error) {
+	file, err := uc.extractUpload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *document.Document
+	var emb *embedder
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		doc, err := loadDocument(ctx, provider, userID, documentID)
+		if err != nil {
+			return err
+		}
+		if doc.Status == document.StatusProcessing {
+			return errors.NewAppError(errors.CodeConflict, "Document is still being processed", nil)
+		}
+
+		if title := strings.TrimSpace(req.Title); title != "" {
+			doc.Title = truncate(title, document.MaxTitleLength)
+		}
+		doc.Filename, doc.Format, doc.Size = file.filename, file.format, file.size
+
+		// Unchanged text that is already embedded with the current model needs no indexing.
+		if doc.ContentHash == file.hash && uc.isCurrent(doc) {
+			updated, err = provider.Document().Update(ctx, doc)
+			return err
+		}
+
+		emb, err = uc.embedderFor(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		if err := provider.DocumentChunk().DeleteByDocumentID(ctx, documentID); err != nil {
+			return err
+		}
+		for _, chunk := range file.chunks {
+			chunk.DocumentID = documentID
+		}
+		if err := provider.DocumentChunk().CreateBatch(ctx, file.chunks); err != nil {
+			return err
+		}
+		doc.ContentHash, doc.ChunkCount = file.hash, len(file.chunks)
+		doc.Status, doc.Error, doc.EmbeddingModel = document.StatusProcessing, nil, nil
+		updated, err = provider.Document().Update(ctx, doc)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if emb != nil {
+		if err := uc.startIndexing(ctx, updated, emb); err != nil {
+			return nil, err
+		}
+	}
+	return ToDocumentResponse(updated), nil
+}
+
+// extractUpload validates an uploaded file, extracts its text and splits it into chunks.
+func (uc *DocumentUseCase) extractUpload(req *UploadDocumentRequest) (*upload, error) {
+	if len(req.Data) == 0 {
+		return nil, errors.NewAppError(errors.CodeValidation, "file is empty", nil)
+	}
+	if maxSize := uc.config.RAG.MaxUploadMB << 20; len(req.Data) > maxSize {
+		return nil, errors.NewAppError(errors.CodeValidation, fmt.Sprintf("file must be at most %d MB", uc.config.RAG.MaxUploadMB), nil)
+	}
+
+	format, err := document.DetectFormat(req.Filename, req.ContentType, req.Data)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+	extraction, err := document.Extract(format, req.Data)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	hash := sha256.Sum256([]byte(extraction.Text()))
+	return &upload{
+		title:    extraction.Title,
+		filename: cleanFilename(req.Filename, format),
+		format:   format,
+		size:     int64(len(req.Data)),
+		hash:     hex.EncodeToString(hash[:]),
+		chunks: document.Split(extraction.Sections, document.ChunkOptions{
+			Size:    uc.config.RAG.ChunkSize,
+			Overlap: uc.config.RAG.ChunkOverlap,
+		}),
+	}, nil
+}
+
+// startIndexing embeds the chunks of a document in the background, replacing
+// those it has in the vector index. The document must be committed as processing.
+func (uc *DocumentUseCase) startIndexing(ctx context.Context, doc *document.Document, emb *embedder) error {
+	if err := uc.index.Remove(ctx, doc.ID); err != nil {
+		return err
+	}
+	go uc.indexDocument(doc, emb)
+	return nil
+}
+
+// isCurrent reports whether a document is embedded with the configured model.
+func (uc *DocumentUseCase) isCurrent(doc *document.Document) bool {
+	return doc.Status == document.StatusReady && doc.EmbeddingModel != nil && *doc.EmbeddingModel == uc.config.RAG.EmbeddingModel
+}
+
+// indexDocument embeds the chunks of a document and adds them to the vector
+// index, recording the outcome on the document.
+func (uc *DocumentUseCase) indexDocument(doc *document.Document, emb *embedder) {
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	status, modelName := document.StatusReady, &emb.model.Name
+	var failure *string
+	if err := uc.embedDocument(ctx, doc, emb); err != nil {
+		log.Printf("Failed to index document %s: %v", doc.ID, err)
+		message := err.Error()
+		status, modelName, failure = document.StatusFailed, nil, &message
+	}
+
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		_, err := provider.Document().UpdateStatus(ctx, doc.ID, status, failure, modelName)
+		return err
+	})
+	if err != nil && err != errors.ErrDocumentNotFound {
+		log.Printf("Failed to update status of document %s: %v", doc.ID, err)
+	}
+}
+
+func (uc *DocumentUseCase) embedDocument(ctx context.Context, doc *document.Document, emb *embedder) error {
+	var chunks []*document.Chunk
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		chunks, err = provider.DocumentChunk().ListByDocumentID(ctx, doc.ID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(chunks); start += embedBatchSize {
+		batch := chunks[start:min(start+embedBatchSize, len(chunks))]
+		inputs := make([]string, len(batch))
+		for i, chunk := range batch {
+			inputs[i] = embeddingInput(doc.Title, chunk)
+		}
+		embeddings, err := uc.llmService.Embed(ctx, emb.provider, emb.model, inputs, emb.apiKey, emb.apiBaseOverride)
+		if err != nil {
+			return fmt.Errorf("embedding failed: %w", err)
+		}
+
+		err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+			for i, chunk := range batch {
+				chunk.Embedding = embeddings[i]
+				if err := provider.DocumentChunk().SetEmbedding(ctx, chunk.ID, chunk.Embedding); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return uc.index.Add(ctx, chunks)
+}
+
+// ListDocuments returns the user's documents, newest first.
+func (uc *DocumentUseCase) ListDocuments(ctx context.Context, userID uuid.UUID, req *ListDocumentsRequest) ([]*DocumentResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	var documents []*document.Document
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		documents, err = provider.Document().ListByUserID(ctx, userID, limit, max(req.Offset, 0))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*DocumentResponse, len(documents))
+	for i, d := range documents {
+		responses[i] = ToDocumentResponse(d)
+	}
+	return responses, nil
+}
+
+// GetDocument returns a document of the user with its chunks.
+func (uc *DocumentUseCase) GetDocument(ctx context.Context, userID, documentID uuid.UUID) (*DocumentDetailResponse, error) {
+	var doc *document.Document
+	var chunks []*document.Chunk
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		doc, err = loadDocument(ctx, provider, userID, documentID)
+		if err != nil {
+			return err
+		}
+		chunks, err = provider.DocumentChunk().ListByDocumentID(ctx, documentID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DocumentDetailResponse{DocumentResponse: ToDocumentResponse(doc), Chunks: make([]*ChunkResponse, len(chunks))}
+	for i, c := range chunks {
+		response.Chunks[i] = &ChunkResponse{
+			ID:       c.ID,
+			Index:    c.Index,
+			Content:  c.Content,
+			Page:     c.Page,
+			Heading:  c.Heading,
+			Embedded: c.Embedding != nil,
+		}
+	}
+	return response, nil
+}
+
+// DeleteDocument deletes a document of the user and its chunks.
+func (uc *DocumentUseCase) DeleteDocument(ctx context.Context, userID, documentID uuid.UUID) error {
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadDocument(ctx, provider, userID, documentID); err != nil {
+			return err
+		}
+		return provider.Document().Delete(ctx, documentID)
+	})
+	if err != nil {
+		return err
+	}
+	return uc.index.Remove(ctx, documentID)
+}
+
+// PurgeUserDocuments deletes all of the user's documents along with their
+// chunks, the cached embeddings of those chunks and their vector index entries.
+func (uc *DocumentUseCase) PurgeUserDocuments(ctx context.Context, userID uuid.UUID) error {
+	var documentIDs []uuid.UUID
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		documents, err := provider.Document().ListByUserID(ctx, userID, 0, 0)
+		if err != nil {
+			return err
+		}
+		var hashes []string
+		for _, doc := range documents {
+			chunks, err := provider.DocumentChunk().ListByDocumentID(ctx, doc.ID)
+			if err != nil {
+				return err
+			}
+			for _, chunk := range chunks {
+				hashes = append(hashes, chat.ContentHash(embeddingInput(doc.Title, chunk)))
+			}
+			if err := provider.Document().Delete(ctx, doc.ID); err != nil {
+				return err
+			}
+			documentIDs = append(documentIDs, doc.ID)
+		}
+		return provider.EmbeddingCache().DeleteByContentHashes(ctx, hashes)
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range documentIDs {
+		if err := uc.index.Remove(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Retrieve returns the chunks most similar to the query, as numbered citations.
+// The documents of the knowledge bases attached to the conversation are searched;
+// without any, the user's own documents are. The conversation settings can
+// override how many chunks are returned and how similar they must be. Users
+// without documents get no citations and cause no embedding request.
+func (uc *DocumentUseCase) Retrieve(ctx context.Context, userID, conversationID uuid.UUID, query string) ([]*services.Citation, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	topK, minSimilarity := uc.config.RAG.TopK, uc.config.RAG.MinSimilarity
+	var documents []*document.Document
+	var emb *embedder
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		attached := false
+		if conversationID != uuid.Nil {
+			conversation, err := provider.Conversation().GetByID(ctx, conversationID)
+			if err != nil {
+				return fmt.Errorf("failed to get conversation: %w", err)
+			}
+			if v, ok := conversation.NumberSetting(chat.SettingRetrievalTopK); ok {
+				topK = min(max(int(v), 1), chat.MaxRetrievalTopK)
+			}
+			if v, ok := conversation.NumberSetting(chat.SettingRetrievalMinSimilarity); ok {
+				minSimilarity = v
+			}
+
+			knowledgeBases, err := provider.KnowledgeBase().ListByConversationID(ctx, conversationID, userID)
+			if err != nil {
+				return err
+			}
+			for _, kb := range knowledgeBases {
+				attached = attached || kb.Role.Allows(document.RoleViewer)
+			}
+		}
+
+		var err error
+		if attached {
+			documents, err = provider.Document().ListReadyByConversationID(ctx, conversationID, userID, uc.config.RAG.EmbeddingModel)
+		} else {
+			documents, err = provider.Document().ListReadyByUserID(ctx, userID, uc.config.RAG.EmbeddingModel)
+		}
+		if err != nil || len(documents) == 0 {
+			return err
+		}
+		emb, err = uc.embedderFor(ctx, provider, userID)
+		return err
+	})
+	if err != nil || len(documents) == 0 {
+		return nil, err
+	}
+
+	embeddings, err := uc.llmService.Embed(ctx, emb.provider, emb.model, []string{query}, emb.apiKey, emb.apiBaseOverride)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	titles := make(map[uuid.UUID]string, len(documents))
+	documentIDs := make([]uuid.UUID, len(documents))
+	for i, d := range documents {
+		titles[d.ID] = d.Title
+		documentIDs[i] = d.ID
+	}
+	matches, err := uc.index.Search(ctx, embeddings[0], documentIDs, topK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	var citations []*services.Citation
+	for _, match := range matches {
+		if match.Similarity < minSimilarity {
+			continue
+		}
+		citations = append(citations, &services.Citation{
+			Index:         len(citations) + 1,
+			DocumentID:    match.Chunk.DocumentID,
+			DocumentTitle: titles[match.Chunk.DocumentID],
+			ChunkID:       match.Chunk.ID,
+			Page:          match.Chunk.Page,
+			Heading:       match.Chunk.Heading,
+			Similarity:    match.Similarity,
+			Excerpt:       truncate(strings.Join(strings.Fields(match.Chunk.Content), " "), excerptLength),
+			Content:       match.Chunk.Content,
+		})
+	}
+	return citations, nil
+}
+
+// embedderFor resolves the configured embedding model and the user's API key
+// for its provider.
+func (uc *DocumentUseCase) embedderFor(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID) (*embedder, error) {
+	providers, err := provider.Provider().GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get providers: %w", err)
+	}
+	var embeddingProvider *chat.Provider
+	for _, p := range providers {
+		if p.Name == uc.config.RAG.EmbeddingProvider {
+			embeddingProvider = p
+			break
+		}
+	}
+	if embeddingProvider == nil {
+		return nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("Embedding provider '%s' is not available", uc.config.RAG.EmbeddingProvider), nil)
+	}
+
+	userSetting, err := provider.UserProviderSetting().GetForUser(ctx, userID, embeddingProvider.ID, nil)
+	if err != nil {
+		if err == errors.ErrUserProviderSettingNotFound {
+			return nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("Documents are embedded with %s. Please add an API key for it in settings.", embeddingProvider.DisplayName), err)
+		}
+		return nil, fmt.Errorf("failed to get user provider settings: %w", err)
+	}
+	if !userSetting.IsActive || userSetting.EncryptedAPIKey == nil || *userSetting.EncryptedAPIKey == "" {
+		return nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not active or not set.", embeddingProvider.DisplayName), nil)
+	}
+
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	apiKey, err := keyring.Decrypt(*userSetting.EncryptedAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt API key: %w", err)
+	}
+	// Models that are not registered are passed through, so any model the provider
+	// serves can be configured; registered ones must be embedding models.
+	model, err := provider.Model().GetModelByName(ctx, embeddingProvider.ID, uc.config.RAG.EmbeddingModel)
+	switch {
+	case err == errors.ErrModelNotFound:
+		model = &chat.Model{ProviderID: embeddingProvider.ID, Name: uc.config.RAG.EmbeddingModel, SupportsEmbeddings: true}
+	case err != nil:
+		return nil, fmt.Errorf("failed to get embedding model: %w", err)
+	case !model.SupportsEmbeddings:
+		return nil, errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("Model '%s' does not support embeddings", model.Name), nil)
+	}
+
+	emb := &embedder{
+		provider: embeddingProvider,
+		model:    model,
+		apiKey:   apiKey,
+	}
+	if userSetting.APIBaseOverride != nil {
+		emb.apiBaseOverride = *userSetting.APIBaseOverride
+	}
+	return emb, nil
+}
+
+// loadDocument checks that the document exists and belongs to the user.
+func loadDocument(ctx context.Context, provider database.RepositoryProvider, userID, documentID uuid.UUID) (*document.Document, error) {
+	doc, err := provider.Document().GetByID(ctx, documentID)
+	if err != nil {
+		if err == errors.ErrDocumentNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Document not found", err)
+		}
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return doc, nil
+}
+
+// embeddingInput prefixes a chunk with the document title and its heading, so
+// that passages which do not repeat them can still be found by them.
+func embeddingInput(title string, chunk *document.Chunk) string {
+	prefix := title
+	if chunk.Heading != nil && *chunk.Heading != title {
+		prefix += " - " + *chunk.Heading
+	}
+	return prefix + "\n\n" + chunk.Content
+}
+
+// cleanFilename keeps the base name of an uploaded file, naming unnamed files
+// after their format.
+func cleanFilename(filename string, format document.Format) string {
+	name := path.Base(strings.ReplaceAll(strings.TrimSpace(filename), "\\", "/"))
+	if name == "." || name == "/" || name == "" {
+		name = "document." + string(format)
+	}
+	return truncate(name, maxFilenameLength)
+}
+
+// truncate cuts s to at most n characters, adding an ellipsis when cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n-1])) + "…"
+}
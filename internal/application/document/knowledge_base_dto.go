@@ -0,0 +1,81 @@
+package document
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/document"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateKnowledgeBaseRequest creates a knowledge base owned by the user.
+type CreateKnowledgeBaseRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// UpdateKnowledgeBaseRequest renames a knowledge base and replaces its description.
+type UpdateKnowledgeBaseRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// AddKnowledgeBaseDocumentRequest adds one of the user's documents to a knowledge base.
+type AddKnowledgeBaseDocumentRequest struct {
+	DocumentID uuid.UUID `json:"document_id"`
+}
+
+// SetKnowledgeBaseMemberRequest shares a knowledge base with a user, or changes
+// the role of a member.
+type SetKnowledgeBaseMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // viewer or editor
+}
+
+// --- Response DTOs ---
+
+// KnowledgeBaseResponse represents a knowledge base with the user's role in it.
+type KnowledgeBaseResponse struct {
+	ID          uuid.UUID `json:"id"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Role        string    `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ToKnowledgeBaseResponse converts a knowledge base to its response.
+func ToKnowledgeBaseResponse(kb *document.KnowledgeBase) *KnowledgeBaseResponse {
+	return &KnowledgeBaseResponse{
+		ID:          kb.ID,
+		OwnerID:     kb.UserID,
+		Name:        kb.Name,
+		Description: kb.Description,
+		Role:        string(kb.Role),
+		CreatedAt:   kb.CreatedAt,
+		UpdatedAt:   kb.UpdatedAt,
+	}
+}
+
+// KnowledgeBaseMemberResponse represents a user a knowledge base is shared with.
+type KnowledgeBaseMemberResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// KnowledgeBaseDetailResponse represents a knowledge base with its documents and members.
+type KnowledgeBaseDetailResponse struct {
+	*KnowledgeBaseResponse
+	Documents []*DocumentResponse            `json:"documents"`
+	Members   []*KnowledgeBaseMemberResponse `json:"members"`
+}
+
+// ReindexKnowledgeBaseResponse reports the documents queued for embedding.
+type ReindexKnowledgeBaseResponse struct {
+	Documents []*DocumentResponse `json:"documents"`
+}
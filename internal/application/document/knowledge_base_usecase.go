@@ -0,0 +1,377 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appChat "trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// KnowledgeBaseUseCase handles knowledge bases: named collections of documents
+// that are shared with other users and attached to conversations.
+type KnowledgeBaseUseCase struct {
+	dbService           *database.Service
+	documentUseCase     *DocumentUseCase
+	conversationUseCase *appChat.ConversationUseCase
+	auditLogger         services.AuditLogger
+}
+
+// NewKnowledgeBaseUseCase creates a new KnowledgeBaseUseCase instance.
+func NewKnowledgeBaseUseCase(dbService *database.Service, documentUseCase *DocumentUseCase, conversationUseCase *appChat.ConversationUseCase, auditLogger services.AuditLogger) *KnowledgeBaseUseCase {
+	return &KnowledgeBaseUseCase{
+		dbService:           dbService,
+		documentUseCase:     documentUseCase,
+		conversationUseCase: conversationUseCase,
+		auditLogger:         auditLogger,
+	}
+}
+
+// CreateKnowledgeBase creates an empty knowledge base owned by the user.
+func (uc *KnowledgeBaseUseCase) CreateKnowledgeBase(ctx context.Context, userID uuid.UUID, req *CreateKnowledgeBaseRequest) (*KnowledgeBaseResponse, error) {
+	kb := &document.KnowledgeBase{UserID: userID}
+	if err := applyKnowledgeBaseFields(kb, req.Name, req.Description); err != nil {
+		return nil, err
+	}
+
+	var created *document.KnowledgeBase
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		created, err = provider.KnowledgeBase().Create(ctx, kb)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToKnowledgeBaseResponse(created), nil
+}
+
+// ListKnowledgeBases returns the knowledge bases the user owns or is a member of.
+// With a conversation, only those attached to it are returned, including ones
+// the user has lost access to.
+func (uc *KnowledgeBaseUseCase) ListKnowledgeBases(ctx context.Context, userID uuid.UUID, conversationID *uuid.UUID) ([]*KnowledgeBaseResponse, error) {
+	var knowledgeBases []*document.KnowledgeBase
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		if conversationID == nil {
+			knowledgeBases, err = provider.KnowledgeBase().ListByUserID(ctx, userID)
+			return err
+		}
+		if _, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, *conversationID, userID, chat.ConversationPermissionRead); err != nil {
+			return err
+		}
+		knowledgeBases, err = provider.KnowledgeBase().ListByConversationID(ctx, *conversationID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*KnowledgeBaseResponse, len(knowledgeBases))
+	for i, kb := range knowledgeBases {
+		responses[i] = ToKnowledgeBaseResponse(kb)
+	}
+	return responses, nil
+}
+
+// GetKnowledgeBase returns a knowledge base with its documents and members.
+func (uc *KnowledgeBaseUseCase) GetKnowledgeBase(ctx context.Context, userID, knowledgeBaseID uuid.UUID) (*KnowledgeBaseDetailResponse, error) {
+	var kb *document.KnowledgeBase
+	var documents []*document.Document
+	var members []*document.KnowledgeBaseMember
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		kb, err = loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleViewer)
+		if err != nil {
+			return err
+		}
+		documents, err = provider.Document().ListByKnowledgeBaseID(ctx, knowledgeBaseID)
+		if err != nil {
+			return err
+		}
+		members, err = provider.KnowledgeBase().ListMembers(ctx, knowledgeBaseID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &KnowledgeBaseDetailResponse{
+		KnowledgeBaseResponse: ToKnowledgeBaseResponse(kb),
+		Documents:             make([]*DocumentResponse, len(documents)),
+		Members:               make([]*KnowledgeBaseMemberResponse, len(members)),
+	}
+	for i, d := range documents {
+		response.Documents[i] = ToDocumentResponse(d)
+	}
+	for i, m := range members {
+		response.Members[i] = &KnowledgeBaseMemberResponse{
+			UserID:    m.UserID,
+			Email:     m.Email,
+			Role:      string(m.Role),
+			CreatedAt: m.CreatedAt,
+		}
+	}
+	return response, nil
+}
+
+// UpdateKnowledgeBase renames a knowledge base of the user.
+func (uc *KnowledgeBaseUseCase) UpdateKnowledgeBase(ctx context.Context, userID, knowledgeBaseID uuid.UUID, req *UpdateKnowledgeBaseRequest) (*KnowledgeBaseResponse, error) {
+	var updated *document.KnowledgeBase
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		kb, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleOwner)
+		if err != nil {
+			return err
+		}
+		if err := applyKnowledgeBaseFields(kb, req.Name, req.Description); err != nil {
+			return err
+		}
+		updated, err = provider.KnowledgeBase().Update(ctx, kb)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToKnowledgeBaseResponse(updated), nil
+}
+
+// DeleteKnowledgeBase deletes a knowledge base of the user. Its documents are kept.
+func (uc *KnowledgeBaseUseCase) DeleteKnowledgeBase(ctx context.Context, userID, knowledgeBaseID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleOwner); err != nil {
+			return err
+		}
+		return provider.KnowledgeBase().Delete(ctx, knowledgeBaseID)
+	})
+}
+
+// SetMember shares a knowledge base of the user with another user by email, or
+// changes the role of a member.
+func (uc *KnowledgeBaseUseCase) SetMember(ctx context.Context, userID, knowledgeBaseID uuid.UUID, req *SetKnowledgeBaseMemberRequest) (*KnowledgeBaseMemberResponse, error) {
+	role := document.Role(req.Role)
+	if !document.IsValidMemberRole(role) {
+		return nil, errors.NewAppError(errors.CodeValidation, "role must be viewer or editor", nil)
+	}
+	email := utils.NormalizeEmail(req.Email)
+	if !utils.IsValidEmail(email) {
+		return nil, errors.NewAppError(errors.CodeValidation, "a valid email is required", errors.ErrInvalidEmail)
+	}
+
+	var member *document.KnowledgeBaseMember
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		kb, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleOwner)
+		if err != nil {
+			return err
+		}
+		user, err := provider.User().GetByEmail(ctx, email)
+		if err != nil {
+			if err == errors.ErrUserNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "No user with this email", err)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.ID == kb.UserID {
+			return errors.NewAppError(errors.CodeValidation, "The owner cannot be added as a member", nil)
+		}
+
+		member = &document.KnowledgeBaseMember{
+			KnowledgeBaseID: knowledgeBaseID,
+			UserID:          user.ID,
+			Email:           user.Email,
+			Role:            role,
+		}
+		if err := provider.KnowledgeBase().SetMember(ctx, member); err != nil {
+			return err
+		}
+
+		// Report when the membership started, not when the role last changed.
+		members, err := provider.KnowledgeBase().ListMembers(ctx, knowledgeBaseID)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if m.UserID == user.ID {
+				member = m
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.UserEvent(audit.ActionKnowledgeBaseShared, userID).
+		On(audit.ResourceKnowledgeBase, knowledgeBaseID).
+		With("member_id", member.UserID.String()).
+		With("role", string(member.Role)))
+	return &KnowledgeBaseMemberResponse{
+		UserID:    member.UserID,
+		Email:     member.Email,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt,
+	}, nil
+}
+
+// RemoveMember stops sharing a knowledge base with a user. The owner can remove
+// any member; members can only remove themselves.
+func (uc *KnowledgeBaseUseCase) RemoveMember(ctx context.Context, userID, knowledgeBaseID, memberID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		required := document.RoleOwner
+		if memberID == userID {
+			required = document.RoleViewer
+		}
+		kb, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, required)
+		if err != nil {
+			return err
+		}
+		if memberID == kb.UserID {
+			return errors.NewAppError(errors.CodeValidation, "The owner cannot be removed", nil)
+		}
+		return provider.KnowledgeBase().RemoveMember(ctx, knowledgeBaseID, memberID)
+	})
+}
+
+// AddDocument adds one of the user's documents to a knowledge base the user can edit.
+func (uc *KnowledgeBaseUseCase) AddDocument(ctx context.Context, userID, knowledgeBaseID uuid.UUID, req *AddKnowledgeBaseDocumentRequest) (*DocumentResponse, error) {
+	var doc *document.Document
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleEditor); err != nil {
+			return err
+		}
+		var err error
+		doc, err = loadDocument(ctx, provider, userID, req.DocumentID)
+		if err != nil {
+			return err
+		}
+		return provider.KnowledgeBase().AddDocument(ctx, knowledgeBaseID, doc.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToDocumentResponse(doc), nil
+}
+
+// RemoveDocument removes a document from a knowledge base the user can edit.
+// The document itself is kept.
+func (uc *KnowledgeBaseUseCase) RemoveDocument(ctx context.Context, userID, knowledgeBaseID, documentID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleEditor); err != nil {
+			return err
+		}
+		return provider.KnowledgeBase().RemoveDocument(ctx, knowledgeBaseID, documentID)
+	})
+}
+
+// ReindexKnowledgeBase embeds the documents of a knowledge base the user can edit
+// again, with the configured model and the user's API key. Only documents that
+// failed or were embedded with another model are re-indexed, unless forced.
+// Documents still processing are skipped.
+func (uc *KnowledgeBaseUseCase) ReindexKnowledgeBase(ctx context.Context, userID, knowledgeBaseID uuid.UUID, force bool) (*ReindexKnowledgeBaseResponse, error) {
+	var queued []*document.Document
+	var emb *embedder
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleEditor); err != nil {
+			return err
+		}
+		documents, err := provider.Document().ListByKnowledgeBaseID(ctx, knowledgeBaseID)
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range documents {
+			if doc.Status == document.StatusProcessing || (!force && uc.documentUseCase.isCurrent(doc)) {
+				continue
+			}
+			if emb == nil {
+				if emb, err = uc.documentUseCase.embedderFor(ctx, provider, userID); err != nil {
+					return err
+				}
+			}
+			updated, err := provider.Document().UpdateStatus(ctx, doc.ID, document.StatusProcessing, nil, nil)
+			if err != nil {
+				return err
+			}
+			queued = append(queued, updated)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReindexKnowledgeBaseResponse{Documents: make([]*DocumentResponse, len(queued))}
+	for i, doc := range queued {
+		if err := uc.documentUseCase.startIndexing(ctx, doc, emb); err != nil {
+			return nil, err
+		}
+		response.Documents[i] = ToDocumentResponse(doc)
+	}
+	return response, nil
+}
+
+// AttachToConversation attaches a knowledge base the user can access to a
+// conversation the user may write to, whose messages are then answered from its documents.
+func (uc *KnowledgeBaseUseCase) AttachToConversation(ctx context.Context, userID, knowledgeBaseID, conversationID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionWrite); err != nil {
+			return err
+		}
+		if _, err := loadKnowledgeBase(ctx, provider, userID, knowledgeBaseID, document.RoleViewer); err != nil {
+			return err
+		}
+		return provider.KnowledgeBase().AttachToConversation(ctx, knowledgeBaseID, conversationID)
+	})
+}
+
+// DetachFromConversation detaches a knowledge base from a conversation the user
+// may write to. It works even when the user has lost access to the knowledge base.
+func (uc *KnowledgeBaseUseCase) DetachFromConversation(ctx context.Context, userID, knowledgeBaseID, conversationID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, conversationID, userID, chat.ConversationPermissionWrite); err != nil {
+			return err
+		}
+		return provider.KnowledgeBase().DetachFromConversation(ctx, knowledgeBaseID, conversationID)
+	})
+}
+
+// applyKnowledgeBaseFields validates and sets the name and description of a knowledge base.
+func applyKnowledgeBaseFields(kb *document.KnowledgeBase, name string, description *string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.NewAppError(errors.CodeValidation, "name is required", nil)
+	}
+	if len([]rune(name)) > document.MaxKnowledgeBaseNameLength {
+		return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("name must be at most %d characters", document.MaxKnowledgeBaseNameLength), nil)
+	}
+	kb.Name = name
+	kb.Description = nil
+	if description != nil && strings.TrimSpace(*description) != "" {
+		trimmed := strings.TrimSpace(*description)
+		kb.Description = &trimmed
+	}
+	return nil
+}
+
+// loadKnowledgeBase checks that the knowledge base exists and that the user has
+// at least the given role in it.
+func loadKnowledgeBase(ctx context.Context, provider database.RepositoryProvider, userID, knowledgeBaseID uuid.UUID, role document.Role) (*document.KnowledgeBase, error) {
+	kb, err := provider.KnowledgeBase().GetByID(ctx, knowledgeBaseID, userID)
+	if err != nil {
+		if err == errors.ErrKnowledgeBaseNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Knowledge base not found", err)
+		}
+		return nil, fmt.Errorf("failed to get knowledge base: %w", err)
+	}
+	if !kb.Role.Allows(role) {
+		return nil, errors.ErrForbidden
+	}
+	return kb, nil
+}
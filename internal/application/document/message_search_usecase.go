@@ -0,0 +1,221 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxMessageSearchLimit bounds the number of messages a search returns.
+	maxMessageSearchLimit = 50
+	// messageIndexTimeout bounds the background embedding of messages.
+	messageIndexTimeout = 2 * time.Minute
+	// messageScanPageSize is the number of embeddings read per query when
+	// searching without pgvector.
+	messageScanPageSize = 1000
+)
+
+// MessageSearchUseCase embeds chat messages with the document embedding model
+// and finds the past messages most similar to a query.
+type MessageSearchUseCase struct {
+	dbService       *database.Service
+	documentUseCase *DocumentUseCase
+}
+
+// NewMessageSearchUseCase creates a new MessageSearchUseCase instance.
+func NewMessageSearchUseCase(dbService *database.Service, documentUseCase *DocumentUseCase) *MessageSearchUseCase {
+	return &MessageSearchUseCase{
+		dbService:       dbService,
+		documentUseCase: documentUseCase,
+	}
+}
+
+// IndexMessages embeds the user and assistant messages with text in the
+// background. Users without an API key for the embedding provider are skipped.
+func (uc *MessageSearchUseCase) IndexMessages(userID uuid.UUID, messages []*chat.Message) {
+	var indexable []*chat.Message
+	for _, msg := range messages {
+		if msg == nil || strings.TrimSpace(msg.Content) == "" {
+			continue
+		}
+		if msg.Role == shared.MessageRoleUser || msg.Role == shared.MessageRoleAssistant {
+			indexable = append(indexable, msg)
+		}
+	}
+	if len(indexable) == 0 {
+		return
+	}
+	go uc.indexMessages(userID, indexable)
+}
+
+func (uc *MessageSearchUseCase) indexMessages(userID uuid.UUID, messages []*chat.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), messageIndexTimeout)
+	defer cancel()
+
+	var emb *embedder
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		emb, err = uc.documentUseCase.embedderFor(ctx, provider, userID)
+		return err
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.CodeConfiguration {
+			log.Printf("Failed to index messages of user %s: %v", userID, err)
+		}
+		return
+	}
+
+	inputs := make([]string, len(messages))
+	for i, msg := range messages {
+		inputs[i] = msg.Content
+	}
+	embeddings, err := uc.documentUseCase.llmService.Embed(ctx, emb.provider, emb.model, inputs, emb.apiKey, emb.apiBaseOverride)
+	if err != nil {
+		log.Printf("Failed to embed messages of conversation %s: %v", messages[0].ConversationID, err)
+		return
+	}
+
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		for i, msg := range messages {
+			err := provider.MessageEmbedding().Create(ctx, &chat.MessageEmbedding{
+				MessageID:      msg.ID,
+				ConversationID: msg.ConversationID,
+				UserID:         userID,
+				Model:          emb.model.Name,
+				Embedding:      embeddings[i],
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to store message embeddings of conversation %s: %v", messages[0].ConversationID, err)
+	}
+}
+
+// SearchMessages returns the user's messages most similar to the query, best
+// first. Messages less similar than the retrieval threshold are left out.
+func (uc *MessageSearchUseCase) SearchMessages(ctx context.Context, userID uuid.UUID, query string, excludeConversationID uuid.UUID, limit int) ([]*chat.MessageMatch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, errors.NewAppError(errors.CodeValidation, "Search query is required", nil)
+	}
+	limit = min(max(limit, 1), maxMessageSearchLimit)
+
+	var emb *embedder
+	var vectorSearch bool
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		emb, err = uc.documentUseCase.embedderFor(ctx, provider, userID)
+		if err != nil {
+			return err
+		}
+		vectorSearch, err = provider.DocumentChunk().VectorSearchAvailable(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := uc.documentUseCase.llmService.Embed(ctx, emb.provider, emb.model, []string{query}, emb.apiKey, emb.apiBaseOverride)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	var matches []*chat.MessageMatch
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		if vectorSearch {
+			matches, err = provider.MessageEmbedding().Search(ctx, userID, emb.model.Name, embeddings[0], excludeConversationID, limit)
+		} else {
+			matches, err = scanMessages(ctx, provider, userID, emb.model.Name, embeddings[0], excludeConversationID, limit)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	minSimilarity := uc.documentUseCase.config.RAG.MinSimilarity
+	results := matches[:0]
+	for _, match := range matches {
+		if match.Similarity >= minSimilarity {
+			results = append(results, match)
+		}
+	}
+	return results, nil
+}
+
+// scanMessages compares the query with each of the user's message embeddings,
+// for databases without pgvector.
+func scanMessages(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID, model string, query []float32, excludeConversationID uuid.UUID, limit int) ([]*chat.MessageMatch, error) {
+	type scored struct {
+		messageID  uuid.UUID
+		similarity float64
+	}
+	var best []scored
+	after := uuid.Nil
+	for {
+		page, err := provider.MessageEmbedding().ListByUserID(ctx, userID, model, excludeConversationID, after, messageScanPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page {
+			best = append(best, scored{messageID: e.MessageID, similarity: cosineSimilarity(query, e.Embedding)})
+		}
+		sort.Slice(best, func(i, j int) bool { return best[i].similarity > best[j].similarity })
+		best = best[:min(len(best), limit)]
+		if len(page) < messageScanPageSize {
+			break
+		}
+		after = page[len(page)-1].MessageID
+	}
+
+	ids := make([]uuid.UUID, len(best))
+	similarities := make(map[uuid.UUID]float64, len(best))
+	for i, s := range best {
+		ids[i] = s.messageID
+		similarities[s.messageID] = s.similarity
+	}
+	matches, err := provider.MessageEmbedding().ListMatchesByMessageIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		match.Similarity = similarities[match.MessageID]
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Similarity > matches[j].Similarity })
+	return matches, nil
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or zero when
+// they cannot be compared.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,89 @@
+package document
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+)
+
+const (
+	defaultRecallLimit = 5
+	maxRecallLimit     = 20
+	// recallContentLength is the length in characters of the messages returned to the model.
+	recallContentLength = 1500
+)
+
+// RecallPastConversationsTool exposes MessageSearchUseCase.SearchMessages to the
+// LLM as the recall_past_conversations tool, so the assistant can refer to what
+// the user discussed in other conversations.
+type RecallPastConversationsTool struct {
+	useCase *MessageSearchUseCase
+}
+
+// NewRecallPastConversationsTool creates the recall_past_conversations tool handler.
+func NewRecallPastConversationsTool(useCase *MessageSearchUseCase) services.ToolHandler {
+	return &RecallPastConversationsTool{useCase: useCase}
+}
+
+// recallArguments are the arguments of the recall_past_conversations tool.
+type recallArguments struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit"`
+}
+
+// Definition describes the recall_past_conversations tool.
+func (t *RecallPastConversationsTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "recall_past_conversations",
+		Description: "Search the user's previous conversations for messages related to a topic, matched by meaning rather than exact words. Use it when the user refers to something discussed before, or when earlier discussions (positions, strategies, preferences) would help the answer. The current conversation is not searched.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{"type": "string", "description": "What to look for, e.g. \"my thoughts on NVDA earnings\""},
+				"limit": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxRecallLimit, "default": defaultRecallLimit, "description": "Maximum number of messages to return"},
+			},
+			"required": []string{"query"},
+		},
+	}
+}
+
+// RequiredScope reports that searching past conversations needs chat:read.
+func (t *RecallPastConversationsTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourceChat, false
+}
+
+// Execute returns the past messages most similar to the query.
+func (t *RecallPastConversationsTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var args recallArguments
+	if len(invocation.Arguments) > 0 {
+		if err := json.Unmarshal(invocation.Arguments, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	if args.Limit <= 0 {
+		args.Limit = defaultRecallLimit
+	}
+
+	matches, err := t.useCase.SearchMessages(ctx, invocation.UserID, args.Query, invocation.ConversationID, min(args.Limit, maxRecallLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]map[string]interface{}, len(matches))
+	for i, match := range matches {
+		messages[i] = map[string]interface{}{
+			"conversation_title": match.ConversationTitle,
+			"role":               match.Role,
+			"content":            truncate(strings.TrimSpace(match.Content), recallContentLength),
+			"date":               match.CreatedAt.UTC().Format("2006-01-02"),
+			"similarity":         match.Similarity,
+		}
+	}
+	return &services.ToolResult{Output: shared.JSONB{"messages": messages, "count": len(messages)}}, nil
+}
@@ -0,0 +1,92 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/encryption"
+	"trading-alchemist/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+)
+
+const defaultBatchSize = 100
+
+// KeyRotationUseCase re-encrypts stored secrets with the current encryption
+// key, so keys that are no longer current can be retired.
+type KeyRotationUseCase struct {
+	dbService *database.Service
+	config    *config.Config
+}
+
+// NewKeyRotationUseCase creates a new KeyRotationUseCase instance.
+func NewKeyRotationUseCase(dbService *database.Service, config *config.Config) *KeyRotationUseCase {
+	return &KeyRotationUseCase{
+		dbService: dbService,
+		config:    config,
+	}
+}
+
+// RotationResult counts the secrets of one kind seen and re-encrypted.
+type RotationResult struct {
+	Kind        encryption.SecretKind
+	Scanned     int
+	Reencrypted int
+}
+
+// RotateKeys re-encrypts every secret not encrypted with the current key yet.
+// Secrets are processed in batches of batchSize, each in its own transaction,
+// so a failed run keeps the batches it finished and can simply be repeated.
+func (uc *KeyRotationUseCase) RotateKeys(ctx context.Context, batchSize int) ([]RotationResult, error) {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+
+	results := make([]RotationResult, 0, len(encryption.SecretKinds))
+	for _, kind := range encryption.SecretKinds {
+		result := RotationResult{Kind: kind}
+		afterID := uuid.Nil
+		for {
+			var count, reencrypted int
+			err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+				repo := provider.Secret(kind)
+				secrets, err := repo.ListAfter(ctx, afterID, batchSize)
+				if err != nil {
+					return fmt.Errorf("failed to list secrets: %w", err)
+				}
+				for _, secret := range secrets {
+					ciphertext, changed, err := keyring.Reencrypt(secret.Ciphertext)
+					if err != nil {
+						return fmt.Errorf("failed to re-encrypt secret of row %s: %w", secret.ID, err)
+					}
+					if changed {
+						if err := repo.Update(ctx, secret.ID, ciphertext); err != nil {
+							return fmt.Errorf("failed to update secret of row %s: %w", secret.ID, err)
+						}
+						reencrypted++
+					}
+				}
+				count = len(secrets)
+				if count > 0 {
+					afterID = secrets[count-1].ID
+				}
+				return nil
+			})
+			if err != nil {
+				return append(results, result), fmt.Errorf("%s: %w", kind, err)
+			}
+			result.Scanned += count
+			result.Reencrypted += reencrypted
+			if count < batchSize {
+				break
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,148 @@
+package journal
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/journal"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateEntryRequest represents a trade to record in the journal. Screenshots
+// are image artifacts the user has already uploaded in a conversation.
+type CreateEntryRequest struct {
+	Symbol         string      `json:"symbol" validate:"required"`
+	Side           string      `json:"side" validate:"required,oneof=long short"`
+	Quantity       float64     `json:"quantity" validate:"required,gt=0"`
+	EntryPrice     float64     `json:"entry_price" validate:"required,gt=0"`
+	EntryTime      time.Time   `json:"entry_time" validate:"required"`
+	ExitPrice      *float64    `json:"exit_price,omitempty"`
+	ExitTime       *time.Time  `json:"exit_time,omitempty"`
+	Fees           float64     `json:"fees,omitempty"`
+	Setup          *string     `json:"setup,omitempty"`
+	Thesis         *string     `json:"thesis,omitempty"`
+	Notes          *string     `json:"notes,omitempty"`
+	Tags           []string    `json:"tags,omitempty"`
+	ConversationID *uuid.UUID  `json:"conversation_id,omitempty"`
+	ScreenshotIDs  []uuid.UUID `json:"screenshot_ids,omitempty"`
+}
+
+// UpdateEntryRequest updates a journal entry. Omitted fields are left unchanged;
+// tags and screenshot_ids replace the current ones when given.
+type UpdateEntryRequest struct {
+	Symbol         *string     `json:"symbol,omitempty"`
+	Side           *string     `json:"side,omitempty" validate:"omitempty,oneof=long short"`
+	Quantity       *float64    `json:"quantity,omitempty"`
+	EntryPrice     *float64    `json:"entry_price,omitempty"`
+	EntryTime      *time.Time  `json:"entry_time,omitempty"`
+	ExitPrice      *float64    `json:"exit_price,omitempty"`
+	ExitTime       *time.Time  `json:"exit_time,omitempty"`
+	Fees           *float64    `json:"fees,omitempty"`
+	Setup          *string     `json:"setup,omitempty"`
+	Thesis         *string     `json:"thesis,omitempty"`
+	Notes          *string     `json:"notes,omitempty"`
+	Tags           []string    `json:"tags,omitempty"`
+	ConversationID *uuid.UUID  `json:"conversation_id,omitempty"`
+	ScreenshotIDs  []uuid.UUID `json:"screenshot_ids,omitempty"`
+}
+
+// ListEntriesRequest filters the journal. All fields are optional.
+type ListEntriesRequest struct {
+	Start          *time.Time
+	End            *time.Time
+	Symbol         string
+	Tag            string
+	Setup          string
+	ConversationID *uuid.UUID
+	Limit          int
+	Offset         int
+}
+
+// StatsRequest selects the period and grouping of journal statistics.
+type StatsRequest struct {
+	Start   *time.Time
+	End     *time.Time
+	GroupBy string // tag or setup; defaults to tag
+}
+
+// ReviewTradesRequest asks the model to critique the journal entries of a period.
+// The review is posted to the conversation, using its model.
+type ReviewTradesRequest struct {
+	ConversationID uuid.UUID `json:"conversation_id" validate:"required"`
+	Start          time.Time `json:"start" validate:"required"`
+	End            time.Time `json:"end" validate:"required"`
+	Tag            string    `json:"tag,omitempty"`
+	Setup          string    `json:"setup,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// EntryResponse represents a journal entry with its realized result.
+type EntryResponse struct {
+	ID             uuid.UUID   `json:"id"`
+	Symbol         string      `json:"symbol"`
+	Side           string      `json:"side"`
+	Quantity       float64     `json:"quantity"`
+	EntryPrice     float64     `json:"entry_price"`
+	EntryTime      time.Time   `json:"entry_time"`
+	ExitPrice      *float64    `json:"exit_price,omitempty"`
+	ExitTime       *time.Time  `json:"exit_time,omitempty"`
+	Fees           float64     `json:"fees"`
+	PnL            *float64    `json:"pnl,omitempty"`
+	ReturnPct      *float64    `json:"return_pct,omitempty"`
+	Setup          *string     `json:"setup,omitempty"`
+	Thesis         *string     `json:"thesis,omitempty"`
+	Notes          *string     `json:"notes,omitempty"`
+	Tags           []string    `json:"tags"`
+	ConversationID *uuid.UUID  `json:"conversation_id,omitempty"`
+	ScreenshotIDs  []uuid.UUID `json:"screenshot_ids"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// ToEntryResponse converts a journal entry to its response.
+func ToEntryResponse(e *journal.Entry) *EntryResponse {
+	return &EntryResponse{
+		ID:             e.ID,
+		Symbol:         e.Symbol,
+		Side:           string(e.Side),
+		Quantity:       e.Quantity,
+		EntryPrice:     e.EntryPrice,
+		EntryTime:      e.EntryTime,
+		ExitPrice:      e.ExitPrice,
+		ExitTime:       e.ExitTime,
+		Fees:           e.Fees,
+		PnL:            e.PnL(),
+		ReturnPct:      e.ReturnPct(),
+		Setup:          e.Setup,
+		Thesis:         e.Thesis,
+		Notes:          e.Notes,
+		Tags:           e.Tags,
+		ConversationID: e.ConversationID,
+		ScreenshotIDs:  e.ScreenshotIDs,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+// StatsResponse represents the journal statistics of a period.
+type StatsResponse struct {
+	Start   *time.Time           `json:"start,omitempty"`
+	End     *time.Time           `json:"end,omitempty"`
+	GroupBy string               `json:"group_by"`
+	Overall journal.Stats        `json:"overall"`
+	Groups  []journal.GroupStats `json:"groups"`
+}
+
+// ReviewTradesResponse represents a completed trade review.
+type ReviewTradesResponse struct {
+	ConversationID uuid.UUID     `json:"conversation_id"`
+	MessageID      uuid.UUID     `json:"message_id"`
+	ArtifactID     uuid.UUID     `json:"artifact_id"`
+	Title          string        `json:"title"`
+	Review         string        `json:"review"`
+	Stats          journal.Stats `json:"stats"`
+	EntriesCount   int           `json:"entries_count"`
+}
@@ -0,0 +1,260 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/journal"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/internal/infrastructure/llm/prompts"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	reviewPromptName = "review_trades"
+	// maxReviewTrades caps the number of entries listed in the review prompt;
+	// the statistics still cover the whole period.
+	maxReviewTrades = 100
+	// metadataKeyPrompt marks messages generated from a registered prompt.
+	metadataKeyPrompt = "prompt"
+
+	reviewTimeLayout = "2006-01-02 15:04"
+)
+
+// ReviewTrades asks the conversation's model to critique the journal entries of a
+// period. The prompt and the review are added to the conversation, and the review
+// is attached to the answer as a document artifact.
+func (uc *JournalUseCase) ReviewTrades(ctx context.Context, userID uuid.UUID, req *ReviewTradesRequest) (*ReviewTradesResponse, error) {
+	if !req.End.After(req.Start) {
+		return nil, errors.NewAppError(errors.CodeValidation, "end must be after start", nil)
+	}
+
+	var entries []*journal.Entry
+	var model *chat.Model
+	var llmProvider *chat.Provider
+	var userSetting *chat.UserProviderSetting
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		conversation, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, req.ConversationID, userID, chat.ConversationPermissionRead)
+		if err != nil {
+			return err
+		}
+
+		entries, err = provider.JournalEntry().List(ctx, userID, toFilter(&req.Start, &req.End, "", req.Tag, req.Setup, nil), 0, 0)
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+		if len(entries) == 0 {
+			return errors.NewAppError(errors.CodeValidation, "No journal entries in this period", nil)
+		}
+
+		model, err = provider.Model().GetByID(ctx, conversation.ModelID)
+		if err != nil {
+			return fmt.Errorf("failed to get model: %w", err)
+		}
+		llmProvider, err = provider.Provider().GetByID(ctx, model.ProviderID)
+		if err != nil {
+			return fmt.Errorf("failed to get provider for model: %w", err)
+		}
+		userSetting, err = provider.UserProviderSetting().GetForUser(ctx, userID, llmProvider.ID, nil)
+		if err != nil {
+			if err == errors.ErrUserProviderSettingNotFound {
+				return errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not configured. Please add it in settings.", llmProvider.DisplayName), err)
+			}
+			return fmt.Errorf("failed to get user provider settings: %w", err)
+		}
+		if !userSetting.IsActive || userSetting.EncryptedAPIKey == nil || *userSetting.EncryptedAPIKey == "" {
+			return errors.NewAppError(errors.CodeConfiguration, fmt.Sprintf("API key for provider '%s' is not active or not set.", llmProvider.DisplayName), nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := journal.Summarize(entries)
+	systemPrompt, err := uc.promptManager.GetSystemPrompt(reviewPromptName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review prompt: %w", err)
+	}
+	userPrompt, err := uc.promptManager.RenderUserPrompt(reviewPromptName, reviewData(req, entries, stats))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render review prompt: %w", err)
+	}
+
+	review, err := uc.complete(ctx, llmProvider, model, userSetting, systemPrompt, userPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	title := fmt.Sprintf("Trade review: %s to %s", req.Start.Format("2006-01-02"), req.End.Format("2006-01-02"))
+	response := &ReviewTradesResponse{
+		ConversationID: req.ConversationID,
+		Title:          title,
+		Review:         review,
+		Stats:          stats,
+		EntriesCount:   len(entries),
+	}
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		metadata := shared.JSONB{metadataKeyPrompt: reviewPromptName}
+		if _, err := provider.Message().Create(ctx, &chat.Message{
+			ConversationID: req.ConversationID,
+			Role:           shared.MessageRoleUser,
+			Content:        userPrompt,
+			ModelID:        &model.ID,
+			Metadata:       metadata,
+		}); err != nil {
+			return fmt.Errorf("failed to save review prompt: %w", err)
+		}
+
+		answer, err := provider.Message().Create(ctx, &chat.Message{
+			ConversationID: req.ConversationID,
+			Role:           shared.MessageRoleAssistant,
+			Content:        review,
+			ModelID:        &model.ID,
+			Metadata:       metadata,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save review: %w", err)
+		}
+
+		language := "markdown"
+		artifact := chat.NewArtifact(title, shared.ArtifactTypeDocument, &language, review)
+		artifact.MessageID = answer.ID
+		created, err := provider.Artifact().Create(ctx, artifact)
+		if err != nil {
+			return fmt.Errorf("failed to save review artifact: %w", err)
+		}
+
+		if err := provider.Conversation().UpdateLastMessageAt(ctx, req.ConversationID, answer.CreatedAt); err != nil {
+			return fmt.Errorf("failed to update conversation timestamp: %w", err)
+		}
+		response.MessageID = answer.ID
+		response.ArtifactID = created.ID
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// complete runs a single completion without tools and returns the full answer.
+func (uc *JournalUseCase) complete(ctx context.Context, llmProvider *chat.Provider, model *chat.Model, userSetting *chat.UserProviderSetting, systemPrompt, userPrompt string) (string, error) {
+	keyring, err := uc.config.GetKeyring()
+	if err != nil {
+		return "", fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	apiKey, err := keyring.Decrypt(*userSetting.EncryptedAPIKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt API key: %w", err)
+	}
+	apiBaseOverride := ""
+	if userSetting.APIBaseOverride != nil {
+		apiBaseOverride = *userSetting.APIBaseOverride
+	}
+
+	messages := []*chat.Message{
+		{Role: shared.MessageRoleSystem, Content: systemPrompt},
+		{Role: shared.MessageRoleUser, Content: userPrompt},
+	}
+	events, err := uc.llmService.StreamChatCompletion(ctx, llmProvider, model, messages, nil, apiKey, apiBaseOverride)
+	if err != nil {
+		return "", fmt.Errorf("failed to start trade review: %w", err)
+	}
+
+	var content strings.Builder
+	for event := range events {
+		if event.Error != nil {
+			log.Printf("Error during trade review completion: %v", event.Error)
+			return "", fmt.Errorf("trade review failed: %w", event.Error)
+		}
+		content.WriteString(event.ContentDelta)
+		if event.IsLast {
+			break
+		}
+	}
+
+	review := strings.TrimSpace(content.String())
+	if review == "" {
+		return "", fmt.Errorf("trade review failed: the model returned an empty answer")
+	}
+	return review, nil
+}
+
+// reviewData prepares the template data of the review prompt. Entries arrive
+// newest first; the most recent ones are listed in chronological order.
+func reviewData(req *ReviewTradesRequest, entries []*journal.Entry, stats journal.Stats) *prompts.ReviewTradesData {
+	data := &prompts.ReviewTradesData{
+		Start:   req.Start.Format(reviewTimeLayout),
+		End:     req.End.Format(reviewTimeLayout),
+		Summary: statsTable(stats),
+	}
+
+	var focus []string
+	if req.Tag != "" {
+		focus = append(focus, "tag "+req.Tag)
+	}
+	if req.Setup != "" {
+		focus = append(focus, "setup "+req.Setup)
+	}
+	data.Focus = strings.Join(focus, ", ")
+
+	listed := entries
+	if len(listed) > maxReviewTrades {
+		data.Omitted = len(listed) - maxReviewTrades
+		listed = listed[:maxReviewTrades]
+	}
+	for i := len(listed) - 1; i >= 0; i-- {
+		data.Trades = append(data.Trades, reviewTrade(listed[i]))
+	}
+	return data
+}
+
+func reviewTrade(e *journal.Entry) prompts.ReviewTrade {
+	trade := prompts.ReviewTrade{
+		Symbol:      e.Symbol,
+		Side:        string(e.Side),
+		Opened:      e.EntryTime.Format(reviewTimeLayout),
+		Quantity:    fmt.Sprintf("%g", e.Quantity),
+		EntryPrice:  fmt.Sprintf("%g", e.EntryPrice),
+		Tags:        strings.Join(e.Tags, ", "),
+		Screenshots: len(e.ScreenshotIDs),
+	}
+	if e.IsClosed() {
+		trade.Closed = e.ExitTime.Format(reviewTimeLayout)
+		trade.ExitPrice = fmt.Sprintf("%g", *e.ExitPrice)
+		trade.PnL = fmt.Sprintf("%.2f", *e.PnL())
+		trade.ReturnPct = fmt.Sprintf("%.2f%%", *e.ReturnPct())
+	}
+	if e.Setup != nil {
+		trade.Setup = *e.Setup
+	}
+	if e.Thesis != nil {
+		trade.Thesis = *e.Thesis
+	}
+	if e.Notes != nil {
+		trade.Notes = *e.Notes
+	}
+	return trade
+}
+
+func statsTable(s journal.Stats) string {
+	var b strings.Builder
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Entries | %d (%d closed, %d open) |\n", s.Entries, s.ClosedTrades, s.OpenTrades)
+	fmt.Fprintf(&b, "| Win rate | %.2f%% (%d wins, %d losses) |\n", s.WinRate*100, s.Wins, s.Losses)
+	fmt.Fprintf(&b, "| Net P&L | %.2f |\n", s.NetPnL)
+	fmt.Fprintf(&b, "| Average P&L | %.2f |\n", s.AveragePnL)
+	fmt.Fprintf(&b, "| Average win / loss | %.2f / %.2f |\n", s.AverageWin, s.AverageLoss)
+	fmt.Fprintf(&b, "| Largest win / loss | %.2f / %.2f |\n", s.LargestWin, s.LargestLoss)
+	fmt.Fprintf(&b, "| Profit factor | %.2f |\n", s.ProfitFactor)
+	fmt.Fprintf(&b, "| Average return | %.2f%% |\n", s.AverageReturnPct)
+	fmt.Fprintf(&b, "| Fees | %.2f |", s.TotalFees)
+	return b.String()
+}
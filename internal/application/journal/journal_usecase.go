@@ -0,0 +1,347 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appChat "trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/journal"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/internal/infrastructure/llm/prompts"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// JournalUseCase handles the trading journal: entries, statistics and
+// model-assisted reviews.
+type JournalUseCase struct {
+	dbService           *database.Service
+	config              *config.Config
+	llmService          services.LLMService
+	conversationUseCase *appChat.ConversationUseCase
+	promptManager       *prompts.PromptManager
+}
+
+// NewJournalUseCase creates a new JournalUseCase instance.
+func NewJournalUseCase(dbService *database.Service, config *config.Config, llmService services.LLMService, conversationUseCase *appChat.ConversationUseCase) *JournalUseCase {
+	return &JournalUseCase{
+		dbService:           dbService,
+		config:              config,
+		llmService:          llmService,
+		conversationUseCase: conversationUseCase,
+		promptManager:       prompts.NewPromptManager(),
+	}
+}
+
+// CreateEntry records a trade in the journal.
+func (uc *JournalUseCase) CreateEntry(ctx context.Context, userID uuid.UUID, req *CreateEntryRequest) (*EntryResponse, error) {
+	entry := &journal.Entry{
+		UserID:         userID,
+		Symbol:         req.Symbol,
+		Side:           journal.Side(req.Side),
+		Quantity:       req.Quantity,
+		EntryPrice:     req.EntryPrice,
+		EntryTime:      req.EntryTime,
+		ExitPrice:      req.ExitPrice,
+		ExitTime:       req.ExitTime,
+		Fees:           req.Fees,
+		Setup:          emptyToNil(req.Setup),
+		Thesis:         emptyToNil(req.Thesis),
+		Notes:          emptyToNil(req.Notes),
+		Tags:           req.Tags,
+		ConversationID: req.ConversationID,
+		ScreenshotIDs:  dedupeIDs(req.ScreenshotIDs),
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var created *journal.Entry
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if err := uc.checkLinks(ctx, provider, userID, entry); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = provider.JournalEntry().Create(ctx, entry)
+		if err != nil {
+			return fmt.Errorf("failed to create journal entry: %w", err)
+		}
+		if err := provider.JournalEntry().SetScreenshots(ctx, created.ID, entry.ScreenshotIDs); err != nil {
+			return err
+		}
+		created.ScreenshotIDs = entry.ScreenshotIDs
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToEntryResponse(created), nil
+}
+
+// ListEntries returns the user's journal entries, newest first.
+func (uc *JournalUseCase) ListEntries(ctx context.Context, userID uuid.UUID, req *ListEntriesRequest) ([]*EntryResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	var entries []*journal.Entry
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		entries, err = provider.JournalEntry().List(ctx, userID, toFilter(req.Start, req.End, req.Symbol, req.Tag, req.Setup, req.ConversationID), limit, req.Offset)
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*EntryResponse, len(entries))
+	for i, e := range entries {
+		responses[i] = ToEntryResponse(e)
+	}
+	return responses, nil
+}
+
+// GetEntry returns one of the user's journal entries.
+func (uc *JournalUseCase) GetEntry(ctx context.Context, userID, entryID uuid.UUID) (*EntryResponse, error) {
+	var entry *journal.Entry
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		entry, err = loadEntry(ctx, provider, userID, entryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToEntryResponse(entry), nil
+}
+
+// UpdateEntry changes a journal entry, for example to record the exit of an open trade.
+func (uc *JournalUseCase) UpdateEntry(ctx context.Context, userID, entryID uuid.UUID, req *UpdateEntryRequest) (*EntryResponse, error) {
+	var updated *journal.Entry
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		entry, err := loadEntry(ctx, provider, userID, entryID)
+		if err != nil {
+			return err
+		}
+
+		if req.Symbol != nil {
+			entry.Symbol = *req.Symbol
+		}
+		if req.Side != nil {
+			entry.Side = journal.Side(*req.Side)
+		}
+		if req.Quantity != nil {
+			entry.Quantity = *req.Quantity
+		}
+		if req.EntryPrice != nil {
+			entry.EntryPrice = *req.EntryPrice
+		}
+		if req.EntryTime != nil {
+			entry.EntryTime = *req.EntryTime
+		}
+		if req.ExitPrice != nil {
+			entry.ExitPrice = req.ExitPrice
+		}
+		if req.ExitTime != nil {
+			entry.ExitTime = req.ExitTime
+		}
+		if req.Fees != nil {
+			entry.Fees = *req.Fees
+		}
+		if req.Setup != nil {
+			entry.Setup = emptyToNil(req.Setup)
+		}
+		if req.Thesis != nil {
+			entry.Thesis = emptyToNil(req.Thesis)
+		}
+		if req.Notes != nil {
+			entry.Notes = emptyToNil(req.Notes)
+		}
+		if req.Tags != nil {
+			entry.Tags = req.Tags
+		}
+		if req.ConversationID != nil {
+			entry.ConversationID = req.ConversationID
+		}
+		screenshotsChanged := req.ScreenshotIDs != nil
+		if screenshotsChanged {
+			entry.ScreenshotIDs = dedupeIDs(req.ScreenshotIDs)
+		}
+
+		if err := entry.Validate(); err != nil {
+			return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		if err := uc.checkLinks(ctx, provider, userID, entry); err != nil {
+			return err
+		}
+
+		updated, err = provider.JournalEntry().Update(ctx, entry)
+		if err != nil {
+			return fmt.Errorf("failed to update journal entry: %w", err)
+		}
+		if screenshotsChanged {
+			if err := provider.JournalEntry().SetScreenshots(ctx, entry.ID, entry.ScreenshotIDs); err != nil {
+				return err
+			}
+			updated.ScreenshotIDs = entry.ScreenshotIDs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToEntryResponse(updated), nil
+}
+
+// DeleteEntry removes a journal entry. Its screenshots stay in their conversation.
+func (uc *JournalUseCase) DeleteEntry(ctx context.Context, userID, entryID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadEntry(ctx, provider, userID, entryID); err != nil {
+			return err
+		}
+		if err := provider.JournalEntry().Delete(ctx, entryID); err != nil {
+			return fmt.Errorf("failed to delete journal entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetStats aggregates the journal entries of a period, overall and per tag or setup.
+func (uc *JournalUseCase) GetStats(ctx context.Context, userID uuid.UUID, req *StatsRequest) (*StatsResponse, error) {
+	groupBy := req.GroupBy
+	if groupBy == "" {
+		groupBy = "tag"
+	}
+	if groupBy != "tag" && groupBy != "setup" {
+		return nil, errors.NewAppError(errors.CodeValidation, "group_by must be tag or setup", nil)
+	}
+	if req.Start != nil && req.End != nil && !req.End.After(*req.Start) {
+		return nil, errors.NewAppError(errors.CodeValidation, "end must be after start", nil)
+	}
+
+	var entries []*journal.Entry
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		entries, err = provider.JournalEntry().List(ctx, userID, toFilter(req.Start, req.End, "", "", "", nil), 0, 0)
+		if err != nil {
+			return fmt.Errorf("failed to list journal entries: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StatsResponse{
+		Start:   req.Start,
+		End:     req.End,
+		GroupBy: groupBy,
+		Overall: journal.Summarize(entries),
+	}
+	if groupBy == "setup" {
+		response.Groups = journal.GroupBySetup(entries)
+	} else {
+		response.Groups = journal.GroupByTag(entries)
+	}
+	return response, nil
+}
+
+// loadEntry checks that the journal entry exists and belongs to the user.
+func loadEntry(ctx context.Context, provider database.RepositoryProvider, userID, entryID uuid.UUID) (*journal.Entry, error) {
+	entry, err := provider.JournalEntry().GetByID(ctx, entryID)
+	if err != nil {
+		if err == errors.ErrJournalEntryNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Journal entry not found", err)
+		}
+		return nil, fmt.Errorf("failed to get journal entry: %w", err)
+	}
+	if entry.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return entry, nil
+}
+
+// checkLinks verifies that the user may write to the linked conversation, that
+// the screenshots belong to the user and that every screenshot is an image artifact.
+func (uc *JournalUseCase) checkLinks(ctx context.Context, provider database.RepositoryProvider, userID uuid.UUID, entry *journal.Entry) error {
+	if entry.ConversationID != nil {
+		if _, _, err := uc.conversationUseCase.LoadConversationWithProvider(provider, ctx, *entry.ConversationID, userID, chat.ConversationPermissionWrite); err != nil {
+			return err
+		}
+	}
+
+	for _, artifactID := range entry.ScreenshotIDs {
+		artifact, err := provider.Artifact().GetByID(ctx, artifactID)
+		if err != nil {
+			if err == errors.ErrArtifactNotFound {
+				return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("Screenshot %s not found", artifactID), err)
+			}
+			return fmt.Errorf("failed to get screenshot artifact: %w", err)
+		}
+		if artifact.Type != shared.ArtifactTypeImage {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("Screenshot %s is not an image artifact", artifactID), nil)
+		}
+		ownerID, err := provider.Artifact().GetOwnerID(ctx, artifactID)
+		if err != nil {
+			return fmt.Errorf("failed to get screenshot owner: %w", err)
+		}
+		if ownerID != userID {
+			return errors.ErrForbidden
+		}
+	}
+	return nil
+}
+
+func toFilter(start, end *time.Time, symbol, tag, setup string, conversationID *uuid.UUID) journal.EntryFilter {
+	return journal.EntryFilter{
+		Start:          start,
+		End:            end,
+		Symbol:         strings.ToUpper(strings.TrimSpace(symbol)),
+		Tag:            strings.ToLower(strings.TrimSpace(tag)),
+		Setup:          strings.TrimSpace(setup),
+		ConversationID: conversationID,
+	}
+}
+
+func emptyToNil(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*v)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func dedupeIDs(ids []uuid.UUID) []uuid.UUID {
+	deduped := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			deduped = append(deduped, id)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/notification"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// --- Response DTOs ---
+
+// NotificationResponse represents an entry in the in-app notification feed.
+type NotificationResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	Type      notification.Type `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      shared.JSONB      `json:"data,omitempty" swaggertype:"object"`
+	Read      bool              `json:"read"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NotificationFeedResponse represents a page of the notification feed.
+type NotificationFeedResponse struct {
+	Notifications []*NotificationResponse `json:"notifications"`
+	UnreadCount   int                     `json:"unread_count"`
+}
+
+// UnreadCountResponse reports how many notifications have not been read.
+type UnreadCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+// ToNotificationResponse converts a notification to a NotificationResponse.
+func ToNotificationResponse(n *notification.Notification) *NotificationResponse {
+	return &NotificationResponse{
+		ID:        n.ID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/notification"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// NotificationUseCase handles the user's in-app notification feed.
+type NotificationUseCase struct {
+	dbService *database.Service
+}
+
+// NewNotificationUseCase creates a new NotificationUseCase instance.
+func NewNotificationUseCase(dbService *database.Service) *NotificationUseCase {
+	return &NotificationUseCase{dbService: dbService}
+}
+
+// ListNotifications returns a page of the feed, newest first, with the number of unread notifications.
+func (uc *NotificationUseCase) ListNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) (*NotificationFeedResponse, error) {
+	response := &NotificationFeedResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		notifications, err := provider.Notification().GetByUserID(ctx, userID, unreadOnly, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list notifications: %w", err)
+		}
+		response.Notifications = make([]*NotificationResponse, len(notifications))
+		for i, n := range notifications {
+			response.Notifications[i] = ToNotificationResponse(n)
+		}
+
+		response.UnreadCount, err = provider.Notification().CountUnread(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to count unread notifications: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CountUnread returns the number of notifications the user has not read.
+func (uc *NotificationUseCase) CountUnread(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error) {
+	response := &UnreadCountResponse{}
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		response.UnreadCount, err = provider.Notification().CountUnread(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to count unread notifications: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// MarkRead marks a notification as read.
+func (uc *NotificationUseCase) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) (*NotificationResponse, error) {
+	var response *NotificationResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadNotification(ctx, provider, userID, notificationID); err != nil {
+			return err
+		}
+		if err := provider.Notification().MarkRead(ctx, notificationID); err != nil {
+			return fmt.Errorf("failed to mark notification as read: %w", err)
+		}
+		n, err := provider.Notification().GetByID(ctx, notificationID)
+		if err != nil {
+			return fmt.Errorf("failed to get notification: %w", err)
+		}
+		response = ToNotificationResponse(n)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// MarkAllRead marks every notification of the user as read.
+func (uc *NotificationUseCase) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if err := provider.Notification().MarkAllRead(ctx, userID); err != nil {
+			return fmt.Errorf("failed to mark notifications as read: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteNotification removes a notification from the feed.
+func (uc *NotificationUseCase) DeleteNotification(ctx context.Context, userID, notificationID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadNotification(ctx, provider, userID, notificationID); err != nil {
+			return err
+		}
+		if err := provider.Notification().Delete(ctx, notificationID); err != nil {
+			return fmt.Errorf("failed to delete notification: %w", err)
+		}
+		return nil
+	})
+}
+
+// loadNotification checks that the notification exists and belongs to the user.
+func loadNotification(ctx context.Context, provider database.RepositoryProvider, userID, notificationID uuid.UUID) (*notification.Notification, error) {
+	n, err := provider.Notification().GetByID(ctx, notificationID)
+	if err != nil {
+		if err == errors.ErrNotificationNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Notification not found", err)
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+	if n.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return n, nil
+}
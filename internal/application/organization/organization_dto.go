@@ -0,0 +1,102 @@
+package organization
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/organization"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateOrganizationRequest creates an organization with the user as its owner.
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateOrganizationRequest renames an organization.
+type UpdateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// InviteMemberRequest invites a user to an organization by email.
+type InviteMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // member, admin or owner; defaults to member
+}
+
+// UpdateMemberRequest changes the role of a member.
+type UpdateMemberRequest struct {
+	Role string `json:"role"` // member, admin or owner
+}
+
+// --- Response DTOs ---
+
+// OrganizationResponse represents an organization with the user's role in it.
+type OrganizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ToOrganizationResponse converts an organization to its response.
+func ToOrganizationResponse(org *organization.Organization) *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Role:      string(org.Role),
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
+
+// MemberResponse represents a member of an organization.
+type MemberResponse struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToMemberResponse converts a member to its response.
+func ToMemberResponse(m *organization.Member) *MemberResponse {
+	return &MemberResponse{
+		UserID:    m.UserID,
+		Email:     m.Email,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// InvitationResponse represents a pending invitation to an organization.
+type InvitationResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	InvitedBy *uuid.UUID `json:"invited_by,omitempty"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ToInvitationResponse converts an invitation to its response.
+func ToInvitationResponse(i *organization.Invitation) *InvitationResponse {
+	return &InvitationResponse{
+		ID:        i.ID,
+		Email:     i.Email,
+		Role:      string(i.Role),
+		InvitedBy: i.InvitedBy,
+		ExpiresAt: i.ExpiresAt,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+// OrganizationDetailResponse represents an organization with its members and,
+// for admins, its pending invitations.
+type OrganizationDetailResponse struct {
+	*OrganizationResponse
+	Members     []*MemberResponse     `json:"members"`
+	Invitations []*InvitationResponse `json:"invitations,omitempty"`
+}
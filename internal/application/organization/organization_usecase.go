@@ -0,0 +1,439 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/domain/ratelimit"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationUseCase handles organizations: team workspaces whose members
+// share provider API keys and conversations.
+type OrganizationUseCase struct {
+	emailService services.EmailService
+	rateLimiter  ratelimit.Limiter
+	auditLogger  services.AuditLogger
+	config       *config.Config
+	dbService    *database.Service
+}
+
+// NewOrganizationUseCase creates a new OrganizationUseCase instance.
+func NewOrganizationUseCase(emailService services.EmailService, rateLimiter ratelimit.Limiter, auditLogger services.AuditLogger, config *config.Config, dbService *database.Service) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		emailService: emailService,
+		rateLimiter:  rateLimiter,
+		auditLogger:  auditLogger,
+		config:       config,
+		dbService:    dbService,
+	}
+}
+
+// CreateOrganization creates an organization with the user as its only owner.
+func (uc *OrganizationUseCase) CreateOrganization(ctx context.Context, userID uuid.UUID, req *CreateOrganizationRequest) (*OrganizationResponse, error) {
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *organization.Organization
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		created, err = provider.Organization().Create(ctx, &organization.Organization{Name: name, Role: organization.RoleOwner})
+		if err != nil {
+			return err
+		}
+		return provider.Organization().SetMember(ctx, &organization.Member{
+			OrganizationID: created.ID,
+			UserID:         userID,
+			Role:           organization.RoleOwner,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToOrganizationResponse(created), nil
+}
+
+// ListOrganizations returns the organizations the user is a member of.
+func (uc *OrganizationUseCase) ListOrganizations(ctx context.Context, userID uuid.UUID) ([]*OrganizationResponse, error) {
+	var organizations []*organization.Organization
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		organizations, err = provider.Organization().ListByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*OrganizationResponse, len(organizations))
+	for i, org := range organizations {
+		responses[i] = ToOrganizationResponse(org)
+	}
+	return responses, nil
+}
+
+// GetOrganization returns an organization with its members. Admins also see
+// its pending invitations.
+func (uc *OrganizationUseCase) GetOrganization(ctx context.Context, userID, organizationID uuid.UUID) (*OrganizationDetailResponse, error) {
+	var org *organization.Organization
+	var members []*organization.Member
+	var invitations []*organization.Invitation
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		org, err = LoadOrganization(ctx, provider, userID, organizationID, organization.RoleMember)
+		if err != nil {
+			return err
+		}
+		members, err = provider.Organization().ListMembers(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		if !org.Role.Allows(organization.RoleAdmin) {
+			return nil
+		}
+		invitations, err = provider.OrganizationInvitation().ListByOrganizationID(ctx, organizationID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OrganizationDetailResponse{
+		OrganizationResponse: ToOrganizationResponse(org),
+		Members:              make([]*MemberResponse, len(members)),
+	}
+	for i, m := range members {
+		response.Members[i] = ToMemberResponse(m)
+	}
+	for _, inv := range invitations {
+		response.Invitations = append(response.Invitations, ToInvitationResponse(inv))
+	}
+	return response, nil
+}
+
+// UpdateOrganization renames an organization. Only owners can rename it.
+func (uc *OrganizationUseCase) UpdateOrganization(ctx context.Context, userID, organizationID uuid.UUID, req *UpdateOrganizationRequest) (*OrganizationResponse, error) {
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *organization.Organization
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		org, err := LoadOrganization(ctx, provider, userID, organizationID, organization.RoleOwner)
+		if err != nil {
+			return err
+		}
+		org.Name = name
+		updated, err = provider.Organization().Update(ctx, org)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToOrganizationResponse(updated), nil
+}
+
+// DeleteOrganization deletes an organization with its provider keys. Its
+// conversations go back to the members who started them.
+func (uc *OrganizationUseCase) DeleteOrganization(ctx context.Context, userID, organizationID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := LoadOrganization(ctx, provider, userID, organizationID, organization.RoleOwner); err != nil {
+			return err
+		}
+		return provider.Organization().Delete(ctx, organizationID)
+	})
+}
+
+// InviteMember invites a user to an organization by email. The user, created
+// if needed, joins by following the magic link in the invitation email.
+// Inviting the same user again replaces the earlier invitation.
+func (uc *OrganizationUseCase) InviteMember(ctx context.Context, userID, organizationID uuid.UUID, req *InviteMemberRequest) (*InvitationResponse, error) {
+	role := organization.RoleMember
+	if req.Role != "" {
+		role = organization.Role(req.Role)
+	}
+	if !organization.IsValidRole(role) {
+		return nil, errors.NewAppError(errors.CodeValidation, "role must be member, admin or owner", nil)
+	}
+	email := utils.NormalizeEmail(req.Email)
+	if !utils.IsValidEmail(email) {
+		return nil, errors.NewAppError(errors.CodeValidation, "a valid email is required", errors.ErrInvalidEmail)
+	}
+	// Invitations are magic links, so they count against the same per-address limit
+	decision, err := uc.rateLimiter.Allow(ctx, ratelimit.Key("magic_link", "email", email), ratelimit.Limit(uc.config.RateLimit.MagicLinkPerEmail))
+	if err != nil {
+		log.Printf("Rate limiter failed, allowing the invitation: %v", err)
+	} else if !decision.Allowed {
+		return nil, errors.NewRateLimitError("Too many emails have been sent to this address, please try again later", decision.RetryAfter)
+	}
+	magicLinkTTL, err := time.ParseDuration(uc.config.App.MagicLinkTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAGIC_LINK_TTL configuration: %w", err)
+	}
+
+	var org *organization.Organization
+	var invitee, inviter *auth.User
+	var magicLink *auth.MagicLink
+	var invitation *organization.Invitation
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		org, err = LoadOrganization(ctx, provider, userID, organizationID, organization.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		if role == organization.RoleOwner && !org.Role.Allows(organization.RoleOwner) {
+			return errors.ErrForbidden
+		}
+		inviter, err = provider.User().GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		invitee, err = provider.User().GetByEmail(ctx, email)
+		if err != nil {
+			if err != errors.ErrUserNotFound {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+			invitee, err = provider.User().Create(ctx, &auth.User{
+				ID:       uuid.New(),
+				Email:    email,
+				IsActive: true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create user: %w", err)
+			}
+		}
+		membership, err := provider.Organization().GetByID(ctx, organizationID, invitee.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get organization: %w", err)
+		}
+		if membership.Role != "" {
+			return errors.NewAppError(errors.CodeConflict, "User is already a member of the organization", nil)
+		}
+
+		// The link in an earlier invitation stops working once it is replaced.
+		pending, err := provider.OrganizationInvitation().ListByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		for _, inv := range pending {
+			if inv.UserID == invitee.ID {
+				if err := expireInvitationLink(ctx, provider, inv); err != nil {
+					return err
+				}
+			}
+		}
+
+		token, err := utils.GenerateSecureToken(32)
+		if err != nil {
+			return fmt.Errorf("failed to generate token: %w", err)
+		}
+		magicLink, err = provider.MagicLink().Create(ctx, &auth.MagicLink{
+			ID:        uuid.New(),
+			UserID:    invitee.ID,
+			Token:     token,
+			TokenHash: utils.HashToken(token),
+			ExpiresAt: time.Now().Add(magicLinkTTL),
+			Purpose:   auth.MagicLinkPurposeOrganizationInvite,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create magic link: %w", err)
+		}
+
+		invitation, err = provider.OrganizationInvitation().Create(ctx, &organization.Invitation{
+			OrganizationID: organizationID,
+			UserID:         invitee.ID,
+			Role:           role,
+			InvitedBy:      &userID,
+			MagicLinkID:    magicLink.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.auditLogger.Log(ctx, audit.NewEvent(audit.ActionMagicLinkIssued).
+		By(userID).
+		Concerning(invitee.ID).
+		On(audit.ResourceMagicLink, magicLink.ID).
+		With("purpose", string(auth.MagicLinkPurposeOrganizationInvite)).
+		With("organization_id", organizationID.String()))
+
+	// Send email asynchronously after transaction is committed
+	go func(userToSend *auth.User, org *organization.Organization, inviter *auth.User, linkToSend *auth.MagicLink) {
+		if err := uc.emailService.SendOrganizationInvitationEmail(context.Background(), userToSend, org, inviter, linkToSend); err != nil {
+			log.Printf("Failed to send organization invitation email: %v\n", err)
+		}
+	}(invitee, org, inviter, magicLink)
+
+	return ToInvitationResponse(invitation), nil
+}
+
+// RevokeInvitation withdraws a pending invitation; its magic link stops working.
+func (uc *OrganizationUseCase) RevokeInvitation(ctx context.Context, userID, organizationID, invitationID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		org, err := LoadOrganization(ctx, provider, userID, organizationID, organization.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		invitation, err := provider.OrganizationInvitation().GetByID(ctx, invitationID)
+		if err != nil {
+			if err == errors.ErrOrganizationInvitationNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Invitation not found", err)
+			}
+			return fmt.Errorf("failed to get invitation: %w", err)
+		}
+		if invitation.OrganizationID != organizationID {
+			return errors.NewAppError(errors.CodeNotFound, "Invitation not found", errors.ErrOrganizationInvitationNotFound)
+		}
+		if invitation.Role == organization.RoleOwner && !org.Role.Allows(organization.RoleOwner) {
+			return errors.ErrForbidden
+		}
+		if err := expireInvitationLink(ctx, provider, invitation); err != nil {
+			return err
+		}
+		return provider.OrganizationInvitation().Delete(ctx, invitationID)
+	})
+}
+
+// UpdateMember changes the role of a member. Admins can change the roles of
+// members and admins; only owners can make or demote owners, and the last
+// owner cannot be demoted.
+func (uc *OrganizationUseCase) UpdateMember(ctx context.Context, userID, organizationID, memberID uuid.UUID, req *UpdateMemberRequest) (*MemberResponse, error) {
+	role := organization.Role(req.Role)
+	if !organization.IsValidRole(role) {
+		return nil, errors.NewAppError(errors.CodeValidation, "role must be member, admin or owner", nil)
+	}
+
+	var member *organization.Member
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		org, err := LoadOrganization(ctx, provider, userID, organizationID, organization.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		members, err := provider.Organization().ListMembers(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		member = findMember(members, memberID)
+		if member == nil {
+			return errors.NewAppError(errors.CodeNotFound, "Member not found", nil)
+		}
+		if (member.Role == organization.RoleOwner || role == organization.RoleOwner) && !org.Role.Allows(organization.RoleOwner) {
+			return errors.ErrForbidden
+		}
+		if member.Role == organization.RoleOwner && role != organization.RoleOwner && countOwners(members) == 1 {
+			return errors.NewAppError(errors.CodeValidation, "An organization needs at least one owner", nil)
+		}
+		member.Role = role
+		return provider.Organization().SetMember(ctx, member)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToMemberResponse(member), nil
+}
+
+// RemoveMember removes a member from an organization. Admins can remove members
+// and admins, owners can remove anyone, and every member can leave; the last
+// owner cannot.
+func (uc *OrganizationUseCase) RemoveMember(ctx context.Context, userID, organizationID, memberID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		required := organization.RoleAdmin
+		if memberID == userID {
+			required = organization.RoleMember
+		}
+		org, err := LoadOrganization(ctx, provider, userID, organizationID, required)
+		if err != nil {
+			return err
+		}
+		members, err := provider.Organization().ListMembers(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		member := findMember(members, memberID)
+		if member == nil {
+			return errors.NewAppError(errors.CodeNotFound, "Member not found", nil)
+		}
+		if member.Role == organization.RoleOwner {
+			if memberID != userID && !org.Role.Allows(organization.RoleOwner) {
+				return errors.ErrForbidden
+			}
+			if countOwners(members) == 1 {
+				return errors.NewAppError(errors.CodeValidation, "The last owner cannot be removed; make another member an owner or delete the organization", nil)
+			}
+		}
+		return provider.Organization().RemoveMember(ctx, organizationID, memberID)
+	})
+}
+
+// LoadOrganization checks that the organization exists and that the user has
+// at least the given role in it.
+func LoadOrganization(ctx context.Context, provider database.RepositoryProvider, userID, organizationID uuid.UUID, role organization.Role) (*organization.Organization, error) {
+	org, err := provider.Organization().GetByID(ctx, organizationID, userID)
+	if err != nil {
+		if err == errors.ErrOrganizationNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Organization not found", err)
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if !org.Role.Allows(role) {
+		return nil, errors.ErrForbidden
+	}
+	return org, nil
+}
+
+// validateName trims and checks the name of an organization.
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.NewAppError(errors.CodeValidation, "name is required", nil)
+	}
+	if len([]rune(name)) > organization.MaxNameLength {
+		return "", errors.NewAppError(errors.CodeValidation, fmt.Sprintf("name must be at most %d characters", organization.MaxNameLength), nil)
+	}
+	return name, nil
+}
+
+// expireInvitationLink marks the magic link of an invitation as used, so that
+// it no longer signs anyone in.
+func expireInvitationLink(ctx context.Context, provider database.RepositoryProvider, invitation *organization.Invitation) error {
+	if _, err := provider.MagicLink().MarkAsUsed(ctx, invitation.MagicLinkID); err != nil && err != errors.ErrMagicLinkNotFound {
+		return fmt.Errorf("failed to expire invitation link: %w", err)
+	}
+	return nil
+}
+
+func findMember(members []*organization.Member, userID uuid.UUID) *organization.Member {
+	for _, m := range members {
+		if m.UserID == userID {
+			return m
+		}
+	}
+	return nil
+}
+
+func countOwners(members []*organization.Member) int {
+	owners := 0
+	for _, m := range members {
+		if m.Role == organization.RoleOwner {
+			owners++
+		}
+	}
+	return owners
+}
@@ -0,0 +1,100 @@
+package paper
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/paper"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreateAccountRequest represents the data needed to open a paper trading account.
+type CreateAccountRequest struct {
+	Name           string   `json:"name" validate:"required,min=1,max=255"`
+	InitialCash    float64  `json:"initial_cash" validate:"required,gt=0"`
+	Timeframe      *string  `json:"timeframe,omitempty"`       // Candles orders are matched against, defaults to 1m
+	CommissionRate *float64 `json:"commission_rate,omitempty"` // Fraction of notional per fill, defaults to 0
+}
+
+// PlaceOrderRequest represents a new paper order.
+type PlaceOrderRequest struct {
+	Symbol     string   `json:"symbol" validate:"required"`
+	Side       string   `json:"side" validate:"required,oneof=buy sell"`
+	Type       string   `json:"type" validate:"required,oneof=market limit stop"`
+	Quantity   float64  `json:"quantity" validate:"required,gt=0"`
+	LimitPrice *float64 `json:"limit_price,omitempty"`
+	StopPrice  *float64 `json:"stop_price,omitempty"`
+}
+
+// --- Response DTOs ---
+
+// AccountResponse represents a paper account valued at the latest candle closes.
+type AccountResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	Name           string              `json:"name"`
+	Timeframe      string              `json:"timeframe"`
+	InitialCash    float64             `json:"initial_cash"`
+	Cash           float64             `json:"cash"`
+	CommissionRate float64             `json:"commission_rate"`
+	PositionsValue float64             `json:"positions_value"`
+	Equity         float64             `json:"equity"`
+	RealizedPnL    float64             `json:"realized_pnl"`
+	UnrealizedPnL  float64             `json:"unrealized_pnl"`
+	TotalReturnPct float64             `json:"total_return_pct"`
+	Positions      []*PositionResponse `json:"positions,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// PositionResponse represents a position valued at the latest candle close.
+// LastPrice is nil when no candles are stored for the symbol; the position is
+// then valued at its average price.
+type PositionResponse struct {
+	Symbol        string    `json:"symbol"`
+	Quantity      float64   `json:"quantity"`
+	AveragePrice  float64   `json:"average_price"`
+	LastPrice     *float64  `json:"last_price"`
+	MarketValue   float64   `json:"market_value"`
+	UnrealizedPnL float64   `json:"unrealized_pnl"`
+	RealizedPnL   float64   `json:"realized_pnl"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// OrderResponse represents a paper order.
+type OrderResponse struct {
+	ID           uuid.UUID         `json:"id"`
+	AccountID    uuid.UUID         `json:"account_id"`
+	Symbol       string            `json:"symbol"`
+	Side         paper.OrderSide   `json:"side"`
+	Type         paper.OrderType   `json:"type"`
+	Quantity     float64           `json:"quantity"`
+	LimitPrice   *float64          `json:"limit_price,omitempty"`
+	StopPrice    *float64          `json:"stop_price,omitempty"`
+	Status       paper.OrderStatus `json:"status"`
+	FillPrice    *float64          `json:"fill_price,omitempty"`
+	Commission   float64           `json:"commission"`
+	RejectReason *string           `json:"reject_reason,omitempty"`
+	FilledAt     *time.Time        `json:"filled_at,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// ToOrderResponse converts a domain order to its response DTO.
+func ToOrderResponse(o *paper.Order) *OrderResponse {
+	return &OrderResponse{
+		ID:           o.ID,
+		AccountID:    o.AccountID,
+		Symbol:       o.Symbol,
+		Side:         o.Side,
+		Type:         o.Type,
+		Quantity:     o.Quantity,
+		LimitPrice:   o.LimitPrice,
+		StopPrice:    o.StopPrice,
+		Status:       o.Status,
+		FillPrice:    o.FillPrice,
+		Commission:   o.Commission,
+		RejectReason: o.RejectReason,
+		FilledAt:     o.FilledAt,
+		CreatedAt:    o.CreatedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package paper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// PlaceOrderTool exposes PaperTradingUseCase.PlaceOrder to the LLM as the place_paper_order tool.
+// Calls only run after the user approves them.
+type PlaceOrderTool struct {
+	useCase *PaperTradingUseCase
+}
+
+// NewPlaceOrderTool creates the place_paper_order tool handler.
+func NewPlaceOrderTool(useCase *PaperTradingUseCase) services.ToolHandler {
+	return &PlaceOrderTool{useCase: useCase}
+}
+
+// Definition describes the place_paper_order tool.
+func (t *PlaceOrderTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "place_paper_order",
+		Description: "Place a simulated order in the user's paper trading account. The user must approve the order before it is submitted. Market orders fill at the latest close; limit and stop orders rest until the price trades through. Accounts are long only.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"account_id":  map[string]interface{}{"type": "string", "format": "uuid", "description": "Paper account to trade in; may be omitted if the user has a single account"},
+				"symbol":      map[string]interface{}{"type": "string", "description": "Instrument symbol, e.g. BTCUSDT"},
+				"side":        map[string]interface{}{"type": "string", "enum": []string{"buy", "sell"}},
+				"type":        map[string]interface{}{"type": "string", "enum": []string{"market", "limit", "stop"}},
+				"quantity":    map[string]interface{}{"type": "number", "exclusiveMinimum": 0},
+				"limit_price": map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "description": "Required for limit orders"},
+				"stop_price":  map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "description": "Required for stop orders"},
+			},
+			"required": []string{"symbol", "side", "type", "quantity"},
+		},
+	}
+}
+
+// RequiresConfirmation reports that orders must be approved by the user.
+func (t *PlaceOrderTool) RequiresConfirmation() bool {
+	return true
+}
+
+// RequiredScope reports that orders need paper:write.
+func (t *PlaceOrderTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourcePaper, true
+}
+
+// Execute places the order described by the tool arguments.
+func (t *PlaceOrderTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var args struct {
+		AccountID *uuid.UUID `json:"account_id"`
+		PlaceOrderRequest
+	}
+	if err := json.Unmarshal(invocation.Arguments, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	accountID, err := t.useCase.ResolveAccountID(ctx, invocation.UserID, args.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := t.useCase.PlaceOrder(ctx, invocation.UserID, accountID, &args.PlaceOrderRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.ToolResult{Output: shared.JSONB{"order": order}}, nil
+}
+
+// GetPositionsTool exposes the paper account valuation to the LLM as the get_positions tool.
+// Calls only run after the user approves them.
+type GetPositionsTool struct {
+	useCase *PaperTradingUseCase
+}
+
+// NewGetPositionsTool creates the get_positions tool handler.
+func NewGetPositionsTool(useCase *PaperTradingUseCase) services.ToolHandler {
+	return &GetPositionsTool{useCase: useCase}
+}
+
+// Definition describes the get_positions tool.
+func (t *GetPositionsTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "get_positions",
+		Description: "Get the cash, equity, positions and realized and unrealized P&L of the user's paper trading account. The user must approve the request.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"account_id": map[string]interface{}{"type": "string", "format": "uuid", "description": "Paper account to read; may be omitted if the user has a single account"},
+			},
+		},
+	}
+}
+
+// RequiresConfirmation reports that account data is only shared once the user approves.
+func (t *GetPositionsTool) RequiresConfirmation() bool {
+	return true
+}
+
+// RequiredScope reports that positions need paper:read.
+func (t *GetPositionsTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourcePaper, false
+}
+
+// Execute returns the valued account with its positions.
+func (t *GetPositionsTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var args struct {
+		AccountID *uuid.UUID `json:"account_id"`
+	}
+	if len(invocation.Arguments) > 0 {
+		if err := json.Unmarshal(invocation.Arguments, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	accountID, err := t.useCase.ResolveAccountID(ctx, invocation.UserID, args.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := t.useCase.GetAccount(ctx, invocation.UserID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.ToolResult{Output: shared.JSONB{"account": account}}, nil
+}
@@ -0,0 +1,497 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/paper"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultAccountTimeframe is the candle timeframe orders are matched against unless configured.
+	defaultAccountTimeframe = market.Timeframe1m
+	// matchPageSize is how many candles are loaded at a time when matching resting orders.
+	matchPageSize = 5000
+)
+
+// PaperTradingUseCase handles simulated accounts, orders and positions.
+// Resting limit and stop orders are matched against stored candles whenever an
+// account is read or traded, so the state is always current as of the latest bar.
+type PaperTradingUseCase struct {
+	dbService *database.Service
+}
+
+// NewPaperTradingUseCase creates a new PaperTradingUseCase instance.
+func NewPaperTradingUseCase(dbService *database.Service) *PaperTradingUseCase {
+	return &PaperTradingUseCase{dbService: dbService}
+}
+
+// CreateAccount opens a new paper account funded with the initial cash.
+func (uc *PaperTradingUseCase) CreateAccount(ctx context.Context, userID uuid.UUID, req *CreateAccountRequest) (*AccountResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 255 {
+		return nil, errors.NewAppError(errors.CodeValidation, "name must be between 1 and 255 characters", nil)
+	}
+	if !(req.InitialCash > 0) || math.IsInf(req.InitialCash, 0) {
+		return nil, errors.NewAppError(errors.CodeValidation, "initial_cash must be positive", nil)
+	}
+
+	timeframe := defaultAccountTimeframe
+	if req.Timeframe != nil {
+		tf, err := market.ParseTimeframe(*req.Timeframe)
+		if err != nil {
+			return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+		timeframe = tf
+	}
+
+	commissionRate := 0.0
+	if req.CommissionRate != nil {
+		commissionRate = *req.CommissionRate
+		if commissionRate < 0 || commissionRate >= 1 {
+			return nil, errors.NewAppError(errors.CodeValidation, "commission_rate must be in [0, 1)", nil)
+		}
+	}
+
+	var created *paper.Account
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		created, err = provider.PaperAccount().Create(ctx, &paper.Account{
+			UserID:         userID,
+			Name:           name,
+			Timeframe:      timeframe,
+			InitialCash:    req.InitialCash,
+			Cash:           req.InitialCash,
+			CommissionRate: commissionRate,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create paper account: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAccountResponse(created, nil, nil), nil
+}
+
+// ListAccounts returns the user's paper accounts with their current valuation.
+func (uc *PaperTradingUseCase) ListAccounts(ctx context.Context, userID uuid.UUID) ([]*AccountResponse, error) {
+	var response []*AccountResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		accounts, err := provider.PaperAccount().GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list paper accounts: %w", err)
+		}
+
+		response = make([]*AccountResponse, 0, len(accounts))
+		for _, a := range accounts {
+			account, err := loadAccount(ctx, provider, userID, a.ID)
+			if err != nil {
+				return err
+			}
+			valued, err := valueAccount(ctx, provider, account, false)
+			if err != nil {
+				return err
+			}
+			response = append(response, valued)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetAccount returns a paper account with its positions, valued at the latest closes.
+func (uc *PaperTradingUseCase) GetAccount(ctx context.Context, userID, accountID uuid.UUID) (*AccountResponse, error) {
+	var response *AccountResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		account, err := loadAccount(ctx, provider, userID, accountID)
+		if err != nil {
+			return err
+		}
+		response, err = valueAccount(ctx, provider, account, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteAccount closes a paper account and removes its orders and positions.
+func (uc *PaperTradingUseCase) DeleteAccount(ctx context.Context, userID, accountID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadAccount(ctx, provider, userID, accountID); err != nil {
+			return err
+		}
+		if err := provider.PaperAccount().Delete(ctx, accountID); err != nil {
+			return fmt.Errorf("failed to delete paper account: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListPositions returns the positions of a paper account, including closed ones
+// that carry realized P&L.
+func (uc *PaperTradingUseCase) ListPositions(ctx context.Context, userID, accountID uuid.UUID) ([]*PositionResponse, error) {
+	account, err := uc.GetAccount(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Positions == nil {
+		return []*PositionResponse{}, nil
+	}
+	return account.Positions, nil
+}
+
+// ListOrders returns the orders of a paper account, newest first.
+func (uc *PaperTradingUseCase) ListOrders(ctx context.Context, userID, accountID uuid.UUID, limit, offset int) ([]*OrderResponse, error) {
+	var orders []*paper.Order
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		account, err := loadAccount(ctx, provider, userID, accountID)
+		if err != nil {
+			return err
+		}
+		if err := matchOpenOrders(ctx, provider, account); err != nil {
+			return err
+		}
+		orders, err = provider.PaperOrder().GetByAccountID(ctx, accountID, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list paper orders: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*OrderResponse, len(orders))
+	for i, o := range orders {
+		response[i] = ToOrderResponse(o)
+	}
+	return response, nil
+}
+
+// PlaceOrder submits an order. Market orders, and limit or stop orders that are
+// already marketable, fill immediately at the latest close; the others rest until
+// a later candle trades through their price. Orders the account cannot cover are
+// returned with status rejected rather than as an error.
+func (uc *PaperTradingUseCase) PlaceOrder(ctx context.Context, userID, accountID uuid.UUID, req *PlaceOrderRequest) (*OrderResponse, error) {
+	order := &paper.Order{
+		AccountID:  accountID,
+		Symbol:     strings.TrimSpace(req.Symbol),
+		Side:       paper.OrderSide(strings.ToLower(req.Side)),
+		Type:       paper.OrderType(strings.ToLower(req.Type)),
+		Quantity:   req.Quantity,
+		LimitPrice: req.LimitPrice,
+		StopPrice:  req.StopPrice,
+		Status:     paper.OrderStatusOpen,
+	}
+	if err := order.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var placed *paper.Order
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		account, err := loadAccount(ctx, provider, userID, accountID)
+		if err != nil {
+			return err
+		}
+		// Bring earlier orders up to date first so cash and positions are current.
+		if err := matchOpenOrders(ctx, provider, account); err != nil {
+			return err
+		}
+
+		latest, err := provider.Candle().GetLatest(ctx, order.Symbol, account.Timeframe, 1)
+		if err != nil {
+			return fmt.Errorf("failed to get latest candle: %w", err)
+		}
+		if len(latest) == 0 {
+			return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("no %s candles stored for %s", account.Timeframe, order.Symbol), nil)
+		}
+
+		placed, err = provider.PaperOrder().Create(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to create paper order: %w", err)
+		}
+
+		if price, ok := paper.MatchLast(placed, latest[0].Close); ok {
+			placed, err = fillOrder(ctx, provider, account, placed, price, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToOrderResponse(placed), nil
+}
+
+// CancelOrder cancels an open order.
+func (uc *PaperTradingUseCase) CancelOrder(ctx context.Context, userID, accountID, orderID uuid.UUID) (*OrderResponse, error) {
+	var cancelled *paper.Order
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		account, err := loadAccount(ctx, provider, userID, accountID)
+		if err != nil {
+			return err
+		}
+		// The order may have filled since it was last looked at.
+		if err := matchOpenOrders(ctx, provider, account); err != nil {
+			return err
+		}
+
+		order, err := provider.PaperOrder().GetByID(ctx, orderID)
+		if err != nil {
+			if err == errors.ErrPaperOrderNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Order not found", err)
+			}
+			return fmt.Errorf("failed to get paper order: %w", err)
+		}
+		if order.AccountID != account.ID {
+			return errors.NewAppError(errors.CodeNotFound, "Order not found", nil)
+		}
+		if !order.IsOpen() {
+			return errors.NewAppError(errors.CodeConflict, fmt.Sprintf("Order is already %s", order.Status), nil)
+		}
+
+		order.Status = paper.OrderStatusCancelled
+		cancelled, err = provider.PaperOrder().UpdateStatus(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to cancel paper order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToOrderResponse(cancelled), nil
+}
+
+// ResolveAccountID picks the account a tool call operates on. Without an explicit
+// ID the user's only account is used.
+func (uc *PaperTradingUseCase) ResolveAccountID(ctx context.Context, userID uuid.UUID, accountID *uuid.UUID) (uuid.UUID, error) {
+	if accountID != nil {
+		return *accountID, nil
+	}
+
+	var accounts []*paper.Account
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		accounts, err = provider.PaperAccount().GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list paper accounts: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch len(accounts) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("the user has no paper trading account; one must be created first")
+	case 1:
+		return accounts[0].ID, nil
+	default:
+		names := make([]string, len(accounts))
+		for i, a := range accounts {
+			names[i] = fmt.Sprintf("%s (%s)", a.Name, a.ID)
+		}
+		return uuid.Nil, fmt.Errorf("the user has several paper accounts, specify account_id: %s", strings.Join(names, ", "))
+	}
+}
+
+// loadAccount locks the account for the rest of the transaction and checks ownership.
+func loadAccount(ctx context.Context, provider database.RepositoryProvider, userID, accountID uuid.UUID) (*paper.Account, error) {
+	account, err := provider.PaperAccount().GetByIDForUpdate(ctx, accountID)
+	if err != nil {
+		if err == errors.ErrPaperAccountNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Paper account not found", err)
+		}
+		return nil, fmt.Errorf("failed to get paper account: %w", err)
+	}
+	if account.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return account, nil
+}
+
+// orderMatch is the first bar a resting order trades through.
+type orderMatch struct {
+	order *paper.Order
+	price float64
+	at    time.Time
+}
+
+// matchOpenOrders replays candles that opened after each resting order was placed
+// and fills the order on the first bar that trades through its price. Fills are
+// booked in the order their bars opened, so cash and positions evolve as they
+// would have.
+func matchOpenOrders(ctx context.Context, provider database.RepositoryProvider, account *paper.Account) error {
+	orders, err := provider.PaperOrder().GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get open paper orders: %w", err)
+	}
+
+	now := time.Now()
+	var matches []orderMatch
+	for _, order := range orders {
+		from := order.CreatedAt
+		for matched := false; !matched; {
+			bars, err := provider.Candle().GetRange(ctx, order.Symbol, account.Timeframe, from, now, matchPageSize)
+			if err != nil {
+				return fmt.Errorf("failed to load candles for matching: %w", err)
+			}
+			for _, bar := range bars {
+				if price, ok := paper.MatchBar(order, bar); ok {
+					matches = append(matches, orderMatch{order: order, price: price, at: bar.OpenTime})
+					matched = true
+					break
+				}
+			}
+			if len(bars) < matchPageSize {
+				break
+			}
+			from = bars[len(bars)-1].OpenTime.Add(time.Nanosecond)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].at.Equal(matches[j].at) {
+			return matches[i].at.Before(matches[j].at)
+		}
+		return matches[i].order.CreatedAt.Before(matches[j].order.CreatedAt)
+	})
+	for _, m := range matches {
+		if _, err := fillOrder(ctx, provider, account, m.order, m.price, m.at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillOrder books a fill and persists the account, position and order. Fills the
+// account cannot cover reject the order instead.
+func fillOrder(ctx context.Context, provider database.RepositoryProvider, account *paper.Account, order *paper.Order, price float64, at time.Time) (*paper.Order, error) {
+	position, err := provider.PaperPosition().Get(ctx, account.ID, order.Symbol)
+	if err != nil {
+		if err != errors.ErrPaperPositionNotFound {
+			return nil, fmt.Errorf("failed to get paper position: %w", err)
+		}
+		position = &paper.Position{AccountID: account.ID, Symbol: order.Symbol}
+	}
+
+	commission, err := paper.ApplyFill(account, position, order, price)
+	switch err {
+	case nil:
+		order.Status = paper.OrderStatusFilled
+		order.FillPrice = &price
+		order.Commission = commission
+		order.FilledAt = &at
+
+		if err := provider.PaperAccount().UpdateCash(ctx, account.ID, account.Cash); err != nil {
+			return nil, err
+		}
+		if _, err := provider.PaperPosition().Upsert(ctx, position); err != nil {
+			return nil, err
+		}
+	case paper.ErrInsufficientCash, paper.ErrInsufficientPosition:
+		reason := err.Error()
+		order.Status = paper.OrderStatusRejected
+		order.RejectReason = &reason
+	default:
+		return nil, err
+	}
+
+	updated, err := provider.PaperOrder().UpdateStatus(ctx, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update paper order: %w", err)
+	}
+	return updated, nil
+}
+
+// valueAccount brings the account up to date and values it at the latest closes.
+func valueAccount(ctx context.Context, provider database.RepositoryProvider, account *paper.Account, withPositions bool) (*AccountResponse, error) {
+	if err := matchOpenOrders(ctx, provider, account); err != nil {
+		return nil, err
+	}
+
+	positions, err := provider.PaperPosition().GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paper positions: %w", err)
+	}
+
+	prices := make(map[string]float64)
+	for _, p := range positions {
+		if p.Quantity == 0 {
+			continue
+		}
+		latest, err := provider.Candle().GetLatest(ctx, p.Symbol, account.Timeframe, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest candle: %w", err)
+		}
+		if len(latest) > 0 {
+			prices[p.Symbol] = latest[0].Close
+		}
+	}
+
+	response := toAccountResponse(account, positions, prices)
+	if !withPositions {
+		response.Positions = nil
+	}
+	return response, nil
+}
+
+func toAccountResponse(account *paper.Account, positions []*paper.Position, prices map[string]float64) *AccountResponse {
+	response := &AccountResponse{
+		ID:             account.ID,
+		Name:           account.Name,
+		Timeframe:      string(account.Timeframe),
+		InitialCash:    account.InitialCash,
+		Cash:           account.Cash,
+		CommissionRate: account.CommissionRate,
+		CreatedAt:      account.CreatedAt,
+	}
+
+	for _, p := range positions {
+		position := &PositionResponse{
+			Symbol:       p.Symbol,
+			Quantity:     p.Quantity,
+			AveragePrice: p.AveragePrice,
+			RealizedPnL:  p.RealizedPnL,
+			UpdatedAt:    p.UpdatedAt,
+		}
+		mark := p.AveragePrice
+		if price, ok := prices[p.Symbol]; ok {
+			position.LastPrice = &price
+			mark = price
+		}
+		position.MarketValue = p.MarketValue(mark)
+		position.UnrealizedPnL = p.UnrealizedPnL(mark)
+
+		response.PositionsValue += position.MarketValue
+		response.RealizedPnL += position.RealizedPnL
+		response.UnrealizedPnL += position.UnrealizedPnL
+		response.Positions = append(response.Positions, position)
+	}
+
+	response.Equity = response.Cash + response.PositionsValue
+	if account.InitialCash > 0 {
+		response.TotalReturnPct = (response.Equity/account.InitialCash - 1) * 100
+	}
+	return response
+}
@@ -0,0 +1,236 @@
+package portfolio
+
+import (
+	"time"
+
+	"trading-alchemist/internal/domain/portfolio"
+
+	"github.com/google/uuid"
+)
+
+// --- Request DTOs ---
+
+// CreatePortfolioRequest represents the data needed to create a portfolio.
+type CreatePortfolioRequest struct {
+	Name            string  `json:"name" validate:"required,min=1,max=255"`
+	BaseCurrency    *string `json:"base_currency,omitempty"`    // Defaults to USD
+	BenchmarkSymbol *string `json:"benchmark_symbol,omitempty"` // Default benchmark for beta, e.g. SPY
+}
+
+// UpdatePortfolioRequest represents changes to a portfolio. Omitted fields are left unchanged;
+// an empty benchmark_symbol clears the benchmark.
+type UpdatePortfolioRequest struct {
+	Name            *string `json:"name,omitempty"`
+	BaseCurrency    *string `json:"base_currency,omitempty"`
+	BenchmarkSymbol *string `json:"benchmark_symbol,omitempty"`
+}
+
+// AddTransactionRequest represents a manually entered ledger entry. Buys and sells
+// use quantity, price and fee; dividends, deposits, withdrawals and fees use amount.
+type AddTransactionRequest struct {
+	Type       string    `json:"type" validate:"required,oneof=buy sell dividend deposit withdrawal fee"`
+	Symbol     string    `json:"symbol,omitempty"`
+	Quantity   float64   `json:"quantity,omitempty"`
+	Price      float64   `json:"price,omitempty"`
+	Fee        float64   `json:"fee,omitempty"`
+	Amount     float64   `json:"amount,omitempty"`
+	ExecutedAt time.Time `json:"executed_at" validate:"required"`
+	Notes      *string   `json:"notes,omitempty"`
+}
+
+// AddHoldingRequest records an existing holding as a buy at its average cost,
+// optionally classifying the asset at the same time.
+type AddHoldingRequest struct {
+	Symbol      string     `json:"symbol" validate:"required"`
+	Quantity    float64    `json:"quantity" validate:"required,gt=0"`
+	AverageCost float64    `json:"average_cost" validate:"gte=0"`
+	AcquiredAt  *time.Time `json:"acquired_at,omitempty"` // Defaults to now
+	Name        *string    `json:"name,omitempty"`
+	AssetClass  *string    `json:"asset_class,omitempty"`
+	Sector      *string    `json:"sector,omitempty"`
+}
+
+// UpdateAssetRequest classifies an asset for the allocation breakdown. Omitted
+// fields keep their stored value.
+type UpdateAssetRequest struct {
+	Name       *string `json:"name,omitempty"`
+	AssetClass *string `json:"asset_class,omitempty"` // e.g. equity, bond, crypto, commodity
+	Sector     *string `json:"sector,omitempty"`
+}
+
+// SummaryRequest selects the period the performance and risk metrics cover.
+type SummaryRequest struct {
+	Start     *time.Time `json:"start,omitempty"`     // Defaults to the first transaction
+	End       *time.Time `json:"end,omitempty"`       // Defaults to now
+	Benchmark *string    `json:"benchmark,omitempty"` // Overrides the portfolio's benchmark
+}
+
+// --- Response DTOs ---
+
+// PortfolioResponse represents a portfolio.
+type PortfolioResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	BaseCurrency    string    `json:"base_currency"`
+	BenchmarkSymbol *string   `json:"benchmark_symbol"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TransactionResponse represents a ledger entry.
+type TransactionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	Symbol     string    `json:"symbol,omitempty"`
+	Quantity   float64   `json:"quantity"`
+	Price      float64   `json:"price"`
+	Fee        float64   `json:"fee"`
+	Amount     float64   `json:"amount"`
+	ExecutedAt time.Time `json:"executed_at"`
+	Notes      *string   `json:"notes"`
+	Source     string    `json:"source"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AssetResponse represents the classification of an asset.
+type AssetResponse struct {
+	Symbol     string  `json:"symbol"`
+	Name       *string `json:"name"`
+	AssetClass *string `json:"asset_class"`
+	Sector     *string `json:"sector"`
+}
+
+// ImportRowError describes a CSV row that could not be imported.
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportResponse reports the outcome of a CSV import. Rows that were imported
+// before are counted as duplicates and skipped, so a statement can be re-imported safely.
+type ImportResponse struct {
+	DryRun       bool                   `json:"dry_run"`
+	Imported     int                    `json:"imported"`
+	Duplicates   int                    `json:"duplicates"`
+	Errors       []ImportRowError       `json:"errors"`
+	Transactions []*TransactionResponse `json:"transactions"`
+}
+
+// SummaryResponse represents the current value, allocation, performance and risk of a portfolio.
+type SummaryResponse struct {
+	Portfolio        *PortfolioResponse         `json:"portfolio"`
+	AsOf             time.Time                  `json:"as_of"`
+	Value            float64                    `json:"value"`
+	Cash             float64                    `json:"cash"`
+	HoldingsValue    float64                    `json:"holdings_value"`
+	NetContributions float64                    `json:"net_contributions"` // Deposits less withdrawals, including buys funded from outside
+	UnrealizedPnL    float64                    `json:"unrealized_pnl"`
+	RealizedPnL      float64                    `json:"realized_pnl"`
+	Dividends        float64                    `json:"dividends"`
+	Holdings         []*HoldingResponse         `json:"holdings"`
+	Allocation       *AllocationResponse        `json:"allocation"`
+	Performance      *PerformanceResponse       `json:"performance"`
+	Risk             *RiskResponse              `json:"risk"`
+	Series           []portfolio.ValuationPoint `json:"series,omitempty"`
+}
+
+// HoldingResponse represents an open holding valued at the latest stored close.
+// LastPrice is nil when no daily candles are stored for the symbol; the holding
+// is then valued at its last traded price.
+type HoldingResponse struct {
+	Symbol           string     `json:"symbol"`
+	Name             *string    `json:"name"`
+	AssetClass       *string    `json:"asset_class"`
+	Sector           *string    `json:"sector"`
+	Quantity         float64    `json:"quantity"`
+	AverageCost      float64    `json:"average_cost"`
+	CostBasis        float64    `json:"cost_basis"`
+	LastPrice        *float64   `json:"last_price"`
+	PriceTime        *time.Time `json:"price_time"`
+	MarketValue      float64    `json:"market_value"`
+	UnrealizedPnL    float64    `json:"unrealized_pnl"`
+	UnrealizedPnLPct float64    `json:"unrealized_pnl_pct"`
+	RealizedPnL      float64    `json:"realized_pnl"`
+	Dividends        float64    `json:"dividends"`
+	Weight           float64    `json:"weight"`
+}
+
+// AllocationResponse breaks the portfolio value down by asset, sector and asset class.
+type AllocationResponse struct {
+	ByAsset      []AllocationSlice `json:"by_asset"`
+	BySector     []AllocationSlice `json:"by_sector"`
+	ByAssetClass []AllocationSlice `json:"by_asset_class"`
+}
+
+// AllocationSlice is the value and weight of one group in an allocation breakdown.
+type AllocationSlice struct {
+	Key    string  `json:"key"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+}
+
+// PerformanceResponse represents the returns over the selected period. Annualized
+// figures are only reported for periods longer than a year.
+type PerformanceResponse struct {
+	Start                         time.Time `json:"start"`
+	End                           time.Time `json:"end"`
+	StartValue                    float64   `json:"start_value"`
+	EndValue                      float64   `json:"end_value"`
+	NetFlows                      float64   `json:"net_flows"`
+	TimeWeightedReturn            float64   `json:"time_weighted_return"`
+	AnnualizedTimeWeightedReturn  *float64  `json:"annualized_time_weighted_return"`
+	MoneyWeightedReturn           *float64  `json:"money_weighted_return"`
+	AnnualizedMoneyWeightedReturn *float64  `json:"annualized_money_weighted_return"`
+}
+
+// RiskResponse represents risk metrics computed from daily returns over the selected period.
+// VaR figures are one-day historical values at risk, as a fraction of value and as an amount.
+type RiskResponse struct {
+	Observations int      `json:"observations"`
+	Volatility   float64  `json:"volatility"` // Annualized
+	Benchmark    *string  `json:"benchmark"`
+	Beta         *float64 `json:"beta"`
+	VaR95        float64  `json:"var_95"`
+	VaR99        float64  `json:"var_99"`
+	VaR95Amount  float64  `json:"var_95_amount"`
+	VaR99Amount  float64  `json:"var_99_amount"`
+}
+
+// ToPortfolioResponse converts a domain portfolio to its response DTO.
+func ToPortfolioResponse(p *portfolio.Portfolio) *PortfolioResponse {
+	return &PortfolioResponse{
+		ID:              p.ID,
+		Name:            p.Name,
+		BaseCurrency:    p.BaseCurrency,
+		BenchmarkSymbol: p.BenchmarkSymbol,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+// ToTransactionResponse converts a domain transaction to its response DTO.
+func ToTransactionResponse(t *portfolio.Transaction) *TransactionResponse {
+	return &TransactionResponse{
+		ID:         t.ID,
+		Type:       string(t.Type),
+		Symbol:     t.Symbol,
+		Quantity:   t.Quantity,
+		Price:      t.Price,
+		Fee:        t.Fee,
+		Amount:     t.Amount,
+		ExecutedAt: t.ExecutedAt,
+		Notes:      t.Notes,
+		Source:     string(t.Source),
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+// ToAssetResponse converts a domain asset to its response DTO.
+func ToAssetResponse(a *portfolio.Asset) *AssetResponse {
+	return &AssetResponse{
+		Symbol:     a.Symbol,
+		Name:       a.Name,
+		AssetClass: a.AssetClass,
+		Sector:     a.Sector,
+	}
+}
@@ -0,0 +1,341 @@
+package portfolio
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// maxImportRows bounds the size of a single statement import.
+const maxImportRows = 10000
+
+// csvColumns maps the column names used by common broker exports to transaction fields.
+var csvColumns = map[string]string{
+	"date":             "date",
+	"trade date":       "date",
+	"trade_date":       "date",
+	"executed_at":      "date",
+	"time":             "date",
+	"symbol":           "symbol",
+	"ticker":           "symbol",
+	"instrument":       "symbol",
+	"type":             "type",
+	"action":           "type",
+	"side":             "type",
+	"transaction type": "type",
+	"quantity":         "quantity",
+	"qty":              "quantity",
+	"shares":           "quantity",
+	"units":            "quantity",
+	"price":            "price",
+	"fee":              "fee",
+	"fees":             "fee",
+	"commission":       "fee",
+	"amount":           "amount",
+	"net amount":       "amount",
+	"total":            "amount",
+	"notes":            "notes",
+	"description":      "notes",
+}
+
+// csvActions maps broker action labels to transaction types.
+var csvActions = map[string]portfolio.TransactionType{
+	"buy":        portfolio.TransactionTypeBuy,
+	"bought":     portfolio.TransactionTypeBuy,
+	"sell":       portfolio.TransactionTypeSell,
+	"sold":       portfolio.TransactionTypeSell,
+	"dividend":   portfolio.TransactionTypeDividend,
+	"div":        portfolio.TransactionTypeDividend,
+	"deposit":    portfolio.TransactionTypeDeposit,
+	"withdrawal": portfolio.TransactionTypeWithdrawal,
+	"withdraw":   portfolio.TransactionTypeWithdrawal,
+	"fee":        portfolio.TransactionTypeFee,
+}
+
+// csvDateLayouts are tried in order; slashed dates are read month first.
+var csvDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"2006/01/02",
+	"02.01.2006",
+}
+
+// ImportTransactions imports a broker statement in CSV format. The header row
+// names the columns; date, symbol, type and quantity or amount are required.
+// Rows that cannot be parsed are reported and skipped. Each row is fingerprinted,
+// so rows imported before are skipped as duplicates. With dryRun the rows are
+// parsed and checked against the ledger but not stored.
+func (uc *PortfolioUseCase) ImportTransactions(ctx context.Context, userID, portfolioID uuid.UUID, r io.Reader, dryRun bool) (*ImportResponse, error) {
+	parsed, rowErrors, err := parseTransactionsCSV(r, portfolioID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	response := &ImportResponse{
+		DryRun:       dryRun,
+		Errors:       rowErrors,
+		Transactions: []*TransactionResponse{},
+	}
+	err = uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+
+		existing, err := provider.PortfolioTransaction().GetByPortfolioID(ctx, portfolioID)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio transactions: %w", err)
+		}
+		seen := make(map[string]bool, len(existing))
+		for _, t := range existing {
+			if t.ExternalID != nil {
+				seen[*t.ExternalID] = true
+			}
+		}
+
+		var fresh []*portfolio.Transaction
+		for _, t := range parsed {
+			if seen[*t.ExternalID] {
+				response.Duplicates++
+				continue
+			}
+			fresh = append(fresh, t)
+		}
+
+		// Check the combined ledger before storing anything.
+		combined := append(append([]*portfolio.Transaction{}, existing...), fresh...)
+		portfolio.SortTransactions(combined)
+		ledger := portfolio.NewLedger()
+		for _, t := range combined {
+			if _, err := ledger.Apply(t); err != nil {
+				return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+			}
+		}
+
+		for _, t := range fresh {
+			if dryRun {
+				response.Transactions = append(response.Transactions, ToTransactionResponse(t))
+				continue
+			}
+			created, err := provider.PortfolioTransaction().Create(ctx, t)
+			if err != nil {
+				if err == errors.ErrDuplicatePortfolioTransaction {
+					response.Duplicates++
+					continue
+				}
+				return fmt.Errorf("failed to import portfolio transaction: %w", err)
+			}
+			response.Transactions = append(response.Transactions, ToTransactionResponse(created))
+		}
+		response.Imported = len(response.Transactions)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// parseTransactionsCSV reads the statement into transactions. Problems with the
+// file as a whole are returned as an error, problems with single rows as row errors.
+func parseTransactionsCSV(r io.Reader, portfolioID uuid.UUID) ([]*portfolio.Transaction, []ImportRowError, error) {
+	buffered := bufio.NewReader(r)
+	firstLine, err := buffered.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if header, _, _ := strings.Cut(string(firstLine), "\n"); strings.Count(header, ";") > strings.Count(header, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := csvColumns[name]; ok {
+			if _, dup := columns[field]; !dup {
+				columns[field] = i
+			}
+		}
+	}
+	for _, required := range []string{"date", "type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("CSV header has no %s column", required)
+		}
+	}
+
+	var transactions []*portfolio.Transaction
+	rowErrors := []ImportRowError{}
+	occurrences := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(transactions)+len(rowErrors) >= maxImportRows {
+			return nil, nil, fmt.Errorf("CSV has more than %d rows", maxImportRows)
+		}
+
+		t, err := parseTransactionRecord(record, columns)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		t.PortfolioID = portfolioID
+		t.Source = portfolio.TransactionSourceCSV
+
+		// Identical rows in one statement are distinct trades, so the fingerprint
+		// includes how often the row occurred before.
+		key := transactionFingerprint(t)
+		occurrences[key]++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s#%d", key, occurrences[key])))
+		externalID := hex.EncodeToString(sum[:])
+		t.ExternalID = &externalID
+
+		transactions = append(transactions, t)
+	}
+	return transactions, rowErrors, nil
+}
+
+func parseTransactionRecord(record []string, columns map[string]int) (*portfolio.Transaction, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	action := strings.ToLower(field("type"))
+	txType, ok := csvActions[action]
+	if !ok {
+		return nil, fmt.Errorf("unsupported transaction type %q", field("type"))
+	}
+	executedAt, err := parseCSVDate(field("date"))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &portfolio.Transaction{
+		Type:       txType,
+		Symbol:     normalizeSymbol(field("symbol")),
+		ExecutedAt: executedAt,
+	}
+	if notes := field("notes"); notes != "" {
+		t.Notes = &notes
+	}
+	for _, n := range []struct {
+		name   string
+		target *float64
+	}{
+		{"quantity", &t.Quantity},
+		{"price", &t.Price},
+		{"fee", &t.Fee},
+		{"amount", &t.Amount},
+	} {
+		value, err := parseCSVNumber(field(n.name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", n.name, err)
+		}
+		// Brokers sign quantities and amounts by direction; the type already carries it.
+		*n.target = math.Abs(value)
+	}
+
+	switch txType {
+	case portfolio.TransactionTypeBuy, portfolio.TransactionTypeSell:
+		if t.Price == 0 && t.Quantity > 0 && t.Amount > 0 {
+			t.Price = t.Amount / t.Quantity
+		}
+		t.Amount = 0
+	default:
+		t.Quantity, t.Price = 0, 0
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// parseCSVNumber reads numbers with optional currency symbols, thousands
+// separators and accounting-style parentheses. Empty values are zero.
+func parseCSVNumber(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+	negative := strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+	s = strings.Trim(s, "()")
+	s = strings.NewReplacer("$", "", "€", "", "£", "", ",", "", " ", "").Replace(s)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if negative {
+		v = -v
+	}
+	return v, nil
+}
+
+func parseCSVDate(s string) (time.Time, error) {
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// transactionFingerprint identifies a row by its content, independent of formatting.
+func transactionFingerprint(t *portfolio.Transaction) string {
+	return strings.Join([]string{
+		t.ExecutedAt.UTC().Format(time.RFC3339),
+		string(t.Type),
+		t.Symbol,
+		strconv.FormatFloat(t.Quantity, 'g', -1, 64),
+		strconv.FormatFloat(t.Price, 'g', -1, 64),
+		strconv.FormatFloat(t.Fee, 'g', -1, 64),
+		strconv.FormatFloat(t.Amount, 'g', -1, 64),
+	}, "|")
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,338 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+)
+
+const (
+	// priceTimeframe is the candle timeframe holdings are valued with.
+	priceTimeframe = market.Timeframe1d
+	// pricePageSize is how many candles are loaded at a time when building price histories.
+	pricePageSize = 5000
+	// priceLookbackDays lets the first valuation day pick up the close of a preceding
+	// weekend or holiday.
+	priceLookbackDays = 7
+
+	cashKey      = "CASH"
+	unclassified = "Unclassified"
+)
+
+// buildSummary values the portfolio over the requested period and derives the
+// allocation, performance and risk figures from the daily valuation series.
+func buildSummary(ctx context.Context, provider database.RepositoryProvider, p *portfolio.Portfolio, transactions []*portfolio.Transaction, assets []*portfolio.Asset, req *SummaryRequest) (*SummaryResponse, error) {
+	now := time.Now().UTC()
+	end := now
+	if req.End != nil && req.End.Before(now) {
+		end = req.End.UTC()
+	}
+	start := end
+	if req.Start != nil {
+		start = req.Start.UTC()
+	} else if len(transactions) > 0 {
+		start = transactions[0].ExecutedAt.UTC()
+	}
+	if start.After(end) {
+		return nil, errors.NewAppError(errors.CodeValidation, "start must be before end", nil)
+	}
+	startDay, endDay := truncateDay(start), truncateDay(end)
+
+	benchmark := p.BenchmarkSymbol
+	if req.Benchmark != nil {
+		benchmark = optionalSymbol(req.Benchmark)
+	}
+
+	// Load the daily closes of every symbol ever traded, from before the first
+	// transaction so holdings opened earlier than the period are valued too.
+	historyStart := startDay
+	if len(transactions) > 0 && transactions[0].ExecutedAt.Before(historyStart) {
+		historyStart = truncateDay(transactions[0].ExecutedAt.UTC())
+	}
+	historyStart = historyStart.AddDate(0, 0, -priceLookbackDays)
+	historyEnd := endDay.AddDate(0, 0, 1)
+
+	prices := make(map[string]*portfolio.PriceSeries)
+	for _, t := range transactions {
+		if t.Type != portfolio.TransactionTypeBuy && t.Type != portfolio.TransactionTypeSell {
+			continue
+		}
+		if _, ok := prices[t.Symbol]; ok {
+			continue
+		}
+		series, err := loadPriceSeries(ctx, provider, t.Symbol, historyStart, historyEnd)
+		if err != nil {
+			return nil, err
+		}
+		prices[t.Symbol] = series
+	}
+
+	points, ledger, err := portfolio.BuildValuationSeries(transactions, prices, valuationDays(prices, startDay, endDay))
+	if err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	response := &SummaryResponse{
+		Portfolio:        ToPortfolioResponse(p),
+		AsOf:             end,
+		Cash:             ledger.Cash,
+		NetContributions: ledger.NetContributions,
+		Series:           points,
+	}
+
+	classification := make(map[string]*portfolio.Asset, len(assets))
+	for _, a := range assets {
+		classification[a.Symbol] = a
+	}
+
+	for _, h := range ledger.Holdings {
+		response.RealizedPnL += h.RealizedPnL
+		response.Dividends += h.Dividends
+	}
+	for _, h := range ledger.OpenHoldings() {
+		holding := &HoldingResponse{
+			Symbol:      h.Symbol,
+			Quantity:    h.Quantity,
+			AverageCost: h.AverageCost(),
+			CostBasis:   h.CostBasis,
+			RealizedPnL: h.RealizedPnL,
+			Dividends:   h.Dividends,
+		}
+		if a, ok := classification[h.Symbol]; ok {
+			holding.Name, holding.AssetClass, holding.Sector = a.Name, a.AssetClass, a.Sector
+		}
+		mark := h.LastTradePrice
+		if priceTime, price, ok := prices[h.Symbol].Last(end); ok {
+			holding.LastPrice, holding.PriceTime = &price, &priceTime
+			mark = price
+		}
+		holding.MarketValue = h.Quantity * mark
+		holding.UnrealizedPnL = holding.MarketValue - h.CostBasis
+		if h.CostBasis > 0 {
+			holding.UnrealizedPnLPct = holding.UnrealizedPnL / h.CostBasis * 100
+		}
+
+		response.HoldingsValue += holding.MarketValue
+		response.UnrealizedPnL += holding.UnrealizedPnL
+		response.Holdings = append(response.Holdings, holding)
+	}
+	response.Value = response.Cash + response.HoldingsValue
+	if response.Holdings == nil {
+		response.Holdings = []*HoldingResponse{}
+	}
+
+	response.Allocation = allocation(response)
+	returns := portfolio.DailyReturns(points)
+	response.Performance = performance(points, returns, startDay, endDay)
+	response.Risk, err = risk(ctx, provider, points, returns, benchmark, historyStart, historyEnd, response.Value)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// allocation groups the holdings and cash by asset, sector and asset class.
+func allocation(summary *SummaryResponse) *AllocationResponse {
+	byAsset := make(map[string]float64)
+	bySector := make(map[string]float64)
+	byClass := make(map[string]float64)
+	for _, h := range summary.Holdings {
+		byAsset[h.Symbol] += h.MarketValue
+		bySector[labelOr(h.Sector, unclassified)] += h.MarketValue
+		byClass[labelOr(h.AssetClass, unclassified)] += h.MarketValue
+	}
+	if summary.Cash != 0 {
+		byAsset[cashKey] += summary.Cash
+		bySector[cashKey] += summary.Cash
+		byClass[cashKey] += summary.Cash
+	}
+
+	for _, h := range summary.Holdings {
+		if summary.Value != 0 {
+			h.Weight = h.MarketValue / summary.Value
+		}
+	}
+	return &AllocationResponse{
+		ByAsset:      toSlices(byAsset, summary.Value),
+		BySector:     toSlices(bySector, summary.Value),
+		ByAssetClass: toSlices(byClass, summary.Value),
+	}
+}
+
+func toSlices(values map[string]float64, total float64) []AllocationSlice {
+	slices := make([]AllocationSlice, 0, len(values))
+	for key, value := range values {
+		slice := AllocationSlice{Key: key, Value: value}
+		if total != 0 {
+			slice.Weight = value / total
+		}
+		slices = append(slices, slice)
+	}
+	sort.Slice(slices, func(i, j int) bool {
+		if slices[i].Value != slices[j].Value {
+			return slices[i].Value > slices[j].Value
+		}
+		return slices[i].Key < slices[j].Key
+	})
+	return slices
+}
+
+// performance computes the time-weighted and money-weighted returns of the series.
+// The money-weighted return treats the starting value as a contribution and the
+// ending value as a final withdrawal.
+func performance(points []portfolio.ValuationPoint, returns []float64, startDay, endDay time.Time) *PerformanceResponse {
+	response := &PerformanceResponse{Start: startDay, End: endDay}
+	if len(points) == 0 {
+		return response
+	}
+	first, last := points[0], points[len(points)-1]
+	response.StartValue, response.EndValue = first.Value, last.Value
+
+	var flows []portfolio.CashFlow
+	if first.Value != 0 {
+		flows = append(flows, portfolio.CashFlow{Date: first.Date, Amount: -first.Value})
+	}
+	for _, point := range points[1:] {
+		response.NetFlows += point.Flow
+		if point.Flow != 0 {
+			flows = append(flows, portfolio.CashFlow{Date: point.Date, Amount: -point.Flow})
+		}
+	}
+	if last.Value != 0 {
+		flows = append(flows, portfolio.CashFlow{Date: last.Date, Amount: last.Value})
+	}
+
+	response.TimeWeightedReturn = sanitize(portfolio.TimeWeightedReturn(returns))
+	years := endDay.Sub(startDay).Hours() / (24 * 365)
+	if years > 1 {
+		annualized := sanitize(portfolio.Annualize(response.TimeWeightedReturn, years))
+		response.AnnualizedTimeWeightedReturn = &annualized
+	}
+
+	if rate, ok := portfolio.XIRR(flows); ok {
+		// The money-weighted period starts with the first flow, which may be after startDay.
+		flowYears := last.Date.Sub(flows[0].Date).Hours() / (24 * 365)
+		period := sanitize(math.Pow(1+rate, flowYears) - 1)
+		response.MoneyWeightedReturn = &period
+		if flowYears > 1 {
+			annualized := sanitize(rate)
+			response.AnnualizedMoneyWeightedReturn = &annualized
+		}
+	}
+	return response
+}
+
+// risk computes volatility, beta against the benchmark and historical VaR from daily returns.
+func risk(ctx context.Context, provider database.RepositoryProvider, points []portfolio.ValuationPoint, returns []float64, benchmark *string, historyStart, historyEnd time.Time, value float64) (*RiskResponse, error) {
+	response := &RiskResponse{Observations: len(returns), Benchmark: benchmark}
+	if len(returns) == 0 {
+		return response, nil
+	}
+
+	// Annualize with the observed number of valuation days per year, so markets
+	// closed on weekends are not treated as trading every day.
+	years := points[len(points)-1].Date.Sub(points[0].Date).Hours() / (24 * 365)
+	if years > 0 {
+		response.Volatility = sanitize(portfolio.Volatility(returns, float64(len(returns))/years))
+	}
+
+	response.VaR95 = sanitize(portfolio.HistoricalVaR(returns, 0.95))
+	response.VaR99 = sanitize(portfolio.HistoricalVaR(returns, 0.99))
+	response.VaR95Amount = response.VaR95 * value
+	response.VaR99Amount = response.VaR99 * value
+
+	if benchmark == nil {
+		return response, nil
+	}
+	series, err := loadPriceSeries(ctx, provider, *benchmark, historyStart, historyEnd)
+	if err != nil {
+		return nil, err
+	}
+	var aligned, benchmarkReturns []float64
+	for i := 1; i < len(points); i++ {
+		previous, ok := series.At(points[i-1].Date)
+		if !ok || previous <= 0 {
+			continue
+		}
+		current, _ := series.At(points[i].Date)
+		aligned = append(aligned, returns[i-1])
+		benchmarkReturns = append(benchmarkReturns, current/previous-1)
+	}
+	if beta, ok := portfolio.Beta(aligned, benchmarkReturns); ok {
+		beta = sanitize(beta)
+		response.Beta = &beta
+	}
+	return response, nil
+}
+
+// loadPriceSeries loads the daily closes of a symbol with open time in [from, to).
+func loadPriceSeries(ctx context.Context, provider database.RepositoryProvider, symbol string, from, to time.Time) (*portfolio.PriceSeries, error) {
+	series := &portfolio.PriceSeries{}
+	for {
+		candles, err := provider.Candle().GetRange(ctx, symbol, priceTimeframe, from, to, pricePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s prices: %w", symbol, err)
+		}
+		for _, c := range candles {
+			series.Dates = append(series.Dates, c.OpenTime.UTC())
+			series.Closes = append(series.Closes, c.Close)
+		}
+		if len(candles) < pricePageSize {
+			return series, nil
+		}
+		from = candles[len(candles)-1].OpenTime.Add(time.Nanosecond)
+	}
+}
+
+// valuationDays returns the days with a stored close for any symbol within
+// [startDay, endDay], always including both ends. Without any stored prices
+// every calendar day is used.
+func valuationDays(prices map[string]*portfolio.PriceSeries, startDay, endDay time.Time) []time.Time {
+	set := map[time.Time]bool{startDay: true, endDay: true}
+	for _, series := range prices {
+		for _, date := range series.Dates {
+			day := truncateDay(date)
+			if !day.Before(startDay) && !day.After(endDay) {
+				set[day] = true
+			}
+		}
+	}
+	if len(set) <= 2 {
+		for day := startDay; day.Before(endDay); day = day.AddDate(0, 0, 1) {
+			set[day] = true
+		}
+	}
+
+	days := make([]time.Time, 0, len(set))
+	for day := range set {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return days
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func labelOr(label *string, fallback string) string {
+	if label == nil || *label == "" {
+		return fallback
+	}
+	return *label
+}
+
+// sanitize replaces NaN and Inf, which JSON cannot represent, with zero.
+func sanitize(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,74 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// GetPortfolioSummaryTool exposes PortfolioUseCase.GetSummary to the LLM as the
+// get_portfolio_summary tool, so the assistant can answer questions about the
+// user's own holdings.
+type GetPortfolioSummaryTool struct {
+	useCase *PortfolioUseCase
+}
+
+// NewGetPortfolioSummaryTool creates the get_portfolio_summary tool handler.
+func NewGetPortfolioSummaryTool(useCase *PortfolioUseCase) services.ToolHandler {
+	return &GetPortfolioSummaryTool{useCase: useCase}
+}
+
+// Definition describes the get_portfolio_summary tool.
+func (t *GetPortfolioSummaryTool) Definition() *chat.Tool {
+	return &chat.Tool{
+		Name:        "get_portfolio_summary",
+		Description: "Get the user's real portfolio: current value, cash, holdings with cost basis and P&L, allocation by asset, sector and asset class, time-weighted and money-weighted returns, and risk metrics (annualized volatility, beta against a benchmark, one-day historical VaR at 95% and 99%). Holdings are valued at the latest stored daily closes. Returns are fractions, e.g. 0.05 is 5%.",
+		Schema: shared.JSONB{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"portfolio_id": map[string]interface{}{"type": "string", "format": "uuid", "description": "Portfolio to summarize; may be omitted if the user has a single portfolio"},
+				"start":        map[string]interface{}{"type": "string", "format": "date-time", "description": "Start of the performance period (RFC 3339); defaults to the first transaction"},
+				"end":          map[string]interface{}{"type": "string", "format": "date-time", "description": "End of the performance period (RFC 3339); defaults to now"},
+				"benchmark":    map[string]interface{}{"type": "string", "description": "Symbol to compute beta against, e.g. SPY; defaults to the portfolio's benchmark"},
+			},
+		},
+	}
+}
+
+// RequiredScope reports that portfolios need portfolio:read.
+func (t *GetPortfolioSummaryTool) RequiredScope() (string, bool) {
+	return domainAuth.ScopeResourcePortfolio, false
+}
+
+// Execute returns the portfolio summary without the daily valuation series.
+func (t *GetPortfolioSummaryTool) Execute(ctx context.Context, invocation *services.ToolInvocation) (*services.ToolResult, error) {
+	var args struct {
+		PortfolioID *uuid.UUID `json:"portfolio_id"`
+		SummaryRequest
+	}
+	if len(invocation.Arguments) > 0 {
+		if err := json.Unmarshal(invocation.Arguments, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	portfolioID, err := t.useCase.ResolvePortfolioID(ctx, invocation.UserID, args.PortfolioID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := t.useCase.GetSummary(ctx, invocation.UserID, portfolioID, &args.SummaryRequest)
+	if err != nil {
+		return nil, err
+	}
+	// The series can hold years of daily points; the model only needs the figures.
+	summary.Series = nil
+
+	return &services.ToolResult{Output: shared.JSONB{"summary": summary}}, nil
+}
@@ -0,0 +1,438 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const defaultBaseCurrency = "USD"
+
+// PortfolioUseCase handles real portfolios: the transaction ledger, asset
+// classification and the valuation, performance and risk summary.
+type PortfolioUseCase struct {
+	dbService *database.Service
+}
+
+// NewPortfolioUseCase creates a new PortfolioUseCase instance.
+func NewPortfolioUseCase(dbService *database.Service) *PortfolioUseCase {
+	return &PortfolioUseCase{dbService: dbService}
+}
+
+// CreatePortfolio creates an empty portfolio.
+func (uc *PortfolioUseCase) CreatePortfolio(ctx context.Context, userID uuid.UUID, req *CreatePortfolioRequest) (*PortfolioResponse, error) {
+	p := &portfolio.Portfolio{
+		UserID:          userID,
+		Name:            strings.TrimSpace(req.Name),
+		BaseCurrency:    defaultBaseCurrency,
+		BenchmarkSymbol: optionalSymbol(req.BenchmarkSymbol),
+	}
+	if req.BaseCurrency != nil {
+		p.BaseCurrency = strings.ToUpper(strings.TrimSpace(*req.BaseCurrency))
+	}
+	if err := validatePortfolio(p); err != nil {
+		return nil, err
+	}
+
+	var created *portfolio.Portfolio
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		created, err = provider.Portfolio().Create(ctx, p)
+		if err != nil {
+			return fmt.Errorf("failed to create portfolio: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToPortfolioResponse(created), nil
+}
+
+// ListPortfolios returns the user's portfolios.
+func (uc *PortfolioUseCase) ListPortfolios(ctx context.Context, userID uuid.UUID) ([]*PortfolioResponse, error) {
+	var portfolios []*portfolio.Portfolio
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		portfolios, err = provider.Portfolio().GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to list portfolios: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*PortfolioResponse, len(portfolios))
+	for i, p := range portfolios {
+		response[i] = ToPortfolioResponse(p)
+	}
+	return response, nil
+}
+
+// GetPortfolio returns a portfolio owned by the user.
+func (uc *PortfolioUseCase) GetPortfolio(ctx context.Context, userID, portfolioID uuid.UUID) (*PortfolioResponse, error) {
+	var p *portfolio.Portfolio
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		p, err = loadPortfolio(ctx, provider, userID, portfolioID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToPortfolioResponse(p), nil
+}
+
+// UpdatePortfolio renames a portfolio or changes its currency or benchmark.
+func (uc *PortfolioUseCase) UpdatePortfolio(ctx context.Context, userID, portfolioID uuid.UUID, req *UpdatePortfolioRequest) (*PortfolioResponse, error) {
+	var updated *portfolio.Portfolio
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		p, err := loadPortfolio(ctx, provider, userID, portfolioID)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			p.Name = strings.TrimSpace(*req.Name)
+		}
+		if req.BaseCurrency != nil {
+			p.BaseCurrency = strings.ToUpper(strings.TrimSpace(*req.BaseCurrency))
+		}
+		if req.BenchmarkSymbol != nil {
+			p.BenchmarkSymbol = optionalSymbol(req.BenchmarkSymbol)
+		}
+		if err := validatePortfolio(p); err != nil {
+			return err
+		}
+
+		updated, err = provider.Portfolio().Update(ctx, p)
+		if err != nil {
+			return fmt.Errorf("failed to update portfolio: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToPortfolioResponse(updated), nil
+}
+
+// DeletePortfolio deletes a portfolio with its transactions and asset classifications.
+func (uc *PortfolioUseCase) DeletePortfolio(ctx context.Context, userID, portfolioID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+		if err := provider.Portfolio().Delete(ctx, portfolioID); err != nil {
+			return fmt.Errorf("failed to delete portfolio: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListTransactions returns the portfolio's ledger, oldest first.
+func (uc *PortfolioUseCase) ListTransactions(ctx context.Context, userID, portfolioID uuid.UUID) ([]*TransactionResponse, error) {
+	var transactions []*portfolio.Transaction
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+		var err error
+		transactions, err = provider.PortfolioTransaction().GetByPortfolioID(ctx, portfolioID)
+		if err != nil {
+			return fmt.Errorf("failed to list portfolio transactions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*TransactionResponse, len(transactions))
+	for i, t := range transactions {
+		response[i] = ToTransactionResponse(t)
+	}
+	return response, nil
+}
+
+// AddTransaction records a manually entered transaction. Sells that exceed the
+// quantity held at that point in the ledger are rejected.
+func (uc *PortfolioUseCase) AddTransaction(ctx context.Context, userID, portfolioID uuid.UUID, req *AddTransactionRequest) (*TransactionResponse, error) {
+	t := &portfolio.Transaction{
+		PortfolioID: portfolioID,
+		Type:        portfolio.TransactionType(strings.ToLower(strings.TrimSpace(req.Type))),
+		Symbol:      normalizeSymbol(req.Symbol),
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		Fee:         req.Fee,
+		Amount:      req.Amount,
+		ExecutedAt:  req.ExecutedAt,
+		Notes:       req.Notes,
+		Source:      portfolio.TransactionSourceManual,
+	}
+	if err := t.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var created *portfolio.Transaction
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+		var err error
+		created, err = provider.PortfolioTransaction().Create(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to create portfolio transaction: %w", err)
+		}
+		return checkLedger(ctx, provider, portfolioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToTransactionResponse(created), nil
+}
+
+// AddHolding records an existing holding as a buy at its average cost and
+// stores the optional classification of the asset.
+func (uc *PortfolioUseCase) AddHolding(ctx context.Context, userID, portfolioID uuid.UUID, req *AddHoldingRequest) (*TransactionResponse, error) {
+	executedAt := time.Now()
+	if req.AcquiredAt != nil {
+		executedAt = *req.AcquiredAt
+	}
+	t := &portfolio.Transaction{
+		PortfolioID: portfolioID,
+		Type:        portfolio.TransactionTypeBuy,
+		Symbol:      normalizeSymbol(req.Symbol),
+		Quantity:    req.Quantity,
+		Price:       req.AverageCost,
+		ExecutedAt:  executedAt,
+		Source:      portfolio.TransactionSourceManual,
+	}
+	if err := t.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	var asset *portfolio.Asset
+	if req.Name != nil || req.AssetClass != nil || req.Sector != nil {
+		asset = &portfolio.Asset{
+			PortfolioID: portfolioID,
+			Symbol:      t.Symbol,
+			Name:        optionalText(req.Name),
+			AssetClass:  optionalText(req.AssetClass),
+			Sector:      optionalText(req.Sector),
+		}
+		if err := asset.Validate(); err != nil {
+			return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+	}
+
+	var created *portfolio.Transaction
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+		var err error
+		created, err = provider.PortfolioTransaction().Create(ctx, t)
+		if err != nil {
+			return fmt.Errorf("failed to create portfolio transaction: %w", err)
+		}
+		if asset != nil {
+			if _, err := provider.PortfolioAsset().Upsert(ctx, asset); err != nil {
+				return fmt.Errorf("failed to classify asset: %w", err)
+			}
+		}
+		return checkLedger(ctx, provider, portfolioID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToTransactionResponse(created), nil
+}
+
+// DeleteTransaction removes a transaction, unless later sells depend on it.
+func (uc *PortfolioUseCase) DeleteTransaction(ctx context.Context, userID, portfolioID, transactionID uuid.UUID) error {
+	return uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+
+		t, err := provider.PortfolioTransaction().GetByID(ctx, transactionID)
+		if err != nil {
+			if err == errors.ErrPortfolioTransactionNotFound {
+				return errors.NewAppError(errors.CodeNotFound, "Transaction not found", err)
+			}
+			return fmt.Errorf("failed to get portfolio transaction: %w", err)
+		}
+		if t.PortfolioID != portfolioID {
+			return errors.NewAppError(errors.CodeNotFound, "Transaction not found", nil)
+		}
+
+		if err := provider.PortfolioTransaction().Delete(ctx, transactionID); err != nil {
+			return fmt.Errorf("failed to delete portfolio transaction: %w", err)
+		}
+		return checkLedger(ctx, provider, portfolioID)
+	})
+}
+
+// UpdateAsset sets the name, asset class or sector used for the allocation breakdown.
+func (uc *PortfolioUseCase) UpdateAsset(ctx context.Context, userID, portfolioID uuid.UUID, symbol string, req *UpdateAssetRequest) (*AssetResponse, error) {
+	asset := &portfolio.Asset{
+		PortfolioID: portfolioID,
+		Symbol:      normalizeSymbol(symbol),
+		Name:        optionalText(req.Name),
+		AssetClass:  optionalText(req.AssetClass),
+		Sector:      optionalText(req.Sector),
+	}
+	if err := asset.Validate(); err != nil {
+		return nil, errors.NewAppError(errors.CodeValidation, err.Error(), err)
+	}
+
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		if _, err := loadPortfolio(ctx, provider, userID, portfolioID); err != nil {
+			return err
+		}
+		var err error
+		asset, err = provider.PortfolioAsset().Upsert(ctx, asset)
+		if err != nil {
+			return fmt.Errorf("failed to update portfolio asset: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ToAssetResponse(asset), nil
+}
+
+// GetSummary values the portfolio at the latest stored daily closes and computes
+// its allocation, returns and risk over the requested period.
+func (uc *PortfolioUseCase) GetSummary(ctx context.Context, userID, portfolioID uuid.UUID, req *SummaryRequest) (*SummaryResponse, error) {
+	var response *SummaryResponse
+	err := uc.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		p, err := loadPortfolio(ctx, provider, userID, portfolioID)
+		if err != nil {
+			return err
+		}
+		transactions, err := provider.PortfolioTransaction().GetByPortfolioID(ctx, portfolioID)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio transactions: %w", err)
+		}
+		assets, err := provider.PortfolioAsset().GetByPortfolioID(ctx, portfolioID)
+		if err != nil {
+			return fmt.Errorf("failed to get portfolio assets: %w", err)
+		}
+		response, err = buildSummary(ctx, provider, p, transactions, assets, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ResolvePortfolioID picks the portfolio a tool call operates on. Without an
+// explicit ID the user's only portfolio is used.
+func (uc *PortfolioUseCase) ResolvePortfolioID(ctx context.Context, userID uuid.UUID, portfolioID *uuid.UUID) (uuid.UUID, error) {
+	if portfolioID != nil {
+		return *portfolioID, nil
+	}
+
+	portfolios, err := uc.ListPortfolios(ctx, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	switch len(portfolios) {
+	case 0:
+		return uuid.Nil, fmt.Errorf("the user has no portfolio; one must be created first")
+	case 1:
+		return portfolios[0].ID, nil
+	default:
+		names := make([]string, len(portfolios))
+		for i, p := range portfolios {
+			names[i] = fmt.Sprintf("%s (%s)", p.Name, p.ID)
+		}
+		return uuid.Nil, fmt.Errorf("the user has several portfolios, specify portfolio_id: %s", strings.Join(names, ", "))
+	}
+}
+
+// loadPortfolio fetches the portfolio and checks ownership.
+func loadPortfolio(ctx context.Context, provider database.RepositoryProvider, userID, portfolioID uuid.UUID) (*portfolio.Portfolio, error) {
+	p, err := provider.Portfolio().GetByID(ctx, portfolioID)
+	if err != nil {
+		if err == errors.ErrPortfolioNotFound {
+			return nil, errors.NewAppError(errors.CodeNotFound, "Portfolio not found", err)
+		}
+		return nil, fmt.Errorf("failed to get portfolio: %w", err)
+	}
+	if p.UserID != userID {
+		return nil, errors.ErrForbidden
+	}
+	return p, nil
+}
+
+// checkLedger replays the stored transactions and fails if a sell exceeds the
+// quantity held at that point, which rolls back the change that caused it.
+func checkLedger(ctx context.Context, provider database.RepositoryProvider, portfolioID uuid.UUID) error {
+	transactions, err := provider.PortfolioTransaction().GetByPortfolioID(ctx, portfolioID)
+	if err != nil {
+		return fmt.Errorf("failed to get portfolio transactions: %w", err)
+	}
+	ledger := portfolio.NewLedger()
+	for _, t := range transactions {
+		if _, err := ledger.Apply(t); err != nil {
+			return errors.NewAppError(errors.CodeValidation, err.Error(), err)
+		}
+	}
+	return nil
+}
+
+func validatePortfolio(p *portfolio.Portfolio) error {
+	if p.Name == "" || len(p.Name) > 255 {
+		return errors.NewAppError(errors.CodeValidation, "name must be between 1 and 255 characters", nil)
+	}
+	if len(p.BaseCurrency) != 3 {
+		return errors.NewAppError(errors.CodeValidation, "base_currency must be a 3-letter currency code", nil)
+	}
+	if p.BenchmarkSymbol != nil && len(*p.BenchmarkSymbol) > portfolio.MaxSymbolLength {
+		return errors.NewAppError(errors.CodeValidation, fmt.Sprintf("benchmark_symbol must be at most %d characters", portfolio.MaxSymbolLength), nil)
+	}
+	return nil
+}
+
+func normalizeSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// optionalSymbol normalizes an optional symbol; empty values become nil.
+func optionalSymbol(symbol *string) *string {
+	if symbol == nil {
+		return nil
+	}
+	s := normalizeSymbol(*symbol)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// optionalText trims an optional string; empty values become nil.
+func optionalText(text *string) *string {
+	if text == nil {
+		return nil
+	}
+	s := strings.TrimSpace(*text)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -3,13 +3,18 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"encoding/base64"
 
 	"github.com/spf13/viper"
+
+	"trading-alchemist/pkg/utils"
 )
 
 type Config struct {
@@ -27,6 +32,27 @@ type Config struct {
 
 	// App configuration
 	App AppConfig
+
+	// Alert worker configuration
+	Alerts AlertConfig
+
+	// Code sandbox configuration
+	Sandbox SandboxConfig
+
+	// Document retrieval configuration
+	RAG RAGConfig
+
+	// External sign-in provider configuration
+	OIDC OIDCConfig
+
+	// Second factor configuration
+	TwoFactor TwoFactorConfig
+
+	// Request throttling configuration
+	RateLimit RateLimitConfig
+
+	// Account deletion configuration
+	Account AccountConfig
 }
 
 type ServerConfig struct {
@@ -34,6 +60,7 @@ type ServerConfig struct {
 	Port         string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	ProxyHeader  string // Header holding the client IP behind a reverse proxy, e.g. X-Forwarded-For
 }
 
 type DatabaseConfig struct {
@@ -57,8 +84,9 @@ type EmailConfig struct {
 }
 
 type JWTConfig struct {
-	Secret string
-	TTL    string
+	Secret     string
+	TTL        string // Lifetime of access tokens
+	RefreshTTL string // Lifetime of refresh tokens; each refresh issues a new one
 }
 
 type AppConfig struct {
@@ -68,7 +96,96 @@ type AppConfig struct {
 	FrontendBaseURL string
 	MagicLinkTTL    string
 	DefaultModel    string
-	EncryptionKey   string
+	EncryptionKey   string // Key of ciphertexts without a key ID
+	EncryptionKeys  string // Versioned keys as id:base64key pairs separated by commas
+	EncryptionKeyID string // ID of the key new ciphertexts are encrypted with
+}
+
+type AlertConfig struct {
+	WorkerEnabled  bool
+	WorkerInterval time.Duration
+}
+
+// SandboxConfig limits the scripts run by the run_code tool.
+type SandboxConfig struct {
+	Enabled        bool
+	Interpreter    string        // Python interpreter, looked up in PATH
+	WorkDir        string        // Parent directory of the per-run workspaces; defaults to the system temp directory
+	Timeout        time.Duration // Wall-clock limit; the CPU time limit is derived from it
+	MemoryMB       int           // Address space limit
+	MaxFileMB      int           // Largest file a script may write
+	MaxOutputKB    int           // Captured stdout and stderr, each
+	MaxOutputFiles int           // Output files collected as artifacts
+	Isolate        bool          // Run in new user, network, mount and PID namespaces
+	HiddenPaths    []string      // Paths covered inside the sandbox; defaults to the working directory
+}
+
+// RAGConfig controls document indexing and retrieval.
+type RAGConfig struct {
+	Enabled           bool
+	EmbeddingProvider string  // Provider name; users need an API key for it
+	EmbeddingModel    string  // Model name at that provider
+	ChunkSize         int     // Target chunk size in characters
+	ChunkOverlap      int     // Characters repeated between consecutive chunks
+	TopK              int     // Chunks retrieved per message
+	MinSimilarity     float64 // Chunks less similar to the message are dropped
+	MaxUploadMB       int     // Largest accepted upload
+	VectorIndex       string  // auto, pgvector or memory
+}
+
+// OIDCConfig configures sign-in with external OIDC and OAuth providers.
+type OIDCConfig struct {
+	Providers   []OIDCProviderConfig
+	RedirectURL string        // Frontend page the providers send the user back to with the code and state
+	LoginTTL    time.Duration // Time allowed to complete a sign-in at the provider
+}
+
+// OIDCProviderConfig configures one sign-in provider, read from OIDC_<NAME>_* settings.
+type OIDCProviderConfig struct {
+	Name         string   // Identifies the provider in URLs and linked identities; must not change
+	Type         string   // oidc, or github for GitHub's OAuth API
+	IssuerURL    string   // OIDC issuer, discovered at /.well-known/openid-configuration; the web base URL for github
+	APIURL       string   // REST API base URL, for github only
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	RedirectURL  string // Overrides OIDCConfig.RedirectURL
+}
+
+// TwoFactorConfig configures TOTP and WebAuthn second factors.
+type TwoFactorConfig struct {
+	ChallengeTTL    time.Duration // Time allowed to enter a second factor
+	StepUpMaxAge    time.Duration // How long a second factor check covers sensitive actions
+	WebAuthnRPID    string        // Domain passkeys are bound to; defaults to the host of FRONTEND_BASE_URL
+	WebAuthnRPName  string        // Shown by the browser; defaults to APP_NAME
+	WebAuthnOrigins []string      // Origins allowed to use passkeys; defaults to FRONTEND_BASE_URL
+}
+
+// AccountConfig controls the deletion of accounts at their owners' request.
+type AccountConfig struct {
+	DeletionGracePeriod    time.Duration // Time a user has to cancel a deletion
+	DeletionWorkerEnabled  bool
+	DeletionWorkerInterval time.Duration // How often accounts past their grace period are purged
+}
+
+// RateLimitConfig configures request throttling. Each limit is written as
+// "<requests>/<window>", e.g. "5/1h", and allows bursts of up to <requests>;
+// "0" turns the limit off.
+type RateLimitConfig struct {
+	Enabled           bool
+	Store             string    // memory or postgres
+	MagicLinkPerIP    RateLimit // Magic link requests per client IP
+	MagicLinkPerEmail RateLimit // Magic links and invitations sent per email address
+	AuthPerIP         RateLimit // Other sign-in attempts (verify, refresh, OIDC, 2FA) per client IP
+	ChatPerUser       RateLimit // Chat messages and tool call confirmations per user
+
+	invalid []string // Settings that could not be parsed, reported by Validate
+}
+
+// RateLimit allows Requests requests per Window.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
 }
 
 // Load loads configuration from environment variables using Viper
@@ -88,6 +205,7 @@ func Load() *Config {
 			Port:         v.GetString("SERVER_PORT"),
 			ReadTimeout:  v.GetDuration("SERVER_READ_TIMEOUT"),
 			WriteTimeout: v.GetDuration("SERVER_WRITE_TIMEOUT"),
+			ProxyHeader:  v.GetString("SERVER_PROXY_HEADER"),
 		},
 		Database: DatabaseConfig{
 			Host:         v.GetString("DB_HOST"),
@@ -107,8 +225,9 @@ func Load() *Config {
 			FromName:     v.GetString("FROM_NAME"),
 		},
 		JWT: JWTConfig{
-			Secret: v.GetString("JWT_SECRET"),
-			TTL:    v.GetString("JWT_TTL"),
+			Secret:     v.GetString("JWT_SECRET"),
+			TTL:        v.GetString("JWT_TTL"),
+			RefreshTTL: v.GetString("JWT_REFRESH_TTL"),
 		},
 		App: AppConfig{
 			Name:            v.GetString("APP_NAME"),
@@ -118,10 +237,161 @@ func Load() *Config {
 			MagicLinkTTL:    v.GetString("MAGIC_LINK_TTL"),
 			DefaultModel:    v.GetString("DEFAULT_MODEL"),
 			EncryptionKey:   v.GetString("ENCRYPTION_KEY"),
+			EncryptionKeys:  v.GetString("ENCRYPTION_KEYS"),
+			EncryptionKeyID: v.GetString("ENCRYPTION_KEY_ID"),
+		},
+		Alerts: AlertConfig{
+			WorkerEnabled:  v.GetBool("ALERT_WORKER_ENABLED"),
+			WorkerInterval: v.GetDuration("ALERT_WORKER_INTERVAL"),
+		},
+		Sandbox: SandboxConfig{
+			Enabled:        v.GetBool("SANDBOX_ENABLED"),
+			Interpreter:    v.GetString("SANDBOX_INTERPRETER"),
+			WorkDir:        v.GetString("SANDBOX_WORK_DIR"),
+			Timeout:        v.GetDuration("SANDBOX_TIMEOUT"),
+			MemoryMB:       v.GetInt("SANDBOX_MEMORY_MB"),
+			MaxFileMB:      v.GetInt("SANDBOX_MAX_FILE_MB"),
+			MaxOutputKB:    v.GetInt("SANDBOX_MAX_OUTPUT_KB"),
+			MaxOutputFiles: v.GetInt("SANDBOX_MAX_OUTPUT_FILES"),
+			Isolate:        v.GetBool("SANDBOX_ISOLATE"),
+			HiddenPaths:    splitList(v.GetString("SANDBOX_HIDDEN_PATHS")),
+		},
+		RAG: RAGConfig{
+			Enabled:           v.GetBool("RAG_ENABLED"),
+			EmbeddingProvider: v.GetString("RAG_EMBEDDING_PROVIDER"),
+			EmbeddingModel:    v.GetString("RAG_EMBEDDING_MODEL"),
+			ChunkSize:         v.GetInt("RAG_CHUNK_SIZE"),
+			ChunkOverlap:      v.GetInt("RAG_CHUNK_OVERLAP"),
+			TopK:              v.GetInt("RAG_TOP_K"),
+			MinSimilarity:     v.GetFloat64("RAG_MIN_SIMILARITY"),
+			MaxUploadMB:       v.GetInt("RAG_MAX_UPLOAD_MB"),
+			VectorIndex:       v.GetString("RAG_VECTOR_INDEX"),
+		},
+		OIDC:      loadOIDCConfig(v),
+		TwoFactor: loadTwoFactorConfig(v),
+		RateLimit: loadRateLimitConfig(v),
+		Account: AccountConfig{
+			DeletionGracePeriod:    v.GetDuration("ACCOUNT_DELETION_GRACE_PERIOD"),
+			DeletionWorkerEnabled:  v.GetBool("ACCOUNT_DELETION_WORKER_ENABLED"),
+			DeletionWorkerInterval: v.GetDuration("ACCOUNT_DELETION_WORKER_INTERVAL"),
 		},
 	}
 }
 
+// loadOIDCConfig reads the providers listed in OIDC_PROVIDERS. Well-known names
+// (google, github) only need a client ID and secret.
+func loadOIDCConfig(v *viper.Viper) OIDCConfig {
+	cfg := OIDCConfig{
+		RedirectURL: v.GetString("OIDC_REDIRECT_URL"),
+		LoginTTL:    v.GetDuration("OIDC_LOGIN_TTL"),
+	}
+	if cfg.RedirectURL == "" {
+		cfg.RedirectURL = strings.TrimRight(v.GetString("FRONTEND_BASE_URL"), "/") + "/auth/callback"
+	}
+
+	for _, name := range splitList(v.GetString("OIDC_PROVIDERS")) {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		setting := func(key, defaultValue string) string {
+			if value := v.GetString(prefix + key); value != "" {
+				return value
+			}
+			return defaultValue
+		}
+
+		provider := OIDCProviderConfig{
+			Name:         name,
+			ClientID:     v.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: v.GetString(prefix + "CLIENT_SECRET"),
+			RedirectURL:  setting("REDIRECT_URL", cfg.RedirectURL),
+		}
+		switch name {
+		case "github":
+			provider.Type = setting("TYPE", "github")
+			provider.IssuerURL = setting("ISSUER_URL", "https://github.com")
+			provider.APIURL = setting("API_URL", "https://api.github.com")
+			provider.Scopes = splitList(setting("SCOPES", "read:user,user:email"))
+		case "google":
+			provider.Type = setting("TYPE", "oidc")
+			provider.IssuerURL = setting("ISSUER_URL", "https://accounts.google.com")
+			provider.Scopes = splitList(setting("SCOPES", "openid,email,profile"))
+		default:
+			provider.Type = setting("TYPE", "oidc")
+			provider.IssuerURL = setting("ISSUER_URL", "")
+			provider.APIURL = setting("API_URL", "")
+			provider.Scopes = splitList(setting("SCOPES", "openid,email,profile"))
+		}
+		cfg.Providers = append(cfg.Providers, provider)
+	}
+	return cfg
+}
+
+// loadTwoFactorConfig reads the second factor settings. WebAuthn defaults to
+// the frontend's origin, which is where the browser API is called.
+func loadTwoFactorConfig(v *viper.Viper) TwoFactorConfig {
+	cfg := TwoFactorConfig{
+		ChallengeTTL:    v.GetDuration("TWO_FACTOR_CHALLENGE_TTL"),
+		StepUpMaxAge:    v.GetDuration("TWO_FACTOR_STEP_UP_MAX_AGE"),
+		WebAuthnRPID:    v.GetString("WEBAUTHN_RP_ID"),
+		WebAuthnRPName:  v.GetString("WEBAUTHN_RP_NAME"),
+		WebAuthnOrigins: splitList(v.GetString("WEBAUTHN_ORIGINS")),
+	}
+	frontendBaseURL := strings.TrimRight(v.GetString("FRONTEND_BASE_URL"), "/")
+	if cfg.WebAuthnRPID == "" {
+		if u, err := url.Parse(frontendBaseURL); err == nil {
+			cfg.WebAuthnRPID = u.Hostname()
+		}
+	}
+	if cfg.WebAuthnRPName == "" {
+		cfg.WebAuthnRPName = v.GetString("APP_NAME")
+	}
+	if len(cfg.WebAuthnOrigins) == 0 {
+		cfg.WebAuthnOrigins = []string{frontendBaseURL}
+	}
+	return cfg
+}
+
+// loadRateLimitConfig reads the request throttling settings.
+func loadRateLimitConfig(v *viper.Viper) RateLimitConfig {
+	cfg := RateLimitConfig{
+		Enabled: v.GetBool("RATE_LIMIT_ENABLED"),
+		Store:   v.GetString("RATE_LIMIT_STORE"),
+	}
+	limit := func(key string) RateLimit {
+		l, err := parseRateLimit(v.GetString(key))
+		if err != nil {
+			cfg.invalid = append(cfg.invalid, key)
+		}
+		return l
+	}
+	cfg.MagicLinkPerIP = limit("RATE_LIMIT_MAGIC_LINK_PER_IP")
+	cfg.MagicLinkPerEmail = limit("RATE_LIMIT_MAGIC_LINK_PER_EMAIL")
+	cfg.AuthPerIP = limit("RATE_LIMIT_AUTH_PER_IP")
+	cfg.ChatPerUser = limit("RATE_LIMIT_CHAT_PER_USER")
+	return cfg
+}
+
+// parseRateLimit parses a "<requests>/<window>" limit such as "5/1h".
+func parseRateLimit(value string) (RateLimit, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return RateLimit{}, nil
+	}
+	requests, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("missing window in %q", value)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 0 {
+		return RateLimit{}, fmt.Errorf("invalid request count in %q", value)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid window in %q", value)
+	}
+	return RateLimit{Requests: n, Window: d}, nil
+}
+
 // configureViper sets up Viper configuration
 func configureViper(v *viper.Viper, env string) {
 	// Set the config name and type
@@ -157,6 +427,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("SERVER_PORT", "8080")
 	v.SetDefault("SERVER_READ_TIMEOUT", "10s")
 	v.SetDefault("SERVER_WRITE_TIMEOUT", "10s")
+	v.SetDefault("SERVER_PROXY_HEADER", "")
 
 	// Database defaults
 	v.SetDefault("DB_HOST", "localhost")
@@ -177,7 +448,8 @@ func setDefaults(v *viper.Viper) {
 
 	// JWT defaults
 	v.SetDefault("JWT_SECRET", "your-secret-key")
-	v.SetDefault("JWT_TTL", "24h")
+	v.SetDefault("JWT_TTL", "15m")
+	v.SetDefault("JWT_REFRESH_TTL", "720h")
 
 	// App defaults
 	v.SetDefault("APP_NAME", "Trading Alchemist")
@@ -187,6 +459,60 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("MAGIC_LINK_TTL", "15m")
 	v.SetDefault("DEFAULT_MODEL", "openai/gpt-4o-mini")
 	v.SetDefault("ENCRYPTION_KEY", "")
+	v.SetDefault("ENCRYPTION_KEYS", "")
+	v.SetDefault("ENCRYPTION_KEY_ID", "")
+
+	// Alert worker defaults
+	v.SetDefault("ALERT_WORKER_ENABLED", true)
+	v.SetDefault("ALERT_WORKER_INTERVAL", "1m")
+
+	// Code sandbox defaults
+	v.SetDefault("SANDBOX_ENABLED", false)
+	v.SetDefault("SANDBOX_INTERPRETER", "python3")
+	v.SetDefault("SANDBOX_WORK_DIR", "")
+	v.SetDefault("SANDBOX_TIMEOUT", "30s")
+	v.SetDefault("SANDBOX_MEMORY_MB", 512)
+	v.SetDefault("SANDBOX_MAX_FILE_MB", 10)
+	v.SetDefault("SANDBOX_MAX_OUTPUT_KB", 64)
+	v.SetDefault("SANDBOX_MAX_OUTPUT_FILES", 10)
+	v.SetDefault("SANDBOX_ISOLATE", true)
+	v.SetDefault("SANDBOX_HIDDEN_PATHS", "")
+
+	// Document retrieval defaults
+	v.SetDefault("RAG_ENABLED", true)
+	v.SetDefault("RAG_EMBEDDING_PROVIDER", "openai")
+	v.SetDefault("RAG_EMBEDDING_MODEL", "text-embedding-3-small")
+	v.SetDefault("RAG_CHUNK_SIZE", 1200)
+	v.SetDefault("RAG_CHUNK_OVERLAP", 200)
+	v.SetDefault("RAG_TOP_K", 5)
+	v.SetDefault("RAG_MIN_SIMILARITY", 0.3)
+	v.SetDefault("RAG_MAX_UPLOAD_MB", 20)
+	v.SetDefault("RAG_VECTOR_INDEX", "auto")
+
+	// External sign-in defaults
+	v.SetDefault("OIDC_PROVIDERS", "")
+	v.SetDefault("OIDC_REDIRECT_URL", "")
+	v.SetDefault("OIDC_LOGIN_TTL", "10m")
+
+	// Second factor defaults
+	v.SetDefault("TWO_FACTOR_CHALLENGE_TTL", "5m")
+	v.SetDefault("TWO_FACTOR_STEP_UP_MAX_AGE", "15m")
+	v.SetDefault("WEBAUTHN_RP_ID", "")
+	v.SetDefault("WEBAUTHN_RP_NAME", "")
+	v.SetDefault("WEBAUTHN_ORIGINS", "")
+
+	// Rate limiting defaults
+	v.SetDefault("RATE_LIMIT_ENABLED", true)
+	v.SetDefault("RATE_LIMIT_STORE", "memory")
+	v.SetDefault("RATE_LIMIT_MAGIC_LINK_PER_IP", "10/1h")
+	v.SetDefault("RATE_LIMIT_MAGIC_LINK_PER_EMAIL", "5/1h")
+	v.SetDefault("RATE_LIMIT_AUTH_PER_IP", "30/1m")
+	v.SetDefault("RATE_LIMIT_CHAT_PER_USER", "20/1m")
+
+	// Account deletion defaults
+	v.SetDefault("ACCOUNT_DELETION_GRACE_PERIOD", "720h")
+	v.SetDefault("ACCOUNT_DELETION_WORKER_ENABLED", true)
+	v.SetDefault("ACCOUNT_DELETION_WORKER_INTERVAL", "1h")
 }
 
 // LoadForEnvironment loads configuration for a specific environment
@@ -210,28 +536,122 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("database password must be changed for production environment")
 	}
 
+	if c.App.EncryptionKey != "" || c.App.EncryptionKeys != "" {
+		if _, err := c.GetKeyring(); err != nil {
+			log.Printf("Warning: encryption keys are invalid, stored API keys and secrets cannot be used: %v", err)
+		}
+	}
+
+	if c.Alerts.WorkerEnabled && c.Alerts.WorkerInterval <= 0 {
+		return fmt.Errorf("ALERT_WORKER_INTERVAL must be positive")
+	}
+
+	if c.Sandbox.Enabled {
+		if c.Sandbox.Timeout <= 0 || c.Sandbox.MemoryMB <= 0 || c.Sandbox.MaxFileMB <= 0 || c.Sandbox.MaxOutputKB <= 0 {
+			return fmt.Errorf("SANDBOX_TIMEOUT, SANDBOX_MEMORY_MB, SANDBOX_MAX_FILE_MB and SANDBOX_MAX_OUTPUT_KB must be positive")
+		}
+	}
+
+	if c.RAG.Enabled {
+		if c.RAG.ChunkSize < 100 || c.RAG.ChunkOverlap < 0 || c.RAG.TopK <= 0 || c.RAG.MaxUploadMB <= 0 {
+			return fmt.Errorf("RAG_CHUNK_SIZE must be at least 100, RAG_CHUNK_OVERLAP non-negative, and RAG_TOP_K and RAG_MAX_UPLOAD_MB positive")
+		}
+		switch c.RAG.VectorIndex {
+		case "auto", "pgvector", "memory":
+		default:
+			return fmt.Errorf("RAG_VECTOR_INDEX must be auto, pgvector or memory")
+		}
+	}
+
+	if len(c.OIDC.Providers) > 0 && c.OIDC.LoginTTL <= 0 {
+		return fmt.Errorf("OIDC_LOGIN_TTL must be positive")
+	}
+	seen := make(map[string]bool, len(c.OIDC.Providers))
+	for _, p := range c.OIDC.Providers {
+		if seen[p.Name] {
+			return fmt.Errorf("OIDC provider %q is listed twice in OIDC_PROVIDERS", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Type != "oidc" && p.Type != "github" {
+			return fmt.Errorf("OIDC provider %q has unsupported type %q: must be oidc or github", p.Name, p.Type)
+		}
+		if p.ClientID == "" || p.IssuerURL == "" {
+			return fmt.Errorf("OIDC provider %q needs a client ID and an issuer URL", p.Name)
+		}
+		if p.Type == "github" && p.APIURL == "" {
+			return fmt.Errorf("OIDC provider %q needs an API URL", p.Name)
+		}
+	}
+
+	if c.TwoFactor.ChallengeTTL <= 0 || c.TwoFactor.StepUpMaxAge <= 0 {
+		return fmt.Errorf("TWO_FACTOR_CHALLENGE_TTL and TWO_FACTOR_STEP_UP_MAX_AGE must be positive")
+	}
+	if c.TwoFactor.WebAuthnRPID == "" {
+		return fmt.Errorf("WEBAUTHN_RP_ID must be set when FRONTEND_BASE_URL has no host")
+	}
+
+	if c.RateLimit.Enabled {
+		if len(c.RateLimit.invalid) > 0 {
+			return fmt.Errorf("%s must look like 5/1h, or 0 to turn the limit off", strings.Join(c.RateLimit.invalid, ", "))
+		}
+		if c.RateLimit.Store != "memory" && c.RateLimit.Store != "postgres" {
+			return fmt.Errorf("RATE_LIMIT_STORE must be memory or postgres")
+		}
+	}
+
+	if c.Account.DeletionGracePeriod < 0 {
+		return fmt.Errorf("ACCOUNT_DELETION_GRACE_PERIOD must not be negative")
+	}
+	if c.Account.DeletionWorkerEnabled && c.Account.DeletionWorkerInterval <= 0 {
+		return fmt.Errorf("ACCOUNT_DELETION_WORKER_INTERVAL must be positive")
+	}
+
 	return nil
 }
 
-// GetEncryptionKey returns the encryption key as a 32-byte array for AES-256.
-// The key is expected to be base64-encoded in the configuration.
-func (c *Config) GetEncryptionKey() ([]byte, error) {
-	if c.App.EncryptionKey == "" {
-		return nil, fmt.Errorf("encryption key is not configured")
+// GetKeyring returns the keyring of the configured encryption keys. Keys are
+// expected to be base64-encoded. ENCRYPTION_KEY decrypts ciphertexts written
+// before keys were versioned; new ciphertexts are encrypted with the key of
+// ENCRYPTION_KEY_ID, or by default the last key of ENCRYPTION_KEYS.
+func (c *Config) GetKeyring() (*utils.Keyring, error) {
+	keys := make(map[string][]byte)
+	currentID := c.App.EncryptionKeyID
+	if c.App.EncryptionKey != "" {
+		key, err := base64.StdEncoding.DecodeString(c.App.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode encryption key from base64: %w", err)
+		}
+		keys[utils.LegacyKeyID] = key
+		if currentID == "" {
+			currentID = utils.LegacyKeyID
+		}
 	}
-	
-	// Decode from base64
-	key, err := base64.StdEncoding.DecodeString(c.App.EncryptionKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode encryption key from base64: %w", err)
+	for _, pair := range strings.Split(c.App.EncryptionKeys, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		id, encoded, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("ENCRYPTION_KEYS entries must look like id:base64key")
+		}
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("encryption key %s is configured twice", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode encryption key %s from base64: %w", id, err)
+		}
+		keys[id] = key
+		if c.App.EncryptionKeyID == "" {
+			currentID = id
+		}
 	}
-	
-	// Validate key size (must be 32 bytes for AES-256)
-	if len(key) != 32 {
-		return nil, fmt.Errorf("encryption key must be exactly 32 bytes for AES-256, got %d bytes", len(key))
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("encryption key is not configured")
 	}
-	
-	return key, nil
+
+	return utils.NewKeyring(currentID, keys)
 }
 
 // GetConfigPath returns the path to the config file for the given environment
@@ -247,6 +667,17 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// splitList splits a comma-separated setting, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // Legacy helper functions (kept for compatibility but deprecated)
 func getIntEnv(key string, defaultValue int) int {
 	log.Printf("Warning: getIntEnv is deprecated, use Viper configuration instead")
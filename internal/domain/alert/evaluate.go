@@ -0,0 +1,154 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/strategy"
+)
+
+const (
+	// maxWindowBars bounds the percent move window so the bars it needs can be loaded at once.
+	maxWindowBars = 1000
+	// indicatorWarmupBars are loaded on top of an indicator's period so that
+	// smoothed indicators such as EMA and RSI have settled.
+	indicatorWarmupBars = 100
+)
+
+// Trigger is a candle on which a rule fired.
+type Trigger struct {
+	Candle  *market.Candle
+	Message string
+}
+
+// Lookback returns how many bars before an evaluated bar the rule reads,
+// including the bar its previous state is derived from.
+func (r *Rule) Lookback() int {
+	switch r.Type {
+	case RuleTypePercentMove:
+		return int(r.Window()/r.Timeframe.Duration()) + 1
+	case RuleTypeIndicator:
+		return strategy.Lookback(r.Condition) + indicatorWarmupBars + 1
+	}
+	return 1
+}
+
+// Scan evaluates the rule on every closed candle after the one recorded in state
+// and returns the candles on which it fired. A rule fires when its condition
+// starts to hold, so a level that stays crossed alerts once, and it does not fire
+// again within the cooldown. Without a state only the latest closed candle is
+// evaluated, so creating a rule does not alert on history. bars must be sorted
+// by open time and include Lookback bars before the first candle to evaluate.
+// The returned state reflects the last evaluated candle.
+func Scan(rule *Rule, symbol string, state *State, bars []*market.Candle, now time.Time) (*State, []Trigger) {
+	barDuration := rule.Timeframe.Duration()
+	closed := len(bars)
+	for closed > 0 && bars[closed-1].OpenTime.Add(barDuration).After(now) {
+		closed--
+	}
+	if closed == 0 {
+		return state, nil
+	}
+
+	c := newCheck(rule, bars)
+	start := closed - 1
+	var active bool
+	if state == nil {
+		state = &State{RuleID: rule.ID, Symbol: symbol}
+		active = start > 0 && c.holds(start-1)
+	} else {
+		active = state.Active
+		start = sort.Search(closed, func(i int) bool { return bars[i].OpenTime.After(state.LastCandleTime) })
+		if start == closed {
+			return state, nil
+		}
+	}
+
+	var triggers []Trigger
+	for i := start; i < closed; i++ {
+		holds := c.holds(i)
+		if holds && !active {
+			closeTime := bars[i].OpenTime.Add(barDuration)
+			if state.LastTriggeredAt == nil || !closeTime.Before(state.LastTriggeredAt.Add(rule.Cooldown())) {
+				triggers = append(triggers, Trigger{Candle: bars[i], Message: c.describe(i)})
+				state.LastTriggeredAt = &closeTime
+			}
+		}
+		active = holds
+		state.LastCandleTime = bars[i].OpenTime
+	}
+	state.Active = active
+	return state, triggers
+}
+
+// check evaluates a rule's condition on a fixed series of bars.
+type check struct {
+	rule      *Rule
+	bars      []*market.Candle
+	indicator *strategy.ConditionEvaluator
+}
+
+func newCheck(rule *Rule, bars []*market.Candle) *check {
+	c := &check{rule: rule, bars: bars}
+	if rule.Type == RuleTypeIndicator {
+		c.indicator = strategy.NewConditionEvaluator(rule.Condition, bars)
+	}
+	return c
+}
+
+// holds reports whether the rule's condition holds at the close of bar i.
+func (c *check) holds(i int) bool {
+	switch c.rule.Type {
+	case RuleTypePriceCross:
+		if c.rule.Direction == DirectionAbove {
+			return c.bars[i].Close > *c.rule.Level
+		}
+		return c.bars[i].Close < *c.rule.Level
+	case RuleTypePercentMove:
+		change, ok := c.change(i)
+		if !ok {
+			return false
+		}
+		switch c.rule.Direction {
+		case DirectionUp:
+			return change >= *c.rule.Percent
+		case DirectionDown:
+			return change <= -*c.rule.Percent
+		}
+		return math.Abs(change) >= *c.rule.Percent
+	case RuleTypeIndicator:
+		return c.indicator.Holds(i)
+	}
+	return false
+}
+
+// change returns the percentage change of the close at bar i from the last
+// close at or before the start of the window.
+func (c *check) change(i int) (float64, bool) {
+	target := c.bars[i].OpenTime.Add(-c.rule.Window())
+	j := sort.Search(i+1, func(j int) bool { return c.bars[j].OpenTime.After(target) }) - 1
+	if j < 0 || c.bars[j].Close <= 0 {
+		return 0, false
+	}
+	return (c.bars[i].Close/c.bars[j].Close - 1) * 100, true
+}
+
+func (c *check) describe(i int) string {
+	bar := c.bars[i]
+	switch c.rule.Type {
+	case RuleTypePriceCross:
+		return fmt.Sprintf("%s closed %s %s at %s", bar.Symbol, c.rule.Direction, formatNumber(*c.rule.Level), formatNumber(bar.Close))
+	case RuleTypePercentMove:
+		change, _ := c.change(i)
+		return fmt.Sprintf("%s moved %+.2f%% within %s to %s", bar.Symbol, change, c.rule.Window(), formatNumber(bar.Close))
+	}
+	return fmt.Sprintf("%s matched %s at %s", bar.Symbol, c.rule.Condition, formatNumber(bar.Close))
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
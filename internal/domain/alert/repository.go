@@ -0,0 +1,48 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type WatchlistRepository interface {
+	Create(ctx context.Context, watchlist *Watchlist) (*Watchlist, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Watchlist, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Watchlist, error)
+	Update(ctx context.Context, watchlist *Watchlist) (*Watchlist, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type WatchlistItemRepository interface {
+	// Upsert adds the symbol to the watchlist or updates its notes
+	Upsert(ctx context.Context, item *WatchlistItem) (*WatchlistItem, error)
+	GetByWatchlistID(ctx context.Context, watchlistID uuid.UUID) ([]*WatchlistItem, error)
+	Delete(ctx context.Context, watchlistID uuid.UUID, symbol string) error
+}
+
+type RuleRepository interface {
+	Create(ctx context.Context, rule *Rule) (*Rule, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Rule, error)
+	// GetEnabled returns the enabled rules of all users, for the alert worker
+	GetEnabled(ctx context.Context) ([]*Rule, error)
+	Update(ctx context.Context, rule *Rule) (*Rule, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type StateRepository interface {
+	// Get returns ErrAlertRuleStateNotFound if the rule was never evaluated for the symbol
+	Get(ctx context.Context, ruleID uuid.UUID, symbol string) (*State, error)
+	Upsert(ctx context.Context, state *State) (*State, error)
+	// DeleteByRuleID resets the evaluation progress, e.g. after the rule changed
+	DeleteByRuleID(ctx context.Context, ruleID uuid.UUID) error
+}
+
+type EventRepository interface {
+	// Create returns ErrDuplicateAlertEvent if the rule already fired on the same candle
+	Create(ctx context.Context, event *Event) (*Event, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*Event, error)
+	GetByRuleID(ctx context.Context, ruleID uuid.UUID, limit, offset int) ([]*Event, error)
+	UpdateEmailStatus(ctx context.Context, id uuid.UUID, status EmailStatus) error
+}
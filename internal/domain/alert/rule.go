@@ -0,0 +1,164 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/strategy"
+
+	"github.com/google/uuid"
+)
+
+// MaxSymbolLength is the longest symbol that can be stored.
+const MaxSymbolLength = 32
+
+// RuleType is the kind of condition an alert rule checks.
+type RuleType string
+
+const (
+	// RuleTypePriceCross fires when the close crosses Level in Direction (above or below).
+	RuleTypePriceCross RuleType = "price_cross"
+	// RuleTypePercentMove fires when the close moved by at least Percent within the window.
+	RuleTypePercentMove RuleType = "percent_move"
+	// RuleTypeIndicator fires when a strategy DSL condition starts to hold, e.g. RSI below 30.
+	RuleTypeIndicator RuleType = "indicator"
+)
+
+// Direction restricts which way a price cross or move fires.
+type Direction string
+
+const (
+	DirectionAbove Direction = "above"
+	DirectionBelow Direction = "below"
+	DirectionUp    Direction = "up"
+	DirectionDown  Direction = "down"
+	DirectionAny   Direction = "any"
+)
+
+// Rule is an alert condition evaluated against closed candles of a single
+// symbol or of every symbol on a watchlist.
+type Rule struct {
+	ID              uuid.UUID           `json:"id" db:"id"`
+	UserID          uuid.UUID           `json:"user_id" db:"user_id"`
+	Name            string              `json:"name" db:"name"`
+	Symbol          *string             `json:"symbol" db:"symbol"`             // Set when the rule watches a single symbol
+	WatchlistID     *uuid.UUID          `json:"watchlist_id" db:"watchlist_id"` // Set when the rule watches a watchlist
+	Timeframe       market.Timeframe    `json:"timeframe" db:"timeframe"`
+	Type            RuleType            `json:"type" db:"type"`
+	Direction       Direction           `json:"direction" db:"direction"`
+	Level           *float64            `json:"level" db:"level"`
+	Percent         *float64            `json:"percent" db:"percent"`
+	WindowSeconds   *int                `json:"window_seconds" db:"window_seconds"`
+	Condition       *strategy.Condition `json:"indicator_condition" db:"indicator_condition"`
+	CooldownSeconds int                 `json:"cooldown_seconds" db:"cooldown_seconds"` // Minimum time between two triggers per symbol
+	NotifyEmail     bool                `json:"notify_email" db:"notify_email"`
+	NotifyInApp     bool                `json:"notify_in_app" db:"notify_in_app"`
+	Enabled         bool                `json:"enabled" db:"enabled"`
+	CreatedAt       time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// Window returns the period a percent move is measured over.
+func (r *Rule) Window() time.Duration {
+	if r.WindowSeconds == nil {
+		return 0
+	}
+	return time.Duration(*r.WindowSeconds) * time.Second
+}
+
+// Cooldown returns the minimum time between two triggers for the same symbol.
+func (r *Rule) Cooldown() time.Duration {
+	return time.Duration(r.CooldownSeconds) * time.Second
+}
+
+// Validate checks that the target is set and that the fields required by the rule type are present.
+func (r *Rule) Validate() error {
+	if (r.Symbol == nil) == (r.WatchlistID == nil) {
+		return fmt.Errorf("exactly one of symbol and watchlist_id is required")
+	}
+	if r.Symbol != nil && (*r.Symbol == "" || len(*r.Symbol) > MaxSymbolLength) {
+		return fmt.Errorf("symbol must be between 1 and %d characters", MaxSymbolLength)
+	}
+	if r.Timeframe.Duration() == 0 {
+		return fmt.Errorf("unsupported timeframe: %s", r.Timeframe)
+	}
+	if r.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldown_seconds must not be negative")
+	}
+	if !r.NotifyEmail && !r.NotifyInApp {
+		return fmt.Errorf("at least one of notify_email and notify_in_app must be enabled")
+	}
+
+	switch r.Type {
+	case RuleTypePriceCross:
+		if r.Level == nil || !isFinite(*r.Level) || *r.Level <= 0 {
+			return fmt.Errorf("level must be a positive number for price_cross rules")
+		}
+		if r.Direction != DirectionAbove && r.Direction != DirectionBelow {
+			return fmt.Errorf("direction must be above or below for price_cross rules")
+		}
+	case RuleTypePercentMove:
+		if r.Percent == nil || !isFinite(*r.Percent) || *r.Percent <= 0 {
+			return fmt.Errorf("percent must be a positive number for percent_move rules")
+		}
+		if r.Window() < r.Timeframe.Duration() {
+			return fmt.Errorf("window_seconds must be at least one %s bar", r.Timeframe)
+		}
+		if r.Window() > maxWindowBars*r.Timeframe.Duration() {
+			return fmt.Errorf("window_seconds must be at most %d %s bars", maxWindowBars, r.Timeframe)
+		}
+		if r.Direction != DirectionUp && r.Direction != DirectionDown && r.Direction != DirectionAny {
+			return fmt.Errorf("direction must be up, down or any for percent_move rules")
+		}
+	case RuleTypeIndicator:
+		if r.Condition == nil {
+			return fmt.Errorf("indicator_condition is required for indicator rules")
+		}
+	default:
+		return fmt.Errorf("type must be one of price_cross, percent_move, indicator")
+	}
+	return nil
+}
+
+// State is the evaluation progress of a rule for one symbol. Active records
+// whether the condition held on the last evaluated candle, so a condition that
+// keeps holding fires only once.
+type State struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	RuleID          uuid.UUID  `json:"rule_id" db:"rule_id"`
+	Symbol          string     `json:"symbol" db:"symbol"`
+	LastCandleTime  time.Time  `json:"last_candle_time" db:"last_candle_time"` // Open time of the last evaluated candle
+	Active          bool       `json:"active" db:"active"`
+	LastTriggeredAt *time.Time `json:"last_triggered_at" db:"last_triggered_at"` // Close time of the candle that last fired
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// EmailStatus tracks the delivery of the alert email for an event.
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "pending"
+	EmailStatusSent    EmailStatus = "sent"
+	EmailStatusFailed  EmailStatus = "failed"
+	EmailStatusSkipped EmailStatus = "skipped"
+)
+
+// Event is a recorded trigger of a rule, kept as alert history.
+type Event struct {
+	ID          uuid.UUID        `json:"id" db:"id"`
+	RuleID      uuid.UUID        `json:"rule_id" db:"rule_id"`
+	UserID      uuid.UUID        `json:"user_id" db:"user_id"`
+	Symbol      string           `json:"symbol" db:"symbol"`
+	Timeframe   market.Timeframe `json:"timeframe" db:"timeframe"`
+	CandleTime  time.Time        `json:"candle_time" db:"candle_time"` // Open time of the candle that fired
+	Price       float64          `json:"price" db:"price"`
+	Message     string           `json:"message" db:"message"`
+	EmailStatus EmailStatus      `json:"email_status" db:"email_status"`
+	TriggeredAt time.Time        `json:"triggered_at" db:"triggered_at"`
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
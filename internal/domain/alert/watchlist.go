@@ -0,0 +1,26 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Watchlist is a named list of symbols a user follows.
+type Watchlist struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// WatchlistItem is a symbol on a watchlist.
+type WatchlistItem struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	WatchlistID uuid.UUID `json:"watchlist_id" db:"watchlist_id"`
+	Symbol      string    `json:"symbol" db:"symbol"`
+	Notes       *string   `json:"notes" db:"notes"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
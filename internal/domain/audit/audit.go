@@ -0,0 +1,157 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Action names a security-relevant action recorded in the audit log.
+type Action string
+
+const (
+	ActionLogin                Action = "user.login"
+	ActionSecondFactorFailed   Action = "user.second_factor_failed"
+	ActionMagicLinkIssued      Action = "magic_link.issued"
+	ActionMagicLinkUsed        Action = "magic_link.used"
+	ActionAPITokenCreated      Action = "api_token.created"
+	ActionAPITokenDeleted      Action = "api_token.deleted"
+	ActionProviderKeyCreated   Action = "provider_key.created"
+	ActionProviderKeyUpdated   Action = "provider_key.updated"
+	ActionConversationShared   Action = "conversation.shared"
+	ActionKnowledgeBaseShared  Action = "knowledge_base.shared"
+	ActionAdminProviderCreated Action = "admin.provider_created"
+	ActionAdminProviderUpdated Action = "admin.provider_updated"
+	ActionAdminModelCreated    Action = "admin.model_created"
+	ActionAdminModelUpdated    Action = "admin.model_updated"
+	ActionAdminToolUpdated     Action = "admin.tool_updated"
+	ActionAdminEmailVerified   Action = "admin.user_email_verified"
+	ActionAdminUserDeactivated Action = "admin.user_deactivated"
+	ActionAdminRoleUpdated     Action = "admin.user_role_updated"
+
+	// Account lifecycle; events of deleted accounts are kept
+	ActionAccountExported          Action = "account.exported"
+	ActionAccountDeletionScheduled Action = "account.deletion_scheduled"
+	ActionAccountDeletionCancelled Action = "account.deletion_cancelled"
+	ActionAccountDeleted           Action = "account.deleted"
+)
+
+// Resource types events refer to.
+const (
+	ResourceUser          = "user"
+	ResourceSession       = "session"
+	ResourceMagicLink     = "magic_link"
+	ResourceAPIToken      = "api_token"
+	ResourceProviderKey   = "provider_setting"
+	ResourceConversation  = "conversation"
+	ResourceKnowledgeBase = "knowledge_base"
+	ResourceProvider      = "provider"
+	ResourceModel         = "model"
+	ResourceTool          = "tool"
+)
+
+// Event is an entry of the append-only audit log.
+type Event struct {
+	ID           uuid.UUID
+	Action       Action
+	ActorID      *uuid.UUID // User who performed the action; nil for anonymous requests
+	UserID       *uuid.UUID // Account the action concerns
+	ResourceType string
+	ResourceID   *uuid.UUID
+	IPAddress    *string
+	UserAgent    *string
+	Metadata     map[string]any
+	CreatedAt    time.Time
+}
+
+// NewEvent creates an event for an action; the setters below fill it in.
+func NewEvent(action Action) *Event {
+	return &Event{Action: action}
+}
+
+// UserEvent creates an event for an action users take on their own account.
+func UserEvent(action Action, userID uuid.UUID) *Event {
+	return NewEvent(action).By(userID).Concerning(userID)
+}
+
+// By sets the user who performed the action.
+func (e *Event) By(actorID uuid.UUID) *Event {
+	e.ActorID = &actorID
+	return e
+}
+
+// Concerning sets the account the action concerns.
+func (e *Event) Concerning(userID uuid.UUID) *Event {
+	e.UserID = &userID
+	return e
+}
+
+// On sets the resource the action was taken on.
+func (e *Event) On(resourceType string, resourceID uuid.UUID) *Event {
+	e.ResourceType = resourceType
+	e.ResourceID = &resourceID
+	return e
+}
+
+// With adds a detail of the action.
+func (e *Event) With(key string, value any) *Event {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]any)
+	}
+	e.Metadata[key] = value
+	return e
+}
+
+// From sets the client the action was made from; empty values are ignored.
+func (e *Event) From(ipAddress, userAgent string) *Event {
+	if ipAddress != "" {
+		e.IPAddress = &ipAddress
+	}
+	if userAgent != "" {
+		e.UserAgent = &userAgent
+	}
+	return e
+}
+
+// EventFilter selects audit events. Zero values match everything.
+type EventFilter struct {
+	InvolvingUserID *uuid.UUID // Events performed by or concerning the user
+	ActorID         *uuid.UUID
+	UserID          *uuid.UUID
+	Action          Action
+	ResourceType    string
+	Since           *time.Time // Inclusive
+	Until           *time.Time // Exclusive
+}
+
+// EventRepository stores audit events. Events are never updated or deleted.
+type EventRepository interface {
+	Create(ctx context.Context, event *Event) (*Event, error)
+	// List returns the matching events, newest first
+	List(ctx context.Context, filter EventFilter, limit, offset int) ([]*Event, error)
+	Count(ctx context.Context, filter EventFilter) (int64, error)
+}
+
+// RequestInfo describes the client of the request an action was made in.
+type RequestInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoKey is the context key of the RequestInfo. HTTP middleware stores
+// it in the request locals, which back the request's context.
+var RequestInfoKey = requestInfoKey{}
+
+// WithRequestInfo returns a copy of ctx carrying the request info.
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, RequestInfoKey, info)
+}
+
+// RequestInfoFromContext returns the request info of ctx, if any.
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(RequestInfoKey).(*RequestInfo)
+	return info
+}
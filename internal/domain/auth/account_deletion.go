@@ -0,0 +1,16 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountDeletion is a user's pending request to delete their account. The
+// account and everything it owns are purged once ScheduledFor has passed,
+// unless the user cancels the request first.
+type AccountDeletion struct {
+	UserID       uuid.UUID `json:"user_id"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+	CreatedAt    time.Time `json:"created_at"`
+}
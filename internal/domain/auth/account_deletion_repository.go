@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountDeletionRepository interface {
+	// Schedule requests the deletion of a user's account; an earlier request keeps its date
+	Schedule(ctx context.Context, userID uuid.UUID, scheduledFor time.Time) (*AccountDeletion, error)
+
+	// GetByUserID retrieves the user's pending deletion request
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*AccountDeletion, error)
+
+	// Cancel withdraws the user's pending deletion request
+	Cancel(ctx context.Context, userID uuid.UUID) error
+
+	// ListDue retrieves the requests scheduled at or before the given time, oldest first
+	ListDue(ctx context.Context, before time.Time, limit int) ([]*AccountDeletion, error)
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APITokenPrefix starts every personal access token, so they can be told apart
+// from JWTs in the Authorization header.
+const APITokenPrefix = "ta_pat_"
+
+const (
+	// MaxAPITokensPerUser caps the number of personal access tokens of one user.
+	MaxAPITokensPerUser = 50
+	// MaxAPITokenNameLength caps the length of a token's name.
+	MaxAPITokenNameLength = 100
+)
+
+// Scope resources. A token is granted "<resource>:read" for safe requests or
+// "<resource>:write", which also allows reading.
+const (
+	ScopeResourceProfile       = "profile"
+	ScopeResourceChat          = "chat"
+	ScopeResourceProviders     = "providers"
+	ScopeResourceStrategy      = "strategies"
+	ScopeResourcePaper         = "paper"
+	ScopeResourcePortfolio     = "portfolio"
+	ScopeResourceAlerts        = "alerts"
+	ScopeResourceBroker        = "broker"
+	ScopeResourceJournal       = "journal"
+	ScopeResourceMarket        = "market"
+	ScopeResourceDocuments     = "documents"
+	ScopeResourceOrganizations = "organizations"
+)
+
+// ScopeResources lists the resources tokens can be granted access to.
+var ScopeResources = []string{
+	ScopeResourceProfile,
+	ScopeResourceChat,
+	ScopeResourceProviders,
+	ScopeResourceStrategy,
+	ScopeResourcePaper,
+	ScopeResourcePortfolio,
+	ScopeResourceAlerts,
+	ScopeResourceBroker,
+	ScopeResourceJournal,
+	ScopeResourceMarket,
+	ScopeResourceDocuments,
+	ScopeResourceOrganizations,
+}
+
+// APIToken is a personal access token. Only its hash is stored; the token itself
+// is shown once, when it is created.
+type APIToken struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Name        string     `json:"name" db:"name"`
+	TokenPrefix string     `json:"token_prefix" db:"token_prefix"`
+	TokenHash   string     `json:"-" db:"token_hash"` // Never expose in JSON
+	Scopes      []string   `json:"scopes" db:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at" db:"expires_at"` // Nil for tokens that never expire
+	LastUsedAt  *time.Time `json:"last_used_at" db:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsExpired checks if the token has expired
+func (t *APIToken) IsExpired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
+
+// Validate checks the token's name and scopes, normalizing the scopes.
+func (t *APIToken) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len([]rune(t.Name)) > MaxAPITokenNameLength {
+		return fmt.Errorf("name must be at most %d characters", MaxAPITokenNameLength)
+	}
+	if len(t.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+
+	seen := make(map[string]bool, len(t.Scopes))
+	scopes := make([]string, 0, len(t.Scopes))
+	for _, scope := range t.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !IsValidScope(scope) {
+			return fmt.Errorf("invalid scope: %s", scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	t.Scopes = scopes
+	return nil
+}
+
+// IsValidScope reports whether scope is "<resource>:read" or "<resource>:write"
+// for a known resource.
+func IsValidScope(scope string) bool {
+	resource, access, ok := strings.Cut(scope, ":")
+	if !ok || (access != "read" && access != "write") {
+		return false
+	}
+	for _, r := range ScopeResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsScope reports whether a token granted scopes may access the resource;
+// write access also allows reading.
+func AllowsScope(scopes []string, resource string, write bool) bool {
+	for _, scope := range scopes {
+		if scope == resource+":write" || (!write && scope == resource+":read") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type APITokenRepository interface {
+	// Create creates a new personal access token
+	Create(ctx context.Context, token *APIToken) (*APIToken, error)
+
+	// GetByID retrieves a token by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*APIToken, error)
+
+	// GetByHash retrieves a token by the hash of its value
+	GetByHash(ctx context.Context, tokenHash string) (*APIToken, error)
+
+	// ListByUserID retrieves the user's tokens, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*APIToken, error)
+
+	// CountByUserID counts the user's tokens
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// Touch records that the token was used; it is throttled to avoid a write per request
+	Touch(ctx context.Context, id uuid.UUID) error
+
+	// Delete revokes a token
+	Delete(ctx context.Context, id uuid.UUID) error
+}
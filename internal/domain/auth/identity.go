@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links a user to their account at an external OIDC or OAuth
+// provider, so they can sign in there instead of with a magic link.
+type Identity struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"user_id" db:"user_id"`
+	Provider    string     `json:"provider" db:"provider"` // Configured provider name
+	Subject     string     `json:"subject" db:"subject"`   // Stable user ID at the provider
+	Email       *string    `json:"email" db:"email"`
+	LastLoginAt *time.Time `json:"last_login_at" db:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// OIDCLoginRequest is an authorization request sent to a provider and waiting
+// for its callback. It is looked up by the hash of the state parameter and
+// holds the PKCE verifier and nonce needed to complete the sign-in.
+type OIDCLoginRequest struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	Provider     string    `json:"provider" db:"provider"`
+	StateHash    string    `json:"-" db:"state_hash"`
+	Nonce        string    `json:"-" db:"nonce"`
+	CodeVerifier string    `json:"-" db:"code_verifier"` // Never expose in JSON
+	RedirectURL  string    `json:"redirect_url" db:"redirect_url"`
+	ExpiresAt    time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsExpired checks if the login request has expired
+func (r *OIDCLoginRequest) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type IdentityRepository interface {
+	// Create links an external identity to a user
+	Create(ctx context.Context, identity *Identity) (*Identity, error)
+
+	// GetByProviderSubject retrieves the identity of a provider's user
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*Identity, error)
+
+	// ListByUserID retrieves the identities linked to a user, oldest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*Identity, error)
+
+	// RecordLogin records a sign-in with the identity and the email the provider reported
+	RecordLogin(ctx context.Context, id uuid.UUID, email *string) error
+}
+
+type OIDCLoginRequestRepository interface {
+	// Create stores a pending authorization request
+	Create(ctx context.Context, request *OIDCLoginRequest) (*OIDCLoginRequest, error)
+
+	// Consume retrieves and deletes the request with the given state hash, so it can be used once
+	Consume(ctx context.Context, stateHash string) (*OIDCLoginRequest, error)
+
+	// CleanupExpired removes expired requests
+	CleanupExpired(ctx context.Context) error
+}
@@ -12,6 +12,8 @@ const (
 	MagicLinkPurposeLogin             MagicLinkPurpose = "login"
 	MagicLinkPurposeEmailVerification MagicLinkPurpose = "email_verification"
 	MagicLinkPurposePasswordReset     MagicLinkPurpose = "password_reset"
+	// MagicLinkPurposeOrganizationInvite signs the user in and accepts an invitation to an organization
+	MagicLinkPurposeOrganizationInvite MagicLinkPurpose = "organization_invite"
 )
 
 type MagicLink struct {
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one refresh token issued to a signed-in device. Refreshing rotates
+// the token: the session is marked rotated and a new one is created in the same
+// family, so presenting a rotated token again reveals that it was stolen.
+type Session struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	UserID           uuid.UUID  `json:"user_id" db:"user_id"`
+	FamilyID         uuid.UUID  `json:"family_id" db:"family_id"`  // Shared by all sessions descending from one sign-in
+	RefreshTokenHash string     `json:"-" db:"refresh_token_hash"` // Never expose in JSON
+	DeviceName       *string    `json:"device_name" db:"device_name"`
+	IPAddress        *string    `json:"ip_address" db:"ip_address"`
+	UserAgent        *string    `json:"user_agent" db:"user_agent"`
+	AuthenticatedAt  time.Time  `json:"authenticated_at" db:"authenticated_at"` // When the family signed in
+	SecondFactorAt   *time.Time `json:"second_factor_at" db:"second_factor_at"` // Last second factor check
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	LastSeenAt       time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	RotatedAt        *time.Time `json:"rotated_at" db:"rotated_at"`
+	RevokedAt        *time.Time `json:"revoked_at" db:"revoked_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsExpired checks if the refresh token has expired
+func (s *Session) IsExpired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// IsRotated checks if the refresh token has already been exchanged for a new one
+func (s *Session) IsRotated() bool {
+	return s.RotatedAt != nil
+}
+
+// IsRevoked checks if the session has been signed out
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// HasRecentSecondFactor checks if a second factor was verified within maxAge
+func (s *Session) HasRecentSecondFactor(maxAge time.Duration) bool {
+	return s.SecondFactorAt != nil && time.Since(*s.SecondFactorAt) <= maxAge
+}
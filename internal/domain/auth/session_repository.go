@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SessionRepository interface {
+	// Create creates a new session
+	Create(ctx context.Context, session *Session) (*Session, error)
+
+	// GetByID retrieves a session by its ID
+	GetByID(ctx context.Context, id uuid.UUID) (*Session, error)
+
+	// GetByRefreshTokenHash retrieves and locks the session of a refresh token
+	GetByRefreshTokenHash(ctx context.Context, tokenHash string) (*Session, error)
+
+	// ListActiveByUserID retrieves the user's sessions that can still be refreshed, most recently seen first
+	ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+
+	// MarkRotated marks the session's refresh token as exchanged
+	MarkRotated(ctx context.Context, id uuid.UUID) error
+
+	// MarkSecondFactor records that the user of the session has just verified a second factor
+	MarkSecondFactor(ctx context.Context, id uuid.UUID) error
+
+	// RevokeFamily revokes every session descending from the same sign-in
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+
+	// Touch records activity on the session; it is throttled to avoid a write per request
+	Touch(ctx context.Context, id uuid.UUID) error
+
+	// CleanupExpired removes expired sessions
+	CleanupExpired(ctx context.Context) error
+}
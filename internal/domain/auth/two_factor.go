@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RecoveryCodeCount is the number of recovery codes generated at a time.
+	RecoveryCodeCount = 10
+	// MaxWebAuthnCredentialsPerUser caps the number of security keys and passkeys of one user.
+	MaxWebAuthnCredentialsPerUser = 20
+	// MaxWebAuthnCredentialNameLength caps the length of a credential's name.
+	MaxWebAuthnCredentialNameLength = 100
+	// MaxTwoFactorAttempts is the number of wrong codes a challenge tolerates.
+	MaxTwoFactorAttempts = 5
+)
+
+// Second factor methods
+const (
+	TwoFactorMethodTOTP         = "totp"
+	TwoFactorMethodWebAuthn     = "webauthn"
+	TwoFactorMethodRecoveryCode = "recovery_code"
+)
+
+// TwoFactorPurpose is what a second factor challenge is for.
+type TwoFactorPurpose string
+
+const (
+	// TwoFactorPurposeLogin completes a sign-in: a session is started once it is verified.
+	TwoFactorPurposeLogin TwoFactorPurpose = "login"
+	// TwoFactorPurposeStepUp re-verifies the user of an existing session before a sensitive action.
+	TwoFactorPurposeStepUp TwoFactorPurpose = "step_up"
+	// TwoFactorPurposeWebAuthnRegistration holds the challenge of a credential being registered.
+	TwoFactorPurposeWebAuthnRegistration TwoFactorPurpose = "webauthn_registration"
+)
+
+// TOTPSecret is the shared secret of a user's authenticator app. It is stored
+// encrypted and becomes active once the user has entered a first code.
+type TOTPSecret struct {
+	UserID          uuid.UUID  `json:"user_id" db:"user_id"`
+	EncryptedSecret string     `json:"-" db:"encrypted_secret"` // Never expose in JSON
+	ConfirmedAt     *time.Time `json:"confirmed_at" db:"confirmed_at"`
+	LastUsedStep    int64      `json:"-" db:"last_used_step"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsConfirmed checks if the secret has been confirmed with a code
+func (s *TOTPSecret) IsConfirmed() bool {
+	return s.ConfirmedAt != nil
+}
+
+// WebAuthnCredential is a security key or passkey registered by a user.
+type WebAuthnCredential struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	UserID       uuid.UUID  `json:"user_id" db:"user_id"`
+	Name         string     `json:"name" db:"name"`
+	CredentialID []byte     `json:"-" db:"credential_id"`
+	PublicKey    []byte     `json:"-" db:"public_key"` // COSE_Key
+	SignCount    uint32     `json:"-" db:"sign_count"`
+	Transports   []string   `json:"transports" db:"transports"`
+	LastUsedAt   *time.Time `json:"last_used_at" db:"last_used_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TwoFactorChallenge is a pending second factor check. The client holds the
+// token; only its hash is stored.
+type TwoFactorChallenge struct {
+	ID                uuid.UUID        `json:"id" db:"id"`
+	UserID            uuid.UUID        `json:"user_id" db:"user_id"`
+	Purpose           TwoFactorPurpose `json:"purpose" db:"purpose"`
+	TokenHash         string           `json:"-" db:"token_hash"` // Never expose in JSON
+	WebAuthnChallenge []byte           `json:"-" db:"webauthn_challenge"`
+	SessionID         *uuid.UUID       `json:"session_id" db:"session_id"` // Step-up: the session to mark as verified
+	DeviceName        *string          `json:"device_name" db:"device_name"`
+	IPAddress         *string          `json:"ip_address" db:"ip_address"`
+	UserAgent         *string          `json:"user_agent" db:"user_agent"`
+	Attempts          int              `json:"attempts" db:"attempts"`
+	ExpiresAt         time.Time        `json:"expires_at" db:"expires_at"`
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+}
+
+// IsExpired checks if the challenge has expired
+func (c *TwoFactorChallenge) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
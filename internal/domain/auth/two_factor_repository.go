@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type TwoFactorRepository interface {
+	// UpsertTOTPSecret stores a new unconfirmed authenticator secret, replacing any previous one
+	UpsertTOTPSecret(ctx context.Context, userID uuid.UUID, encryptedSecret string) (*TOTPSecret, error)
+
+	// GetTOTPSecret retrieves and locks the user's authenticator secret
+	GetTOTPSecret(ctx context.Context, userID uuid.UUID) (*TOTPSecret, error)
+
+	// ConfirmTOTPSecret activates the user's authenticator secret
+	ConfirmTOTPSecret(ctx context.Context, userID uuid.UUID) error
+
+	// UpdateTOTPLastUsedStep records the time step of the last accepted code
+	UpdateTOTPLastUsedStep(ctx context.Context, userID uuid.UUID, step int64) error
+
+	// DeleteTOTPSecret removes the user's authenticator secret
+	DeleteTOTPSecret(ctx context.Context, userID uuid.UUID) error
+
+	// CreateWebAuthnCredential registers a security key or passkey
+	CreateWebAuthnCredential(ctx context.Context, credential *WebAuthnCredential) (*WebAuthnCredential, error)
+
+	// GetWebAuthnCredential retrieves a credential by its ID
+	GetWebAuthnCredential(ctx context.Context, id uuid.UUID) (*WebAuthnCredential, error)
+
+	// GetWebAuthnCredentialByCredentialID retrieves and locks a credential by the ID the authenticator assigned
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (*WebAuthnCredential, error)
+
+	// ListWebAuthnCredentials retrieves the user's credentials, oldest first
+	ListWebAuthnCredentials(ctx context.Context, userID uuid.UUID) ([]*WebAuthnCredential, error)
+
+	// CountWebAuthnCredentials counts the user's credentials
+	CountWebAuthnCredentials(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// RecordWebAuthnUsage records a sign-in with the credential and its new signature counter
+	RecordWebAuthnUsage(ctx context.Context, id uuid.UUID, signCount uint32) error
+
+	// DeleteWebAuthnCredential removes a credential
+	DeleteWebAuthnCredential(ctx context.Context, id uuid.UUID) error
+
+	// ReplaceRecoveryCodes replaces the user's recovery codes with the given hashes
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// UseRecoveryCode marks an unused recovery code as used, reporting whether one matched
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+
+	// CountUnusedRecoveryCodes counts the user's recovery codes that have not been used
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// DeleteRecoveryCodes removes the user's recovery codes
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+}
+
+type TwoFactorChallengeRepository interface {
+	// Create stores a pending challenge
+	Create(ctx context.Context, challenge *TwoFactorChallenge) (*TwoFactorChallenge, error)
+
+	// GetByTokenHash retrieves and locks the challenge with the given token hash
+	GetByTokenHash(ctx context.Context, tokenHash string) (*TwoFactorChallenge, error)
+
+	// IncrementAttempts records a failed attempt
+	IncrementAttempts(ctx context.Context, id uuid.UUID) error
+
+	// Delete removes a challenge once it is used up
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CleanupExpired removes expired challenges
+	CleanupExpired(ctx context.Context) error
+}
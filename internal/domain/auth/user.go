@@ -6,6 +6,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// Role determines what a user may manage beyond their own data.
+type Role string
+
+const (
+	// RoleUser is the default role.
+	RoleUser Role = "user"
+	// RoleAdmin may manage providers, models, tools and other users.
+	RoleAdmin Role = "admin"
+)
+
+// IsValid checks if the role is one of the known roles
+func (r Role) IsValid() bool {
+	return r == RoleUser || r == RoleAdmin
+}
+
 type User struct {
 	ID            uuid.UUID  `json:"id" db:"id"`
 	Email         string     `json:"email" db:"email"`
@@ -14,6 +29,8 @@ type User struct {
 	LastName      *string    `json:"last_name" db:"last_name"`
 	AvatarURL     *string    `json:"avatar_url" db:"avatar_url"`
 	IsActive      bool       `json:"is_active" db:"is_active"`
+	MemoryEnabled bool       `json:"memory_enabled" db:"memory_enabled"` // Whether the assistant remembers facts about the user
+	Role          Role       `json:"role" db:"role"`
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 }
@@ -49,4 +66,9 @@ func (u *User) IsEmailVerified() bool {
 // IsAccountActive checks if the user account is active
 func (u *User) IsAccountActive() bool {
 	return u.IsActive
+}
+
+// IsAdmin checks if the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
 } 
\ No newline at end of file
@@ -24,4 +24,19 @@ type UserRepository interface {
 	
 	// Deactivate deactivates a user account
 	Deactivate(ctx context.Context, userID uuid.UUID) error
+
+	// GetByIDIncludingInactive retrieves a user by their ID, even if deactivated
+	GetByIDIncludingInactive(ctx context.Context, id uuid.UUID) (*User, error)
+
+	// List retrieves all users, newest first
+	List(ctx context.Context, limit, offset int) ([]*User, error)
+
+	// Count counts all users
+	Count(ctx context.Context) (int64, error)
+
+	// UpdateRole changes a user's role
+	UpdateRole(ctx context.Context, userID uuid.UUID, role Role) (*User, error)
+
+	// Delete permanently removes a user and, by cascade, everything they own
+	Delete(ctx context.Context, userID uuid.UUID) error
 } 
\ No newline at end of file
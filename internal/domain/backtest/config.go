@@ -0,0 +1,66 @@
+package backtest
+
+import "fmt"
+
+// SizingMode controls how the size of a new position is determined.
+type SizingMode string
+
+const (
+	// SizingPercentEquity invests SizeValue percent of current equity.
+	SizingPercentEquity SizingMode = "percent_equity"
+	// SizingFixedNotional invests a fixed cash amount per position, at most
+	// the cash available for long positions.
+	SizingFixedNotional SizingMode = "fixed_notional"
+	// SizingFixedQuantity trades a fixed number of units per position, at most
+	// as many as the cash available buys for long positions.
+	SizingFixedQuantity SizingMode = "fixed_quantity"
+)
+
+// Config holds the execution assumptions of a backtest run.
+type Config struct {
+	InitialCapital  float64    `json:"initial_capital"`
+	CommissionRate  float64    `json:"commission_rate"`  // Fraction of traded notional, e.g. 0.001 = 10 bps
+	CommissionFixed float64    `json:"commission_fixed"` // Flat fee per fill
+	SlippageBps     float64    `json:"slippage_bps"`     // Adverse price move applied to every fill
+	Sizing          SizingMode `json:"sizing"`
+	SizeValue       float64    `json:"size_value"`
+	AllowShort      bool       `json:"allow_short"`
+}
+
+// DefaultConfig returns a long-only configuration that goes all-in on every entry.
+func DefaultConfig() Config {
+	return Config{
+		InitialCapital: 10000,
+		Sizing:         SizingPercentEquity,
+		SizeValue:      100,
+	}
+}
+
+// Validate checks that the configuration describes a runnable backtest.
+func (c *Config) Validate() error {
+	if c.InitialCapital <= 0 {
+		return fmt.Errorf("initial_capital must be positive")
+	}
+	if c.CommissionRate < 0 || c.CommissionRate >= 1 {
+		return fmt.Errorf("commission_rate must be in [0, 1)")
+	}
+	if c.CommissionFixed < 0 {
+		return fmt.Errorf("commission_fixed must not be negative")
+	}
+	if c.SlippageBps < 0 || c.SlippageBps >= 10000 {
+		return fmt.Errorf("slippage_bps must be in [0, 10000)")
+	}
+	switch c.Sizing {
+	case SizingPercentEquity:
+		if c.SizeValue <= 0 || c.SizeValue > 100 {
+			return fmt.Errorf("size_value must be in (0, 100] for percent_equity sizing")
+		}
+	case SizingFixedNotional, SizingFixedQuantity:
+		if c.SizeValue <= 0 {
+			return fmt.Errorf("size_value must be positive for %s sizing", c.Sizing)
+		}
+	default:
+		return fmt.Errorf("unsupported sizing mode: %s", c.Sizing)
+	}
+	return nil
+}
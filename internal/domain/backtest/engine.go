@@ -0,0 +1,280 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+)
+
+// ErrInsufficientData is returned when the candle series is too short to simulate.
+var ErrInsufficientData = errors.New("at least two candles are required to run a backtest")
+
+// Exit reasons recorded on trades.
+const (
+	ExitReasonSignal     = "signal"
+	ExitReasonStopLoss   = "stop_loss"
+	ExitReasonTakeProfit = "take_profit"
+	ExitReasonEndOfData  = "end_of_data"
+)
+
+// position is the open position tracked by the engine.
+type position struct {
+	side       Side
+	quantity   float64
+	entryPrice float64
+	entryTime  time.Time
+	entryBar   int
+	entryFee   float64
+}
+
+// engine simulates a strategy bar by bar. It is not safe for concurrent use;
+// Run creates a fresh engine for every call so results are deterministic.
+type engine struct {
+	cfg    Config
+	risk   RiskRules
+	bars   []*market.Candle
+	cash   float64
+	pos    *position
+	trades []Trade
+	fees   float64
+}
+
+// Run simulates the strategy over the candle series and returns the trades,
+// equity curve and summary statistics.
+//
+// Signals are evaluated on each bar's close and filled at the next bar's open,
+// adjusted for slippage. Any position still open after the last bar is closed
+// at that bar's close.
+func Run(bars []*market.Candle, strategy Strategy, cfg Config) (*Result, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if len(bars) < 2 {
+		return nil, ErrInsufficientData
+	}
+	if err := strategy.Init(bars); err != nil {
+		return nil, fmt.Errorf("failed to initialize strategy %s: %w", strategy.Name(), err)
+	}
+
+	e := &engine{cfg: cfg, bars: bars, cash: cfg.InitialCapital}
+	if rm, ok := strategy.(RiskManaged); ok {
+		e.risk = rm.RiskRules()
+	}
+	equity := make([]EquityPoint, 0, len(bars))
+	pending := SignalHold
+	exposedBars := 0
+	peak := cfg.InitialCapital
+
+	for i, bar := range bars {
+		if pending != SignalHold {
+			e.apply(pending, i, bar.Open, bar.OpenTime)
+			pending = SignalHold
+		}
+		if e.pos != nil {
+			e.checkRisk(i, bar)
+		}
+
+		if i == len(bars)-1 && e.pos != nil {
+			e.close(i, bar.Close, bar.CloseTime(), ExitReasonEndOfData)
+		}
+		if e.pos != nil {
+			exposedBars++
+		}
+
+		value := e.equity(bar.Close)
+		if value > peak {
+			peak = value
+		}
+		drawdown := 0.0
+		if peak > 0 {
+			drawdown = (peak - value) / peak
+		}
+		equity = append(equity, EquityPoint{Time: bar.CloseTime(), Equity: value, Drawdown: drawdown})
+
+		if i < len(bars)-1 {
+			pending = strategy.Signal(i)
+		}
+	}
+
+	result := &Result{
+		Strategy: strategy.Name(),
+		Symbol:   bars[0].Symbol,
+		Config:   cfg,
+		Start:    bars[0].OpenTime,
+		End:      bars[len(bars)-1].CloseTime(),
+		Bars:     len(bars),
+		Trades:   e.trades,
+		Equity:   equity,
+	}
+	if result.Trades == nil {
+		result.Trades = []Trade{}
+	}
+	result.Stats = computeStats(result, bars[0].Timeframe, exposedBars, e.fees)
+	return result, nil
+}
+
+// apply moves the current position towards the signal's target at the given price.
+func (e *engine) apply(signal Signal, i int, price float64, at time.Time) {
+	var target Side
+	switch signal {
+	case SignalLong:
+		target = SideLong
+	case SignalShort:
+		if e.cfg.AllowShort {
+			target = SideShort
+		}
+	case SignalFlat:
+	case SignalExitLong, SignalExitShort:
+		if e.pos != nil && (signal == SignalExitLong) == (e.pos.side == SideLong) {
+			e.close(i, price, at, ExitReasonSignal)
+		}
+		return
+	default:
+		return
+	}
+
+	if e.pos != nil && e.pos.side == target {
+		return
+	}
+	if e.pos != nil {
+		e.close(i, price, at, ExitReasonSignal)
+	}
+	if target != "" {
+		e.open(target, i, price, at)
+	}
+}
+
+// checkRisk closes the position when the bar trades through its stop-loss or
+// take-profit level. Gaps through a level fill at the open. When both levels are
+// touched within the same bar the stop is assumed to have been hit first.
+func (e *engine) checkRisk(i int, bar *market.Candle) {
+	p := e.pos
+	sl, tp := e.risk.StopLossPct/100, e.risk.TakeProfitPct/100
+	at := bar.CloseTime()
+
+	if p.side == SideLong {
+		if sl > 0 {
+			if stop := p.entryPrice * (1 - sl); bar.Low <= stop {
+				e.close(i, math.Min(stop, bar.Open), at, ExitReasonStopLoss)
+				return
+			}
+		}
+		if tp > 0 {
+			if target := p.entryPrice * (1 + tp); bar.High >= target {
+				e.close(i, math.Max(target, bar.Open), at, ExitReasonTakeProfit)
+			}
+		}
+		return
+	}
+
+	if sl > 0 {
+		if stop := p.entryPrice * (1 + sl); bar.High >= stop {
+			e.close(i, math.Max(stop, bar.Open), at, ExitReasonStopLoss)
+			return
+		}
+	}
+	if tp > 0 {
+		if target := p.entryPrice * (1 - tp); bar.Low <= target {
+			e.close(i, math.Min(target, bar.Open), at, ExitReasonTakeProfit)
+		}
+	}
+}
+
+func (e *engine) open(side Side, i int, price float64, at time.Time) {
+	fill := e.fillPrice(price, side == SideLong)
+
+	var quantity float64
+	switch e.cfg.Sizing {
+	case SizingPercentEquity:
+		// Reserve room for commission so the position never exceeds available equity.
+		budget := e.cash * e.cfg.SizeValue / 100
+		quantity = (budget - e.cfg.CommissionFixed) / (fill * (1 + e.cfg.CommissionRate))
+	case SizingFixedNotional:
+		quantity = e.cfg.SizeValue / fill
+	case SizingFixedQuantity:
+		quantity = e.cfg.SizeValue
+	}
+	if side == SideLong {
+		// Fixed sizes are capped at the cash available; positions are never leveraged.
+		affordable := (e.cash - e.cfg.CommissionFixed) / (fill * (1 + e.cfg.CommissionRate))
+		quantity = math.Min(quantity, affordable)
+	}
+	if quantity <= 0 {
+		return
+	}
+
+	fee := e.commission(quantity * fill)
+	if side == SideLong {
+		e.cash -= quantity*fill + fee
+	} else {
+		e.cash += quantity*fill - fee
+	}
+	e.fees += fee
+	e.pos = &position{
+		side:       side,
+		quantity:   quantity,
+		entryPrice: fill,
+		entryTime:  at,
+		entryBar:   i,
+		entryFee:   fee,
+	}
+}
+
+func (e *engine) close(i int, price float64, at time.Time, reason string) {
+	p := e.pos
+	fill := e.fillPrice(price, p.side == SideShort)
+	fee := e.commission(p.quantity * fill)
+
+	var gross float64
+	if p.side == SideLong {
+		e.cash += p.quantity*fill - fee
+		gross = (fill - p.entryPrice) * p.quantity
+	} else {
+		e.cash -= p.quantity*fill + fee
+		gross = (p.entryPrice - fill) * p.quantity
+	}
+	e.fees += fee
+
+	pnl := gross - p.entryFee - fee
+	e.trades = append(e.trades, Trade{
+		Side:       p.side,
+		EntryTime:  p.entryTime,
+		EntryPrice: p.entryPrice,
+		ExitTime:   at,
+		ExitPrice:  fill,
+		Quantity:   p.quantity,
+		Commission: p.entryFee + fee,
+		PnL:        pnl,
+		ReturnPct:  pnl / (p.entryPrice * p.quantity) * 100,
+		BarsHeld:   i - p.entryBar + 1,
+		ExitReason: reason,
+	})
+	e.pos = nil
+}
+
+// fillPrice applies slippage against the trader: buys fill higher, sells lower.
+func (e *engine) fillPrice(price float64, buy bool) float64 {
+	slip := e.cfg.SlippageBps / 10000
+	if buy {
+		return price * (1 + slip)
+	}
+	return price * (1 - slip)
+}
+
+func (e *engine) commission(notional float64) float64 {
+	return notional*e.cfg.CommissionRate + e.cfg.CommissionFixed
+}
+
+// equity marks the account to market at the given price.
+func (e *engine) equity(price float64) float64 {
+	if e.pos == nil {
+		return e.cash
+	}
+	if e.pos.side == SideLong {
+		return e.cash + e.pos.quantity*price
+	}
+	return e.cash - e.pos.quantity*price
+}
@@ -0,0 +1,62 @@
+package backtest
+
+import "time"
+
+// Side is the direction of a position.
+type Side string
+
+const (
+	SideLong  Side = "long"
+	SideShort Side = "short"
+)
+
+// Trade is a completed round trip.
+type Trade struct {
+	Side       Side      `json:"side"`
+	EntryTime  time.Time `json:"entry_time"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitTime   time.Time `json:"exit_time"`
+	ExitPrice  float64   `json:"exit_price"`
+	Quantity   float64   `json:"quantity"`
+	Commission float64   `json:"commission"` // Entry and exit commission combined
+	PnL        float64   `json:"pnl"`        // Net of commission
+	ReturnPct  float64   `json:"return_pct"` // PnL relative to entry notional, in percent
+	BarsHeld   int       `json:"bars_held"`
+	ExitReason string    `json:"exit_reason"`
+}
+
+// EquityPoint is the marked-to-market account value at a bar close.
+type EquityPoint struct {
+	Time     time.Time `json:"time"`
+	Equity   float64   `json:"equity"`
+	Drawdown float64   `json:"drawdown"` // Fraction below the running peak, 0..1
+}
+
+// Stats summarizes the performance of a run.
+type Stats struct {
+	InitialCapital  float64 `json:"initial_capital"`
+	FinalEquity     float64 `json:"final_equity"`
+	TotalReturn     float64 `json:"total_return"`
+	CAGR            float64 `json:"cagr"`
+	Sharpe          float64 `json:"sharpe"`
+	Sortino         float64 `json:"sortino"`
+	MaxDrawdown     float64 `json:"max_drawdown"`
+	WinRate         float64 `json:"win_rate"`
+	ProfitFactor    float64 `json:"profit_factor"`
+	TradeCount      int     `json:"trade_count"`
+	TotalCommission float64 `json:"total_commission"`
+	Exposure        float64 `json:"exposure"` // Fraction of bars with an open position
+}
+
+// Result is the full output of a backtest run.
+type Result struct {
+	Strategy string        `json:"strategy"`
+	Symbol   string        `json:"symbol"`
+	Config   Config        `json:"config"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Bars     int           `json:"bars"`
+	Trades   []Trade       `json:"trades"`
+	Equity   []EquityPoint `json:"equity"`
+	Stats    Stats         `json:"stats"`
+}
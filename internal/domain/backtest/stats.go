@@ -0,0 +1,107 @@
+package backtest
+
+import (
+	"math"
+
+	"trading-alchemist/internal/domain/market"
+)
+
+func computeStats(result *Result, timeframe market.Timeframe, exposedBars int, fees float64) Stats {
+	initial := result.Config.InitialCapital
+	final := initial
+	if n := len(result.Equity); n > 0 {
+		final = result.Equity[n-1].Equity
+	}
+
+	stats := Stats{
+		InitialCapital:  initial,
+		FinalEquity:     final,
+		TotalReturn:     final/initial - 1,
+		TradeCount:      len(result.Trades),
+		TotalCommission: fees,
+	}
+	if result.Bars > 0 {
+		stats.Exposure = float64(exposedBars) / float64(result.Bars)
+	}
+
+	years := result.End.Sub(result.Start).Hours() / (24 * 365)
+	if years > 0 && final > 0 {
+		stats.CAGR = math.Pow(final/initial, 1/years) - 1
+	}
+
+	// Per-bar returns, the first one measured against the starting capital.
+	returns := make([]float64, len(result.Equity))
+	prev := initial
+	for i, point := range result.Equity {
+		if prev != 0 {
+			returns[i] = point.Equity/prev - 1
+		}
+		prev = point.Equity
+		if point.Drawdown > stats.MaxDrawdown {
+			stats.MaxDrawdown = point.Drawdown
+		}
+	}
+
+	annualization := math.Sqrt(timeframe.BarsPerYear())
+	mean, std, downside := returnMoments(returns)
+	if std > 0 {
+		stats.Sharpe = mean / std * annualization
+	}
+	if downside > 0 {
+		stats.Sortino = mean / downside * annualization
+	}
+
+	var wins int
+	var grossProfit, grossLoss float64
+	for _, t := range result.Trades {
+		if t.PnL > 0 {
+			wins++
+			grossProfit += t.PnL
+		} else {
+			grossLoss -= t.PnL
+		}
+	}
+	if len(result.Trades) > 0 {
+		stats.WinRate = float64(wins) / float64(len(result.Trades))
+	}
+	if grossLoss > 0 {
+		stats.ProfitFactor = grossProfit / grossLoss
+	}
+
+	return sanitizeStats(stats)
+}
+
+// returnMoments returns the mean, sample standard deviation and downside
+// deviation (relative to zero) of a return series.
+func returnMoments(returns []float64) (mean, std, downside float64) {
+	n := float64(len(returns))
+	if n < 2 {
+		return 0, 0, 0
+	}
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= n
+
+	var variance, downsideSq float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+		if r < 0 {
+			downsideSq += r * r
+		}
+	}
+	return mean, math.Sqrt(variance / (n - 1)), math.Sqrt(downsideSq / n)
+}
+
+// sanitizeStats replaces NaN and Inf, which JSON cannot represent, with zero.
+func sanitizeStats(s Stats) Stats {
+	for _, v := range []*float64{
+		&s.FinalEquity, &s.TotalReturn, &s.CAGR, &s.Sharpe, &s.Sortino,
+		&s.MaxDrawdown, &s.WinRate, &s.ProfitFactor, &s.TotalCommission, &s.Exposure,
+	} {
+		if math.IsNaN(*v) || math.IsInf(*v, 0) {
+			*v = 0
+		}
+	}
+	return s
+}
@@ -0,0 +1,146 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"trading-alchemist/internal/domain/market"
+)
+
+// StrategyFactory builds a strategy from numeric parameters.
+type StrategyFactory func(params map[string]float64) (Strategy, error)
+
+var builtinStrategies = map[string]StrategyFactory{
+	"buy_and_hold":  newBuyAndHold,
+	"sma_crossover": newSMACrossover,
+	"rsi_reversion": newRSIReversion,
+}
+
+// NewBuiltinStrategy returns one of the built-in strategies by name.
+func NewBuiltinStrategy(name string, params map[string]float64) (Strategy, error) {
+	factory, ok := builtinStrategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy: %s", name)
+	}
+	return factory(params)
+}
+
+// BuiltinStrategyNames returns the names of the built-in strategies in sorted order.
+func BuiltinStrategyNames() []string {
+	names := make([]string, 0, len(builtinStrategies))
+	for name := range builtinStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func param(params map[string]float64, key string, def float64) float64 {
+	if v, ok := params[key]; ok {
+		return v
+	}
+	return def
+}
+
+// buyAndHold enters long on the first bar and holds until the end.
+type buyAndHold struct{}
+
+func newBuyAndHold(map[string]float64) (Strategy, error) { return &buyAndHold{}, nil }
+
+func (s *buyAndHold) Name() string                     { return "buy_and_hold" }
+func (s *buyAndHold) Init(bars []*market.Candle) error { return nil }
+
+func (s *buyAndHold) Signal(i int) Signal {
+	if i == 0 {
+		return SignalLong
+	}
+	return SignalHold
+}
+
+// smaCrossover goes long when the fast SMA crosses above the slow SMA and
+// short (or flat when shorting is disabled) when it crosses below.
+type smaCrossover struct {
+	fastPeriod int
+	slowPeriod int
+	fast       []float64
+	slow       []float64
+}
+
+func newSMACrossover(params map[string]float64) (Strategy, error) {
+	fast := int(param(params, "fast", 10))
+	slow := int(param(params, "slow", 30))
+	if fast <= 0 || slow <= 0 {
+		return nil, fmt.Errorf("sma_crossover: periods must be positive")
+	}
+	if fast >= slow {
+		return nil, fmt.Errorf("sma_crossover: fast period (%d) must be less than slow period (%d)", fast, slow)
+	}
+	return &smaCrossover{fastPeriod: fast, slowPeriod: slow}, nil
+}
+
+func (s *smaCrossover) Name() string { return "sma_crossover" }
+
+func (s *smaCrossover) Init(bars []*market.Candle) error {
+	closes := market.Closes(bars)
+	s.fast = market.SMA(closes, s.fastPeriod)
+	s.slow = market.SMA(closes, s.slowPeriod)
+	return nil
+}
+
+func (s *smaCrossover) Signal(i int) Signal {
+	if i == 0 || math.IsNaN(s.slow[i-1]) {
+		return SignalHold
+	}
+	wasAbove := s.fast[i-1] > s.slow[i-1]
+	isAbove := s.fast[i] > s.slow[i]
+	switch {
+	case isAbove && !wasAbove:
+		return SignalLong
+	case !isAbove && wasAbove:
+		return SignalShort
+	}
+	return SignalHold
+}
+
+// rsiReversion buys when RSI drops below the oversold level and exits (or
+// goes short when allowed) when it rises above the overbought level.
+type rsiReversion struct {
+	period     int
+	oversold   float64
+	overbought float64
+	rsi        []float64
+}
+
+func newRSIReversion(params map[string]float64) (Strategy, error) {
+	period := int(param(params, "period", 14))
+	oversold := param(params, "oversold", 30)
+	overbought := param(params, "overbought", 70)
+	if period <= 0 {
+		return nil, fmt.Errorf("rsi_reversion: period must be positive")
+	}
+	if oversold <= 0 || overbought >= 100 || oversold >= overbought {
+		return nil, fmt.Errorf("rsi_reversion: require 0 < oversold < overbought < 100")
+	}
+	return &rsiReversion{period: period, oversold: oversold, overbought: overbought}, nil
+}
+
+func (s *rsiReversion) Name() string { return "rsi_reversion" }
+
+func (s *rsiReversion) Init(bars []*market.Candle) error {
+	s.rsi = market.RSI(market.Closes(bars), s.period)
+	return nil
+}
+
+func (s *rsiReversion) Signal(i int) Signal {
+	v := s.rsi[i]
+	switch {
+	case math.IsNaN(v):
+		return SignalHold
+	case v < s.oversold:
+		return SignalLong
+	case v > s.overbought:
+		return SignalShort
+	}
+	return SignalHold
+}
@@ -0,0 +1,45 @@
+package backtest
+
+import "trading-alchemist/internal/domain/market"
+
+// Signal is the target position a strategy requests after observing a bar.
+type Signal int
+
+const (
+	// SignalHold keeps the current position unchanged.
+	SignalHold Signal = iota
+	// SignalLong requests a long position, reversing a short if needed.
+	SignalLong
+	// SignalShort requests a short position, reversing a long if needed.
+	// When shorting is disabled it is treated as SignalFlat.
+	SignalShort
+	// SignalFlat closes any open position.
+	SignalFlat
+	// SignalExitLong closes the position only if it is long.
+	SignalExitLong
+	// SignalExitShort closes the position only if it is short.
+	SignalExitShort
+)
+
+// Strategy produces trading signals for a candle series.
+//
+// Init is called once with the full series so indicators can be precomputed.
+// Signal(i) must only use information from bars[:i+1]; the engine executes the
+// resulting order at the open of bar i+1.
+type Strategy interface {
+	Name() string
+	Init(bars []*market.Candle) error
+	Signal(i int) Signal
+}
+
+// RiskRules are protective exits evaluated intrabar against each bar's high and low.
+// Zero values disable the corresponding rule.
+type RiskRules struct {
+	StopLossPct   float64 `json:"stop_loss_pct,omitempty"`
+	TakeProfitPct float64 `json:"take_profit_pct,omitempty"`
+}
+
+// RiskManaged is implemented by strategies that carry their own stop-loss and take-profit.
+type RiskManaged interface {
+	RiskRules() RiskRules
+}
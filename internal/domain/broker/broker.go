@@ -0,0 +1,201 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Side is the direction of an order.
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+// OrderType selects how an order is priced.
+type OrderType string
+
+const (
+	OrderTypeMarket OrderType = "market"
+	OrderTypeLimit  OrderType = "limit"
+	OrderTypeStop   OrderType = "stop"
+)
+
+// OrderStatus is the lifecycle state of an order at the broker.
+type OrderStatus string
+
+const (
+	OrderStatusPending         OrderStatus = "pending" // Accepted but not yet working at the venue
+	OrderStatusOpen            OrderStatus = "open"
+	OrderStatusPartiallyFilled OrderStatus = "partially_filled"
+	OrderStatusFilled          OrderStatus = "filled"
+	OrderStatusCancelled       OrderStatus = "cancelled"
+	OrderStatusRejected        OrderStatus = "rejected"
+)
+
+// IsTerminal reports whether the order can no longer change.
+func (s OrderStatus) IsTerminal() bool {
+	return s == OrderStatusFilled || s == OrderStatusCancelled || s == OrderStatusRejected
+}
+
+// OrderScope filters orders by whether they are still working.
+type OrderScope string
+
+const (
+	OrderScopeOpen   OrderScope = "open"
+	OrderScopeClosed OrderScope = "closed"
+	OrderScopeAll    OrderScope = "all"
+)
+
+var (
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrOrderNotCancellable  = errors.New("order is no longer open")
+	ErrInsufficientFunds    = errors.New("insufficient buying power")
+	ErrInsufficientPosition = errors.New("insufficient position; short selling is not supported")
+	ErrNoPrice              = errors.New("no price available for symbol")
+)
+
+// Account is the balance of a brokerage account.
+type Account struct {
+	Currency    string  `json:"currency"`
+	Cash        float64 `json:"cash"`
+	Equity      float64 `json:"equity"`
+	BuyingPower float64 `json:"buying_power"`
+}
+
+// Position is the holding of one symbol at the broker.
+type Position struct {
+	Symbol        string  `json:"symbol"`
+	Quantity      float64 `json:"quantity"`
+	AveragePrice  float64 `json:"average_price"`
+	MarketPrice   float64 `json:"market_price"`
+	MarketValue   float64 `json:"market_value"`
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+}
+
+// OrderRequest is a new order to submit. ClientOrderID, when set, makes the
+// submission idempotent: placing the same client order ID twice returns the
+// order created first.
+type OrderRequest struct {
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+	Symbol        string    `json:"symbol"`
+	Side          Side      `json:"side"`
+	Type          OrderType `json:"type"`
+	Quantity      float64   `json:"quantity"`
+	LimitPrice    *float64  `json:"limit_price,omitempty"`
+	StopPrice     *float64  `json:"stop_price,omitempty"`
+}
+
+// Validate checks that the request is well formed for its type.
+func (r *OrderRequest) Validate() error {
+	if r.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if r.Side != SideBuy && r.Side != SideSell {
+		return fmt.Errorf("side must be buy or sell")
+	}
+	if !(r.Quantity > 0) || math.IsInf(r.Quantity, 0) {
+		return fmt.Errorf("quantity must be positive")
+	}
+
+	switch r.Type {
+	case OrderTypeMarket:
+		if r.LimitPrice != nil || r.StopPrice != nil {
+			return fmt.Errorf("market orders take no limit or stop price")
+		}
+	case OrderTypeLimit:
+		if r.LimitPrice == nil || !(*r.LimitPrice > 0) {
+			return fmt.Errorf("limit orders require a positive limit_price")
+		}
+		if r.StopPrice != nil {
+			return fmt.Errorf("limit orders take no stop price")
+		}
+	case OrderTypeStop:
+		if r.StopPrice == nil || !(*r.StopPrice > 0) {
+			return fmt.Errorf("stop orders require a positive stop_price")
+		}
+		if r.LimitPrice != nil {
+			return fmt.Errorf("stop orders take no limit price")
+		}
+	default:
+		return fmt.Errorf("type must be market, limit or stop")
+	}
+	return nil
+}
+
+// Order is an order as reported by the broker. IDs are assigned by the broker.
+type Order struct {
+	ID               string      `json:"id"`
+	ClientOrderID    string      `json:"client_order_id,omitempty"`
+	Symbol           string      `json:"symbol"`
+	Side             Side        `json:"side"`
+	Type             OrderType   `json:"type"`
+	Quantity         float64     `json:"quantity"`
+	FilledQuantity   float64     `json:"filled_quantity"`
+	LimitPrice       *float64    `json:"limit_price,omitempty"`
+	StopPrice        *float64    `json:"stop_price,omitempty"`
+	AverageFillPrice *float64    `json:"average_fill_price,omitempty"`
+	Commission       float64     `json:"commission"`
+	Status           OrderStatus `json:"status"`
+	RejectReason     *string     `json:"reject_reason,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// OrderFilter selects the orders returned by ListOrders.
+type OrderFilter struct {
+	Scope  OrderScope // Defaults to all
+	Symbol string     // Optional
+	Limit  int        // Zero means the implementation's default
+}
+
+// Matches reports whether the order passes the filter, ignoring the limit.
+func (f OrderFilter) Matches(o *Order) bool {
+	if f.Symbol != "" && o.Symbol != f.Symbol {
+		return false
+	}
+	switch f.Scope {
+	case OrderScopeOpen:
+		return !o.Status.IsTerminal()
+	case OrderScopeClosed:
+		return o.Status.IsTerminal()
+	}
+	return true
+}
+
+// OrderUpdate reports a change of an order: a new status, a fill or a cancellation.
+type OrderUpdate struct {
+	Order Order     `json:"order"`
+	Time  time.Time `json:"time"`
+}
+
+// Broker routes orders to a trading venue. Implementations must be safe for
+// concurrent use.
+type Broker interface {
+	GetAccount(ctx context.Context) (*Account, error)
+	GetPositions(ctx context.Context) ([]*Position, error)
+
+	// PlaceOrder submits an order. Orders the broker refuses are returned with
+	// status rejected rather than as an error.
+	PlaceOrder(ctx context.Context, req *OrderRequest) (*Order, error)
+	// CancelOrder returns ErrOrderNotCancellable if the order is no longer open
+	CancelOrder(ctx context.Context, orderID string) (*Order, error)
+	// GetOrder returns ErrOrderNotFound if the broker does not know the order
+	GetOrder(ctx context.Context, orderID string) (*Order, error)
+	// ListOrders returns matching orders, newest first
+	ListOrders(ctx context.Context, filter OrderFilter) ([]*Order, error)
+
+	// StreamOrderUpdates delivers order updates until ctx is done, then closes the channel
+	StreamOrderUpdates(ctx context.Context) (<-chan OrderUpdate, error)
+}
+
+// PriceSource supplies the latest traded price of a symbol to brokers that
+// do not have their own market data, such as the simulator.
+type PriceSource interface {
+	// LastPrice returns ErrNoPrice if no price is known for the symbol
+	LastPrice(ctx context.Context, symbol string) (float64, error)
+}
@@ -0,0 +1,92 @@
+package broker
+
+import (
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// Kind selects the Broker implementation behind a connection.
+type Kind string
+
+const (
+	KindSimulator Kind = "simulator" // Fills orders locally against stored prices
+	KindREST      Kind = "rest"      // Talks to a broker's REST API at api_base_override
+)
+
+// Connection is a user's configured broker account.
+type Connection struct {
+	ID                   uuid.UUID    `json:"id" db:"id"`
+	UserID               uuid.UUID    `json:"user_id" db:"user_id"`
+	Name                 string       `json:"name" db:"name"`
+	Kind                 Kind         `json:"kind" db:"kind"`
+	EncryptedCredentials *string      `json:"-" db:"encrypted_credentials"` // Not exposed in JSON responses
+	APIBaseOverride      *string      `json:"api_base_override" db:"api_base_override"`
+	Settings             shared.JSONB `json:"settings" db:"settings"` // Implementation-specific, e.g. the simulator's initial cash
+	IsActive             bool         `json:"is_active" db:"is_active"`
+	CreatedAt            time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Credentials authenticate against a broker API. They are stored encrypted as JSON.
+type Credentials struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret,omitempty"`
+}
+
+// SimulatorSettings configure a simulator connection.
+type SimulatorSettings struct {
+	Currency       string
+	InitialCash    float64
+	CommissionRate float64 // Fraction of notional per fill
+}
+
+const (
+	defaultSimulatorCurrency = "USD"
+	defaultSimulatorCash     = 100000
+)
+
+// SimulatorSettings reads the simulator settings, applying defaults.
+func (c *Connection) SimulatorSettings() SimulatorSettings {
+	settings := SimulatorSettings{Currency: defaultSimulatorCurrency, InitialCash: defaultSimulatorCash}
+	if v, ok := c.Settings["currency"].(string); ok && v != "" {
+		settings.Currency = v
+	}
+	if v, ok := c.Settings["initial_cash"].(float64); ok {
+		settings.InitialCash = v
+	}
+	if v, ok := c.Settings["commission_rate"].(float64); ok {
+		settings.CommissionRate = v
+	}
+	return settings
+}
+
+// Validate checks the connection's kind and settings.
+func (c *Connection) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch c.Kind {
+	case KindSimulator:
+		settings := c.SimulatorSettings()
+		if !(settings.InitialCash > 0) {
+			return fmt.Errorf("initial_cash must be positive")
+		}
+		if settings.CommissionRate < 0 || settings.CommissionRate >= 1 {
+			return fmt.Errorf("commission_rate must be in [0, 1)")
+		}
+	case KindREST:
+		if c.APIBaseOverride == nil || *c.APIBaseOverride == "" {
+			return fmt.Errorf("api_base_override is required for rest connections")
+		}
+		if c.EncryptedCredentials == nil {
+			return fmt.Errorf("api_key is required for rest connections")
+		}
+	default:
+		return fmt.Errorf("kind must be simulator or rest")
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package broker
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ConnectionRepository interface {
+	Create(ctx context.Context, connection *Connection) (*Connection, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Connection, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Connection, error)
+	Update(ctx context.Context, connection *Connection) (*Connection, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
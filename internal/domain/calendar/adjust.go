@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+
+	"trading-alchemist/internal/domain/market"
+)
+
+// Adjustment selects which corporate actions are applied to a candle series.
+type Adjustment string
+
+const (
+	AdjustmentNone   Adjustment = "none"
+	AdjustmentSplits Adjustment = "splits"
+	AdjustmentAll    Adjustment = "all" // Splits and dividends
+)
+
+// ParseAdjustment parses an adjustment mode; the empty string means none.
+func ParseAdjustment(s string) (Adjustment, error) {
+	switch a := Adjustment(s); a {
+	case "":
+		return AdjustmentNone, nil
+	case AdjustmentNone, AdjustmentSplits, AdjustmentAll:
+		return a, nil
+	}
+	return "", fmt.Errorf("unsupported adjustment %q, expected none, splits or all", s)
+}
+
+// AdjustCandles returns a copy of the candles, in ascending order, with the
+// bars before each corporate action rescaled so that the series is continuous
+// in terms of the last candle. A split with ratio r divides earlier prices by r
+// and multiplies earlier volume by r. A dividend of d per share multiplies
+// earlier prices by 1 - d/c, where c is the close of the last bar before the
+// ex-date. Actions outside the span of the series have no effect.
+func AdjustCandles(candles []*market.Candle, actions []*Event, adjustment Adjustment) []*market.Candle {
+	adjusted := make([]*market.Candle, len(candles))
+	for i, c := range candles {
+		copied := *c
+		adjusted[i] = &copied
+	}
+	if adjustment == AdjustmentNone || len(adjusted) < 2 {
+		return adjusted
+	}
+
+	var applicable []*Event
+	for _, a := range actions {
+		switch {
+		case a.Type == EventTypeSplit && a.Ratio != nil && *a.Ratio > 0:
+		case a.Type == EventTypeDividend && a.Amount != nil && *a.Amount > 0 && adjustment == AdjustmentAll:
+		default:
+			continue
+		}
+		applicable = append(applicable, a)
+	}
+	// Walk both series from newest to oldest, accumulating the factors of the
+	// actions already passed.
+	sort.Slice(applicable, func(i, j int) bool {
+		return applicable[i].ScheduledAt.After(applicable[j].ScheduledAt)
+	})
+	last := adjusted[len(adjusted)-1].OpenTime
+	priceFactor, volumeFactor := 1.0, 1.0
+	next := 0
+	for i := len(adjusted) - 1; i >= 0; i-- {
+		c := adjusted[i]
+		for ; next < len(applicable) && applicable[next].ScheduledAt.After(c.OpenTime); next++ {
+			a := applicable[next]
+			if a.ScheduledAt.After(last) {
+				continue
+			}
+			switch a.Type {
+			case EventTypeSplit:
+				priceFactor /= *a.Ratio
+				volumeFactor *= *a.Ratio
+			case EventTypeDividend:
+				// c is still unadjusted here: the last bar before the ex-date.
+				if c.Close > *a.Amount {
+					priceFactor *= 1 - *a.Amount/c.Close
+				}
+			}
+		}
+		c.Open *= priceFactor
+		c.High *= priceFactor
+		c.Low *= priceFactor
+		c.Close *= priceFactor
+		c.Volume *= volumeFactor
+	}
+	return adjusted
+}
@@ -0,0 +1,135 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is the kind of a scheduled market event.
+type EventType string
+
+const (
+	EventTypeEarnings EventType = "earnings"
+	EventTypeMacro    EventType = "macro"
+	EventTypeDividend EventType = "dividend"
+	EventTypeSplit    EventType = "split"
+)
+
+// IsValid reports whether t is a known event type.
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventTypeEarnings, EventTypeMacro, EventTypeDividend, EventTypeSplit:
+		return true
+	}
+	return false
+}
+
+// IsCorporateAction reports whether events of this type change the price basis of a symbol.
+func (t EventType) IsCorporateAction() bool {
+	return t == EventTypeDividend || t == EventTypeSplit
+}
+
+// Importance ranks how much an event is expected to move the market.
+type Importance string
+
+const (
+	ImportanceLow    Importance = "low"
+	ImportanceMedium Importance = "medium"
+	ImportanceHigh   Importance = "high"
+)
+
+// IsValid reports whether i is a known importance.
+func (i Importance) IsValid() bool {
+	switch i {
+	case ImportanceLow, ImportanceMedium, ImportanceHigh:
+		return true
+	}
+	return false
+}
+
+// EventSource records how an event entered the store.
+type EventSource string
+
+const (
+	EventSourceManual EventSource = "manual"
+	EventSourceICS    EventSource = "ics"
+	EventSourceCSV    EventSource = "csv"
+)
+
+// MaxTitleLength caps the length of an event title.
+const MaxTitleLength = 255
+
+// Event is a scheduled catalyst: an earnings date, a macro release or a
+// corporate action. Events without a user are shared with everyone; events
+// imported by a user are visible to that user only.
+//
+// For dividends ScheduledAt is the ex-date and Amount the cash paid per share.
+// For splits ScheduledAt is the ex-date and Ratio the number of new shares per
+// old share, e.g. 4 for a 4-for-1 split or 0.1 for a 1-for-10 reverse split.
+type Event struct {
+	ID          uuid.UUID   `json:"id" db:"id"`
+	UserID      *uuid.UUID  `json:"user_id" db:"user_id"`
+	Type        EventType   `json:"type" db:"type"`
+	Symbol      *string     `json:"symbol" db:"symbol"` // Nil for macro events
+	Title       string      `json:"title" db:"title"`
+	Description *string     `json:"description" db:"description"`
+	ScheduledAt time.Time   `json:"scheduled_at" db:"scheduled_at"`
+	AllDay      bool        `json:"all_day" db:"all_day"`
+	Importance  Importance  `json:"importance" db:"importance"`
+	Amount      *float64    `json:"amount" db:"amount"`
+	Ratio       *float64    `json:"ratio" db:"ratio"`
+	Source      EventSource `json:"source" db:"source"`
+	ExternalID  string      `json:"external_id" db:"external_id"` // Deduplicates re-imports per owner and source
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the event's type-specific fields, normalizing the symbol and
+// defaulting the importance.
+func (e *Event) Validate() error {
+	if !e.Type.IsValid() {
+		return fmt.Errorf("unsupported event type %q", e.Type)
+	}
+	e.Title = strings.TrimSpace(e.Title)
+	if e.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	if len(e.Title) > MaxTitleLength {
+		return fmt.Errorf("title must be at most %d characters", MaxTitleLength)
+	}
+	if e.ScheduledAt.IsZero() {
+		return fmt.Errorf("scheduled time is required")
+	}
+	if e.Importance == "" {
+		e.Importance = ImportanceMedium
+	}
+	if !e.Importance.IsValid() {
+		return fmt.Errorf("unsupported importance %q", e.Importance)
+	}
+
+	if e.Symbol != nil {
+		symbol := strings.ToUpper(strings.TrimSpace(*e.Symbol))
+		e.Symbol = &symbol
+		if symbol == "" {
+			e.Symbol = nil
+		}
+	}
+	if e.Symbol == nil && e.Type != EventTypeMacro {
+		return fmt.Errorf("%s events require a symbol", e.Type)
+	}
+
+	switch e.Type {
+	case EventTypeDividend:
+		if e.Amount == nil || *e.Amount <= 0 {
+			return fmt.Errorf("dividend events require a positive amount")
+		}
+	case EventTypeSplit:
+		if e.Ratio == nil || *e.Ratio <= 0 {
+			return fmt.Errorf("split events require a positive ratio")
+		}
+	}
+	return nil
+}
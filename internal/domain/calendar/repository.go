@@ -0,0 +1,27 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventFilter selects events. Zero values match everything. Macro events have
+// no symbol and therefore always pass the symbol filter; exclude them by type.
+type EventFilter struct {
+	Start   *time.Time // Inclusive, on scheduled time
+	End     *time.Time // Exclusive, on scheduled time
+	Symbols []string
+	Types   []EventType
+}
+
+type EventRepository interface {
+	// Upsert inserts an event or overwrites the one with the same owner, source and external ID
+	Upsert(ctx context.Context, event *Event) (*Event, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Event, error)
+	// List returns the shared events and the user's own events that match, in
+	// chronological order; a limit of zero returns all of them
+	List(ctx context.Context, userID uuid.UUID, filter EventFilter, limit, offset int) ([]*Event, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
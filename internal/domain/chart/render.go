@@ -0,0 +1,495 @@
+package chart
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+)
+
+var palette = []string{"#2563eb", "#f59e0b", "#10b981", "#8b5cf6", "#ec4899", "#0ea5e9", "#64748b", "#84cc16"}
+
+const (
+	upColor       = "#16a34a"
+	downColor     = "#dc2626"
+	drawdownColor = "#dc2626"
+	gridColor     = "#e5e7eb"
+	textColor     = "#111827"
+	mutedColor    = "#6b7280"
+
+	marginLeft   = 28
+	marginRight  = 72 // Room for the value axis labels
+	marginTop    = 52 // Room for the title and the legend
+	marginBottom = 28
+	paneGap      = 20
+	// rsiPaneShare is the fraction of the plot height given to the RSI pane.
+	rsiPaneShare = 0.25
+
+	valueTicks = 5
+	timeTicks  = 6
+)
+
+// Render draws the chart as a standalone SVG document. It uses no scripts or
+// external resources, so the result can be embedded as is.
+func Render(spec *Spec) (string, error) {
+	if err := spec.Validate(); err != nil {
+		return "", err
+	}
+	r := &renderer{spec: spec}
+	return r.render(), nil
+}
+
+type rect struct {
+	left, top, right, bottom float64
+}
+
+func (r rect) width() float64  { return r.right - r.left }
+func (r rect) height() float64 { return r.bottom - r.top }
+
+type legendEntry struct {
+	label string
+	color string
+	area  bool
+}
+
+// renderer accumulates the SVG of one chart.
+type renderer struct {
+	spec   *Spec
+	b      strings.Builder
+	legend []legendEntry
+	colors int
+
+	// The time axis is either continuous or, for candles and bars, one slot per
+	// time so that gaps such as weekends take no space.
+	categorical bool
+	slots       map[int64]int
+	slotCount   int
+	minTime     time.Time
+	maxTime     time.Time
+	plot        rect
+}
+
+func (r *renderer) render() string {
+	s := r.spec
+	w, h := float64(s.Width), float64(s.Height)
+	r.plot = rect{left: marginLeft, top: marginTop, right: w - marginRight, bottom: h - marginBottom}
+
+	var rsi []Overlay
+	var overlays []Overlay
+	for _, o := range s.Overlays {
+		if o.Indicator == IndicatorRSI {
+			rsi = append(rsi, o)
+		} else {
+			overlays = append(overlays, o)
+		}
+	}
+	price := r.plot
+	var rsiPane rect
+	if len(rsi) > 0 {
+		rsiHeight := r.plot.height() * rsiPaneShare
+		rsiPane = rect{left: r.plot.left, top: r.plot.bottom - rsiHeight, right: r.plot.right, bottom: r.plot.bottom}
+		price.bottom = rsiPane.top - paneGap
+	}
+
+	r.setupTimeAxis()
+
+	fmt.Fprintf(&r.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif" font-size="11">`, s.Width, s.Height, s.Width, s.Height)
+	r.b.WriteString("\n")
+	fmt.Fprintf(&r.b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	if s.Title != "" {
+		fmt.Fprintf(&r.b, `<text x="%s" y="22" font-size="15" font-weight="bold" fill="%s">%s</text>`+"\n", num(marginLeft), textColor, html.EscapeString(s.Title))
+	}
+
+	overlayValues := make([][]float64, len(overlays))
+	for i, o := range overlays {
+		overlayValues[i] = o.compute(s.Candles)
+	}
+
+	lo, hi := r.valueRange(overlayValues)
+	y := r.drawValueAxis(price, lo, hi)
+	r.drawTimeAxis(r.plot.bottom)
+
+	switch s.Type {
+	case TypeCandlestick:
+		r.drawCandles(y)
+	case TypeLine:
+		for _, series := range r.lineSeries() {
+			r.drawLine(series.Name, series.Points, y)
+		}
+	case TypeBar:
+		r.drawBars(y)
+	case TypeEquityCurve:
+		r.drawEquity(y)
+	}
+	for i, o := range overlays {
+		r.drawLine(o.Label(), candlePoints(s.Candles, overlayValues[i]), y)
+	}
+
+	if len(rsi) > 0 {
+		ry := r.drawValueAxis(rsiPane, 0, 100)
+		for _, level := range []float64{30, 70} {
+			fmt.Fprintf(&r.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-dasharray="4 3"/>`+"\n", num(rsiPane.left), num(ry(level)), num(rsiPane.right), num(ry(level)), mutedColor)
+		}
+		for _, o := range rsi {
+			r.drawLine(o.Label(), candlePoints(s.Candles, o.compute(s.Candles)), ry)
+		}
+	}
+
+	r.drawLegend()
+	r.b.WriteString("</svg>\n")
+	return r.b.String()
+}
+
+// setupTimeAxis collects the time domain of everything drawn.
+func (r *renderer) setupTimeAxis() {
+	s := r.spec
+	r.categorical = s.Type == TypeCandlestick || s.Type == TypeBar
+
+	var times []time.Time
+	for _, c := range s.Candles {
+		times = append(times, c.OpenTime)
+	}
+	for _, series := range s.Series {
+		for _, p := range series.Points {
+			times = append(times, p.Time)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	if len(times) == 0 {
+		return
+	}
+	r.minTime, r.maxTime = times[0], times[len(times)-1]
+
+	if r.categorical {
+		r.slots = make(map[int64]int)
+		for _, t := range times {
+			if _, ok := r.slots[t.UnixNano()]; !ok {
+				r.slots[t.UnixNano()] = r.slotCount
+				r.slotCount++
+			}
+		}
+	}
+}
+
+// x returns the horizontal position of a time within the plot.
+func (r *renderer) x(t time.Time) float64 {
+	if r.categorical {
+		return r.plot.left + (float64(r.slots[t.UnixNano()])+0.5)*r.slotWidth()
+	}
+	span := r.maxTime.Sub(r.minTime)
+	if span <= 0 {
+		return r.plot.left + r.plot.width()/2
+	}
+	return r.plot.left + float64(t.Sub(r.minTime))/float64(span)*r.plot.width()
+}
+
+func (r *renderer) slotWidth() float64 {
+	if r.slotCount == 0 {
+		return r.plot.width()
+	}
+	return r.plot.width() / float64(r.slotCount)
+}
+
+// valueRange returns the lowest and highest value drawn in the price pane.
+func (r *renderer) valueRange(overlays [][]float64) (float64, float64) {
+	s := r.spec
+	lo, hi := math.Inf(1), math.Inf(-1)
+	include := func(v float64) {
+		if math.IsNaN(v) {
+			return
+		}
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+
+	if s.Type == TypeCandlestick {
+		for _, c := range s.Candles {
+			include(c.Low)
+			include(c.High)
+		}
+	} else {
+		for _, series := range r.lineSeries() {
+			for _, p := range series.Points {
+				include(p.Value)
+			}
+		}
+	}
+	for _, values := range overlays {
+		for _, v := range values {
+			include(v)
+		}
+	}
+	if s.Type == TypeBar {
+		include(0)
+	}
+	if math.IsInf(lo, 0) {
+		return 0, 1
+	}
+	return lo, hi
+}
+
+// lineSeries returns the series drawn by line, bar and equity charts; without
+// series the candle closes are used.
+func (r *renderer) lineSeries() []Series {
+	if len(r.spec.Series) > 0 {
+		return r.spec.Series
+	}
+	closes := make([]float64, len(r.spec.Candles))
+	for i, c := range r.spec.Candles {
+		closes[i] = c.Close
+	}
+	return []Series{{Name: "Close", Points: candlePoints(r.spec.Candles, closes)}}
+}
+
+// drawValueAxis draws the horizontal grid lines and value labels of a pane and
+// returns the scale mapping values to vertical positions.
+func (r *renderer) drawValueAxis(pane rect, lo, hi float64) func(float64) float64 {
+	lo, hi, step := niceTicks(lo, hi, valueTicks)
+	y := func(v float64) float64 {
+		return pane.bottom - (v-lo)/(hi-lo)*pane.height()
+	}
+	for v := lo; v <= hi+step/2; v += step {
+		pos := y(v)
+		fmt.Fprintf(&r.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n", num(pane.left), num(pos), num(pane.right), num(pos), gridColor)
+		fmt.Fprintf(&r.b, `<text x="%s" y="%s" fill="%s" dominant-baseline="middle">%s</text>`+"\n", num(pane.right+6), num(pos), mutedColor, formatValue(v, step))
+	}
+	return y
+}
+
+// drawTimeAxis draws the time labels below the plot.
+func (r *renderer) drawTimeAxis(bottom float64) {
+	layout := timeLayout(r.maxTime.Sub(r.minTime))
+	label := func(t time.Time) {
+		fmt.Fprintf(&r.b, `<text x="%s" y="%s" fill="%s" text-anchor="middle">%s</text>`+"\n", num(r.x(t)), num(bottom+16), mutedColor, t.UTC().Format(layout))
+	}
+
+	if r.categorical {
+		times := make([]time.Time, r.slotCount)
+		for _, c := range r.spec.Candles {
+			times[r.slots[c.OpenTime.UnixNano()]] = c.OpenTime
+		}
+		for _, series := range r.spec.Series {
+			for _, p := range series.Points {
+				times[r.slots[p.Time.UnixNano()]] = p.Time
+			}
+		}
+		step := (len(times) + timeTicks - 1) / timeTicks
+		for i := 0; i < len(times); i += step {
+			label(times[i])
+		}
+		return
+	}
+
+	if !r.maxTime.After(r.minTime) {
+		label(r.minTime)
+		return
+	}
+	span := r.maxTime.Sub(r.minTime)
+	for i := 0; i <= timeTicks; i++ {
+		label(r.minTime.Add(span * time.Duration(i) / timeTicks))
+	}
+}
+
+func (r *renderer) drawCandles(y func(float64) float64) {
+	body := math.Max(1, r.slotWidth()*0.7)
+	for _, c := range r.spec.Candles {
+		color := upColor
+		if c.Close < c.Open {
+			color = downColor
+		}
+		cx := r.x(c.OpenTime)
+		top := y(math.Max(c.Open, c.Close))
+		height := math.Max(1, y(math.Min(c.Open, c.Close))-top)
+		fmt.Fprintf(&r.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`, num(cx), num(y(c.High)), num(cx), num(y(c.Low)), color)
+		fmt.Fprintf(&r.b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n", num(cx-body/2), num(top), num(body), num(height), color)
+	}
+}
+
+func (r *renderer) drawBars(y func(float64) float64) {
+	series := r.lineSeries()
+	group := r.slotWidth() * 0.8
+	width := math.Max(1, group/float64(len(series)))
+	zero := y(0)
+	for i, s := range series {
+		color := r.nextColor()
+		r.legend = append(r.legend, legendEntry{label: s.Name, color: color, area: true})
+		for _, p := range s.Points {
+			if math.IsNaN(p.Value) {
+				continue
+			}
+			left := r.x(p.Time) - group/2 + float64(i)*width
+			top, bottom := y(p.Value), zero
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			fmt.Fprintf(&r.b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n", num(left), num(top), num(width), num(math.Max(1, bottom-top)), color)
+		}
+	}
+}
+
+// drawEquity draws the equity series with the area between its running peak and
+// the equity shaded, followed by any further series.
+func (r *renderer) drawEquity(y func(float64) float64) {
+	equity := r.spec.Series[0]
+	var upper, lower []string
+	peak := math.Inf(-1)
+	maxDrawdown := 0.0
+	for _, p := range equity.Points {
+		if math.IsNaN(p.Value) {
+			continue
+		}
+		peak = math.Max(peak, p.Value)
+		if peak > 0 {
+			maxDrawdown = math.Max(maxDrawdown, (peak-p.Value)/peak)
+		}
+		x := num(r.x(p.Time))
+		upper = append(upper, x+","+num(y(peak)))
+		lower = append(lower, x+","+num(y(p.Value)))
+	}
+	for i, j := 0, len(lower)-1; i < j; i, j = i+1, j-1 {
+		lower[i], lower[j] = lower[j], lower[i]
+	}
+	if len(upper) > 1 {
+		fmt.Fprintf(&r.b, `<polygon points="%s %s" fill="%s" fill-opacity="0.25"/>`+"\n", strings.Join(upper, " "), strings.Join(lower, " "), drawdownColor)
+	}
+
+	name := equity.Name
+	if name == "" {
+		name = "Equity"
+	}
+	r.drawLine(name, equity.Points, y)
+	r.legend = append(r.legend, legendEntry{label: fmt.Sprintf("Drawdown (max %.2f%%)", maxDrawdown*100), color: drawdownColor, area: true})
+	for _, series := range r.spec.Series[1:] {
+		r.drawLine(series.Name, series.Points, y)
+	}
+}
+
+// drawLine draws a series as a polyline, breaking it where values are missing.
+func (r *renderer) drawLine(name string, points []Point, y func(float64) float64) {
+	color := r.nextColor()
+	r.legend = append(r.legend, legendEntry{label: name, color: color})
+
+	var path strings.Builder
+	move := true
+	for _, p := range points {
+		if math.IsNaN(p.Value) {
+			move = true
+			continue
+		}
+		if move {
+			path.WriteString("M")
+			move = false
+		} else {
+			path.WriteString("L")
+		}
+		path.WriteString(num(r.x(p.Time)) + " " + num(y(p.Value)) + " ")
+	}
+	if path.Len() == 0 {
+		return
+	}
+	fmt.Fprintf(&r.b, `<path d="%s" fill="none" stroke="%s" stroke-width="1.5" stroke-linejoin="round"/>`+"\n", strings.TrimSpace(path.String()), color)
+}
+
+func (r *renderer) drawLegend() {
+	x := float64(marginLeft)
+	const y = marginTop - 16
+	for _, e := range r.legend {
+		if e.label == "" {
+			continue
+		}
+		if e.area {
+			fmt.Fprintf(&r.b, `<rect x="%s" y="%s" width="12" height="8" fill="%s" fill-opacity="0.6"/>`, num(x), num(y-4), e.color)
+		} else {
+			fmt.Fprintf(&r.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="2"/>`, num(x), num(y), num(x+12), num(y), e.color)
+		}
+		fmt.Fprintf(&r.b, `<text x="%s" y="%s" fill="%s" dominant-baseline="middle">%s</text>`+"\n", num(x+16), num(y), textColor, html.EscapeString(e.label))
+		// Approximate the label width; SVG has no text measurement without a browser.
+		x += 16 + float64(len(e.label))*6.2 + 14
+	}
+}
+
+func (r *renderer) nextColor() string {
+	color := palette[r.colors%len(palette)]
+	r.colors++
+	return color
+}
+
+// candlePoints pairs values aligned with the candles with their open times.
+func candlePoints(candles []*market.Candle, values []float64) []Point {
+	points := make([]Point, len(candles))
+	for i, c := range candles {
+		points[i] = Point{Time: c.OpenTime, Value: values[i]}
+	}
+	return points
+}
+
+// niceTicks widens [lo, hi] to multiples of a round step giving about count ticks.
+func niceTicks(lo, hi float64, count int) (float64, float64, float64) {
+	if hi == lo {
+		pad := math.Max(math.Abs(lo)*0.05, 1)
+		lo, hi = lo-pad, hi+pad
+	}
+	step := niceNumber((hi-lo)/float64(count-1), true)
+	return math.Floor(lo/step) * step, math.Ceil(hi/step) * step, step
+}
+
+// niceNumber returns a number of the form 1, 2 or 5 times a power of ten close to x.
+func niceNumber(x float64, round bool) float64 {
+	exp := math.Floor(math.Log10(x))
+	fraction := x / math.Pow(10, exp)
+	var nice float64
+	switch {
+	case round && fraction < 1.5, !round && fraction <= 1:
+		nice = 1
+	case round && fraction < 3, !round && fraction <= 2:
+		nice = 2
+	case round && fraction < 7, !round && fraction <= 5:
+		nice = 5
+	default:
+		nice = 10
+	}
+	return nice * math.Pow(10, exp)
+}
+
+// formatValue prints an axis value with as many decimals as the tick step needs,
+// abbreviating thousands and millions.
+func formatValue(v, step float64) string {
+	suffix := ""
+	switch {
+	case step >= 1e6:
+		v, step, suffix = v/1e6, step/1e6, "M"
+	case step >= 1e3 && math.Abs(v) >= 1e4:
+		v, step, suffix = v/1e3, step/1e3, "K"
+	}
+	decimals := 0
+	if step < 1 {
+		decimals = int(math.Min(8, math.Ceil(-math.Log10(step))))
+	}
+	if math.Abs(v) < step/2 {
+		v = 0 // Avoid printing -0
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64) + suffix
+}
+
+// timeLayout picks a label format that fits the span of the time axis.
+func timeLayout(span time.Duration) string {
+	switch {
+	case span >= 2*365*24*time.Hour:
+		return "Jan 2006"
+	case span >= 3*24*time.Hour:
+		return "Jan 02"
+	case span >= 24*time.Hour:
+		return "Jan 02 15:04"
+	default:
+		return "15:04"
+	}
+}
+
+// num formats a coordinate with one decimal, which is plenty for screen output.
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
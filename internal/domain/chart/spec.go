@@ -0,0 +1,204 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+)
+
+// Type is the kind of chart a spec describes.
+type Type string
+
+const (
+	TypeCandlestick Type = "candlestick"
+	TypeLine        Type = "line"
+	TypeBar         Type = "bar"
+	TypeEquityCurve Type = "equity_curve"
+)
+
+// Indicator is a technical indicator that can be overlaid on a candle series.
+type Indicator string
+
+const (
+	IndicatorSMA     Indicator = "sma"
+	IndicatorEMA     Indicator = "ema"
+	IndicatorHighest Indicator = "highest"
+	IndicatorLowest  Indicator = "lowest"
+	IndicatorRSI     Indicator = "rsi" // Drawn in its own pane below the price
+)
+
+const (
+	DefaultWidth  = 960
+	DefaultHeight = 540
+	MinWidth      = 320
+	MaxWidth      = 2400
+	MinHeight     = 200
+	MaxHeight     = 1600
+
+	// MaxPoints caps the number of candles or points per series.
+	MaxPoints   = 5000
+	MaxSeries   = 8
+	MaxOverlays = 6
+	MaxPeriod   = 500
+)
+
+// Point is one value of a series.
+type Point struct {
+	Time  time.Time `json:"t"`
+	Value float64   `json:"v"`
+}
+
+// Series is a named list of points, sorted by time.
+type Series struct {
+	Name   string  `json:"name"`
+	Points []Point `json:"points"`
+}
+
+// Overlay is an indicator computed from the candle closes and drawn over the price.
+type Overlay struct {
+	Indicator Indicator `json:"indicator"`
+	Period    int       `json:"period"`
+}
+
+// Label returns the legend label of the overlay, e.g. "SMA(20)".
+func (o Overlay) Label() string {
+	return fmt.Sprintf("%s(%d)", indicatorLabels[o.Indicator], o.Period)
+}
+
+var indicatorLabels = map[Indicator]string{
+	IndicatorSMA:     "SMA",
+	IndicatorEMA:     "EMA",
+	IndicatorHighest: "Highest",
+	IndicatorLowest:  "Lowest",
+	IndicatorRSI:     "RSI",
+}
+
+// Spec describes a chart to render.
+//
+// Candlestick charts draw Candles; line and bar charts draw Series, or the candle
+// closes when no series are given. Equity curves draw the first series as the
+// equity and shade the drawdown from its running peak; further series, such as a
+// benchmark, are drawn as lines. Overlays require candles.
+type Spec struct {
+	Type     Type             `json:"type"`
+	Title    string           `json:"title"`
+	Width    int              `json:"width,omitempty"`
+	Height   int              `json:"height,omitempty"`
+	Candles  []*market.Candle `json:"candles,omitempty"`
+	Series   []Series         `json:"series,omitempty"`
+	Overlays []Overlay        `json:"overlays,omitempty"`
+}
+
+// Validate checks the spec and fills in the default size.
+func (s *Spec) Validate() error {
+	switch s.Type {
+	case TypeCandlestick, TypeLine, TypeBar, TypeEquityCurve:
+	default:
+		return fmt.Errorf("unsupported chart type %q", s.Type)
+	}
+
+	if s.Width == 0 {
+		s.Width = DefaultWidth
+	}
+	if s.Height == 0 {
+		s.Height = DefaultHeight
+	}
+	if s.Width < MinWidth || s.Width > MaxWidth {
+		return fmt.Errorf("width must be between %d and %d", MinWidth, MaxWidth)
+	}
+	if s.Height < MinHeight || s.Height > MaxHeight {
+		return fmt.Errorf("height must be between %d and %d", MinHeight, MaxHeight)
+	}
+
+	if len(s.Candles) > MaxPoints {
+		return fmt.Errorf("at most %d candles can be charted", MaxPoints)
+	}
+	for i, c := range s.Candles {
+		if c == nil {
+			return fmt.Errorf("candle %d is empty", i)
+		}
+		if i > 0 && !c.OpenTime.After(s.Candles[i-1].OpenTime) {
+			return fmt.Errorf("candles must be sorted by time")
+		}
+		if !finite(c.Open, c.High, c.Low, c.Close) || c.Low > c.High {
+			return fmt.Errorf("candle %d has invalid prices", i)
+		}
+	}
+
+	if len(s.Series) > MaxSeries {
+		return fmt.Errorf("at most %d series can be charted", MaxSeries)
+	}
+	for _, series := range s.Series {
+		if len(series.Points) > MaxPoints {
+			return fmt.Errorf("series %q has more than %d points", series.Name, MaxPoints)
+		}
+		for i, p := range series.Points {
+			if i > 0 && !p.Time.After(series.Points[i-1].Time) {
+				return fmt.Errorf("series %q must be sorted by time", series.Name)
+			}
+			if math.IsInf(p.Value, 0) {
+				return fmt.Errorf("series %q has an infinite value", series.Name)
+			}
+		}
+	}
+
+	if len(s.Overlays) > MaxOverlays {
+		return fmt.Errorf("at most %d overlays can be charted", MaxOverlays)
+	}
+	if len(s.Overlays) > 0 && len(s.Candles) == 0 {
+		return fmt.Errorf("indicator overlays require candles")
+	}
+	for _, o := range s.Overlays {
+		if _, ok := indicatorLabels[o.Indicator]; !ok {
+			return fmt.Errorf("unsupported indicator %q", o.Indicator)
+		}
+		if o.Period < 1 || o.Period > MaxPeriod {
+			return fmt.Errorf("%s period must be between 1 and %d", o.Indicator, MaxPeriod)
+		}
+	}
+
+	switch s.Type {
+	case TypeCandlestick:
+		if len(s.Candles) == 0 {
+			return fmt.Errorf("candlestick charts require candles")
+		}
+	case TypeLine, TypeBar:
+		if len(s.Series) == 0 && len(s.Candles) == 0 {
+			return fmt.Errorf("%s charts require series or candles", s.Type)
+		}
+	case TypeEquityCurve:
+		if len(s.Series) == 0 || len(s.Series[0].Points) == 0 {
+			return fmt.Errorf("equity curves require an equity series")
+		}
+	}
+	return nil
+}
+
+// compute returns the overlay values aligned with the candles.
+func (o Overlay) compute(candles []*market.Candle) []float64 {
+	closes := market.Closes(candles)
+	switch o.Indicator {
+	case IndicatorSMA:
+		return market.SMA(closes, o.Period)
+	case IndicatorEMA:
+		return market.EMA(closes, o.Period)
+	case IndicatorHighest:
+		return market.Highest(closes, o.Period)
+	case IndicatorLowest:
+		return market.Lowest(closes, o.Period)
+	case IndicatorRSI:
+		return market.RSI(closes, o.Period)
+	}
+	return nil
+}
+
+func finite(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,8 @@
 package chat
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 	"trading-alchemist/internal/domain/shared"
 
@@ -9,15 +11,49 @@ import (
 
 // Artifact represents generated content like code, documents, etc.
 type Artifact struct {
-	ID           uuid.UUID    `json:"id" db:"id"`
-	MessageID    uuid.UUID    `json:"message_id" db:"message_id"`
-	Title        string       `json:"title" db:"title"`
-	Type         shared.ArtifactType `json:"type" db:"type"`
-	Language     *string      `json:"language" db:"language"` // For code artifacts
-	Content      string       `json:"content" db:"content"`
-	ContentHash  string       `json:"content_hash" db:"content_hash"` // For deduplication
-	Size         int64        `json:"size" db:"size"`                 // Content size in bytes
-	IsPublic     bool         `json:"is_public" db:"is_public"`
-	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at" db:"updated_at"`
-} 
\ No newline at end of file
+	ID             uuid.UUID           `json:"id" db:"id"`
+	MessageID      uuid.UUID           `json:"message_id" db:"message_id"`
+	Title          string              `json:"title" db:"title"`
+	Type           shared.ArtifactType `json:"type" db:"type"`
+	Language       *string             `json:"language" db:"language"` // For code artifacts
+	Content        string              `json:"content" db:"content"`
+	ContentHash    string              `json:"content_hash" db:"content_hash"` // For deduplication
+	Size           int64               `json:"size" db:"size"`                 // Content size in bytes
+	IsPublic       bool                `json:"is_public" db:"is_public"`
+	Version        int                 `json:"version" db:"version"`
+	RootArtifactID *uuid.UUID          `json:"root_artifact_id" db:"root_artifact_id"` // First version; nil for the first version itself
+	CreatedAt      time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// NewArtifact creates an artifact with its content hash and size filled in.
+func NewArtifact(title string, artifactType shared.ArtifactType, language *string, content string) *Artifact {
+	sum := sha256.Sum256([]byte(content))
+	return &Artifact{
+		Title:       title,
+		Type:        artifactType,
+		Language:    language,
+		Content:     content,
+		ContentHash: hex.EncodeToString(sum[:]),
+		Size:        int64(len(content)),
+		Version:     1,
+	}
+}
+
+// LineageID identifies all versions of an artifact: the ID of its first version.
+func (a *Artifact) LineageID() uuid.UUID {
+	if a.RootArtifactID != nil {
+		return *a.RootArtifactID
+	}
+	return a.ID
+}
+
+// NewVersion returns a copy of the artifact with new content, numbered after latest.
+func (a *Artifact) NewVersion(latest *Artifact, title, content string) *Artifact {
+	next := NewArtifact(title, a.Type, a.Language, content)
+	next.MessageID = a.MessageID
+	next.Version = latest.Version + 1
+	rootID := a.LineageID()
+	next.RootArtifactID = &rootID
+	return next
+}
@@ -2,6 +2,7 @@ package chat
 
 import (
 	"context"
+	"trading-alchemist/internal/domain/shared"
 
 	"github.com/google/uuid"
 )
@@ -13,4 +14,13 @@ type ArtifactRepository interface {
 	Update(ctx context.Context, artifact *Artifact) (*Artifact, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetPublicArtifacts(ctx context.Context, limit, offset int) ([]*Artifact, error)
+
+	// GetVersions returns every version of the artifact lineage rooted at rootID, oldest first
+	GetVersions(ctx context.Context, rootID uuid.UUID) ([]*Artifact, error)
+
+	// ListLatestByUserAndType returns the latest version of each of the user's artifacts of a type
+	ListLatestByUserAndType(ctx context.Context, userID uuid.UUID, artifactType shared.ArtifactType, limit, offset int) ([]*Artifact, error)
+
+	// GetOwnerID returns the ID of the user owning the conversation the artifact belongs to
+	GetOwnerID(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
 } 
\ No newline at end of file
@@ -19,4 +19,55 @@ type Conversation struct {
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 	LastMessageAt *time.Time `json:"last_message_at" db:"last_message_at"`
-} 
\ No newline at end of file
+	// Organization the conversation belongs to or is shared with; nil for private conversations
+	OrganizationID         *uuid.UUID             `json:"organization_id" db:"organization_id"`
+	OrganizationPermission ConversationPermission `json:"organization_permission" db:"organization_permission"` // What its members may do
+}
+
+// ConversationPermission is what a user may do with a conversation.
+type ConversationPermission string
+
+const (
+	ConversationPermissionRead   ConversationPermission = "read"   // Read the messages
+	ConversationPermissionWrite  ConversationPermission = "write"  // Also post messages, confirm tool calls and rename
+	ConversationPermissionManage ConversationPermission = "manage" // Also change settings, share and archive
+)
+
+var conversationPermissionRanks = map[ConversationPermission]int{
+	ConversationPermissionRead:   1,
+	ConversationPermissionWrite:  2,
+	ConversationPermissionManage: 3,
+}
+
+// IsValidOrganizationPermission reports whether the permission can be given to
+// the members of an organization.
+func IsValidOrganizationPermission(permission ConversationPermission) bool {
+	return permission == ConversationPermissionRead || permission == ConversationPermissionWrite
+}
+
+// Allows reports whether the permission grants everything the other one does.
+// The empty permission, for users without access, allows nothing.
+func (p ConversationPermission) Allows(other ConversationPermission) bool {
+	return conversationPermissionRanks[p] > 0 && conversationPermissionRanks[p] >= conversationPermissionRanks[other]
+}
+
+// Conversation settings read by document retrieval
+const (
+	SettingRetrievalTopK          = "retrieval_top_k"          // Number of passages given to the model
+	SettingRetrievalMinSimilarity = "retrieval_min_similarity" // Least similarity of a passage to the message
+)
+
+// MaxRetrievalTopK caps the number of passages retrieved for a message.
+const MaxRetrievalTopK = 50
+
+// NumberSetting returns a numeric setting and whether it is set. Settings
+// loaded from the database hold JSON numbers as float64.
+func (c *Conversation) NumberSetting(key string) (float64, bool) {
+	switch v := c.Settings[key].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
@@ -11,8 +11,17 @@ type ConversationRepository interface {
 	Create(ctx context.Context, conversation *Conversation) (*Conversation, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Conversation, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*Conversation, error)
+	// ListAllByUserID returns the conversations the user started, archived ones included, oldest first
+	ListAllByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*Conversation, error)
+	// ListAccessibleByUserID returns the conversations the user started or that belong to one
+	// of the user's organizations, most recent first; with an organization, only its conversations
+	ListAccessibleByUserID(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, limit, offset int) ([]*Conversation, error)
 	Update(ctx context.Context, conversation *Conversation) (*Conversation, error)
 	UpdateLastMessageAt(ctx context.Context, id uuid.UUID, lastMessageAt time.Time) error
 	UpdateTitle(ctx context.Context, id uuid.UUID, title string) error
 	Archive(ctx context.Context, id uuid.UUID) error
+	// SetOrganization moves the conversation into an organization, or back out of it with a nil ID
+	SetOrganization(ctx context.Context, id uuid.UUID, organizationID *uuid.UUID, permission ConversationPermission) error
+	// CountByUserSince counts the conversations the user started since the given time
+	CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
 } 
\ No newline at end of file
@@ -0,0 +1,25 @@
+package chat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// EmbeddingCacheRepository stores embeddings by model and the hash of the embedded
+// text, so the same text is only sent to the provider once.
+type EmbeddingCacheRepository interface {
+	// Get returns the cached embeddings of the hashes, keyed by hash. Hashes that
+	// are not cached are missing from the result.
+	Get(ctx context.Context, model string, contentHashes []string) (map[string][]float32, error)
+	// Put caches an embedding; an existing entry is kept.
+	Put(ctx context.Context, model, contentHash string, embedding []float32) error
+	// DeleteByContentHashes removes the cached embeddings of the hashes for every model.
+	DeleteByContentHashes(ctx context.Context, contentHashes []string) error
+}
+
+// ContentHash returns the key under which the embedding of a text is cached.
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
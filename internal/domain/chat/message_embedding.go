@@ -0,0 +1,30 @@
+package chat
+
+import (
+	"time"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// MessageEmbedding is the embedding of a message, used to find past messages
+// similar to a query.
+type MessageEmbedding struct {
+	MessageID      uuid.UUID
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	Model          string // Embedding model
+	Embedding      []float32
+	CreatedAt      time.Time
+}
+
+// MessageMatch is a past message found by semantic search.
+type MessageMatch struct {
+	MessageID         uuid.UUID          `json:"message_id"`
+	ConversationID    uuid.UUID          `json:"conversation_id"`
+	ConversationTitle string             `json:"conversation_title"`
+	Role              shared.MessageRole `json:"role"`
+	Content           string             `json:"content"`
+	Similarity        float64            `json:"similarity"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
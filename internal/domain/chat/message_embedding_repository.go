@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// MessageEmbeddingRepository stores message embeddings for semantic search. Only
+// embeddings of the given model in conversations that are not archived are
+// searched; excludeConversationID may be uuid.Nil.
+type MessageEmbeddingRepository interface {
+	// Create stores the embedding of a message, replacing an existing one.
+	Create(ctx context.Context, embedding *MessageEmbedding) error
+	// Search returns the user's messages nearest to the query, best first. It
+	// requires the pgvector extension.
+	Search(ctx context.Context, userID uuid.UUID, model string, query []float32, excludeConversationID uuid.UUID, limit int) ([]*MessageMatch, error)
+	// ListByUserID pages through the user's embeddings ordered by message ID, for
+	// searching without pgvector.
+	ListByUserID(ctx context.Context, userID uuid.UUID, model string, excludeConversationID, afterID uuid.UUID, limit int) ([]*MessageEmbedding, error)
+	// ListMatchesByMessageIDs loads the given messages; Similarity is left unset.
+	ListMatchesByMessageIDs(ctx context.Context, messageIDs []uuid.UUID) ([]*MessageMatch, error)
+}
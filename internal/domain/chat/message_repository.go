@@ -12,10 +12,14 @@ type MessageRepository interface {
 	GetByConversationID(ctx context.Context, conversationID uuid.UUID, limit, offset int) ([]*Message, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Message, error)
 	GetThread(ctx context.Context, parentID uuid.UUID) ([]*Message, error)
+	// GetToolMessageByCallID returns the tool message answering the given tool call and locks it for the transaction
+	GetToolMessageByCallID(ctx context.Context, conversationID uuid.UUID, toolCallID string) (*Message, error)
 	Update(ctx context.Context, message *Message) (*Message, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	// For large conversations - get paginated with cursor
 	GetByConversationIDWithCursor(ctx context.Context, conversationID uuid.UUID, cursor *time.Time, limit int) ([]*Message, error)
 	// Count messages in a conversation for title generation
 	CountByConversationID(ctx context.Context, conversationID uuid.UUID) (int, error)
+	// GetUsageByUser sums up the user's assistant messages since the given time, per model
+	GetUsageByUser(ctx context.Context, userID uuid.UUID, since time.Time) ([]*ModelUsage, error)
 } 
\ No newline at end of file
@@ -1,6 +1,7 @@
 package chat
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,7 +15,36 @@ type Model struct {
 	DisplayName       string
 	SupportsFunctions bool
 	SupportsVision    bool
-	IsActive          bool
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-} 
\ No newline at end of file
+	// SupportsEmbeddings marks embedding models, which cannot be chatted with.
+	SupportsEmbeddings bool
+	IsActive           bool
+	// Prices in USD per million tokens; nil when unknown.
+	InputPricePerMillion  *float64
+	OutputPricePerMillion *float64
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// Validate checks the model's names and prices
+func (m *Model) Validate() error {
+	if m.Name == "" || len(m.Name) > 255 {
+		return fmt.Errorf("name must be between 1 and 255 characters")
+	}
+	if m.DisplayName == "" || len(m.DisplayName) > 255 {
+		return fmt.Errorf("display_name must be between 1 and 255 characters")
+	}
+	if m.InputPricePerMillion != nil && !validPrice(*m.InputPricePerMillion) {
+		return fmt.Errorf("input_price_per_million must be between 0 and %d", maxPricePerMillion)
+	}
+	if m.OutputPricePerMillion != nil && !validPrice(*m.OutputPricePerMillion) {
+		return fmt.Errorf("output_price_per_million must be between 0 and %d", maxPricePerMillion)
+	}
+	return nil
+}
+
+// maxPricePerMillion bounds prices to what the price columns can hold.
+const maxPricePerMillion = 1000000
+
+func validPrice(price float64) bool {
+	return price >= 0 && price < maxPricePerMillion
+}
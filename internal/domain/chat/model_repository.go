@@ -12,4 +12,7 @@ type ModelRepository interface {
 	CreateModel(ctx context.Context, model *Model) (*Model, error)
 	GetModelByName(ctx context.Context, providerID uuid.UUID, name string) (*Model, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Model, error)
+	// GetAll returns every model, including inactive ones
+	GetAll(ctx context.Context) ([]*Model, error)
+	Update(ctx context.Context, model *Model) (*Model, error)
 } 
\ No newline at end of file
@@ -1,6 +1,8 @@
 package chat
 
 import (
+	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,4 +17,19 @@ type Provider struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 	Models      []*Model
-} 
\ No newline at end of file
+}
+
+// providerNamePattern matches provider names: lowercase identifiers the LLM
+// service dispatches on.
+var providerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Validate checks the provider's name and display name
+func (p *Provider) Validate() error {
+	if !providerNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("name must be a lowercase identifier of at most 50 characters")
+	}
+	if p.DisplayName == "" || len(p.DisplayName) > 255 {
+		return fmt.Errorf("display_name must be between 1 and 255 characters")
+	}
+	return nil
+}
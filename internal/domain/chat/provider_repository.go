@@ -13,6 +13,7 @@ type ProviderRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*Provider, error)
 	GetByName(ctx context.Context, name string) (*Provider, error)
 	Create(ctx context.Context, provider *Provider) (*Provider, error)
+	Update(ctx context.Context, provider *Provider) (*Provider, error)
 	// GetAvailableModelsForUser returns models with API key status for a user (optimized with JOINs)
 	GetAvailableModelsForUser(ctx context.Context, userID uuid.UUID) ([]*Provider, error)
 } 
\ No newline at end of file
@@ -7,6 +7,12 @@ import (
 )
 
 type ToolRepository interface {
+	Create(ctx context.Context, tool *Tool) (*Tool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Tool, error)
+	GetByName(ctx context.Context, name string) (*Tool, error)
+	// GetAll returns every tool, including inactive ones
+	GetAll(ctx context.Context) ([]*Tool, error)
+	Update(ctx context.Context, tool *Tool) (*Tool, error)
 	GetAvailableTools(ctx context.Context, providerID *uuid.UUID) ([]*Tool, error)
 	LogToolUsage(ctx context.Context, messageTool *MessageTool) error
 } 
\ No newline at end of file
@@ -0,0 +1,12 @@
+package chat
+
+import "github.com/google/uuid"
+
+// ModelUsage sums up the assistant messages a user received from one model.
+// Token counts and costs are only included for messages that recorded them.
+type ModelUsage struct {
+	ModelID      *uuid.UUID // Nil for messages without a model
+	MessageCount int64
+	TokenCount   int64
+	Cost         float64
+}
@@ -0,0 +1,78 @@
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MemoryCategory groups what the assistant remembers about a user.
+type MemoryCategory string
+
+const (
+	MemoryCategoryRiskTolerance MemoryCategory = "risk_tolerance"
+	MemoryCategoryMarkets       MemoryCategory = "markets"
+	MemoryCategoryTimeZone      MemoryCategory = "time_zone"
+	MemoryCategoryTradingStyle  MemoryCategory = "trading_style"
+	MemoryCategoryGoals         MemoryCategory = "goals"
+	MemoryCategoryOther         MemoryCategory = "other"
+)
+
+// MemoryCategories lists the valid memory categories.
+var MemoryCategories = []MemoryCategory{
+	MemoryCategoryRiskTolerance,
+	MemoryCategoryMarkets,
+	MemoryCategoryTimeZone,
+	MemoryCategoryTradingStyle,
+	MemoryCategoryGoals,
+	MemoryCategoryOther,
+}
+
+const (
+	// MaxMemoriesPerUser caps the number of memories kept for one user.
+	MaxMemoriesPerUser = 200
+	// MaxMemoriesInPrompt caps the number of memories added to the system prompt.
+	MaxMemoriesInPrompt = 50
+	// MaxMemoryLength caps the length in characters of a single memory.
+	MaxMemoryLength = 500
+)
+
+// UserMemory is a stable fact about a user, such as their risk tolerance or the
+// markets they trade, extracted from their conversations and recalled in later ones.
+type UserMemory struct {
+	ID                   uuid.UUID      `json:"id" db:"id"`
+	UserID               uuid.UUID      `json:"user_id" db:"user_id"`
+	Category             MemoryCategory `json:"category" db:"category"`
+	Content              string         `json:"content" db:"content"`
+	SourceConversationID *uuid.UUID     `json:"source_conversation_id" db:"source_conversation_id"` // Conversation the memory was extracted from
+	CreatedAt            time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// IsValidMemoryCategory reports whether c is a known memory category.
+func IsValidMemoryCategory(c MemoryCategory) bool {
+	for _, category := range MemoryCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the memory's category and content, trimming the content.
+func (m *UserMemory) Validate() error {
+	if !IsValidMemoryCategory(m.Category) {
+		return fmt.Errorf("invalid memory category: %s", m.Category)
+	}
+	m.Content = strings.TrimSpace(m.Content)
+	if m.Content == "" {
+		return fmt.Errorf("memory content is required")
+	}
+	if utf8.RuneCountInString(m.Content) > MaxMemoryLength {
+		return fmt.Errorf("memory content must be at most %d characters", MaxMemoryLength)
+	}
+	return nil
+}
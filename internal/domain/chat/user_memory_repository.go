@@ -0,0 +1,18 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserMemoryRepository interface {
+	Create(ctx context.Context, memory *UserMemory) (*UserMemory, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*UserMemory, error)
+	// ListByUserID returns the user's memories, most recently updated first
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*UserMemory, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// Update changes the category and content of the memory
+	Update(ctx context.Context, memory *UserMemory) (*UserMemory, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
-// UserProviderSetting stores user-specific settings for an LLM provider.
+// UserProviderSetting stores the settings of a user or an organization for an
+// LLM provider. An organization's API key serves all of its members.
 type UserProviderSetting struct {
 	ID                uuid.UUID `json:"id" db:"id"`
-	UserID            uuid.UUID `json:"user_id" db:"user_id"`
+	UserID            uuid.UUID `json:"user_id" db:"user_id"` // uuid.Nil for organization settings
+	OrganizationID    *uuid.UUID `json:"organization_id" db:"organization_id"`
 	ProviderID        uuid.UUID `json:"provider_id" db:"provider_id"`
 	EncryptedAPIKey   *string   `json:"-" db:"encrypted_api_key"` // Not exposed in JSON responses
 	APIBaseOverride   *string   `json:"api_base_override" db:"api_base_override"`
@@ -10,6 +10,12 @@ type UserProviderSettingRepository interface {
 	Create(ctx context.Context, setting *UserProviderSetting) (*UserProviderSetting, error)
 	GetByUserIDAndProviderID(ctx context.Context, userID, providerID uuid.UUID) (*UserProviderSetting, error)
 	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*UserProviderSetting, error)
+	GetByOrganizationIDAndProviderID(ctx context.Context, organizationID, providerID uuid.UUID) (*UserProviderSetting, error)
+	ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*UserProviderSetting, error)
+	// GetForUser returns the setting a user's requests to a provider run with, preferring
+	// usable keys: the given organization's if the user is a member, then the user's own,
+	// then that of another organization the user belongs to
+	GetForUser(ctx context.Context, userID, providerID uuid.UUID, organizationID *uuid.UUID) (*UserProviderSetting, error)
 	Update(ctx context.Context, setting *UserProviderSetting) (*UserProviderSetting, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 } 
\ No newline at end of file
@@ -0,0 +1,177 @@
+package document
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Chunk is a passage of a document, the unit of retrieval.
+type Chunk struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	DocumentID uuid.UUID `json:"document_id" db:"document_id"`
+	Index      int       `json:"index" db:"chunk_index"` // Position in the document, from 0
+	Content    string    `json:"content" db:"content"`
+	Page       *int      `json:"page" db:"page"`       // 1-based page of PDF documents
+	Heading    *string   `json:"heading" db:"heading"` // Nearest heading of Markdown and HTML documents
+	Embedding  []float32 `json:"-" db:"embedding"`     // Nil until the document is indexed
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// ChunkMatch is a chunk found by a similarity search.
+type ChunkMatch struct {
+	Chunk      *Chunk
+	Similarity float64 // Cosine similarity to the query, from -1 to 1
+}
+
+// ChunkOptions sizes the chunks, in characters.
+type ChunkOptions struct {
+	Size    int // Target maximum size of a chunk
+	Overlap int // Text repeated from the end of the previous chunk of the same section
+}
+
+// Split cuts the sections of an extracted document into chunks. Chunks never
+// span sections, so their page and heading stay accurate. Text is split at
+// paragraph breaks where possible, then at sentence ends, then between words.
+func Split(sections []Section, opts ChunkOptions) []*Chunk {
+	if opts.Overlap >= opts.Size/2 {
+		opts.Overlap = opts.Size / 4
+	}
+
+	var chunks []*Chunk
+	for _, section := range sections {
+		var current strings.Builder
+		fresh := false // Whether current holds more than the overlap
+		flush := func() {
+			content := strings.TrimSpace(current.String())
+			current.Reset()
+			if !fresh || content == "" {
+				return
+			}
+			chunks = append(chunks, &Chunk{
+				Index:   len(chunks),
+				Content: content,
+				Page:    section.Page,
+				Heading: section.Heading,
+			})
+			current.WriteString(overlapTail(content, opts.Overlap))
+			fresh = false
+		}
+
+		for _, piece := range pieces(section.Text, opts.Size) {
+			if fresh && current.Len()+len(piece)+2 > opts.Size {
+				flush()
+			}
+			if current.Len() > 0 {
+				current.WriteString("\n\n")
+			}
+			current.WriteString(piece)
+			fresh = true
+		}
+		flush()
+	}
+	return chunks
+}
+
+// pieces splits text into paragraphs no longer than size, breaking long
+// paragraphs at sentence ends and, failing that, between words.
+func pieces(text string, size int) []string {
+	var result []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		if len(paragraph) <= size {
+			result = append(result, paragraph)
+			continue
+		}
+		var current strings.Builder
+		for _, sentence := range splitSentences(paragraph) {
+			for _, part := range splitWords(sentence, size) {
+				if current.Len() > 0 && current.Len()+len(part)+1 > size {
+					result = append(result, current.String())
+					current.Reset()
+				}
+				if current.Len() > 0 {
+					current.WriteByte(' ')
+				}
+				current.WriteString(part)
+			}
+		}
+		if current.Len() > 0 {
+			result = append(result, current.String())
+		}
+	}
+	return result
+}
+
+// splitSentences splits text after sentence-ending punctuation followed by a space.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i := 0; i < len(text)-1; i++ {
+		if (text[i] == '.' || text[i] == '!' || text[i] == '?') && unicode.IsSpace(rune(text[i+1])) {
+			sentences = append(sentences, strings.TrimSpace(text[start:i+1]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
+
+// splitWords breaks text longer than size between words, and words longer
+// than size wherever needed.
+func splitWords(text string, size int) []string {
+	if len(text) <= size {
+		return []string{text}
+	}
+	var parts []string
+	var current strings.Builder
+	for _, word := range strings.Fields(text) {
+		for len(word) > size {
+			cut := validCut(word, size)
+			parts = append(parts, word[:cut])
+			word = word[cut:]
+		}
+		if current.Len() > 0 && current.Len()+len(word)+1 > size {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteByte(' ')
+		}
+		current.WriteString(word)
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// overlapTail returns about the last n characters of content, starting at a word.
+func overlapTail(content string, n int) string {
+	if n <= 0 || len(content) <= n {
+		return ""
+	}
+	tail := content[len(content)-n:]
+	if i := strings.IndexFunc(tail, unicode.IsSpace); i >= 0 {
+		tail = tail[i:]
+	}
+	return strings.TrimSpace(strings.ToValidUTF8(tail, ""))
+}
+
+// validCut returns the largest index up to n that does not split a UTF-8 sequence.
+func validCut(s string, n int) int {
+	for n > 0 && n < len(s) && s[n]&0xC0 == 0x80 {
+		n--
+	}
+	if n == 0 {
+		n = 1
+	}
+	return n
+}
@@ -0,0 +1,48 @@
+package document
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format is the file format a document was extracted from.
+type Format string
+
+const (
+	FormatPDF      Format = "pdf"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatCSV      Format = "csv"
+	FormatText     Format = "text"
+)
+
+// Status tracks the indexing of a document.
+type Status string
+
+const (
+	StatusProcessing Status = "processing" // Text extracted, chunks not embedded yet
+	StatusReady      Status = "ready"      // Chunks embedded and searchable
+	StatusFailed     Status = "failed"     // Embedding failed; see Error
+)
+
+// MaxTitleLength caps the length of a document title.
+const MaxTitleLength = 255
+
+// Document is an uploaded file whose text is split into chunks that are
+// embedded for retrieval. The original file is not kept.
+type Document struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	Title          string    `json:"title" db:"title"`
+	Filename       string    `json:"filename" db:"filename"`
+	Format         Format    `json:"format" db:"format"`
+	Size           int64     `json:"size" db:"size"`                 // Size of the uploaded file in bytes
+	ContentHash    string    `json:"content_hash" db:"content_hash"` // SHA-256 of the extracted text
+	Status         Status    `json:"status" db:"status"`
+	Error          *string   `json:"error" db:"error"`
+	ChunkCount     int       `json:"chunk_count" db:"chunk_count"`
+	EmbeddingModel *string   `json:"embedding_model" db:"embedding_model"` // Model the chunks were embedded with, once ready
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,237 @@
+package document
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Section is a part of a document's text with its location: a page of a PDF
+// or the text under a heading of a Markdown or HTML document.
+type Section struct {
+	Page    *int
+	Heading *string
+	Text    string // Paragraphs separated by blank lines
+}
+
+// Extraction is the text extracted from an uploaded file.
+type Extraction struct {
+	Format   Format
+	Title    string // Title found in the file, if any
+	Sections []Section
+}
+
+// Text returns the extracted text of all sections.
+func (e *Extraction) Text() string {
+	parts := make([]string, 0, len(e.Sections))
+	for _, s := range e.Sections {
+		parts = append(parts, s.Text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// DetectFormat determines the format of a file from its content, falling back
+// to its name and declared content type.
+func DetectFormat(filename, contentType string, data []byte) (Format, error) {
+	if bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF-")) {
+		return FormatPDF, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".pdf":
+		return "", fmt.Errorf("file is not a valid PDF")
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".html", ".htm", ".xhtml":
+		return FormatHTML, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".txt", ".text":
+		return FormatText, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/markdown", "text/x-markdown":
+		return FormatMarkdown, nil
+	case "text/html", "application/xhtml+xml":
+		return FormatHTML, nil
+	case "text/csv":
+		return FormatCSV, nil
+	case "text/plain":
+		return FormatText, nil
+	}
+
+	head := strings.ToLower(string(data[:min(len(data), 512)]))
+	if strings.Contains(head, "<html") || strings.Contains(head, "<!doctype html") {
+		return FormatHTML, nil
+	}
+	if utf8.Valid(data) {
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("unsupported file type; upload a PDF, Markdown, HTML, CSV or text file")
+}
+
+// Extract extracts the text of a file in the given format.
+func Extract(format Format, data []byte) (*Extraction, error) {
+	var extraction *Extraction
+	var err error
+	switch format {
+	case FormatPDF:
+		extraction, err = extractPDF(data)
+	case FormatHTML:
+		extraction, err = extractHTML(data)
+	case FormatMarkdown:
+		extraction, err = extractMarkdown(decodeText(data))
+	case FormatCSV:
+		extraction, err = extractCSV(decodeText(data))
+	case FormatText:
+		extraction = &Extraction{Format: FormatText, Sections: []Section{{Text: normalizeText(decodeText(data))}}}
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []Section
+	for _, s := range extraction.Sections {
+		if s.Text = strings.TrimSpace(s.Text); s.Text != "" {
+			sections = append(sections, s)
+		}
+	}
+	if len(sections) == 0 {
+		if format == FormatPDF {
+			return nil, fmt.Errorf("no text found in the PDF; scanned documents are not supported")
+		}
+		return nil, fmt.Errorf("no text found in the file")
+	}
+	extraction.Sections = sections
+	return extraction, nil
+}
+
+var (
+	markdownHeading = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+	// Setext headings are underlined with = or -.
+	markdownUnderline = regexp.MustCompile(`^(=+|-+)\s*$`)
+)
+
+// extractMarkdown splits a Markdown document at its headings. The text keeps
+// its Markdown syntax, which embeds well and reads well when cited.
+func extractMarkdown(text string) (*Extraction, error) {
+	extraction := &Extraction{Format: FormatMarkdown}
+	var heading *string
+	var body []string
+	inFence := false
+	flush := func() {
+		extraction.Sections = append(extraction.Sections, Section{Heading: heading, Text: normalizeText(strings.Join(body, "\n"))})
+		body = nil
+	}
+
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			title := ""
+			if m := markdownHeading.FindStringSubmatch(trimmed); m != nil {
+				title = m[1]
+			} else if trimmed != "" && i+1 < len(lines) && markdownUnderline.MatchString(lines[i+1]) && (len(body) == 0 || strings.TrimSpace(body[len(body)-1]) == "") {
+				title = trimmed
+				i++
+			}
+			if title != "" {
+				flush()
+				heading = &title
+				if extraction.Title == "" {
+					extraction.Title = title
+				}
+				continue
+			}
+		}
+		body = append(body, line)
+	}
+	flush()
+	return extraction, nil
+}
+
+// extractCSV turns each row into a line of "column: value" pairs, so that a
+// chunk of rows is understandable without the header.
+func extractCSV(text string) (*Extraction, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	var rows []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		pairs := make([]string, 0, len(record))
+		for i, value := range record {
+			if value = strings.TrimSpace(value); value == "" {
+				continue
+			}
+			column := fmt.Sprintf("column %d", i+1)
+			if i < len(header) && strings.TrimSpace(header[i]) != "" {
+				column = strings.TrimSpace(header[i])
+			}
+			pairs = append(pairs, column+": "+value)
+		}
+		if len(pairs) > 0 {
+			rows = append(rows, strings.Join(pairs, "; "))
+		}
+	}
+	// Rows are separate paragraphs, so chunks hold whole rows.
+	return &Extraction{Format: FormatCSV, Sections: []Section{{Text: strings.Join(rows, "\n\n")}}}, nil
+}
+
+// decodeText converts file content to valid UTF-8, dropping a byte order mark
+// and decoding Latin-1 when the content is not UTF-8.
+func decodeText(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+var (
+	horizontalSpace = regexp.MustCompile(`[ \t\f\v\r]+`)
+	blankLines      = regexp.MustCompile(`\n\s*\n\s*`)
+)
+
+// normalizeText collapses runs of spaces and blank lines, keeping paragraph breaks.
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = horizontalSpace.ReplaceAllString(text, " ")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
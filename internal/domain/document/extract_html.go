@@ -0,0 +1,107 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skippedElements hold no readable text.
+var skippedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Svg: true, atom.Canvas: true, atom.Iframe: true, atom.Object: true,
+	atom.Nav: true, atom.Footer: true, atom.Form: true, atom.Button: true,
+}
+
+// blockElements end a paragraph.
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.Header: true, atom.Aside: true, atom.Blockquote: true, atom.Pre: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Table: true, atom.Tr: true, atom.Figure: true, atom.Figcaption: true,
+	atom.Hr: true, atom.Address: true, atom.Details: true, atom.Summary: true,
+}
+
+// extractHTML extracts the visible text of an HTML document, split at its headings.
+func extractHTML(data []byte) (*Extraction, error) {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTML: %w", err)
+	}
+
+	extraction := &Extraction{Format: FormatHTML}
+	var heading *string
+	var text strings.Builder
+	flush := func() {
+		extraction.Sections = append(extraction.Sections, Section{Heading: heading, Text: normalizeText(text.String())})
+		text.Reset()
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			text.WriteString(n.Data)
+			return
+		case html.ElementNode:
+			if skippedElements[n.DataAtom] {
+				return
+			}
+			switch n.DataAtom {
+			case atom.Title:
+				if extraction.Title == "" {
+					extraction.Title = strings.Join(strings.Fields(nodeText(n)), " ")
+				}
+				return
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				title := strings.Join(strings.Fields(nodeText(n)), " ")
+				if title != "" {
+					flush()
+					heading = &title
+					if extraction.Title == "" {
+						extraction.Title = title
+					}
+				}
+				return
+			case atom.Br:
+				text.WriteString("\n")
+				return
+			case atom.Td, atom.Th:
+				text.WriteString(" ")
+			}
+		}
+
+		block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+		if block {
+			text.WriteString("\n\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if block {
+			text.WriteString("\n\n")
+		}
+	}
+	walk(root)
+	flush()
+	return extraction, nil
+}
+
+// nodeText returns the text content of a node.
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
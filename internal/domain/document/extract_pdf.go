@@ -0,0 +1,911 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// The PDF reader below extracts text only. It scans the file for objects instead
+// of trusting the cross-reference table, which makes it tolerant of damaged and
+// incrementally updated files, and reads objects packed in object streams.
+// Encrypted files and scanned pages are not supported.
+
+const (
+	// maxPDFStreamSize caps the decompressed size of a single stream.
+	maxPDFStreamSize = 64 << 20
+	// maxPDFDepth caps the nesting of values, page trees and form XObjects.
+	maxPDFDepth = 64
+)
+
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfDict    map[pdfName]any
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		data []byte
+	}
+)
+
+var (
+	pdfObjectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	pdfEncrypt      = regexp.MustCompile(`/Encrypt\s*(\d+\s+\d+\s+R|<<)`)
+	pdfInfo         = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R`)
+)
+
+// extractPDF extracts the text of each page of a PDF into its own section.
+func extractPDF(data []byte) (extraction *Extraction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			extraction, err = nil, fmt.Errorf("invalid PDF")
+		}
+	}()
+
+	if pdfEncrypt.Match(data) {
+		return nil, fmt.Errorf("encrypted PDFs are not supported")
+	}
+	file := parsePDF(data)
+	pages := file.pages()
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("invalid PDF: no pages found")
+	}
+
+	extraction = &Extraction{Format: FormatPDF, Title: file.title(data)}
+	for i, page := range pages {
+		number := i + 1
+		var text strings.Builder
+		file.runContent(file.pageContent(page.dict), page.resources, &text, 0)
+		extraction.Sections = append(extraction.Sections, Section{Page: &number, Text: normalizeText(text.String())})
+	}
+	return extraction, nil
+}
+
+// --- Lexer ---
+
+type pdfLexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func isPDFSpace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token reads the next token: a number, string, name, or a keyword for
+// operators and delimiters. Composite values are assembled by complete.
+func (l *pdfLexer) token() (any, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+	c := l.data[l.pos]
+	switch c {
+	case '/':
+		l.pos++
+		return pdfName(l.word()), true
+	case '(':
+		return l.literalString(), true
+	case '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return pdfKeyword("<<"), true
+		}
+		return l.hexString(), true
+	case '>':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return pdfKeyword(">>"), true
+		}
+		l.pos++
+		return pdfKeyword(">"), true
+	case '[', ']', '{', '}', ')':
+		l.pos++
+		return pdfKeyword(string(c)), true
+	}
+
+	word := l.word()
+	if first := word[0]; first >= '0' && first <= '9' || first == '-' || first == '+' || first == '.' {
+		if n, err := strconv.ParseFloat(word, 64); err == nil {
+			return n, true
+		}
+	}
+	return pdfKeyword(word), true
+}
+
+// word reads up to the next space or delimiter, decoding #xx escapes of names.
+func (l *pdfLexer) word() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	if start == l.pos && l.pos < len(l.data) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if !strings.Contains(word, "#") {
+		return word
+	}
+	var b strings.Builder
+	for i := 0; i < len(word); i++ {
+		if word[i] == '#' && i+2 < len(word) {
+			if v, err := strconv.ParseUint(word[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(word[i])
+	}
+	return b.String()
+}
+
+func (l *pdfLexer) literalString() []byte {
+	l.pos++ // (
+	var out []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return out
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return out
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func (l *pdfLexer) hexString() []byte {
+	l.pos++ // <
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F' {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++ // >
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	hex.Decode(out, digits)
+	return out
+}
+
+// value reads a complete value.
+func (l *pdfLexer) value() (any, bool) {
+	tok, ok := l.token()
+	if !ok {
+		return nil, false
+	}
+	return l.complete(tok), true
+}
+
+// complete assembles the value starting with tok: dictionaries, arrays and
+// indirect references span several tokens.
+func (l *pdfLexer) complete(tok any) any {
+	switch t := tok.(type) {
+	case pdfKeyword:
+		switch t {
+		case "<<", "[":
+			if l.depth >= maxPDFDepth {
+				return nil
+			}
+			l.depth++
+			defer func() { l.depth-- }()
+		}
+		switch t {
+		case "<<":
+			dict := pdfDict{}
+			for {
+				key, ok := l.token()
+				if !ok || key == pdfKeyword(">>") {
+					return dict
+				}
+				value, ok := l.value()
+				if !ok {
+					return dict
+				}
+				if name, isName := key.(pdfName); isName {
+					dict[name] = value
+				}
+			}
+		case "[":
+			array := []any{}
+			for {
+				tok, ok := l.token()
+				if !ok || tok == pdfKeyword("]") {
+					return array
+				}
+				array = append(array, l.complete(tok))
+			}
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+	case float64:
+		if isPDFInteger(t) {
+			save := l.pos
+			if gen, ok := l.token(); ok {
+				if g, isNumber := gen.(float64); isNumber && isPDFInteger(g) {
+					if r, ok := l.token(); ok && r == pdfKeyword("R") {
+						return pdfRef{int(t), int(g)}
+					}
+				}
+			}
+			l.pos = save
+		}
+	}
+	return tok
+}
+
+func isPDFInteger(n float64) bool {
+	return n >= 0 && n < 1<<31 && n == float64(int(n))
+}
+
+// --- File structure ---
+
+type pdfFile struct {
+	objects map[int]any
+	fonts   map[pdfRef]*pdfFont
+}
+
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// parsePDF reads every object in the file. Later definitions of an object
+// replace earlier ones, as in incremental updates.
+func parsePDF(data []byte) *pdfFile {
+	file := &pdfFile{objects: make(map[int]any), fonts: make(map[pdfRef]*pdfFont)}
+	skipUntil := 0
+	for _, match := range pdfObjectHeader.FindAllSubmatchIndex(data, -1) {
+		if match[0] < skipUntil || (match[0] > 0 && !isPDFSpace(data[match[0]-1]) && !isPDFDelimiter(data[match[0]-1])) {
+			continue
+		}
+		num, err := strconv.Atoi(string(data[match[2]:match[3]]))
+		if err != nil {
+			continue
+		}
+		lex := &pdfLexer{data: data, pos: match[1]}
+		value, ok := lex.value()
+		if !ok {
+			continue
+		}
+		if dict, isDict := value.(pdfDict); isDict {
+			lex.skipSpace()
+			if bytes.HasPrefix(data[lex.pos:], []byte("stream")) {
+				stream, end := readPDFStream(data, lex.pos+len("stream"), dict)
+				value = stream
+				skipUntil = end
+			}
+		}
+		file.objects[num] = value
+	}
+
+	// Objects packed in object streams, unless defined directly.
+	nums := file.objectNumbers()
+	for _, num := range nums {
+		stream, ok := file.objects[num].(*pdfStream)
+		if !ok || stream.dict["Type"] != pdfName("ObjStm") {
+			continue
+		}
+		data, err := file.decode(stream)
+		if err != nil {
+			continue
+		}
+		count, _ := file.resolve(stream.dict["N"]).(float64)
+		first, _ := file.resolve(stream.dict["First"]).(float64)
+		header := &pdfLexer{data: data}
+		for i := 0; i < int(count); i++ {
+			numValue, ok1 := header.value()
+			offsetValue, ok2 := header.value()
+			n, isNum := numValue.(float64)
+			offset, isOffset := offsetValue.(float64)
+			if !ok1 || !ok2 || !isNum || !isOffset {
+				break
+			}
+			pos := int(first) + int(offset)
+			if _, exists := file.objects[int(n)]; exists || pos < 0 || pos >= len(data) {
+				continue
+			}
+			lex := &pdfLexer{data: data, pos: pos}
+			if value, ok := lex.value(); ok {
+				file.objects[int(n)] = value
+			}
+		}
+	}
+	return file
+}
+
+// readPDFStream reads the data of a stream starting after the stream keyword,
+// returning the stream and the offset of its end.
+func readPDFStream(data []byte, start int, dict pdfDict) (*pdfStream, int) {
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start < len(data) && data[start] == '\n' {
+		start++
+	}
+	if length, ok := dict["Length"].(float64); ok && length >= 0 && start+int(length) <= len(data) {
+		end := start + int(length)
+		rest := bytes.TrimLeft(data[end:min(end+32, len(data))], "\x00\t\n\f\r ")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return &pdfStream{dict: dict, data: data[start:end]}, end
+		}
+	}
+	end := bytes.Index(data[start:], []byte("endstream"))
+	if end < 0 {
+		return &pdfStream{dict: dict, data: data[start:]}, len(data)
+	}
+	end += start
+	body := bytes.TrimSuffix(bytes.TrimSuffix(data[start:end], []byte("\n")), []byte("\r"))
+	return &pdfStream{dict: dict, data: body}, end
+}
+
+func (f *pdfFile) objectNumbers() []int {
+	nums := make([]int, 0, len(f.objects))
+	for num := range f.objects {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// resolve follows indirect references.
+func (f *pdfFile) resolve(value any) any {
+	for i := 0; i < maxPDFDepth; i++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		value = f.objects[ref.num]
+	}
+	return nil
+}
+
+func (f *pdfFile) dict(value any) pdfDict {
+	switch v := f.resolve(value).(type) {
+	case pdfDict:
+		return v
+	case *pdfStream:
+		return v.dict
+	}
+	return nil
+}
+
+// decode applies the filters of a stream.
+func (f *pdfFile) decode(stream *pdfStream) ([]byte, error) {
+	var filters []pdfName
+	switch v := f.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []pdfName{v}
+	case []any:
+		for _, item := range v {
+			if name, ok := f.resolve(item).(pdfName); ok {
+				filters = append(filters, name)
+			}
+		}
+	}
+
+	data := stream.data
+	for _, filter := range filters {
+		switch filter {
+		case "FlateDecode", "Fl":
+			reader, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, err
+			}
+			// Keep what was inflated from truncated or corrupt streams.
+			out, err := io.ReadAll(io.LimitReader(reader, maxPDFStreamSize))
+			if err != nil && len(out) == 0 {
+				return nil, err
+			}
+			data = out
+		case "ASCIIHexDecode", "AHx":
+			data = (&pdfLexer{data: append([]byte("<"), data...)}).hexString()
+		default:
+			return nil, fmt.Errorf("unsupported filter %s", filter)
+		}
+	}
+	return data, nil
+}
+
+// pages returns the pages in order, walking the page tree from the catalog and
+// falling back to every page object in object order.
+func (f *pdfFile) pages() []pdfPage {
+	var root any
+	for _, num := range f.objectNumbers() {
+		if dict, ok := f.objects[num].(pdfDict); ok && dict["Type"] == pdfName("Catalog") && dict["Pages"] != nil {
+			root = dict["Pages"]
+		}
+	}
+
+	var pages []pdfPage
+	visited := make(map[pdfRef]bool)
+	var walk func(node any, resources pdfDict, depth int)
+	walk = func(node any, resources pdfDict, depth int) {
+		if ref, ok := node.(pdfRef); ok {
+			if visited[ref] {
+				return
+			}
+			visited[ref] = true
+		}
+		dict := f.dict(node)
+		if dict == nil || depth > maxPDFDepth {
+			return
+		}
+		if own := f.dict(dict["Resources"]); own != nil {
+			resources = own
+		}
+		kids, ok := f.resolve(dict["Kids"]).([]any)
+		if !ok {
+			if dict["Type"] == pdfName("Page") || dict["Contents"] != nil {
+				pages = append(pages, pdfPage{dict: dict, resources: resources})
+			}
+			return
+		}
+		for _, kid := range kids {
+			walk(kid, resources, depth+1)
+		}
+	}
+	if root != nil {
+		walk(root, nil, 0)
+	}
+
+	if len(pages) == 0 {
+		for _, num := range f.objectNumbers() {
+			if dict, ok := f.objects[num].(pdfDict); ok && dict["Type"] == pdfName("Page") {
+				pages = append(pages, pdfPage{dict: dict, resources: f.dict(dict["Resources"])})
+			}
+		}
+	}
+	return pages
+}
+
+// pageContent returns the decoded content streams of a page.
+func (f *pdfFile) pageContent(page pdfDict) []byte {
+	var refs []any
+	switch v := f.resolve(page["Contents"]).(type) {
+	case []any:
+		refs = v
+	case *pdfStream:
+		refs = []any{v}
+	}
+
+	var content []byte
+	for _, ref := range refs {
+		stream, ok := f.resolve(ref).(*pdfStream)
+		if !ok {
+			continue
+		}
+		if data, err := f.decode(stream); err == nil {
+			content = append(content, data...)
+			content = append(content, '\n')
+		}
+	}
+	return content
+}
+
+// title returns the title from the document information dictionary.
+func (f *pdfFile) title(data []byte) string {
+	matches := pdfInfo.FindAllSubmatch(data, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	num, err := strconv.Atoi(string(matches[len(matches)-1][1]))
+	if err != nil {
+		return ""
+	}
+	info, _ := f.resolve(f.objects[num]).(pdfDict)
+	title, _ := f.resolve(info["Title"]).([]byte)
+	return strings.Join(strings.Fields(decodePDFTextString(title)), " ")
+}
+
+// decodePDFTextString decodes a text string, which is UTF-16BE with a byte
+// order mark or single-byte.
+func decodePDFTextString(s []byte) string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		return decodeUTF16BE(s[2:])
+	}
+	var b strings.Builder
+	for _, c := range s {
+		b.WriteRune(winAnsiRune(c))
+	}
+	return b.String()
+}
+
+func decodeUTF16BE(s []byte) string {
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// winAnsiRune maps a byte of a simple font without a ToUnicode map, assuming
+// the common WinAnsi encoding.
+func winAnsiRune(c byte) rune {
+	switch c {
+	case 0x80:
+		return '€'
+	case 0x85:
+		return '…'
+	case 0x91:
+		return '‘'
+	case 0x92:
+		return '’'
+	case 0x93:
+		return '“'
+	case 0x94:
+		return '”'
+	case 0x95:
+		return '•'
+	case 0x96:
+		return '–'
+	case 0x97:
+		return '—'
+	}
+	return rune(c)
+}
+
+// --- Fonts ---
+
+// pdfFont maps the character codes of a font to text.
+type pdfFont struct {
+	toUnicode map[uint32]string
+	codeLen   int  // Bytes per character code
+	composite bool // Type0 fonts, whose codes cannot be decoded without a ToUnicode map
+}
+
+func (f *pdfFile) font(resources pdfDict, name pdfName) *pdfFont {
+	fonts := f.dict(resources["Font"])
+	ref, isRef := fonts[name].(pdfRef)
+	if cached, ok := f.fonts[ref]; isRef && ok {
+		return cached
+	}
+	dict := f.dict(fonts[name])
+	if dict == nil {
+		return nil
+	}
+
+	font := &pdfFont{composite: dict["Subtype"] == pdfName("Type0"), codeLen: 1}
+	if font.composite {
+		font.codeLen = 2
+	}
+	if stream, ok := f.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := f.decode(stream); err == nil {
+			font.parseCMap(data)
+		}
+	}
+	if isRef {
+		f.fonts[ref] = font
+	}
+	return font
+}
+
+// parseCMap reads the code space and the bfchar and bfrange mappings of a ToUnicode CMap.
+func (font *pdfFont) parseCMap(data []byte) {
+	font.toUnicode = make(map[uint32]string)
+	lex := &pdfLexer{data: data}
+	next := func() any {
+		value, ok := lex.value()
+		if !ok {
+			return pdfKeyword("end")
+		}
+		return value
+	}
+	isEnd := func(v any) bool {
+		keyword, ok := v.(pdfKeyword)
+		return ok && strings.HasPrefix(string(keyword), "end")
+	}
+
+	for {
+		tok, ok := lex.value()
+		if !ok {
+			return
+		}
+		switch tok {
+		case pdfKeyword("begincodespacerange"):
+			for {
+				low := next()
+				if isEnd(low) {
+					break
+				}
+				next()
+				if code, ok := low.([]byte); ok && len(code) > 0 && len(code) <= 4 {
+					font.codeLen = len(code)
+				}
+			}
+		case pdfKeyword("beginbfchar"):
+			for {
+				src := next()
+				if isEnd(src) {
+					break
+				}
+				code, ok1 := src.([]byte)
+				dst, ok2 := next().([]byte)
+				if ok1 && ok2 && len(code) <= 4 {
+					font.toUnicode[codeValue(code)] = decodeUTF16BE(dst)
+				}
+			}
+		case pdfKeyword("beginbfrange"):
+			for {
+				lowValue := next()
+				if isEnd(lowValue) {
+					break
+				}
+				low, ok1 := lowValue.([]byte)
+				high, ok2 := next().([]byte)
+				dst := next()
+				if !ok1 || !ok2 || len(low) > 4 || len(high) > 4 {
+					continue
+				}
+				start, end := codeValue(low), codeValue(high)
+				if end < start || end-start > 0xFFFF {
+					continue
+				}
+				for code := start; code <= end; code++ {
+					offset := int(code - start)
+					switch d := dst.(type) {
+					case []byte:
+						// The last byte of the destination is incremented.
+						text := append([]byte(nil), d...)
+						if len(text) > 0 {
+							text[len(text)-1] += byte(offset)
+						}
+						font.toUnicode[code] = decodeUTF16BE(text)
+					case []any:
+						if offset < len(d) {
+							if text, ok := d[offset].([]byte); ok {
+								font.toUnicode[code] = decodeUTF16BE(text)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func codeValue(code []byte) uint32 {
+	var v uint32
+	for _, c := range code {
+		v = v<<8 | uint32(c)
+	}
+	return v
+}
+
+// decode converts a shown string to text.
+func (font *pdfFont) decode(s []byte) string {
+	var b strings.Builder
+	if font == nil {
+		for _, c := range s {
+			b.WriteRune(winAnsiRune(c))
+		}
+		return b.String()
+	}
+	for i := 0; i+font.codeLen <= len(s); i += font.codeLen {
+		code := codeValue(s[i : i+font.codeLen])
+		if text, ok := font.toUnicode[code]; ok {
+			b.WriteString(text)
+		} else if !font.composite {
+			b.WriteRune(winAnsiRune(byte(code)))
+		}
+	}
+	return b.String()
+}
+
+// --- Content streams ---
+
+// runContent interprets the text operators of a content stream, writing the
+// text shown with line breaks where the text moves to a new line.
+func (f *pdfFile) runContent(data []byte, resources pdfDict, out *strings.Builder, depth int) {
+	lex := &pdfLexer{data: data}
+	var font *pdfFont
+	var operands []any
+	var lastY *float64
+
+	lastByte := func() byte {
+		s := out.String()
+		if s == "" {
+			return '\n'
+		}
+		return s[len(s)-1]
+	}
+	newline := func() {
+		if lastByte() != '\n' {
+			out.WriteByte('\n')
+		}
+	}
+	space := func() {
+		if c := lastByte(); c != '\n' && c != ' ' {
+			out.WriteByte(' ')
+		}
+	}
+	show := func(value any) {
+		if s, ok := value.([]byte); ok {
+			for _, r := range font.decode(s) {
+				if r >= 0x20 || r == '\t' {
+					out.WriteRune(r)
+				}
+			}
+		}
+	}
+	operand := func(i int) any {
+		if i < len(operands) {
+			return operands[i]
+		}
+		return nil
+	}
+	number := func(i int) float64 {
+		n, _ := operand(i).(float64)
+		return n
+	}
+
+	for {
+		tok, ok := lex.token()
+		if !ok {
+			return
+		}
+		op, isOperator := tok.(pdfKeyword)
+		if !isOperator || op == "[" || op == "<<" || op == "true" || op == "false" || op == "null" {
+			operands = append(operands, lex.complete(tok))
+			continue
+		}
+
+		switch op {
+		case "BT":
+			lastY = nil
+		case "ET":
+			newline()
+		case "Tf":
+			if name, ok := operand(len(operands) - 2).(pdfName); ok {
+				font = f.font(resources, name)
+			}
+		case "Tj":
+			show(operand(len(operands) - 1))
+		case "'":
+			newline()
+			show(operand(len(operands) - 1))
+		case "\"":
+			newline()
+			show(operand(2))
+		case "TJ":
+			items, _ := operand(len(operands) - 1).([]any)
+			for _, item := range items {
+				switch v := item.(type) {
+				case []byte:
+					show(v)
+				case float64:
+					// Large negative adjustments separate words.
+					if v < -200 {
+						space()
+					}
+				}
+			}
+		case "Td", "TD":
+			if number(1) != 0 {
+				newline()
+			} else if number(0) != 0 {
+				space()
+			}
+		case "T*":
+			newline()
+		case "Tm":
+			y := number(5)
+			if lastY != nil && *lastY != y {
+				newline()
+			} else {
+				space()
+			}
+			lastY = &y
+		case "Do":
+			if depth >= maxPDFDepth/8 {
+				break
+			}
+			name, _ := operand(0).(pdfName)
+			stream, ok := f.resolve(f.dict(resources["XObject"])[name]).(*pdfStream)
+			if !ok || stream.dict["Subtype"] != pdfName("Form") {
+				break
+			}
+			if content, err := f.decode(stream); err == nil {
+				formResources := f.dict(stream.dict["Resources"])
+				if formResources == nil {
+					formResources = resources
+				}
+				newline()
+				f.runContent(content, formResources, out, depth+1)
+				newline()
+			}
+		case "ID":
+			// Skip the binary data of an inline image, up to EI.
+			lex.pos++
+			for lex.pos < len(data) {
+				i := bytes.Index(data[lex.pos:], []byte("EI"))
+				if i < 0 {
+					lex.pos = len(data)
+					break
+				}
+				end := lex.pos + i
+				lex.pos = end + 2
+				if end > 0 && isPDFSpace(data[end-1]) && (lex.pos == len(data) || isPDFSpace(data[lex.pos])) {
+					break
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
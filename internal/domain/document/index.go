@@ -0,0 +1,18 @@
+package document
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// VectorIndex finds the chunks nearest to a query embedding by cosine similarity.
+// Chunks are stored by the ChunkRepository; an index may keep its own copy.
+type VectorIndex interface {
+	// Add indexes embedded chunks
+	Add(ctx context.Context, chunks []*Chunk) error
+	// Remove drops the chunks of a document
+	Remove(ctx context.Context, documentID uuid.UUID) error
+	// Search returns up to limit chunks of the given documents, most similar first
+	Search(ctx context.Context, query []float32, documentIDs []uuid.UUID, limit int) ([]*ChunkMatch, error)
+}
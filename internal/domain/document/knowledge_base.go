@@ -0,0 +1,54 @@
+package document
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role is what a user may do with a knowledge base.
+type Role string
+
+const (
+	RoleViewer Role = "viewer" // Search the documents and attach the knowledge base to conversations
+	RoleEditor Role = "editor" // Also add and remove documents and re-index them
+	RoleOwner  Role = "owner"  // Also rename, share and delete; held by the creator only
+)
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// IsValidMemberRole reports whether the role can be given to a member.
+func IsValidMemberRole(role Role) bool {
+	return role == RoleViewer || role == RoleEditor
+}
+
+// Allows reports whether the role grants everything the other role does. The
+// empty role, for users without access, allows nothing.
+func (r Role) Allows(other Role) bool {
+	return roleRanks[r] > 0 && roleRanks[r] >= roleRanks[other]
+}
+
+// MaxKnowledgeBaseNameLength caps the length of a knowledge base name.
+const MaxKnowledgeBaseNameLength = 255
+
+// KnowledgeBase is a named collection of documents that can be shared with
+// other users and attached to conversations, whose messages are then answered
+// from its documents.
+type KnowledgeBase struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"` // Owner
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description" db:"description"`
+	Role        Role      `json:"role" db:"role"` // Role of the user it was loaded for; empty without access
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// KnowledgeBaseMember is a user the owner shared a knowledge base with.
+type KnowledgeBaseMember struct {
+	KnowledgeBaseID uuid.UUID `json:"knowledge_base_id" db:"knowledge_base_id"`
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+	Email           string    `json:"email" db:"email"`
+	Role            Role      `json:"role" db:"role"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,68 @@
+package document
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type DocumentRepository interface {
+	Create(ctx context.Context, document *Document) (*Document, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Document, error)
+	// ListByUserID returns the user's documents, newest first; a limit of zero returns all of them
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*Document, error)
+	// ListReadyByUserID returns the user's documents embedded with the given model
+	ListReadyByUserID(ctx context.Context, userID uuid.UUID, embeddingModel string) ([]*Document, error)
+	// ListByKnowledgeBaseID returns the documents of a knowledge base by title
+	ListByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) ([]*Document, error)
+	// ListReadyByConversationID returns the documents embedded with the given model
+	// in the knowledge bases attached to a conversation that the user can access
+	ListReadyByConversationID(ctx context.Context, conversationID, userID uuid.UUID, embeddingModel string) ([]*Document, error)
+	// Update replaces the file details and indexing state of a document
+	Update(ctx context.Context, document *Document) (*Document, error)
+	// UpdateStatus records the outcome of indexing
+	UpdateStatus(ctx context.Context, id uuid.UUID, status Status, errorMessage *string, embeddingModel *string) (*Document, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ChunkRepository interface {
+	// CreateBatch inserts the chunks of a document, without embeddings
+	CreateBatch(ctx context.Context, chunks []*Chunk) error
+	ListByDocumentID(ctx context.Context, documentID uuid.UUID) ([]*Chunk, error)
+	DeleteByDocumentID(ctx context.Context, documentID uuid.UUID) error
+	SetEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) error
+	// ListEmbedded returns the embedded chunks of ready documents, ordered by ID,
+	// starting after the given ID, for loading an in-memory index in pages
+	ListEmbedded(ctx context.Context, after uuid.UUID, limit int) ([]*Chunk, error)
+	// Search returns the chunks of the given documents most similar to the
+	// query, best first. It requires the pgvector extension.
+	Search(ctx context.Context, query []float32, documentIDs []uuid.UUID, limit int) ([]*ChunkMatch, error)
+	// VectorSearchAvailable reports whether the pgvector extension is installed
+	VectorSearchAvailable(ctx context.Context) (bool, error)
+}
+
+type KnowledgeBaseRepository interface {
+	Create(ctx context.Context, knowledgeBase *KnowledgeBase) (*KnowledgeBase, error)
+	// GetByID returns the knowledge base with the role the user has in it
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*KnowledgeBase, error)
+	// ListByUserID returns the knowledge bases the user owns or is a member of, by name
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*KnowledgeBase, error)
+	// ListByConversationID returns the knowledge bases attached to a conversation,
+	// with the role the user has in each
+	ListByConversationID(ctx context.Context, conversationID, userID uuid.UUID) ([]*KnowledgeBase, error)
+	Update(ctx context.Context, knowledgeBase *KnowledgeBase) (*KnowledgeBase, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// SetMember adds a member or changes their role
+	SetMember(ctx context.Context, member *KnowledgeBaseMember) error
+	ListMembers(ctx context.Context, knowledgeBaseID uuid.UUID) ([]*KnowledgeBaseMember, error)
+	RemoveMember(ctx context.Context, knowledgeBaseID, userID uuid.UUID) error
+
+	// AddDocument adds a document; adding it twice has no effect
+	AddDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID) error
+	RemoveDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID) error
+
+	// AttachToConversation attaches the knowledge base; attaching it twice has no effect
+	AttachToConversation(ctx context.Context, knowledgeBaseID, conversationID uuid.UUID) error
+	DetachFromConversation(ctx context.Context, knowledgeBaseID, conversationID uuid.UUID) error
+}
@@ -0,0 +1,39 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SecretKind names a column holding encrypted secrets.
+type SecretKind string
+
+const (
+	SecretProviderAPIKey    SecretKind = "user_provider_settings.encrypted_api_key"
+	SecretBrokerCredentials SecretKind = "broker_connections.encrypted_credentials"
+	SecretTOTPSecret        SecretKind = "totp_secrets.encrypted_secret"
+)
+
+// SecretKinds lists every kind of encrypted secret, so all of them are
+// re-encrypted when the encryption key is rotated.
+var SecretKinds = []SecretKind{
+	SecretProviderAPIKey,
+	SecretBrokerCredentials,
+	SecretTOTPSecret,
+}
+
+// Secret is an encrypted value of the row with the given ID.
+type Secret struct {
+	ID         uuid.UUID
+	Ciphertext string
+}
+
+// SecretRepository reads and rewrites the encrypted secrets of one kind
+// without knowing what they protect.
+type SecretRepository interface {
+	// ListAfter retrieves and locks up to limit secrets of rows whose IDs come after afterID, ordered by ID
+	ListAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]*Secret, error)
+	// Update replaces the encrypted value of a row
+	Update(ctx context.Context, id uuid.UUID, ciphertext string) error
+}
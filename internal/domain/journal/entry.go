@@ -0,0 +1,147 @@
+package journal
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Side is the direction of a journaled trade.
+type Side string
+
+const (
+	SideLong  Side = "long"
+	SideShort Side = "short"
+)
+
+const (
+	// MaxTags caps the number of tags on one entry.
+	MaxTags = 20
+	// MaxTagLength caps the length of a single tag.
+	MaxTagLength = 64
+	// MaxScreenshots caps the number of screenshots attached to one entry.
+	MaxScreenshots = 10
+)
+
+// Entry is one trade in a user's journal. An entry without an exit is an open trade.
+type Entry struct {
+	ID             uuid.UUID   `json:"id" db:"id"`
+	UserID         uuid.UUID   `json:"user_id" db:"user_id"`
+	Symbol         string      `json:"symbol" db:"symbol"`
+	Side           Side        `json:"side" db:"side"`
+	Quantity       float64     `json:"quantity" db:"quantity"`
+	EntryPrice     float64     `json:"entry_price" db:"entry_price"`
+	EntryTime      time.Time   `json:"entry_time" db:"entry_time"`
+	ExitPrice      *float64    `json:"exit_price" db:"exit_price"`
+	ExitTime       *time.Time  `json:"exit_time" db:"exit_time"`
+	Fees           float64     `json:"fees" db:"fees"`
+	Setup          *string     `json:"setup" db:"setup"` // The pattern or playbook the trade followed
+	Thesis         *string     `json:"thesis" db:"thesis"`
+	Notes          *string     `json:"notes" db:"notes"` // Post-trade notes
+	Tags           []string    `json:"tags" db:"tags"`
+	ConversationID *uuid.UUID  `json:"conversation_id" db:"conversation_id"`
+	ScreenshotIDs  []uuid.UUID `json:"screenshot_ids" db:"-"` // Image artifacts
+	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// IsClosed reports whether the trade has been exited.
+func (e *Entry) IsClosed() bool {
+	return e.ExitPrice != nil && e.ExitTime != nil
+}
+
+// PnL returns the realized profit or loss net of fees, or nil for an open trade.
+func (e *Entry) PnL() *float64 {
+	if !e.IsClosed() {
+		return nil
+	}
+	pnl := (*e.ExitPrice - e.EntryPrice) * e.Quantity
+	if e.Side == SideShort {
+		pnl = -pnl
+	}
+	pnl -= e.Fees
+	return &pnl
+}
+
+// ReturnPct returns the realized P&L as a percentage of the entry notional,
+// or nil for an open trade.
+func (e *Entry) ReturnPct() *float64 {
+	pnl := e.PnL()
+	if pnl == nil {
+		return nil
+	}
+	pct := *pnl / (e.EntryPrice * e.Quantity) * 100
+	return &pct
+}
+
+// Validate checks the entry's prices, times and tags, normalizing the symbol and tags.
+func (e *Entry) Validate() error {
+	e.Symbol = strings.ToUpper(strings.TrimSpace(e.Symbol))
+	if e.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if e.Side != SideLong && e.Side != SideShort {
+		return fmt.Errorf("side must be long or short")
+	}
+	if !positive(e.Quantity) {
+		return fmt.Errorf("quantity must be positive")
+	}
+	if !positive(e.EntryPrice) {
+		return fmt.Errorf("entry_price must be positive")
+	}
+	if e.EntryTime.IsZero() {
+		return fmt.Errorf("entry_time is required")
+	}
+	if (e.ExitPrice == nil) != (e.ExitTime == nil) {
+		return fmt.Errorf("exit_price and exit_time must be given together")
+	}
+	if e.ExitPrice != nil {
+		if !positive(*e.ExitPrice) {
+			return fmt.Errorf("exit_price must be positive")
+		}
+		if e.ExitTime.Before(e.EntryTime) {
+			return fmt.Errorf("exit_time must not be before entry_time")
+		}
+	}
+	if e.Fees < 0 || math.IsNaN(e.Fees) || math.IsInf(e.Fees, 0) {
+		return fmt.Errorf("fees must not be negative")
+	}
+
+	tags, err := NormalizeTags(e.Tags)
+	if err != nil {
+		return err
+	}
+	e.Tags = tags
+	if len(e.ScreenshotIDs) > MaxScreenshots {
+		return fmt.Errorf("at most %d screenshots can be attached", MaxScreenshots)
+	}
+	return nil
+}
+
+// NormalizeTags lowercases and trims tags, dropping empty and duplicate ones.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("tags must be at most %d characters", MaxTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("at most %d tags are allowed", MaxTags)
+	}
+	return normalized, nil
+}
+
+func positive(v float64) bool {
+	return v > 0 && !math.IsInf(v, 0)
+}
@@ -0,0 +1,29 @@
+package journal
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntryFilter selects journal entries. Zero values match everything.
+type EntryFilter struct {
+	Start          *time.Time // Inclusive, on entry time
+	End            *time.Time // Exclusive, on entry time
+	Symbol         string
+	Tag            string
+	Setup          string
+	ConversationID *uuid.UUID
+}
+
+type EntryRepository interface {
+	Create(ctx context.Context, entry *Entry) (*Entry, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Entry, error)
+	// List returns the user's matching entries, newest first; a limit of zero returns all of them
+	List(ctx context.Context, userID uuid.UUID, filter EntryFilter, limit, offset int) ([]*Entry, error)
+	Update(ctx context.Context, entry *Entry) (*Entry, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetScreenshots replaces the image artifacts attached to the entry
+	SetScreenshots(ctx context.Context, entryID uuid.UUID, artifactIDs []uuid.UUID) error
+}
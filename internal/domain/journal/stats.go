@@ -0,0 +1,116 @@
+package journal
+
+import (
+	"math"
+	"sort"
+)
+
+// Stats aggregates the outcome of a set of journal entries. Only closed trades
+// count towards the P&L figures.
+type Stats struct {
+	Entries          int     `json:"entries"`
+	OpenTrades       int     `json:"open_trades"`
+	ClosedTrades     int     `json:"closed_trades"`
+	Wins             int     `json:"wins"`
+	Losses           int     `json:"losses"`
+	WinRate          float64 `json:"win_rate"`
+	NetPnL           float64 `json:"net_pnl"`
+	AveragePnL       float64 `json:"average_pnl"`
+	AverageWin       float64 `json:"average_win"`
+	AverageLoss      float64 `json:"average_loss"`
+	LargestWin       float64 `json:"largest_win"`
+	LargestLoss      float64 `json:"largest_loss"`
+	ProfitFactor     float64 `json:"profit_factor"` // Zero when there are no losing trades
+	AverageReturnPct float64 `json:"average_return_pct"`
+	TotalFees        float64 `json:"total_fees"`
+}
+
+// GroupStats are the statistics of the entries sharing a tag or setup.
+type GroupStats struct {
+	Key   string `json:"key"`
+	Stats Stats  `json:"stats"`
+}
+
+// Summarize computes the statistics of the entries.
+func Summarize(entries []*Entry) Stats {
+	var stats Stats
+	var grossProfit, grossLoss, sumReturn float64
+
+	for _, e := range entries {
+		stats.Entries++
+		stats.TotalFees += e.Fees
+		pnl := e.PnL()
+		if pnl == nil {
+			stats.OpenTrades++
+			continue
+		}
+
+		stats.ClosedTrades++
+		stats.NetPnL += *pnl
+		sumReturn += *e.ReturnPct()
+		switch {
+		case *pnl > 0:
+			stats.Wins++
+			grossProfit += *pnl
+			stats.LargestWin = math.Max(stats.LargestWin, *pnl)
+		case *pnl < 0:
+			stats.Losses++
+			grossLoss -= *pnl
+			stats.LargestLoss = math.Min(stats.LargestLoss, *pnl)
+		}
+	}
+
+	if stats.ClosedTrades > 0 {
+		stats.WinRate = float64(stats.Wins) / float64(stats.ClosedTrades)
+		stats.AveragePnL = stats.NetPnL / float64(stats.ClosedTrades)
+		stats.AverageReturnPct = sumReturn / float64(stats.ClosedTrades)
+	}
+	if stats.Wins > 0 {
+		stats.AverageWin = grossProfit / float64(stats.Wins)
+	}
+	if stats.Losses > 0 {
+		stats.AverageLoss = -grossLoss / float64(stats.Losses)
+	}
+	if grossLoss > 0 {
+		stats.ProfitFactor = grossProfit / grossLoss
+	}
+	return stats
+}
+
+// GroupByTag computes the statistics per tag. An entry with several tags counts
+// towards each of them; untagged entries are left out.
+func GroupByTag(entries []*Entry) []GroupStats {
+	return group(entries, func(e *Entry) []string { return e.Tags })
+}
+
+// GroupBySetup computes the statistics per setup; entries without a setup are left out.
+func GroupBySetup(entries []*Entry) []GroupStats {
+	return group(entries, func(e *Entry) []string {
+		if e.Setup == nil || *e.Setup == "" {
+			return nil
+		}
+		return []string{*e.Setup}
+	})
+}
+
+// group summarizes the entries per key, ordered by net P&L, best first.
+func group(entries []*Entry, keys func(*Entry) []string) []GroupStats {
+	byKey := make(map[string][]*Entry)
+	for _, e := range entries {
+		for _, key := range keys(e) {
+			byKey[key] = append(byKey[key], e)
+		}
+	}
+
+	groups := make([]GroupStats, 0, len(byKey))
+	for key, members := range byKey {
+		groups = append(groups, GroupStats{Key: key, Stats: Summarize(members)})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Stats.NetPnL != groups[j].Stats.NetPnL {
+			return groups[i].Stats.NetPnL > groups[j].Stats.NetPnL
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
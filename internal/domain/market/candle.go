@@ -0,0 +1,35 @@
+package market
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Candle represents a single OHLCV bar for a symbol and timeframe.
+type Candle struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Symbol    string    `json:"symbol" db:"symbol"`
+	Timeframe Timeframe `json:"timeframe" db:"timeframe"`
+	OpenTime  time.Time `json:"open_time" db:"open_time"`
+	Open      float64   `json:"open" db:"open"`
+	High      float64   `json:"high" db:"high"`
+	Low       float64   `json:"low" db:"low"`
+	Close     float64   `json:"close" db:"close"`
+	Volume    float64   `json:"volume" db:"volume"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// CloseTime returns the time at which the bar closes.
+func (c *Candle) CloseTime() time.Time {
+	return c.OpenTime.Add(c.Timeframe.Duration())
+}
+
+// Closes extracts the close prices of a candle series.
+func Closes(candles []*Candle) []float64 {
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+	return closes
+}
@@ -0,0 +1,17 @@
+package market
+
+import (
+	"context"
+	"time"
+)
+
+type CandleRepository interface {
+	// Upsert inserts a candle or overwrites the existing bar with the same symbol, timeframe and open time
+	Upsert(ctx context.Context, candle *Candle) (*Candle, error)
+
+	// GetRange returns candles with open_time in [from, to) in ascending order, capped at limit rows
+	GetRange(ctx context.Context, symbol string, timeframe Timeframe, from, to time.Time, limit int) ([]*Candle, error)
+
+	// GetLatest returns the most recent candles in ascending order
+	GetLatest(ctx context.Context, symbol string, timeframe Timeframe, limit int) ([]*Candle, error)
+}
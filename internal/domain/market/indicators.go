@@ -0,0 +1,122 @@
+package market
+
+import "math"
+
+// The indicator functions below return a series aligned with their input.
+// Values that cannot be computed yet (warm-up period) are NaN. Every output
+// at index i depends only on inputs[:i+1], so they are safe to use in
+// bar-by-bar simulations without lookahead.
+
+// SMA computes the simple moving average over period values.
+func SMA(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	if period <= 0 {
+		return out
+	}
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// EMA computes the exponential moving average, seeded with the SMA of the first period values.
+func EMA(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	if period <= 0 || len(values) < period {
+		return out
+	}
+	k := 2.0 / float64(period+1)
+	var seed float64
+	for i := 0; i < period; i++ {
+		seed += values[i]
+	}
+	out[period-1] = seed / float64(period)
+	for i := period; i < len(values); i++ {
+		out[i] = values[i]*k + out[i-1]*(1-k)
+	}
+	return out
+}
+
+// RSI computes Wilder's relative strength index.
+func RSI(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	if period <= 0 || len(values) <= period {
+		return out
+	}
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	avgGain := gain / float64(period)
+	avgLoss := loss / float64(period)
+	out[period] = rsiFromAverages(avgGain, avgLoss)
+	for i := period + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		var g, l float64
+		if change > 0 {
+			g = change
+		} else {
+			l = -change
+		}
+		avgGain = (avgGain*float64(period-1) + g) / float64(period)
+		avgLoss = (avgLoss*float64(period-1) + l) / float64(period)
+		out[i] = rsiFromAverages(avgGain, avgLoss)
+	}
+	return out
+}
+
+// Highest computes the rolling maximum over period values.
+func Highest(values []float64, period int) []float64 {
+	return rollingExtreme(values, period, math.Max)
+}
+
+// Lowest computes the rolling minimum over period values.
+func Lowest(values []float64, period int) []float64 {
+	return rollingExtreme(values, period, math.Min)
+}
+
+func rollingExtreme(values []float64, period int, pick func(a, b float64) float64) []float64 {
+	out := nanSeries(len(values))
+	if period <= 0 {
+		return out
+	}
+	for i := period - 1; i < len(values); i++ {
+		v := values[i-period+1]
+		for _, w := range values[i-period+2 : i+1] {
+			v = pick(v, w)
+		}
+		out[i] = v
+	}
+	return out
+}
+
+func rsiFromAverages(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	rs := avgGain / avgLoss
+	return 100 - 100/(1+rs)
+}
+
+func nanSeries(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package market
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timeframe is the bar interval of a candle series.
+type Timeframe string
+
+const (
+	Timeframe1m  Timeframe = "1m"
+	Timeframe5m  Timeframe = "5m"
+	Timeframe15m Timeframe = "15m"
+	Timeframe30m Timeframe = "30m"
+	Timeframe1h  Timeframe = "1h"
+	Timeframe4h  Timeframe = "4h"
+	Timeframe1d  Timeframe = "1d"
+	Timeframe1w  Timeframe = "1w"
+)
+
+var timeframeDurations = map[Timeframe]time.Duration{
+	Timeframe1m:  time.Minute,
+	Timeframe5m:  5 * time.Minute,
+	Timeframe15m: 15 * time.Minute,
+	Timeframe30m: 30 * time.Minute,
+	Timeframe1h:  time.Hour,
+	Timeframe4h:  4 * time.Hour,
+	Timeframe1d:  24 * time.Hour,
+	Timeframe1w:  7 * 24 * time.Hour,
+}
+
+// ParseTimeframe validates a timeframe string.
+func ParseTimeframe(s string) (Timeframe, error) {
+	tf := Timeframe(s)
+	if _, ok := timeframeDurations[tf]; !ok {
+		return "", fmt.Errorf("unsupported timeframe: %s", s)
+	}
+	return tf, nil
+}
+
+// Duration returns the length of a single bar.
+func (tf Timeframe) Duration() time.Duration {
+	return timeframeDurations[tf]
+}
+
+// BarsPerYear returns the number of bars in a calendar year, used to annualize returns.
+func (tf Timeframe) BarsPerYear() float64 {
+	d := tf.Duration()
+	if d == 0 {
+		return 0
+	}
+	return float64(365*24*time.Hour) / float64(d)
+}
@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"time"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// Type is the kind of event a notification reports.
+type Type string
+
+const (
+	TypeAlert Type = "alert"
+)
+
+// Notification is an entry in a user's in-app notification feed.
+type Notification struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	UserID    uuid.UUID    `json:"user_id" db:"user_id"`
+	Type      Type         `json:"type" db:"type"`
+	Title     string       `json:"title" db:"title"`
+	Body      string       `json:"body" db:"body"`
+	Data      shared.JSONB `json:"data" db:"data"` // References to the source, e.g. the alert rule and event
+	ReadAt    *time.Time   `json:"read_at" db:"read_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *Notification) (*Notification, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Notification, error)
+	// GetByUserID returns the newest notifications first
+	GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,71 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role is what a member may do in an organization.
+type Role string
+
+const (
+	RoleMember Role = "member" // Use the organization's provider keys and its shared conversations
+	RoleAdmin  Role = "admin"  // Also invite and remove members, manage provider keys and every organization conversation
+	RoleOwner  Role = "owner"  // Also rename and delete the organization and manage admins and owners
+)
+
+var roleRanks = map[Role]int{RoleMember: 1, RoleAdmin: 2, RoleOwner: 3}
+
+// IsValidRole reports whether the role can be given to a member.
+func IsValidRole(role Role) bool {
+	return roleRanks[role] > 0
+}
+
+// Allows reports whether the role grants everything the other role does. The
+// empty role, for users who are not members, allows nothing.
+func (r Role) Allows(other Role) bool {
+	return roleRanks[r] > 0 && roleRanks[r] >= roleRanks[other]
+}
+
+// MaxNameLength caps the length of an organization name.
+const MaxNameLength = 255
+
+// Organization is a team workspace. Its members share its provider API keys
+// and the conversations that belong to it.
+type Organization struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Role      Role      `json:"role" db:"role"` // Role of the user it was loaded for; empty for non-members
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Member is a user who belongs to an organization.
+type Member struct {
+	OrganizationID uuid.UUID `json:"organization_id" db:"organization_id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	Email          string    `json:"email" db:"email"`
+	Role           Role      `json:"role" db:"role"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// Invitation is a pending invitation to join an organization. It is accepted
+// by following the magic link emailed to the invited user, and it expires
+// with that link.
+type Invitation struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID uuid.UUID  `json:"organization_id" db:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	Email          string     `json:"email" db:"email"`
+	Role           Role       `json:"role" db:"role"`
+	InvitedBy      *uuid.UUID `json:"invited_by" db:"invited_by"` // Nil once the inviting user is deleted
+	MagicLinkID    uuid.UUID  `json:"magic_link_id" db:"magic_link_id"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsExpired checks if the invitation's magic link has expired
+func (i *Invitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *Organization) (*Organization, error)
+	// GetByID returns the organization with the role the user has in it
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*Organization, error)
+	// ListByUserID returns the organizations the user is a member of, by name
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*Organization, error)
+	Update(ctx context.Context, organization *Organization) (*Organization, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// SetMember adds a member or changes their role
+	SetMember(ctx context.Context, member *Member) error
+	// ListMembers returns the members of an organization, oldest first
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]*Member, error)
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+}
+
+type InvitationRepository interface {
+	// Create adds an invitation, replacing an earlier one of the same user to the same organization
+	Create(ctx context.Context, invitation *Invitation) (*Invitation, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	// GetByMagicLinkID returns the invitation sent with a magic link
+	GetByMagicLinkID(ctx context.Context, magicLinkID uuid.UUID) (*Invitation, error)
+	// ListByOrganizationID returns the pending invitations of an organization, newest first
+	ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*Invitation, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,21 @@
+package paper
+
+import (
+	"time"
+	"trading-alchemist/internal/domain/market"
+
+	"github.com/google/uuid"
+)
+
+// Account is a simulated brokerage account holding cash and positions.
+type Account struct {
+	ID             uuid.UUID        `json:"id" db:"id"`
+	UserID         uuid.UUID        `json:"user_id" db:"user_id"`
+	Name           string           `json:"name" db:"name"`
+	Timeframe      market.Timeframe `json:"timeframe" db:"timeframe"` // Candles the matching engine fills against
+	InitialCash    float64          `json:"initial_cash" db:"initial_cash"`
+	Cash           float64          `json:"cash" db:"cash"`
+	CommissionRate float64          `json:"commission_rate" db:"commission_rate"` // Fraction of notional charged per fill
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,111 @@
+package paper
+
+import (
+	"errors"
+	"trading-alchemist/internal/domain/market"
+)
+
+// quantityEpsilon absorbs floating point noise when comparing quantities and cash.
+const quantityEpsilon = 1e-9
+
+var (
+	ErrInsufficientCash     = errors.New("insufficient cash")
+	ErrInsufficientPosition = errors.New("insufficient position; short selling is not supported")
+)
+
+// MatchLast reports whether the order is marketable against the last traded price
+// and, if so, the price it fills at. It is used when an order is placed.
+func MatchLast(o *Order, last float64) (float64, bool) {
+	switch o.Type {
+	case OrderTypeMarket:
+		return last, true
+	case OrderTypeLimit:
+		if (o.Side == OrderSideBuy && last <= *o.LimitPrice) || (o.Side == OrderSideSell && last >= *o.LimitPrice) {
+			return last, true
+		}
+	case OrderTypeStop:
+		if (o.Side == OrderSideBuy && last >= *o.StopPrice) || (o.Side == OrderSideSell && last <= *o.StopPrice) {
+			return last, true
+		}
+	}
+	return 0, false
+}
+
+// MatchBar reports whether a resting order fills during the bar and at what price.
+// A bar that opens through the order's price fills at the open, so gaps are not
+// filled at prices that never traded.
+func MatchBar(o *Order, bar *market.Candle) (float64, bool) {
+	switch o.Type {
+	case OrderTypeMarket:
+		return bar.Open, true
+	case OrderTypeLimit:
+		limit := *o.LimitPrice
+		if o.Side == OrderSideBuy {
+			if bar.Open <= limit {
+				return bar.Open, true
+			}
+			if bar.Low <= limit {
+				return limit, true
+			}
+		} else {
+			if bar.Open >= limit {
+				return bar.Open, true
+			}
+			if bar.High >= limit {
+				return limit, true
+			}
+		}
+	case OrderTypeStop:
+		stop := *o.StopPrice
+		if o.Side == OrderSideBuy {
+			if bar.Open >= stop {
+				return bar.Open, true
+			}
+			if bar.High >= stop {
+				return stop, true
+			}
+		} else {
+			if bar.Open <= stop {
+				return bar.Open, true
+			}
+			if bar.Low <= stop {
+				return stop, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// ApplyFill books a fill of the order at price into the account and position and
+// returns the commission charged. Accounts are long only: buys must be covered by
+// cash and sells by the held quantity. Commissions are deducted from realized P&L.
+// Nothing is modified when an error is returned.
+func ApplyFill(account *Account, position *Position, o *Order, price float64) (float64, error) {
+	notional := o.Quantity * price
+	commission := notional * account.CommissionRate
+
+	switch o.Side {
+	case OrderSideBuy:
+		cost := notional + commission
+		if cost > account.Cash+quantityEpsilon {
+			return 0, ErrInsufficientCash
+		}
+		newQuantity := position.Quantity + o.Quantity
+		position.AveragePrice = (position.Quantity*position.AveragePrice + notional) / newQuantity
+		position.Quantity = newQuantity
+		position.RealizedPnL -= commission
+		account.Cash -= cost
+	case OrderSideSell:
+		if o.Quantity > position.Quantity+quantityEpsilon {
+			return 0, ErrInsufficientPosition
+		}
+		position.RealizedPnL += o.Quantity*(price-position.AveragePrice) - commission
+		position.Quantity -= o.Quantity
+		if position.Quantity < quantityEpsilon {
+			position.Quantity = 0
+			position.AveragePrice = 0
+		}
+		account.Cash += notional - commission
+	}
+	return commission, nil
+}
@@ -0,0 +1,97 @@
+package paper
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderSide is the direction of an order.
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "buy"
+	OrderSideSell OrderSide = "sell"
+)
+
+// OrderType selects how an order is priced.
+type OrderType string
+
+const (
+	OrderTypeMarket OrderType = "market" // Fills immediately at the latest close
+	OrderTypeLimit  OrderType = "limit"  // Fills at the limit price or better
+	OrderTypeStop   OrderType = "stop"   // Becomes a market order once the stop price trades
+)
+
+// OrderStatus is the lifecycle state of an order.
+type OrderStatus string
+
+const (
+	OrderStatusOpen      OrderStatus = "open"
+	OrderStatusFilled    OrderStatus = "filled"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusRejected  OrderStatus = "rejected"
+)
+
+// Order is a simulated order. Orders are filled in full or not at all.
+type Order struct {
+	ID           uuid.UUID   `json:"id" db:"id"`
+	AccountID    uuid.UUID   `json:"account_id" db:"account_id"`
+	Symbol       string      `json:"symbol" db:"symbol"`
+	Side         OrderSide   `json:"side" db:"side"`
+	Type         OrderType   `json:"type" db:"type"`
+	Quantity     float64     `json:"quantity" db:"quantity"`
+	LimitPrice   *float64    `json:"limit_price" db:"limit_price"`
+	StopPrice    *float64    `json:"stop_price" db:"stop_price"`
+	Status       OrderStatus `json:"status" db:"status"`
+	FillPrice    *float64    `json:"fill_price" db:"fill_price"`
+	Commission   float64     `json:"commission" db:"commission"`
+	RejectReason *string     `json:"reject_reason" db:"reject_reason"`
+	FilledAt     *time.Time  `json:"filled_at" db:"filled_at"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks that the order is well formed for its type.
+func (o *Order) Validate() error {
+	if o.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if o.Side != OrderSideBuy && o.Side != OrderSideSell {
+		return fmt.Errorf("side must be buy or sell")
+	}
+	if !(o.Quantity > 0) || math.IsInf(o.Quantity, 0) {
+		return fmt.Errorf("quantity must be positive")
+	}
+
+	switch o.Type {
+	case OrderTypeMarket:
+		if o.LimitPrice != nil || o.StopPrice != nil {
+			return fmt.Errorf("market orders take no limit or stop price")
+		}
+	case OrderTypeLimit:
+		if o.LimitPrice == nil || !(*o.LimitPrice > 0) {
+			return fmt.Errorf("limit orders require a positive limit_price")
+		}
+		if o.StopPrice != nil {
+			return fmt.Errorf("limit orders take no stop price")
+		}
+	case OrderTypeStop:
+		if o.StopPrice == nil || !(*o.StopPrice > 0) {
+			return fmt.Errorf("stop orders require a positive stop_price")
+		}
+		if o.LimitPrice != nil {
+			return fmt.Errorf("stop orders take no limit price")
+		}
+	default:
+		return fmt.Errorf("type must be market, limit or stop")
+	}
+	return nil
+}
+
+// IsOpen reports whether the order can still be filled or cancelled.
+func (o *Order) IsOpen() bool {
+	return o.Status == OrderStatusOpen
+}
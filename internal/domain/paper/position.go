@@ -0,0 +1,30 @@
+package paper
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Position is the holding of one symbol in an account. Rows are kept after the
+// position is closed so realized P&L survives.
+type Position struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	AccountID    uuid.UUID `json:"account_id" db:"account_id"`
+	Symbol       string    `json:"symbol" db:"symbol"`
+	Quantity     float64   `json:"quantity" db:"quantity"`
+	AveragePrice float64   `json:"average_price" db:"average_price"`
+	RealizedPnL  float64   `json:"realized_pnl" db:"realized_pnl"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MarketValue is the value of the position at the given price.
+func (p *Position) MarketValue(price float64) float64 {
+	return p.Quantity * price
+}
+
+// UnrealizedPnL is the open profit or loss at the given price.
+func (p *Position) UnrealizedPnL(price float64) float64 {
+	return p.Quantity * (price - p.AveragePrice)
+}
@@ -0,0 +1,33 @@
+package paper
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AccountRepository interface {
+	Create(ctx context.Context, account *Account) (*Account, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Account, error)
+	// GetByIDForUpdate locks the account row until the transaction ends so fills are serialized
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Account, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Account, error)
+	UpdateCash(ctx context.Context, id uuid.UUID, cash float64) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type OrderRepository interface {
+	Create(ctx context.Context, order *Order) (*Order, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Order, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*Order, error)
+	// GetOpenByAccountID returns open orders oldest first, the order in which they are matched
+	GetOpenByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Order, error)
+	// UpdateStatus persists the status, fill and rejection fields of the order
+	UpdateStatus(ctx context.Context, order *Order) (*Order, error)
+}
+
+type PositionRepository interface {
+	Get(ctx context.Context, accountID uuid.UUID, symbol string) (*Position, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Position, error)
+	Upsert(ctx context.Context, position *Position) (*Position, error)
+}
@@ -0,0 +1,247 @@
+package portfolio
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ValuationPoint is the portfolio value at the close of one day together with the
+// external flows booked on that day.
+type ValuationPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+	Flow  float64   `json:"flow"`
+}
+
+// PriceSeries holds the daily closes of one symbol, sorted by date.
+type PriceSeries struct {
+	Dates  []time.Time
+	Closes []float64
+}
+
+// At returns the last close on or before t, and false if there is none.
+func (s *PriceSeries) At(t time.Time) (float64, bool) {
+	_, price, ok := s.Last(t)
+	return price, ok
+}
+
+// Last returns the date and close of the last candle on or before t, and false if there is none.
+func (s *PriceSeries) Last(t time.Time) (time.Time, float64, bool) {
+	if s == nil {
+		return time.Time{}, 0, false
+	}
+	i := sort.Search(len(s.Dates), func(i int) bool { return s.Dates[i].After(t) })
+	if i == 0 {
+		return time.Time{}, 0, false
+	}
+	return s.Dates[i-1], s.Closes[i-1], true
+}
+
+// BuildValuationSeries replays transactions over the given days and values the
+// portfolio at each day's close. Transactions before the first day are folded into
+// the first point. Symbols without a stored price yet are valued at their last
+// traded price. Transactions must be sorted chronologically.
+func BuildValuationSeries(txs []*Transaction, prices map[string]*PriceSeries, days []time.Time) ([]ValuationPoint, *Ledger, error) {
+	ledger := NewLedger()
+	points := make([]ValuationPoint, 0, len(days))
+
+	next := 0
+	for _, day := range days {
+		endOfDay := day.AddDate(0, 0, 1)
+		var flow float64
+		for next < len(txs) && txs[next].ExecutedAt.Before(endOfDay) {
+			f, err := ledger.Apply(txs[next])
+			if err != nil {
+				return nil, nil, err
+			}
+			flow += f
+			next++
+		}
+
+		value := ledger.Cash
+		for _, h := range ledger.OpenHoldings() {
+			price, ok := prices[h.Symbol].At(day)
+			if !ok {
+				price = h.LastTradePrice
+			}
+			value += h.Quantity * price
+		}
+		points = append(points, ValuationPoint{Date: day, Value: value, Flow: flow})
+	}
+	return points, ledger, nil
+}
+
+// DailyReturns returns the return of each day after the first, with that day's
+// flows treated as happening at the start of the day.
+func DailyReturns(points []ValuationPoint) []float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	returns := make([]float64, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		base := points[i-1].Value + points[i].Flow
+		if base <= 0 {
+			returns = append(returns, 0)
+			continue
+		}
+		returns = append(returns, points[i].Value/base-1)
+	}
+	return returns
+}
+
+// TimeWeightedReturn chains the daily returns so that the timing and size of
+// deposits and withdrawals do not affect the result.
+func TimeWeightedReturn(returns []float64) float64 {
+	growth := 1.0
+	for _, r := range returns {
+		growth *= 1 + r
+	}
+	return growth - 1
+}
+
+// Annualize converts a return over the given number of years to a yearly rate.
+func Annualize(total, years float64) float64 {
+	if years <= 0 || total <= -1 {
+		return total
+	}
+	return math.Pow(1+total, 1/years) - 1
+}
+
+// CashFlow is a dated amount from the investor's point of view: negative when money
+// goes into the portfolio, positive when it comes out.
+type CashFlow struct {
+	Date   time.Time
+	Amount float64
+}
+
+// XIRR returns the annualized internal rate of return of the cash flows, which is
+// the money-weighted return. ok is false when the flows have no solution.
+func XIRR(flows []CashFlow) (rate float64, ok bool) {
+	if len(flows) < 2 {
+		return 0, false
+	}
+	var hasIn, hasOut bool
+	for _, f := range flows {
+		hasIn = hasIn || f.Amount < 0
+		hasOut = hasOut || f.Amount > 0
+	}
+	if !hasIn || !hasOut {
+		return 0, false
+	}
+
+	start := flows[0].Date
+	for _, f := range flows {
+		if f.Date.Before(start) {
+			start = f.Date
+		}
+	}
+	years := make([]float64, len(flows))
+	for i, f := range flows {
+		years[i] = f.Date.Sub(start).Hours() / (24 * 365)
+	}
+
+	npv := func(r float64) (value, derivative float64) {
+		for i, f := range flows {
+			discount := math.Pow(1+r, years[i])
+			value += f.Amount / discount
+			derivative -= years[i] * f.Amount / (discount * (1 + r))
+		}
+		return value, derivative
+	}
+
+	// Newton's method converges quickly for ordinary flows.
+	r := 0.1
+	for i := 0; i < 50; i++ {
+		value, derivative := npv(r)
+		if math.Abs(value) < 1e-7 {
+			return r, true
+		}
+		if derivative == 0 {
+			break
+		}
+		nextRate := r - value/derivative
+		if nextRate <= -1 || math.IsNaN(nextRate) || math.IsInf(nextRate, 0) {
+			break
+		}
+		r = nextRate
+	}
+
+	// Fall back to bisection, which always converges once the root is bracketed.
+	low, high := -0.9999, 10.0
+	lowValue, _ := npv(low)
+	highValue, _ := npv(high)
+	if lowValue*highValue > 0 {
+		return 0, false
+	}
+	for i := 0; i < 200; i++ {
+		mid := (low + high) / 2
+		value, _ := npv(mid)
+		if math.Abs(value) < 1e-7 {
+			return mid, true
+		}
+		if value*lowValue < 0 {
+			high = mid
+		} else {
+			low, lowValue = mid, value
+		}
+	}
+	return (low + high) / 2, true
+}
+
+// Volatility returns the annualized sample standard deviation of daily returns.
+func Volatility(returns []float64, periodsPerYear float64) float64 {
+	_, std := meanStd(returns)
+	return std * math.Sqrt(periodsPerYear)
+}
+
+// Beta returns the sensitivity of the portfolio returns to the benchmark returns.
+// Both series must be aligned day by day.
+func Beta(returns, benchmark []float64) (float64, bool) {
+	if len(returns) != len(benchmark) || len(returns) < 2 {
+		return 0, false
+	}
+	meanP, _ := meanStd(returns)
+	meanB, _ := meanStd(benchmark)
+	var covariance, variance float64
+	for i := range returns {
+		covariance += (returns[i] - meanP) * (benchmark[i] - meanB)
+		variance += (benchmark[i] - meanB) * (benchmark[i] - meanB)
+	}
+	if variance == 0 {
+		return 0, false
+	}
+	return covariance / variance, true
+}
+
+// HistoricalVaR returns the one-day value at risk at the given confidence level as a
+// positive fraction of portfolio value, read from the empirical return distribution.
+func HistoricalVaR(returns []float64, confidence float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), returns...)
+	sort.Float64s(sorted)
+	index := int(math.Floor((1 - confidence) * float64(len(sorted))))
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return math.Max(0, -sorted[index])
+}
+
+// meanStd returns the mean and sample standard deviation of a series.
+func meanStd(values []float64) (mean, std float64) {
+	n := float64(len(values))
+	if n < 2 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / (n - 1))
+}
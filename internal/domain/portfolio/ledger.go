@@ -0,0 +1,121 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+)
+
+// quantityEpsilon absorbs floating point noise when a holding is sold down to zero.
+const quantityEpsilon = 1e-9
+
+// Holding is the position in one symbol derived from the ledger, using average cost.
+type Holding struct {
+	Symbol         string  `json:"symbol"`
+	Quantity       float64 `json:"quantity"`
+	CostBasis      float64 `json:"cost_basis"`
+	RealizedPnL    float64 `json:"realized_pnl"`
+	Dividends      float64 `json:"dividends"`
+	LastTradePrice float64 `json:"last_trade_price"` // Fallback valuation when no prices are stored
+}
+
+// AverageCost is the cost basis per unit held.
+func (h *Holding) AverageCost() float64 {
+	if h.Quantity == 0 {
+		return 0
+	}
+	return h.CostBasis / h.Quantity
+}
+
+// Ledger replays transactions into cash and holdings.
+//
+// Cash is part of the portfolio. Deposits and withdrawals are external flows; a buy
+// that costs more than the available cash is treated as funded by an implicit
+// deposit of the shortfall. This way portfolios entered as holdings only, without
+// any cash records, still get meaningful returns.
+type Ledger struct {
+	Cash     float64
+	Holdings map[string]*Holding
+	// NetContributions is the sum of all external flows, implicit deposits included.
+	NetContributions float64
+}
+
+// NewLedger creates an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{Holdings: make(map[string]*Holding)}
+}
+
+// Apply books a transaction and returns the external flow it caused: positive for
+// money put into the portfolio, negative for money taken out.
+func (l *Ledger) Apply(t *Transaction) (float64, error) {
+	var flow float64
+	switch t.Type {
+	case TransactionTypeBuy:
+		h := l.holding(t.Symbol)
+		cost := t.Quantity*t.Price + t.Fee
+		if cost > l.Cash {
+			flow = cost - l.Cash
+			l.Cash = cost
+		}
+		l.Cash -= cost
+		h.Quantity += t.Quantity
+		h.CostBasis += cost
+		h.LastTradePrice = t.Price
+	case TransactionTypeSell:
+		h := l.holding(t.Symbol)
+		if t.Quantity > h.Quantity+quantityEpsilon {
+			return 0, fmt.Errorf("selling %g %s on %s exceeds the %g held", t.Quantity, t.Symbol, t.ExecutedAt.Format("2006-01-02"), h.Quantity)
+		}
+		proceeds := t.Quantity*t.Price - t.Fee
+		released := h.AverageCost() * t.Quantity
+		h.RealizedPnL += proceeds - released
+		h.Quantity -= t.Quantity
+		h.CostBasis -= released
+		if h.Quantity < quantityEpsilon {
+			h.Quantity = 0
+			h.CostBasis = 0
+		}
+		h.LastTradePrice = t.Price
+		l.Cash += proceeds
+	case TransactionTypeDividend:
+		l.holding(t.Symbol).Dividends += t.Amount
+		l.Cash += t.Amount
+	case TransactionTypeDeposit:
+		flow = t.Amount
+		l.Cash += t.Amount
+	case TransactionTypeWithdrawal:
+		flow = -t.Amount
+		l.Cash -= t.Amount
+	case TransactionTypeFee:
+		l.Cash -= t.Amount
+	default:
+		return 0, fmt.Errorf("unknown transaction type %q", t.Type)
+	}
+	l.NetContributions += flow
+	return flow, nil
+}
+
+// OpenHoldings returns the holdings with a non-zero quantity, sorted by symbol.
+func (l *Ledger) OpenHoldings() []*Holding {
+	var open []*Holding
+	for _, h := range l.Holdings {
+		if h.Quantity > 0 {
+			open = append(open, h)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].Symbol < open[j].Symbol })
+	return open
+}
+
+func (l *Ledger) holding(symbol string) *Holding {
+	h, ok := l.Holdings[symbol]
+	if !ok {
+		h = &Holding{Symbol: symbol}
+		l.Holdings[symbol] = h
+	}
+	return h
+}
+
+// SortTransactions orders transactions chronologically, the order they are replayed in.
+func SortTransactions(txs []*Transaction) {
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].ExecutedAt.Before(txs[j].ExecutedAt) })
+}
@@ -0,0 +1,137 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Portfolio is a set of real holdings tracked through a transaction ledger.
+type Portfolio struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	UserID          uuid.UUID `json:"user_id" db:"user_id"`
+	Name            string    `json:"name" db:"name"`
+	BaseCurrency    string    `json:"base_currency" db:"base_currency"`
+	BenchmarkSymbol *string   `json:"benchmark_symbol" db:"benchmark_symbol"` // Default benchmark for beta
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MaxSymbolLength is the longest symbol that can be stored.
+const MaxSymbolLength = 32
+
+// TransactionType is the kind of ledger entry.
+type TransactionType string
+
+const (
+	TransactionTypeBuy        TransactionType = "buy"
+	TransactionTypeSell       TransactionType = "sell"
+	TransactionTypeDividend   TransactionType = "dividend"
+	TransactionTypeDeposit    TransactionType = "deposit"
+	TransactionTypeWithdrawal TransactionType = "withdrawal"
+	TransactionTypeFee        TransactionType = "fee"
+)
+
+// TransactionSource records how a transaction entered the ledger.
+type TransactionSource string
+
+const (
+	TransactionSourceManual TransactionSource = "manual"
+	TransactionSourceCSV    TransactionSource = "csv"
+)
+
+// Transaction is a single ledger entry. Buys and sells use Quantity, Price and Fee;
+// dividends, deposits, withdrawals and fees use Amount.
+type Transaction struct {
+	ID          uuid.UUID         `json:"id" db:"id"`
+	PortfolioID uuid.UUID         `json:"portfolio_id" db:"portfolio_id"`
+	Type        TransactionType   `json:"type" db:"type"`
+	Symbol      string            `json:"symbol" db:"symbol"` // Empty for cash-only transactions
+	Quantity    float64           `json:"quantity" db:"quantity"`
+	Price       float64           `json:"price" db:"price"`
+	Fee         float64           `json:"fee" db:"fee"`
+	Amount      float64           `json:"amount" db:"amount"`
+	ExecutedAt  time.Time         `json:"executed_at" db:"executed_at"`
+	Notes       *string           `json:"notes" db:"notes"`
+	Source      TransactionSource `json:"source" db:"source"`
+	ExternalID  *string           `json:"external_id" db:"external_id"` // Fingerprint of imported rows
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+}
+
+// Validate checks that the fields required by the transaction type are present.
+func (t *Transaction) Validate() error {
+	if t.ExecutedAt.IsZero() {
+		return fmt.Errorf("executed_at is required")
+	}
+	if !isFinite(t.Quantity) || !isFinite(t.Price) || !isFinite(t.Fee) || !isFinite(t.Amount) {
+		return fmt.Errorf("numbers must be finite")
+	}
+	if t.Fee < 0 {
+		return fmt.Errorf("fee must not be negative")
+	}
+	if len(t.Symbol) > MaxSymbolLength {
+		return fmt.Errorf("symbol must be at most %d characters", MaxSymbolLength)
+	}
+
+	switch t.Type {
+	case TransactionTypeBuy, TransactionTypeSell:
+		if t.Symbol == "" {
+			return fmt.Errorf("symbol is required for %s transactions", t.Type)
+		}
+		if t.Quantity <= 0 {
+			return fmt.Errorf("quantity must be positive")
+		}
+		if t.Price < 0 {
+			return fmt.Errorf("price must not be negative")
+		}
+	case TransactionTypeDividend:
+		if t.Symbol == "" {
+			return fmt.Errorf("symbol is required for dividend transactions")
+		}
+		if t.Amount <= 0 {
+			return fmt.Errorf("amount must be positive")
+		}
+	case TransactionTypeDeposit, TransactionTypeWithdrawal, TransactionTypeFee:
+		if t.Amount <= 0 {
+			return fmt.Errorf("amount must be positive")
+		}
+	default:
+		return fmt.Errorf("type must be one of buy, sell, dividend, deposit, withdrawal, fee")
+	}
+	return nil
+}
+
+// Asset is the user's classification of an instrument held in a portfolio.
+type Asset struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	PortfolioID uuid.UUID `json:"portfolio_id" db:"portfolio_id"`
+	Symbol      string    `json:"symbol" db:"symbol"`
+	Name        *string   `json:"name" db:"name"`
+	AssetClass  *string   `json:"asset_class" db:"asset_class"`
+	Sector      *string   `json:"sector" db:"sector"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the classification fits the stored column sizes.
+func (a *Asset) Validate() error {
+	if a.Symbol == "" || len(a.Symbol) > MaxSymbolLength {
+		return fmt.Errorf("symbol must be between 1 and %d characters", MaxSymbolLength)
+	}
+	if a.Name != nil && len(*a.Name) > 255 {
+		return fmt.Errorf("name must be at most 255 characters")
+	}
+	if a.AssetClass != nil && len(*a.AssetClass) > 32 {
+		return fmt.Errorf("asset_class must be at most 32 characters")
+	}
+	if a.Sector != nil && len(*a.Sector) > 64 {
+		return fmt.Errorf("sector must be at most 64 characters")
+	}
+	return nil
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,30 @@
+package portfolio
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PortfolioRepository interface {
+	Create(ctx context.Context, portfolio *Portfolio) (*Portfolio, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Portfolio, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Portfolio, error)
+	Update(ctx context.Context, portfolio *Portfolio) (*Portfolio, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type TransactionRepository interface {
+	// Create returns ErrDuplicatePortfolioTransaction if a transaction with the same external ID exists
+	Create(ctx context.Context, transaction *Transaction) (*Transaction, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	// GetByPortfolioID returns all transactions oldest first, the order in which they are replayed
+	GetByPortfolioID(ctx context.Context, portfolioID uuid.UUID) ([]*Transaction, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type AssetRepository interface {
+	// Upsert creates or updates the classification; nil fields keep their stored value
+	Upsert(ctx context.Context, asset *Asset) (*Asset, error)
+	GetByPortfolioID(ctx context.Context, portfolioID uuid.UUID) ([]*Asset, error)
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit allows Requests requests per Window, in bursts of up to Requests. A
+// limit without requests or window does not throttle.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Enabled reports whether the limit throttles requests.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// refillRate returns the number of tokens added to a bucket per second.
+func (l Limit) refillRate() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed    bool
+	Remaining  int           // Requests left in the current burst
+	RetryAfter time.Duration // Time until the next request is allowed, when denied
+}
+
+// Limiter throttles requests with token buckets identified by key.
+type Limiter interface {
+	// Allow takes a token from the bucket of key, creating it full if needed.
+	Allow(ctx context.Context, key string, limit Limit) (*Decision, error)
+}
+
+// Key builds a bucket key from the throttled action and the client it is
+// counted against, e.g. Key("magic_link", "email", "ada@example.com").
+func Key(scope, kind, value string) string {
+	return scope + ":" + kind + ":" + value
+}
+
+// Unlimited is a Limiter that allows every request, used when rate limiting
+// is turned off.
+type Unlimited struct{}
+
+func (Unlimited) Allow(ctx context.Context, key string, limit Limit) (*Decision, error) {
+	return &Decision{Allowed: true, Remaining: limit.Requests}, nil
+}
+
+// Bucket is the state of a token bucket.
+type Bucket struct {
+	Key       string
+	Tokens    float64
+	UpdatedAt time.Time
+	ExpiresAt time.Time // The bucket is full again by then and can be dropped
+}
+
+// NewBucket creates a full bucket.
+func NewBucket(key string, limit Limit, now time.Time) *Bucket {
+	return &Bucket{Key: key, Tokens: float64(limit.Requests), UpdatedAt: now, ExpiresAt: now}
+}
+
+// Take refills the bucket for the time elapsed since it was last updated and
+// takes a token if one is available.
+func (b *Bucket) Take(limit Limit, now time.Time) *Decision {
+	capacity := float64(limit.Requests)
+	rate := limit.refillRate()
+	if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+elapsed*rate)
+	}
+	b.UpdatedAt = now
+
+	decision := &Decision{}
+	if b.Tokens >= 1 {
+		b.Tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = time.Duration((1 - b.Tokens) / rate * float64(time.Second))
+	}
+	decision.Remaining = int(b.Tokens)
+	b.ExpiresAt = now.Add(time.Duration((capacity - b.Tokens) / rate * float64(time.Second)))
+	return decision
+}
@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// BucketRepository stores token buckets shared by all instances of the server.
+type BucketRepository interface {
+	// Lock returns the bucket of key, locked until the end of the
+	// transaction. A missing bucket is created full.
+	Lock(ctx context.Context, key string, limit Limit, now time.Time) (*Bucket, error)
+	Update(ctx context.Context, bucket *Bucket) error
+	// DeleteExpired removes the buckets that are full again.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"trading-alchemist/internal/domain/audit"
+)
+
+// AuditLogger records security-relevant actions in the audit log. Events are
+// written on their own, so failed attempts are kept when the action's
+// transaction rolls back; a failure to record is logged and never fails the
+// action.
+type AuditLogger interface {
+	Log(ctx context.Context, event *audit.Event)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// Workspace layout of a code run, relative to the script's working directory.
+const (
+	CodeRunDataDir   = "data"   // Read-only inputs
+	CodeRunOutputDir = "output" // Files collected after the run
+)
+
+// CodeFile is a file passed into or produced by a code run.
+type CodeFile struct {
+	Name    string
+	Content []byte
+}
+
+// CodeRun is a script to execute in isolation. Inputs are placed read-only in
+// the data directory of the workspace; files the script writes to the output
+// directory are collected.
+type CodeRun struct {
+	Code    string
+	Inputs  []CodeFile
+	Timeout time.Duration // Zero uses the runner's limit; longer values are capped by it
+}
+
+// CodeRunResult is the outcome of a code run. A script that fails or is killed
+// still produces a result; errors are reserved for failures of the runner.
+type CodeRunResult struct {
+	ExitCode        int
+	TimedOut        bool
+	Duration        time.Duration
+	Stdout          string
+	Stderr          string
+	StdoutTruncated bool
+	StderrTruncated bool
+	Outputs         []CodeFile
+	SkippedOutputs  []string // Output files dropped for exceeding the size or count limits
+}
+
+// CodeRunner executes untrusted scripts in a sandbox.
+type CodeRunner interface {
+	// Language is the language of the scripts the runner executes, e.g. python
+	Language() string
+
+	Run(ctx context.Context, run *CodeRun) (*CodeRunResult, error)
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"trading-alchemist/internal/domain/alert"
 	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/organization"
 )
 
 type EmailService interface {
@@ -15,4 +18,13 @@ type EmailService interface {
 	
 	// SendEmailVerificationEmail sends an email verification email
 	SendEmailVerificationEmail(ctx context.Context, user *auth.User, magicLink *auth.MagicLink) error
+
+	// SendAlertEmail notifies the user that one of their alert rules fired
+	SendAlertEmail(ctx context.Context, user *auth.User, rule *alert.Rule, event *alert.Event) error
+
+	// SendOrganizationInvitationEmail invites the user to join an organization with a magic link
+	SendOrganizationInvitationEmail(ctx context.Context, user *auth.User, org *organization.Organization, inviter *auth.User, magicLink *auth.MagicLink) error
+
+	// SendAccountDeletionEmail confirms that the user's account will be deleted at the given time
+	SendAccountDeletionEmail(ctx context.Context, user *auth.User, scheduledFor time.Time) error
 } 
\ No newline at end of file
@@ -0,0 +1,24 @@
+package services
+
+import "context"
+
+// ExternalIdentity is the user an identity provider vouches for after a sign-in.
+type ExternalIdentity struct {
+	Subject       string // Stable user ID at the provider
+	Email         string
+	EmailVerified bool // Whether the provider has verified that the user owns the email
+	FirstName     *string
+	LastName      *string
+}
+
+// IdentityProvider signs users in with an external OIDC or OAuth provider using
+// the authorization code flow with PKCE.
+type IdentityProvider interface {
+	// AuthCodeURL returns the URL of the provider's sign-in page for a new
+	// authorization request.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge, redirectURL string) (string, error)
+
+	// Exchange redeems an authorization code and returns the signed-in user. The
+	// nonce and code verifier are those of the authorization request.
+	Exchange(ctx context.Context, code, codeVerifier, nonce, redirectURL string) (*ExternalIdentity, error)
+}
@@ -2,26 +2,93 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/shared"
 )
 
+// ToolCall is a function call requested by the model.
+type ToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"` // Raw JSON arguments as produced by the model
+}
+
+// ToolCallResult is emitted to the client after a tool call has been executed.
+type ToolCallResult struct {
+	ToolCallID  string   `json:"tool_call_id"`
+	Name        string   `json:"name"`
+	Success     bool     `json:"success"`
+	Error       string   `json:"error,omitempty"`
+	ArtifactIDs []string `json:"artifact_ids,omitempty"`
+	// PendingConfirmation is set when the call waits for the user to approve it.
+	PendingConfirmation bool `json:"pending_confirmation,omitempty"`
+}
+
 // ChatStreamEvent represents a single event in a chat completion stream.
 type ChatStreamEvent struct {
-	ContentDelta string      `json:"content_delta"`
-	ToolCall     interface{} `json:"tool_call,omitempty"` // Placeholder for tool call data
-	IsLast       bool        `json:"is_last"`
-	Error        error       `json:"error,omitempty"`
+	ContentDelta string          `json:"content_delta"`
+	ToolCalls    []*ToolCall     `json:"tool_calls,omitempty"`  // Set on the last event when the model requested tools
+	ToolResult   *ToolCallResult `json:"tool_result,omitempty"` // Set when a requested tool has finished
+	Citations    []*Citation     `json:"citations,omitempty"`   // Set on an event before the response when documents were retrieved
+	IsLast       bool            `json:"is_last"`
+	Error        error           `json:"error,omitempty"`
 }
 
 // LLMService defines the interface for interacting with a Large Language Model.
 type LLMService interface {
 	// StreamChatCompletion sends a chat request and streams the response.
+	// Tools may be nil when the model should not call functions.
 	StreamChatCompletion(
 		ctx context.Context,
 		provider *chat.Provider,
 		model *chat.Model,
 		messages []*chat.Message,
+		tools []*chat.Tool,
 		apiKey string,
 		apiBaseOverride string,
 	) (<-chan ChatStreamEvent, error)
-} 
\ No newline at end of file
+
+	// Embed returns one embedding per input, in the order of the inputs.
+	Embed(
+		ctx context.Context,
+		provider *chat.Provider,
+		model *chat.Model,
+		inputs []string,
+		apiKey string,
+		apiBaseOverride string,
+	) ([][]float32, error)
+}
+
+// Message metadata keys used to persist tool calling state.
+const (
+	MetadataKeyToolCalls  = "tool_calls"   // On assistant messages: the calls the model requested
+	MetadataKeyToolCallID = "tool_call_id" // On tool messages: the call the result answers
+	MetadataKeyToolName   = "tool_name"    // On tool messages: the name of the executed tool
+	MetadataKeyToolStatus = "tool_status"  // On tool messages of confirmable tools: one of the ToolStatus values
+	MetadataKeyCitations  = "citations"    // On assistant messages: the document excerpts the response was given
+)
+
+// Status values of calls to tools that require user confirmation.
+const (
+	ToolStatusPendingConfirmation = "pending_confirmation"
+	ToolStatusConfirmed           = "confirmed"
+	ToolStatusDeclined            = "declined"
+)
+
+// ToolCallsFromMetadata decodes the tool calls stored on an assistant message.
+func ToolCallsFromMetadata(metadata shared.JSONB) []*ToolCall {
+	raw, ok := metadata[MetadataKeyToolCalls]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var calls []*ToolCall
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil
+	}
+	return calls
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"trading-alchemist/internal/domain/chat"
+
+	"github.com/google/uuid"
+)
+
+// MessageSearcher finds past chat messages by meaning rather than keywords.
+type MessageSearcher interface {
+	// IndexMessages embeds saved messages in the background so they can be found
+	// later. Messages without text are skipped.
+	IndexMessages(userID uuid.UUID, messages []*chat.Message)
+
+	// SearchMessages returns the user's messages most similar to the query, best
+	// first, leaving out those of excludeConversationID unless it is uuid.Nil.
+	SearchMessages(ctx context.Context, userID uuid.UUID, query string, excludeConversationID uuid.UUID, limit int) ([]*chat.MessageMatch, error)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Citation is a document excerpt given to the model as context, numbered so the
+// response can refer to it as [n].
+type Citation struct {
+	Index         int       `json:"index"`
+	DocumentID    uuid.UUID `json:"document_id"`
+	DocumentTitle string    `json:"document_title"`
+	ChunkID       uuid.UUID `json:"chunk_id"`
+	Page          *int      `json:"page,omitempty"`
+	Heading       *string   `json:"heading,omitempty"`
+	Similarity    float64   `json:"similarity"`
+	Excerpt       string    `json:"excerpt"` // Start of the chunk, for display
+	Content       string    `json:"-"`       // Full chunk text given to the model
+}
+
+// Retriever finds the passages of a user's documents relevant to a message.
+type Retriever interface {
+	// Retrieve returns the passages most relevant to the query, best first. It
+	// returns no citations when the user has nothing relevant.
+	Retrieve(ctx context.Context, userID, conversationID uuid.UUID, query string) ([]*Citation, error)
+}
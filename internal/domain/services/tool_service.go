@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// ToolInvocation carries the context of a single tool call.
+type ToolInvocation struct {
+	UserID         uuid.UUID
+	ConversationID uuid.UUID
+	MessageID      uuid.UUID // The assistant message that requested the call
+	Arguments      json.RawMessage
+	// Scopes granted to the API token the call was made with; nil for user
+	// sessions, which may use every tool.
+	Scopes []string
+}
+
+// ToolResult is the outcome of a tool call.
+type ToolResult struct {
+	// Output is returned to the model and logged in message_tools.
+	Output shared.JSONB
+	// Artifacts are attached to the requesting assistant message.
+	Artifacts []*chat.Artifact
+}
+
+// ToolHandler implements a function the model can call.
+type ToolHandler interface {
+	// Definition describes the tool; Name and Schema are sent to the model.
+	Definition() *chat.Tool
+
+	// RequiredScope names the API token scope a call needs, e.g. ("paper", true)
+	// for paper:write.
+	RequiredScope() (resource string, write bool)
+
+	// Execute runs the tool. Returned errors are reported back to the model.
+	Execute(ctx context.Context, invocation *ToolInvocation) (*ToolResult, error)
+}
+
+// ConfirmableToolHandler is implemented by tools the user must approve before they
+// run, such as tools that place orders. Calls are held until the user confirms or
+// declines them.
+type ConfirmableToolHandler interface {
+	ToolHandler
+
+	// RequiresConfirmation reports whether calls must be approved by the user.
+	RequiresConfirmation() bool
+}
@@ -48,4 +48,5 @@ const (
 	ArtifactTypeImage    ArtifactType = "image"
 	ArtifactTypeHTML     ArtifactType = "html"
 	ArtifactTypeSVG      ArtifactType = "svg"
+	ArtifactTypeStrategy ArtifactType = "strategy"
 ) 
\ No newline at end of file
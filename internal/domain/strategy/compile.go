@@ -0,0 +1,224 @@
+package strategy
+
+import (
+	"math"
+
+	"trading-alchemist/internal/domain/backtest"
+	"trading-alchemist/internal/domain/market"
+)
+
+// Compile turns a parsed definition into a strategy the backtest engine can run.
+func Compile(def *Definition) backtest.Strategy {
+	return &compiled{def: def}
+}
+
+// compiled evaluates a Definition bar by bar. Entry rules take precedence over
+// exit rules: when both hold on the same bar, the entry wins.
+type compiled struct {
+	def    *Definition
+	series map[string][]float64
+}
+
+func (s *compiled) Name() string { return s.def.Name }
+
+// RiskRules implements backtest.RiskManaged.
+func (s *compiled) RiskRules() backtest.RiskRules {
+	return backtest.RiskRules{
+		StopLossPct:   s.def.Risk.StopLossPct,
+		TakeProfitPct: s.def.Risk.TakeProfitPct,
+	}
+}
+
+// Init precomputes every price and indicator series referenced by the rules.
+func (s *compiled) Init(bars []*market.Candle) error {
+	s.series = make(map[string][]float64)
+	for _, c := range []*Condition{s.def.Entry.Long, s.def.Entry.Short, s.def.Exit.Long, s.def.Exit.Short} {
+		s.prepare(c, bars)
+	}
+	return nil
+}
+
+func (s *compiled) prepare(c *Condition, bars []*market.Candle) {
+	if c == nil {
+		return
+	}
+	for _, child := range c.Children {
+		s.prepare(child, bars)
+	}
+	for _, o := range []*Operand{c.Left, c.Right} {
+		if o == nil || o.IsConstant() {
+			continue
+		}
+		if _, done := s.series[o.Key()]; done {
+			continue
+		}
+		s.series[o.Key()] = computeSeries(o, bars)
+	}
+}
+
+func computeSeries(o *Operand, bars []*market.Candle) []float64 {
+	values := make([]float64, len(bars))
+	for i, b := range bars {
+		switch o.Source {
+		case SourceOpen:
+			values[i] = b.Open
+		case SourceHigh:
+			values[i] = b.High
+		case SourceLow:
+			values[i] = b.Low
+		case SourceVolume:
+			values[i] = b.Volume
+		default:
+			values[i] = b.Close
+		}
+	}
+	switch o.Indicator {
+	case IndicatorSMA:
+		return market.SMA(values, o.Period)
+	case IndicatorEMA:
+		return market.EMA(values, o.Period)
+	case IndicatorRSI:
+		return market.RSI(values, o.Period)
+	case IndicatorHighest:
+		return market.Highest(values, o.Period)
+	case IndicatorLowest:
+		return market.Lowest(values, o.Period)
+	}
+	return values
+}
+
+func (s *compiled) Signal(i int) backtest.Signal {
+	switch {
+	case s.eval(s.def.Entry.Long, i):
+		return backtest.SignalLong
+	case s.eval(s.def.Entry.Short, i):
+		return backtest.SignalShort
+	}
+	exitLong := s.eval(s.def.Exit.Long, i)
+	exitShort := s.eval(s.def.Exit.Short, i)
+	switch {
+	case exitLong && exitShort:
+		return backtest.SignalFlat
+	case exitLong:
+		return backtest.SignalExitLong
+	case exitShort:
+		return backtest.SignalExitShort
+	}
+	return backtest.SignalHold
+}
+
+// eval reports whether the condition holds at bar i. Comparisons involving a
+// value that is not available yet (indicator warm-up, offset before the first
+// bar) are false.
+func (s *compiled) eval(c *Condition, i int) bool {
+	if c == nil {
+		return false
+	}
+	switch c.Op {
+	case OpAll:
+		for _, child := range c.Children {
+			if !s.eval(child, i) {
+				return false
+			}
+		}
+		return true
+	case OpAny:
+		for _, child := range c.Children {
+			if s.eval(child, i) {
+				return true
+			}
+		}
+		return false
+	case OpNot:
+		return !s.eval(c.Children[0], i)
+	case OpCrossAbove, OpCrossBelow:
+		if i == 0 {
+			return false
+		}
+		prevL, prevR := s.value(c.Left, i-1), s.value(c.Right, i-1)
+		curL, curR := s.value(c.Left, i), s.value(c.Right, i)
+		if anyNaN(prevL, prevR, curL, curR) {
+			return false
+		}
+		if c.Op == OpCrossAbove {
+			return prevL <= prevR && curL > curR
+		}
+		return prevL >= prevR && curL < curR
+	}
+
+	l, r := s.value(c.Left, i), s.value(c.Right, i)
+	if anyNaN(l, r) {
+		return false
+	}
+	switch c.Op {
+	case OpGT:
+		return l > r
+	case OpGTE:
+		return l >= r
+	case OpLT:
+		return l < r
+	case OpLTE:
+		return l <= r
+	}
+	return false
+}
+
+func (s *compiled) value(o *Operand, i int) float64 {
+	if o.IsConstant() {
+		return *o.Constant
+	}
+	j := i - o.Offset
+	if j < 0 {
+		return math.NaN()
+	}
+	return s.series[o.Key()][j]
+}
+
+func anyNaN(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConditionEvaluator evaluates a single condition over a fixed series of bars.
+type ConditionEvaluator struct {
+	condition *Condition
+	compiled  *compiled
+}
+
+// NewConditionEvaluator precomputes the series the condition references.
+func NewConditionEvaluator(c *Condition, bars []*market.Candle) *ConditionEvaluator {
+	s := &compiled{series: make(map[string][]float64)}
+	s.prepare(c, bars)
+	return &ConditionEvaluator{condition: c, compiled: s}
+}
+
+// Holds reports whether the condition holds at bar i.
+func (e *ConditionEvaluator) Holds(i int) bool {
+	return e.compiled.eval(e.condition, i)
+}
+
+// Lookback returns how many bars before the evaluated bar the condition reads,
+// including the previous bar compared by cross operators.
+func Lookback(c *Condition) int {
+	if c == nil {
+		return 0
+	}
+	bars := 0
+	for _, child := range c.Children {
+		bars = max(bars, Lookback(child))
+	}
+	for _, o := range []*Operand{c.Left, c.Right} {
+		if o == nil || o.IsConstant() {
+			continue
+		}
+		bars = max(bars, o.Period+o.Offset)
+	}
+	if c.Op == OpCrossAbove || c.Op == OpCrossBelow {
+		bars++
+	}
+	return bars
+}
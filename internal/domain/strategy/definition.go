@@ -0,0 +1,178 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Definition is a declarative trading strategy.
+//
+// A strategy enters when an entry rule holds at a bar's close and leaves when the
+// matching exit rule holds or a risk limit is hit. Orders are executed by the
+// backtest engine at the next bar's open.
+type Definition struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Entry       RuleSet `json:"entry"`
+	Exit        RuleSet `json:"exit,omitempty"`
+	Risk        Risk    `json:"risk,omitempty"`
+}
+
+// RuleSet holds the conditions for each side. A nil condition never fires.
+type RuleSet struct {
+	Long  *Condition `json:"long,omitempty"`
+	Short *Condition `json:"short,omitempty"`
+}
+
+// Risk holds protective exits expressed as a percentage of the entry price.
+type Risk struct {
+	StopLossPct   float64 `json:"stop_loss_pct,omitempty"`
+	TakeProfitPct float64 `json:"take_profit_pct,omitempty"`
+}
+
+// Condition operators.
+const (
+	OpAll        = "all"
+	OpAny        = "any"
+	OpNot        = "not"
+	OpGT         = "gt"
+	OpGTE        = "gte"
+	OpLT         = "lt"
+	OpLTE        = "lte"
+	OpCrossAbove = "cross_above"
+	OpCrossBelow = "cross_below"
+)
+
+var (
+	logicalOps    = []string{OpAll, OpAny, OpNot}
+	comparisonOps = []string{OpGT, OpGTE, OpLT, OpLTE}
+	crossOps      = []string{OpCrossAbove, OpCrossBelow}
+)
+
+// Condition is a boolean rule. Exactly one operator is set:
+// all/any combine Children, not negates Children[0], and comparison or cross
+// operators relate Left and Right.
+type Condition struct {
+	Op       string
+	Children []*Condition
+	Left     *Operand
+	Right    *Operand
+}
+
+// MarshalJSON encodes the condition in the same compact form the parser accepts.
+func (c *Condition) MarshalJSON() ([]byte, error) {
+	switch c.Op {
+	case OpAll, OpAny:
+		return json.Marshal(map[string][]*Condition{c.Op: c.Children})
+	case OpNot:
+		return json.Marshal(map[string]*Condition{c.Op: c.Children[0]})
+	default:
+		return json.Marshal(map[string][2]*Operand{c.Op: {c.Left, c.Right}})
+	}
+}
+
+// Price sources an operand can read.
+const (
+	SourceOpen   = "open"
+	SourceHigh   = "high"
+	SourceLow    = "low"
+	SourceClose  = "close"
+	SourceVolume = "volume"
+)
+
+var sources = []string{SourceOpen, SourceHigh, SourceLow, SourceClose, SourceVolume}
+
+// Indicators an operand can compute.
+const (
+	IndicatorSMA     = "sma"
+	IndicatorEMA     = "ema"
+	IndicatorRSI     = "rsi"
+	IndicatorHighest = "highest"
+	IndicatorLowest  = "lowest"
+)
+
+var indicators = []string{IndicatorSMA, IndicatorEMA, IndicatorRSI, IndicatorHighest, IndicatorLowest}
+
+// Operand is a value compared by a condition: a constant, a price series or an
+// indicator series. Offset looks back the given number of bars.
+type Operand struct {
+	Constant  *float64
+	Source    string
+	Indicator string
+	Period    int
+	Offset    int
+}
+
+// IsConstant reports whether the operand is a literal number.
+func (o *Operand) IsConstant() bool {
+	return o.Constant != nil
+}
+
+// Key identifies the series an operand evaluates to, ignoring the offset.
+func (o *Operand) Key() string {
+	if o.Indicator != "" {
+		return fmt.Sprintf("%s(%s,%d)", o.Indicator, o.Source, o.Period)
+	}
+	return o.Source
+}
+
+// String renders the operand for human-readable output.
+func (o *Operand) String() string {
+	if o.Constant != nil {
+		return fmt.Sprintf("%g", *o.Constant)
+	}
+	s := o.Key()
+	if o.Offset > 0 {
+		s += fmt.Sprintf("[-%d]", o.Offset)
+	}
+	return s
+}
+
+// MarshalJSON encodes the operand in the same compact form the parser accepts.
+func (o *Operand) MarshalJSON() ([]byte, error) {
+	if o.Constant != nil {
+		return json.Marshal(*o.Constant)
+	}
+	if o.Indicator == "" && o.Offset == 0 {
+		return json.Marshal(o.Source)
+	}
+	m := map[string]interface{}{}
+	if o.Indicator != "" {
+		m["indicator"] = o.Indicator
+		m["period"] = o.Period
+		if o.Source != SourceClose {
+			m["source"] = o.Source
+		}
+	} else {
+		m["price"] = o.Source
+	}
+	if o.Offset > 0 {
+		m["offset"] = o.Offset
+	}
+	return json.Marshal(m)
+}
+
+// String renders the condition for human-readable output.
+func (c *Condition) String() string {
+	switch c.Op {
+	case OpAll, OpAny:
+		parts := make([]string, len(c.Children))
+		for i, child := range c.Children {
+			parts[i] = child.String()
+		}
+		sep := " AND "
+		if c.Op == OpAny {
+			sep = " OR "
+		}
+		return "(" + strings.Join(parts, sep) + ")"
+	case OpNot:
+		return "NOT " + c.Children[0].String()
+	case OpCrossAbove:
+		return fmt.Sprintf("%s crosses above %s", c.Left, c.Right)
+	case OpCrossBelow:
+		return fmt.Sprintf("%s crosses below %s", c.Left, c.Right)
+	}
+	symbols := map[string]string{OpGT: ">", OpGTE: ">=", OpLT: "<", OpLTE: "<="}
+	return fmt.Sprintf("%s %s %s", c.Left, symbols[c.Op], c.Right)
+}
@@ -0,0 +1,507 @@
+package strategy
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maxNameLength     = 255
+	maxConditionDepth = 16
+	maxConditions     = 256
+	maxPeriod         = 1000
+	maxOffset         = 1000
+)
+
+// Formats a strategy source can be written in.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// FieldError describes a single problem in a strategy source.
+type FieldError struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	var b strings.Builder
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		fmt.Fprintf(&b, "line %d, column %d: ", e.Line, e.Column)
+	case e.Line > 0:
+		fmt.Fprintf(&b, "line %d: ", e.Line)
+	}
+	if e.Path != "" {
+		b.WriteString(e.Path)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// ValidationError lists every problem found while parsing a strategy.
+type ValidationError struct {
+	Errors []*FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return "invalid strategy: " + strings.Join(msgs, "; ")
+}
+
+// DetectFormat guesses whether a strategy source is JSON or YAML.
+func DetectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+var yamlLineRe = regexp.MustCompile(`^(?:yaml: )?line (\d+): `)
+
+// Parse decodes and validates a strategy written in JSON or YAML.
+// On failure it returns a *ValidationError that reports every problem found,
+// each with the path of the offending field and its line and column.
+func Parse(data []byte) (*Definition, error) {
+	root, err := decode(data, "strategy is empty")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{}
+	def := p.definition(root)
+	if len(p.errs) > 0 {
+		return nil, &ValidationError{Errors: p.errs}
+	}
+	return def, nil
+}
+
+// ParseCondition decodes and validates a single condition written in JSON or
+// YAML, in the same form used for entry and exit rules.
+func ParseCondition(data []byte) (*Condition, error) {
+	root, err := decode(data, "condition is empty")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{}
+	c := p.condition(root, "", 1)
+	if len(p.errs) > 0 {
+		return nil, &ValidationError{Errors: p.errs}
+	}
+	return c, nil
+}
+
+// decode parses the source into its root node, reporting syntax errors as a *ValidationError.
+func decode(data []byte, emptyMessage string) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		fe := &FieldError{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if m := yamlLineRe.FindStringSubmatch(err.Error()); m != nil {
+			fe.Line, _ = strconv.Atoi(m[1])
+			fe.Message = err.Error()[len(m[0]):]
+		}
+		return nil, &ValidationError{Errors: []*FieldError{fe}}
+	}
+	if len(root.Content) == 0 {
+		return nil, &ValidationError{Errors: []*FieldError{{Message: emptyMessage}}}
+	}
+	return root.Content[0], nil
+}
+
+type parser struct {
+	errs       []*FieldError
+	conditions int
+}
+
+func (p *parser) fail(n *yaml.Node, path, format string, args ...interface{}) {
+	fe := &FieldError{Path: path, Message: fmt.Sprintf(format, args...)}
+	if n != nil {
+		fe.Line, fe.Column = n.Line, n.Column
+	}
+	p.errs = append(p.errs, fe)
+}
+
+func resolve(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// fields returns the values of a mapping by key, reporting unknown and duplicate keys.
+func (p *parser) fields(n *yaml.Node, path string, allowed []string) (map[string]*yaml.Node, bool) {
+	n = resolve(n)
+	if n.Kind != yaml.MappingNode {
+		p.fail(n, path, "expected an object, got %s", describe(n))
+		return nil, false
+	}
+	out := make(map[string]*yaml.Node, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], resolve(n.Content[i+1])
+		if !contains(allowed, key.Value) {
+			p.fail(key, join(path, key.Value), "unknown field (expected one of: %s)", strings.Join(allowed, ", "))
+			continue
+		}
+		if _, dup := out[key.Value]; dup {
+			p.fail(key, join(path, key.Value), "duplicate field")
+			continue
+		}
+		out[key.Value] = value
+	}
+	return out, true
+}
+
+func (p *parser) str(n *yaml.Node, path string) (string, bool) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+		p.fail(n, path, "expected a string, got %s", describe(n))
+		return "", false
+	}
+	return n.Value, true
+}
+
+func (p *parser) enum(n *yaml.Node, path, what string, allowed []string) (string, bool) {
+	s, ok := p.str(n, path)
+	if !ok {
+		return "", false
+	}
+	if !contains(allowed, s) {
+		p.fail(n, path, "unknown %s %q (expected one of: %s)", what, s, strings.Join(allowed, ", "))
+		return "", false
+	}
+	return s, true
+}
+
+func (p *parser) number(n *yaml.Node, path string) (float64, bool) {
+	var f float64
+	if n.Kind != yaml.ScalarNode || (n.Tag != "!!int" && n.Tag != "!!float") || n.Decode(&f) != nil {
+		p.fail(n, path, "expected a number, got %s", describe(n))
+		return 0, false
+	}
+	return f, true
+}
+
+func (p *parser) integer(n *yaml.Node, path string, min, max int) (int, bool) {
+	var v int
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!int" || n.Decode(&v) != nil {
+		p.fail(n, path, "expected an integer, got %s", describe(n))
+		return 0, false
+	}
+	if v < min || v > max {
+		p.fail(n, path, "must be between %d and %d, got %d", min, max, v)
+		return 0, false
+	}
+	return v, true
+}
+
+func (p *parser) definition(n *yaml.Node) *Definition {
+	f, ok := p.fields(n, "", []string{"name", "description", "entry", "exit", "risk"})
+	if !ok {
+		return nil
+	}
+	def := &Definition{}
+
+	if v, ok := f["name"]; !ok {
+		p.fail(n, "name", "is required")
+	} else if name, ok := p.str(v, "name"); ok {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			p.fail(v, "name", "must not be empty")
+		case len(name) > maxNameLength:
+			p.fail(v, "name", "must be at most %d characters", maxNameLength)
+		}
+		def.Name = name
+	}
+	if v, ok := f["description"]; ok {
+		def.Description, _ = p.str(v, "description")
+	}
+
+	if v, ok := f["entry"]; !ok {
+		p.fail(n, "entry", "is required")
+	} else {
+		def.Entry = p.ruleSet(v, "entry")
+		if m := resolve(v); m.Kind == yaml.MappingNode && !hasKey(m, "long") && !hasKey(m, "short") {
+			p.fail(v, "entry", "must define a long or short rule")
+		}
+	}
+	if v, ok := f["exit"]; ok {
+		def.Exit = p.ruleSet(v, "exit")
+		if def.Exit.Long != nil && def.Entry.Long == nil && f["entry"] != nil {
+			p.fail(v, "exit.long", "has no effect without entry.long")
+		}
+		if def.Exit.Short != nil && def.Entry.Short == nil && f["entry"] != nil {
+			p.fail(v, "exit.short", "has no effect without entry.short")
+		}
+	}
+	if v, ok := f["risk"]; ok {
+		def.Risk = p.risk(v, "risk")
+	}
+	return def
+}
+
+func (p *parser) ruleSet(n *yaml.Node, path string) RuleSet {
+	var rs RuleSet
+	f, ok := p.fields(n, path, []string{"long", "short"})
+	if !ok {
+		return rs
+	}
+	if v, ok := f["long"]; ok {
+		rs.Long = p.condition(v, join(path, "long"), 1)
+	}
+	if v, ok := f["short"]; ok {
+		rs.Short = p.condition(v, join(path, "short"), 1)
+	}
+	return rs
+}
+
+func (p *parser) risk(n *yaml.Node, path string) Risk {
+	var r Risk
+	f, ok := p.fields(n, path, []string{"stop_loss_pct", "take_profit_pct"})
+	if !ok {
+		return r
+	}
+	if v, ok := f["stop_loss_pct"]; ok {
+		if pct, ok := p.number(v, join(path, "stop_loss_pct")); ok {
+			if pct <= 0 || pct >= 100 {
+				p.fail(v, join(path, "stop_loss_pct"), "must be greater than 0 and less than 100")
+			}
+			r.StopLossPct = pct
+		}
+	}
+	if v, ok := f["take_profit_pct"]; ok {
+		if pct, ok := p.number(v, join(path, "take_profit_pct")); ok {
+			if pct <= 0 {
+				p.fail(v, join(path, "take_profit_pct"), "must be greater than 0")
+			}
+			r.TakeProfitPct = pct
+		}
+	}
+	return r
+}
+
+func (p *parser) condition(n *yaml.Node, path string, depth int) *Condition {
+	n = resolve(n)
+	if depth > maxConditionDepth {
+		p.fail(n, path, "conditions are nested too deeply (maximum depth is %d)", maxConditionDepth)
+		return nil
+	}
+	p.conditions++
+	if p.conditions == maxConditions+1 {
+		p.fail(n, path, "strategy has too many conditions (maximum is %d)", maxConditions)
+	}
+	if p.conditions > maxConditions {
+		return nil
+	}
+
+	allOps := append(append(append([]string{}, logicalOps...), comparisonOps...), crossOps...)
+	if n.Kind != yaml.MappingNode {
+		p.fail(n, path, "expected a condition object with one of: %s; got %s", strings.Join(allOps, ", "), describe(n))
+		return nil
+	}
+	switch len(n.Content) / 2 {
+	case 0:
+		p.fail(n, path, "condition must have exactly one operator (one of: %s)", strings.Join(allOps, ", "))
+		return nil
+	case 1:
+	default:
+		keys := make([]string, 0, len(n.Content)/2)
+		for i := 0; i < len(n.Content); i += 2 {
+			keys = append(keys, n.Content[i].Value)
+		}
+		p.fail(n, path, "condition has several operators (%s); combine them with all or any", strings.Join(keys, ", "))
+		return nil
+	}
+
+	key, value := n.Content[0], resolve(n.Content[1])
+	op := key.Value
+	opPath := join(path, op)
+
+	switch {
+	case op == OpAll || op == OpAny:
+		if value.Kind != yaml.SequenceNode {
+			p.fail(value, opPath, "expected a list of conditions, got %s", describe(value))
+			return nil
+		}
+		if len(value.Content) == 0 {
+			p.fail(value, opPath, "must contain at least one condition")
+			return nil
+		}
+		c := &Condition{Op: op}
+		for i, child := range value.Content {
+			c.Children = append(c.Children, p.condition(child, fmt.Sprintf("%s[%d]", opPath, i), depth+1))
+		}
+		return c
+
+	case op == OpNot:
+		child := p.condition(value, opPath, depth+1)
+		return &Condition{Op: op, Children: []*Condition{child}}
+
+	case contains(comparisonOps, op) || contains(crossOps, op):
+		if value.Kind != yaml.SequenceNode || len(value.Content) != 2 {
+			p.fail(value, opPath, "expected a list of exactly two operands, got %s", describe(value))
+			return nil
+		}
+		c := &Condition{
+			Op:    op,
+			Left:  p.operand(value.Content[0], opPath+"[0]"),
+			Right: p.operand(value.Content[1], opPath+"[1]"),
+		}
+		if c.Left != nil && c.Right != nil && c.Left.IsConstant() && c.Right.IsConstant() {
+			p.fail(value, opPath, "at least one operand must be a price or indicator series")
+		}
+		return c
+	}
+
+	p.fail(key, opPath, "unknown operator %q (expected one of: %s)", op, strings.Join(allOps, ", "))
+	return nil
+}
+
+func (p *parser) operand(n *yaml.Node, path string) *Operand {
+	n = resolve(n)
+	if n.Kind == yaml.ScalarNode {
+		switch n.Tag {
+		case "!!int", "!!float":
+			if v, ok := p.number(n, path); ok {
+				return &Operand{Constant: &v}
+			}
+			return nil
+		case "!!str":
+			if contains(indicators, n.Value) {
+				p.fail(n, path, "indicator %q must be written as an object, e.g. {indicator: %s, period: 14}", n.Value, n.Value)
+				return nil
+			}
+			if src, ok := p.enum(n, path, "price source", sources); ok {
+				return &Operand{Source: src}
+			}
+			return nil
+		}
+	}
+	if n.Kind != yaml.MappingNode {
+		p.fail(n, path, "expected a number, a price source or an indicator object, got %s", describe(n))
+		return nil
+	}
+
+	f, ok := p.fields(n, path, []string{"indicator", "period", "source", "price", "offset"})
+	if !ok {
+		return nil
+	}
+	o := &Operand{Source: SourceClose}
+	valid := true
+
+	_, hasIndicator := f["indicator"]
+	_, hasPrice := f["price"]
+	switch {
+	case hasIndicator && hasPrice:
+		p.fail(n, path, "set either indicator or price, not both")
+		return nil
+	case hasIndicator:
+		if ind, ok := p.enum(f["indicator"], join(path, "indicator"), "indicator", indicators); ok {
+			o.Indicator = ind
+		} else {
+			valid = false
+		}
+		if v, ok := f["period"]; !ok {
+			p.fail(n, join(path, "period"), "is required for indicator operands")
+			valid = false
+		} else if period, ok := p.integer(v, join(path, "period"), 1, maxPeriod); ok {
+			o.Period = period
+		} else {
+			valid = false
+		}
+		if v, ok := f["source"]; ok {
+			if src, ok := p.enum(v, join(path, "source"), "price source", sources); ok {
+				o.Source = src
+			} else {
+				valid = false
+			}
+		}
+	case hasPrice:
+		if src, ok := p.enum(f["price"], join(path, "price"), "price source", sources); ok {
+			o.Source = src
+		} else {
+			valid = false
+		}
+		for _, field := range []string{"period", "source"} {
+			if v, ok := f[field]; ok {
+				p.fail(v, join(path, field), "only applies to indicator operands")
+				valid = false
+			}
+		}
+	default:
+		p.fail(n, path, "operand object must set indicator or price")
+		return nil
+	}
+
+	if v, ok := f["offset"]; ok {
+		if offset, ok := p.integer(v, join(path, "offset"), 0, maxOffset); ok {
+			o.Offset = offset
+		} else {
+			valid = false
+		}
+	}
+	if !valid {
+		return nil
+	}
+	return o
+}
+
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return fmt.Sprintf("a list of %d items", len(n.Content))
+	case yaml.ScalarNode:
+		switch n.Tag {
+		case "!!str":
+			return fmt.Sprintf("string %q", n.Value)
+		case "!!int", "!!float":
+			return "number " + n.Value
+		case "!!bool":
+			return "boolean " + n.Value
+		case "!!null":
+			return "null"
+		}
+		return n.Value
+	}
+	return "an unsupported value"
+}
+
+func hasKey(mapping *yaml.Node, key string) bool {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
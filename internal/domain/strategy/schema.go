@@ -0,0 +1,119 @@
+package strategy
+
+import "trading-alchemist/internal/domain/shared"
+
+// SchemaDefinitions returns the reusable JSON Schema definitions of the DSL,
+// meant to be placed under "$defs" of a schema that references
+// "#/$defs/strategy".
+func SchemaDefinitions() map[string]interface{} {
+	series := map[string]interface{}{
+		"oneOf": []interface{}{
+			map[string]interface{}{
+				"type":        "string",
+				"enum":        sources,
+				"description": "Price series of the current bar",
+			},
+			map[string]interface{}{
+				"type":                 "object",
+				"description":          "Indicator series, e.g. {\"indicator\": \"sma\", \"period\": 20}",
+				"additionalProperties": false,
+				"required":             []string{"indicator", "period"},
+				"properties": map[string]interface{}{
+					"indicator": map[string]interface{}{"type": "string", "enum": indicators},
+					"period":    map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxPeriod},
+					"source":    map[string]interface{}{"type": "string", "enum": sources, "default": SourceClose},
+					"offset":    map[string]interface{}{"type": "integer", "minimum": 0, "maximum": maxOffset, "description": "Bars to look back"},
+				},
+			},
+			map[string]interface{}{
+				"type":                 "object",
+				"description":          "Price series with a look-back, e.g. {\"price\": \"close\", \"offset\": 1}",
+				"additionalProperties": false,
+				"required":             []string{"price"},
+				"properties": map[string]interface{}{
+					"price":  map[string]interface{}{"type": "string", "enum": sources},
+					"offset": map[string]interface{}{"type": "integer", "minimum": 0, "maximum": maxOffset},
+				},
+			},
+		},
+	}
+
+	operandPair := map[string]interface{}{
+		"type":     "array",
+		"minItems": 2,
+		"maxItems": 2,
+		"items": map[string]interface{}{
+			"oneOf": []interface{}{
+				map[string]interface{}{"type": "number"},
+				map[string]interface{}{"$ref": "#/$defs/operand"},
+			},
+		},
+	}
+
+	var conditionVariants []interface{}
+	for _, op := range []string{OpAll, OpAny} {
+		conditionVariants = append(conditionVariants, singleKey(op, map[string]interface{}{
+			"type":     "array",
+			"minItems": 1,
+			"items":    map[string]interface{}{"$ref": "#/$defs/condition"},
+		}))
+	}
+	conditionVariants = append(conditionVariants, singleKey(OpNot, map[string]interface{}{"$ref": "#/$defs/condition"}))
+	for _, op := range append(append([]string{}, comparisonOps...), crossOps...) {
+		conditionVariants = append(conditionVariants, singleKey(op, operandPair))
+	}
+
+	ruleSet := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"long":  map[string]interface{}{"$ref": "#/$defs/condition"},
+			"short": map[string]interface{}{"$ref": "#/$defs/condition"},
+		},
+	}
+
+	return map[string]interface{}{
+		"strategy": map[string]interface{}{
+			"type":                 "object",
+			"description":          "Declarative trading strategy. Rules are evaluated on each bar's close and orders fill at the next bar's open. Short rules require allow_short.",
+			"additionalProperties": false,
+			"required":             []string{"name", "entry"},
+			"properties": map[string]interface{}{
+				"name":        map[string]interface{}{"type": "string", "minLength": 1, "maxLength": maxNameLength},
+				"description": map[string]interface{}{"type": "string"},
+				"entry":       ruleSet,
+				"exit":        ruleSet,
+				"risk": map[string]interface{}{
+					"type":                 "object",
+					"additionalProperties": false,
+					"properties": map[string]interface{}{
+						"stop_loss_pct":   map[string]interface{}{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 100},
+						"take_profit_pct": map[string]interface{}{"type": "number", "exclusiveMinimum": 0},
+					},
+				},
+			},
+		},
+		"condition": map[string]interface{}{"oneOf": conditionVariants},
+		"operand":   series,
+	}
+}
+
+// JSONSchema returns the JSON Schema of a strategy definition.
+func JSONSchema() shared.JSONB {
+	defs := SchemaDefinitions()
+	schema := shared.JSONB{}
+	for k, v := range defs["strategy"].(map[string]interface{}) {
+		schema[k] = v
+	}
+	schema["$defs"] = defs
+	return schema
+}
+
+func singleKey(key string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{key},
+		"properties":           map[string]interface{}{key: value},
+	}
+}
@@ -0,0 +1,24 @@
+package brokerage
+
+import (
+	"fmt"
+
+	"trading-alchemist/internal/domain/broker"
+)
+
+// NewBroker creates the Broker implementation for a connection. Credentials are
+// the decrypted credentials of the connection and may be nil for the simulator.
+func NewBroker(conn *broker.Connection, credentials *broker.Credentials, prices broker.PriceSource) (broker.Broker, error) {
+	switch conn.Kind {
+	case broker.KindSimulator:
+		return NewSimulator(conn.SimulatorSettings(), prices), nil
+	case broker.KindREST:
+		baseURL := ""
+		if conn.APIBaseOverride != nil {
+			baseURL = *conn.APIBaseOverride
+		}
+		return NewRESTBroker(baseURL, credentials)
+	default:
+		return nil, fmt.Errorf("unsupported broker kind: %s", conn.Kind)
+	}
+}
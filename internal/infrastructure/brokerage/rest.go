@@ -0,0 +1,217 @@
+package brokerage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/domain/broker"
+)
+
+const (
+	restTimeout      = 15 * time.Second
+	restPollInterval = 2 * time.Second
+	// maxErrorBody bounds how much of an error response is included in errors.
+	maxErrorBody = 4096
+)
+
+// RESTBroker is an adapter skeleton for brokers with a JSON REST API. It expects
+// the following endpoints under the base URL, exchanging the domain types as JSON:
+//
+//	GET    /account                 broker.Account
+//	GET    /positions               []broker.Position
+//	POST   /orders                  broker.OrderRequest -> broker.Order
+//	GET    /orders?status=&symbol=&limit=   []broker.Order
+//	GET    /orders/{id}             broker.Order
+//	DELETE /orders/{id}             broker.Order
+//
+// Requests are authenticated with the X-API-Key and X-API-Secret headers. Brokers
+// with a different API get their own adapter built on the same helpers. Order
+// updates are polled, since streaming APIs differ between brokers.
+type RESTBroker struct {
+	baseURL     *url.URL
+	credentials broker.Credentials
+	client      *http.Client
+}
+
+// NewRESTBroker creates an adapter for the API at baseURL.
+func NewRESTBroker(baseURL string, credentials *broker.Credentials) (*RESTBroker, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid broker API base URL: %s", baseURL)
+	}
+	if credentials == nil || credentials.APIKey == "" {
+		return nil, fmt.Errorf("broker API key is not provided")
+	}
+	return &RESTBroker{
+		baseURL:     u,
+		credentials: *credentials,
+		client:      &http.Client{Timeout: restTimeout},
+	}, nil
+}
+
+func (b *RESTBroker) GetAccount(ctx context.Context) (*broker.Account, error) {
+	var account broker.Account
+	if err := b.do(ctx, http.MethodGet, "/account", nil, nil, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (b *RESTBroker) GetPositions(ctx context.Context) ([]*broker.Position, error) {
+	var positions []*broker.Position
+	if err := b.do(ctx, http.MethodGet, "/positions", nil, nil, &positions); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+func (b *RESTBroker) PlaceOrder(ctx context.Context, req *broker.OrderRequest) (*broker.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var order broker.Order
+	if err := b.do(ctx, http.MethodPost, "/orders", nil, req, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (b *RESTBroker) CancelOrder(ctx context.Context, orderID string) (*broker.Order, error) {
+	var order broker.Order
+	if err := b.do(ctx, http.MethodDelete, "/orders/"+url.PathEscape(orderID), nil, nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (b *RESTBroker) GetOrder(ctx context.Context, orderID string) (*broker.Order, error) {
+	var order broker.Order
+	if err := b.do(ctx, http.MethodGet, "/orders/"+url.PathEscape(orderID), nil, nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (b *RESTBroker) ListOrders(ctx context.Context, filter broker.OrderFilter) ([]*broker.Order, error) {
+	query := url.Values{}
+	if filter.Scope != "" {
+		query.Set("status", string(filter.Scope))
+	}
+	if filter.Symbol != "" {
+		query.Set("symbol", filter.Symbol)
+	}
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	var orders []*broker.Order
+	if err := b.do(ctx, http.MethodGet, "/orders", query, nil, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// StreamOrderUpdates polls the recent orders and reports those whose status or
+// fill changed since the previous poll. Orders seen on the first poll are the
+// baseline and are not reported.
+func (b *RESTBroker) StreamOrderUpdates(ctx context.Context) (<-chan broker.OrderUpdate, error) {
+	initial, err := b.ListOrders(ctx, broker.OrderFilter{Scope: broker.OrderScopeAll})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]broker.Order, len(initial))
+	for _, o := range initial {
+		seen[o.ID] = *o
+	}
+
+	updates := make(chan broker.OrderUpdate, updateBuffer)
+	go func() {
+		defer close(updates)
+		ticker := time.NewTicker(restPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			orders, err := b.ListOrders(ctx, broker.OrderFilter{Scope: broker.OrderScopeAll})
+			if err != nil {
+				// Transient API errors are retried on the next tick.
+				continue
+			}
+			now := time.Now().UTC()
+			for _, o := range orders {
+				previous, ok := seen[o.ID]
+				if ok && previous.Status == o.Status && previous.FilledQuantity == o.FilledQuantity {
+					continue
+				}
+				seen[o.ID] = *o
+				select {
+				case updates <- broker.OrderUpdate{Order: *o, Time: now}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// do sends a request and decodes the JSON response into out.
+func (b *RESTBroker) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	u := *b.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal broker request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return fmt.Errorf("failed to create broker request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-API-Key", b.credentials.APIKey)
+	if b.credentials.APISecret != "" {
+		req.Header.Set("X-API-Secret", b.credentials.APISecret)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("broker request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		switch {
+		case resp.StatusCode == http.StatusNotFound && strings.HasPrefix(path, "/orders/"):
+			return broker.ErrOrderNotFound
+		case resp.StatusCode == http.StatusConflict && method == http.MethodDelete:
+			return broker.ErrOrderNotCancellable
+		}
+		return fmt.Errorf("broker returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode broker response: %w", err)
+	}
+	return nil
+}
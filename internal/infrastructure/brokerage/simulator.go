@@ -0,0 +1,394 @@
+package brokerage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"trading-alchemist/internal/domain/broker"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// simulatorPollInterval is how often resting orders are re-matched while
+	// order updates are streamed.
+	simulatorPollInterval = 5 * time.Second
+	// defaultOrderListLimit caps ListOrders when the filter sets no limit.
+	defaultOrderListLimit = 100
+	// updateBuffer is the number of order updates buffered per subscriber;
+	// updates for slow subscribers are dropped.
+	updateBuffer = 64
+	// quantityEpsilon absorbs floating point noise when comparing quantities and cash.
+	quantityEpsilon = 1e-9
+)
+
+// Simulator is a Broker that fills orders locally against the prices of a
+// PriceSource. Orders are filled in full or not at all, accounts are long only,
+// and state lives in memory for the lifetime of the simulator.
+//
+// Resting limit and stop orders are matched against the latest price whenever
+// the simulator is used, and periodically while order updates are streamed.
+type Simulator struct {
+	prices   broker.PriceSource
+	settings broker.SimulatorSettings
+
+	mu          sync.Mutex
+	cash        float64
+	positions   map[string]*simulatedPosition
+	orders      map[string]*broker.Order
+	byClientID  map[string]*broker.Order
+	subscribers map[chan broker.OrderUpdate]struct{}
+}
+
+type simulatedPosition struct {
+	quantity     float64
+	averagePrice float64
+}
+
+// NewSimulator creates a simulator funded with the settings' initial cash.
+func NewSimulator(settings broker.SimulatorSettings, prices broker.PriceSource) *Simulator {
+	return &Simulator{
+		prices:      prices,
+		settings:    settings,
+		cash:        settings.InitialCash,
+		positions:   make(map[string]*simulatedPosition),
+		orders:      make(map[string]*broker.Order),
+		byClientID:  make(map[string]*broker.Order),
+		subscribers: make(map[chan broker.OrderUpdate]struct{}),
+	}
+}
+
+func (s *Simulator) GetAccount(ctx context.Context) (*broker.Account, error) {
+	if err := s.match(ctx); err != nil {
+		return nil, err
+	}
+	positions, err := s.GetPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	cash := s.cash
+	s.mu.Unlock()
+
+	equity := cash
+	for _, p := range positions {
+		equity += p.MarketValue
+	}
+	return &broker.Account{
+		Currency:    s.settings.Currency,
+		Cash:        cash,
+		Equity:      equity,
+		BuyingPower: cash,
+	}, nil
+}
+
+func (s *Simulator) GetPositions(ctx context.Context) ([]*broker.Position, error) {
+	if err := s.match(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	positions := make([]*broker.Position, 0, len(s.positions))
+	for symbol, p := range s.positions {
+		positions = append(positions, &broker.Position{Symbol: symbol, Quantity: p.quantity, AveragePrice: p.averagePrice})
+	}
+	s.mu.Unlock()
+
+	for _, p := range positions {
+		price, err := s.prices.LastPrice(ctx, p.Symbol)
+		if err != nil {
+			if err != broker.ErrNoPrice {
+				return nil, err
+			}
+			// Without a price the position is valued at cost.
+			price = p.AveragePrice
+		}
+		p.MarketPrice = price
+		p.MarketValue = p.Quantity * price
+		p.UnrealizedPnL = p.Quantity * (price - p.AveragePrice)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Symbol < positions[j].Symbol })
+	return positions, nil
+}
+
+func (s *Simulator) PlaceOrder(ctx context.Context, req *broker.OrderRequest) (*broker.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if existing, ok := s.byClientID[req.ClientOrderID]; ok && req.ClientOrderID != "" {
+		order := *existing
+		s.mu.Unlock()
+		return &order, nil
+	}
+	s.mu.Unlock()
+
+	// Look the price up before taking the lock; price sources may hit the database.
+	last, err := s.prices.LastPrice(ctx, req.Symbol)
+	if err != nil && err != broker.ErrNoPrice {
+		return nil, err
+	}
+	hasPrice := err == nil
+
+	now := time.Now().UTC()
+	order := &broker.Order{
+		ID:            uuid.NewString(),
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		Quantity:      req.Quantity,
+		LimitPrice:    req.LimitPrice,
+		StopPrice:     req.StopPrice,
+		Status:        broker.OrderStatusOpen,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.byClientID[req.ClientOrderID]; ok && req.ClientOrderID != "" {
+		order := *existing
+		return &order, nil
+	}
+	s.orders[order.ID] = order
+	if order.ClientOrderID != "" {
+		s.byClientID[order.ClientOrderID] = order
+	}
+
+	switch {
+	case !hasPrice && order.Type == broker.OrderTypeMarket:
+		s.reject(order, broker.ErrNoPrice.Error(), now)
+	case hasPrice:
+		if price, ok := marketable(order, last); ok {
+			s.fill(order, price, now)
+		} else {
+			s.publish(order, now)
+		}
+	default:
+		s.publish(order, now)
+	}
+	result := *order
+	return &result, nil
+}
+
+func (s *Simulator) CancelOrder(ctx context.Context, orderID string) (*broker.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, ok := s.orders[orderID]
+	if !ok {
+		return nil, broker.ErrOrderNotFound
+	}
+	if order.Status.IsTerminal() {
+		return nil, broker.ErrOrderNotCancellable
+	}
+	now := time.Now().UTC()
+	order.Status = broker.OrderStatusCancelled
+	order.UpdatedAt = now
+	s.publish(order, now)
+
+	result := *order
+	return &result, nil
+}
+
+func (s *Simulator) GetOrder(ctx context.Context, orderID string) (*broker.Order, error) {
+	if err := s.match(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order, ok := s.orders[orderID]
+	if !ok {
+		return nil, broker.ErrOrderNotFound
+	}
+	result := *order
+	return &result, nil
+}
+
+func (s *Simulator) ListOrders(ctx context.Context, filter broker.OrderFilter) ([]*broker.Order, error) {
+	if err := s.match(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	var orders []*broker.Order
+	for _, o := range s.orders {
+		if filter.Matches(o) {
+			order := *o
+			orders = append(orders, &order)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(orders, func(i, j int) bool { return orders[i].CreatedAt.After(orders[j].CreatedAt) })
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultOrderListLimit
+	}
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+	return orders, nil
+}
+
+func (s *Simulator) StreamOrderUpdates(ctx context.Context) (<-chan broker.OrderUpdate, error) {
+	updates := make(chan broker.OrderUpdate, updateBuffer)
+	s.mu.Lock()
+	s.subscribers[updates] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(simulatorPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.mu.Lock()
+				delete(s.subscribers, updates)
+				close(updates)
+				s.mu.Unlock()
+				return
+			case <-ticker.C:
+				// Matching failures are retried on the next tick.
+				_ = s.match(ctx)
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// match fills resting orders that became marketable at the latest prices.
+func (s *Simulator) match(ctx context.Context) error {
+	s.mu.Lock()
+	symbols := make(map[string]bool)
+	for _, o := range s.orders {
+		if !o.Status.IsTerminal() {
+			symbols[o.Symbol] = true
+		}
+	}
+	s.mu.Unlock()
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	prices := make(map[string]float64, len(symbols))
+	for symbol := range symbols {
+		price, err := s.prices.LastPrice(ctx, symbol)
+		if err != nil {
+			if err == broker.ErrNoPrice {
+				continue
+			}
+			return err
+		}
+		prices[symbol] = price
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var open []*broker.Order
+	for _, o := range s.orders {
+		if !o.Status.IsTerminal() {
+			open = append(open, o)
+		}
+	}
+	// Match oldest first so earlier orders get the cash first.
+	sort.Slice(open, func(i, j int) bool { return open[i].CreatedAt.Before(open[j].CreatedAt) })
+	now := time.Now().UTC()
+	for _, o := range open {
+		last, ok := prices[o.Symbol]
+		if !ok {
+			continue
+		}
+		if price, ok := marketable(o, last); ok {
+			s.fill(o, price, now)
+		}
+	}
+	return nil
+}
+
+// fill books the order at price, or rejects it if cash or position do not
+// cover it. The caller must hold the lock.
+func (s *Simulator) fill(o *broker.Order, price float64, now time.Time) {
+	notional := o.Quantity * price
+	commission := notional * s.settings.CommissionRate
+	position := s.positions[o.Symbol]
+	if position == nil {
+		position = &simulatedPosition{}
+	}
+
+	switch o.Side {
+	case broker.SideBuy:
+		cost := notional + commission
+		if cost > s.cash+quantityEpsilon {
+			s.reject(o, broker.ErrInsufficientFunds.Error(), now)
+			return
+		}
+		quantity := position.quantity + o.Quantity
+		position.averagePrice = (position.quantity*position.averagePrice + notional) / quantity
+		position.quantity = quantity
+		s.cash -= cost
+	case broker.SideSell:
+		if o.Quantity > position.quantity+quantityEpsilon {
+			s.reject(o, broker.ErrInsufficientPosition.Error(), now)
+			return
+		}
+		position.quantity -= o.Quantity
+		s.cash += notional - commission
+	}
+
+	if position.quantity < quantityEpsilon {
+		delete(s.positions, o.Symbol)
+	} else {
+		s.positions[o.Symbol] = position
+	}
+
+	o.Status = broker.OrderStatusFilled
+	o.FilledQuantity = o.Quantity
+	o.AverageFillPrice = &price
+	o.Commission = commission
+	o.UpdatedAt = now
+	s.publish(o, now)
+}
+
+// reject marks the order rejected. The caller must hold the lock.
+func (s *Simulator) reject(o *broker.Order, reason string, now time.Time) {
+	o.Status = broker.OrderStatusRejected
+	o.RejectReason = &reason
+	o.UpdatedAt = now
+	s.publish(o, now)
+}
+
+// publish sends the order's current state to every subscriber without blocking.
+// The caller must hold the lock.
+func (s *Simulator) publish(o *broker.Order, now time.Time) {
+	update := broker.OrderUpdate{Order: *o, Time: now}
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- update:
+		default:
+		}
+	}
+}
+
+// marketable reports whether the order fills at the last traded price.
+func marketable(o *broker.Order, last float64) (float64, bool) {
+	switch o.Type {
+	case broker.OrderTypeMarket:
+		return last, true
+	case broker.OrderTypeLimit:
+		if (o.Side == broker.SideBuy && last <= *o.LimitPrice) || (o.Side == broker.SideSell && last >= *o.LimitPrice) {
+			return last, true
+		}
+	case broker.OrderTypeStop:
+		if (o.Side == broker.SideBuy && last >= *o.StopPrice) || (o.Side == broker.SideSell && last <= *o.StopPrice) {
+			return last, true
+		}
+	}
+	return 0, false
+}
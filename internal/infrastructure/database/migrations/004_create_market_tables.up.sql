@@ -0,0 +1,15 @@
+-- 1. Candles Table (OHLCV bars per symbol and timeframe)
+CREATE TABLE candles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    symbol VARCHAR(32) NOT NULL,
+    timeframe VARCHAR(8) NOT NULL, -- 1m, 5m, 15m, 30m, 1h, 4h, 1d, 1w
+    open_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    open DOUBLE PRECISION NOT NULL,
+    high DOUBLE PRECISION NOT NULL,
+    low DOUBLE PRECISION NOT NULL,
+    close DOUBLE PRECISION NOT NULL,
+    volume DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(symbol, timeframe, open_time)
+);
+CREATE INDEX idx_candles_symbol_timeframe_time ON candles (symbol, timeframe, open_time DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_artifacts_type;
+DROP INDEX IF EXISTS idx_artifacts_root_artifact_id;
+ALTER TABLE artifacts
+    DROP COLUMN IF EXISTS root_artifact_id,
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,6 @@
+-- Artifact versioning: editing an artifact creates a new row that points at the first version.
+ALTER TABLE artifacts
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN root_artifact_id UUID REFERENCES artifacts(id) ON DELETE CASCADE;
+CREATE INDEX idx_artifacts_root_artifact_id ON artifacts(root_artifact_id);
+CREATE INDEX idx_artifacts_type ON artifacts(type);
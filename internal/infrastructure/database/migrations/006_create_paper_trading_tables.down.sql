@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS update_paper_positions_updated_at ON paper_positions;
+DROP TRIGGER IF EXISTS update_paper_orders_updated_at ON paper_orders;
+DROP TRIGGER IF EXISTS update_paper_accounts_updated_at ON paper_accounts;
+
+DROP TABLE IF EXISTS paper_positions;
+DROP TABLE IF EXISTS paper_orders;
+DROP TABLE IF EXISTS paper_accounts;
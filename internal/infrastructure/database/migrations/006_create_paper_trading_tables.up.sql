@@ -0,0 +1,53 @@
+-- 1. Paper Accounts Table (simulated cash accounts per user)
+CREATE TABLE paper_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    timeframe VARCHAR(8) NOT NULL DEFAULT '1m', -- Candles the matching engine fills against
+    initial_cash DOUBLE PRECISION NOT NULL,
+    cash DOUBLE PRECISION NOT NULL,
+    commission_rate DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+CREATE INDEX idx_paper_accounts_user_id ON paper_accounts (user_id);
+
+-- 2. Paper Orders Table
+CREATE TABLE paper_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES paper_accounts(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    side VARCHAR(8) NOT NULL, -- buy, sell
+    type VARCHAR(16) NOT NULL, -- market, limit, stop
+    quantity DOUBLE PRECISION NOT NULL,
+    limit_price DOUBLE PRECISION,
+    stop_price DOUBLE PRECISION,
+    status VARCHAR(16) NOT NULL DEFAULT 'open', -- open, filled, cancelled, rejected
+    fill_price DOUBLE PRECISION,
+    commission DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reject_reason TEXT,
+    filled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_paper_orders_account_id ON paper_orders (account_id, created_at DESC);
+CREATE INDEX idx_paper_orders_open ON paper_orders (account_id) WHERE status = 'open';
+
+-- 3. Paper Positions Table (one row per account and symbol, kept after closing for realized P&L)
+CREATE TABLE paper_positions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID NOT NULL REFERENCES paper_accounts(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    average_price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    realized_pnl DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(account_id, symbol)
+);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_paper_accounts_updated_at BEFORE UPDATE ON paper_accounts FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_paper_orders_updated_at BEFORE UPDATE ON paper_orders FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_paper_positions_updated_at BEFORE UPDATE ON paper_positions FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_portfolio_assets_updated_at ON portfolio_assets;
+DROP TRIGGER IF EXISTS update_portfolios_updated_at ON portfolios;
+
+DROP TABLE IF EXISTS portfolio_assets;
+DROP TABLE IF EXISTS portfolio_transactions;
+DROP TABLE IF EXISTS portfolios;
@@ -0,0 +1,48 @@
+-- 1. Portfolios Table (real holdings tracked by a user)
+CREATE TABLE portfolios (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    base_currency VARCHAR(8) NOT NULL DEFAULT 'USD',
+    benchmark_symbol VARCHAR(32), -- Default benchmark for beta
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+CREATE INDEX idx_portfolios_user_id ON portfolios (user_id);
+
+-- 2. Portfolio Transactions Table (the ledger holdings and cash are derived from)
+CREATE TABLE portfolio_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    portfolio_id UUID NOT NULL REFERENCES portfolios(id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL, -- buy, sell, dividend, deposit, withdrawal, fee
+    symbol VARCHAR(32) NOT NULL DEFAULT '', -- Empty for cash-only transactions
+    quantity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    price DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fee DOUBLE PRECISION NOT NULL DEFAULT 0,
+    amount DOUBLE PRECISION NOT NULL DEFAULT 0, -- Cash amount of dividends, deposits, withdrawals and fees
+    executed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    notes TEXT,
+    source VARCHAR(16) NOT NULL DEFAULT 'manual', -- manual, csv
+    external_id VARCHAR(64), -- Fingerprint of imported rows so re-imports are idempotent
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(portfolio_id, external_id)
+);
+CREATE INDEX idx_portfolio_transactions_portfolio_id ON portfolio_transactions (portfolio_id, executed_at);
+
+-- 3. Portfolio Assets Table (user classification of the instruments held)
+CREATE TABLE portfolio_assets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    portfolio_id UUID NOT NULL REFERENCES portfolios(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    name VARCHAR(255),
+    asset_class VARCHAR(32), -- equity, etf, bond, crypto, commodity, ...
+    sector VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(portfolio_id, symbol)
+);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_portfolios_updated_at BEFORE UPDATE ON portfolios FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_portfolio_assets_updated_at BEFORE UPDATE ON portfolio_assets FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_alert_rule_states_updated_at ON alert_rule_states;
+DROP TRIGGER IF EXISTS update_alert_rules_updated_at ON alert_rules;
+DROP TRIGGER IF EXISTS update_watchlists_updated_at ON watchlists;
+
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS alert_events;
+DROP TABLE IF EXISTS alert_rule_states;
+DROP TABLE IF EXISTS alert_rules;
+DROP TABLE IF EXISTS watchlist_items;
+DROP TABLE IF EXISTS watchlists;
@@ -0,0 +1,94 @@
+-- 1. Watchlists Table
+CREATE TABLE watchlists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+CREATE INDEX idx_watchlists_user_id ON watchlists (user_id);
+
+-- 2. Watchlist Items Table
+CREATE TABLE watchlist_items (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    watchlist_id UUID NOT NULL REFERENCES watchlists(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(watchlist_id, symbol)
+);
+
+-- 3. Alert Rules Table (targets a single symbol or every symbol of a watchlist)
+CREATE TABLE alert_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    symbol VARCHAR(32),
+    watchlist_id UUID REFERENCES watchlists(id) ON DELETE CASCADE,
+    timeframe VARCHAR(8) NOT NULL DEFAULT '1m', -- Candles the rule is evaluated against
+    type VARCHAR(16) NOT NULL, -- price_cross, percent_move, indicator
+    direction VARCHAR(8) NOT NULL DEFAULT 'any', -- above, below, up, down, any
+    level DOUBLE PRECISION,
+    percent DOUBLE PRECISION,
+    window_seconds INTEGER,
+    indicator_condition JSONB, -- Strategy DSL condition
+    cooldown_seconds INTEGER NOT NULL DEFAULT 3600,
+    notify_email BOOLEAN NOT NULL DEFAULT FALSE,
+    notify_in_app BOOLEAN NOT NULL DEFAULT TRUE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK ((symbol IS NULL) <> (watchlist_id IS NULL))
+);
+CREATE INDEX idx_alert_rules_user_id ON alert_rules (user_id);
+CREATE INDEX idx_alert_rules_enabled ON alert_rules (timeframe) WHERE enabled;
+
+-- 4. Alert Rule States Table (evaluation progress per rule and symbol)
+CREATE TABLE alert_rule_states (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    last_candle_time TIMESTAMP WITH TIME ZONE NOT NULL, -- Open time of the last evaluated candle
+    active BOOLEAN NOT NULL DEFAULT FALSE, -- Whether the condition held on that candle
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(rule_id, symbol)
+);
+
+-- 5. Alert Events Table (history of triggered alerts)
+CREATE TABLE alert_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    rule_id UUID NOT NULL REFERENCES alert_rules(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    timeframe VARCHAR(8) NOT NULL,
+    candle_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    message TEXT NOT NULL,
+    email_status VARCHAR(16) NOT NULL DEFAULT 'skipped', -- pending, sent, failed, skipped
+    triggered_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(rule_id, symbol, candle_time)
+);
+CREATE INDEX idx_alert_events_user_id ON alert_events (user_id, triggered_at DESC);
+CREATE INDEX idx_alert_events_rule_id ON alert_events (rule_id, triggered_at DESC);
+
+-- 6. Notifications Table (in-app notification feed)
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL, -- alert
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    data JSONB,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_notifications_user_id ON notifications (user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+-- Triggers for updated_at
+CREATE TRIGGER update_watchlists_updated_at BEFORE UPDATE ON watchlists FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_alert_rules_updated_at BEFORE UPDATE ON alert_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_alert_rule_states_updated_at BEFORE UPDATE ON alert_rule_states FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
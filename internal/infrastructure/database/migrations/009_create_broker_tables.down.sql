@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_broker_connections_updated_at ON broker_connections;
+
+DROP TABLE IF EXISTS broker_connections;
@@ -0,0 +1,18 @@
+-- 1. Broker Connections Table
+CREATE TABLE broker_connections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL, -- simulator, rest
+    encrypted_credentials TEXT, -- AES-GCM encrypted JSON credentials
+    api_base_override TEXT,
+    settings JSONB NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+CREATE INDEX idx_broker_connections_user_id ON broker_connections (user_id);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_broker_connections_updated_at BEFORE UPDATE ON broker_connections FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
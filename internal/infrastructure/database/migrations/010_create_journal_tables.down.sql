@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_journal_entries_updated_at ON journal_entries;
+
+DROP TABLE IF EXISTS journal_screenshots;
+DROP TABLE IF EXISTS journal_entries;
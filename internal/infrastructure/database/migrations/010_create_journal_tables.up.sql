@@ -0,0 +1,35 @@
+-- 1. Journal Entries Table
+CREATE TABLE journal_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    symbol VARCHAR(32) NOT NULL,
+    side VARCHAR(8) NOT NULL, -- long, short
+    quantity DOUBLE PRECISION NOT NULL,
+    entry_price DOUBLE PRECISION NOT NULL,
+    entry_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    exit_price DOUBLE PRECISION, -- NULL while the trade is open
+    exit_time TIMESTAMP WITH TIME ZONE,
+    fees DOUBLE PRECISION NOT NULL DEFAULT 0,
+    setup VARCHAR(255),
+    thesis TEXT,
+    notes TEXT,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    conversation_id UUID REFERENCES conversations(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK ((exit_price IS NULL) = (exit_time IS NULL))
+);
+CREATE INDEX idx_journal_entries_user_entry_time ON journal_entries (user_id, entry_time DESC);
+CREATE INDEX idx_journal_entries_conversation_id ON journal_entries (conversation_id);
+CREATE INDEX idx_journal_entries_tags ON journal_entries USING GIN (tags);
+
+-- 2. Journal Screenshots Table (image artifacts attached to an entry)
+CREATE TABLE journal_screenshots (
+    entry_id UUID NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    artifact_id UUID NOT NULL REFERENCES artifacts(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    PRIMARY KEY (entry_id, artifact_id)
+);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_journal_entries_updated_at BEFORE UPDATE ON journal_entries FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_calendar_events_updated_at ON calendar_events;
+
+DROP TABLE IF EXISTS calendar_events;
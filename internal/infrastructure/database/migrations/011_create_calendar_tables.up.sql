@@ -0,0 +1,24 @@
+-- 1. Calendar Events Table (earnings dates, macro releases and corporate actions)
+CREATE TABLE calendar_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE, -- NULL for events shared with all users
+    type VARCHAR(16) NOT NULL, -- earnings, macro, dividend, split
+    symbol VARCHAR(32), -- NULL for macro events
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL, -- ex-date for dividends and splits
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    importance VARCHAR(8) NOT NULL DEFAULT 'medium', -- low, medium, high
+    amount DOUBLE PRECISION, -- dividend per share
+    ratio DOUBLE PRECISION, -- new shares per old share for splits
+    source VARCHAR(16) NOT NULL, -- manual, ics, csv
+    external_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE NULLS NOT DISTINCT (user_id, source, external_id)
+);
+CREATE INDEX idx_calendar_events_scheduled_at ON calendar_events (scheduled_at);
+CREATE INDEX idx_calendar_events_symbol_scheduled_at ON calendar_events (symbol, scheduled_at);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_calendar_events_updated_at BEFORE UPDATE ON calendar_events FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_documents_updated_at ON documents;
+
+DROP TABLE IF EXISTS document_chunks;
+DROP TABLE IF EXISTS documents;
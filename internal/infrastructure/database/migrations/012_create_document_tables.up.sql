@@ -0,0 +1,43 @@
+-- pgvector speeds up similarity search when it is installed; without it the
+-- application keeps an in-memory index instead.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'vector') THEN
+        CREATE EXTENSION IF NOT EXISTS vector;
+    END IF;
+END
+$$;
+
+-- 1. Documents Table (uploaded files whose text is indexed for retrieval)
+CREATE TABLE documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    format VARCHAR(16) NOT NULL, -- pdf, markdown, html, csv, text
+    size BIGINT NOT NULL, -- bytes of the uploaded file
+    content_hash VARCHAR(64) NOT NULL, -- SHA-256 of the extracted text
+    status VARCHAR(16) NOT NULL DEFAULT 'processing', -- processing, ready, failed
+    error TEXT,
+    chunk_count INTEGER NOT NULL DEFAULT 0,
+    embedding_model VARCHAR(100), -- model the chunks were embedded with
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_documents_user_id_created_at ON documents (user_id, created_at DESC);
+
+-- 2. Document Chunks Table (passages of a document and their embeddings)
+CREATE TABLE document_chunks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    chunk_index INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    page INTEGER, -- 1-based page of PDF documents
+    heading TEXT, -- nearest heading of Markdown and HTML documents
+    embedding REAL[], -- NULL until embedded
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (document_id, chunk_index)
+);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_documents_updated_at BEFORE UPDATE ON documents FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
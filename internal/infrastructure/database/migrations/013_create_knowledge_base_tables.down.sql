@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_knowledge_bases_updated_at ON knowledge_bases;
+
+DROP TABLE IF EXISTS conversation_knowledge_bases;
+DROP TABLE IF EXISTS knowledge_base_documents;
+DROP TABLE IF EXISTS knowledge_base_members;
+DROP TABLE IF EXISTS knowledge_bases;
@@ -0,0 +1,41 @@
+-- 1. Knowledge Bases Table (named collections of documents)
+CREATE TABLE knowledge_bases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- owner
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_knowledge_bases_user_id ON knowledge_bases (user_id);
+
+-- 2. Knowledge Base Members Table (users the owner shared a knowledge base with)
+CREATE TABLE knowledge_base_members (
+    knowledge_base_id UUID NOT NULL REFERENCES knowledge_bases(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL, -- viewer, editor
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (knowledge_base_id, user_id)
+);
+CREATE INDEX idx_knowledge_base_members_user_id ON knowledge_base_members (user_id);
+
+-- 3. Knowledge Base Documents Table (a document can be in several knowledge bases)
+CREATE TABLE knowledge_base_documents (
+    knowledge_base_id UUID NOT NULL REFERENCES knowledge_bases(id) ON DELETE CASCADE,
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (knowledge_base_id, document_id)
+);
+CREATE INDEX idx_knowledge_base_documents_document_id ON knowledge_base_documents (document_id);
+
+-- 4. Conversation Knowledge Bases Table (knowledge bases searched for a conversation)
+CREATE TABLE conversation_knowledge_bases (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    knowledge_base_id UUID NOT NULL REFERENCES knowledge_bases(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, knowledge_base_id)
+);
+CREATE INDEX idx_conversation_knowledge_bases_knowledge_base_id ON conversation_knowledge_bases (knowledge_base_id);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_knowledge_bases_updated_at BEFORE UPDATE ON knowledge_bases FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
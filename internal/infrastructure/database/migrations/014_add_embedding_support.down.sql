@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS embedding_cache;
+
+ALTER TABLE models
+    DROP COLUMN IF EXISTS supports_embeddings;
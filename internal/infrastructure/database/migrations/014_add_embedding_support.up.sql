@@ -0,0 +1,13 @@
+-- Embedding models are listed alongside chat models but can only be used for embeddings.
+ALTER TABLE models
+    ADD COLUMN supports_embeddings BOOLEAN DEFAULT FALSE;
+
+-- Embedding Cache Table (embeddings keyed by model and the SHA-256 of the input text)
+CREATE TABLE embedding_cache (
+    model VARCHAR(512) NOT NULL, -- provider/model, plus the API base when overridden
+    content_hash VARCHAR(64) NOT NULL,
+    embedding REAL[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (model, content_hash)
+);
+CREATE INDEX idx_embedding_cache_created_at ON embedding_cache (created_at);
@@ -0,0 +1,10 @@
+-- Message Embeddings Table (chat messages embedded for semantic search over past conversations)
+CREATE TABLE message_embeddings (
+    message_id UUID PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    embedding_model VARCHAR(100) NOT NULL,
+    embedding REAL[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_message_embeddings_user_id_model ON message_embeddings (user_id, embedding_model);
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_user_memories_updated_at ON user_memories;
+
+DROP TABLE IF EXISTS user_memories;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS memory_enabled;
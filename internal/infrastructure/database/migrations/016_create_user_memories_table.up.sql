@@ -0,0 +1,18 @@
+-- Users can opt out of long-term memory; nothing is remembered or recalled for them.
+ALTER TABLE users
+    ADD COLUMN memory_enabled BOOLEAN DEFAULT TRUE;
+
+-- User Memories Table (stable facts about the user extracted from conversations)
+CREATE TABLE user_memories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category VARCHAR(32) NOT NULL, -- risk_tolerance, markets, time_zone, trading_style, goals, other
+    content TEXT NOT NULL,
+    source_conversation_id UUID REFERENCES conversations(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_user_memories_user_id_updated_at ON user_memories (user_id, updated_at DESC);
+
+-- Triggers for updated_at
+CREATE TRIGGER update_user_memories_updated_at BEFORE UPDATE ON user_memories FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,20 @@
+-- Sessions Table (one row per refresh token; rotating a token adds a row to the same family)
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL, -- All tokens descending from one sign-in
+    refresh_token_hash VARCHAR(64) UNIQUE NOT NULL,
+    device_name VARCHAR(255),
+    ip_address INET,
+    user_agent TEXT,
+    authenticated_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Sign-in time of the family
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    rotated_at TIMESTAMP WITH TIME ZONE, -- Set once the token has been exchanged for a new one
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX idx_sessions_family_id ON sessions(family_id);
+CREATE INDEX idx_sessions_expires_at ON sessions(expires_at);
@@ -0,0 +1,27 @@
+-- Identities Table (accounts at external OIDC/OAuth providers linked to users)
+CREATE TABLE identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL, -- Configured provider name, e.g. google, github
+    subject VARCHAR(255) NOT NULL, -- Stable user ID at the provider
+    email VARCHAR(255), -- Email reported by the provider at the last sign-in
+    last_login_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_identities_user_id ON identities(user_id);
+
+-- OIDC Login Requests Table (pending authorization requests, consumed by the callback)
+CREATE TABLE oidc_login_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    provider VARCHAR(50) NOT NULL,
+    state_hash VARCHAR(64) UNIQUE NOT NULL,
+    nonce VARCHAR(255) NOT NULL,
+    code_verifier VARCHAR(255) NOT NULL, -- PKCE verifier; never leaves the server
+    redirect_url TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_oidc_login_requests_expires_at ON oidc_login_requests(expires_at);
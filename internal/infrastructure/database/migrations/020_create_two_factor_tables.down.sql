@@ -0,0 +1,5 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS second_factor_at;
+DROP TABLE IF EXISTS two_factor_challenges;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS webauthn_credentials;
+DROP TABLE IF EXISTS totp_secrets;
@@ -0,0 +1,55 @@
+-- TOTP Secrets Table (one authenticator app per user)
+CREATE TABLE totp_secrets (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    encrypted_secret TEXT NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE, -- NULL until the user enters a first code
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- Time step of the last accepted code, so each code works once
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- WebAuthn Credentials Table (security keys and passkeys)
+CREATE TABLE webauthn_credentials (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    credential_id BYTEA UNIQUE NOT NULL,
+    public_key BYTEA NOT NULL, -- COSE_Key from the attested credential data
+    sign_count BIGINT NOT NULL DEFAULT 0,
+    transports TEXT[] NOT NULL DEFAULT '{}',
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_webauthn_credentials_user_id ON webauthn_credentials(user_id);
+
+-- Recovery Codes Table (single-use codes for when no other factor is at hand)
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes(user_id);
+
+-- Two-Factor Challenges Table (pending second factor checks)
+CREATE TABLE two_factor_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(30) NOT NULL, -- login, step_up or webauthn_registration
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    webauthn_challenge BYTEA, -- Challenge signed by the authenticator
+    session_id UUID REFERENCES sessions(id) ON DELETE CASCADE, -- step_up: session to mark as verified
+    device_name VARCHAR(255), -- login: details of the session to start
+    ip_address INET,
+    user_agent TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_two_factor_challenges_expires_at ON two_factor_challenges(expires_at);
+
+-- Time of the last second factor check of a session, for actions requiring a recent one
+ALTER TABLE sessions ADD COLUMN second_factor_at TIMESTAMP WITH TIME ZONE;
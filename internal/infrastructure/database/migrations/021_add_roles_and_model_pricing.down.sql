@@ -0,0 +1,8 @@
+ALTER TABLE models
+    DROP COLUMN IF EXISTS output_price_per_million,
+    DROP COLUMN IF EXISTS input_price_per_million;
+
+DROP INDEX IF EXISTS idx_users_role;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,9 @@
+-- Users are either regular users or admins, who manage providers, models, tools and users.
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin'));
+CREATE INDEX idx_users_role ON users(role);
+
+-- Model pricing in USD per million tokens; NULL when unknown.
+ALTER TABLE models
+    ADD COLUMN input_price_per_million NUMERIC(12, 6) CHECK (input_price_per_million >= 0),
+    ADD COLUMN output_price_per_million NUMERIC(12, 6) CHECK (output_price_per_million >= 0);
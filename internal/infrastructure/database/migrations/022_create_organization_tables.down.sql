@@ -0,0 +1,19 @@
+DROP TRIGGER IF EXISTS update_organizations_updated_at ON organizations;
+
+DROP INDEX IF EXISTS idx_conversations_organization_last_message;
+ALTER TABLE conversations
+    DROP COLUMN IF EXISTS organization_permission,
+    DROP COLUMN IF EXISTS organization_id;
+
+DELETE FROM user_provider_settings WHERE organization_id IS NOT NULL;
+ALTER TABLE user_provider_settings
+    DROP CONSTRAINT IF EXISTS user_provider_settings_organization_id_provider_id_key,
+    DROP CONSTRAINT IF EXISTS user_provider_settings_owner_check,
+    DROP COLUMN IF EXISTS organization_id,
+    ALTER COLUMN user_id SET NOT NULL;
+
+DELETE FROM magic_links WHERE purpose = 'organization_invite';
+
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,46 @@
+-- 1. Organizations Table (workspaces shared by a team)
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- 2. Organization Members Table
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+CREATE INDEX idx_organization_members_user_id ON organization_members (user_id);
+
+-- 3. Organization Invitations Table (pending invitations, accepted through an organization_invite magic link)
+CREATE TABLE organization_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- invited user, created on invitation if needed
+    role VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    magic_link_id UUID NOT NULL REFERENCES magic_links(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (organization_id, user_id)
+);
+CREATE INDEX idx_organization_invitations_magic_link_id ON organization_invitations (magic_link_id);
+
+-- 4. Provider settings are owned by a user or by an organization, whose key serves all its members
+ALTER TABLE user_provider_settings
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE,
+    ADD CONSTRAINT user_provider_settings_owner_check CHECK ((user_id IS NULL) <> (organization_id IS NULL)),
+    ADD CONSTRAINT user_provider_settings_organization_id_provider_id_key UNIQUE (organization_id, provider_id);
+
+-- 5. Conversations can belong to an organization, whose members get read or write access
+ALTER TABLE conversations
+    ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    ADD COLUMN organization_permission VARCHAR(10) NOT NULL DEFAULT 'read' CHECK (organization_permission IN ('read', 'write'));
+CREATE INDEX idx_conversations_organization_last_message ON conversations (organization_id, last_message_at DESC) WHERE organization_id IS NOT NULL;
+
+-- Triggers for updated_at
+CREATE TRIGGER update_organizations_updated_at BEFORE UPDATE ON organizations FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
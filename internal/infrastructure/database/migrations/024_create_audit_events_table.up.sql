@@ -0,0 +1,34 @@
+-- Append-only log of security-relevant actions. Users are referenced without
+-- foreign keys so the log outlives the accounts it mentions.
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    action VARCHAR(100) NOT NULL,
+    actor_id UUID,
+    user_id UUID,
+    resource_type VARCHAR(50),
+    resource_id UUID,
+    ip_address INET,
+    user_agent TEXT,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_events_created_at ON audit_events (created_at DESC);
+CREATE INDEX idx_audit_events_user_id ON audit_events (user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_audit_events_actor_id ON audit_events (actor_id, created_at DESC) WHERE actor_id IS NOT NULL;
+CREATE INDEX idx_audit_events_action ON audit_events (action, created_at DESC);
+
+CREATE OR REPLACE FUNCTION reject_audit_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_event_changes();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_event_changes();
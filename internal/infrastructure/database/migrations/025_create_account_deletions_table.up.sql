@@ -0,0 +1,7 @@
+-- Account Deletions Table (accounts purged with all their data once the grace period is over)
+CREATE TABLE account_deletions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+CREATE INDEX idx_account_deletions_scheduled_for ON account_deletions (scheduled_for);
@@ -3,6 +3,8 @@ package seeder
 import (
 	"context"
 	"log"
+	"strings"
+	"trading-alchemist/internal/domain/auth"
 	"trading-alchemist/internal/domain/chat"
 	"trading-alchemist/internal/infrastructure/database"
 	"trading-alchemist/pkg/errors"
@@ -17,10 +19,12 @@ type ProviderSeed struct {
 }
 
 type ModelSeed struct {
-	Name           string
-	DisplayName    string
-	SupportsVision bool
-	IsActive       bool
+	Name               string
+	DisplayName        string
+	SupportsFunctions  bool
+	SupportsVision     bool
+	SupportsEmbeddings bool
+	IsActive           bool
 }
 
 var seeds = []ProviderSeed{
@@ -28,22 +32,35 @@ var seeds = []ProviderSeed{
 		Name:        "openai",
 		DisplayName: "OpenAI",
 		Models: []ModelSeed{
-			{Name: "gpt-4o", DisplayName: "GPT-4o", SupportsVision: true, IsActive: true},
-			{Name: "gpt-4o-mini", DisplayName: "GPT-4o Mini", SupportsVision: true, IsActive: true},
-			{Name: "gpt-4.1", DisplayName: "GPT-4.1", SupportsVision: true, IsActive: true}, // Assuming GPT-4.1 supports vision based on sources
-			{Name: "gpt-4.1-mini", DisplayName: "GPT-4.1 Mini", SupportsVision: true, IsActive: true},
-			{Name: "o3", DisplayName: "OpenAI o3", SupportsVision: true, IsActive: true},
-			{Name: "o3-pro", DisplayName: "OpenAI o3-pro", SupportsVision: true, IsActive: true},
-			{Name: "o4-mini", DisplayName: "OpenAI o4-mini", SupportsVision: true, IsActive: true},
+			{Name: "gpt-4o", DisplayName: "GPT-4o", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "gpt-4o-mini", DisplayName: "GPT-4o Mini", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "gpt-4.1", DisplayName: "GPT-4.1", SupportsFunctions: true, SupportsVision: true, IsActive: true}, // Assuming GPT-4.1 supports vision based on sources
+			{Name: "gpt-4.1-mini", DisplayName: "GPT-4.1 Mini", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "o3", DisplayName: "OpenAI o3", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "o3-pro", DisplayName: "OpenAI o3-pro", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "o4-mini", DisplayName: "OpenAI o4-mini", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "text-embedding-3-small", DisplayName: "Text Embedding 3 Small", SupportsEmbeddings: true, IsActive: true},
+			{Name: "text-embedding-3-large", DisplayName: "Text Embedding 3 Large", SupportsEmbeddings: true, IsActive: true},
 		},
 	},
 	{
 		Name:        "google",
 		DisplayName: "Google",
 		Models: []ModelSeed{
-			{Name: "gemini-2.5-pro", DisplayName: "Gemini 2.5 Pro", SupportsVision: true, IsActive: true},
-			{Name: "gemini-2.5-flash", DisplayName: "Gemini 2.5 Flash", SupportsVision: true, IsActive: true},
-			{Name: "gemini-2.5-flash-lite", DisplayName: "Gemini 2.5 Flash-Lite", SupportsVision: true, IsActive: true},
+			{Name: "gemini-2.5-pro", DisplayName: "Gemini 2.5 Pro", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "gemini-2.5-flash", DisplayName: "Gemini 2.5 Flash", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "gemini-2.5-flash-lite", DisplayName: "Gemini 2.5 Flash-Lite", SupportsFunctions: true, SupportsVision: true, IsActive: true},
+			{Name: "gemini-embedding-001", DisplayName: "Gemini Embedding", SupportsEmbeddings: true, IsActive: true},
+			{Name: "text-embedding-004", DisplayName: "Text Embedding 004", SupportsEmbeddings: true, IsActive: true},
+		},
+	},
+	{
+		// Self-hosted servers that speak the OpenAI API (Ollama, vLLM, LM Studio, ...).
+		// Users point the provider at their server with an API base override.
+		Name:        "openai_compatible",
+		DisplayName: "OpenAI-compatible",
+		Models: []ModelSeed{
+			{Name: "nomic-embed-text", DisplayName: "Nomic Embed Text", SupportsEmbeddings: true, IsActive: true},
 		},
 	},
 }
@@ -90,11 +107,13 @@ func Seed(dbService *database.Service) {
 				}
 
 				newModel := &chat.Model{
-					ProviderID:     providerID,
-					Name:           mSeed.Name,
-					DisplayName:    mSeed.DisplayName,
-					SupportsVision: mSeed.SupportsVision,
-					IsActive:       mSeed.IsActive,
+					ProviderID:         providerID,
+					Name:               mSeed.Name,
+					DisplayName:        mSeed.DisplayName,
+					SupportsFunctions:  mSeed.SupportsFunctions,
+					SupportsVision:     mSeed.SupportsVision,
+					SupportsEmbeddings: mSeed.SupportsEmbeddings,
+					IsActive:           mSeed.IsActive,
 				}
 				_, err = modelRepo.CreateModel(context.Background(), newModel)
 				if err != nil {
@@ -111,4 +130,24 @@ func Seed(dbService *database.Service) {
 	}
 
 	log.Println("Seeding completed.")
-}
\ No newline at end of file
+}
+
+// GrantAdmin gives an existing user the admin role. The first admin is created
+// this way; later ones can be appointed through the admin API.
+func GrantAdmin(dbService *database.Service, email string) {
+	ctx := context.Background()
+	err := dbService.ExecuteInTx(ctx, func(repoProvider database.RepositoryProvider) error {
+		user, err := repoProvider.User().GetByEmail(ctx, strings.TrimSpace(email))
+		if err != nil {
+			return err
+		}
+		_, err = repoProvider.User().UpdateRole(ctx, user.ID, auth.RoleAdmin)
+		return err
+	})
+
+	if err != nil {
+		log.Fatalf("Failed to grant the admin role to %s: %v", email, err)
+	}
+
+	log.Printf("Granted the admin role to %s.", email)
+}
@@ -4,10 +4,36 @@ import (
 	"context"
 	"fmt"
 
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/domain/audit"
 	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/broker"
+	"trading-alchemist/internal/domain/calendar"
 	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/domain/encryption"
+	"trading-alchemist/internal/domain/journal"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/notification"
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/domain/paper"
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/domain/ratelimit"
+	alertRepo "trading-alchemist/internal/infrastructure/repositories/postgres/alert"
+	auditRepo "trading-alchemist/internal/infrastructure/repositories/postgres/audit"
 	authRepo "trading-alchemist/internal/infrastructure/repositories/postgres/auth"
+	brokerRepo "trading-alchemist/internal/infrastructure/repositories/postgres/broker"
+	calendarRepo "trading-alchemist/internal/infrastructure/repositories/postgres/calendar"
 	chatRepo "trading-alchemist/internal/infrastructure/repositories/postgres/chat"
+	documentRepo "trading-alchemist/internal/infrastructure/repositories/postgres/document"
+	encryptionRepo "trading-alchemist/internal/infrastructure/repositories/postgres/encryption"
+	journalRepo "trading-alchemist/internal/infrastructure/repositories/postgres/journal"
+	marketRepo "trading-alchemist/internal/infrastructure/repositories/postgres/market"
+	notificationRepo "trading-alchemist/internal/infrastructure/repositories/postgres/notification"
+	organizationRepo "trading-alchemist/internal/infrastructure/repositories/postgres/organization"
+	paperRepo "trading-alchemist/internal/infrastructure/repositories/postgres/paper"
+	portfolioRepo "trading-alchemist/internal/infrastructure/repositories/postgres/portfolio"
+	ratelimitRepo "trading-alchemist/internal/infrastructure/repositories/postgres/ratelimit"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -27,13 +53,47 @@ type DBTX interface {
 type RepositoryProvider interface {
 	User() auth.UserRepository
 	MagicLink() auth.MagicLinkRepository
+	Session() auth.SessionRepository
+	APIToken() auth.APITokenRepository
+	Identity() auth.IdentityRepository
+	OIDCLoginRequest() auth.OIDCLoginRequestRepository
+	TwoFactor() auth.TwoFactorRepository
+	TwoFactorChallenge() auth.TwoFactorChallengeRepository
+	AccountDeletion() auth.AccountDeletionRepository
 	Provider() chat.ProviderRepository
 	UserProviderSetting() chat.UserProviderSettingRepository
 	Conversation() chat.ConversationRepository
 	Message() chat.MessageRepository
+	MessageEmbedding() chat.MessageEmbeddingRepository
 	Artifact() chat.ArtifactRepository
 	Tool() chat.ToolRepository
 	Model() chat.ModelRepository
+	EmbeddingCache() chat.EmbeddingCacheRepository
+	UserMemory() chat.UserMemoryRepository
+	Candle() market.CandleRepository
+	PaperAccount() paper.AccountRepository
+	PaperOrder() paper.OrderRepository
+	PaperPosition() paper.PositionRepository
+	Portfolio() portfolio.PortfolioRepository
+	PortfolioTransaction() portfolio.TransactionRepository
+	PortfolioAsset() portfolio.AssetRepository
+	Watchlist() alert.WatchlistRepository
+	WatchlistItem() alert.WatchlistItemRepository
+	AlertRule() alert.RuleRepository
+	AlertRuleState() alert.StateRepository
+	AlertEvent() alert.EventRepository
+	Notification() notification.NotificationRepository
+	BrokerConnection() broker.ConnectionRepository
+	JournalEntry() journal.EntryRepository
+	CalendarEvent() calendar.EventRepository
+	Document() document.DocumentRepository
+	DocumentChunk() document.ChunkRepository
+	KnowledgeBase() document.KnowledgeBaseRepository
+	Organization() organization.OrganizationRepository
+	OrganizationInvitation() organization.InvitationRepository
+	RateLimitBucket() ratelimit.BucketRepository
+	AuditEvent() audit.EventRepository
+	Secret(kind encryption.SecretKind) encryption.SecretRepository
 }
 
 // transactionalRepositoryProvider provides repositories that are bound to a specific database transaction.
@@ -54,6 +114,34 @@ func (p *transactionalRepositoryProvider) MagicLink() auth.MagicLinkRepository {
 	return authRepo.NewMagicLinkRepository(p.tx)
 }
 
+func (p *transactionalRepositoryProvider) Session() auth.SessionRepository {
+	return authRepo.NewSessionRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) APIToken() auth.APITokenRepository {
+	return authRepo.NewAPITokenRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Identity() auth.IdentityRepository {
+	return authRepo.NewIdentityRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) OIDCLoginRequest() auth.OIDCLoginRequestRepository {
+	return authRepo.NewOIDCLoginRequestRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) TwoFactor() auth.TwoFactorRepository {
+	return authRepo.NewTwoFactorRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) TwoFactorChallenge() auth.TwoFactorChallengeRepository {
+	return authRepo.NewTwoFactorChallengeRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) AccountDeletion() auth.AccountDeletionRepository {
+	return authRepo.NewAccountDeletionRepository(p.tx)
+}
+
 func (p *transactionalRepositoryProvider) Conversation() chat.ConversationRepository {
 	return chatRepo.NewConversationRepository(p.tx)
 }
@@ -82,6 +170,114 @@ func (trp *transactionalRepositoryProvider) Model() chat.ModelRepository {
 	return chatRepo.NewModelRepository(trp.tx)
 }
 
+func (p *transactionalRepositoryProvider) MessageEmbedding() chat.MessageEmbeddingRepository {
+	return chatRepo.NewMessageEmbeddingRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) EmbeddingCache() chat.EmbeddingCacheRepository {
+	return chatRepo.NewEmbeddingCacheRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) UserMemory() chat.UserMemoryRepository {
+	return chatRepo.NewUserMemoryRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Candle() market.CandleRepository {
+	return marketRepo.NewCandleRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) PaperAccount() paper.AccountRepository {
+	return paperRepo.NewAccountRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) PaperOrder() paper.OrderRepository {
+	return paperRepo.NewOrderRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) PaperPosition() paper.PositionRepository {
+	return paperRepo.NewPositionRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Portfolio() portfolio.PortfolioRepository {
+	return portfolioRepo.NewPortfolioRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) PortfolioTransaction() portfolio.TransactionRepository {
+	return portfolioRepo.NewTransactionRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) PortfolioAsset() portfolio.AssetRepository {
+	return portfolioRepo.NewAssetRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Watchlist() alert.WatchlistRepository {
+	return alertRepo.NewWatchlistRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) WatchlistItem() alert.WatchlistItemRepository {
+	return alertRepo.NewWatchlistItemRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) AlertRule() alert.RuleRepository {
+	return alertRepo.NewRuleRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) AlertRuleState() alert.StateRepository {
+	return alertRepo.NewStateRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) AlertEvent() alert.EventRepository {
+	return alertRepo.NewEventRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Notification() notification.NotificationRepository {
+	return notificationRepo.NewNotificationRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) BrokerConnection() broker.ConnectionRepository {
+	return brokerRepo.NewConnectionRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) JournalEntry() journal.EntryRepository {
+	return journalRepo.NewEntryRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) CalendarEvent() calendar.EventRepository {
+	return calendarRepo.NewEventRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Document() document.DocumentRepository {
+	return documentRepo.NewDocumentRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) DocumentChunk() document.ChunkRepository {
+	return documentRepo.NewChunkRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) KnowledgeBase() document.KnowledgeBaseRepository {
+	return documentRepo.NewKnowledgeBaseRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Organization() organization.OrganizationRepository {
+	return organizationRepo.NewOrganizationRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) OrganizationInvitation() organization.InvitationRepository {
+	return organizationRepo.NewInvitationRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) RateLimitBucket() ratelimit.BucketRepository {
+	return ratelimitRepo.NewBucketRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) AuditEvent() audit.EventRepository {
+	return auditRepo.NewEventRepository(p.tx)
+}
+
+func (p *transactionalRepositoryProvider) Secret(kind encryption.SecretKind) encryption.SecretRepository {
+	return encryptionRepo.NewSecretRepository(p.tx, kind)
+}
+
 // Service provides a high-level abstraction for database operations,
 // including transaction management.
 type Service struct {
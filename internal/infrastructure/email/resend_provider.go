@@ -3,9 +3,13 @@ package email
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
+	"time"
 	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/alert"
 	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/organization"
 	"trading-alchemist/internal/domain/services"
 
 	"github.com/resend/resend-go/v2"
@@ -40,6 +44,24 @@ func (r *ResendProvider) SendEmailVerificationEmail(ctx context.Context, user *a
 	html := r.buildEmailVerificationBody(user, magicLink)
 	return r.sendEmail(ctx, user.Email, subject, html)
 }
+// SendAlertEmail sends an alert notification email using Resend
+func (r *ResendProvider) SendAlertEmail(ctx context.Context, user *auth.User, rule *alert.Rule, event *alert.Event) error {
+	subject := fmt.Sprintf("Alert: %s (%s)", rule.Name, event.Symbol)
+	html := r.buildAlertEmailBody(user, rule, event)
+	return r.sendEmail(ctx, user.Email, subject, html)
+}
+// SendOrganizationInvitationEmail sends an organization invitation email using Resend
+func (r *ResendProvider) SendOrganizationInvitationEmail(ctx context.Context, user *auth.User, org *organization.Organization, inviter *auth.User, magicLink *auth.MagicLink) error {
+	subject := fmt.Sprintf("You're invited to join %s on %s", org.Name, r.config.App.Name)
+	html := r.buildOrganizationInvitationBody(user, org, inviter, magicLink)
+	return r.sendEmail(ctx, user.Email, subject, html)
+}
+// SendAccountDeletionEmail confirms a scheduled account deletion using Resend
+func (r *ResendProvider) SendAccountDeletionEmail(ctx context.Context, user *auth.User, scheduledFor time.Time) error {
+	subject := fmt.Sprintf("Your %s account is scheduled for deletion", r.config.App.Name)
+	html := r.buildAccountDeletionBody(user, scheduledFor)
+	return r.sendEmail(ctx, user.Email, subject, html)
+}
 // sendEmail sends an email using the Resend API
 func (r *ResendProvider) sendEmail(ctx context.Context, to, subject, html string) error {
 	params := &resend.SendEmailRequest{
@@ -400,4 +422,362 @@ func (r *ResendProvider) buildEmailVerificationBody(user *auth.User, magicLink *
 </body>
 </html>
 	`, user.DisplayName(), r.config.App.Name, verificationURL, verificationURL, r.config.App.Name)
-}
\ No newline at end of file
+}
+// buildAlertEmailBody builds the alert notification email body
+func (r *ResendProvider) buildAlertEmailBody(user *auth.User, rule *alert.Rule, event *alert.Event) string {
+	alertsURL := fmt.Sprintf("%s/alerts/%s", r.config.App.FrontendBaseURL, rule.ID)
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Alert: %s</title>
+    <style>
+        body { 
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; 
+            line-height: 1.6; 
+            color: #333333; 
+            margin: 0; 
+            padding: 0; 
+            background-color: #f6f6f6; 
+        }
+        .container { 
+            max-width: 600px; 
+            margin: 20px auto; 
+            padding: 30px; 
+            background-color: #ffffff; 
+            border-radius: 8px; 
+            box-shadow: 0 4px 12px rgba(0, 0, 0, 0.05); 
+        }
+        .header { 
+            text-align: center; 
+            padding-bottom: 25px; 
+            margin-bottom: 25px; 
+            border-bottom: 1px solid #eeeeee; 
+        }
+        .header h1 {
+            color: #6A0DAD; 
+            font-size: 28px;
+            margin: 0;
+            padding: 0;
+        }
+        p {
+            margin-bottom: 15px;
+            font-size: 16px;
+            color: #333333;
+        }
+        .alert-message {
+            background-color: #f8f9fa;
+            padding: 12px;
+            border-radius: 4px;
+            border-left: 4px solid #6A0DAD;
+            font-size: 16px;
+        }
+        table.details {
+            width: 100%%;
+            border-collapse: collapse;
+            margin: 20px 0;
+            font-size: 14px;
+        }
+        table.details td {
+            padding: 6px 0;
+            border-bottom: 1px solid #eeeeee;
+        }
+        table.details td.label {
+            color: #666666;
+            width: 40%%;
+        }
+        .button-container { 
+            text-align: center; 
+            margin: 30px 0;
+        }
+        .button { 
+            display: inline-block; 
+            padding: 15px 30px; 
+            background-color: #6A0DAD; /* Deep purple button */
+            color: white; 
+            text-decoration: none; 
+            border-radius: 6px; 
+            font-weight: bold;
+            font-size: 18px;
+        }
+        .footer { 
+            margin-top: 35px; 
+            padding-top: 25px; 
+            border-top: 1px solid #eeeeee; 
+            font-size: 13px; 
+            color: #666666; 
+            text-align: center;
+        }
+        .footer p {
+            margin: 5px 0;
+        }
+        @media only screen and (max-width: 600px) {
+            .container {
+                margin: 10px;
+                padding: 20px;
+            }
+            .header h1 {
+                font-size: 24px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <p>Hi %s,</p>
+        <p>Your alert <strong>%s</strong> was triggered:</p>
+        <p class="alert-message">%s</p>
+        <table class="details">
+            <tr><td class="label">Symbol</td><td>%s</td></tr>
+            <tr><td class="label">Price</td><td>%g</td></tr>
+            <tr><td class="label">Timeframe</td><td>%s</td></tr>
+            <tr><td class="label">Candle</td><td>%s</td></tr>
+        </table>
+        <div class="button-container">
+            <a href="%s" class="button">View Alert</a>
+        </div>
+        <div class="footer">
+            <p>This alert will not fire again for the same symbol within its cool-down period.</p>
+            <p>You can disable email delivery or the alert itself in your alert settings.</p>
+            <p>The %s Team</p>
+        </div>
+    </div>
+</body>
+</html>
+	`, html.EscapeString(rule.Name), r.config.App.Name, user.DisplayName(), html.EscapeString(rule.Name), html.EscapeString(event.Message),
+		html.EscapeString(event.Symbol), event.Price, event.Timeframe, event.CandleTime.UTC().Format("2006-01-02 15:04 MST"), alertsURL, r.config.App.Name)
+}
+// buildOrganizationInvitationBody builds the organization invitation email body
+func (r *ResendProvider) buildOrganizationInvitationBody(user *auth.User, org *organization.Organization, inviter *auth.User, magicLink *auth.MagicLink) string {
+	acceptURL := fmt.Sprintf("%s/auth/verify?token=%s", r.config.App.FrontendBaseURL, magicLink.Token)
+	inviterName := "A team member"
+	if inviter != nil {
+		inviterName = inviter.DisplayName()
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Join %s</title>
+    <style>
+        body { 
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; 
+            line-height: 1.6; 
+            color: #333333; 
+            margin: 0; 
+            padding: 0; 
+            background-color: #f6f6f6; 
+        }
+        .container { 
+            max-width: 600px; 
+            margin: 20px auto; 
+            padding: 30px; 
+            background-color: #ffffff; 
+            border-radius: 8px; 
+            box-shadow: 0 4px 12px rgba(0, 0, 0, 0.05); 
+        }
+        .header { 
+            text-align: center; 
+            padding-bottom: 25px; 
+            margin-bottom: 25px; 
+            border-bottom: 1px solid #eeeeee; 
+        }
+        .header h1 {
+            color: #6A0DAD; 
+            font-size: 28px;
+            margin: 0;
+            padding: 0;
+        }
+        p {
+            margin-bottom: 15px;
+            font-size: 16px;
+            color: #333333;
+        }
+        .button-container { 
+            text-align: center; 
+            margin: 30px 0;
+        }
+        .button { 
+            display: inline-block; 
+            padding: 15px 30px; 
+            background-color: #6A0DAD; /* Deep purple button */
+            color: white; 
+            text-decoration: none; 
+            border-radius: 6px; 
+            font-weight: bold;
+            font-size: 18px;
+        }
+        .link-text {
+            word-break: break-all; 
+            background-color: #f8f9fa; 
+            padding: 12px; 
+            border-radius: 4px;
+            font-family: monospace;
+            font-size: 14px;
+            color: #333333;
+            border: 1px dashed #cccccc;
+            margin-top: 20px;
+        }
+        .footer { 
+            margin-top: 35px; 
+            padding-top: 25px; 
+            border-top: 1px solid #eeeeee; 
+            font-size: 13px; 
+            color: #666666; 
+            text-align: center;
+        }
+        .footer p {
+            margin: 5px 0;
+        }
+        @media only screen and (max-width: 600px) {
+            .container {
+                margin: 10px;
+                padding: 20px;
+            }
+            .header h1 {
+                font-size: 24px;
+            }
+            .button {
+                padding: 12px 25px;
+                font-size: 16px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Join %s</h1>
+        </div>
+        <p>Hi %s,</p>
+        <p>%s invited you to join the <strong>%s</strong> workspace on %s. Members share the workspace's AI provider keys and conversations.</p>
+        <div class="button-container">
+            <a href="%s" class="button">Accept Invitation</a>
+        </div>
+        <p>If the button doesn't work, you can copy and paste this link into your browser:</p>
+        <p class="link-text"><code>%s</code></p>
+        <div class="footer">
+            <p>This invitation will expire at %s.</p>
+            <p>If you don't want to join, you can safely ignore this email.</p>
+            <p>The %s Team</p>
+        </div>
+    </div>
+</body>
+</html>
+	`, html.EscapeString(org.Name), html.EscapeString(org.Name), user.DisplayName(), html.EscapeString(inviterName), html.EscapeString(org.Name), r.config.App.Name,
+		acceptURL, acceptURL, magicLink.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"), r.config.App.Name)
+}
+// buildAccountDeletionBody builds the account deletion confirmation email body
+func (r *ResendProvider) buildAccountDeletionBody(user *auth.User, scheduledFor time.Time) string {
+	settingsURL := fmt.Sprintf("%s/settings/account", r.config.App.FrontendBaseURL)
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Account Deletion Scheduled</title>
+    <style>
+        body { 
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; 
+            line-height: 1.6; 
+            color: #333333; 
+            margin: 0; 
+            padding: 0; 
+            background-color: #f6f6f6; 
+        }
+        .container { 
+            max-width: 600px; 
+            margin: 20px auto; 
+            padding: 30px; 
+            background-color: #ffffff; 
+            border-radius: 8px; 
+            box-shadow: 0 4px 12px rgba(0, 0, 0, 0.05); 
+        }
+        .header { 
+            text-align: center; 
+            padding-bottom: 25px; 
+            margin-bottom: 25px; 
+            border-bottom: 1px solid #eeeeee; 
+        }
+        .header h1 {
+            color: #6A0DAD; 
+            font-size: 28px;
+            margin: 0;
+            padding: 0;
+        }
+        p {
+            margin-bottom: 15px;
+            font-size: 16px;
+            color: #333333;
+        }
+        .button-container { 
+            text-align: center; 
+            margin: 30px 0;
+        }
+        .button { 
+            display: inline-block; 
+            padding: 15px 30px; 
+            background-color: #6A0DAD; /* Deep purple button */
+            color: white; 
+            text-decoration: none; 
+            border-radius: 6px; 
+            font-weight: bold;
+            font-size: 18px;
+        }
+        .footer { 
+            margin-top: 35px; 
+            padding-top: 25px; 
+            border-top: 1px solid #eeeeee; 
+            font-size: 13px; 
+            color: #666666; 
+            text-align: center;
+        }
+        .footer p {
+            margin: 5px 0;
+        }
+        @media only screen and (max-width: 600px) {
+            .container {
+                margin: 10px;
+                padding: 20px;
+            }
+            .header h1 {
+                font-size: 24px;
+            }
+            .button {
+                padding: 12px 25px;
+                font-size: 16px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <p>Hi %s,</p>
+        <p>We received a request to delete your account. Your profile, conversations, documents and settings will be permanently deleted on <strong>%s</strong>.</p>
+        <p>Until then you can still sign in, export your data, or cancel the deletion from your account settings.</p>
+        <div class="button-container">
+            <a href="%s" class="button">Manage Your Account</a>
+        </div>
+        <div class="footer">
+            <p>If you didn't request this, sign in and cancel the deletion right away.</p>
+            <p>The %s Team</p>
+        </div>
+    </div>
+</body>
+</html>
+	`, r.config.App.Name, html.EscapeString(user.DisplayName()), scheduledFor.UTC().Format("2006-01-02 15:04 MST"), settingsURL, r.config.App.Name)
+}
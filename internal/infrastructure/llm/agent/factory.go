@@ -2,10 +2,12 @@ package agent
 
 import (
 	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
 )
 
-// NewLLMService creates the central LLM orchestrator.
-func NewLLMService() (services.LLMService, error) {
-	orchestrator := NewOrchestratorService()
+// NewLLMService creates the central LLM orchestrator. Embeddings are cached in
+// the database.
+func NewLLMService(dbService *database.Service) (services.LLMService, error) {
+	orchestrator := NewOrchestratorService(dbService)
 	return orchestrator, nil
 } 
\ No newline at end of file
@@ -3,19 +3,23 @@ package agent
 import (
 	"context"
 	"fmt"
+	"log"
 	"trading-alchemist/internal/domain/chat"
 	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
 	"trading-alchemist/internal/infrastructure/llm/provider"
 )
 
 // OrchestratorService is an implementation of services.LLMService that delegates
 // to the appropriate provider client based on the provider's name.
 type OrchestratorService struct {
+	dbService *database.Service // Embedding cache; nil disables caching
 }
 
-// NewOrchestratorService creates a new LLM orchestrator.
-func NewOrchestratorService() *OrchestratorService {
-	return &OrchestratorService{}
+// NewOrchestratorService creates a new LLM orchestrator. Embeddings are cached
+// in the database when dbService is set.
+func NewOrchestratorService(dbService *database.Service) *OrchestratorService {
+	return &OrchestratorService{dbService: dbService}
 }
 
 // StreamChatCompletion finds the correct provider client and delegates the call.
@@ -24,6 +28,7 @@ func (s *OrchestratorService) StreamChatCompletion(
 	providerE *chat.Provider,
 	model *chat.Model,
 	messages []*chat.Message,
+	tools []*chat.Tool,
 	apiKey string,
 	apiBaseOverride string,
 ) (<-chan services.ChatStreamEvent, error) {
@@ -32,5 +37,90 @@ func (s *OrchestratorService) StreamChatCompletion(
 		return nil, fmt.Errorf("failed to create client for provider %s: %w", providerE.Name, err)
 	}
 
-	return client.StreamChatCompletion(ctx, model, messages)
-} 
\ No newline at end of file
+	return client.StreamChatCompletion(ctx, model, messages, tools)
+}
+
+// Embed finds the correct provider client and delegates the call. Inputs whose
+// embedding is cached are not sent to the provider.
+func (s *OrchestratorService) Embed(
+	ctx context.Context,
+	providerE *chat.Provider,
+	model *chat.Model,
+	inputs []string,
+	apiKey string,
+	apiBaseOverride string,
+) ([][]float32, error) {
+	client, err := provider.NewClientForProvider(providerE.Name, apiKey, apiBaseOverride)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for provider %s: %w", providerE.Name, err)
+	}
+	if s.dbService == nil || len(inputs) == 0 {
+		return client.Embed(ctx, model, inputs)
+	}
+
+	// The same model name can serve different embeddings behind another API base.
+	cacheKey := providerE.Name + "/" + model.Name
+	if apiBaseOverride != "" {
+		cacheKey += "@" + apiBaseOverride
+	}
+
+	hashes := make([]string, len(inputs))
+	for i, input := range inputs {
+		hashes[i] = chat.ContentHash(input)
+	}
+
+	var cached map[string][]float32
+	err = s.dbService.ExecuteInTx(ctx, func(repos database.RepositoryProvider) error {
+		var err error
+		cached, err = repos.EmbeddingCache().Get(ctx, cacheKey, hashes)
+		return err
+	})
+	if err != nil {
+		// The cache only saves requests; fall back to the provider.
+		log.Printf("Failed to read embedding cache: %v", err)
+		cached = map[string][]float32{}
+	}
+
+	// Embed each missing text once, even when it is repeated in the inputs.
+	var missing []string
+	var missingHashes []string
+	queued := make(map[string]bool)
+	for i, hash := range hashes {
+		if _, ok := cached[hash]; ok || queued[hash] {
+			continue
+		}
+		queued[hash] = true
+		missing = append(missing, inputs[i])
+		missingHashes = append(missingHashes, hash)
+	}
+
+	if len(missing) > 0 {
+		embedded, err := client.Embed(ctx, model, missing)
+		if err != nil {
+			return nil, err
+		}
+		if len(embedded) != len(missing) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(missing), len(embedded))
+		}
+		for i, hash := range missingHashes {
+			cached[hash] = embedded[i]
+		}
+		err = s.dbService.ExecuteInTx(ctx, func(repos database.RepositoryProvider) error {
+			for i, hash := range missingHashes {
+				if err := repos.EmbeddingCache().Put(ctx, cacheKey, hash, embedded[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("Failed to write embedding cache: %v", err)
+		}
+	}
+
+	embeddings := make([][]float32, len(inputs))
+	for i, hash := range hashes {
+		embeddings[i] = cached[hash]
+	}
+	return embeddings, nil
+}
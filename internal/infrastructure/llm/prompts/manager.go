@@ -37,6 +37,12 @@ func (pm *PromptManager) registerPrompts() {
 	// Register title generation prompt
 	pm.prompts["title_generation"] = GetTitleGenerationPrompt()
 	
+	// Register trade review prompt
+	pm.prompts["review_trades"] = GetReviewTradesPrompt()
+	
+	// Register user memory extraction prompt
+	pm.prompts["memory_extraction"] = GetMemoryExtractionPrompt()
+	
 	// Future prompts can be registered here:
 	// pm.prompts["code_analysis"] = GetCodeAnalysisPrompt()
 	// pm.prompts["creative_writing"] = GetCreativeWritingPrompt()
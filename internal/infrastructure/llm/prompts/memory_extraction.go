@@ -0,0 +1,62 @@
+package prompts
+
+// MemoryExtractionData represents the data structure for memory extraction templates
+type MemoryExtractionData struct {
+	Memories         []ExtractedMemory // What is already remembered about the user
+	UserMessage      string
+	AssistantMessage string
+}
+
+// ExtractedMemory is a remembered fact as shown to the model. Index is what the
+// model refers to when it replaces the memory.
+type ExtractedMemory struct {
+	Index    int
+	Category string
+	Content  string
+}
+
+// GetMemoryExtractionPrompt returns the prompt configuration for extracting lasting facts about the user from an exchange
+func GetMemoryExtractionPrompt() *Prompt {
+	return &Prompt{
+		Name:        "memory_extraction",
+		Description: "Extracts stable facts about the user, such as risk tolerance and markets traded, from a conversation exchange",
+		Version:     "1.0",
+		SystemPrompt: `You maintain the long-term memory of a trading assistant about its user.
+Your task is to find facts about the user in the latest exchange that will still be true and useful in future conversations.
+
+Remember only stable facts the user states or clearly confirms about themselves, in these categories:
+- risk_tolerance: how much risk they accept, position sizing, maximum drawdown
+- markets: markets, asset classes and instruments they trade or follow
+- time_zone: where they are or the hours they trade
+- trading_style: timeframes, holding periods, strategies and methods
+- goals: what they want to achieve
+- other: anything else stable and relevant to trading, such as experience or broker
+
+Guidelines:
+- Do not remember questions, market data, prices, one-off requests or anything the assistant said
+- Do not remember passwords, API keys, account numbers or other secrets
+- Write each fact as a short, self-contained sentence in the third person, e.g. "Trades US tech stocks on the daily chart"
+- If a fact updates or contradicts a remembered one, replace it by giving its number
+- Do not repeat facts that are already remembered
+- Most exchanges contain nothing worth remembering; then return an empty list
+
+Respond with JSON only, without Markdown, in this format:
+{"memories": [{"category": "markets", "content": "Trades US tech stocks", "replaces": 0}]}
+where "replaces" is the number of the remembered fact it replaces, or 0 for a new fact.`,
+
+		UserTemplate: `Already remembered about the user:
+{{range .Memories}}
+{{.Index}}. [{{.Category}}] {{.Content}}
+{{- else}}
+Nothing yet.
+{{- end}}
+
+Latest exchange:
+
+User: {{.UserMessage}}
+
+Assistant: {{.AssistantMessage}}
+
+Return the facts to remember as JSON:`,
+	}
+}
@@ -0,0 +1,81 @@
+package prompts
+
+// ReviewTradesData represents the data structure for trade review templates.
+// Values are preformatted by the caller.
+type ReviewTradesData struct {
+	Start   string
+	End     string
+	Focus   string // Optional description of the filter applied, e.g. "tag breakout"
+	Summary string // Markdown table of the period's statistics
+	Trades  []ReviewTrade
+	Omitted int // Entries left out to keep the prompt short
+}
+
+// ReviewTrade is one journal entry as shown to the model.
+type ReviewTrade struct {
+	Symbol      string
+	Side        string
+	Opened      string
+	Closed      string // Empty for an open trade
+	Quantity    string
+	EntryPrice  string
+	ExitPrice   string
+	PnL         string
+	ReturnPct   string
+	Setup       string
+	Tags        string
+	Thesis      string
+	Notes       string
+	Screenshots int
+}
+
+// GetReviewTradesPrompt returns the prompt configuration for reviewing a period of journal entries
+func GetReviewTradesPrompt() *Prompt {
+	return &Prompt{
+		Name:        "review_trades",
+		Description: "Critiques a period of trading journal entries and suggests concrete improvements",
+		Version:     "1.0",
+		SystemPrompt: `You are an experienced trading coach reviewing a trader's journal.
+Your task is to give an honest, specific critique of the trades in the period and help the trader improve.
+
+Guidelines:
+- Base every observation on the trades and statistics provided; do not invent trades or numbers
+- Compare each thesis with the outcome: was the idea wrong, or was the execution poor?
+- Look for patterns across trades: setups that work or fail, sizing, holding times, fees, repeated mistakes
+- Call out good decisions as well as bad ones
+- Keep recommendations concrete and actionable
+- Do not give financial advice about future trades in specific instruments
+
+Format the review in Markdown with these sections:
+## Summary
+## What Worked
+## What Didn't Work
+## Patterns and Habits
+## Action Items`,
+
+		UserTemplate: `Review my trading journal from {{.Start}} to {{.End}}{{if .Focus}} ({{.Focus}}){{end}}.
+
+### Statistics
+
+{{.Summary}}
+
+### Trades
+{{range .Trades}}
+- {{.Symbol}} {{.Side}} {{.Quantity}} @ {{.EntryPrice}}, opened {{.Opened}}{{if .Closed}}, closed {{.Closed}} @ {{.ExitPrice}}, P&L {{.PnL}} ({{.ReturnPct}}){{else}}, still open{{end}}
+{{- if .Setup}}
+  Setup: {{.Setup}}{{end}}
+{{- if .Tags}}
+  Tags: {{.Tags}}{{end}}
+{{- if .Thesis}}
+  Thesis: {{.Thesis}}{{end}}
+{{- if .Notes}}
+  Notes: {{.Notes}}{{end}}
+{{- if .Screenshots}}
+  Screenshots attached: {{.Screenshots}}{{end}}
+{{- end}}
+{{if .Omitted}}
+{{.Omitted}} older entries were left out.
+{{end}}
+Write the review now:`,
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+)
+
+// EmbedLimits bounds the embedding requests sent to a provider.
+type EmbedLimits struct {
+	MaxInputs     int // Inputs per request
+	MaxInputChars int // Longer inputs are truncated
+	MaxBatchChars int // Characters per request, summed over its inputs
+}
+
+// embedInBatches splits inputs into requests within limits and calls embed for
+// each of them, returning one embedding per input in order.
+func embedInBatches(
+	ctx context.Context,
+	inputs []string,
+	limits EmbedLimits,
+	embed func(ctx context.Context, batch []string) ([][]float32, error),
+) ([][]float32, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	embeddings := make([][]float32, 0, len(inputs))
+	batch := make([]string, 0, min(len(inputs), limits.MaxInputs))
+	batchChars := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		result, err := embed(ctx, batch)
+		if err != nil {
+			return err
+		}
+		if len(result) != len(batch) {
+			return fmt.Errorf("expected %d embeddings, got %d", len(batch), len(result))
+		}
+		embeddings = append(embeddings, result...)
+		batch = batch[:0]
+		batchChars = 0
+		return nil
+	}
+
+	for _, input := range inputs {
+		input = truncateRunes(input, limits.MaxInputChars)
+		chars := utf8.RuneCountInString(input)
+		if len(batch) > 0 &&
+			((limits.MaxInputs > 0 && len(batch) >= limits.MaxInputs) ||
+				(limits.MaxBatchChars > 0 && batchChars+chars > limits.MaxBatchChars)) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		batch = append(batch, input)
+		batchChars += chars
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
+
+// truncateRunes cuts s to at most n characters without splitting a rune.
+// A limit of zero leaves s unchanged.
+func truncateRunes(s string, n int) string {
+	if n <= 0 || len(s) <= n {
+		return s
+	}
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
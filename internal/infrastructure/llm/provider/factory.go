@@ -9,8 +9,10 @@ func NewClientForProvider(providerName, apiKey, apiBaseOverride string) (Provide
 	switch providerName {
 	case "openai":
 		return NewOpenAIClient(apiKey, apiBaseOverride)
-	// case "google":
-	// 	return NewGoogleClient(apiKey, apiBaseOverride)
+	case "google":
+		return NewGoogleClient(apiKey, apiBaseOverride)
+	case "openai_compatible":
+		return NewOpenAICompatibleClient(apiKey, apiBaseOverride)
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", providerName)
 	}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/services"
+)
+
+const googleDefaultBaseURL = "https://generativelanguage.googleapis.com"
+
+// googleEmbedLimits follows the limits of the Gemini batchEmbedContents endpoint:
+// 100 inputs per request and 2048 tokens per input, of which the rest is dropped.
+var googleEmbedLimits = EmbedLimits{MaxInputs: 100, MaxInputChars: 4000}
+
+// GoogleClient implements the ProviderClient for the Gemini API. Only embeddings
+// are supported for now.
+type GoogleClient struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewGoogleClient creates a new Gemini API client.
+func NewGoogleClient(apiKey, apiBaseOverride string) (ProviderClient, error) {
+	if apiKey == "" {
+		return nil, errors.New("Google API key is not provided")
+	}
+
+	baseURL := googleDefaultBaseURL
+	if apiBaseOverride != "" {
+		baseURL = apiBaseOverride
+	}
+	return &GoogleClient{
+		apiKey:     apiKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// StreamChatCompletion is not implemented for Google yet.
+func (c *GoogleClient) StreamChatCompletion(
+	ctx context.Context,
+	model *chat.Model,
+	messages []*chat.Message,
+	tools []*chat.Tool,
+) (<-chan services.ChatStreamEvent, error) {
+	return nil, errors.New("chat completions are not supported for Google yet")
+}
+
+// Embed computes embeddings for the inputs, split into requests within the
+// endpoint's limits.
+func (c *GoogleClient) Embed(ctx context.Context, model *chat.Model, inputs []string) ([][]float32, error) {
+	return embedInBatches(ctx, inputs, googleEmbedLimits, func(ctx context.Context, batch []string) ([][]float32, error) {
+		return c.embedBatch(ctx, model, batch)
+	})
+}
+
+type googlePart struct {
+	Text string `json:"text"`
+}
+
+type googleContent struct {
+	Parts []googlePart `json:"parts"`
+}
+
+type googleEmbedContentRequest struct {
+	Model   string        `json:"model"`
+	Content googleContent `json:"content"`
+}
+
+type googleBatchEmbedRequest struct {
+	Requests []googleEmbedContentRequest `json:"requests"`
+}
+
+type googleBatchEmbedResponse struct {
+	Embeddings []struct {
+		Values []float32 `json:"values"`
+	} `json:"embeddings"`
+}
+
+type googleErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// embedBatch computes embeddings for the inputs in a single request.
+func (c *GoogleClient) embedBatch(ctx context.Context, model *chat.Model, inputs []string) ([][]float32, error) {
+	modelID := strings.TrimPrefix(model.Name, "models/")
+	modelName := "models/" + modelID
+	reqBody := googleBatchEmbedRequest{Requests: make([]googleEmbedContentRequest, len(inputs))}
+	for i, input := range inputs {
+		reqBody.Requests[i] = googleEmbedContentRequest{
+			Model:   modelName,
+			Content: googleContent{Parts: []googlePart{{Text: input}}},
+		}
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode embedding request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1beta/models/%s:batchEmbedContents", c.baseURL, url.PathEscape(modelID))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", c.apiKey)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedding response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr googleErrorResponse
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("google embeddings request failed (%d %s): %s", resp.StatusCode, apiErr.Error.Status, apiErr.Error.Message)
+		}
+		return nil, fmt.Errorf("google embeddings request failed with status %d", resp.StatusCode)
+	}
+
+	var result googleBatchEmbedResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode embedding response: %w", err)
+	}
+	if len(result.Embeddings) != len(inputs) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(inputs), len(result.Embeddings))
+	}
+	embeddings := make([][]float32, len(inputs))
+	for i, e := range result.Embeddings {
+		if len(e.Values) == 0 {
+			return nil, fmt.Errorf("missing embedding for input %d", i)
+		}
+		embeddings[i] = e.Values
+	}
+	return embeddings, nil
+}
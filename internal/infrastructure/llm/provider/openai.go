@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"trading-alchemist/internal/domain/chat"
 	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/domain/shared"
@@ -11,9 +12,19 @@ import (
 	"github.com/openai/openai-go/option"
 )
 
+// openAIEmbedLimits follows the limits of the OpenAI embeddings endpoint: 2048 inputs
+// per request, 8192 tokens per input and 300k tokens per request. Characters are
+// counted instead of tokens, assuming at least two characters per token.
+var openAIEmbedLimits = EmbedLimits{MaxInputs: 2048, MaxInputChars: 16000, MaxBatchChars: 500000}
+
+// openAICompatibleEmbedLimits are conservative limits for self-hosted servers,
+// which often accept far smaller requests than OpenAI itself.
+var openAICompatibleEmbedLimits = EmbedLimits{MaxInputs: 64, MaxInputChars: 8000, MaxBatchChars: 64000}
+
 // OpenAIClient implements the ProviderClient for the OpenAI API.
 type OpenAIClient struct {
-	client *openai.Client
+	client      *openai.Client
+	embedLimits EmbedLimits
 }
 
 // NewOpenAIClient creates a new OpenAI LLM service client.
@@ -28,7 +39,22 @@ func NewOpenAIClient(apiKey, apiBaseOverride string) (ProviderClient, error) {
 	}
 
 	client := openai.NewClient(opts...)
-	return &OpenAIClient{client: &client}, nil
+	return &OpenAIClient{client: &client, embedLimits: openAIEmbedLimits}, nil
+}
+
+// NewOpenAICompatibleClient creates a client for a server that implements the
+// OpenAI API, such as Ollama or vLLM. The server's URL is required; the API key
+// may be any placeholder the server accepts.
+func NewOpenAICompatibleClient(apiKey, apiBaseOverride string) (ProviderClient, error) {
+	if apiBaseOverride == "" {
+		return nil, errors.New("API base URL is required for OpenAI-compatible providers")
+	}
+	if apiKey == "" {
+		return nil, errors.New("API key is not provided")
+	}
+
+	client := openai.NewClient(option.WithAPIKey(apiKey), option.WithBaseURL(apiBaseOverride))
+	return &OpenAIClient{client: &client, embedLimits: openAICompatibleEmbedLimits}, nil
 }
 
 // StreamChatCompletion sends a chat request and streams the response.
@@ -36,6 +62,7 @@ func (c *OpenAIClient) StreamChatCompletion(
 	ctx context.Context,
 	model *chat.Model,
 	messages []*chat.Message,
+	tools []*chat.Tool,
 ) (<-chan services.ChatStreamEvent, error) {
 	// 1. Convert domain messages to OpenAI messages
 	openAIMessages, err := c.toOpenAIMessages(messages)
@@ -47,6 +74,7 @@ func (c *OpenAIClient) StreamChatCompletion(
 	params := openai.ChatCompletionNewParams{
 		Model:    openai.ChatModel(model.Name), // Use the model name from the conversation
 		Messages: openAIMessages,
+		Tools:    c.toOpenAITools(tools),
 	}
 
 	stream := c.client.Chat.Completions.NewStreaming(ctx, params)
@@ -58,9 +86,12 @@ func (c *OpenAIClient) StreamChatCompletion(
 	go func() {
 		defer close(events)
 
+		// The accumulator assembles tool call arguments that arrive in fragments.
+		acc := openai.ChatCompletionAccumulator{}
 		for stream.Next() {
 			chunk := stream.Current()
-			if len(chunk.Choices) > 0 {
+			acc.AddChunk(chunk)
+			if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
 				event := services.ChatStreamEvent{
 					ContentDelta: chunk.Choices[0].Delta.Content,
 				}
@@ -73,16 +104,83 @@ func (c *OpenAIClient) StreamChatCompletion(
 			return
 		}
 
-		// Send final event to signal the end of the stream
-		events <- services.ChatStreamEvent{IsLast: true}
+		// Send final event to signal the end of the stream, including any requested tool calls
+		final := services.ChatStreamEvent{IsLast: true}
+		if len(acc.Choices) > 0 {
+			for _, tc := range acc.Choices[0].Message.ToolCalls {
+				final.ToolCalls = append(final.ToolCalls, &services.ToolCall{
+					ID:        tc.ID,
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
+				})
+			}
+		}
+		events <- final
 	}()
 
 	return events, nil
 }
 
+// Embed computes embeddings for the inputs, split into requests within the
+// endpoint's limits.
+func (c *OpenAIClient) Embed(ctx context.Context, model *chat.Model, inputs []string) ([][]float32, error) {
+	return embedInBatches(ctx, inputs, c.embedLimits, func(ctx context.Context, batch []string) ([][]float32, error) {
+		return c.embedBatch(ctx, model, batch)
+	})
+}
+
+// embedBatch computes embeddings for the inputs in a single request.
+func (c *OpenAIClient) embedBatch(ctx context.Context, model *chat.Model, inputs []string) ([][]float32, error) {
+	resp, err := c.client.Embeddings.New(ctx, openai.EmbeddingNewParams{
+		Input:          openai.EmbeddingNewParamsInputUnion{OfArrayOfStrings: inputs},
+		Model:          openai.EmbeddingModel(model.Name),
+		EncodingFormat: openai.EmbeddingNewParamsEncodingFormatFloat,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(inputs))
+	for _, data := range resp.Data {
+		if data.Index < 0 || int(data.Index) >= len(inputs) {
+			return nil, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+		embedding := make([]float32, len(data.Embedding))
+		for i, v := range data.Embedding {
+			embedding[i] = float32(v)
+		}
+		embeddings[data.Index] = embedding
+	}
+	for i, embedding := range embeddings {
+		if embedding == nil {
+			return nil, fmt.Errorf("missing embedding for input %d", i)
+		}
+	}
+	return embeddings, nil
+}
+
+func (c *OpenAIClient) toOpenAITools(tools []*chat.Tool) []openai.ChatCompletionToolParam {
+	if len(tools) == 0 {
+		return nil
+	}
+	params := make([]openai.ChatCompletionToolParam, len(tools))
+	for i, tool := range tools {
+		params[i] = openai.ChatCompletionToolParam{
+			Function: openai.FunctionDefinitionParam{
+				Name:        tool.Name,
+				Description: openai.String(tool.Description),
+				Parameters:  openai.FunctionParameters(tool.Schema),
+			},
+		}
+	}
+	return params
+}
+
 func (c *OpenAIClient) toOpenAIMessages(messages []*chat.Message) ([]openai.ChatCompletionMessageParamUnion, error) {
-	openAIMessages := make([]openai.ChatCompletionMessageParamUnion, len(messages))
-	for i, msg := range messages {
+	openAIMessages := make([]openai.ChatCompletionMessageParamUnion, 0, len(messages))
+	// Tool results are only valid directly after the assistant message that requested them.
+	pendingToolCalls := map[string]bool{}
+	for _, msg := range messages {
 		var param openai.ChatCompletionMessageParamUnion
 		switch msg.Role {
 		case shared.MessageRoleUser:
@@ -94,13 +192,24 @@ func (c *OpenAIClient) toOpenAIMessages(messages []*chat.Message) ([]openai.Chat
 				},
 			}
 		case shared.MessageRoleAssistant:
-			param = openai.ChatCompletionMessageParamUnion{
-				OfAssistant: &openai.ChatCompletionAssistantMessageParam{
-					Content: openai.ChatCompletionAssistantMessageParamContentUnion{
-						OfString: openai.String(msg.Content),
+			assistant := &openai.ChatCompletionAssistantMessageParam{}
+			if msg.Content != "" {
+				assistant.Content = openai.ChatCompletionAssistantMessageParamContentUnion{
+					OfString: openai.String(msg.Content),
+				}
+			}
+			pendingToolCalls = map[string]bool{}
+			for _, tc := range services.ToolCallsFromMetadata(msg.Metadata) {
+				assistant.ToolCalls = append(assistant.ToolCalls, openai.ChatCompletionMessageToolCallParam{
+					ID: tc.ID,
+					Function: openai.ChatCompletionMessageToolCallFunctionParam{
+						Name:      tc.Name,
+						Arguments: tc.Arguments,
 					},
-				},
+				})
+				pendingToolCalls[tc.ID] = true
 			}
+			param = openai.ChatCompletionMessageParamUnion{OfAssistant: assistant}
 		case shared.MessageRoleSystem:
 			param = openai.ChatCompletionMessageParamUnion{
 				OfSystem: &openai.ChatCompletionSystemMessageParam{
@@ -109,12 +218,18 @@ func (c *OpenAIClient) toOpenAIMessages(messages []*chat.Message) ([]openai.Chat
 					},
 				},
 			}
-		// TODO: Handle tool messages
+		case shared.MessageRoleTool:
+			toolCallID, _ := msg.Metadata[services.MetadataKeyToolCallID].(string)
+			if !pendingToolCalls[toolCallID] {
+				// The requesting assistant message fell outside the history window.
+				continue
+			}
+			param = openai.ToolMessage(msg.Content, toolCallID)
 		default:
 			// Let's be strict for now and return an error for unhandled roles.
 			return nil, errors.New("unsupported message role: " + string(msg.Role))
 		}
-		openAIMessages[i] = param
+		openAIMessages = append(openAIMessages, param)
 	}
 	return openAIMessages, nil
-} 
\ No newline at end of file
+}
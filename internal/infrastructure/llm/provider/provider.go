@@ -12,7 +12,9 @@ type ProviderClient interface {
 		ctx context.Context,
 		model *chat.Model,
 		messages []*chat.Message,
+		tools []*chat.Tool,
 	) (<-chan services.ChatStreamEvent, error)
-	// In the future, we could add other methods like:
-	// GetToolDefinitions() []ToolDefinition
+
+	// Embed returns one embedding per input, in the order of the inputs.
+	Embed(ctx context.Context, model *chat.Model, inputs []string) ([][]float32, error)
 } 
\ No newline at end of file
@@ -0,0 +1,26 @@
+package oidc
+
+import (
+	"fmt"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/services"
+)
+
+// NewIdentityProviders creates the sign-in providers listed in the configuration,
+// keyed by name. Providers contact their servers on first use, so a provider
+// that is down does not prevent startup.
+func NewIdentityProviders(cfg *config.Config) (map[string]services.IdentityProvider, error) {
+	providers := make(map[string]services.IdentityProvider, len(cfg.OIDC.Providers))
+	for _, p := range cfg.OIDC.Providers {
+		switch p.Type {
+		case "oidc":
+			providers[p.Name] = NewOIDCProvider(p)
+		case "github":
+			providers[p.Name] = NewGitHubProvider(p)
+		default:
+			return nil, fmt.Errorf("unsupported type %q for OIDC provider %s", p.Type, p.Name)
+		}
+	}
+	return providers, nil
+}
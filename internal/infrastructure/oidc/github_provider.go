@@ -0,0 +1,131 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/services"
+)
+
+// GitHubProvider signs users in with GitHub, which supports OAuth with PKCE but
+// not OpenID Connect: the user is read from the REST API instead of an ID token.
+type GitHubProvider struct {
+	config     config.OIDCProviderConfig
+	httpClient *http.Client
+}
+
+// NewGitHubProvider creates a GitHub sign-in provider.
+func NewGitHubProvider(cfg config.OIDCProviderConfig) services.IdentityProvider {
+	return &GitHubProvider{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// AuthCodeURL returns the URL of GitHub's authorization page. GitHub has no
+// nonce; the state and the PKCE verifier bind the callback to the request.
+func (p *GitHubProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge, redirectURL string) (string, error) {
+	query := url.Values{
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+		"allow_signup":          {"true"},
+	}
+	return strings.TrimRight(p.config.IssuerURL, "/") + "/login/oauth/authorize?" + query.Encode(), nil
+}
+
+// githubUser is the response of GET /user.
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+// githubEmail is an item of the response of GET /user/emails.
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// Exchange redeems an authorization code and reads the user and their verified
+// primary email from the API.
+func (p *GitHubProvider) Exchange(ctx context.Context, code, codeVerifier, nonce, redirectURL string) (*services.ExternalIdentity, error) {
+	form := url.Values{
+		"client_id":     {p.config.ClientID},
+		"client_secret": {p.config.ClientSecret},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.config.IssuerURL, "/")+"/login/oauth/access_token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	// GitHub reports invalid codes with a 200 response holding an error
+	var token oidcTokenResponse
+	if err := doJSON(p.httpClient, req, &token); err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	if token.Error != "" {
+		return nil, fmt.Errorf("token request failed: %s: %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+
+	var user githubUser
+	if err := p.get(ctx, "/user", token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+	var emails []githubEmail
+	if err := p.get(ctx, "/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &services.ExternalIdentity{Subject: strconv.FormatInt(user.ID, 10)}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+			break
+		}
+	}
+	if user.Name == "" {
+		user.Name = user.Login
+	}
+	if firstName, lastName := splitName(user.Name); firstName != "" {
+		identity.FirstName = &firstName
+		if lastName != "" {
+			identity.LastName = &lastName
+		}
+	}
+	return identity, nil
+}
+
+// get calls an endpoint of the REST API on behalf of the user.
+func (p *GitHubProvider) get(ctx context.Context, path, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(p.config.APIURL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if err := doJSON(p.httpClient, req, out); err != nil {
+		return fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	return nil
+}
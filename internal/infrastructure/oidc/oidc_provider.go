@@ -0,0 +1,368 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/services"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// discoveryTTL is how long the discovery document of a provider is cached.
+	discoveryTTL = time.Hour
+	// minKeyRefreshInterval limits how often the JWKS is fetched again when an
+	// ID token is signed with an unknown key.
+	minKeyRefreshInterval = time.Minute
+	// clockSkew is the leeway allowed when checking the times in ID tokens.
+	clockSkew = time.Minute
+	// maxResponseSize bounds the responses read from providers.
+	maxResponseSize = 1 << 20
+)
+
+// idTokenSigningMethods are the ID token algorithms accepted. Symmetric ones
+// are left out as they would be keyed with the client secret.
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// discoveryDocument holds the fields used from a provider's
+// /.well-known/openid-configuration.
+type discoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// OIDCProvider signs users in with an OpenID Connect provider, configured from
+// its discovery document. ID tokens are verified against the provider's JWKS.
+type OIDCProvider struct {
+	config     config.OIDCProviderConfig
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	discovery     *discoveryDocument
+	discoveredAt  time.Time
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+// NewOIDCProvider creates an OpenID Connect sign-in provider.
+func NewOIDCProvider(cfg config.OIDCProviderConfig) services.IdentityProvider {
+	return &OIDCProvider{
+		config:     cfg,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// AuthCodeURL returns the URL of the provider's sign-in page.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge, redirectURL string) (string, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", redirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// oidcTokenResponse is the response of the token endpoint.
+type oidcTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange redeems an authorization code and verifies the returned ID token.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce, redirectURL string) (*services.ExternalIdentity, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	basicAuth := p.config.ClientSecret != "" && (len(doc.TokenEndpointAuthMethodsSupported) == 0 ||
+		slices.Contains(doc.TokenEndpointAuthMethodsSupported, "client_secret_basic"))
+	if !basicAuth {
+		form.Set("client_id", p.config.ClientID)
+		if p.config.ClientSecret != "" {
+			form.Set("client_secret", p.config.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basicAuth {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var token oidcTokenResponse
+	if err := p.doJSON(req, &token); err != nil {
+		if token.Error != "" {
+			return nil, fmt.Errorf("token request failed: %s: %s", token.Error, token.ErrorDescription)
+		}
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no ID token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, doc, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// Providers may leave the email out of the ID token and only return it from
+	// the userinfo endpoint
+	if claims.Email == "" && doc.UserinfoEndpoint != "" && token.AccessToken != "" {
+		userinfo, err := p.fetchUserinfo(ctx, doc.UserinfoEndpoint, token.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		if userinfo.Subject != claims.Subject {
+			return nil, errors.New("userinfo subject does not match the ID token")
+		}
+		claims.userClaims = userinfo.userClaims
+	}
+
+	return claims.toExternalIdentity(), nil
+}
+
+// userClaims are the standard claims describing the user, found in ID tokens
+// and userinfo responses.
+type userClaims struct {
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	GivenName     string   `json:"given_name"`
+	FamilyName    string   `json:"family_name"`
+	Name          string   `json:"name"`
+}
+
+// idTokenClaims are the claims of an ID token.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	userClaims
+	Nonce string `json:"nonce"`
+}
+
+// userinfoResponse is the response of the userinfo endpoint.
+type userinfoResponse struct {
+	Subject string `json:"sub"`
+	userClaims
+}
+
+// flexBool reads booleans that some providers send as strings.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
+
+func (c *idTokenClaims) toExternalIdentity() *services.ExternalIdentity {
+	identity := &services.ExternalIdentity{
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: bool(c.EmailVerified),
+	}
+	firstName, lastName := c.GivenName, c.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName = splitName(c.Name)
+	}
+	if firstName != "" {
+		identity.FirstName = &firstName
+	}
+	if lastName != "" {
+		identity.LastName = &lastName
+	}
+	return identity
+}
+
+// verifyIDToken checks the signature, issuer, audience, lifetime and nonce of
+// an ID token and returns its claims.
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, doc *discoveryDocument, idToken, nonce string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.signingKey(ctx, doc, kid)
+	},
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid ID token: missing subject")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("invalid ID token: nonce does not match the authorization request")
+	}
+	return claims, nil
+}
+
+// signingKey returns the provider's public key with the given ID, fetching the
+// JWKS again when the key is unknown, as happens after a key rotation.
+func (p *OIDCProvider) signingKey(ctx context.Context, doc *discoveryDocument, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key := lookupKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < minKeyRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, doc.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	var set jsonWebKeySet
+	if err := p.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+	p.keys = set.publicKeys()
+	p.keysFetchedAt = time.Now()
+
+	if key := lookupKey(p.keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupKey finds a key by ID. Tokens without a key ID can only be verified
+// when the provider has a single key.
+func lookupKey(keys map[string]crypto.PublicKey, kid string) crypto.PublicKey {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key
+		}
+	}
+	return keys[kid]
+}
+
+// discover returns the provider's discovery document, fetching it when it is
+// missing or stale.
+func (p *OIDCProvider) discover(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil && time.Since(p.discoveredAt) < discoveryTTL {
+		return p.discovery, nil
+	}
+
+	issuer := strings.TrimRight(p.config.IssuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var doc discoveryDocument
+	if err := p.doJSON(req, &doc); err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider %s: %w", p.config.Name, err)
+	}
+	if strings.TrimRight(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC provider %s reports issuer %q instead of %q", p.config.Name, doc.Issuer, p.config.IssuerURL)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC provider %s has an incomplete discovery document", p.config.Name)
+	}
+
+	p.discovery = &doc
+	p.discoveredAt = time.Now()
+	return p.discovery, nil
+}
+
+// fetchUserinfo calls the userinfo endpoint with the access token.
+func (p *OIDCProvider) fetchUserinfo(ctx context.Context, endpoint, accessToken string) (*userinfoResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var userinfo userinfoResponse
+	if err := p.doJSON(req, &userinfo); err != nil {
+		return nil, fmt.Errorf("userinfo request failed: %w", err)
+	}
+	return &userinfo, nil
+}
+
+func (p *OIDCProvider) doJSON(req *http.Request, out interface{}) error {
+	return doJSON(p.httpClient, req, out)
+}
+
+// doJSON sends a request and decodes its JSON response into out. The body of
+// error responses is decoded too, as it often describes the error.
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	decodeErr := json.Unmarshal(body, out)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("request to %s failed with status %d", req.URL.Redacted(), resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+	return nil
+}
+
+// splitName splits a display name into a first name and the rest.
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	first, last, _ := strings.Cut(name, " ")
+	return first, strings.TrimSpace(last)
+}
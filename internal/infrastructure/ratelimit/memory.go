@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"trading-alchemist/internal/domain/ratelimit"
+)
+
+// MemoryLimiter keeps token buckets in process memory.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*ratelimit.Bucket
+	lastSweep time.Time
+}
+
+// NewMemoryLimiter creates a MemoryLimiter without buckets.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*ratelimit.Bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Decision, error) {
+	if !limit.Enabled() {
+		return &ratelimit.Decision{Allowed: true}, nil
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		for k, bucket := range l.buckets {
+			if now.After(bucket.ExpiresAt) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = ratelimit.NewBucket(key, limit, now)
+		l.buckets[key] = bucket
+	}
+	return bucket.Take(limit, now), nil
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"trading-alchemist/internal/domain/ratelimit"
+	"trading-alchemist/internal/infrastructure/database"
+)
+
+// PostgresLimiter keeps token buckets in the rate_limit_buckets table, so all
+// server instances share them. Each request locks its bucket row for the
+// duration of a short transaction.
+type PostgresLimiter struct {
+	dbService *database.Service
+	lastSweep atomic.Int64 // Unix nanoseconds
+}
+
+// NewPostgresLimiter creates a new PostgresLimiter.
+func NewPostgresLimiter(dbService *database.Service) *PostgresLimiter {
+	l := &PostgresLimiter{dbService: dbService}
+	l.lastSweep.Store(time.Now().UnixNano())
+	return l
+}
+
+func (l *PostgresLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (*ratelimit.Decision, error) {
+	if !limit.Enabled() {
+		return &ratelimit.Decision{Allowed: true}, nil
+	}
+
+	now := time.Now()
+	l.sweep(ctx, now)
+
+	var decision *ratelimit.Decision
+	err := l.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		repo := provider.RateLimitBucket()
+		bucket, err := repo.Lock(ctx, key, limit, now)
+		if err != nil {
+			return err
+		}
+		decision = bucket.Take(limit, now)
+		return repo.Update(ctx, bucket)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decision, nil
+}
+
+// sweep drops the buckets that are full again, at most once per sweepInterval
+// per process.
+func (l *PostgresLimiter) sweep(ctx context.Context, now time.Time) {
+	last := l.lastSweep.Load()
+	if now.UnixNano()-last < int64(sweepInterval) || !l.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	err := l.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		_, err := provider.RateLimitBucket().DeleteExpired(ctx, now)
+		return err
+	})
+	if err != nil {
+		log.Printf("Failed to delete expired rate limit buckets: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/ratelimit"
+	"trading-alchemist/internal/infrastructure/database"
+)
+
+// Bucket stores selectable with RATE_LIMIT_STORE.
+const (
+	StoreMemory   = "memory"   // Per process; each server instance counts on its own
+	StorePostgres = "postgres" // Shared by all server instances
+)
+
+// sweepInterval is how often buckets that are full again are dropped.
+const sweepInterval = time.Minute
+
+// New creates the rate limiter configured by cfg. It allows every request when
+// rate limiting is turned off.
+func New(cfg config.RateLimitConfig, dbService *database.Service) (ratelimit.Limiter, error) {
+	if !cfg.Enabled {
+		return ratelimit.Unlimited{}, nil
+	}
+	switch cfg.Store {
+	case StoreMemory:
+		return NewMemoryLimiter(), nil
+	case StorePostgres:
+		return NewPostgresLimiter(dbService), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", cfg.Store)
+	}
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EventRepository implements the domain's alert EventRepository interface using PostgreSQL.
+type EventRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewEventRepository creates a new postgres alert event repository.
+func NewEventRepository(db sqlc.DBTX) alert.EventRepository {
+	return &EventRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *EventRepository) Create(ctx context.Context, event *alert.Event) (*alert.Event, error) {
+	sqlcEvent, err := r.queries.CreateAlertEvent(ctx, sqlc.CreateAlertEventParams{
+		RuleID:      pgtype.UUID{Bytes: event.RuleID, Valid: true},
+		UserID:      pgtype.UUID{Bytes: event.UserID, Valid: true},
+		Symbol:      event.Symbol,
+		Timeframe:   string(event.Timeframe),
+		CandleTime:  pgtype.Timestamptz{Time: event.CandleTime, Valid: true},
+		Price:       event.Price,
+		Message:     event.Message,
+		EmailStatus: string(event.EmailStatus),
+	})
+	if err != nil {
+		// ON CONFLICT DO NOTHING returns no row when the rule already fired on this candle
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrDuplicateAlertEvent
+		}
+		return nil, fmt.Errorf("failed to create alert event: %w", err)
+	}
+	return sqlcAlertEventToEntity(&sqlcEvent), nil
+}
+
+func (r *EventRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*alert.Event, error) {
+	sqlcEvents, err := r.queries.GetAlertEventsByUserID(ctx, sqlc.GetAlertEventsByUserIDParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert events by user ID: %w", err)
+	}
+
+	events := make([]*alert.Event, len(sqlcEvents))
+	for i, e := range sqlcEvents {
+		events[i] = sqlcAlertEventToEntity(&e)
+	}
+	return events, nil
+}
+
+func (r *EventRepository) GetByRuleID(ctx context.Context, ruleID uuid.UUID, limit, offset int) ([]*alert.Event, error) {
+	sqlcEvents, err := r.queries.GetAlertEventsByRuleID(ctx, sqlc.GetAlertEventsByRuleIDParams{
+		RuleID: pgtype.UUID{Bytes: ruleID, Valid: true},
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert events by rule ID: %w", err)
+	}
+
+	events := make([]*alert.Event, len(sqlcEvents))
+	for i, e := range sqlcEvents {
+		events[i] = sqlcAlertEventToEntity(&e)
+	}
+	return events, nil
+}
+
+func (r *EventRepository) UpdateEmailStatus(ctx context.Context, id uuid.UUID, status alert.EmailStatus) error {
+	err := r.queries.UpdateAlertEventEmailStatus(ctx, sqlc.UpdateAlertEventEmailStatusParams{
+		ID:          pgtype.UUID{Bytes: id, Valid: true},
+		EmailStatus: string(status),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update alert event email status: %w", err)
+	}
+	return nil
+}
+
+func sqlcAlertEventToEntity(e *sqlc.AlertEvent) *alert.Event {
+	return &alert.Event{
+		ID:          e.ID.Bytes,
+		RuleID:      e.RuleID.Bytes,
+		UserID:      e.UserID.Bytes,
+		Symbol:      e.Symbol,
+		Timeframe:   market.Timeframe(e.Timeframe),
+		CandleTime:  e.CandleTime.Time,
+		Price:       e.Price,
+		Message:     e.Message,
+		EmailStatus: alert.EmailStatus(e.EmailStatus),
+		TriggeredAt: e.TriggeredAt.Time,
+	}
+}
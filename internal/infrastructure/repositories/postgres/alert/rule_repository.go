@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/strategy"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RuleRepository implements the domain's alert RuleRepository interface using PostgreSQL.
+type RuleRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewRuleRepository creates a new postgres alert rule repository.
+func NewRuleRepository(db sqlc.DBTX) alert.RuleRepository {
+	return &RuleRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *RuleRepository) Create(ctx context.Context, rule *alert.Rule) (*alert.Rule, error) {
+	condition, err := conditionToJSON(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+	sqlcRule, err := r.queries.CreateAlertRule(ctx, sqlc.CreateAlertRuleParams{
+		UserID:             pgtype.UUID{Bytes: rule.UserID, Valid: true},
+		Name:               rule.Name,
+		Symbol:             textFromPtr(rule.Symbol),
+		WatchlistID:        uuidFromPtr(rule.WatchlistID),
+		Timeframe:          string(rule.Timeframe),
+		Type:               string(rule.Type),
+		Direction:          string(rule.Direction),
+		Level:              float8FromPtr(rule.Level),
+		Percent:            float8FromPtr(rule.Percent),
+		WindowSeconds:      int4FromPtr(rule.WindowSeconds),
+		IndicatorCondition: condition,
+		CooldownSeconds:    int32(rule.CooldownSeconds),
+		NotifyEmail:        rule.NotifyEmail,
+		NotifyInApp:        rule.NotifyInApp,
+		Enabled:            rule.Enabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	return sqlcAlertRuleToEntity(&sqlcRule)
+}
+
+func (r *RuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*alert.Rule, error) {
+	sqlcRule, err := r.queries.GetAlertRuleByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrAlertRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get alert rule by ID: %w", err)
+	}
+	return sqlcAlertRuleToEntity(&sqlcRule)
+}
+
+func (r *RuleRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*alert.Rule, error) {
+	sqlcRules, err := r.queries.GetAlertRulesByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules by user ID: %w", err)
+	}
+	return sqlcAlertRulesToEntities(sqlcRules)
+}
+
+func (r *RuleRepository) GetEnabled(ctx context.Context) ([]*alert.Rule, error) {
+	sqlcRules, err := r.queries.GetEnabledAlertRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled alert rules: %w", err)
+	}
+	return sqlcAlertRulesToEntities(sqlcRules)
+}
+
+func (r *RuleRepository) Update(ctx context.Context, rule *alert.Rule) (*alert.Rule, error) {
+	condition, err := conditionToJSON(rule.Condition)
+	if err != nil {
+		return nil, err
+	}
+	sqlcRule, err := r.queries.UpdateAlertRule(ctx, sqlc.UpdateAlertRuleParams{
+		ID:                 pgtype.UUID{Bytes: rule.ID, Valid: true},
+		Name:               rule.Name,
+		Symbol:             textFromPtr(rule.Symbol),
+		WatchlistID:        uuidFromPtr(rule.WatchlistID),
+		Timeframe:          string(rule.Timeframe),
+		Type:               string(rule.Type),
+		Direction:          string(rule.Direction),
+		Level:              float8FromPtr(rule.Level),
+		Percent:            float8FromPtr(rule.Percent),
+		WindowSeconds:      int4FromPtr(rule.WindowSeconds),
+		IndicatorCondition: condition,
+		CooldownSeconds:    int32(rule.CooldownSeconds),
+		NotifyEmail:        rule.NotifyEmail,
+		NotifyInApp:        rule.NotifyInApp,
+		Enabled:            rule.Enabled,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrAlertRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to update alert rule: %w", err)
+	}
+	return sqlcAlertRuleToEntity(&sqlcRule)
+}
+
+func (r *RuleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteAlertRule(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+	return nil
+}
+
+func conditionToJSON(c *strategy.Condition) ([]byte, error) {
+	if c == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal indicator condition: %w", err)
+	}
+	return data, nil
+}
+
+func sqlcAlertRulesToEntities(sqlcRules []sqlc.AlertRule) ([]*alert.Rule, error) {
+	rules := make([]*alert.Rule, len(sqlcRules))
+	for i, rule := range sqlcRules {
+		entity, err := sqlcAlertRuleToEntity(&rule)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = entity
+	}
+	return rules, nil
+}
+
+func sqlcAlertRuleToEntity(r *sqlc.AlertRule) (*alert.Rule, error) {
+	rule := &alert.Rule{
+		ID:              r.ID.Bytes,
+		UserID:          r.UserID.Bytes,
+		Name:            r.Name,
+		Symbol:          ptrFromText(r.Symbol),
+		Timeframe:       market.Timeframe(r.Timeframe),
+		Type:            alert.RuleType(r.Type),
+		Direction:       alert.Direction(r.Direction),
+		Level:           ptrFromFloat8(r.Level),
+		Percent:         ptrFromFloat8(r.Percent),
+		CooldownSeconds: int(r.CooldownSeconds),
+		NotifyEmail:     r.NotifyEmail,
+		NotifyInApp:     r.NotifyInApp,
+		Enabled:         r.Enabled,
+		CreatedAt:       r.CreatedAt.Time,
+		UpdatedAt:       r.UpdatedAt.Time,
+	}
+	if r.WatchlistID.Valid {
+		id := uuid.UUID(r.WatchlistID.Bytes)
+		rule.WatchlistID = &id
+	}
+	if r.WindowSeconds.Valid {
+		seconds := int(r.WindowSeconds.Int32)
+		rule.WindowSeconds = &seconds
+	}
+	if len(r.IndicatorCondition) > 0 {
+		// The condition was validated when the rule was saved, so parsing only fails on corrupt rows.
+		condition, err := strategy.ParseCondition(r.IndicatorCondition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse indicator condition of alert rule %s: %w", uuid.UUID(r.ID.Bytes), err)
+		}
+		rule.Condition = condition
+	}
+	return rule, nil
+}
+
+func uuidFromPtr(v *uuid.UUID) pgtype.UUID {
+	if v == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *v, Valid: true}
+}
+
+func float8FromPtr(v *float64) pgtype.Float8 {
+	if v == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *v, Valid: true}
+}
+
+func ptrFromFloat8(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func int4FromPtr(v *int) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*v), Valid: true}
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// StateRepository implements the domain's alert StateRepository interface using PostgreSQL.
+type StateRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewStateRepository creates a new postgres alert rule state repository.
+func NewStateRepository(db sqlc.DBTX) alert.StateRepository {
+	return &StateRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *StateRepository) Get(ctx context.Context, ruleID uuid.UUID, symbol string) (*alert.State, error) {
+	sqlcState, err := r.queries.GetAlertRuleState(ctx, sqlc.GetAlertRuleStateParams{
+		RuleID: pgtype.UUID{Bytes: ruleID, Valid: true},
+		Symbol: symbol,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrAlertRuleStateNotFound
+		}
+		return nil, fmt.Errorf("failed to get alert rule state: %w", err)
+	}
+	return sqlcAlertRuleStateToEntity(&sqlcState), nil
+}
+
+func (r *StateRepository) Upsert(ctx context.Context, state *alert.State) (*alert.State, error) {
+	params := sqlc.UpsertAlertRuleStateParams{
+		RuleID:         pgtype.UUID{Bytes: state.RuleID, Valid: true},
+		Symbol:         state.Symbol,
+		LastCandleTime: pgtype.Timestamptz{Time: state.LastCandleTime, Valid: true},
+		Active:         state.Active,
+	}
+	if state.LastTriggeredAt != nil {
+		params.LastTriggeredAt = pgtype.Timestamptz{Time: *state.LastTriggeredAt, Valid: true}
+	}
+	sqlcState, err := r.queries.UpsertAlertRuleState(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert alert rule state: %w", err)
+	}
+	return sqlcAlertRuleStateToEntity(&sqlcState), nil
+}
+
+func (r *StateRepository) DeleteByRuleID(ctx context.Context, ruleID uuid.UUID) error {
+	if err := r.queries.DeleteAlertRuleStates(ctx, pgtype.UUID{Bytes: ruleID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete alert rule states: %w", err)
+	}
+	return nil
+}
+
+func sqlcAlertRuleStateToEntity(s *sqlc.AlertRuleState) *alert.State {
+	state := &alert.State{
+		ID:             s.ID.Bytes,
+		RuleID:         s.RuleID.Bytes,
+		Symbol:         s.Symbol,
+		LastCandleTime: s.LastCandleTime.Time,
+		Active:         s.Active,
+		UpdatedAt:      s.UpdatedAt.Time,
+	}
+	if s.LastTriggeredAt.Valid {
+		state.LastTriggeredAt = &s.LastTriggeredAt.Time
+	}
+	return state
+}
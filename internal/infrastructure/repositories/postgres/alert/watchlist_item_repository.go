@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// WatchlistItemRepository implements the domain's alert WatchlistItemRepository interface using PostgreSQL.
+type WatchlistItemRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewWatchlistItemRepository creates a new postgres watchlist item repository.
+func NewWatchlistItemRepository(db sqlc.DBTX) alert.WatchlistItemRepository {
+	return &WatchlistItemRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *WatchlistItemRepository) Upsert(ctx context.Context, item *alert.WatchlistItem) (*alert.WatchlistItem, error) {
+	sqlcItem, err := r.queries.UpsertWatchlistItem(ctx, sqlc.UpsertWatchlistItemParams{
+		WatchlistID: pgtype.UUID{Bytes: item.WatchlistID, Valid: true},
+		Symbol:      item.Symbol,
+		Notes:       textFromPtr(item.Notes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert watchlist item: %w", err)
+	}
+	return sqlcWatchlistItemToEntity(&sqlcItem), nil
+}
+
+func (r *WatchlistItemRepository) GetByWatchlistID(ctx context.Context, watchlistID uuid.UUID) ([]*alert.WatchlistItem, error) {
+	sqlcItems, err := r.queries.GetWatchlistItems(ctx, pgtype.UUID{Bytes: watchlistID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist items: %w", err)
+	}
+
+	items := make([]*alert.WatchlistItem, len(sqlcItems))
+	for i, item := range sqlcItems {
+		items[i] = sqlcWatchlistItemToEntity(&item)
+	}
+	return items, nil
+}
+
+func (r *WatchlistItemRepository) Delete(ctx context.Context, watchlistID uuid.UUID, symbol string) error {
+	err := r.queries.DeleteWatchlistItem(ctx, sqlc.DeleteWatchlistItemParams{
+		WatchlistID: pgtype.UUID{Bytes: watchlistID, Valid: true},
+		Symbol:      symbol,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete watchlist item: %w", err)
+	}
+	return nil
+}
+
+func sqlcWatchlistItemToEntity(item *sqlc.WatchlistItem) *alert.WatchlistItem {
+	return &alert.WatchlistItem{
+		ID:          item.ID.Bytes,
+		WatchlistID: item.WatchlistID.Bytes,
+		Symbol:      item.Symbol,
+		Notes:       ptrFromText(item.Notes),
+		CreatedAt:   item.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/alert"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// WatchlistRepository implements the domain's alert WatchlistRepository interface using PostgreSQL.
+type WatchlistRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewWatchlistRepository creates a new postgres watchlist repository.
+func NewWatchlistRepository(db sqlc.DBTX) alert.WatchlistRepository {
+	return &WatchlistRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *WatchlistRepository) Create(ctx context.Context, w *alert.Watchlist) (*alert.Watchlist, error) {
+	sqlcWatchlist, err := r.queries.CreateWatchlist(ctx, sqlc.CreateWatchlistParams{
+		UserID:      pgtype.UUID{Bytes: w.UserID, Valid: true},
+		Name:        w.Name,
+		Description: textFromPtr(w.Description),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watchlist: %w", err)
+	}
+	return sqlcWatchlistToEntity(&sqlcWatchlist), nil
+}
+
+func (r *WatchlistRepository) GetByID(ctx context.Context, id uuid.UUID) (*alert.Watchlist, error) {
+	sqlcWatchlist, err := r.queries.GetWatchlistByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrWatchlistNotFound
+		}
+		return nil, fmt.Errorf("failed to get watchlist by ID: %w", err)
+	}
+	return sqlcWatchlistToEntity(&sqlcWatchlist), nil
+}
+
+func (r *WatchlistRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*alert.Watchlist, error) {
+	sqlcWatchlists, err := r.queries.GetWatchlistsByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlists by user ID: %w", err)
+	}
+
+	watchlists := make([]*alert.Watchlist, len(sqlcWatchlists))
+	for i, w := range sqlcWatchlists {
+		watchlists[i] = sqlcWatchlistToEntity(&w)
+	}
+	return watchlists, nil
+}
+
+func (r *WatchlistRepository) Update(ctx context.Context, w *alert.Watchlist) (*alert.Watchlist, error) {
+	sqlcWatchlist, err := r.queries.UpdateWatchlist(ctx, sqlc.UpdateWatchlistParams{
+		ID:          pgtype.UUID{Bytes: w.ID, Valid: true},
+		Name:        w.Name,
+		Description: textFromPtr(w.Description),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrWatchlistNotFound
+		}
+		return nil, fmt.Errorf("failed to update watchlist: %w", err)
+	}
+	return sqlcWatchlistToEntity(&sqlcWatchlist), nil
+}
+
+func (r *WatchlistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteWatchlist(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete watchlist: %w", err)
+	}
+	return nil
+}
+
+func sqlcWatchlistToEntity(w *sqlc.Watchlist) *alert.Watchlist {
+	return &alert.Watchlist{
+		ID:          w.ID.Bytes,
+		UserID:      w.UserID.Bytes,
+		Name:        w.Name,
+		Description: ptrFromText(w.Description),
+		CreatedAt:   w.CreatedAt.Time,
+		UpdatedAt:   w.UpdatedAt.Time,
+	}
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+func ptrFromText(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EventRepository implements the domain's EventRepository interface using PostgreSQL.
+type EventRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewEventRepository creates a new postgres audit event repository.
+func NewEventRepository(db sqlc.DBTX) audit.EventRepository {
+	return &EventRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create appends an event to the audit log.
+func (r *EventRepository) Create(ctx context.Context, event *audit.Event) (*audit.Event, error) {
+	metadata := []byte("{}")
+	if len(event.Metadata) > 0 {
+		var err error
+		metadata, err = json.Marshal(event.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal audit event metadata: %w", err)
+		}
+	}
+
+	params := sqlc.CreateAuditEventParams{
+		Action:     string(event.Action),
+		ActorID:    uuidFromPtr(event.ActorID),
+		UserID:     uuidFromPtr(event.UserID),
+		ResourceID: uuidFromPtr(event.ResourceID),
+		Metadata:   metadata,
+	}
+	if event.ResourceType != "" {
+		params.ResourceType = pgtype.Text{String: event.ResourceType, Valid: true}
+	}
+	if event.IPAddress != nil {
+		if ip := net.ParseIP(*event.IPAddress); ip != nil {
+			if addr, ok := netip.AddrFromSlice(ip); ok {
+				addr = addr.Unmap()
+				params.IpAddress = &addr
+			}
+		}
+	}
+	if event.UserAgent != nil {
+		params.UserAgent = pgtype.Text{String: *event.UserAgent, Valid: true}
+	}
+
+	created, err := r.queries.CreateAuditEvent(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit event: %w", err)
+	}
+	return sqlcEventToEntity(&created), nil
+}
+
+// List returns the matching events, newest first.
+func (r *EventRepository) List(ctx context.Context, filter audit.EventFilter, limit, offset int) ([]*audit.Event, error) {
+	params := sqlc.ListAuditEventsParams{
+		InvolvingUserID: uuidFromPtr(filter.InvolvingUserID),
+		ActorID:         uuidFromPtr(filter.ActorID),
+		UserID:          uuidFromPtr(filter.UserID),
+		Action:          string(filter.Action),
+		ResourceType:    filter.ResourceType,
+		Since:           timestamptzFromPtr(filter.Since),
+		Until:           timestamptzFromPtr(filter.Until),
+		MaxRows:         int32(limit),
+		SkipRows:        int32(offset),
+	}
+	sqlcEvents, err := r.queries.ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	events := make([]*audit.Event, len(sqlcEvents))
+	for i, e := range sqlcEvents {
+		events[i] = sqlcEventToEntity(&e)
+	}
+	return events, nil
+}
+
+// Count returns the number of matching events.
+func (r *EventRepository) Count(ctx context.Context, filter audit.EventFilter) (int64, error) {
+	count, err := r.queries.CountAuditEvents(ctx, sqlc.CountAuditEventsParams{
+		InvolvingUserID: uuidFromPtr(filter.InvolvingUserID),
+		ActorID:         uuidFromPtr(filter.ActorID),
+		UserID:          uuidFromPtr(filter.UserID),
+		Action:          string(filter.Action),
+		ResourceType:    filter.ResourceType,
+		Since:           timestamptzFromPtr(filter.Since),
+		Until:           timestamptzFromPtr(filter.Until),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+	return count, nil
+}
+
+func sqlcEventToEntity(e *sqlc.AuditEvent) *audit.Event {
+	event := &audit.Event{
+		ID:        e.ID.Bytes,
+		Action:    audit.Action(e.Action),
+		CreatedAt: e.CreatedAt.Time,
+	}
+	if e.ActorID.Valid {
+		id := uuid.UUID(e.ActorID.Bytes)
+		event.ActorID = &id
+	}
+	if e.UserID.Valid {
+		id := uuid.UUID(e.UserID.Bytes)
+		event.UserID = &id
+	}
+	if e.ResourceType.Valid {
+		event.ResourceType = e.ResourceType.String
+	}
+	if e.ResourceID.Valid {
+		id := uuid.UUID(e.ResourceID.Bytes)
+		event.ResourceID = &id
+	}
+	if e.IpAddress != nil {
+		ip := e.IpAddress.String()
+		event.IPAddress = &ip
+	}
+	if e.UserAgent.Valid {
+		event.UserAgent = &e.UserAgent.String
+	}
+	if len(e.Metadata) > 0 {
+		var metadata map[string]any
+		if err := json.Unmarshal(e.Metadata, &metadata); err == nil && len(metadata) > 0 {
+			event.Metadata = metadata
+		}
+	}
+	return event
+}
+
+func uuidFromPtr(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *id, Valid: true}
+}
+
+func timestamptzFromPtr(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AccountDeletionRepository implements the domain's AccountDeletionRepository interface using PostgreSQL.
+type AccountDeletionRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewAccountDeletionRepository creates a new postgres account deletion repository.
+func NewAccountDeletionRepository(db sqlc.DBTX) auth.AccountDeletionRepository {
+	return &AccountDeletionRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Schedule requests the deletion of a user's account; an earlier request keeps its date.
+func (r *AccountDeletionRepository) Schedule(ctx context.Context, userID uuid.UUID, scheduledFor time.Time) (*auth.AccountDeletion, error) {
+	sqlcDeletion, err := r.queries.ScheduleAccountDeletion(ctx, sqlc.ScheduleAccountDeletionParams{
+		UserID:       pgtype.UUID{Bytes: userID, Valid: true},
+		ScheduledFor: pgtype.Timestamptz{Time: scheduledFor, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+	return sqlcAccountDeletionToEntity(&sqlcDeletion), nil
+}
+
+// GetByUserID retrieves the user's pending deletion request.
+func (r *AccountDeletionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*auth.AccountDeletion, error) {
+	sqlcDeletion, err := r.queries.GetAccountDeletion(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrAccountDeletionNotFound
+		}
+		return nil, fmt.Errorf("failed to get account deletion: %w", err)
+	}
+	return sqlcAccountDeletionToEntity(&sqlcDeletion), nil
+}
+
+// Cancel withdraws the user's pending deletion request.
+func (r *AccountDeletionRepository) Cancel(ctx context.Context, userID uuid.UUID) error {
+	rows, err := r.queries.CancelAccountDeletion(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	if rows == 0 {
+		return errors.ErrAccountDeletionNotFound
+	}
+	return nil
+}
+
+// ListDue retrieves the requests scheduled at or before the given time, oldest first.
+func (r *AccountDeletionRepository) ListDue(ctx context.Context, before time.Time, limit int) ([]*auth.AccountDeletion, error) {
+	sqlcDeletions, err := r.queries.ListDueAccountDeletions(ctx, sqlc.ListDueAccountDeletionsParams{
+		DueAt:   pgtype.Timestamptz{Time: before, Valid: true},
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due account deletions: %w", err)
+	}
+
+	deletions := make([]*auth.AccountDeletion, len(sqlcDeletions))
+	for i := range sqlcDeletions {
+		deletions[i] = sqlcAccountDeletionToEntity(&sqlcDeletions[i])
+	}
+	return deletions, nil
+}
+
+func sqlcAccountDeletionToEntity(sqlcDeletion *sqlc.AccountDeletion) *auth.AccountDeletion {
+	return &auth.AccountDeletion{
+		UserID:       sqlcDeletion.UserID.Bytes,
+		ScheduledFor: sqlcDeletion.ScheduledFor.Time,
+		CreatedAt:    sqlcDeletion.CreatedAt.Time,
+	}
+}
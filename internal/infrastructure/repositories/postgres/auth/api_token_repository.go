@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// APITokenRepository implements the domain's APITokenRepository interface using PostgreSQL.
+type APITokenRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewAPITokenRepository creates a new postgres personal access token repository.
+func NewAPITokenRepository(db sqlc.DBTX) auth.APITokenRepository {
+	return &APITokenRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create creates a new personal access token.
+func (r *APITokenRepository) Create(ctx context.Context, token *auth.APIToken) (*auth.APIToken, error) {
+	params := sqlc.CreateAPITokenParams{
+		UserID:      pgtype.UUID{Bytes: token.UserID, Valid: true},
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		TokenHash:   token.TokenHash,
+		Scopes:      token.Scopes,
+	}
+	if token.ExpiresAt != nil {
+		params.ExpiresAt = pgtype.Timestamptz{Time: *token.ExpiresAt, Valid: true}
+	}
+
+	sqlcToken, err := r.queries.CreateAPIToken(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API token: %w", err)
+	}
+	return sqlcAPITokenToEntity(&sqlcToken), nil
+}
+
+// GetByID retrieves a token by its ID.
+func (r *APITokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*auth.APIToken, error) {
+	sqlcToken, err := r.queries.GetAPITokenByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrAPITokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get API token by ID: %w", err)
+	}
+	return sqlcAPITokenToEntity(&sqlcToken), nil
+}
+
+// GetByHash retrieves a token by the hash of its value.
+func (r *APITokenRepository) GetByHash(ctx context.Context, tokenHash string) (*auth.APIToken, error) {
+	sqlcToken, err := r.queries.GetAPITokenByHash(ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrAPITokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get API token by hash: %w", err)
+	}
+	return sqlcAPITokenToEntity(&sqlcToken), nil
+}
+
+// ListByUserID retrieves the user's tokens, newest first.
+func (r *APITokenRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*auth.APIToken, error) {
+	sqlcTokens, err := r.queries.ListAPITokensByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API tokens: %w", err)
+	}
+
+	tokens := make([]*auth.APIToken, len(sqlcTokens))
+	for i, t := range sqlcTokens {
+		tokens[i] = sqlcAPITokenToEntity(&t)
+	}
+	return tokens, nil
+}
+
+// CountByUserID counts the user's tokens.
+func (r *APITokenRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountAPITokensByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count API tokens: %w", err)
+	}
+	return int(count), nil
+}
+
+// Touch records that the token was used.
+func (r *APITokenRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.TouchAPIToken(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to touch API token: %w", err)
+	}
+	return nil
+}
+
+// Delete revokes a token.
+func (r *APITokenRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteAPIToken(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+	return nil
+}
+
+// sqlcAPITokenToEntity converts a SQLC ApiToken to a domain APIToken entity.
+func sqlcAPITokenToEntity(t *sqlc.ApiToken) *auth.APIToken {
+	token := &auth.APIToken{
+		ID:          t.ID.Bytes,
+		UserID:      t.UserID.Bytes,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		TokenHash:   t.TokenHash,
+		Scopes:      t.Scopes,
+		CreatedAt:   t.CreatedAt.Time,
+	}
+	if t.ExpiresAt.Valid {
+		token.ExpiresAt = &t.ExpiresAt.Time
+	}
+	if t.LastUsedAt.Valid {
+		token.LastUsedAt = &t.LastUsedAt.Time
+	}
+	return token
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IdentityRepository implements the domain's IdentityRepository interface using PostgreSQL.
+type IdentityRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewIdentityRepository creates a new postgres identity repository.
+func NewIdentityRepository(db sqlc.DBTX) auth.IdentityRepository {
+	return &IdentityRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create links an external identity to a user.
+func (r *IdentityRepository) Create(ctx context.Context, identity *auth.Identity) (*auth.Identity, error) {
+	sqlcIdentity, err := r.queries.CreateIdentity(ctx, sqlc.CreateIdentityParams{
+		UserID:   pgtype.UUID{Bytes: identity.UserID, Valid: true},
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    textFromPtr(identity.Email),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity: %w", err)
+	}
+	return sqlcIdentityToEntity(&sqlcIdentity), nil
+}
+
+// GetByProviderSubject retrieves the identity of a provider's user.
+func (r *IdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.Identity, error) {
+	sqlcIdentity, err := r.queries.GetIdentityByProviderSubject(ctx, sqlc.GetIdentityByProviderSubjectParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	return sqlcIdentityToEntity(&sqlcIdentity), nil
+}
+
+// ListByUserID retrieves the identities linked to a user.
+func (r *IdentityRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*auth.Identity, error) {
+	sqlcIdentities, err := r.queries.ListIdentitiesByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identities: %w", err)
+	}
+
+	identities := make([]*auth.Identity, len(sqlcIdentities))
+	for i, identity := range sqlcIdentities {
+		identities[i] = sqlcIdentityToEntity(&identity)
+	}
+	return identities, nil
+}
+
+// RecordLogin records a sign-in with the identity.
+func (r *IdentityRepository) RecordLogin(ctx context.Context, id uuid.UUID, email *string) error {
+	err := r.queries.UpdateIdentityLogin(ctx, sqlc.UpdateIdentityLoginParams{
+		ID:    pgtype.UUID{Bytes: id, Valid: true},
+		Email: textFromPtr(email),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record identity login: %w", err)
+	}
+	return nil
+}
+
+// OIDCLoginRequestRepository implements the domain's OIDCLoginRequestRepository interface using PostgreSQL.
+type OIDCLoginRequestRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewOIDCLoginRequestRepository creates a new postgres OIDC login request repository.
+func NewOIDCLoginRequestRepository(db sqlc.DBTX) auth.OIDCLoginRequestRepository {
+	return &OIDCLoginRequestRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create stores a pending authorization request.
+func (r *OIDCLoginRequestRepository) Create(ctx context.Context, request *auth.OIDCLoginRequest) (*auth.OIDCLoginRequest, error) {
+	sqlcRequest, err := r.queries.CreateOIDCLoginRequest(ctx, sqlc.CreateOIDCLoginRequestParams{
+		Provider:     request.Provider,
+		StateHash:    request.StateHash,
+		Nonce:        request.Nonce,
+		CodeVerifier: request.CodeVerifier,
+		RedirectUrl:  request.RedirectURL,
+		ExpiresAt:    pgtype.Timestamptz{Time: request.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OIDC login request: %w", err)
+	}
+	return sqlcOIDCLoginRequestToEntity(&sqlcRequest), nil
+}
+
+// Consume retrieves and deletes the request with the given state hash.
+func (r *OIDCLoginRequestRepository) Consume(ctx context.Context, stateHash string) (*auth.OIDCLoginRequest, error) {
+	sqlcRequest, err := r.queries.ConsumeOIDCLoginRequest(ctx, stateHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrOIDCLoginRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to consume OIDC login request: %w", err)
+	}
+	return sqlcOIDCLoginRequestToEntity(&sqlcRequest), nil
+}
+
+// CleanupExpired removes expired requests.
+func (r *OIDCLoginRequestRepository) CleanupExpired(ctx context.Context) error {
+	if err := r.queries.CleanupExpiredOIDCLoginRequests(ctx); err != nil {
+		return fmt.Errorf("failed to cleanup expired OIDC login requests: %w", err)
+	}
+	return nil
+}
+
+// sqlcIdentityToEntity converts a SQLC Identity to a domain Identity entity.
+func sqlcIdentityToEntity(i *sqlc.Identity) *auth.Identity {
+	identity := &auth.Identity{
+		ID:        i.ID.Bytes,
+		UserID:    i.UserID.Bytes,
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		CreatedAt: i.CreatedAt.Time,
+	}
+	if i.Email.Valid {
+		identity.Email = &i.Email.String
+	}
+	if i.LastLoginAt.Valid {
+		identity.LastLoginAt = &i.LastLoginAt.Time
+	}
+	return identity
+}
+
+// sqlcOIDCLoginRequestToEntity converts a SQLC OidcLoginRequest to a domain OIDCLoginRequest entity.
+func sqlcOIDCLoginRequestToEntity(r *sqlc.OidcLoginRequest) *auth.OIDCLoginRequest {
+	return &auth.OIDCLoginRequest{
+		ID:           r.ID.Bytes,
+		Provider:     r.Provider,
+		StateHash:    r.StateHash,
+		Nonce:        r.Nonce,
+		CodeVerifier: r.CodeVerifier,
+		RedirectURL:  r.RedirectUrl,
+		ExpiresAt:    r.ExpiresAt.Time,
+		CreatedAt:    r.CreatedAt.Time,
+	}
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
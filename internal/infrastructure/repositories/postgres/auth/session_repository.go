@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SessionRepository implements the domain's SessionRepository interface using PostgreSQL.
+type SessionRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewSessionRepository creates a new postgres session repository.
+func NewSessionRepository(db sqlc.DBTX) auth.SessionRepository {
+	return &SessionRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create creates a new session.
+func (r *SessionRepository) Create(ctx context.Context, session *auth.Session) (*auth.Session, error) {
+	params := sqlc.CreateSessionParams{
+		UserID:           pgtype.UUID{Bytes: session.UserID, Valid: true},
+		FamilyID:         pgtype.UUID{Bytes: session.FamilyID, Valid: true},
+		RefreshTokenHash: session.RefreshTokenHash,
+		AuthenticatedAt:  pgtype.Timestamptz{Time: session.AuthenticatedAt, Valid: true},
+		ExpiresAt:        pgtype.Timestamptz{Time: session.ExpiresAt, Valid: true},
+	}
+	if session.DeviceName != nil {
+		params.DeviceName = pgtype.Text{String: *session.DeviceName, Valid: true}
+	}
+	if session.IPAddress != nil {
+		if ip := net.ParseIP(*session.IPAddress); ip != nil {
+			if netipAddr, ok := netip.AddrFromSlice(ip); ok {
+				netipAddr = netipAddr.Unmap()
+				params.IpAddress = &netipAddr
+			}
+		}
+	}
+	if session.UserAgent != nil {
+		params.UserAgent = pgtype.Text{String: *session.UserAgent, Valid: true}
+	}
+	if session.SecondFactorAt != nil {
+		params.SecondFactorAt = pgtype.Timestamptz{Time: *session.SecondFactorAt, Valid: true}
+	}
+
+	sqlcSession, err := r.queries.CreateSession(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return sqlcSessionToEntity(&sqlcSession), nil
+}
+
+// GetByID retrieves a session by its ID.
+func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*auth.Session, error) {
+	sqlcSession, err := r.queries.GetSessionByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session by ID: %w", err)
+	}
+	return sqlcSessionToEntity(&sqlcSession), nil
+}
+
+// GetByRefreshTokenHash retrieves and locks the session of a refresh token.
+func (r *SessionRepository) GetByRefreshTokenHash(ctx context.Context, tokenHash string) (*auth.Session, error) {
+	sqlcSession, err := r.queries.GetSessionByRefreshTokenHash(ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session by refresh token: %w", err)
+	}
+	return sqlcSessionToEntity(&sqlcSession), nil
+}
+
+// ListActiveByUserID retrieves the user's sessions that can still be refreshed.
+func (r *SessionRepository) ListActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*auth.Session, error) {
+	sqlcSessions, err := r.queries.ListActiveSessionsByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*auth.Session, len(sqlcSessions))
+	for i, s := range sqlcSessions {
+		sessions[i] = sqlcSessionToEntity(&s)
+	}
+	return sessions, nil
+}
+
+// MarkRotated marks the session's refresh token as exchanged.
+func (r *SessionRepository) MarkRotated(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.RotateSession(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+	return nil
+}
+
+// MarkSecondFactor records that the user of the session has just verified a second factor.
+func (r *SessionRepository) MarkSecondFactor(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.MarkSessionSecondFactor(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to mark session second factor: %w", err)
+	}
+	return nil
+}
+
+// RevokeFamily revokes every session descending from the same sign-in.
+func (r *SessionRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	if err := r.queries.RevokeSessionFamily(ctx, pgtype.UUID{Bytes: familyID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to revoke session family: %w", err)
+	}
+	return nil
+}
+
+// Touch records activity on the session.
+func (r *SessionRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.TouchSession(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+// CleanupExpired removes expired sessions.
+func (r *SessionRepository) CleanupExpired(ctx context.Context) error {
+	if err := r.queries.CleanupExpiredSessions(ctx); err != nil {
+		return fmt.Errorf("failed to cleanup expired sessions: %w", err)
+	}
+	return nil
+}
+
+// sqlcSessionToEntity converts a SQLC Session to a domain Session entity.
+func sqlcSessionToEntity(s *sqlc.Session) *auth.Session {
+	session := &auth.Session{
+		ID:               s.ID.Bytes,
+		UserID:           s.UserID.Bytes,
+		FamilyID:         s.FamilyID.Bytes,
+		RefreshTokenHash: s.RefreshTokenHash,
+		AuthenticatedAt:  s.AuthenticatedAt.Time,
+		ExpiresAt:        s.ExpiresAt.Time,
+		LastSeenAt:       s.LastSeenAt.Time,
+		CreatedAt:        s.CreatedAt.Time,
+	}
+	if s.DeviceName.Valid {
+		session.DeviceName = &s.DeviceName.String
+	}
+	if s.IpAddress != nil {
+		ipStr := s.IpAddress.String()
+		session.IPAddress = &ipStr
+	}
+	if s.UserAgent.Valid {
+		session.UserAgent = &s.UserAgent.String
+	}
+	if s.SecondFactorAt.Valid {
+		session.SecondFactorAt = &s.SecondFactorAt.Time
+	}
+	if s.RotatedAt.Valid {
+		session.RotatedAt = &s.RotatedAt.Time
+	}
+	if s.RevokedAt.Valid {
+		session.RevokedAt = &s.RevokedAt.Time
+	}
+	return session
+}
@@ -0,0 +1,352 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+
+	"trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TwoFactorRepository implements the domain's TwoFactorRepository interface using PostgreSQL.
+type TwoFactorRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewTwoFactorRepository creates a new postgres two-factor repository.
+func NewTwoFactorRepository(db sqlc.DBTX) auth.TwoFactorRepository {
+	return &TwoFactorRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// UpsertTOTPSecret stores a new unconfirmed authenticator secret.
+func (r *TwoFactorRepository) UpsertTOTPSecret(ctx context.Context, userID uuid.UUID, encryptedSecret string) (*auth.TOTPSecret, error) {
+	sqlcSecret, err := r.queries.UpsertTOTPSecret(ctx, sqlc.UpsertTOTPSecretParams{
+		UserID:          pgtype.UUID{Bytes: userID, Valid: true},
+		EncryptedSecret: encryptedSecret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store TOTP secret: %w", err)
+	}
+	return sqlcTOTPSecretToEntity(&sqlcSecret), nil
+}
+
+// GetTOTPSecret retrieves and locks the user's authenticator secret.
+func (r *TwoFactorRepository) GetTOTPSecret(ctx context.Context, userID uuid.UUID) (*auth.TOTPSecret, error) {
+	sqlcSecret, err := r.queries.GetTOTPSecretByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrTOTPSecretNotFound
+		}
+		return nil, fmt.Errorf("failed to get TOTP secret: %w", err)
+	}
+	return sqlcTOTPSecretToEntity(&sqlcSecret), nil
+}
+
+// ConfirmTOTPSecret activates the user's authenticator secret.
+func (r *TwoFactorRepository) ConfirmTOTPSecret(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.ConfirmTOTPSecret(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to confirm TOTP secret: %w", err)
+	}
+	return nil
+}
+
+// UpdateTOTPLastUsedStep records the time step of the last accepted code.
+func (r *TwoFactorRepository) UpdateTOTPLastUsedStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	err := r.queries.UpdateTOTPLastUsedStep(ctx, sqlc.UpdateTOTPLastUsedStepParams{
+		UserID:       pgtype.UUID{Bytes: userID, Valid: true},
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update TOTP last used step: %w", err)
+	}
+	return nil
+}
+
+// DeleteTOTPSecret removes the user's authenticator secret.
+func (r *TwoFactorRepository) DeleteTOTPSecret(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteTOTPSecret(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete TOTP secret: %w", err)
+	}
+	return nil
+}
+
+// CreateWebAuthnCredential registers a security key or passkey.
+func (r *TwoFactorRepository) CreateWebAuthnCredential(ctx context.Context, credential *auth.WebAuthnCredential) (*auth.WebAuthnCredential, error) {
+	transports := credential.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	sqlcCredential, err := r.queries.CreateWebAuthnCredential(ctx, sqlc.CreateWebAuthnCredentialParams{
+		UserID:       pgtype.UUID{Bytes: credential.UserID, Valid: true},
+		Name:         credential.Name,
+		CredentialID: credential.CredentialID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+		Transports:   transports,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WebAuthn credential: %w", err)
+	}
+	return sqlcWebAuthnCredentialToEntity(&sqlcCredential), nil
+}
+
+// GetWebAuthnCredential retrieves a credential by its ID.
+func (r *TwoFactorRepository) GetWebAuthnCredential(ctx context.Context, id uuid.UUID) (*auth.WebAuthnCredential, error) {
+	sqlcCredential, err := r.queries.GetWebAuthnCredentialByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrWebAuthnCredentialNotFound
+		}
+		return nil, fmt.Errorf("failed to get WebAuthn credential: %w", err)
+	}
+	return sqlcWebAuthnCredentialToEntity(&sqlcCredential), nil
+}
+
+// GetWebAuthnCredentialByCredentialID retrieves and locks a credential by the ID the authenticator assigned.
+func (r *TwoFactorRepository) GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (*auth.WebAuthnCredential, error) {
+	sqlcCredential, err := r.queries.GetWebAuthnCredentialByCredentialID(ctx, credentialID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrWebAuthnCredentialNotFound
+		}
+		return nil, fmt.Errorf("failed to get WebAuthn credential: %w", err)
+	}
+	return sqlcWebAuthnCredentialToEntity(&sqlcCredential), nil
+}
+
+// ListWebAuthnCredentials retrieves the user's credentials.
+func (r *TwoFactorRepository) ListWebAuthnCredentials(ctx context.Context, userID uuid.UUID) ([]*auth.WebAuthnCredential, error) {
+	sqlcCredentials, err := r.queries.ListWebAuthnCredentialsByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WebAuthn credentials: %w", err)
+	}
+
+	credentials := make([]*auth.WebAuthnCredential, len(sqlcCredentials))
+	for i, c := range sqlcCredentials {
+		credentials[i] = sqlcWebAuthnCredentialToEntity(&c)
+	}
+	return credentials, nil
+}
+
+// CountWebAuthnCredentials counts the user's credentials.
+func (r *TwoFactorRepository) CountWebAuthnCredentials(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := r.queries.CountWebAuthnCredentialsByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count WebAuthn credentials: %w", err)
+	}
+	return count, nil
+}
+
+// RecordWebAuthnUsage records a sign-in with the credential.
+func (r *TwoFactorRepository) RecordWebAuthnUsage(ctx context.Context, id uuid.UUID, signCount uint32) error {
+	err := r.queries.UpdateWebAuthnCredentialUsage(ctx, sqlc.UpdateWebAuthnCredentialUsageParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		SignCount: int64(signCount),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record WebAuthn credential usage: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebAuthnCredential removes a credential.
+func (r *TwoFactorRepository) DeleteWebAuthnCredential(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteWebAuthnCredential(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete WebAuthn credential: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes replaces the user's recovery codes with the given hashes.
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	if err := r.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		err := r.queries.CreateRecoveryCode(ctx, sqlc.CreateRecoveryCodeParams{
+			UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+			CodeHash: hash,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used.
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	rows, err := r.queries.UseRecoveryCode(ctx, sqlc.UseRecoveryCodeParams{
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// CountUnusedRecoveryCodes counts the user's recovery codes that have not been used.
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	count, err := r.queries.CountUnusedRecoveryCodesByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteRecoveryCodes removes the user's recovery codes.
+func (r *TwoFactorRepository) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteRecoveryCodesByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	return nil
+}
+
+// TwoFactorChallengeRepository implements the domain's TwoFactorChallengeRepository interface using PostgreSQL.
+type TwoFactorChallengeRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewTwoFactorChallengeRepository creates a new postgres two-factor challenge repository.
+func NewTwoFactorChallengeRepository(db sqlc.DBTX) auth.TwoFactorChallengeRepository {
+	return &TwoFactorChallengeRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create stores a pending challenge.
+func (r *TwoFactorChallengeRepository) Create(ctx context.Context, challenge *auth.TwoFactorChallenge) (*auth.TwoFactorChallenge, error) {
+	params := sqlc.CreateTwoFactorChallengeParams{
+		UserID:            pgtype.UUID{Bytes: challenge.UserID, Valid: true},
+		Purpose:           string(challenge.Purpose),
+		TokenHash:         challenge.TokenHash,
+		WebauthnChallenge: challenge.WebAuthnChallenge,
+		DeviceName:        textFromPtr(challenge.DeviceName),
+		UserAgent:         textFromPtr(challenge.UserAgent),
+		ExpiresAt:         pgtype.Timestamptz{Time: challenge.ExpiresAt, Valid: true},
+	}
+	if challenge.SessionID != nil {
+		params.SessionID = pgtype.UUID{Bytes: *challenge.SessionID, Valid: true}
+	}
+	if challenge.IPAddress != nil {
+		if ip := net.ParseIP(*challenge.IPAddress); ip != nil {
+			if netipAddr, ok := netip.AddrFromSlice(ip); ok {
+				netipAddr = netipAddr.Unmap()
+				params.IpAddress = &netipAddr
+			}
+		}
+	}
+
+	sqlcChallenge, err := r.queries.CreateTwoFactorChallenge(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create two-factor challenge: %w", err)
+	}
+	return sqlcTwoFactorChallengeToEntity(&sqlcChallenge), nil
+}
+
+// GetByTokenHash retrieves and locks the challenge with the given token hash.
+func (r *TwoFactorChallengeRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*auth.TwoFactorChallenge, error) {
+	sqlcChallenge, err := r.queries.GetTwoFactorChallengeByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrTwoFactorChallengeNotFound
+		}
+		return nil, fmt.Errorf("failed to get two-factor challenge: %w", err)
+	}
+	return sqlcTwoFactorChallengeToEntity(&sqlcChallenge), nil
+}
+
+// IncrementAttempts records a failed attempt.
+func (r *TwoFactorChallengeRepository) IncrementAttempts(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.IncrementTwoFactorChallengeAttempts(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to increment two-factor challenge attempts: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a challenge.
+func (r *TwoFactorChallengeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteTwoFactorChallenge(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete two-factor challenge: %w", err)
+	}
+	return nil
+}
+
+// CleanupExpired removes expired challenges.
+func (r *TwoFactorChallengeRepository) CleanupExpired(ctx context.Context) error {
+	if err := r.queries.CleanupExpiredTwoFactorChallenges(ctx); err != nil {
+		return fmt.Errorf("failed to cleanup expired two-factor challenges: %w", err)
+	}
+	return nil
+}
+
+// sqlcTOTPSecretToEntity converts a SQLC TotpSecret to a domain TOTPSecret entity.
+func sqlcTOTPSecretToEntity(s *sqlc.TotpSecret) *auth.TOTPSecret {
+	secret := &auth.TOTPSecret{
+		UserID:          s.UserID.Bytes,
+		EncryptedSecret: s.EncryptedSecret,
+		LastUsedStep:    s.LastUsedStep,
+		CreatedAt:       s.CreatedAt.Time,
+	}
+	if s.ConfirmedAt.Valid {
+		secret.ConfirmedAt = &s.ConfirmedAt.Time
+	}
+	return secret
+}
+
+// sqlcWebAuthnCredentialToEntity converts a SQLC WebauthnCredential to a domain WebAuthnCredential entity.
+func sqlcWebAuthnCredentialToEntity(c *sqlc.WebauthnCredential) *auth.WebAuthnCredential {
+	credential := &auth.WebAuthnCredential{
+		ID:           c.ID.Bytes,
+		UserID:       c.UserID.Bytes,
+		Name:         c.Name,
+		CredentialID: c.CredentialID,
+		PublicKey:    c.PublicKey,
+		SignCount:    uint32(c.SignCount),
+		Transports:   c.Transports,
+		CreatedAt:    c.CreatedAt.Time,
+	}
+	if c.LastUsedAt.Valid {
+		credential.LastUsedAt = &c.LastUsedAt.Time
+	}
+	return credential
+}
+
+// sqlcTwoFactorChallengeToEntity converts a SQLC TwoFactorChallenge to a domain TwoFactorChallenge entity.
+func sqlcTwoFactorChallengeToEntity(c *sqlc.TwoFactorChallenge) *auth.TwoFactorChallenge {
+	challenge := &auth.TwoFactorChallenge{
+		ID:                c.ID.Bytes,
+		UserID:            c.UserID.Bytes,
+		Purpose:           auth.TwoFactorPurpose(c.Purpose),
+		TokenHash:         c.TokenHash,
+		WebAuthnChallenge: c.WebauthnChallenge,
+		Attempts:          int(c.Attempts),
+		ExpiresAt:         c.ExpiresAt.Time,
+		CreatedAt:         c.CreatedAt.Time,
+	}
+	if c.SessionID.Valid {
+		sessionID := uuid.UUID(c.SessionID.Bytes)
+		challenge.SessionID = &sessionID
+	}
+	if c.DeviceName.Valid {
+		challenge.DeviceName = &c.DeviceName.String
+	}
+	if c.IpAddress != nil {
+		ipStr := c.IpAddress.String()
+		challenge.IPAddress = &ipStr
+	}
+	if c.UserAgent.Valid {
+		challenge.UserAgent = &c.UserAgent.String
+	}
+	return challenge
+}
@@ -80,7 +80,8 @@ func (r *UserRepository) Update(ctx context.Context, user *auth.User) (*auth.Use
 	userUUID := pgtype.UUID{Bytes: user.ID, Valid: true}
 	
 	params := sqlc.UpdateUserParams{
-		ID: userUUID,
+		ID:            userUUID,
+		MemoryEnabled: pgtype.Bool{Bool: user.MemoryEnabled, Valid: true},
 	}
 
 	if user.FirstName != nil {
@@ -128,13 +129,78 @@ func (r *UserRepository) Deactivate(ctx context.Context, userID uuid.UUID) error
 	return nil
 }
 
+// GetByIDIncludingInactive retrieves a user by their ID, even if deactivated.
+func (r *UserRepository) GetByIDIncludingInactive(ctx context.Context, id uuid.UUID) (*auth.User, error) {
+	sqlcUser, err := r.queries.GetUserByIDIncludingInactive(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	return r.sqlcUserToEntity(&sqlcUser), nil
+}
+
+// List retrieves all users, newest first.
+func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*auth.User, error) {
+	sqlcUsers, err := r.queries.ListUsers(ctx, sqlc.ListUsersParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*auth.User, len(sqlcUsers))
+	for i, u := range sqlcUsers {
+		users[i] = r.sqlcUserToEntity(&u)
+	}
+	return users, nil
+}
+
+// Count counts all users.
+func (r *UserRepository) Count(ctx context.Context) (int64, error) {
+	count, err := r.queries.CountUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// UpdateRole changes a user's role.
+func (r *UserRepository) UpdateRole(ctx context.Context, userID uuid.UUID, role auth.Role) (*auth.User, error) {
+	sqlcUser, err := r.queries.UpdateUserRole(ctx, sqlc.UpdateUserRoleParams{
+		ID:   pgtype.UUID{Bytes: userID, Valid: true},
+		Role: string(role),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	return r.sqlcUserToEntity(&sqlcUser), nil
+}
+
+// Delete permanently removes a user; rows they own are removed by cascade.
+func (r *UserRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.DeleteUser(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
 // sqlcUserToEntity converts a SQLC User to a domain User entity.
 func (r *UserRepository) sqlcUserToEntity(sqlcUser *sqlc.User) *auth.User {
 	user := &auth.User{
-		Email:     sqlcUser.Email,
-		IsActive:  sqlcUser.IsActive.Bool,
-		CreatedAt: sqlcUser.CreatedAt.Time,
-		UpdatedAt: sqlcUser.UpdatedAt.Time,
+		Email:         sqlcUser.Email,
+		IsActive:      sqlcUser.IsActive.Bool,
+		MemoryEnabled: sqlcUser.MemoryEnabled.Bool,
+		Role:          auth.Role(sqlcUser.Role),
+		CreatedAt:     sqlcUser.CreatedAt.Time,
+		UpdatedAt:     sqlcUser.UpdatedAt.Time,
 	}
 
 	// Convert UUID
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"trading-alchemist/internal/domain/broker"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ConnectionRepository implements the domain's broker ConnectionRepository interface using PostgreSQL.
+type ConnectionRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewConnectionRepository creates a new postgres broker connection repository.
+func NewConnectionRepository(db sqlc.DBTX) broker.ConnectionRepository {
+	return &ConnectionRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *ConnectionRepository) Create(ctx context.Context, c *broker.Connection) (*broker.Connection, error) {
+	settingsJSON, err := settingsToJSON(c.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlcConnection, err := r.queries.CreateBrokerConnection(ctx, sqlc.CreateBrokerConnectionParams{
+		UserID:               pgtype.UUID{Bytes: c.UserID, Valid: true},
+		Name:                 c.Name,
+		Kind:                 string(c.Kind),
+		EncryptedCredentials: textFromPtr(c.EncryptedCredentials),
+		ApiBaseOverride:      textFromPtr(c.APIBaseOverride),
+		Settings:             settingsJSON,
+		IsActive:             c.IsActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broker connection: %w", err)
+	}
+	return sqlcConnectionToEntity(&sqlcConnection), nil
+}
+
+func (r *ConnectionRepository) GetByID(ctx context.Context, id uuid.UUID) (*broker.Connection, error) {
+	sqlcConnection, err := r.queries.GetBrokerConnectionByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrBrokerConnectionNotFound
+		}
+		return nil, fmt.Errorf("failed to get broker connection by ID: %w", err)
+	}
+	return sqlcConnectionToEntity(&sqlcConnection), nil
+}
+
+func (r *ConnectionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*broker.Connection, error) {
+	sqlcConnections, err := r.queries.GetBrokerConnectionsByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broker connections by user ID: %w", err)
+	}
+
+	connections := make([]*broker.Connection, len(sqlcConnections))
+	for i, c := range sqlcConnections {
+		connections[i] = sqlcConnectionToEntity(&c)
+	}
+	return connections, nil
+}
+
+func (r *ConnectionRepository) Update(ctx context.Context, c *broker.Connection) (*broker.Connection, error) {
+	settingsJSON, err := settingsToJSON(c.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlcConnection, err := r.queries.UpdateBrokerConnection(ctx, sqlc.UpdateBrokerConnectionParams{
+		ID:                   pgtype.UUID{Bytes: c.ID, Valid: true},
+		Name:                 c.Name,
+		EncryptedCredentials: textFromPtr(c.EncryptedCredentials),
+		ApiBaseOverride:      textFromPtr(c.APIBaseOverride),
+		Settings:             settingsJSON,
+		IsActive:             c.IsActive,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrBrokerConnectionNotFound
+		}
+		return nil, fmt.Errorf("failed to update broker connection: %w", err)
+	}
+	return sqlcConnectionToEntity(&sqlcConnection), nil
+}
+
+func (r *ConnectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteBrokerConnection(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete broker connection: %w", err)
+	}
+	return nil
+}
+
+func settingsToJSON(settings shared.JSONB) ([]byte, error) {
+	if settings == nil {
+		settings = shared.JSONB{}
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal broker settings: %w", err)
+	}
+	return data, nil
+}
+
+func sqlcConnectionToEntity(c *sqlc.BrokerConnection) *broker.Connection {
+	result := &broker.Connection{
+		ID:                   c.ID.Bytes,
+		UserID:               c.UserID.Bytes,
+		Name:                 c.Name,
+		Kind:                 broker.Kind(c.Kind),
+		EncryptedCredentials: ptrFromText(c.EncryptedCredentials),
+		APIBaseOverride:      ptrFromText(c.ApiBaseOverride),
+		Settings:             shared.JSONB{},
+		IsActive:             c.IsActive,
+		CreatedAt:            c.CreatedAt.Time,
+		UpdatedAt:            c.UpdatedAt.Time,
+	}
+	if c.Settings != nil {
+		var settings shared.JSONB
+		if err := json.Unmarshal(c.Settings, &settings); err == nil {
+			result.Settings = settings
+		}
+	}
+	return result
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+func ptrFromText(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/calendar"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EventRepository implements the domain's calendar EventRepository interface using PostgreSQL.
+type EventRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewEventRepository creates a new postgres calendar event repository.
+func NewEventRepository(db sqlc.DBTX) calendar.EventRepository {
+	return &EventRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *EventRepository) Upsert(ctx context.Context, e *calendar.Event) (*calendar.Event, error) {
+	sqlcEvent, err := r.queries.UpsertCalendarEvent(ctx, sqlc.UpsertCalendarEventParams{
+		UserID:      uuidFromPtr(e.UserID),
+		Type:        string(e.Type),
+		Symbol:      textFromPtr(e.Symbol),
+		Title:       e.Title,
+		Description: textFromPtr(e.Description),
+		ScheduledAt: pgtype.Timestamptz{Time: e.ScheduledAt, Valid: true},
+		AllDay:      e.AllDay,
+		Importance:  string(e.Importance),
+		Amount:      float8FromPtr(e.Amount),
+		Ratio:       float8FromPtr(e.Ratio),
+		Source:      string(e.Source),
+		ExternalID:  e.ExternalID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert calendar event: %w", err)
+	}
+	return sqlcEventToEntity(&sqlcEvent), nil
+}
+
+func (r *EventRepository) GetByID(ctx context.Context, id uuid.UUID) (*calendar.Event, error) {
+	sqlcEvent, err := r.queries.GetCalendarEventByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrCalendarEventNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar event by ID: %w", err)
+	}
+	return sqlcEventToEntity(&sqlcEvent), nil
+}
+
+func (r *EventRepository) List(ctx context.Context, userID uuid.UUID, filter calendar.EventFilter, limit, offset int) ([]*calendar.Event, error) {
+	types := make([]string, len(filter.Types))
+	for i, t := range filter.Types {
+		types[i] = string(t)
+	}
+	symbols := filter.Symbols
+	if symbols == nil {
+		symbols = []string{}
+	}
+	params := sqlc.ListCalendarEventsParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		StartTime: timestamptzFromPtr(filter.Start),
+		EndTime:   timestamptzFromPtr(filter.End),
+		Symbols:   symbols,
+		Types:     types,
+		SkipRows:  int32(offset),
+	}
+	if limit > 0 {
+		params.MaxRows = pgtype.Int4{Int32: int32(limit), Valid: true}
+	}
+
+	sqlcEvents, err := r.queries.ListCalendarEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar events: %w", err)
+	}
+
+	events := make([]*calendar.Event, len(sqlcEvents))
+	for i, e := range sqlcEvents {
+		events[i] = sqlcEventToEntity(&e)
+	}
+	return events, nil
+}
+
+func (r *EventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteCalendarEvent(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete calendar event: %w", err)
+	}
+	return nil
+}
+
+func sqlcEventToEntity(e *sqlc.CalendarEvent) *calendar.Event {
+	event := &calendar.Event{
+		ID:          e.ID.Bytes,
+		Type:        calendar.EventType(e.Type),
+		Symbol:      ptrFromText(e.Symbol),
+		Title:       e.Title,
+		Description: ptrFromText(e.Description),
+		ScheduledAt: e.ScheduledAt.Time,
+		AllDay:      e.AllDay,
+		Importance:  calendar.Importance(e.Importance),
+		Amount:      ptrFromFloat8(e.Amount),
+		Ratio:       ptrFromFloat8(e.Ratio),
+		Source:      calendar.EventSource(e.Source),
+		ExternalID:  e.ExternalID,
+		CreatedAt:   e.CreatedAt.Time,
+		UpdatedAt:   e.UpdatedAt.Time,
+	}
+	if e.UserID.Valid {
+		userID := uuid.UUID(e.UserID.Bytes)
+		event.UserID = &userID
+	}
+	return event
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+func ptrFromText(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
+
+func float8FromPtr(v *float64) pgtype.Float8 {
+	if v == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *v, Valid: true}
+}
+
+func ptrFromFloat8(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func uuidFromPtr(v *uuid.UUID) pgtype.UUID {
+	if v == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *v, Valid: true}
+}
+
+func timestamptzFromPtr(v *time.Time) pgtype.Timestamptz {
+	if v == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *v, Valid: true}
+}
@@ -35,10 +35,17 @@ func (r *ArtifactRepository) Create(ctx context.Context, artifact *chat.Artifact
 		ContentHash: pgtype.Text{String: artifact.ContentHash, Valid: true},
 		Size:        pgtype.Int8{Int64: artifact.Size, Valid: true},
 		IsPublic:    pgtype.Bool{Bool: artifact.IsPublic, Valid: true},
+		Version:     int32(artifact.Version),
+	}
+	if params.Version == 0 {
+		params.Version = 1
 	}
 	if artifact.Language != nil {
 		params.Language = pgtype.Text{String: *artifact.Language, Valid: true}
 	}
+	if artifact.RootArtifactID != nil {
+		params.RootArtifactID = pgtype.UUID{Bytes: *artifact.RootArtifactID, Valid: true}
+	}
 
 	sqlcArtifact, err := r.queries.CreateArtifact(ctx, params)
 	if err != nil {
@@ -115,6 +122,49 @@ func (r *ArtifactRepository) GetPublicArtifacts(ctx context.Context, limit, offs
 	return artifacts, nil
 }
 
+func (r *ArtifactRepository) GetVersions(ctx context.Context, rootID uuid.UUID) ([]*chat.Artifact, error) {
+	sqlcArtifacts, err := r.queries.GetArtifactVersions(ctx, pgtype.UUID{Bytes: rootID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifact versions: %w", err)
+	}
+
+	artifacts := make([]*chat.Artifact, len(sqlcArtifacts))
+	for i, a := range sqlcArtifacts {
+		artifacts[i] = sqlcArtifactToEntity(&a)
+	}
+	return artifacts, nil
+}
+
+func (r *ArtifactRepository) ListLatestByUserAndType(ctx context.Context, userID uuid.UUID, artifactType shared.ArtifactType, limit, offset int) ([]*chat.Artifact, error) {
+	params := sqlc.ListLatestArtifactsByUserAndTypeParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Type:   string(artifactType),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+	sqlcArtifacts, err := r.queries.ListLatestArtifactsByUserAndType(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	artifacts := make([]*chat.Artifact, len(sqlcArtifacts))
+	for i, a := range sqlcArtifacts {
+		artifacts[i] = sqlcArtifactToEntity(&a)
+	}
+	return artifacts, nil
+}
+
+func (r *ArtifactRepository) GetOwnerID(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	ownerID, err := r.queries.GetArtifactOwnerID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return uuid.Nil, errors.ErrArtifactNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get artifact owner: %w", err)
+	}
+	return ownerID.Bytes, nil
+}
+
 func sqlcArtifactToEntity(a *sqlc.Artifact) *chat.Artifact {
 	artifact := &chat.Artifact{
 		Title:       a.Title,
@@ -123,6 +173,7 @@ func sqlcArtifactToEntity(a *sqlc.Artifact) *chat.Artifact {
 		ContentHash: a.ContentHash.String,
 		Size:        a.Size.Int64,
 		IsPublic:    a.IsPublic.Bool,
+		Version:     int(a.Version),
 		CreatedAt:   a.CreatedAt.Time,
 		UpdatedAt:   a.UpdatedAt.Time,
 	}
@@ -136,6 +187,10 @@ func sqlcArtifactToEntity(a *sqlc.Artifact) *chat.Artifact {
 	if a.Language.Valid {
 		artifact.Language = &a.Language.String
 	}
+	if a.RootArtifactID.Valid {
+		rootID := uuid.UUID(a.RootArtifactID.Bytes)
+		artifact.RootArtifactID = &rootID
+	}
 
 	return artifact
 } 
\ No newline at end of file
@@ -33,6 +33,8 @@ func (r *ConversationRepository) Create(ctx context.Context, conversation *chat.
 		UserID:  pgtype.UUID{Bytes: conversation.UserID, Valid: true},
 		Title:   conversation.Title,
 		ModelID: pgtype.UUID{Bytes: conversation.ModelID, Valid: true},
+
+		OrganizationPermission: string(chat.ConversationPermissionRead),
 	}
 	if conversation.SystemPrompt != nil {
 		params.SystemPrompt = pgtype.Text{String: *conversation.SystemPrompt, Valid: true}
@@ -44,6 +46,12 @@ func (r *ConversationRepository) Create(ctx context.Context, conversation *chat.
 		}
 		params.Settings = settingsJSON
 	}
+	if conversation.OrganizationID != nil {
+		params.OrganizationID = pgtype.UUID{Bytes: *conversation.OrganizationID, Valid: true}
+	}
+	if conversation.OrganizationPermission != "" {
+		params.OrganizationPermission = string(conversation.OrganizationPermission)
+	}
 
 	sqlcConv, err := r.queries.CreateConversation(ctx, params)
 	if err != nil {
@@ -84,6 +92,47 @@ func (r *ConversationRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	return convs, nil
 }
 
+func (r *ConversationRepository) ListAllByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*chat.Conversation, error) {
+	params := sqlc.ListAllConversationsByUserIDParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	}
+
+	sqlcConvs, err := r.queries.ListAllConversationsByUserID(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all conversations by user ID: %w", err)
+	}
+
+	convs := make([]*chat.Conversation, len(sqlcConvs))
+	for i, c := range sqlcConvs {
+		convs[i] = sqlcConversationToEntity(&c)
+	}
+	return convs, nil
+}
+
+func (r *ConversationRepository) ListAccessibleByUserID(ctx context.Context, userID uuid.UUID, organizationID *uuid.UUID, limit, offset int) ([]*chat.Conversation, error) {
+	params := sqlc.ListAccessibleConversationsParams{
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		MaxRows:  int32(limit),
+		SkipRows: int32(offset),
+	}
+	if organizationID != nil {
+		params.OrganizationID = pgtype.UUID{Bytes: *organizationID, Valid: true}
+	}
+
+	sqlcConvs, err := r.queries.ListAccessibleConversations(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accessible conversations: %w", err)
+	}
+
+	convs := make([]*chat.Conversation, len(sqlcConvs))
+	for i, c := range sqlcConvs {
+		convs[i] = sqlcConversationToEntity(&c)
+	}
+	return convs, nil
+}
+
 func (r *ConversationRepository) Update(ctx context.Context, conversation *chat.Conversation) (*chat.Conversation, error) {
 	params := sqlc.UpdateConversationParams{
 		ID:      pgtype.UUID{Bytes: conversation.ID, Valid: true},
@@ -133,12 +182,39 @@ func (r *ConversationRepository) Archive(ctx context.Context, id uuid.UUID) erro
 	return r.queries.ArchiveConversation(ctx, convUUID)
 }
 
+func (r *ConversationRepository) SetOrganization(ctx context.Context, id uuid.UUID, organizationID *uuid.UUID, permission chat.ConversationPermission) error {
+	params := sqlc.SetConversationOrganizationParams{
+		ID:                     pgtype.UUID{Bytes: id, Valid: true},
+		OrganizationPermission: string(permission),
+	}
+	if organizationID != nil {
+		params.OrganizationID = pgtype.UUID{Bytes: *organizationID, Valid: true}
+	}
+	if err := r.queries.SetConversationOrganization(ctx, params); err != nil {
+		return fmt.Errorf("failed to set conversation organization: %w", err)
+	}
+	return nil
+}
+
+func (r *ConversationRepository) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	count, err := r.queries.CountUserConversationsSince(ctx, sqlc.CountUserConversationsSinceParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count conversations by user: %w", err)
+	}
+	return count, nil
+}
+
 func sqlcConversationToEntity(c *sqlc.Conversation) *chat.Conversation {
 	conv := &chat.Conversation{
 		Title:      c.Title,
 		IsArchived: c.IsArchived.Bool,
 		CreatedAt:  c.CreatedAt.Time,
 		UpdatedAt:  c.UpdatedAt.Time,
+
+		OrganizationPermission: chat.ConversationPermission(c.OrganizationPermission),
 	}
 
 	if c.ID.Valid {
@@ -162,6 +238,10 @@ func sqlcConversationToEntity(c *sqlc.Conversation) *chat.Conversation {
 	if c.LastMessageAt.Valid {
 		conv.LastMessageAt = &c.LastMessageAt.Time
 	}
+	if c.OrganizationID.Valid {
+		organizationID := uuid.UUID(c.OrganizationID.Bytes)
+		conv.OrganizationID = &organizationID
+	}
 
 	return conv
 } 
\ No newline at end of file
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+)
+
+// EmbeddingCacheRepository implements the domain's EmbeddingCacheRepository interface using PostgreSQL.
+type EmbeddingCacheRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewEmbeddingCacheRepository creates a new postgres embedding cache repository.
+func NewEmbeddingCacheRepository(db sqlc.DBTX) chat.EmbeddingCacheRepository {
+	return &EmbeddingCacheRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *EmbeddingCacheRepository) Get(ctx context.Context, model string, contentHashes []string) (map[string][]float32, error) {
+	cached := make(map[string][]float32, len(contentHashes))
+	if len(contentHashes) == 0 {
+		return cached, nil
+	}
+	rows, err := r.queries.GetCachedEmbeddings(ctx, sqlc.GetCachedEmbeddingsParams{
+		Model:         model,
+		ContentHashes: contentHashes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached embeddings: %w", err)
+	}
+	for _, row := range rows {
+		cached[row.ContentHash] = row.Embedding
+	}
+	return cached, nil
+}
+
+func (r *EmbeddingCacheRepository) Put(ctx context.Context, model, contentHash string, embedding []float32) error {
+	err := r.queries.PutCachedEmbedding(ctx, sqlc.PutCachedEmbeddingParams{
+		Model:       model,
+		ContentHash: contentHash,
+		Embedding:   embedding,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cache embedding: %w", err)
+	}
+	return nil
+}
+
+func (r *EmbeddingCacheRepository) DeleteByContentHashes(ctx context.Context, contentHashes []string) error {
+	if len(contentHashes) == 0 {
+		return nil
+	}
+	if err := r.queries.DeleteCachedEmbeddings(ctx, contentHashes); err != nil {
+		return fmt.Errorf("failed to delete cached embeddings: %w", err)
+	}
+	return nil
+}
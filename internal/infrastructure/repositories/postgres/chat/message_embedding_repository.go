@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// MessageEmbeddingRepository implements the domain's MessageEmbeddingRepository interface using PostgreSQL.
+type MessageEmbeddingRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewMessageEmbeddingRepository creates a new postgres message embedding repository.
+func NewMessageEmbeddingRepository(db sqlc.DBTX) chat.MessageEmbeddingRepository {
+	return &MessageEmbeddingRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *MessageEmbeddingRepository) Create(ctx context.Context, embedding *chat.MessageEmbedding) error {
+	err := r.queries.CreateMessageEmbedding(ctx, sqlc.CreateMessageEmbeddingParams{
+		MessageID:      pgtype.UUID{Bytes: embedding.MessageID, Valid: true},
+		ConversationID: pgtype.UUID{Bytes: embedding.ConversationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: embedding.UserID, Valid: true},
+		EmbeddingModel: embedding.Model,
+		Embedding:      embedding.Embedding,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create message embedding: %w", err)
+	}
+	return nil
+}
+
+func (r *MessageEmbeddingRepository) Search(ctx context.Context, userID uuid.UUID, model string, query []float32, excludeConversationID uuid.UUID, limit int) ([]*chat.MessageMatch, error) {
+	rows, err := r.queries.SearchMessageEmbeddings(ctx, sqlc.SearchMessageEmbeddingsParams{
+		Query:                 vectorLiteral(query),
+		UserID:                pgtype.UUID{Bytes: userID, Valid: true},
+		EmbeddingModel:        model,
+		ExcludeConversationID: pgtype.UUID{Bytes: excludeConversationID, Valid: true},
+		MaxRows:               int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search message embeddings: %w", err)
+	}
+
+	matches := make([]*chat.MessageMatch, len(rows))
+	for i, row := range rows {
+		matches[i] = &chat.MessageMatch{
+			MessageID:         row.ID.Bytes,
+			ConversationID:    row.ConversationID.Bytes,
+			ConversationTitle: row.ConversationTitle,
+			Role:              shared.MessageRole(row.Role),
+			Content:           row.Content.String,
+			Similarity:        row.Similarity,
+			CreatedAt:         row.CreatedAt.Time,
+		}
+	}
+	return matches, nil
+}
+
+func (r *MessageEmbeddingRepository) ListByUserID(ctx context.Context, userID uuid.UUID, model string, excludeConversationID, afterID uuid.UUID, limit int) ([]*chat.MessageEmbedding, error) {
+	rows, err := r.queries.ListMessageEmbeddingsByUserID(ctx, sqlc.ListMessageEmbeddingsByUserIDParams{
+		UserID:                pgtype.UUID{Bytes: userID, Valid: true},
+		EmbeddingModel:        model,
+		ExcludeConversationID: pgtype.UUID{Bytes: excludeConversationID, Valid: true},
+		AfterID:               pgtype.UUID{Bytes: afterID, Valid: true},
+		MaxRows:               int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message embeddings: %w", err)
+	}
+
+	embeddings := make([]*chat.MessageEmbedding, len(rows))
+	for i, row := range rows {
+		embeddings[i] = &chat.MessageEmbedding{
+			MessageID:      row.MessageID.Bytes,
+			ConversationID: row.ConversationID.Bytes,
+			UserID:         row.UserID.Bytes,
+			Model:          row.EmbeddingModel,
+			Embedding:      row.Embedding,
+			CreatedAt:      row.CreatedAt.Time,
+		}
+	}
+	return embeddings, nil
+}
+
+func (r *MessageEmbeddingRepository) ListMatchesByMessageIDs(ctx context.Context, messageIDs []uuid.UUID) ([]*chat.MessageMatch, error) {
+	if len(messageIDs) == 0 {
+		return nil, nil
+	}
+	ids := make([]pgtype.UUID, len(messageIDs))
+	for i, id := range messageIDs {
+		ids[i] = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	rows, err := r.queries.ListMessageMatchesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	matches := make([]*chat.MessageMatch, len(rows))
+	for i, row := range rows {
+		matches[i] = &chat.MessageMatch{
+			MessageID:         row.ID.Bytes,
+			ConversationID:    row.ConversationID.Bytes,
+			ConversationTitle: row.ConversationTitle,
+			Role:              shared.MessageRole(row.Role),
+			Content:           row.Content.String,
+			CreatedAt:         row.CreatedAt.Time,
+		}
+	}
+	return matches, nil
+}
+
+// vectorLiteral formats an embedding as pgvector's text representation.
+func vectorLiteral(v []float32) string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(float64(x), 'g', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
@@ -96,6 +96,20 @@ func (r *MessageRepository) GetByID(ctx context.Context, id uuid.UUID) (*chat.Me
 	return sqlcMessageToEntity(&sqlcMessage), nil
 }
 
+func (r *MessageRepository) GetToolMessageByCallID(ctx context.Context, conversationID uuid.UUID, toolCallID string) (*chat.Message, error) {
+	sqlcMessage, err := r.queries.GetToolMessageByCallID(ctx, sqlc.GetToolMessageByCallIDParams{
+		ConversationID: pgtype.UUID{Bytes: conversationID, Valid: true},
+		ToolCallID:     toolCallID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get tool message by call ID: %w", err)
+	}
+	return sqlcMessageToEntity(&sqlcMessage), nil
+}
+
 func (r *MessageRepository) GetThread(ctx context.Context, parentID uuid.UUID) ([]*chat.Message, error) {
 	parentUUID := pgtype.UUID{Bytes: parentID, Valid: true}
 	sqlcMessages, err := r.queries.GetMessageThread(ctx, parentUUID)
@@ -182,6 +196,30 @@ func (r *MessageRepository) CountByConversationID(ctx context.Context, conversat
 	return int(count), nil
 }
 
+func (r *MessageRepository) GetUsageByUser(ctx context.Context, userID uuid.UUID, since time.Time) ([]*chat.ModelUsage, error) {
+	rows, err := r.queries.GetUserUsageByModel(ctx, sqlc.GetUserUsageByModelParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage by user: %w", err)
+	}
+
+	usage := make([]*chat.ModelUsage, len(rows))
+	for i, row := range rows {
+		usage[i] = &chat.ModelUsage{
+			MessageCount: row.MessageCount,
+			TokenCount:   row.TokenCount,
+			Cost:         row.Cost,
+		}
+		if row.ModelID.Valid {
+			modelID := uuid.UUID(row.ModelID.Bytes)
+			usage[i].ModelID = &modelID
+		}
+	}
+	return usage, nil
+}
+
 func sqlcMessageToEntity(m *sqlc.Message) *chat.Message {
 	msg := &chat.Message{
 		Role:      shared.MessageRole(m.Role),
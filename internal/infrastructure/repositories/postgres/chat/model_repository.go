@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"trading-alchemist/internal/domain/chat"
 	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
@@ -32,44 +34,25 @@ func (r *ModelRepository) GetActiveModelsByProviderID(ctx context.Context, provi
 
 	models := make([]*chat.Model, len(dbModels))
 	for i, dbModel := range dbModels {
-		models[i] = &chat.Model{
-			ID:                dbModel.ID.Bytes,
-			ProviderID:        dbModel.ProviderID.Bytes,
-			Name:              dbModel.Name,
-			DisplayName:       dbModel.DisplayName,
-			SupportsFunctions: dbModel.SupportsFunctions.Bool,
-			SupportsVision:    dbModel.SupportsVision.Bool,
-			IsActive:          dbModel.IsActive.Bool,
-			CreatedAt:         dbModel.CreatedAt.Time,
-			UpdatedAt:         dbModel.UpdatedAt.Time,
-		}
+		models[i] = sqlcModelToEntity(sqlc.GetModelByIDRow(dbModel))
 	}
 	return models, nil
 }
 
 func (r *ModelRepository) CreateModel(ctx context.Context, model *chat.Model) (*chat.Model, error) {
 	dbModel, err := r.q.CreateModel(ctx, sqlc.CreateModelParams{
-		ProviderID:        pgtype.UUID{Bytes: model.ProviderID, Valid: true},
-		Name:              model.Name,
-		DisplayName:       model.DisplayName,
-		SupportsFunctions: pgtype.Bool{Bool: model.SupportsFunctions, Valid: true},
-		SupportsVision:    pgtype.Bool{Bool: model.SupportsVision, Valid: true},
-		IsActive:          pgtype.Bool{Bool: model.IsActive, Valid: true},
+		ProviderID:         pgtype.UUID{Bytes: model.ProviderID, Valid: true},
+		Name:               model.Name,
+		DisplayName:        model.DisplayName,
+		SupportsFunctions:  pgtype.Bool{Bool: model.SupportsFunctions, Valid: true},
+		SupportsVision:     pgtype.Bool{Bool: model.SupportsVision, Valid: true},
+		SupportsEmbeddings: pgtype.Bool{Bool: model.SupportsEmbeddings, Valid: true},
+		IsActive:           pgtype.Bool{Bool: model.IsActive, Valid: true},
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &chat.Model{
-		ID:                dbModel.ID.Bytes,
-		ProviderID:        dbModel.ProviderID.Bytes,
-		Name:              dbModel.Name,
-		DisplayName:       dbModel.DisplayName,
-		SupportsFunctions: dbModel.SupportsFunctions.Bool,
-		SupportsVision:    dbModel.SupportsVision.Bool,
-		IsActive:          dbModel.IsActive.Bool,
-		CreatedAt:         dbModel.CreatedAt.Time,
-		UpdatedAt:         dbModel.UpdatedAt.Time,
-	}, nil
+	return sqlcModelToEntity(sqlc.GetModelByIDRow(dbModel)), nil
 }
 
 func (r *ModelRepository) GetModelByName(ctx context.Context, providerID uuid.UUID, name string) (*chat.Model, error) {
@@ -84,17 +67,7 @@ func (r *ModelRepository) GetModelByName(ctx context.Context, providerID uuid.UU
 		return nil, err
 	}
 
-	return &chat.Model{
-		ID:                dbModel.ID.Bytes,
-		ProviderID:        dbModel.ProviderID.Bytes,
-		Name:              dbModel.Name,
-		DisplayName:       dbModel.DisplayName,
-		SupportsFunctions: dbModel.SupportsFunctions.Bool,
-		SupportsVision:    dbModel.SupportsVision.Bool,
-		IsActive:          dbModel.IsActive.Bool,
-		CreatedAt:         dbModel.CreatedAt.Time,
-		UpdatedAt:         dbModel.UpdatedAt.Time,
-	}, nil
+	return sqlcModelToEntity(sqlc.GetModelByIDRow(dbModel)), nil
 }
 
 func (r *ModelRepository) GetByID(ctx context.Context, id uuid.UUID) (*chat.Model, error) {
@@ -106,15 +79,83 @@ func (r *ModelRepository) GetByID(ctx context.Context, id uuid.UUID) (*chat.Mode
 		return nil, err
 	}
 
+	return sqlcModelToEntity(dbModel), nil
+}
+
+func (r *ModelRepository) GetAll(ctx context.Context) ([]*chat.Model, error) {
+	dbModels, err := r.q.GetAllModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all models: %w", err)
+	}
+
+	models := make([]*chat.Model, len(dbModels))
+	for i, dbModel := range dbModels {
+		models[i] = sqlcModelToEntity(sqlc.GetModelByIDRow(dbModel))
+	}
+	return models, nil
+}
+
+func (r *ModelRepository) Update(ctx context.Context, model *chat.Model) (*chat.Model, error) {
+	params := sqlc.UpdateModelParams{
+		ID:                 pgtype.UUID{Bytes: model.ID, Valid: true},
+		DisplayName:        model.DisplayName,
+		SupportsFunctions:  pgtype.Bool{Bool: model.SupportsFunctions, Valid: true},
+		SupportsVision:     pgtype.Bool{Bool: model.SupportsVision, Valid: true},
+		SupportsEmbeddings: pgtype.Bool{Bool: model.SupportsEmbeddings, Valid: true},
+		IsActive:           pgtype.Bool{Bool: model.IsActive, Valid: true},
+	}
+	var err error
+	if params.InputPricePerMillion, err = priceToNumeric(model.InputPricePerMillion); err != nil {
+		return nil, err
+	}
+	if params.OutputPricePerMillion, err = priceToNumeric(model.OutputPricePerMillion); err != nil {
+		return nil, err
+	}
+
+	dbModel, err := r.q.UpdateModel(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrModelNotFound
+		}
+		return nil, fmt.Errorf("failed to update model: %w", err)
+	}
+	return sqlcModelToEntity(sqlc.GetModelByIDRow(dbModel)), nil
+}
+
+// sqlcModelToEntity converts a model row to a domain Model entity. All model
+// queries select the same columns, so their rows convert to GetModelByIDRow.
+func sqlcModelToEntity(m sqlc.GetModelByIDRow) *chat.Model {
 	return &chat.Model{
-		ID:                dbModel.ID.Bytes,
-		ProviderID:        dbModel.ProviderID.Bytes,
-		Name:              dbModel.Name,
-		DisplayName:       dbModel.DisplayName,
-		SupportsFunctions: dbModel.SupportsFunctions.Bool,
-		SupportsVision:    dbModel.SupportsVision.Bool,
-		IsActive:          dbModel.IsActive.Bool,
-		CreatedAt:         dbModel.CreatedAt.Time,
-		UpdatedAt:         dbModel.UpdatedAt.Time,
-	}, nil
-} 
\ No newline at end of file
+		ID:                    m.ID.Bytes,
+		ProviderID:            m.ProviderID.Bytes,
+		Name:                  m.Name,
+		DisplayName:           m.DisplayName,
+		SupportsFunctions:     m.SupportsFunctions.Bool,
+		SupportsVision:        m.SupportsVision.Bool,
+		SupportsEmbeddings:    m.SupportsEmbeddings.Bool,
+		IsActive:              m.IsActive.Bool,
+		InputPricePerMillion:  numericToPrice(m.InputPricePerMillion),
+		OutputPricePerMillion: numericToPrice(m.OutputPricePerMillion),
+		CreatedAt:             m.CreatedAt.Time,
+		UpdatedAt:             m.UpdatedAt.Time,
+	}
+}
+
+func priceToNumeric(price *float64) (pgtype.Numeric, error) {
+	var n pgtype.Numeric
+	if price == nil {
+		return n, nil
+	}
+	if err := n.Scan(strconv.FormatFloat(*price, 'f', -1, 64)); err != nil {
+		return n, fmt.Errorf("failed to convert model price: %w", err)
+	}
+	return n, nil
+}
+
+func numericToPrice(n pgtype.Numeric) *float64 {
+	f, err := n.Float64Value()
+	if err != nil || !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
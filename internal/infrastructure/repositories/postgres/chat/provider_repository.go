@@ -89,6 +89,22 @@ func (r *ProviderRepository) Create(ctx context.Context, provider *chat.Provider
 	return sqlcProviderToEntity(&sqlcProvider), nil
 }
 
+func (r *ProviderRepository) Update(ctx context.Context, provider *chat.Provider) (*chat.Provider, error) {
+	params := sqlc.UpdateProviderParams{
+		ID:          pgtype.UUID{Bytes: provider.ID, Valid: true},
+		DisplayName: provider.DisplayName,
+		IsActive:    pgtype.Bool{Bool: provider.IsActive, Valid: true},
+	}
+	sqlcProvider, err := r.queries.UpdateProvider(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrProviderNotFound
+		}
+		return nil, fmt.Errorf("failed to update provider: %w", err)
+	}
+	return sqlcProviderToEntity(&sqlcProvider), nil
+}
+
 // GetAvailableModelsForUser returns providers with models and API key status for a user
 func (r *ProviderRepository) GetAvailableModelsForUser(ctx context.Context, userID uuid.UUID) ([]*chat.Provider, error) {
 	userIDPg := pgtype.UUID{}
@@ -129,6 +145,7 @@ func (r *ProviderRepository) GetAvailableModelsForUser(ctx context.Context, user
 				DisplayName:      row.ModelDisplayName,
 				SupportsFunctions: row.ModelSupportsFunctions.Bool,
 				SupportsVision:   row.ModelSupportsVision.Bool,
+				SupportsEmbeddings: row.ModelSupportsEmbeddings.Bool,
 				IsActive:         true, // Only active models are returned
 			}
 			
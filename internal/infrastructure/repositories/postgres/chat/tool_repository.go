@@ -8,8 +8,10 @@ import (
 	"trading-alchemist/internal/domain/chat"
 	"trading-alchemist/internal/domain/shared"
 	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -25,6 +27,90 @@ func NewToolRepository(db sqlc.DBTX) chat.ToolRepository {
 	}
 }
 
+func (r *ToolRepository) Create(ctx context.Context, tool *chat.Tool) (*chat.Tool, error) {
+	schemaJSON, err := json.Marshal(tool.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool schema: %w", err)
+	}
+
+	params := sqlc.CreateToolParams{
+		Name:        tool.Name,
+		Description: pgtype.Text{String: tool.Description, Valid: true},
+		Schema:      schemaJSON,
+		IsActive:    pgtype.Bool{Bool: tool.IsActive, Valid: true},
+	}
+	if tool.ProviderID != nil {
+		params.ProviderID = pgtype.UUID{Bytes: *tool.ProviderID, Valid: true}
+	}
+
+	sqlcTool, err := r.queries.CreateTool(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool: %w", err)
+	}
+	return sqlcToolToEntity(&sqlcTool), nil
+}
+
+func (r *ToolRepository) GetByID(ctx context.Context, id uuid.UUID) (*chat.Tool, error) {
+	sqlcTool, err := r.queries.GetToolByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrToolNotFound
+		}
+		return nil, fmt.Errorf("failed to get tool by ID: %w", err)
+	}
+	return sqlcToolToEntity(&sqlcTool), nil
+}
+
+func (r *ToolRepository) GetByName(ctx context.Context, name string) (*chat.Tool, error) {
+	sqlcTool, err := r.queries.GetToolByName(ctx, name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrToolNotFound
+		}
+		return nil, fmt.Errorf("failed to get tool by name: %w", err)
+	}
+	return sqlcToolToEntity(&sqlcTool), nil
+}
+
+func (r *ToolRepository) GetAll(ctx context.Context) ([]*chat.Tool, error) {
+	sqlcTools, err := r.queries.GetAllTools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all tools: %w", err)
+	}
+
+	tools := make([]*chat.Tool, len(sqlcTools))
+	for i, t := range sqlcTools {
+		tools[i] = sqlcToolToEntity(&t)
+	}
+	return tools, nil
+}
+
+func (r *ToolRepository) Update(ctx context.Context, tool *chat.Tool) (*chat.Tool, error) {
+	schemaJSON, err := json.Marshal(tool.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool schema: %w", err)
+	}
+
+	params := sqlc.UpdateToolParams{
+		ID:          pgtype.UUID{Bytes: tool.ID, Valid: true},
+		Description: pgtype.Text{String: tool.Description, Valid: true},
+		Schema:      schemaJSON,
+		IsActive:    pgtype.Bool{Bool: tool.IsActive, Valid: true},
+	}
+	if tool.ProviderID != nil {
+		params.ProviderID = pgtype.UUID{Bytes: *tool.ProviderID, Valid: true}
+	}
+
+	sqlcTool, err := r.queries.UpdateTool(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrToolNotFound
+		}
+		return nil, fmt.Errorf("failed to update tool: %w", err)
+	}
+	return sqlcToolToEntity(&sqlcTool), nil
+}
+
 func (r *ToolRepository) GetAvailableTools(ctx context.Context, providerID *uuid.UUID) ([]*chat.Tool, error) {
 	var providerUUID pgtype.UUID
 	if providerID != nil {
@@ -98,5 +184,4 @@ func sqlcToolToEntity(t *sqlc.Tool) *chat.Tool {
 
 	return tool
 }
-
-// TODO: Implement methods using sqlc generated queries. 
\ No newline at end of file
+ 
\ No newline at end of file
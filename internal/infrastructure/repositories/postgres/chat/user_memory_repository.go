@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/chat"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UserMemoryRepository implements the domain's UserMemoryRepository interface using PostgreSQL.
+type UserMemoryRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewUserMemoryRepository creates a new postgres user memory repository.
+func NewUserMemoryRepository(db sqlc.DBTX) chat.UserMemoryRepository {
+	return &UserMemoryRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *UserMemoryRepository) Create(ctx context.Context, memory *chat.UserMemory) (*chat.UserMemory, error) {
+	params := sqlc.CreateUserMemoryParams{
+		UserID:   pgtype.UUID{Bytes: memory.UserID, Valid: true},
+		Category: string(memory.Category),
+		Content:  memory.Content,
+	}
+	if memory.SourceConversationID != nil {
+		params.SourceConversationID = pgtype.UUID{Bytes: *memory.SourceConversationID, Valid: true}
+	}
+
+	sqlcMemory, err := r.queries.CreateUserMemory(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user memory: %w", err)
+	}
+	return sqlcUserMemoryToEntity(&sqlcMemory), nil
+}
+
+func (r *UserMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*chat.UserMemory, error) {
+	sqlcMemory, err := r.queries.GetUserMemoryByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrUserMemoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get user memory by ID: %w", err)
+	}
+	return sqlcUserMemoryToEntity(&sqlcMemory), nil
+}
+
+func (r *UserMemoryRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit int) ([]*chat.UserMemory, error) {
+	sqlcMemories, err := r.queries.ListUserMemoriesByUserID(ctx, sqlc.ListUserMemoriesByUserIDParams{
+		UserID:  pgtype.UUID{Bytes: userID, Valid: true},
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user memories: %w", err)
+	}
+
+	memories := make([]*chat.UserMemory, len(sqlcMemories))
+	for i, m := range sqlcMemories {
+		memories[i] = sqlcUserMemoryToEntity(&m)
+	}
+	return memories, nil
+}
+
+func (r *UserMemoryRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountUserMemoriesByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user memories: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *UserMemoryRepository) Update(ctx context.Context, memory *chat.UserMemory) (*chat.UserMemory, error) {
+	sqlcMemory, err := r.queries.UpdateUserMemory(ctx, sqlc.UpdateUserMemoryParams{
+		ID:       pgtype.UUID{Bytes: memory.ID, Valid: true},
+		Category: string(memory.Category),
+		Content:  memory.Content,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrUserMemoryNotFound
+		}
+		return nil, fmt.Errorf("failed to update user memory: %w", err)
+	}
+	return sqlcUserMemoryToEntity(&sqlcMemory), nil
+}
+
+func (r *UserMemoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteUserMemory(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete user memory: %w", err)
+	}
+	return nil
+}
+
+func sqlcUserMemoryToEntity(m *sqlc.UserMemory) *chat.UserMemory {
+	memory := &chat.UserMemory{
+		ID:        m.ID.Bytes,
+		UserID:    m.UserID.Bytes,
+		Category:  chat.MemoryCategory(m.Category),
+		Content:   m.Content,
+		CreatedAt: m.CreatedAt.Time,
+		UpdatedAt: m.UpdatedAt.Time,
+	}
+	if m.SourceConversationID.Valid {
+		conversationID := uuid.UUID(m.SourceConversationID.Bytes)
+		memory.SourceConversationID = &conversationID
+	}
+	return memory
+}
@@ -24,10 +24,14 @@ func NewUserProviderSettingRepository(db sqlc.DBTX) chat.UserProviderSettingRepo
 
 func (r *UserProviderSettingRepository) Create(ctx context.Context, setting *chat.UserProviderSetting) (*chat.UserProviderSetting, error) {
 	params := sqlc.CreateUserProviderSettingParams{
-		UserID:     pgtype.UUID{Bytes: setting.UserID, Valid: true},
 		ProviderID: pgtype.UUID{Bytes: setting.ProviderID, Valid: true},
 		IsActive:   pgtype.Bool{Bool: setting.IsActive, Valid: true},
 	}
+	if setting.OrganizationID != nil {
+		params.OrganizationID = pgtype.UUID{Bytes: *setting.OrganizationID, Valid: true}
+	} else {
+		params.UserID = pgtype.UUID{Bytes: setting.UserID, Valid: true}
+	}
 	if setting.EncryptedAPIKey != nil {
 		params.EncryptedApiKey = pgtype.Text{String: *setting.EncryptedAPIKey, Valid: true}
 	}
@@ -57,6 +61,39 @@ func (r *UserProviderSettingRepository) GetByUserIDAndProviderID(ctx context.Con
 	return sqlcUserProviderSettingToEntity(&sqlcSetting), nil
 }
 
+func (r *UserProviderSettingRepository) GetByOrganizationIDAndProviderID(ctx context.Context, organizationID, providerID uuid.UUID) (*chat.UserProviderSetting, error) {
+	params := sqlc.GetOrganizationProviderSettingParams{
+		OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+		ProviderID:     pgtype.UUID{Bytes: providerID, Valid: true},
+	}
+	sqlcSetting, err := r.queries.GetOrganizationProviderSetting(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrUserProviderSettingNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization provider setting: %w", err)
+	}
+	return sqlcUserProviderSettingToEntity(&sqlcSetting), nil
+}
+
+func (r *UserProviderSettingRepository) GetForUser(ctx context.Context, userID, providerID uuid.UUID, organizationID *uuid.UUID) (*chat.UserProviderSetting, error) {
+	params := sqlc.GetProviderSettingForUserParams{
+		ProviderID: pgtype.UUID{Bytes: providerID, Valid: true},
+		UserID:     pgtype.UUID{Bytes: userID, Valid: true},
+	}
+	if organizationID != nil {
+		params.OrganizationID = pgtype.UUID{Bytes: *organizationID, Valid: true}
+	}
+	sqlcSetting, err := r.queries.GetProviderSettingForUser(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrUserProviderSettingNotFound
+		}
+		return nil, fmt.Errorf("failed to get provider setting for user: %w", err)
+	}
+	return sqlcUserProviderSettingToEntity(&sqlcSetting), nil
+}
+
 func (r *UserProviderSettingRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*chat.UserProviderSetting, error) {
 	userUUID := pgtype.UUID{Bytes: userID, Valid: true}
 	sqlcSettings, err := r.queries.ListUserProviderSettings(ctx, userUUID)
@@ -70,6 +107,18 @@ func (r *UserProviderSettingRepository) ListByUserID(ctx context.Context, userID
 	return settings, nil
 }
 
+func (r *UserProviderSettingRepository) ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*chat.UserProviderSetting, error) {
+	sqlcSettings, err := r.queries.ListOrganizationProviderSettings(ctx, pgtype.UUID{Bytes: organizationID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization provider settings: %w", err)
+	}
+	settings := make([]*chat.UserProviderSetting, len(sqlcSettings))
+	for i, s := range sqlcSettings {
+		settings[i] = sqlcUserProviderSettingToEntity(&s)
+	}
+	return settings, nil
+}
+
 func (r *UserProviderSettingRepository) Update(ctx context.Context, setting *chat.UserProviderSetting) (*chat.UserProviderSetting, error) {
 	params := sqlc.UpdateUserProviderSettingParams{
 		ID:       pgtype.UUID{Bytes: setting.ID, Valid: true},
@@ -109,6 +158,10 @@ func sqlcUserProviderSettingToEntity(s *sqlc.UserProviderSetting) *chat.UserProv
 	if s.UserID.Valid {
 		setting.UserID = s.UserID.Bytes
 	}
+	if s.OrganizationID.Valid {
+		organizationID := uuid.UUID(s.OrganizationID.Bytes)
+		setting.OrganizationID = &organizationID
+	}
 	if s.ProviderID.Valid {
 		setting.ProviderID = s.ProviderID.Bytes
 	}
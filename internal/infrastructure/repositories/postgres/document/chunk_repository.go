@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ChunkRepository implements the domain's ChunkRepository interface using PostgreSQL.
+type ChunkRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewChunkRepository creates a new postgres document chunk repository.
+func NewChunkRepository(db sqlc.DBTX) document.ChunkRepository {
+	return &ChunkRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *ChunkRepository) CreateBatch(ctx context.Context, chunks []*document.Chunk) error {
+	for _, c := range chunks {
+		err := r.queries.CreateDocumentChunk(ctx, sqlc.CreateDocumentChunkParams{
+			DocumentID: pgtype.UUID{Bytes: c.DocumentID, Valid: true},
+			ChunkIndex: int32(c.Index),
+			Content:    c.Content,
+			Page:       int4FromPtr(c.Page),
+			Heading:    textFromPtr(c.Heading),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create document chunk: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *ChunkRepository) ListByDocumentID(ctx context.Context, documentID uuid.UUID) ([]*document.Chunk, error) {
+	sqlcChunks, err := r.queries.ListDocumentChunksByDocumentID(ctx, pgtype.UUID{Bytes: documentID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document chunks: %w", err)
+	}
+	return sqlcChunksToEntities(sqlcChunks), nil
+}
+
+func (r *ChunkRepository) DeleteByDocumentID(ctx context.Context, documentID uuid.UUID) error {
+	if err := r.queries.DeleteDocumentChunksByDocumentID(ctx, pgtype.UUID{Bytes: documentID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete document chunks: %w", err)
+	}
+	return nil
+}
+
+func (r *ChunkRepository) SetEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) error {
+	err := r.queries.SetDocumentChunkEmbedding(ctx, sqlc.SetDocumentChunkEmbeddingParams{
+		ID:        pgtype.UUID{Bytes: id, Valid: true},
+		Embedding: embedding,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set document chunk embedding: %w", err)
+	}
+	return nil
+}
+
+func (r *ChunkRepository) ListEmbedded(ctx context.Context, after uuid.UUID, limit int) ([]*document.Chunk, error) {
+	sqlcChunks, err := r.queries.ListEmbeddedDocumentChunks(ctx, sqlc.ListEmbeddedDocumentChunksParams{
+		AfterID: pgtype.UUID{Bytes: after, Valid: true},
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedded document chunks: %w", err)
+	}
+	return sqlcChunksToEntities(sqlcChunks), nil
+}
+
+func (r *ChunkRepository) Search(ctx context.Context, query []float32, documentIDs []uuid.UUID, limit int) ([]*document.ChunkMatch, error) {
+	ids := make([]pgtype.UUID, len(documentIDs))
+	for i, id := range documentIDs {
+		ids[i] = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	rows, err := r.queries.SearchDocumentChunks(ctx, sqlc.SearchDocumentChunksParams{
+		Query:       vectorLiteral(query),
+		DocumentIds: ids,
+		MaxRows:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search document chunks: %w", err)
+	}
+
+	matches := make([]*document.ChunkMatch, len(rows))
+	for i, row := range rows {
+		matches[i] = &document.ChunkMatch{
+			Chunk: &document.Chunk{
+				ID:         row.ID.Bytes,
+				DocumentID: row.DocumentID.Bytes,
+				Index:      int(row.ChunkIndex),
+				Content:    row.Content,
+				Page:       ptrFromInt4(row.Page),
+				Heading:    ptrFromText(row.Heading),
+				CreatedAt:  row.CreatedAt.Time,
+			},
+			Similarity: row.Similarity,
+		}
+	}
+	return matches, nil
+}
+
+func (r *ChunkRepository) VectorSearchAvailable(ctx context.Context) (bool, error) {
+	count, err := r.queries.CountVectorExtensions(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for the vector extension: %w", err)
+	}
+	return count > 0, nil
+}
+
+// vectorLiteral formats an embedding as pgvector's text representation.
+func vectorLiteral(v []float32) string {
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(float64(x), 'g', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+func sqlcChunksToEntities(sqlcChunks []sqlc.DocumentChunk) []*document.Chunk {
+	chunks := make([]*document.Chunk, len(sqlcChunks))
+	for i, c := range sqlcChunks {
+		chunks[i] = &document.Chunk{
+			ID:         c.ID.Bytes,
+			DocumentID: c.DocumentID.Bytes,
+			Index:      int(c.ChunkIndex),
+			Content:    c.Content,
+			Page:       ptrFromInt4(c.Page),
+			Heading:    ptrFromText(c.Heading),
+			Embedding:  c.Embedding,
+			CreatedAt:  c.CreatedAt.Time,
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DocumentRepository implements the domain's DocumentRepository interface using PostgreSQL.
+type DocumentRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewDocumentRepository creates a new postgres document repository.
+func NewDocumentRepository(db sqlc.DBTX) document.DocumentRepository {
+	return &DocumentRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *DocumentRepository) Create(ctx context.Context, d *document.Document) (*document.Document, error) {
+	sqlcDocument, err := r.queries.CreateDocument(ctx, sqlc.CreateDocumentParams{
+		UserID:      pgtype.UUID{Bytes: d.UserID, Valid: true},
+		Title:       d.Title,
+		Filename:    d.Filename,
+		Format:      string(d.Format),
+		Size:        d.Size,
+		ContentHash: d.ContentHash,
+		Status:      string(d.Status),
+		ChunkCount:  int32(d.ChunkCount),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+	return sqlcDocumentToEntity(&sqlcDocument), nil
+}
+
+func (r *DocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*document.Document, error) {
+	sqlcDocument, err := r.queries.GetDocumentByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to get document by ID: %w", err)
+	}
+	return sqlcDocumentToEntity(&sqlcDocument), nil
+}
+
+func (r *DocumentRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*document.Document, error) {
+	params := sqlc.ListDocumentsByUserIDParams{
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		SkipRows: int32(offset),
+	}
+	if limit > 0 {
+		params.MaxRows = pgtype.Int4{Int32: int32(limit), Valid: true}
+	}
+
+	sqlcDocuments, err := r.queries.ListDocumentsByUserID(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	return sqlcDocumentsToEntities(sqlcDocuments), nil
+}
+
+func (r *DocumentRepository) ListReadyByUserID(ctx context.Context, userID uuid.UUID, embeddingModel string) ([]*document.Document, error) {
+	sqlcDocuments, err := r.queries.ListReadyDocumentsByUserID(ctx, sqlc.ListReadyDocumentsByUserIDParams{
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		EmbeddingModel: pgtype.Text{String: embeddingModel, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ready documents: %w", err)
+	}
+	return sqlcDocumentsToEntities(sqlcDocuments), nil
+}
+
+func (r *DocumentRepository) ListByKnowledgeBaseID(ctx context.Context, knowledgeBaseID uuid.UUID) ([]*document.Document, error) {
+	sqlcDocuments, err := r.queries.ListDocumentsByKnowledgeBaseID(ctx, pgtype.UUID{Bytes: knowledgeBaseID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge base documents: %w", err)
+	}
+	return sqlcDocumentsToEntities(sqlcDocuments), nil
+}
+
+func (r *DocumentRepository) ListReadyByConversationID(ctx context.Context, conversationID, userID uuid.UUID, embeddingModel string) ([]*document.Document, error) {
+	sqlcDocuments, err := r.queries.ListReadyDocumentsByConversationID(ctx, sqlc.ListReadyDocumentsByConversationIDParams{
+		EmbeddingModel: pgtype.Text{String: embeddingModel, Valid: true},
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		ConversationID: pgtype.UUID{Bytes: conversationID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ready conversation documents: %w", err)
+	}
+	return sqlcDocumentsToEntities(sqlcDocuments), nil
+}
+
+func (r *DocumentRepository) Update(ctx context.Context, d *document.Document) (*document.Document, error) {
+	sqlcDocument, err := r.queries.UpdateDocument(ctx, sqlc.UpdateDocumentParams{
+		ID:             pgtype.UUID{Bytes: d.ID, Valid: true},
+		Title:          d.Title,
+		Filename:       d.Filename,
+		Format:         string(d.Format),
+		Size:           d.Size,
+		ContentHash:    d.ContentHash,
+		Status:         string(d.Status),
+		Error:          textFromPtr(d.Error),
+		ChunkCount:     int32(d.ChunkCount),
+		EmbeddingModel: textFromPtr(d.EmbeddingModel),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+	return sqlcDocumentToEntity(&sqlcDocument), nil
+}
+
+func (r *DocumentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status document.Status, errorMessage *string, embeddingModel *string) (*document.Document, error) {
+	sqlcDocument, err := r.queries.UpdateDocumentStatus(ctx, sqlc.UpdateDocumentStatusParams{
+		ID:             pgtype.UUID{Bytes: id, Valid: true},
+		Status:         string(status),
+		Error:          textFromPtr(errorMessage),
+		EmbeddingModel: textFromPtr(embeddingModel),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to update document status: %w", err)
+	}
+	return sqlcDocumentToEntity(&sqlcDocument), nil
+}
+
+func (r *DocumentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteDocument(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
+
+func sqlcDocumentsToEntities(sqlcDocuments []sqlc.Document) []*document.Document {
+	documents := make([]*document.Document, len(sqlcDocuments))
+	for i, d := range sqlcDocuments {
+		documents[i] = sqlcDocumentToEntity(&d)
+	}
+	return documents
+}
+
+func sqlcDocumentToEntity(d *sqlc.Document) *document.Document {
+	return &document.Document{
+		ID:             d.ID.Bytes,
+		UserID:         d.UserID.Bytes,
+		Title:          d.Title,
+		Filename:       d.Filename,
+		Format:         document.Format(d.Format),
+		Size:           d.Size,
+		ContentHash:    d.ContentHash,
+		Status:         document.Status(d.Status),
+		Error:          ptrFromText(d.Error),
+		ChunkCount:     int(d.ChunkCount),
+		EmbeddingModel: ptrFromText(d.EmbeddingModel),
+		CreatedAt:      d.CreatedAt.Time,
+		UpdatedAt:      d.UpdatedAt.Time,
+	}
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+func ptrFromText(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
+
+func int4FromPtr(v *int) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*v), Valid: true}
+}
+
+func ptrFromInt4(v pgtype.Int4) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int32)
+	return &i
+}
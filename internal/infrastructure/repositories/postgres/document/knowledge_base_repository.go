@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// KnowledgeBaseRepository implements the domain's KnowledgeBaseRepository interface using PostgreSQL.
+type KnowledgeBaseRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewKnowledgeBaseRepository creates a new postgres knowledge base repository.
+func NewKnowledgeBaseRepository(db sqlc.DBTX) document.KnowledgeBaseRepository {
+	return &KnowledgeBaseRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *KnowledgeBaseRepository) Create(ctx context.Context, kb *document.KnowledgeBase) (*document.KnowledgeBase, error) {
+	sqlcKnowledgeBase, err := r.queries.CreateKnowledgeBase(ctx, sqlc.CreateKnowledgeBaseParams{
+		UserID:      pgtype.UUID{Bytes: kb.UserID, Valid: true},
+		Name:        kb.Name,
+		Description: textFromPtr(kb.Description),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create knowledge base: %w", err)
+	}
+	return sqlcKnowledgeBaseToEntity(&sqlcKnowledgeBase, document.RoleOwner), nil
+}
+
+func (r *KnowledgeBaseRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*document.KnowledgeBase, error) {
+	row, err := r.queries.GetKnowledgeBaseForUser(ctx, sqlc.GetKnowledgeBaseForUserParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		ID:     pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrKnowledgeBaseNotFound
+		}
+		return nil, fmt.Errorf("failed to get knowledge base by ID: %w", err)
+	}
+	return knowledgeBaseRowToEntity(&row), nil
+}
+
+func (r *KnowledgeBaseRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*document.KnowledgeBase, error) {
+	rows, err := r.queries.ListKnowledgeBasesForUser(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge bases: %w", err)
+	}
+	knowledgeBases := make([]*document.KnowledgeBase, len(rows))
+	for i, row := range rows {
+		getRow := sqlc.GetKnowledgeBaseForUserRow(row)
+		knowledgeBases[i] = knowledgeBaseRowToEntity(&getRow)
+	}
+	return knowledgeBases, nil
+}
+
+func (r *KnowledgeBaseRepository) ListByConversationID(ctx context.Context, conversationID, userID uuid.UUID) ([]*document.KnowledgeBase, error) {
+	rows, err := r.queries.ListKnowledgeBasesByConversationID(ctx, sqlc.ListKnowledgeBasesByConversationIDParams{
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		ConversationID: pgtype.UUID{Bytes: conversationID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversation knowledge bases: %w", err)
+	}
+	knowledgeBases := make([]*document.KnowledgeBase, len(rows))
+	for i, row := range rows {
+		getRow := sqlc.GetKnowledgeBaseForUserRow(row)
+		knowledgeBases[i] = knowledgeBaseRowToEntity(&getRow)
+	}
+	return knowledgeBases, nil
+}
+
+func (r *KnowledgeBaseRepository) Update(ctx context.Context, kb *document.KnowledgeBase) (*document.KnowledgeBase, error) {
+	sqlcKnowledgeBase, err := r.queries.UpdateKnowledgeBase(ctx, sqlc.UpdateKnowledgeBaseParams{
+		ID:          pgtype.UUID{Bytes: kb.ID, Valid: true},
+		Name:        kb.Name,
+		Description: textFromPtr(kb.Description),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrKnowledgeBaseNotFound
+		}
+		return nil, fmt.Errorf("failed to update knowledge base: %w", err)
+	}
+	return sqlcKnowledgeBaseToEntity(&sqlcKnowledgeBase, kb.Role), nil
+}
+
+func (r *KnowledgeBaseRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteKnowledgeBase(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete knowledge base: %w", err)
+	}
+	return nil
+}
+
+func (r *KnowledgeBaseRepository) SetMember(ctx context.Context, member *document.KnowledgeBaseMember) error {
+	err := r.queries.UpsertKnowledgeBaseMember(ctx, sqlc.UpsertKnowledgeBaseMemberParams{
+		KnowledgeBaseID: pgtype.UUID{Bytes: member.KnowledgeBaseID, Valid: true},
+		UserID:          pgtype.UUID{Bytes: member.UserID, Valid: true},
+		Role:            string(member.Role),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set knowledge base member: %w", err)
+	}
+	return nil
+}
+
+func (r *KnowledgeBaseRepository) ListMembers(ctx context.Context, knowledgeBaseID uuid.UUID) ([]*document.KnowledgeBaseMember, error) {
+	rows, err := r.queries.ListKnowledgeBaseMembers(ctx, pgtype.UUID{Bytes: knowledgeBaseID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list knowledge base members: %w", err)
+	}
+	members := make([]*document.KnowledgeBaseMember, len(rows))
+	for i, row := range rows {
+		members[i] = &document.KnowledgeBaseMember{
+			KnowledgeBaseID: row.KnowledgeBaseID.Bytes,
+			UserID:          row.UserID.Bytes,
+			Email:           row.Email,
+			Role:            document.Role(row.Role),
+			CreatedAt:       row.CreatedAt.Time,
+		}
+	}
+	return members, nil
+}
+
+func (r *KnowledgeBaseRepository) RemoveMember(ctx context.Context, knowledgeBaseID, userID uuid.UUID) error {
+	err := r.queries.DeleteKnowledgeBaseMember(ctx, sqlc.DeleteKnowledgeBaseMemberParams{
+		KnowledgeBaseID: pgtype.UUID{Bytes: knowledgeBaseID, Valid: true},
+		UserID:          pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove knowledge base member: %w", err)
+	}
+	return nil
+}
+
+func (r *KnowledgeBaseRepository) AddDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID) error {
+	err := r.queries.AddKnowledgeBaseDocument(ctx, sqlc.AddKnowledgeBaseDocumentParams{
+		KnowledgeBaseID: pgtype.UUID{Bytes: knowledgeBaseID, Valid: true},
+		DocumentID:      pgtype.UUID{Bytes: documentID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add document to knowledge base: %w", err)
+	}
+	return nil
+}
+
+func (r *KnowledgeBaseRepository) RemoveDocument(ctx context.Context, knowledgeBaseID, documentID uuid.UUID) error {
+	err := r.queries.DeleteKnowledgeBaseDocument(ctx, sqlc.DeleteKnowledgeBaseDocumentParams{
+		KnowledgeBaseID: pgtype.UUID{Bytes: knowledgeBaseID, Valid: true},
+		DocumentID:      pgtype.UUID{Bytes: documentID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove document from knowledge base: %w", err)
+	}
+	return nil
+}
+
+func (r *KnowledgeBaseRepository) AttachToConversation(ctx context.Context, knowledgeBaseID, conversationID uuid.UUID) error {
+	err := r.queries.AttachKnowledgeBaseToConversation(ctx, sqlc.AttachKnowledgeBaseToConversationParams{
+		ConversationID:  pgtype.UUID{Bytes: conversationID, Valid: true},
+		KnowledgeBaseID: pgtype.UUID{Bytes: knowledgeBaseID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach knowledge base: %w", err)
+	}
+	return nil
+}
+
+func (r *KnowledgeBaseRepository) DetachFromConversation(ctx context.Context, knowledgeBaseID, conversationID uuid.UUID) error {
+	err := r.queries.DetachKnowledgeBaseFromConversation(ctx, sqlc.DetachKnowledgeBaseFromConversationParams{
+		ConversationID:  pgtype.UUID{Bytes: conversationID, Valid: true},
+		KnowledgeBaseID: pgtype.UUID{Bytes: knowledgeBaseID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach knowledge base: %w", err)
+	}
+	return nil
+}
+
+func sqlcKnowledgeBaseToEntity(kb *sqlc.KnowledgeBase, role document.Role) *document.KnowledgeBase {
+	return &document.KnowledgeBase{
+		ID:          kb.ID.Bytes,
+		UserID:      kb.UserID.Bytes,
+		Name:        kb.Name,
+		Description: ptrFromText(kb.Description),
+		Role:        role,
+		CreatedAt:   kb.CreatedAt.Time,
+		UpdatedAt:   kb.UpdatedAt.Time,
+	}
+}
+
+// knowledgeBaseRowToEntity converts a knowledge base loaded with the role of a user.
+func knowledgeBaseRowToEntity(row *sqlc.GetKnowledgeBaseForUserRow) *document.KnowledgeBase {
+	return &document.KnowledgeBase{
+		ID:          row.ID.Bytes,
+		UserID:      row.UserID.Bytes,
+		Name:        row.Name,
+		Description: ptrFromText(row.Description),
+		Role:        document.Role(row.Role),
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/encryption"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NewSecretRepository creates a new postgres repository of the encrypted
+// secrets of the given kind.
+func NewSecretRepository(db sqlc.DBTX, kind encryption.SecretKind) encryption.SecretRepository {
+	queries := sqlc.New(db)
+	switch kind {
+	case encryption.SecretProviderAPIKey:
+		return &providerAPIKeyRepository{queries: queries}
+	case encryption.SecretBrokerCredentials:
+		return &brokerCredentialsRepository{queries: queries}
+	case encryption.SecretTOTPSecret:
+		return &totpSecretRepository{queries: queries}
+	}
+	panic(fmt.Sprintf("unknown secret kind %q", kind))
+}
+
+// providerAPIKeyRepository rewrites the API keys of user_provider_settings.
+type providerAPIKeyRepository struct {
+	queries *sqlc.Queries
+}
+
+func (r *providerAPIKeyRepository) ListAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]*encryption.Secret, error) {
+	rows, err := r.queries.ListEncryptedProviderAPIKeys(ctx, sqlc.ListEncryptedProviderAPIKeysParams{
+		AfterID: pgtype.UUID{Bytes: afterID, Valid: true},
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*encryption.Secret, len(rows))
+	for i, row := range rows {
+		secrets[i] = &encryption.Secret{ID: row.ID.Bytes, Ciphertext: row.EncryptedApiKey.String}
+	}
+	return secrets, nil
+}
+
+func (r *providerAPIKeyRepository) Update(ctx context.Context, id uuid.UUID, ciphertext string) error {
+	return r.queries.UpdateEncryptedProviderAPIKey(ctx, sqlc.UpdateEncryptedProviderAPIKeyParams{
+		ID:              pgtype.UUID{Bytes: id, Valid: true},
+		EncryptedApiKey: pgtype.Text{String: ciphertext, Valid: true},
+	})
+}
+
+// brokerCredentialsRepository rewrites the credentials of broker_connections.
+type brokerCredentialsRepository struct {
+	queries *sqlc.Queries
+}
+
+func (r *brokerCredentialsRepository) ListAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]*encryption.Secret, error) {
+	rows, err := r.queries.ListEncryptedBrokerCredentials(ctx, sqlc.ListEncryptedBrokerCredentialsParams{
+		AfterID: pgtype.UUID{Bytes: afterID, Valid: true},
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*encryption.Secret, len(rows))
+	for i, row := range rows {
+		secrets[i] = &encryption.Secret{ID: row.ID.Bytes, Ciphertext: row.EncryptedCredentials.String}
+	}
+	return secrets, nil
+}
+
+func (r *brokerCredentialsRepository) Update(ctx context.Context, id uuid.UUID, ciphertext string) error {
+	return r.queries.UpdateEncryptedBrokerCredentials(ctx, sqlc.UpdateEncryptedBrokerCredentialsParams{
+		ID:                   pgtype.UUID{Bytes: id, Valid: true},
+		EncryptedCredentials: pgtype.Text{String: ciphertext, Valid: true},
+	})
+}
+
+// totpSecretRepository rewrites the authenticator secrets of totp_secrets,
+// which are keyed by user ID.
+type totpSecretRepository struct {
+	queries *sqlc.Queries
+}
+
+func (r *totpSecretRepository) ListAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]*encryption.Secret, error) {
+	rows, err := r.queries.ListEncryptedTOTPSecrets(ctx, sqlc.ListEncryptedTOTPSecretsParams{
+		AfterID: pgtype.UUID{Bytes: afterID, Valid: true},
+		MaxRows: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*encryption.Secret, len(rows))
+	for i, row := range rows {
+		secrets[i] = &encryption.Secret{ID: row.UserID.Bytes, Ciphertext: row.EncryptedSecret}
+	}
+	return secrets, nil
+}
+
+func (r *totpSecretRepository) Update(ctx context.Context, id uuid.UUID, ciphertext string) error {
+	return r.queries.UpdateEncryptedTOTPSecret(ctx, sqlc.UpdateEncryptedTOTPSecretParams{
+		UserID:          pgtype.UUID{Bytes: id, Valid: true},
+		EncryptedSecret: ciphertext,
+	})
+}
@@ -0,0 +1,254 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/journal"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EntryRepository implements the domain's journal EntryRepository interface using PostgreSQL.
+type EntryRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewEntryRepository creates a new postgres journal entry repository.
+func NewEntryRepository(db sqlc.DBTX) journal.EntryRepository {
+	return &EntryRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *EntryRepository) Create(ctx context.Context, e *journal.Entry) (*journal.Entry, error) {
+	sqlcEntry, err := r.queries.CreateJournalEntry(ctx, sqlc.CreateJournalEntryParams{
+		UserID:         pgtype.UUID{Bytes: e.UserID, Valid: true},
+		Symbol:         e.Symbol,
+		Side:           string(e.Side),
+		Quantity:       e.Quantity,
+		EntryPrice:     e.EntryPrice,
+		EntryTime:      pgtype.Timestamptz{Time: e.EntryTime, Valid: true},
+		ExitPrice:      float8FromPtr(e.ExitPrice),
+		ExitTime:       timestamptzFromPtr(e.ExitTime),
+		Fees:           e.Fees,
+		Setup:          textFromPtr(e.Setup),
+		Thesis:         textFromPtr(e.Thesis),
+		Notes:          textFromPtr(e.Notes),
+		Tags:           tagsOrEmpty(e.Tags),
+		ConversationID: uuidFromPtr(e.ConversationID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal entry: %w", err)
+	}
+	return sqlcEntryToEntity(&sqlcEntry), nil
+}
+
+func (r *EntryRepository) GetByID(ctx context.Context, id uuid.UUID) (*journal.Entry, error) {
+	sqlcEntry, err := r.queries.GetJournalEntryByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrJournalEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get journal entry by ID: %w", err)
+	}
+
+	entries := []*journal.Entry{sqlcEntryToEntity(&sqlcEntry)}
+	if err := r.loadScreenshots(ctx, entries); err != nil {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+func (r *EntryRepository) List(ctx context.Context, userID uuid.UUID, filter journal.EntryFilter, limit, offset int) ([]*journal.Entry, error) {
+	params := sqlc.ListJournalEntriesParams{
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+		StartTime:      timestamptzFromPtr(filter.Start),
+		EndTime:        timestamptzFromPtr(filter.End),
+		Symbol:         filter.Symbol,
+		Tag:            filter.Tag,
+		Setup:          filter.Setup,
+		ConversationID: uuidFromPtr(filter.ConversationID),
+		SkipRows:       int32(offset),
+	}
+	if limit > 0 {
+		params.MaxRows = pgtype.Int4{Int32: int32(limit), Valid: true}
+	}
+
+	sqlcEntries, err := r.queries.ListJournalEntries(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list journal entries: %w", err)
+	}
+
+	entries := make([]*journal.Entry, len(sqlcEntries))
+	for i, e := range sqlcEntries {
+		entries[i] = sqlcEntryToEntity(&e)
+	}
+	if err := r.loadScreenshots(ctx, entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *EntryRepository) Update(ctx context.Context, e *journal.Entry) (*journal.Entry, error) {
+	sqlcEntry, err := r.queries.UpdateJournalEntry(ctx, sqlc.UpdateJournalEntryParams{
+		ID:             pgtype.UUID{Bytes: e.ID, Valid: true},
+		Symbol:         e.Symbol,
+		Side:           string(e.Side),
+		Quantity:       e.Quantity,
+		EntryPrice:     e.EntryPrice,
+		EntryTime:      pgtype.Timestamptz{Time: e.EntryTime, Valid: true},
+		ExitPrice:      float8FromPtr(e.ExitPrice),
+		ExitTime:       timestamptzFromPtr(e.ExitTime),
+		Fees:           e.Fees,
+		Setup:          textFromPtr(e.Setup),
+		Thesis:         textFromPtr(e.Thesis),
+		Notes:          textFromPtr(e.Notes),
+		Tags:           tagsOrEmpty(e.Tags),
+		ConversationID: uuidFromPtr(e.ConversationID),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrJournalEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to update journal entry: %w", err)
+	}
+
+	entries := []*journal.Entry{sqlcEntryToEntity(&sqlcEntry)}
+	if err := r.loadScreenshots(ctx, entries); err != nil {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+func (r *EntryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteJournalEntry(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete journal entry: %w", err)
+	}
+	return nil
+}
+
+func (r *EntryRepository) SetScreenshots(ctx context.Context, entryID uuid.UUID, artifactIDs []uuid.UUID) error {
+	if err := r.queries.DeleteJournalScreenshots(ctx, pgtype.UUID{Bytes: entryID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to clear journal screenshots: %w", err)
+	}
+	for i, artifactID := range artifactIDs {
+		err := r.queries.AddJournalScreenshot(ctx, sqlc.AddJournalScreenshotParams{
+			EntryID:    pgtype.UUID{Bytes: entryID, Valid: true},
+			ArtifactID: pgtype.UUID{Bytes: artifactID, Valid: true},
+			Position:   int32(i),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add journal screenshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadScreenshots fills in the screenshot IDs of the entries with a single query.
+func (r *EntryRepository) loadScreenshots(ctx context.Context, entries []*journal.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]pgtype.UUID, len(entries))
+	byID := make(map[uuid.UUID]*journal.Entry, len(entries))
+	for i, e := range entries {
+		ids[i] = pgtype.UUID{Bytes: e.ID, Valid: true}
+		byID[e.ID] = e
+	}
+
+	screenshots, err := r.queries.GetJournalScreenshotsByEntryIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get journal screenshots: %w", err)
+	}
+	for _, s := range screenshots {
+		if e, ok := byID[s.EntryID.Bytes]; ok {
+			e.ScreenshotIDs = append(e.ScreenshotIDs, s.ArtifactID.Bytes)
+		}
+	}
+	return nil
+}
+
+func sqlcEntryToEntity(e *sqlc.JournalEntry) *journal.Entry {
+	entry := &journal.Entry{
+		ID:            e.ID.Bytes,
+		UserID:        e.UserID.Bytes,
+		Symbol:        e.Symbol,
+		Side:          journal.Side(e.Side),
+		Quantity:      e.Quantity,
+		EntryPrice:    e.EntryPrice,
+		EntryTime:     e.EntryTime.Time,
+		ExitPrice:     ptrFromFloat8(e.ExitPrice),
+		Fees:          e.Fees,
+		Setup:         ptrFromText(e.Setup),
+		Thesis:        ptrFromText(e.Thesis),
+		Notes:         ptrFromText(e.Notes),
+		Tags:          tagsOrEmpty(e.Tags),
+		ScreenshotIDs: []uuid.UUID{},
+		CreatedAt:     e.CreatedAt.Time,
+		UpdatedAt:     e.UpdatedAt.Time,
+	}
+	if e.ExitTime.Valid {
+		entry.ExitTime = &e.ExitTime.Time
+	}
+	if e.ConversationID.Valid {
+		conversationID := uuid.UUID(e.ConversationID.Bytes)
+		entry.ConversationID = &conversationID
+	}
+	return entry
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+func ptrFromText(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	return &v.String
+}
+
+func float8FromPtr(v *float64) pgtype.Float8 {
+	if v == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *v, Valid: true}
+}
+
+func ptrFromFloat8(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
+
+func uuidFromPtr(v *uuid.UUID) pgtype.UUID {
+	if v == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *v, Valid: true}
+}
+
+func timestamptzFromPtr(v *time.Time) pgtype.Timestamptz {
+	if v == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *v, Valid: true}
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CandleRepository implements the domain's CandleRepository interface using PostgreSQL.
+type CandleRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewCandleRepository creates a new postgres candle repository.
+func NewCandleRepository(db sqlc.DBTX) market.CandleRepository {
+	return &CandleRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *CandleRepository) Upsert(ctx context.Context, candle *market.Candle) (*market.Candle, error) {
+	params := sqlc.UpsertCandleParams{
+		Symbol:    candle.Symbol,
+		Timeframe: string(candle.Timeframe),
+		OpenTime:  pgtype.Timestamptz{Time: candle.OpenTime, Valid: true},
+		Open:      candle.Open,
+		High:      candle.High,
+		Low:       candle.Low,
+		Close:     candle.Close,
+		Volume:    candle.Volume,
+	}
+
+	sqlcCandle, err := r.queries.UpsertCandle(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert candle: %w", err)
+	}
+	return sqlcCandleToEntity(sqlcCandle), nil
+}
+
+func (r *CandleRepository) GetRange(ctx context.Context, symbol string, timeframe market.Timeframe, from, to time.Time, limit int) ([]*market.Candle, error) {
+	sqlcCandles, err := r.queries.GetCandlesInRange(ctx, sqlc.GetCandlesInRangeParams{
+		Symbol:    symbol,
+		Timeframe: string(timeframe),
+		StartTime: pgtype.Timestamptz{Time: from, Valid: true},
+		EndTime:   pgtype.Timestamptz{Time: to, Valid: true},
+		MaxRows:   int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candles in range: %w", err)
+	}
+
+	candles := make([]*market.Candle, len(sqlcCandles))
+	for i, c := range sqlcCandles {
+		candles[i] = sqlcCandleToEntity(c)
+	}
+	return candles, nil
+}
+
+func (r *CandleRepository) GetLatest(ctx context.Context, symbol string, timeframe market.Timeframe, limit int) ([]*market.Candle, error) {
+	sqlcCandles, err := r.queries.GetLatestCandles(ctx, sqlc.GetLatestCandlesParams{
+		Symbol:    symbol,
+		Timeframe: string(timeframe),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest candles: %w", err)
+	}
+
+	// The query returns newest first; callers expect chronological order.
+	candles := make([]*market.Candle, len(sqlcCandles))
+	for i, c := range sqlcCandles {
+		candles[len(sqlcCandles)-1-i] = sqlcCandleToEntity(c)
+	}
+	return candles, nil
+}
+
+func sqlcCandleToEntity(c sqlc.Candle) *market.Candle {
+	return &market.Candle{
+		ID:        c.ID.Bytes,
+		Symbol:    c.Symbol,
+		Timeframe: market.Timeframe(c.Timeframe),
+		OpenTime:  c.OpenTime.Time,
+		Open:      c.Open,
+		High:      c.High,
+		Low:       c.Low,
+		Close:     c.Close,
+		Volume:    c.Volume,
+		CreatedAt: c.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"trading-alchemist/internal/domain/notification"
+	"trading-alchemist/internal/domain/shared"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NotificationRepository implements the domain's NotificationRepository interface using PostgreSQL.
+type NotificationRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewNotificationRepository creates a new postgres notification repository.
+func NewNotificationRepository(db sqlc.DBTX) notification.NotificationRepository {
+	return &NotificationRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n *notification.Notification) (*notification.Notification, error) {
+	var dataJSON []byte
+	if n.Data != nil {
+		var err error
+		dataJSON, err = json.Marshal(n.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal notification data: %w", err)
+		}
+	}
+
+	sqlcNotification, err := r.queries.CreateNotification(ctx, sqlc.CreateNotificationParams{
+		UserID: pgtype.UUID{Bytes: n.UserID, Valid: true},
+		Type:   string(n.Type),
+		Title:  n.Title,
+		Body:   n.Body,
+		Data:   dataJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+	return sqlcNotificationToEntity(&sqlcNotification), nil
+}
+
+func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*notification.Notification, error) {
+	sqlcNotification, err := r.queries.GetNotificationByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification by ID: %w", err)
+	}
+	return sqlcNotificationToEntity(&sqlcNotification), nil
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*notification.Notification, error) {
+	sqlcNotifications, err := r.queries.GetNotificationsByUserID(ctx, sqlc.GetNotificationsByUserIDParams{
+		UserID:     pgtype.UUID{Bytes: userID, Valid: true},
+		UnreadOnly: unreadOnly,
+		MaxRows:    int32(limit),
+		SkipRows:   int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications by user ID: %w", err)
+	}
+
+	notifications := make([]*notification.Notification, len(sqlcNotifications))
+	for i, n := range sqlcNotifications {
+		notifications[i] = sqlcNotificationToEntity(&n)
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.queries.CountUnreadNotifications(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.MarkNotificationRead(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	if err := r.queries.MarkAllNotificationsRead(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteNotification(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete notification: %w", err)
+	}
+	return nil
+}
+
+func sqlcNotificationToEntity(n *sqlc.Notification) *notification.Notification {
+	result := &notification.Notification{
+		ID:        n.ID.Bytes,
+		UserID:    n.UserID.Bytes,
+		Type:      notification.Type(n.Type),
+		Title:     n.Title,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt.Time,
+	}
+	if n.Data != nil {
+		var data shared.JSONB
+		if err := json.Unmarshal(n.Data, &data); err == nil {
+			result.Data = data
+		}
+	}
+	if n.ReadAt.Valid {
+		result.ReadAt = &n.ReadAt.Time
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// InvitationRepository implements the domain's InvitationRepository interface using PostgreSQL.
+type InvitationRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewInvitationRepository creates a new postgres organization invitation repository.
+func NewInvitationRepository(db sqlc.DBTX) organization.InvitationRepository {
+	return &InvitationRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *InvitationRepository) Create(ctx context.Context, invitation *organization.Invitation) (*organization.Invitation, error) {
+	sqlcInvitation, err := r.queries.UpsertOrganizationInvitation(ctx, sqlc.UpsertOrganizationInvitationParams{
+		OrganizationID: pgtype.UUID{Bytes: invitation.OrganizationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: invitation.UserID, Valid: true},
+		Role:           string(invitation.Role),
+		InvitedBy:      uuidFromPtr(invitation.InvitedBy),
+		MagicLinkID:    pgtype.UUID{Bytes: invitation.MagicLinkID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization invitation: %w", err)
+	}
+	// Load the invitation again for the email and expiry of the invited user's link
+	return r.GetByID(ctx, sqlcInvitation.ID.Bytes)
+}
+
+func (r *InvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*organization.Invitation, error) {
+	row, err := r.queries.GetOrganizationInvitationByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrOrganizationInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization invitation by ID: %w", err)
+	}
+	return invitationRowToEntity(&row), nil
+}
+
+func (r *InvitationRepository) GetByMagicLinkID(ctx context.Context, magicLinkID uuid.UUID) (*organization.Invitation, error) {
+	row, err := r.queries.GetOrganizationInvitationByMagicLinkID(ctx, pgtype.UUID{Bytes: magicLinkID, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrOrganizationInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization invitation by magic link: %w", err)
+	}
+	getRow := sqlc.GetOrganizationInvitationByIDRow(row)
+	return invitationRowToEntity(&getRow), nil
+}
+
+func (r *InvitationRepository) ListByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]*organization.Invitation, error) {
+	rows, err := r.queries.ListOrganizationInvitations(ctx, pgtype.UUID{Bytes: organizationID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations: %w", err)
+	}
+	invitations := make([]*organization.Invitation, len(rows))
+	for i, row := range rows {
+		getRow := sqlc.GetOrganizationInvitationByIDRow(row)
+		invitations[i] = invitationRowToEntity(&getRow)
+	}
+	return invitations, nil
+}
+
+func (r *InvitationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteOrganizationInvitation(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete organization invitation: %w", err)
+	}
+	return nil
+}
+
+func invitationRowToEntity(row *sqlc.GetOrganizationInvitationByIDRow) *organization.Invitation {
+	invitation := &organization.Invitation{
+		ID:             row.ID.Bytes,
+		OrganizationID: row.OrganizationID.Bytes,
+		UserID:         row.UserID.Bytes,
+		Email:          row.Email,
+		Role:           organization.Role(row.Role),
+		MagicLinkID:    row.MagicLinkID.Bytes,
+		ExpiresAt:      row.ExpiresAt.Time,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	if row.InvitedBy.Valid {
+		invitedBy := uuid.UUID(row.InvitedBy.Bytes)
+		invitation.InvitedBy = &invitedBy
+	}
+	return invitation
+}
+
+func uuidFromPtr(v *uuid.UUID) pgtype.UUID {
+	if v == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{Bytes: *v, Valid: true}
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/organization"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// OrganizationRepository implements the domain's OrganizationRepository interface using PostgreSQL.
+type OrganizationRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewOrganizationRepository creates a new postgres organization repository.
+func NewOrganizationRepository(db sqlc.DBTX) organization.OrganizationRepository {
+	return &OrganizationRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *OrganizationRepository) Create(ctx context.Context, org *organization.Organization) (*organization.Organization, error) {
+	sqlcOrganization, err := r.queries.CreateOrganization(ctx, org.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	return sqlcOrganizationToEntity(&sqlcOrganization, org.Role), nil
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*organization.Organization, error) {
+	row, err := r.queries.GetOrganizationForUser(ctx, sqlc.GetOrganizationForUserParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		ID:     pgtype.UUID{Bytes: id, Valid: true},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization by ID: %w", err)
+	}
+	return organizationRowToEntity(&row), nil
+}
+
+func (r *OrganizationRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*organization.Organization, error) {
+	rows, err := r.queries.ListOrganizationsForUser(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	organizations := make([]*organization.Organization, len(rows))
+	for i, row := range rows {
+		getRow := sqlc.GetOrganizationForUserRow(row)
+		organizations[i] = organizationRowToEntity(&getRow)
+	}
+	return organizations, nil
+}
+
+func (r *OrganizationRepository) Update(ctx context.Context, org *organization.Organization) (*organization.Organization, error) {
+	sqlcOrganization, err := r.queries.UpdateOrganization(ctx, sqlc.UpdateOrganizationParams{
+		ID:   pgtype.UUID{Bytes: org.ID, Valid: true},
+		Name: org.Name,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+	return sqlcOrganizationToEntity(&sqlcOrganization, org.Role), nil
+}
+
+func (r *OrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeleteOrganization(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) SetMember(ctx context.Context, member *organization.Member) error {
+	err := r.queries.UpsertOrganizationMember(ctx, sqlc.UpsertOrganizationMemberParams{
+		OrganizationID: pgtype.UUID{Bytes: member.OrganizationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: member.UserID, Valid: true},
+		Role:           string(member.Role),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set organization member: %w", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]*organization.Member, error) {
+	rows, err := r.queries.ListOrganizationMembers(ctx, pgtype.UUID{Bytes: organizationID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	members := make([]*organization.Member, len(rows))
+	for i, row := range rows {
+		members[i] = &organization.Member{
+			OrganizationID: row.OrganizationID.Bytes,
+			UserID:         row.UserID.Bytes,
+			Email:          row.Email,
+			Role:           organization.Role(row.Role),
+			CreatedAt:      row.CreatedAt.Time,
+		}
+	}
+	return members, nil
+}
+
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	err := r.queries.DeleteOrganizationMember(ctx, sqlc.DeleteOrganizationMemberParams{
+		OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+	return nil
+}
+
+func sqlcOrganizationToEntity(o *sqlc.Organization, role organization.Role) *organization.Organization {
+	return &organization.Organization{
+		ID:        o.ID.Bytes,
+		Name:      o.Name,
+		Role:      role,
+		CreatedAt: o.CreatedAt.Time,
+		UpdatedAt: o.UpdatedAt.Time,
+	}
+}
+
+// organizationRowToEntity converts an organization loaded with the role of a user.
+func organizationRowToEntity(row *sqlc.GetOrganizationForUserRow) *organization.Organization {
+	return &organization.Organization{
+		ID:        row.ID.Bytes,
+		Name:      row.Name,
+		Role:      organization.Role(row.Role),
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/market"
+	"trading-alchemist/internal/domain/paper"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AccountRepository implements the domain's paper AccountRepository interface using PostgreSQL.
+type AccountRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewAccountRepository creates a new postgres paper account repository.
+func NewAccountRepository(db sqlc.DBTX) paper.AccountRepository {
+	return &AccountRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *AccountRepository) Create(ctx context.Context, account *paper.Account) (*paper.Account, error) {
+	sqlcAccount, err := r.queries.CreatePaperAccount(ctx, sqlc.CreatePaperAccountParams{
+		UserID:         pgtype.UUID{Bytes: account.UserID, Valid: true},
+		Name:           account.Name,
+		Timeframe:      string(account.Timeframe),
+		InitialCash:    account.InitialCash,
+		Cash:           account.Cash,
+		CommissionRate: account.CommissionRate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create paper account: %w", err)
+	}
+	return sqlcPaperAccountToEntity(&sqlcAccount), nil
+}
+
+func (r *AccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*paper.Account, error) {
+	sqlcAccount, err := r.queries.GetPaperAccountByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPaperAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get paper account by ID: %w", err)
+	}
+	return sqlcPaperAccountToEntity(&sqlcAccount), nil
+}
+
+func (r *AccountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*paper.Account, error) {
+	sqlcAccount, err := r.queries.GetPaperAccountByIDForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPaperAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to lock paper account: %w", err)
+	}
+	return sqlcPaperAccountToEntity(&sqlcAccount), nil
+}
+
+func (r *AccountRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*paper.Account, error) {
+	sqlcAccounts, err := r.queries.GetPaperAccountsByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paper accounts by user ID: %w", err)
+	}
+
+	accounts := make([]*paper.Account, len(sqlcAccounts))
+	for i, a := range sqlcAccounts {
+		accounts[i] = sqlcPaperAccountToEntity(&a)
+	}
+	return accounts, nil
+}
+
+func (r *AccountRepository) UpdateCash(ctx context.Context, id uuid.UUID, cash float64) error {
+	err := r.queries.UpdatePaperAccountCash(ctx, sqlc.UpdatePaperAccountCashParams{
+		ID:   pgtype.UUID{Bytes: id, Valid: true},
+		Cash: cash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update paper account cash: %w", err)
+	}
+	return nil
+}
+
+func (r *AccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeletePaperAccount(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete paper account: %w", err)
+	}
+	return nil
+}
+
+func sqlcPaperAccountToEntity(a *sqlc.PaperAccount) *paper.Account {
+	return &paper.Account{
+		ID:             a.ID.Bytes,
+		UserID:         a.UserID.Bytes,
+		Name:           a.Name,
+		Timeframe:      market.Timeframe(a.Timeframe),
+		InitialCash:    a.InitialCash,
+		Cash:           a.Cash,
+		CommissionRate: a.CommissionRate,
+		CreatedAt:      a.CreatedAt.Time,
+		UpdatedAt:      a.UpdatedAt.Time,
+	}
+}
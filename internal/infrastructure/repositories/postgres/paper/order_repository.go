@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/paper"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// OrderRepository implements the domain's paper OrderRepository interface using PostgreSQL.
+type OrderRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewOrderRepository creates a new postgres paper order repository.
+func NewOrderRepository(db sqlc.DBTX) paper.OrderRepository {
+	return &OrderRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *OrderRepository) Create(ctx context.Context, order *paper.Order) (*paper.Order, error) {
+	params := sqlc.CreatePaperOrderParams{
+		AccountID:  pgtype.UUID{Bytes: order.AccountID, Valid: true},
+		Symbol:     order.Symbol,
+		Side:       string(order.Side),
+		Type:       string(order.Type),
+		Quantity:   order.Quantity,
+		LimitPrice: float8FromPtr(order.LimitPrice),
+		StopPrice:  float8FromPtr(order.StopPrice),
+		Status:     string(order.Status),
+	}
+	if params.Status == "" {
+		params.Status = string(paper.OrderStatusOpen)
+	}
+	if order.RejectReason != nil {
+		params.RejectReason = pgtype.Text{String: *order.RejectReason, Valid: true}
+	}
+
+	sqlcOrder, err := r.queries.CreatePaperOrder(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create paper order: %w", err)
+	}
+	return sqlcPaperOrderToEntity(&sqlcOrder), nil
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*paper.Order, error) {
+	sqlcOrder, err := r.queries.GetPaperOrderByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPaperOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get paper order by ID: %w", err)
+	}
+	return sqlcPaperOrderToEntity(&sqlcOrder), nil
+}
+
+func (r *OrderRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*paper.Order, error) {
+	sqlcOrders, err := r.queries.GetPaperOrdersByAccountID(ctx, sqlc.GetPaperOrdersByAccountIDParams{
+		AccountID: pgtype.UUID{Bytes: accountID, Valid: true},
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paper orders by account ID: %w", err)
+	}
+	return sqlcPaperOrdersToEntities(sqlcOrders), nil
+}
+
+func (r *OrderRepository) GetOpenByAccountID(ctx context.Context, accountID uuid.UUID) ([]*paper.Order, error) {
+	sqlcOrders, err := r.queries.GetOpenPaperOrdersByAccountID(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open paper orders: %w", err)
+	}
+	return sqlcPaperOrdersToEntities(sqlcOrders), nil
+}
+
+func (r *OrderRepository) UpdateStatus(ctx context.Context, order *paper.Order) (*paper.Order, error) {
+	params := sqlc.UpdatePaperOrderStatusParams{
+		ID:         pgtype.UUID{Bytes: order.ID, Valid: true},
+		Status:     string(order.Status),
+		FillPrice:  float8FromPtr(order.FillPrice),
+		Commission: order.Commission,
+	}
+	if order.RejectReason != nil {
+		params.RejectReason = pgtype.Text{String: *order.RejectReason, Valid: true}
+	}
+	if order.FilledAt != nil {
+		params.FilledAt = pgtype.Timestamptz{Time: *order.FilledAt, Valid: true}
+	}
+
+	sqlcOrder, err := r.queries.UpdatePaperOrderStatus(ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPaperOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to update paper order: %w", err)
+	}
+	return sqlcPaperOrderToEntity(&sqlcOrder), nil
+}
+
+func sqlcPaperOrdersToEntities(sqlcOrders []sqlc.PaperOrder) []*paper.Order {
+	orders := make([]*paper.Order, len(sqlcOrders))
+	for i, o := range sqlcOrders {
+		orders[i] = sqlcPaperOrderToEntity(&o)
+	}
+	return orders
+}
+
+func sqlcPaperOrderToEntity(o *sqlc.PaperOrder) *paper.Order {
+	order := &paper.Order{
+		ID:         o.ID.Bytes,
+		AccountID:  o.AccountID.Bytes,
+		Symbol:     o.Symbol,
+		Side:       paper.OrderSide(o.Side),
+		Type:       paper.OrderType(o.Type),
+		Quantity:   o.Quantity,
+		LimitPrice: ptrFromFloat8(o.LimitPrice),
+		StopPrice:  ptrFromFloat8(o.StopPrice),
+		Status:     paper.OrderStatus(o.Status),
+		FillPrice:  ptrFromFloat8(o.FillPrice),
+		Commission: o.Commission,
+		CreatedAt:  o.CreatedAt.Time,
+		UpdatedAt:  o.UpdatedAt.Time,
+	}
+	if o.RejectReason.Valid {
+		order.RejectReason = &o.RejectReason.String
+	}
+	if o.FilledAt.Valid {
+		order.FilledAt = &o.FilledAt.Time
+	}
+	return order
+}
+
+func float8FromPtr(v *float64) pgtype.Float8 {
+	if v == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *v, Valid: true}
+}
+
+func ptrFromFloat8(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Float64
+}
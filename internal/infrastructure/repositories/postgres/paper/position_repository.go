@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/paper"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PositionRepository implements the domain's paper PositionRepository interface using PostgreSQL.
+type PositionRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewPositionRepository creates a new postgres paper position repository.
+func NewPositionRepository(db sqlc.DBTX) paper.PositionRepository {
+	return &PositionRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *PositionRepository) Get(ctx context.Context, accountID uuid.UUID, symbol string) (*paper.Position, error) {
+	sqlcPosition, err := r.queries.GetPaperPosition(ctx, sqlc.GetPaperPositionParams{
+		AccountID: pgtype.UUID{Bytes: accountID, Valid: true},
+		Symbol:    symbol,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPaperPositionNotFound
+		}
+		return nil, fmt.Errorf("failed to get paper position: %w", err)
+	}
+	return sqlcPaperPositionToEntity(&sqlcPosition), nil
+}
+
+func (r *PositionRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*paper.Position, error) {
+	sqlcPositions, err := r.queries.GetPaperPositionsByAccountID(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paper positions by account ID: %w", err)
+	}
+
+	positions := make([]*paper.Position, len(sqlcPositions))
+	for i, p := range sqlcPositions {
+		positions[i] = sqlcPaperPositionToEntity(&p)
+	}
+	return positions, nil
+}
+
+func (r *PositionRepository) Upsert(ctx context.Context, position *paper.Position) (*paper.Position, error) {
+	sqlcPosition, err := r.queries.UpsertPaperPosition(ctx, sqlc.UpsertPaperPositionParams{
+		AccountID:    pgtype.UUID{Bytes: position.AccountID, Valid: true},
+		Symbol:       position.Symbol,
+		Quantity:     position.Quantity,
+		AveragePrice: position.AveragePrice,
+		RealizedPnl:  position.RealizedPnL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert paper position: %w", err)
+	}
+	return sqlcPaperPositionToEntity(&sqlcPosition), nil
+}
+
+func sqlcPaperPositionToEntity(p *sqlc.PaperPosition) *paper.Position {
+	return &paper.Position{
+		ID:           p.ID.Bytes,
+		AccountID:    p.AccountID.Bytes,
+		Symbol:       p.Symbol,
+		Quantity:     p.Quantity,
+		AveragePrice: p.AveragePrice,
+		RealizedPnL:  p.RealizedPnl,
+		CreatedAt:    p.CreatedAt.Time,
+		UpdatedAt:    p.UpdatedAt.Time,
+	}
+}
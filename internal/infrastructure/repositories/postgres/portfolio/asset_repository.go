@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AssetRepository implements the domain's portfolio AssetRepository interface using PostgreSQL.
+type AssetRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewAssetRepository creates a new postgres portfolio asset repository.
+func NewAssetRepository(db sqlc.DBTX) portfolio.AssetRepository {
+	return &AssetRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *AssetRepository) Upsert(ctx context.Context, a *portfolio.Asset) (*portfolio.Asset, error) {
+	sqlcAsset, err := r.queries.UpsertPortfolioAsset(ctx, sqlc.UpsertPortfolioAssetParams{
+		PortfolioID: pgtype.UUID{Bytes: a.PortfolioID, Valid: true},
+		Symbol:      a.Symbol,
+		Name:        textFromPtr(a.Name),
+		AssetClass:  textFromPtr(a.AssetClass),
+		Sector:      textFromPtr(a.Sector),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert portfolio asset: %w", err)
+	}
+	return sqlcPortfolioAssetToEntity(&sqlcAsset), nil
+}
+
+func (r *AssetRepository) GetByPortfolioID(ctx context.Context, portfolioID uuid.UUID) ([]*portfolio.Asset, error) {
+	sqlcAssets, err := r.queries.GetPortfolioAssets(ctx, pgtype.UUID{Bytes: portfolioID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio assets: %w", err)
+	}
+
+	assets := make([]*portfolio.Asset, len(sqlcAssets))
+	for i, a := range sqlcAssets {
+		assets[i] = sqlcPortfolioAssetToEntity(&a)
+	}
+	return assets, nil
+}
+
+func sqlcPortfolioAssetToEntity(a *sqlc.PortfolioAsset) *portfolio.Asset {
+	return &portfolio.Asset{
+		ID:          a.ID.Bytes,
+		PortfolioID: a.PortfolioID.Bytes,
+		Symbol:      a.Symbol,
+		Name:        ptrFromText(a.Name),
+		AssetClass:  ptrFromText(a.AssetClass),
+		Sector:      ptrFromText(a.Sector),
+		CreatedAt:   a.CreatedAt.Time,
+		UpdatedAt:   a.UpdatedAt.Time,
+	}
+}
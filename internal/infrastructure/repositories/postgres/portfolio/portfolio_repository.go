@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PortfolioRepository implements the domain's PortfolioRepository interface using PostgreSQL.
+type PortfolioRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewPortfolioRepository creates a new postgres portfolio repository.
+func NewPortfolioRepository(db sqlc.DBTX) portfolio.PortfolioRepository {
+	return &PortfolioRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *PortfolioRepository) Create(ctx context.Context, p *portfolio.Portfolio) (*portfolio.Portfolio, error) {
+	sqlcPortfolio, err := r.queries.CreatePortfolio(ctx, sqlc.CreatePortfolioParams{
+		UserID:          pgtype.UUID{Bytes: p.UserID, Valid: true},
+		Name:            p.Name,
+		BaseCurrency:    p.BaseCurrency,
+		BenchmarkSymbol: textFromPtr(p.BenchmarkSymbol),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create portfolio: %w", err)
+	}
+	return sqlcPortfolioToEntity(&sqlcPortfolio), nil
+}
+
+func (r *PortfolioRepository) GetByID(ctx context.Context, id uuid.UUID) (*portfolio.Portfolio, error) {
+	sqlcPortfolio, err := r.queries.GetPortfolioByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPortfolioNotFound
+		}
+		return nil, fmt.Errorf("failed to get portfolio by ID: %w", err)
+	}
+	return sqlcPortfolioToEntity(&sqlcPortfolio), nil
+}
+
+func (r *PortfolioRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*portfolio.Portfolio, error) {
+	sqlcPortfolios, err := r.queries.GetPortfoliosByUserID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolios by user ID: %w", err)
+	}
+
+	portfolios := make([]*portfolio.Portfolio, len(sqlcPortfolios))
+	for i, p := range sqlcPortfolios {
+		portfolios[i] = sqlcPortfolioToEntity(&p)
+	}
+	return portfolios, nil
+}
+
+func (r *PortfolioRepository) Update(ctx context.Context, p *portfolio.Portfolio) (*portfolio.Portfolio, error) {
+	sqlcPortfolio, err := r.queries.UpdatePortfolio(ctx, sqlc.UpdatePortfolioParams{
+		ID:              pgtype.UUID{Bytes: p.ID, Valid: true},
+		Name:            p.Name,
+		BaseCurrency:    p.BaseCurrency,
+		BenchmarkSymbol: textFromPtr(p.BenchmarkSymbol),
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPortfolioNotFound
+		}
+		return nil, fmt.Errorf("failed to update portfolio: %w", err)
+	}
+	return sqlcPortfolioToEntity(&sqlcPortfolio), nil
+}
+
+func (r *PortfolioRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeletePortfolio(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete portfolio: %w", err)
+	}
+	return nil
+}
+
+func sqlcPortfolioToEntity(p *sqlc.Portfolio) *portfolio.Portfolio {
+	return &portfolio.Portfolio{
+		ID:              p.ID.Bytes,
+		UserID:          p.UserID.Bytes,
+		Name:            p.Name,
+		BaseCurrency:    p.BaseCurrency,
+		BenchmarkSymbol: ptrFromText(p.BenchmarkSymbol),
+		CreatedAt:       p.CreatedAt.Time,
+		UpdatedAt:       p.UpdatedAt.Time,
+	}
+}
+
+func textFromPtr(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+func ptrFromText(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	s := v.String
+	return &s
+}
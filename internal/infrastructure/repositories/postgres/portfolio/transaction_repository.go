@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"trading-alchemist/internal/domain/portfolio"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+	"trading-alchemist/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TransactionRepository implements the domain's portfolio TransactionRepository interface using PostgreSQL.
+type TransactionRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewTransactionRepository creates a new postgres portfolio transaction repository.
+func NewTransactionRepository(db sqlc.DBTX) portfolio.TransactionRepository {
+	return &TransactionRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, t *portfolio.Transaction) (*portfolio.Transaction, error) {
+	sqlcTransaction, err := r.queries.CreatePortfolioTransaction(ctx, sqlc.CreatePortfolioTransactionParams{
+		PortfolioID: pgtype.UUID{Bytes: t.PortfolioID, Valid: true},
+		Type:        string(t.Type),
+		Symbol:      t.Symbol,
+		Quantity:    t.Quantity,
+		Price:       t.Price,
+		Fee:         t.Fee,
+		Amount:      t.Amount,
+		ExecutedAt:  pgtype.Timestamptz{Time: t.ExecutedAt, Valid: true},
+		Notes:       textFromPtr(t.Notes),
+		Source:      string(t.Source),
+		ExternalID:  textFromPtr(t.ExternalID),
+	})
+	if err != nil {
+		// ON CONFLICT DO NOTHING returns no row when the external ID was already imported
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrDuplicatePortfolioTransaction
+		}
+		return nil, fmt.Errorf("failed to create portfolio transaction: %w", err)
+	}
+	return sqlcPortfolioTransactionToEntity(&sqlcTransaction), nil
+}
+
+func (r *TransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*portfolio.Transaction, error) {
+	sqlcTransaction, err := r.queries.GetPortfolioTransactionByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errors.ErrPortfolioTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to get portfolio transaction by ID: %w", err)
+	}
+	return sqlcPortfolioTransactionToEntity(&sqlcTransaction), nil
+}
+
+func (r *TransactionRepository) GetByPortfolioID(ctx context.Context, portfolioID uuid.UUID) ([]*portfolio.Transaction, error) {
+	sqlcTransactions, err := r.queries.GetPortfolioTransactions(ctx, pgtype.UUID{Bytes: portfolioID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio transactions: %w", err)
+	}
+
+	transactions := make([]*portfolio.Transaction, len(sqlcTransactions))
+	for i, t := range sqlcTransactions {
+		transactions[i] = sqlcPortfolioTransactionToEntity(&t)
+	}
+	return transactions, nil
+}
+
+func (r *TransactionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.queries.DeletePortfolioTransaction(ctx, pgtype.UUID{Bytes: id, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete portfolio transaction: %w", err)
+	}
+	return nil
+}
+
+func sqlcPortfolioTransactionToEntity(t *sqlc.PortfolioTransaction) *portfolio.Transaction {
+	return &portfolio.Transaction{
+		ID:          t.ID.Bytes,
+		PortfolioID: t.PortfolioID.Bytes,
+		Type:        portfolio.TransactionType(t.Type),
+		Symbol:      t.Symbol,
+		Quantity:    t.Quantity,
+		Price:       t.Price,
+		Fee:         t.Fee,
+		Amount:      t.Amount,
+		ExecutedAt:  t.ExecutedAt.Time,
+		Notes:       ptrFromText(t.Notes),
+		Source:      portfolio.TransactionSource(t.Source),
+		ExternalID:  ptrFromText(t.ExternalID),
+		CreatedAt:   t.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"trading-alchemist/internal/domain/ratelimit"
+	"trading-alchemist/internal/infrastructure/repositories/postgres/shared/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BucketRepository implements the domain's BucketRepository interface using PostgreSQL.
+type BucketRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewBucketRepository creates a new postgres rate limit bucket repository.
+func NewBucketRepository(db sqlc.DBTX) ratelimit.BucketRepository {
+	return &BucketRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Lock returns the bucket of key, creating it full if it is missing.
+func (r *BucketRepository) Lock(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (*ratelimit.Bucket, error) {
+	bucket, err := r.queries.LockRateLimitBucket(ctx, sqlc.LockRateLimitBucketParams{
+		Key:       key,
+		Tokens:    float64(limit.Requests),
+		UpdatedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ratelimit.Bucket{
+		Key:       bucket.Key,
+		Tokens:    bucket.Tokens,
+		UpdatedAt: bucket.UpdatedAt.Time,
+		ExpiresAt: bucket.ExpiresAt.Time,
+	}, nil
+}
+
+// Update saves the state of a bucket.
+func (r *BucketRepository) Update(ctx context.Context, bucket *ratelimit.Bucket) error {
+	return r.queries.UpdateRateLimitBucket(ctx, sqlc.UpdateRateLimitBucketParams{
+		Key:       bucket.Key,
+		Tokens:    bucket.Tokens,
+		UpdatedAt: pgtype.Timestamptz{Time: bucket.UpdatedAt, Valid: true},
+		ExpiresAt: pgtype.Timestamptz{Time: bucket.ExpiresAt, Valid: true},
+	})
+}
+
+// DeleteExpired removes the buckets that are full again.
+func (r *BucketRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return r.queries.DeleteExpiredRateLimitBuckets(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+}
@@ -0,0 +1,22 @@
+-- name: CreateAlertEvent :one
+INSERT INTO alert_events (rule_id, user_id, symbol, timeframe, candle_time, price, message, email_status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (rule_id, symbol, candle_time) DO NOTHING
+RETURNING id, rule_id, user_id, symbol, timeframe, candle_time, price, message, email_status, triggered_at;
+
+-- name: GetAlertEventsByUserID :many
+SELECT id, rule_id, user_id, symbol, timeframe, candle_time, price, message, email_status, triggered_at FROM alert_events
+WHERE user_id = $1
+ORDER BY triggered_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetAlertEventsByRuleID :many
+SELECT id, rule_id, user_id, symbol, timeframe, candle_time, price, message, email_status, triggered_at FROM alert_events
+WHERE rule_id = $1
+ORDER BY triggered_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateAlertEventEmailStatus :exec
+UPDATE alert_events
+SET email_status = $2
+WHERE id = $1;
@@ -0,0 +1,16 @@
+-- name: GetAlertRuleState :one
+SELECT id, rule_id, symbol, last_candle_time, active, last_triggered_at, updated_at FROM alert_rule_states
+WHERE rule_id = $1 AND symbol = $2;
+
+-- name: UpsertAlertRuleState :one
+INSERT INTO alert_rule_states (rule_id, symbol, last_candle_time, active, last_triggered_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (rule_id, symbol) DO UPDATE SET
+    last_candle_time = EXCLUDED.last_candle_time,
+    active = EXCLUDED.active,
+    last_triggered_at = EXCLUDED.last_triggered_at
+RETURNING id, rule_id, symbol, last_candle_time, active, last_triggered_at, updated_at;
+
+-- name: DeleteAlertRuleStates :exec
+DELETE FROM alert_rule_states
+WHERE rule_id = $1;
@@ -0,0 +1,42 @@
+-- name: CreateAlertRule :one
+INSERT INTO alert_rules (user_id, name, symbol, watchlist_id, timeframe, type, direction, level, percent, window_seconds, indicator_condition, cooldown_seconds, notify_email, notify_in_app, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+RETURNING id, user_id, name, symbol, watchlist_id, timeframe, type, direction, level, percent, window_seconds, indicator_condition, cooldown_seconds, notify_email, notify_in_app, enabled, created_at, updated_at;
+
+-- name: GetAlertRuleByID :one
+SELECT id, user_id, name, symbol, watchlist_id, timeframe, type, direction, level, percent, window_seconds, indicator_condition, cooldown_seconds, notify_email, notify_in_app, enabled, created_at, updated_at FROM alert_rules
+WHERE id = $1;
+
+-- name: GetAlertRulesByUserID :many
+SELECT id, user_id, name, symbol, watchlist_id, timeframe, type, direction, level, percent, window_seconds, indicator_condition, cooldown_seconds, notify_email, notify_in_app, enabled, created_at, updated_at FROM alert_rules
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetEnabledAlertRules :many
+SELECT id, user_id, name, symbol, watchlist_id, timeframe, type, direction, level, percent, window_seconds, indicator_condition, cooldown_seconds, notify_email, notify_in_app, enabled, created_at, updated_at FROM alert_rules
+WHERE enabled = TRUE
+ORDER BY created_at ASC;
+
+-- name: UpdateAlertRule :one
+UPDATE alert_rules
+SET
+    name = $2,
+    symbol = $3,
+    watchlist_id = $4,
+    timeframe = $5,
+    type = $6,
+    direction = $7,
+    level = $8,
+    percent = $9,
+    window_seconds = $10,
+    indicator_condition = $11,
+    cooldown_seconds = $12,
+    notify_email = $13,
+    notify_in_app = $14,
+    enabled = $15
+WHERE id = $1
+RETURNING id, user_id, name, symbol, watchlist_id, timeframe, type, direction, level, percent, window_seconds, indicator_condition, cooldown_seconds, notify_email, notify_in_app, enabled, created_at, updated_at;
+
+-- name: DeleteAlertRule :exec
+DELETE FROM alert_rules
+WHERE id = $1;
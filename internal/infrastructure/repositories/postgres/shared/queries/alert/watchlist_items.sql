@@ -0,0 +1,14 @@
+-- name: UpsertWatchlistItem :one
+INSERT INTO watchlist_items (watchlist_id, symbol, notes)
+VALUES ($1, $2, $3)
+ON CONFLICT (watchlist_id, symbol) DO UPDATE SET notes = EXCLUDED.notes
+RETURNING id, watchlist_id, symbol, notes, created_at;
+
+-- name: GetWatchlistItems :many
+SELECT id, watchlist_id, symbol, notes, created_at FROM watchlist_items
+WHERE watchlist_id = $1
+ORDER BY symbol ASC;
+
+-- name: DeleteWatchlistItem :exec
+DELETE FROM watchlist_items
+WHERE watchlist_id = $1 AND symbol = $2;
@@ -0,0 +1,25 @@
+-- name: CreateWatchlist :one
+INSERT INTO watchlists (user_id, name, description)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, description, created_at, updated_at;
+
+-- name: GetWatchlistByID :one
+SELECT id, user_id, name, description, created_at, updated_at FROM watchlists
+WHERE id = $1;
+
+-- name: GetWatchlistsByUserID :many
+SELECT id, user_id, name, description, created_at, updated_at FROM watchlists
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateWatchlist :one
+UPDATE watchlists
+SET
+    name = $2,
+    description = $3
+WHERE id = $1
+RETURNING id, user_id, name, description, created_at, updated_at;
+
+-- name: DeleteWatchlist :exec
+DELETE FROM watchlists
+WHERE id = $1;
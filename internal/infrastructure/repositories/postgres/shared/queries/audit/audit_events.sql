@@ -0,0 +1,26 @@
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (action, actor_id, user_id, resource_type, resource_id, ip_address, user_agent, metadata)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, action, actor_id, user_id, resource_type, resource_id, ip_address, user_agent, metadata, created_at;
+
+-- name: ListAuditEvents :many
+SELECT id, action, actor_id, user_id, resource_type, resource_id, ip_address, user_agent, metadata, created_at FROM audit_events
+WHERE (sqlc.narg(involving_user_id)::uuid IS NULL OR actor_id = sqlc.narg(involving_user_id)::uuid OR user_id = sqlc.narg(involving_user_id)::uuid)
+  AND (sqlc.narg(actor_id)::uuid IS NULL OR actor_id = sqlc.narg(actor_id)::uuid)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.arg(action)::text = '' OR action = sqlc.arg(action)::text)
+  AND (sqlc.arg(resource_type)::text = '' OR resource_type = sqlc.arg(resource_type)::text)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR created_at < sqlc.narg(until)::timestamptz)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(max_rows) OFFSET sqlc.arg(skip_rows);
+
+-- name: CountAuditEvents :one
+SELECT COUNT(*) FROM audit_events
+WHERE (sqlc.narg(involving_user_id)::uuid IS NULL OR actor_id = sqlc.narg(involving_user_id)::uuid OR user_id = sqlc.narg(involving_user_id)::uuid)
+  AND (sqlc.narg(actor_id)::uuid IS NULL OR actor_id = sqlc.narg(actor_id)::uuid)
+  AND (sqlc.narg(user_id)::uuid IS NULL OR user_id = sqlc.narg(user_id)::uuid)
+  AND (sqlc.arg(action)::text = '' OR action = sqlc.arg(action)::text)
+  AND (sqlc.arg(resource_type)::text = '' OR resource_type = sqlc.arg(resource_type)::text)
+  AND (sqlc.narg(since)::timestamptz IS NULL OR created_at >= sqlc.narg(since)::timestamptz)
+  AND (sqlc.narg(until)::timestamptz IS NULL OR created_at < sqlc.narg(until)::timestamptz);
@@ -0,0 +1,20 @@
+-- name: ScheduleAccountDeletion :one
+-- Keeps the date of an earlier request; the no-op update returns the existing row.
+INSERT INTO account_deletions (user_id, scheduled_for)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+RETURNING user_id, scheduled_for, created_at;
+
+-- name: GetAccountDeletion :one
+SELECT user_id, scheduled_for, created_at FROM account_deletions
+WHERE user_id = $1;
+
+-- name: CancelAccountDeletion :execrows
+DELETE FROM account_deletions
+WHERE user_id = $1;
+
+-- name: ListDueAccountDeletions :many
+SELECT user_id, scheduled_for, created_at FROM account_deletions
+WHERE scheduled_for <= sqlc.arg(due_at)
+ORDER BY scheduled_for
+LIMIT sqlc.arg(max_rows);
@@ -0,0 +1,33 @@
+-- name: CreateIdentity :one
+INSERT INTO identities (user_id, provider, subject, email, last_login_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
+
+-- name: GetIdentityByProviderSubject :one
+SELECT * FROM identities
+WHERE provider = $1 AND subject = $2;
+
+-- name: ListIdentitiesByUserID :many
+SELECT * FROM identities
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: UpdateIdentityLogin :exec
+UPDATE identities
+SET email = $2,
+    last_login_at = NOW()
+WHERE id = $1;
+
+-- name: CreateOIDCLoginRequest :one
+INSERT INTO oidc_login_requests (provider, state_hash, nonce, code_verifier, redirect_url, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ConsumeOIDCLoginRequest :one
+DELETE FROM oidc_login_requests
+WHERE state_hash = $1
+RETURNING *;
+
+-- name: CleanupExpiredOIDCLoginRequests :exec
+DELETE FROM oidc_login_requests
+WHERE expires_at < NOW();
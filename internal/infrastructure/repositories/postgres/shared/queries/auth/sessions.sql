@@ -0,0 +1,47 @@
+-- name: CreateSession :one
+INSERT INTO sessions (user_id, family_id, refresh_token_hash, device_name, ip_address, user_agent, authenticated_at, expires_at, second_factor_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetSessionByID :one
+SELECT * FROM sessions
+WHERE id = $1;
+
+-- name: GetSessionByRefreshTokenHash :one
+SELECT * FROM sessions
+WHERE refresh_token_hash = $1
+FOR UPDATE;
+
+-- name: ListActiveSessionsByUserID :many
+SELECT * FROM sessions
+WHERE user_id = $1
+  AND rotated_at IS NULL
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+ORDER BY last_seen_at DESC;
+
+-- name: RotateSession :exec
+UPDATE sessions
+SET rotated_at = NOW()
+WHERE id = $1;
+
+-- name: RevokeSessionFamily :exec
+UPDATE sessions
+SET revoked_at = NOW()
+WHERE family_id = $1
+  AND revoked_at IS NULL;
+
+-- name: MarkSessionSecondFactor :exec
+UPDATE sessions
+SET second_factor_at = NOW()
+WHERE id = $1;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET last_seen_at = NOW()
+WHERE id = $1
+  AND last_seen_at < NOW() - INTERVAL '1 minute';
+
+-- name: CleanupExpiredSessions :exec
+DELETE FROM sessions
+WHERE expires_at < NOW() - INTERVAL '1 day';
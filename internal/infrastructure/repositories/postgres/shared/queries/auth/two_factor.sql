@@ -0,0 +1,104 @@
+-- name: UpsertTOTPSecret :one
+INSERT INTO totp_secrets (user_id, encrypted_secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET encrypted_secret = EXCLUDED.encrypted_secret,
+    confirmed_at = NULL,
+    last_used_step = 0,
+    created_at = NOW()
+RETURNING *;
+
+-- name: GetTOTPSecretByUserID :one
+SELECT * FROM totp_secrets
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: ConfirmTOTPSecret :exec
+UPDATE totp_secrets
+SET confirmed_at = NOW()
+WHERE user_id = $1;
+
+-- name: UpdateTOTPLastUsedStep :exec
+UPDATE totp_secrets
+SET last_used_step = $2
+WHERE user_id = $1;
+
+-- name: DeleteTOTPSecret :exec
+DELETE FROM totp_secrets
+WHERE user_id = $1;
+
+-- name: CreateWebAuthnCredential :one
+INSERT INTO webauthn_credentials (user_id, name, credential_id, public_key, sign_count, transports)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetWebAuthnCredentialByID :one
+SELECT * FROM webauthn_credentials
+WHERE id = $1;
+
+-- name: GetWebAuthnCredentialByCredentialID :one
+SELECT * FROM webauthn_credentials
+WHERE credential_id = $1
+FOR UPDATE;
+
+-- name: ListWebAuthnCredentialsByUserID :many
+SELECT * FROM webauthn_credentials
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: CountWebAuthnCredentialsByUserID :one
+SELECT COUNT(*) FROM webauthn_credentials
+WHERE user_id = $1;
+
+-- name: UpdateWebAuthnCredentialUsage :exec
+UPDATE webauthn_credentials
+SET sign_count = $2,
+    last_used_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteWebAuthnCredential :exec
+DELETE FROM webauthn_credentials
+WHERE id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1
+  AND code_hash = $2
+  AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodesByUserID :one
+SELECT COUNT(*) FROM recovery_codes
+WHERE user_id = $1
+  AND used_at IS NULL;
+
+-- name: DeleteRecoveryCodesByUserID :exec
+DELETE FROM recovery_codes
+WHERE user_id = $1;
+
+-- name: CreateTwoFactorChallenge :one
+INSERT INTO two_factor_challenges (user_id, purpose, token_hash, webauthn_challenge, session_id, device_name, ip_address, user_agent, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetTwoFactorChallengeByTokenHash :one
+SELECT * FROM two_factor_challenges
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: IncrementTwoFactorChallengeAttempts :exec
+UPDATE two_factor_challenges
+SET attempts = attempts + 1
+WHERE id = $1;
+
+-- name: DeleteTwoFactorChallenge :exec
+DELETE FROM two_factor_challenges
+WHERE id = $1;
+
+-- name: CleanupExpiredTwoFactorChallenges :exec
+DELETE FROM two_factor_challenges
+WHERE expires_at < NOW();
@@ -1,29 +1,51 @@
 -- name: CreateUser :one
 INSERT INTO users (email, first_name, last_name, email_verified)
 VALUES ($1, $2, $3, $4)
-RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at;
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role;
 
 -- name: GetUserByID :one
-SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at FROM users 
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users 
 WHERE id = $1 AND is_active = true;
 
 -- name: GetUserByEmail :one
-SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at FROM users 
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users 
 WHERE email = $1 AND is_active = true;
 
 -- name: UpdateUser :one
 UPDATE users 
-SET first_name = $2, last_name = $3, updated_at = NOW()
+SET first_name = $2, last_name = $3, memory_enabled = $4, updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at;
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role;
 
 -- name: VerifyUserEmail :one
 UPDATE users 
 SET email_verified = true, updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at;
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role;
 
 -- name: DeactivateUser :exec
 UPDATE users 
 SET is_active = false, updated_at = NOW()
-WHERE id = $1; 
\ No newline at end of file
+WHERE id = $1; 
+
+-- name: GetUserByIDIncludingInactive :one
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users
+WHERE id = $1;
+
+-- name: ListUsers :many
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountUsers :one
+SELECT COUNT(*) FROM users;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role;
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1;
@@ -0,0 +1,28 @@
+-- name: CreateBrokerConnection :one
+INSERT INTO broker_connections (user_id, name, kind, encrypted_credentials, api_base_override, settings, is_active)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, name, kind, encrypted_credentials, api_base_override, settings, is_active, created_at, updated_at;
+
+-- name: GetBrokerConnectionByID :one
+SELECT id, user_id, name, kind, encrypted_credentials, api_base_override, settings, is_active, created_at, updated_at FROM broker_connections
+WHERE id = $1;
+
+-- name: GetBrokerConnectionsByUserID :many
+SELECT id, user_id, name, kind, encrypted_credentials, api_base_override, settings, is_active, created_at, updated_at FROM broker_connections
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateBrokerConnection :one
+UPDATE broker_connections
+SET
+    name = $2,
+    encrypted_credentials = $3,
+    api_base_override = $4,
+    settings = $5,
+    is_active = $6
+WHERE id = $1
+RETURNING id, user_id, name, kind, encrypted_credentials, api_base_override, settings, is_active, created_at, updated_at;
+
+-- name: DeleteBrokerConnection :exec
+DELETE FROM broker_connections
+WHERE id = $1;
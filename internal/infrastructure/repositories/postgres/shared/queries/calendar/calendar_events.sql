@@ -0,0 +1,33 @@
+-- name: UpsertCalendarEvent :one
+INSERT INTO calendar_events (user_id, type, symbol, title, description, scheduled_at, all_day, importance, amount, ratio, source, external_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (user_id, source, external_id) DO UPDATE
+SET
+    type = EXCLUDED.type,
+    symbol = EXCLUDED.symbol,
+    title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    scheduled_at = EXCLUDED.scheduled_at,
+    all_day = EXCLUDED.all_day,
+    importance = EXCLUDED.importance,
+    amount = EXCLUDED.amount,
+    ratio = EXCLUDED.ratio
+RETURNING id, user_id, type, symbol, title, description, scheduled_at, all_day, importance, amount, ratio, source, external_id, created_at, updated_at;
+
+-- name: GetCalendarEventByID :one
+SELECT id, user_id, type, symbol, title, description, scheduled_at, all_day, importance, amount, ratio, source, external_id, created_at, updated_at FROM calendar_events
+WHERE id = $1;
+
+-- name: ListCalendarEvents :many
+SELECT id, user_id, type, symbol, title, description, scheduled_at, all_day, importance, amount, ratio, source, external_id, created_at, updated_at FROM calendar_events
+WHERE (user_id IS NULL OR user_id = sqlc.arg(user_id))
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR scheduled_at >= sqlc.narg(start_time)::timestamptz)
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR scheduled_at < sqlc.narg(end_time)::timestamptz)
+  AND (cardinality(sqlc.arg(symbols)::text[]) = 0 OR symbol IS NULL OR symbol = ANY(sqlc.arg(symbols)::text[]))
+  AND (cardinality(sqlc.arg(types)::text[]) = 0 OR type = ANY(sqlc.arg(types)::text[]))
+ORDER BY scheduled_at ASC, created_at ASC
+LIMIT sqlc.narg(max_rows) OFFSET sqlc.arg(skip_rows);
+
+-- name: DeleteCalendarEvent :exec
+DELETE FROM calendar_events
+WHERE id = $1;
@@ -1,23 +1,50 @@
 -- name: CreateArtifact :one
-INSERT INTO artifacts (message_id, title, type, language, content, content_hash, size, is_public)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at;
+INSERT INTO artifacts (message_id, title, type, language, content, content_hash, size, is_public, version, root_artifact_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id;
 
 -- name: GetArtifactByID :one
-SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at FROM artifacts
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
 WHERE id = $1;
 
 -- name: GetArtifactsByMessageID :many
-SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at FROM artifacts
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
 WHERE message_id = $1
 ORDER BY created_at ASC;
 
 -- name: GetPublicArtifacts :many
-SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at FROM artifacts
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
 WHERE is_public = true
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
+-- name: GetArtifactVersions :many
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
+WHERE id = $1 OR root_artifact_id = $1
+ORDER BY version ASC;
+
+-- name: ListLatestArtifactsByUserAndType :many
+SELECT a.id, a.message_id, a.title, a.type, a.language, a.content, a.content_hash, a.size, a.is_public, a.created_at, a.updated_at, a.version, a.root_artifact_id
+FROM artifacts a
+JOIN messages m ON m.id = a.message_id
+JOIN conversations c ON c.id = m.conversation_id
+WHERE c.user_id = $1
+  AND a.type = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM artifacts newer
+    WHERE COALESCE(newer.root_artifact_id, newer.id) = COALESCE(a.root_artifact_id, a.id)
+      AND newer.version > a.version
+  )
+ORDER BY a.created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: GetArtifactOwnerID :one
+SELECT c.user_id
+FROM artifacts a
+JOIN messages m ON m.id = a.message_id
+JOIN conversations c ON c.id = m.conversation_id
+WHERE a.id = $1;
+
 -- name: UpdateArtifact :one
 UPDATE artifacts
 SET
@@ -27,8 +54,8 @@ SET
     size = $5,
     is_public = $6
 WHERE id = $1
-RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at;
+RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id;
 
 -- name: DeleteArtifact :exec
 DELETE FROM artifacts
-WHERE id = $1; 
\ No newline at end of file
+WHERE id = $1;
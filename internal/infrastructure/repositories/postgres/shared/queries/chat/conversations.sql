@@ -1,18 +1,33 @@
 -- name: CreateConversation :one
-INSERT INTO conversations (user_id, title, model_id, system_prompt, settings)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at;
+INSERT INTO conversations (user_id, title, model_id, system_prompt, settings, organization_id, organization_permission)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission;
 
 -- name: GetConversationByID :one
-SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at FROM conversations
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
 WHERE id = $1;
 
 -- name: GetConversationsByUserID :many
-SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at FROM conversations
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
 WHERE user_id = $1 AND is_archived = false
 ORDER BY last_message_at DESC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListAllConversationsByUserID :many
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
+WHERE user_id = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3;
+
+-- name: ListAccessibleConversations :many
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
+WHERE is_archived = false
+  AND (user_id = sqlc.arg(user_id) OR organization_id IN (
+      SELECT m.organization_id FROM organization_members m WHERE m.user_id = sqlc.arg(user_id)))
+  AND (sqlc.narg(organization_id)::uuid IS NULL OR organization_id = sqlc.narg(organization_id)::uuid)
+ORDER BY last_message_at DESC NULLS LAST, created_at DESC
+LIMIT sqlc.arg(max_rows) OFFSET sqlc.arg(skip_rows);
+
 -- name: UpdateConversation :one
 UPDATE conversations
 SET
@@ -21,7 +36,7 @@ SET
     system_prompt = $4,
     settings = $5
 WHERE id = $1
-RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at;
+RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission;
 
 -- name: UpdateConversationLastMessageAt :exec
 UPDATE conversations
@@ -33,6 +48,13 @@ UPDATE conversations
 SET title = $2
 WHERE id = $1;
 
+-- name: SetConversationOrganization :exec
+UPDATE conversations
+SET
+    organization_id = $2,
+    organization_permission = $3
+WHERE id = $1;
+
 -- name: ArchiveConversation :exec
 UPDATE conversations
 SET is_archived = true
@@ -40,4 +62,8 @@ WHERE id = $1;
 
 -- name: DeleteConversation :exec
 DELETE FROM conversations
-WHERE id = $1; 
\ No newline at end of file
+WHERE id = $1; 
+
+-- name: CountUserConversationsSince :one
+SELECT COUNT(*) FROM conversations
+WHERE user_id = $1 AND created_at >= $2;
@@ -0,0 +1,13 @@
+-- name: GetCachedEmbeddings :many
+SELECT model, content_hash, embedding, created_at FROM embedding_cache
+WHERE model = sqlc.arg(model) AND content_hash = ANY(sqlc.arg(content_hashes)::text[]);
+
+-- name: PutCachedEmbedding :exec
+INSERT INTO embedding_cache (model, content_hash, embedding)
+VALUES ($1, $2, $3)
+ON CONFLICT (model, content_hash) DO NOTHING;
+
+-- name: DeleteCachedEmbeddings :exec
+-- Deletes the embeddings of the given texts for every model.
+DELETE FROM embedding_cache
+WHERE content_hash = ANY(sqlc.arg(content_hashes)::text[]);
@@ -0,0 +1,32 @@
+-- name: CreateMessageEmbedding :exec
+INSERT INTO message_embeddings (message_id, conversation_id, user_id, embedding_model, embedding)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (message_id) DO UPDATE
+SET embedding_model = EXCLUDED.embedding_model, embedding = EXCLUDED.embedding;
+
+-- name: SearchMessageEmbeddings :many
+SELECT m.id, m.conversation_id, c.title AS conversation_title, m.role, m.content, m.created_at,
+    (1 - (e.embedding::vector <=> sqlc.arg(query)::text::vector))::float8 AS similarity
+FROM message_embeddings e
+JOIN messages m ON m.id = e.message_id
+JOIN conversations c ON c.id = e.conversation_id
+WHERE e.user_id = sqlc.arg(user_id) AND e.embedding_model = sqlc.arg(embedding_model)
+  AND e.conversation_id <> sqlc.arg(exclude_conversation_id) AND c.is_archived IS NOT TRUE
+ORDER BY e.embedding::vector <=> sqlc.arg(query)::text::vector
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListMessageEmbeddingsByUserID :many
+SELECT e.message_id, e.conversation_id, e.user_id, e.embedding_model, e.embedding, e.created_at
+FROM message_embeddings e
+JOIN conversations c ON c.id = e.conversation_id
+WHERE e.user_id = sqlc.arg(user_id) AND e.embedding_model = sqlc.arg(embedding_model)
+  AND e.conversation_id <> sqlc.arg(exclude_conversation_id) AND c.is_archived IS NOT TRUE
+  AND e.message_id > sqlc.arg(after_id)
+ORDER BY e.message_id ASC
+LIMIT sqlc.arg(max_rows);
+
+-- name: ListMessageMatchesByIDs :many
+SELECT m.id, m.conversation_id, c.title AS conversation_title, m.role, m.content, m.created_at
+FROM messages m
+JOIN conversations c ON c.id = m.conversation_id
+WHERE m.id = ANY(sqlc.arg(message_ids)::uuid[]);
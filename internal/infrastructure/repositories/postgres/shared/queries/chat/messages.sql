@@ -40,4 +40,24 @@ RETURNING id, conversation_id, parent_id, role, content, model_id, token_count,
 
 -- name: DeleteMessage :exec
 DELETE FROM messages
-WHERE id = $1; 
\ No newline at end of file
+WHERE id = $1; 
+-- name: GetToolMessageByCallID :one
+SELECT id, conversation_id, parent_id, role, content, model_id, token_count, cost, metadata, created_at, updated_at FROM messages
+WHERE conversation_id = sqlc.arg(conversation_id)
+  AND role = 'tool'
+  AND metadata->>'tool_call_id' = sqlc.arg(tool_call_id)::text
+ORDER BY created_at DESC
+LIMIT 1
+FOR UPDATE;
+
+-- name: GetUserUsageByModel :many
+SELECT
+    m.model_id,
+    COUNT(*) AS message_count,
+    COALESCE(SUM(m.token_count), 0)::bigint AS token_count,
+    COALESCE(SUM(m.cost), 0)::float8 AS cost
+FROM messages m
+JOIN conversations c ON c.id = m.conversation_id
+WHERE c.user_id = $1 AND m.role = 'assistant' AND m.created_at >= $2
+GROUP BY m.model_id
+ORDER BY message_count DESC;
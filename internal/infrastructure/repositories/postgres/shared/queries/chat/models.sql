@@ -1,27 +1,27 @@
 -- name: CreateModel :one
 INSERT INTO models (
-    provider_id, name, display_name, supports_functions, supports_vision, is_active
+    provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at;
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million;
 
 -- name: GetModelByID :one
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE id = $1
 LIMIT 1;
 
 -- name: GetModelByName :one
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE provider_id = $1 AND name = $2
 LIMIT 1;
 
 -- name: GetModelsByProviderID :many
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE provider_id = $1
 ORDER BY display_name;
 
 -- name: GetActiveModelsByProviderID :many
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE provider_id = $1 AND is_active = TRUE
 ORDER BY name;
 
@@ -31,9 +31,16 @@ SET
     display_name = $2,
     supports_functions = $3,
     supports_vision = $4,
-    is_active = $5
+    supports_embeddings = $5,
+    is_active = $6,
+    input_price_per_million = $7,
+    output_price_per_million = $8
 WHERE id = $1
-RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at;
+RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million;
+
+-- name: GetAllModels :many
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
+ORDER BY provider_id, display_name;
 
 -- name: DeleteModel :exec
 DELETE FROM models
@@ -51,6 +51,7 @@ SELECT
     m.display_name as model_display_name,
     m.supports_functions as model_supports_functions,
     m.supports_vision as model_supports_vision,
+    m.supports_embeddings as model_supports_embeddings,
     m.is_active as model_is_active,
     m.created_at as model_created_at,
     m.updated_at as model_updated_at
@@ -69,6 +70,7 @@ SELECT
     m.display_name as model_display_name,
     m.supports_functions as model_supports_functions,
     m.supports_vision as model_supports_vision,
+    m.supports_embeddings as model_supports_embeddings,
     ups.id as setting_id,
     ups.encrypted_api_key as has_api_key,
     ups.is_active as setting_is_active
@@ -11,6 +11,10 @@ WHERE id = $1;
 SELECT id, name, description, schema, provider_id, is_active, created_at, updated_at FROM tools
 WHERE name = $1;
 
+-- name: GetAllTools :many
+SELECT id, name, description, schema, provider_id, is_active, created_at, updated_at FROM tools
+ORDER BY name;
+
 -- name: GetAvailableTools :many
 SELECT id, name, description, schema, provider_id, is_active, created_at, updated_at FROM tools
 WHERE is_active = true AND (provider_id IS NULL OR provider_id = $1)
@@ -0,0 +1,30 @@
+-- name: CreateUserMemory :one
+INSERT INTO user_memories (user_id, category, content, source_conversation_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, category, content, source_conversation_id, created_at, updated_at;
+
+-- name: GetUserMemoryByID :one
+SELECT id, user_id, category, content, source_conversation_id, created_at, updated_at FROM user_memories
+WHERE id = $1;
+
+-- name: ListUserMemoriesByUserID :many
+SELECT id, user_id, category, content, source_conversation_id, created_at, updated_at FROM user_memories
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY updated_at DESC
+LIMIT sqlc.arg(max_rows);
+
+-- name: CountUserMemoriesByUserID :one
+SELECT COUNT(*) FROM user_memories
+WHERE user_id = $1;
+
+-- name: UpdateUserMemory :one
+UPDATE user_memories
+SET
+    category = $2,
+    content = $3
+WHERE id = $1
+RETURNING id, user_id, category, content, source_conversation_id, created_at, updated_at;
+
+-- name: DeleteUserMemory :exec
+DELETE FROM user_memories
+WHERE id = $1;
@@ -1,17 +1,41 @@
 -- name: CreateUserProviderSetting :one
-INSERT INTO user_provider_settings (user_id, provider_id, encrypted_api_key, api_base_override, is_active)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at;
+INSERT INTO user_provider_settings (user_id, organization_id, provider_id, encrypted_api_key, api_base_override, is_active)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id;
 
 -- name: GetUserProviderSetting :one
-SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at FROM user_provider_settings
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
 WHERE user_id = $1 AND provider_id = $2;
 
 -- name: ListUserProviderSettings :many
-SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at FROM user_provider_settings
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
 WHERE user_id = $1 AND is_active = true
 ORDER BY created_at DESC;
 
+-- name: GetOrganizationProviderSetting :one
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
+WHERE organization_id = $1 AND provider_id = $2;
+
+-- name: ListOrganizationProviderSettings :many
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetProviderSettingForUser :one
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
+WHERE provider_id = sqlc.arg(provider_id)
+  AND (user_id = sqlc.arg(user_id) OR organization_id IN (
+      SELECT m.organization_id FROM organization_members m WHERE m.user_id = sqlc.arg(user_id)))
+ORDER BY
+    (COALESCE(is_active, false) AND COALESCE(encrypted_api_key, '') <> '') DESC,
+    CASE
+        WHEN organization_id = sqlc.narg(organization_id)::uuid THEN 0
+        WHEN user_id = sqlc.arg(user_id) THEN 1
+        ELSE 2
+    END,
+    created_at ASC
+LIMIT 1;
+
 -- name: UpdateUserProviderSetting :one
 UPDATE user_provider_settings
 SET
@@ -19,8 +43,8 @@ SET
     api_base_override = $3,
     is_active = $4
 WHERE id = $1
-RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at;
+RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id;
 
 -- name: DeleteUserProviderSetting :exec
 DELETE FROM user_provider_settings
-WHERE id = $1; 
\ No newline at end of file
+WHERE id = $1; 
@@ -0,0 +1,36 @@
+-- name: CreateDocumentChunk :exec
+INSERT INTO document_chunks (document_id, chunk_index, content, page, heading)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListDocumentChunksByDocumentID :many
+SELECT id, document_id, chunk_index, content, page, heading, embedding, created_at FROM document_chunks
+WHERE document_id = $1
+ORDER BY chunk_index ASC;
+
+-- name: DeleteDocumentChunksByDocumentID :exec
+DELETE FROM document_chunks
+WHERE document_id = $1;
+
+-- name: SetDocumentChunkEmbedding :exec
+UPDATE document_chunks
+SET embedding = $2
+WHERE id = $1;
+
+-- name: ListEmbeddedDocumentChunks :many
+SELECT c.id, c.document_id, c.chunk_index, c.content, c.page, c.heading, c.embedding, c.created_at FROM document_chunks c
+JOIN documents d ON d.id = c.document_id
+WHERE d.status = 'ready' AND c.embedding IS NOT NULL AND c.id > sqlc.arg(after_id)
+ORDER BY c.id ASC
+LIMIT sqlc.arg(max_rows);
+
+-- name: SearchDocumentChunks :many
+SELECT id, document_id, chunk_index, content, page, heading, created_at,
+    (1 - (embedding::vector <=> sqlc.arg(query)::text::vector))::float8 AS similarity
+FROM document_chunks
+WHERE document_id = ANY(sqlc.arg(document_ids)::uuid[]) AND embedding IS NOT NULL
+ORDER BY embedding::vector <=> sqlc.arg(query)::text::vector
+LIMIT sqlc.arg(max_rows);
+
+-- name: CountVectorExtensions :one
+SELECT COUNT(*) FROM pg_extension
+WHERE extname = 'vector';
@@ -0,0 +1,66 @@
+-- name: CreateDocument :one
+INSERT INTO documents (user_id, title, filename, format, size, content_hash, status, chunk_count)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, user_id, title, filename, format, size, content_hash, status, error, chunk_count, embedding_model, created_at, updated_at;
+
+-- name: GetDocumentByID :one
+SELECT id, user_id, title, filename, format, size, content_hash, status, error, chunk_count, embedding_model, created_at, updated_at FROM documents
+WHERE id = $1;
+
+-- name: ListDocumentsByUserID :many
+SELECT id, user_id, title, filename, format, size, content_hash, status, error, chunk_count, embedding_model, created_at, updated_at FROM documents
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_at DESC
+LIMIT sqlc.narg(max_rows) OFFSET sqlc.arg(skip_rows);
+
+-- name: ListReadyDocumentsByUserID :many
+SELECT id, user_id, title, filename, format, size, content_hash, status, error, chunk_count, embedding_model, created_at, updated_at FROM documents
+WHERE user_id = $1 AND status = 'ready' AND embedding_model = $2
+ORDER BY created_at DESC;
+
+-- name: ListDocumentsByKnowledgeBaseID :many
+SELECT d.id, d.user_id, d.title, d.filename, d.format, d.size, d.content_hash, d.status, d.error, d.chunk_count, d.embedding_model, d.created_at, d.updated_at FROM documents d
+JOIN knowledge_base_documents kbd ON kbd.document_id = d.id
+WHERE kbd.knowledge_base_id = $1
+ORDER BY d.title ASC;
+
+-- name: ListReadyDocumentsByConversationID :many
+SELECT d.id, d.user_id, d.title, d.filename, d.format, d.size, d.content_hash, d.status, d.error, d.chunk_count, d.embedding_model, d.created_at, d.updated_at FROM documents d
+WHERE d.status = 'ready' AND d.embedding_model = sqlc.arg(embedding_model)
+  AND d.id IN (
+    SELECT kbd.document_id FROM knowledge_base_documents kbd
+    JOIN conversation_knowledge_bases ckb ON ckb.knowledge_base_id = kbd.knowledge_base_id
+    JOIN knowledge_bases kb ON kb.id = ckb.knowledge_base_id
+    LEFT JOIN knowledge_base_members m ON m.knowledge_base_id = kb.id AND m.user_id = sqlc.arg(user_id)
+    WHERE ckb.conversation_id = sqlc.arg(conversation_id)
+      AND (kb.user_id = sqlc.arg(user_id) OR m.user_id IS NOT NULL)
+  )
+ORDER BY d.created_at DESC;
+
+-- name: UpdateDocument :one
+UPDATE documents
+SET
+    title = $2,
+    filename = $3,
+    format = $4,
+    size = $5,
+    content_hash = $6,
+    status = $7,
+    error = $8,
+    chunk_count = $9,
+    embedding_model = $10
+WHERE id = $1
+RETURNING id, user_id, title, filename, format, size, content_hash, status, error, chunk_count, embedding_model, created_at, updated_at;
+
+-- name: UpdateDocumentStatus :one
+UPDATE documents
+SET
+    status = $2,
+    error = $3,
+    embedding_model = $4
+WHERE id = $1
+RETURNING id, user_id, title, filename, format, size, content_hash, status, error, chunk_count, embedding_model, created_at, updated_at;
+
+-- name: DeleteDocument :exec
+DELETE FROM documents
+WHERE id = $1;
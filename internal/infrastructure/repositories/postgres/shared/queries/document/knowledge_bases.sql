@@ -0,0 +1,73 @@
+-- name: CreateKnowledgeBase :one
+INSERT INTO knowledge_bases (user_id, name, description)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, description, created_at, updated_at;
+
+-- name: GetKnowledgeBaseForUser :one
+SELECT kb.id, kb.user_id, kb.name, kb.description, kb.created_at, kb.updated_at,
+    (CASE WHEN kb.user_id = sqlc.arg(user_id) THEN 'owner' ELSE COALESCE(m.role, '') END)::text AS role
+FROM knowledge_bases kb
+LEFT JOIN knowledge_base_members m ON m.knowledge_base_id = kb.id AND m.user_id = sqlc.arg(user_id)
+WHERE kb.id = sqlc.arg(id);
+
+-- name: ListKnowledgeBasesForUser :many
+SELECT kb.id, kb.user_id, kb.name, kb.description, kb.created_at, kb.updated_at,
+    (CASE WHEN kb.user_id = sqlc.arg(user_id) THEN 'owner' ELSE COALESCE(m.role, '') END)::text AS role
+FROM knowledge_bases kb
+LEFT JOIN knowledge_base_members m ON m.knowledge_base_id = kb.id AND m.user_id = sqlc.arg(user_id)
+WHERE kb.user_id = sqlc.arg(user_id) OR m.user_id IS NOT NULL
+ORDER BY kb.name ASC;
+
+-- name: ListKnowledgeBasesByConversationID :many
+SELECT kb.id, kb.user_id, kb.name, kb.description, kb.created_at, kb.updated_at,
+    (CASE WHEN kb.user_id = sqlc.arg(user_id) THEN 'owner' ELSE COALESCE(m.role, '') END)::text AS role
+FROM conversation_knowledge_bases ckb
+JOIN knowledge_bases kb ON kb.id = ckb.knowledge_base_id
+LEFT JOIN knowledge_base_members m ON m.knowledge_base_id = kb.id AND m.user_id = sqlc.arg(user_id)
+WHERE ckb.conversation_id = sqlc.arg(conversation_id)
+ORDER BY kb.name ASC;
+
+-- name: UpdateKnowledgeBase :one
+UPDATE knowledge_bases
+SET
+    name = $2,
+    description = $3
+WHERE id = $1
+RETURNING id, user_id, name, description, created_at, updated_at;
+
+-- name: DeleteKnowledgeBase :exec
+DELETE FROM knowledge_bases
+WHERE id = $1;
+
+-- name: UpsertKnowledgeBaseMember :exec
+INSERT INTO knowledge_base_members (knowledge_base_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (knowledge_base_id, user_id) DO UPDATE SET role = EXCLUDED.role;
+
+-- name: ListKnowledgeBaseMembers :many
+SELECT m.knowledge_base_id, m.user_id, u.email, m.role, m.created_at FROM knowledge_base_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.knowledge_base_id = $1
+ORDER BY m.created_at ASC;
+
+-- name: DeleteKnowledgeBaseMember :exec
+DELETE FROM knowledge_base_members
+WHERE knowledge_base_id = $1 AND user_id = $2;
+
+-- name: AddKnowledgeBaseDocument :exec
+INSERT INTO knowledge_base_documents (knowledge_base_id, document_id)
+VALUES ($1, $2)
+ON CONFLICT (knowledge_base_id, document_id) DO NOTHING;
+
+-- name: DeleteKnowledgeBaseDocument :exec
+DELETE FROM knowledge_base_documents
+WHERE knowledge_base_id = $1 AND document_id = $2;
+
+-- name: AttachKnowledgeBaseToConversation :exec
+INSERT INTO conversation_knowledge_bases (conversation_id, knowledge_base_id)
+VALUES ($1, $2)
+ON CONFLICT (conversation_id, knowledge_base_id) DO NOTHING;
+
+-- name: DetachKnowledgeBaseFromConversation :exec
+DELETE FROM conversation_knowledge_bases
+WHERE conversation_id = $1 AND knowledge_base_id = $2;
@@ -0,0 +1,35 @@
+-- name: ListEncryptedProviderAPIKeys :many
+SELECT id, encrypted_api_key FROM user_provider_settings
+WHERE id > sqlc.arg(after_id) AND encrypted_api_key IS NOT NULL
+ORDER BY id
+LIMIT sqlc.arg(max_rows)
+FOR UPDATE;
+
+-- name: UpdateEncryptedProviderAPIKey :exec
+UPDATE user_provider_settings
+SET encrypted_api_key = $2
+WHERE id = $1;
+
+-- name: ListEncryptedBrokerCredentials :many
+SELECT id, encrypted_credentials FROM broker_connections
+WHERE id > sqlc.arg(after_id) AND encrypted_credentials IS NOT NULL
+ORDER BY id
+LIMIT sqlc.arg(max_rows)
+FOR UPDATE;
+
+-- name: UpdateEncryptedBrokerCredentials :exec
+UPDATE broker_connections
+SET encrypted_credentials = $2
+WHERE id = $1;
+
+-- name: ListEncryptedTOTPSecrets :many
+SELECT user_id, encrypted_secret FROM totp_secrets
+WHERE user_id > sqlc.arg(after_id)
+ORDER BY user_id
+LIMIT sqlc.arg(max_rows)
+FOR UPDATE;
+
+-- name: UpdateEncryptedTOTPSecret :exec
+UPDATE totp_secrets
+SET encrypted_secret = $2
+WHERE user_id = $1;
@@ -0,0 +1,43 @@
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (user_id, symbol, side, quantity, entry_price, entry_time, exit_price, exit_time, fees, setup, thesis, notes, tags, conversation_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, user_id, symbol, side, quantity, entry_price, entry_time, exit_price, exit_time, fees, setup, thesis, notes, tags, conversation_id, created_at, updated_at;
+
+-- name: GetJournalEntryByID :one
+SELECT id, user_id, symbol, side, quantity, entry_price, entry_time, exit_price, exit_time, fees, setup, thesis, notes, tags, conversation_id, created_at, updated_at FROM journal_entries
+WHERE id = $1;
+
+-- name: ListJournalEntries :many
+SELECT id, user_id, symbol, side, quantity, entry_price, entry_time, exit_price, exit_time, fees, setup, thesis, notes, tags, conversation_id, created_at, updated_at FROM journal_entries
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR entry_time >= sqlc.narg(start_time)::timestamptz)
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR entry_time < sqlc.narg(end_time)::timestamptz)
+  AND (sqlc.arg(symbol)::text = '' OR symbol = sqlc.arg(symbol)::text)
+  AND (sqlc.arg(tag)::text = '' OR sqlc.arg(tag)::text = ANY(tags))
+  AND (sqlc.arg(setup)::text = '' OR setup = sqlc.arg(setup)::text)
+  AND (sqlc.narg(conversation_id)::uuid IS NULL OR conversation_id = sqlc.narg(conversation_id)::uuid)
+ORDER BY entry_time DESC, created_at DESC
+LIMIT sqlc.narg(max_rows) OFFSET sqlc.arg(skip_rows);
+
+-- name: UpdateJournalEntry :one
+UPDATE journal_entries
+SET
+    symbol = $2,
+    side = $3,
+    quantity = $4,
+    entry_price = $5,
+    entry_time = $6,
+    exit_price = $7,
+    exit_time = $8,
+    fees = $9,
+    setup = $10,
+    thesis = $11,
+    notes = $12,
+    tags = $13,
+    conversation_id = $14
+WHERE id = $1
+RETURNING id, user_id, symbol, side, quantity, entry_price, entry_time, exit_price, exit_time, fees, setup, thesis, notes, tags, conversation_id, created_at, updated_at;
+
+-- name: DeleteJournalEntry :exec
+DELETE FROM journal_entries
+WHERE id = $1;
@@ -0,0 +1,12 @@
+-- name: AddJournalScreenshot :exec
+INSERT INTO journal_screenshots (entry_id, artifact_id, position)
+VALUES ($1, $2, $3);
+
+-- name: DeleteJournalScreenshots :exec
+DELETE FROM journal_screenshots
+WHERE entry_id = $1;
+
+-- name: GetJournalScreenshotsByEntryIDs :many
+SELECT entry_id, artifact_id, position FROM journal_screenshots
+WHERE entry_id = ANY(sqlc.arg(entry_ids)::uuid[])
+ORDER BY entry_id, position;
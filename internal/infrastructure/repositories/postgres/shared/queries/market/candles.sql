@@ -0,0 +1,26 @@
+-- name: UpsertCandle :one
+INSERT INTO candles (symbol, timeframe, open_time, open, high, low, close, volume)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (symbol, timeframe, open_time) DO UPDATE
+SET
+    open = EXCLUDED.open,
+    high = EXCLUDED.high,
+    low = EXCLUDED.low,
+    close = EXCLUDED.close,
+    volume = EXCLUDED.volume
+RETURNING id, symbol, timeframe, open_time, open, high, low, close, volume, created_at;
+
+-- name: GetCandlesInRange :many
+SELECT id, symbol, timeframe, open_time, open, high, low, close, volume, created_at FROM candles
+WHERE symbol = sqlc.arg(symbol)
+  AND timeframe = sqlc.arg(timeframe)
+  AND open_time >= sqlc.arg(start_time)::timestamptz
+  AND open_time < sqlc.arg(end_time)::timestamptz
+ORDER BY open_time ASC
+LIMIT sqlc.arg(max_rows);
+
+-- name: GetLatestCandles :many
+SELECT id, symbol, timeframe, open_time, open, high, low, close, volume, created_at FROM candles
+WHERE symbol = $1 AND timeframe = $2
+ORDER BY open_time DESC
+LIMIT $3;
@@ -0,0 +1,33 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id, type, title, body, data)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, type, title, body, data, read_at, created_at;
+
+-- name: GetNotificationByID :one
+SELECT id, user_id, type, title, body, data, read_at, created_at FROM notifications
+WHERE id = $1;
+
+-- name: GetNotificationsByUserID :many
+SELECT id, user_id, type, title, body, data, read_at, created_at FROM notifications
+WHERE user_id = sqlc.arg(user_id)
+  AND (NOT sqlc.arg(unread_only)::boolean OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_rows) OFFSET sqlc.arg(skip_rows);
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :exec
+UPDATE notifications
+SET read_at = NOW()
+WHERE id = $1 AND read_at IS NULL;
+
+-- name: MarkAllNotificationsRead :exec
+UPDATE notifications
+SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: DeleteNotification :exec
+DELETE FROM notifications
+WHERE id = $1;
@@ -0,0 +1,35 @@
+-- name: UpsertOrganizationInvitation :one
+INSERT INTO organization_invitations (organization_id, user_id, role, invited_by, magic_link_id)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET
+    role = EXCLUDED.role,
+    invited_by = EXCLUDED.invited_by,
+    magic_link_id = EXCLUDED.magic_link_id,
+    created_at = NOW()
+RETURNING id, organization_id, user_id, role, invited_by, magic_link_id, created_at;
+
+-- name: GetOrganizationInvitationByID :one
+SELECT i.id, i.organization_id, i.user_id, u.email, i.role, i.invited_by, i.magic_link_id, ml.expires_at, i.created_at
+FROM organization_invitations i
+JOIN users u ON u.id = i.user_id
+JOIN magic_links ml ON ml.id = i.magic_link_id
+WHERE i.id = $1;
+
+-- name: GetOrganizationInvitationByMagicLinkID :one
+SELECT i.id, i.organization_id, i.user_id, u.email, i.role, i.invited_by, i.magic_link_id, ml.expires_at, i.created_at
+FROM organization_invitations i
+JOIN users u ON u.id = i.user_id
+JOIN magic_links ml ON ml.id = i.magic_link_id
+WHERE i.magic_link_id = $1;
+
+-- name: ListOrganizationInvitations :many
+SELECT i.id, i.organization_id, i.user_id, u.email, i.role, i.invited_by, i.magic_link_id, ml.expires_at, i.created_at
+FROM organization_invitations i
+JOIN users u ON u.id = i.user_id
+JOIN magic_links ml ON ml.id = i.magic_link_id
+WHERE i.organization_id = $1
+ORDER BY i.created_at DESC;
+
+-- name: DeleteOrganizationInvitation :exec
+DELETE FROM organization_invitations
+WHERE id = $1;
@@ -0,0 +1,42 @@
+-- name: CreateOrganization :one
+INSERT INTO organizations (name)
+VALUES ($1)
+RETURNING id, name, created_at, updated_at;
+
+-- name: GetOrganizationForUser :one
+SELECT o.id, o.name, o.created_at, o.updated_at, COALESCE(m.role, '')::text AS role
+FROM organizations o
+LEFT JOIN organization_members m ON m.organization_id = o.id AND m.user_id = sqlc.arg(user_id)
+WHERE o.id = sqlc.arg(id);
+
+-- name: ListOrganizationsForUser :many
+SELECT o.id, o.name, o.created_at, o.updated_at, m.role::text AS role
+FROM organizations o
+JOIN organization_members m ON m.organization_id = o.id
+WHERE m.user_id = $1
+ORDER BY o.name ASC;
+
+-- name: UpdateOrganization :one
+UPDATE organizations
+SET name = $2
+WHERE id = $1
+RETURNING id, name, created_at, updated_at;
+
+-- name: DeleteOrganization :exec
+DELETE FROM organizations
+WHERE id = $1;
+
+-- name: UpsertOrganizationMember :exec
+INSERT INTO organization_members (organization_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role;
+
+-- name: ListOrganizationMembers :many
+SELECT m.organization_id, m.user_id, u.email, m.role, m.created_at FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1
+ORDER BY m.created_at ASC;
+
+-- name: DeleteOrganizationMember :exec
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
@@ -0,0 +1,27 @@
+-- name: CreatePaperAccount :one
+INSERT INTO paper_accounts (user_id, name, timeframe, initial_cash, cash, commission_rate)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, name, timeframe, initial_cash, cash, commission_rate, created_at, updated_at;
+
+-- name: GetPaperAccountByID :one
+SELECT id, user_id, name, timeframe, initial_cash, cash, commission_rate, created_at, updated_at FROM paper_accounts
+WHERE id = $1;
+
+-- name: GetPaperAccountByIDForUpdate :one
+SELECT id, user_id, name, timeframe, initial_cash, cash, commission_rate, created_at, updated_at FROM paper_accounts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetPaperAccountsByUserID :many
+SELECT id, user_id, name, timeframe, initial_cash, cash, commission_rate, created_at, updated_at FROM paper_accounts
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdatePaperAccountCash :exec
+UPDATE paper_accounts
+SET cash = $2
+WHERE id = $1;
+
+-- name: DeletePaperAccount :exec
+DELETE FROM paper_accounts
+WHERE id = $1;
@@ -0,0 +1,30 @@
+-- name: CreatePaperOrder :one
+INSERT INTO paper_orders (account_id, symbol, side, type, quantity, limit_price, stop_price, status, reject_reason)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, account_id, symbol, side, type, quantity, limit_price, stop_price, status, fill_price, commission, reject_reason, filled_at, created_at, updated_at;
+
+-- name: GetPaperOrderByID :one
+SELECT id, account_id, symbol, side, type, quantity, limit_price, stop_price, status, fill_price, commission, reject_reason, filled_at, created_at, updated_at FROM paper_orders
+WHERE id = $1;
+
+-- name: GetPaperOrdersByAccountID :many
+SELECT id, account_id, symbol, side, type, quantity, limit_price, stop_price, status, fill_price, commission, reject_reason, filled_at, created_at, updated_at FROM paper_orders
+WHERE account_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetOpenPaperOrdersByAccountID :many
+SELECT id, account_id, symbol, side, type, quantity, limit_price, stop_price, status, fill_price, commission, reject_reason, filled_at, created_at, updated_at FROM paper_orders
+WHERE account_id = $1 AND status = 'open'
+ORDER BY created_at ASC;
+
+-- name: UpdatePaperOrderStatus :one
+UPDATE paper_orders
+SET
+    status = $2,
+    fill_price = $3,
+    commission = $4,
+    reject_reason = $5,
+    filled_at = $6
+WHERE id = $1
+RETURNING id, account_id, symbol, side, type, quantity, limit_price, stop_price, status, fill_price, commission, reject_reason, filled_at, created_at, updated_at;
@@ -0,0 +1,18 @@
+-- name: GetPaperPosition :one
+SELECT id, account_id, symbol, quantity, average_price, realized_pnl, created_at, updated_at FROM paper_positions
+WHERE account_id = $1 AND symbol = $2;
+
+-- name: GetPaperPositionsByAccountID :many
+SELECT id, account_id, symbol, quantity, average_price, realized_pnl, created_at, updated_at FROM paper_positions
+WHERE account_id = $1
+ORDER BY symbol ASC;
+
+-- name: UpsertPaperPosition :one
+INSERT INTO paper_positions (account_id, symbol, quantity, average_price, realized_pnl)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id, symbol) DO UPDATE
+SET
+    quantity = EXCLUDED.quantity,
+    average_price = EXCLUDED.average_price,
+    realized_pnl = EXCLUDED.realized_pnl
+RETURNING id, account_id, symbol, quantity, average_price, realized_pnl, created_at, updated_at;
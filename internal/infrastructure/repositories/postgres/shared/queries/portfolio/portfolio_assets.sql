@@ -0,0 +1,14 @@
+-- name: UpsertPortfolioAsset :one
+INSERT INTO portfolio_assets (portfolio_id, symbol, name, asset_class, sector)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (portfolio_id, symbol) DO UPDATE
+SET
+    name = COALESCE(EXCLUDED.name, portfolio_assets.name),
+    asset_class = COALESCE(EXCLUDED.asset_class, portfolio_assets.asset_class),
+    sector = COALESCE(EXCLUDED.sector, portfolio_assets.sector)
+RETURNING id, portfolio_id, symbol, name, asset_class, sector, created_at, updated_at;
+
+-- name: GetPortfolioAssets :many
+SELECT id, portfolio_id, symbol, name, asset_class, sector, created_at, updated_at FROM portfolio_assets
+WHERE portfolio_id = $1
+ORDER BY symbol ASC;
@@ -0,0 +1,18 @@
+-- name: CreatePortfolioTransaction :one
+INSERT INTO portfolio_transactions (portfolio_id, type, symbol, quantity, price, fee, amount, executed_at, notes, source, external_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (portfolio_id, external_id) DO NOTHING
+RETURNING id, portfolio_id, type, symbol, quantity, price, fee, amount, executed_at, notes, source, external_id, created_at;
+
+-- name: GetPortfolioTransactionByID :one
+SELECT id, portfolio_id, type, symbol, quantity, price, fee, amount, executed_at, notes, source, external_id, created_at FROM portfolio_transactions
+WHERE id = $1;
+
+-- name: GetPortfolioTransactions :many
+SELECT id, portfolio_id, type, symbol, quantity, price, fee, amount, executed_at, notes, source, external_id, created_at FROM portfolio_transactions
+WHERE portfolio_id = $1
+ORDER BY executed_at ASC, created_at ASC;
+
+-- name: DeletePortfolioTransaction :exec
+DELETE FROM portfolio_transactions
+WHERE id = $1;
@@ -0,0 +1,26 @@
+-- name: CreatePortfolio :one
+INSERT INTO portfolios (user_id, name, base_currency, benchmark_symbol)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, name, base_currency, benchmark_symbol, created_at, updated_at;
+
+-- name: GetPortfolioByID :one
+SELECT id, user_id, name, base_currency, benchmark_symbol, created_at, updated_at FROM portfolios
+WHERE id = $1;
+
+-- name: GetPortfoliosByUserID :many
+SELECT id, user_id, name, base_currency, benchmark_symbol, created_at, updated_at FROM portfolios
+WHERE user_id = $1
+ORDER BY created_at ASC;
+
+-- name: UpdatePortfolio :one
+UPDATE portfolios
+SET
+    name = $2,
+    base_currency = $3,
+    benchmark_symbol = $4
+WHERE id = $1
+RETURNING id, user_id, name, base_currency, benchmark_symbol, created_at, updated_at;
+
+-- name: DeletePortfolio :exec
+DELETE FROM portfolios
+WHERE id = $1;
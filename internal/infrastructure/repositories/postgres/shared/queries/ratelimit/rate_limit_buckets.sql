@@ -0,0 +1,15 @@
+-- name: LockRateLimitBucket :one
+-- Creates the bucket full if it is missing; the no-op update locks an existing one.
+INSERT INTO rate_limit_buckets (key, tokens, updated_at, expires_at)
+VALUES ($1, $2, $3, $3)
+ON CONFLICT (key) DO UPDATE SET key = EXCLUDED.key
+RETURNING key, tokens, updated_at, expires_at;
+
+-- name: UpdateRateLimitBucket :exec
+UPDATE rate_limit_buckets
+SET tokens = $2, updated_at = $3, expires_at = $4
+WHERE key = $1;
+
+-- name: DeleteExpiredRateLimitBuckets :execrows
+DELETE FROM rate_limit_buckets
+WHERE expires_at < $1;
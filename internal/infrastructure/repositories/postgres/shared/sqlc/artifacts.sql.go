@@ -12,20 +12,22 @@ import (
 )
 
 const createArtifact = `-- name: CreateArtifact :one
-INSERT INTO artifacts (message_id, title, type, language, content, content_hash, size, is_public)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at
+INSERT INTO artifacts (message_id, title, type, language, content, content_hash, size, is_public, version, root_artifact_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id
 `
 
 type CreateArtifactParams struct {
-	MessageID   pgtype.UUID `json:"message_id"`
-	Title       string      `json:"title"`
-	Type        string      `json:"type"`
-	Language    pgtype.Text `json:"language"`
-	Content     pgtype.Text `json:"content"`
-	ContentHash pgtype.Text `json:"content_hash"`
-	Size        pgtype.Int8 `json:"size"`
-	IsPublic    pgtype.Bool `json:"is_public"`
+	MessageID      pgtype.UUID `json:"message_id"`
+	Title          string      `json:"title"`
+	Type           string      `json:"type"`
+	Language       pgtype.Text `json:"language"`
+	Content        pgtype.Text `json:"content"`
+	ContentHash    pgtype.Text `json:"content_hash"`
+	Size           pgtype.Int8 `json:"size"`
+	IsPublic       pgtype.Bool `json:"is_public"`
+	Version        int32       `json:"version"`
+	RootArtifactID pgtype.UUID `json:"root_artifact_id"`
 }
 
 func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams) (Artifact, error) {
@@ -38,6 +40,8 @@ func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams)
 		arg.ContentHash,
 		arg.Size,
 		arg.IsPublic,
+		arg.Version,
+		arg.RootArtifactID,
 	)
 	var i Artifact
 	err := row.Scan(
@@ -52,6 +56,8 @@ func (q *Queries) CreateArtifact(ctx context.Context, arg CreateArtifactParams)
 		&i.IsPublic,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.RootArtifactID,
 	)
 	return i, err
 }
@@ -67,7 +73,7 @@ func (q *Queries) DeleteArtifact(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getArtifactByID = `-- name: GetArtifactByID :one
-SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at FROM artifacts
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
 WHERE id = $1
 `
 
@@ -86,12 +92,69 @@ func (q *Queries) GetArtifactByID(ctx context.Context, id pgtype.UUID) (Artifact
 		&i.IsPublic,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.RootArtifactID,
 	)
 	return i, err
 }
 
+const getArtifactOwnerID = `-- name: GetArtifactOwnerID :one
+SELECT c.user_id
+FROM artifacts a
+JOIN messages m ON m.id = a.message_id
+JOIN conversations c ON c.id = m.conversation_id
+WHERE a.id = $1
+`
+
+func (q *Queries) GetArtifactOwnerID(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getArtifactOwnerID, id)
+	var userID pgtype.UUID
+	err := row.Scan(&userID)
+	return userID, err
+}
+
+const getArtifactVersions = `-- name: GetArtifactVersions :many
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
+WHERE id = $1 OR root_artifact_id = $1
+ORDER BY version ASC
+`
+
+func (q *Queries) GetArtifactVersions(ctx context.Context, id pgtype.UUID) ([]Artifact, error) {
+	rows, err := q.db.Query(ctx, getArtifactVersions, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Artifact{}
+	for rows.Next() {
+		var i Artifact
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Title,
+			&i.Type,
+			&i.Language,
+			&i.Content,
+			&i.ContentHash,
+			&i.Size,
+			&i.IsPublic,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.RootArtifactID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getArtifactsByMessageID = `-- name: GetArtifactsByMessageID :many
-SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at FROM artifacts
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
 WHERE message_id = $1
 ORDER BY created_at ASC
 `
@@ -117,6 +180,8 @@ func (q *Queries) GetArtifactsByMessageID(ctx context.Context, messageID pgtype.
 			&i.IsPublic,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.RootArtifactID,
 		); err != nil {
 			return nil, err
 		}
@@ -129,7 +194,7 @@ func (q *Queries) GetArtifactsByMessageID(ctx context.Context, messageID pgtype.
 }
 
 const getPublicArtifacts = `-- name: GetPublicArtifacts :many
-SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at FROM artifacts
+SELECT id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id FROM artifacts
 WHERE is_public = true
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -161,6 +226,70 @@ func (q *Queries) GetPublicArtifacts(ctx context.Context, arg GetPublicArtifacts
 			&i.IsPublic,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.RootArtifactID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLatestArtifactsByUserAndType = `-- name: ListLatestArtifactsByUserAndType :many
+SELECT a.id, a.message_id, a.title, a.type, a.language, a.content, a.content_hash, a.size, a.is_public, a.created_at, a.updated_at, a.version, a.root_artifact_id
+FROM artifacts a
+JOIN messages m ON m.id = a.message_id
+JOIN conversations c ON c.id = m.conversation_id
+WHERE c.user_id = $1
+  AND a.type = $2
+  AND NOT EXISTS (
+    SELECT 1 FROM artifacts newer
+    WHERE COALESCE(newer.root_artifact_id, newer.id) = COALESCE(a.root_artifact_id, a.id)
+      AND newer.version > a.version
+  )
+ORDER BY a.created_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListLatestArtifactsByUserAndTypeParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Type   string      `json:"type"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+}
+
+func (q *Queries) ListLatestArtifactsByUserAndType(ctx context.Context, arg ListLatestArtifactsByUserAndTypeParams) ([]Artifact, error) {
+	rows, err := q.db.Query(ctx, listLatestArtifactsByUserAndType,
+		arg.UserID,
+		arg.Type,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Artifact{}
+	for rows.Next() {
+		var i Artifact
+		if err := rows.Scan(
+			&i.ID,
+			&i.MessageID,
+			&i.Title,
+			&i.Type,
+			&i.Language,
+			&i.Content,
+			&i.ContentHash,
+			&i.Size,
+			&i.IsPublic,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.RootArtifactID,
 		); err != nil {
 			return nil, err
 		}
@@ -181,7 +310,7 @@ SET
     size = $5,
     is_public = $6
 WHERE id = $1
-RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at
+RETURNING id, message_id, title, type, language, content, content_hash, size, is_public, created_at, updated_at, version, root_artifact_id
 `
 
 type UpdateArtifactParams struct {
@@ -215,6 +344,8 @@ func (q *Queries) UpdateArtifact(ctx context.Context, arg UpdateArtifactParams)
 		&i.IsPublic,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.RootArtifactID,
 	)
 	return i, err
 }
@@ -22,18 +22,37 @@ func (q *Queries) ArchiveConversation(ctx context.Context, id pgtype.UUID) error
 	return err
 }
 
+const countUserConversationsSince = `-- name: CountUserConversationsSince :one
+SELECT COUNT(*) FROM conversations
+WHERE user_id = $1 AND created_at >= $2
+`
+
+type CountUserConversationsSinceParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) CountUserConversationsSince(ctx context.Context, arg CountUserConversationsSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUserConversationsSince, arg.UserID, arg.CreatedAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createConversation = `-- name: CreateConversation :one
-INSERT INTO conversations (user_id, title, model_id, system_prompt, settings)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at
+INSERT INTO conversations (user_id, title, model_id, system_prompt, settings, organization_id, organization_permission)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission
 `
 
 type CreateConversationParams struct {
-	UserID       pgtype.UUID `json:"user_id"`
-	Title        string      `json:"title"`
-	ModelID      pgtype.UUID `json:"model_id"`
-	SystemPrompt pgtype.Text `json:"system_prompt"`
-	Settings     []byte      `json:"settings"`
+	UserID                 pgtype.UUID `json:"user_id"`
+	Title                  string      `json:"title"`
+	ModelID                pgtype.UUID `json:"model_id"`
+	SystemPrompt           pgtype.Text `json:"system_prompt"`
+	Settings               []byte      `json:"settings"`
+	OrganizationID         pgtype.UUID `json:"organization_id"`
+	OrganizationPermission string      `json:"organization_permission"`
 }
 
 func (q *Queries) CreateConversation(ctx context.Context, arg CreateConversationParams) (Conversation, error) {
@@ -43,6 +62,8 @@ func (q *Queries) CreateConversation(ctx context.Context, arg CreateConversation
 		arg.ModelID,
 		arg.SystemPrompt,
 		arg.Settings,
+		arg.OrganizationID,
+		arg.OrganizationPermission,
 	)
 	var i Conversation
 	err := row.Scan(
@@ -56,6 +77,8 @@ func (q *Queries) CreateConversation(ctx context.Context, arg CreateConversation
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastMessageAt,
+		&i.OrganizationID,
+		&i.OrganizationPermission,
 	)
 	return i, err
 }
@@ -71,7 +94,7 @@ func (q *Queries) DeleteConversation(ctx context.Context, id pgtype.UUID) error
 }
 
 const getConversationByID = `-- name: GetConversationByID :one
-SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at FROM conversations
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
 WHERE id = $1
 `
 
@@ -89,12 +112,14 @@ func (q *Queries) GetConversationByID(ctx context.Context, id pgtype.UUID) (Conv
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastMessageAt,
+		&i.OrganizationID,
+		&i.OrganizationPermission,
 	)
 	return i, err
 }
 
 const getConversationsByUserID = `-- name: GetConversationsByUserID :many
-SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at FROM conversations
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
 WHERE user_id = $1 AND is_archived = false
 ORDER BY last_message_at DESC NULLS LAST, created_at DESC
 LIMIT $2 OFFSET $3
@@ -126,6 +151,8 @@ func (q *Queries) GetConversationsByUserID(ctx context.Context, arg GetConversat
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastMessageAt,
+			&i.OrganizationID,
+			&i.OrganizationPermission,
 		); err != nil {
 			return nil, err
 		}
@@ -137,6 +164,126 @@ func (q *Queries) GetConversationsByUserID(ctx context.Context, arg GetConversat
 	return items, nil
 }
 
+const listAccessibleConversations = `-- name: ListAccessibleConversations :many
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
+WHERE is_archived = false
+  AND (user_id = $1 OR organization_id IN (
+      SELECT m.organization_id FROM organization_members m WHERE m.user_id = $1))
+  AND ($2::uuid IS NULL OR organization_id = $2::uuid)
+ORDER BY last_message_at DESC NULLS LAST, created_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListAccessibleConversationsParams struct {
+	UserID         pgtype.UUID `json:"user_id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	MaxRows        int32       `json:"max_rows"`
+	SkipRows       int32       `json:"skip_rows"`
+}
+
+func (q *Queries) ListAccessibleConversations(ctx context.Context, arg ListAccessibleConversationsParams) ([]Conversation, error) {
+	rows, err := q.db.Query(ctx, listAccessibleConversations,
+		arg.UserID,
+		arg.OrganizationID,
+		arg.MaxRows,
+		arg.SkipRows,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Conversation{}
+	for rows.Next() {
+		var i Conversation
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.ModelID,
+			&i.SystemPrompt,
+			&i.Settings,
+			&i.IsArchived,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastMessageAt,
+			&i.OrganizationID,
+			&i.OrganizationPermission,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllConversationsByUserID = `-- name: ListAllConversationsByUserID :many
+SELECT id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission FROM conversations
+WHERE user_id = $1
+ORDER BY created_at, id
+LIMIT $2 OFFSET $3
+`
+
+type ListAllConversationsByUserIDParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
+}
+
+func (q *Queries) ListAllConversationsByUserID(ctx context.Context, arg ListAllConversationsByUserIDParams) ([]Conversation, error) {
+	rows, err := q.db.Query(ctx, listAllConversationsByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Conversation{}
+	for rows.Next() {
+		var i Conversation
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.ModelID,
+			&i.SystemPrompt,
+			&i.Settings,
+			&i.IsArchived,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastMessageAt,
+			&i.OrganizationID,
+			&i.OrganizationPermission,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setConversationOrganization = `-- name: SetConversationOrganization :exec
+UPDATE conversations
+SET
+    organization_id = $2,
+    organization_permission = $3
+WHERE id = $1
+`
+
+type SetConversationOrganizationParams struct {
+	ID                     pgtype.UUID `json:"id"`
+	OrganizationID         pgtype.UUID `json:"organization_id"`
+	OrganizationPermission string      `json:"organization_permission"`
+}
+
+func (q *Queries) SetConversationOrganization(ctx context.Context, arg SetConversationOrganizationParams) error {
+	_, err := q.db.Exec(ctx, setConversationOrganization, arg.ID, arg.OrganizationID, arg.OrganizationPermission)
+	return err
+}
+
 const updateConversation = `-- name: UpdateConversation :one
 UPDATE conversations
 SET
@@ -145,7 +292,7 @@ SET
     system_prompt = $4,
     settings = $5
 WHERE id = $1
-RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at
+RETURNING id, user_id, title, model_id, system_prompt, settings, is_archived, created_at, updated_at, last_message_at, organization_id, organization_permission
 `
 
 type UpdateConversationParams struct {
@@ -176,6 +323,8 @@ func (q *Queries) UpdateConversation(ctx context.Context, arg UpdateConversation
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastMessageAt,
+		&i.OrganizationID,
+		&i.OrganizationPermission,
 	)
 	return i, err
 }
@@ -230,6 +230,90 @@ func (q *Queries) GetMessagesByConversationIDWithCursor(ctx context.Context, arg
 	return items, nil
 }
 
+const getToolMessageByCallID = `-- name: GetToolMessageByCallID :one
+SELECT id, conversation_id, parent_id, role, content, model_id, token_count, cost, metadata, created_at, updated_at FROM messages
+WHERE conversation_id = $1
+  AND role = 'tool'
+  AND metadata->>'tool_call_id' = $2::text
+ORDER BY created_at DESC
+LIMIT 1
+FOR UPDATE
+`
+
+type GetToolMessageByCallIDParams struct {
+	ConversationID pgtype.UUID `json:"conversation_id"`
+	ToolCallID     string      `json:"tool_call_id"`
+}
+
+func (q *Queries) GetToolMessageByCallID(ctx context.Context, arg GetToolMessageByCallIDParams) (Message, error) {
+	row := q.db.QueryRow(ctx, getToolMessageByCallID, arg.ConversationID, arg.ToolCallID)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.ParentID,
+		&i.Role,
+		&i.Content,
+		&i.ModelID,
+		&i.TokenCount,
+		&i.Cost,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserUsageByModel = `-- name: GetUserUsageByModel :many
+SELECT
+    m.model_id,
+    COUNT(*) AS message_count,
+    COALESCE(SUM(m.token_count), 0)::bigint AS token_count,
+    COALESCE(SUM(m.cost), 0)::float8 AS cost
+FROM messages m
+JOIN conversations c ON c.id = m.conversation_id
+WHERE c.user_id = $1 AND m.role = 'assistant' AND m.created_at >= $2
+GROUP BY m.model_id
+ORDER BY message_count DESC
+`
+
+type GetUserUsageByModelParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type GetUserUsageByModelRow struct {
+	ModelID      pgtype.UUID `json:"model_id"`
+	MessageCount int64       `json:"message_count"`
+	TokenCount   int64       `json:"token_count"`
+	Cost         float64     `json:"cost"`
+}
+
+func (q *Queries) GetUserUsageByModel(ctx context.Context, arg GetUserUsageByModelParams) ([]GetUserUsageByModelRow, error) {
+	rows, err := q.db.Query(ctx, getUserUsageByModel, arg.UserID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserUsageByModelRow{}
+	for rows.Next() {
+		var i GetUserUsageByModelRow
+		if err := rows.Scan(
+			&i.ModelID,
+			&i.MessageCount,
+			&i.TokenCount,
+			&i.Cost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateMessage = `-- name: UpdateMessage :one
 UPDATE messages
 SET
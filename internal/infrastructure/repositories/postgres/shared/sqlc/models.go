@@ -10,31 +10,252 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AccountDeletion struct {
+	UserID       pgtype.UUID        `json:"user_id"`
+	ScheduledFor pgtype.Timestamptz `json:"scheduled_for"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type AlertEvent struct {
+	ID          pgtype.UUID        `json:"id"`
+	RuleID      pgtype.UUID        `json:"rule_id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Symbol      string             `json:"symbol"`
+	Timeframe   string             `json:"timeframe"`
+	CandleTime  pgtype.Timestamptz `json:"candle_time"`
+	Price       float64            `json:"price"`
+	Message     string             `json:"message"`
+	EmailStatus string             `json:"email_status"`
+	TriggeredAt pgtype.Timestamptz `json:"triggered_at"`
+}
+
+type AlertRule struct {
+	ID                 pgtype.UUID        `json:"id"`
+	UserID             pgtype.UUID        `json:"user_id"`
+	Name               string             `json:"name"`
+	Symbol             pgtype.Text        `json:"symbol"`
+	WatchlistID        pgtype.UUID        `json:"watchlist_id"`
+	Timeframe          string             `json:"timeframe"`
+	Type               string             `json:"type"`
+	Direction          string             `json:"direction"`
+	Level              pgtype.Float8      `json:"level"`
+	Percent            pgtype.Float8      `json:"percent"`
+	WindowSeconds      pgtype.Int4        `json:"window_seconds"`
+	IndicatorCondition []byte             `json:"indicator_condition"`
+	CooldownSeconds    int32              `json:"cooldown_seconds"`
+	NotifyEmail        bool               `json:"notify_email"`
+	NotifyInApp        bool               `json:"notify_in_app"`
+	Enabled            bool               `json:"enabled"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AlertRuleState struct {
+	ID              pgtype.UUID        `json:"id"`
+	RuleID          pgtype.UUID        `json:"rule_id"`
+	Symbol          string             `json:"symbol"`
+	LastCandleTime  pgtype.Timestamptz `json:"last_candle_time"`
+	Active          bool               `json:"active"`
+	LastTriggeredAt pgtype.Timestamptz `json:"last_triggered_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ApiToken struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenPrefix string             `json:"token_prefix"`
+	TokenHash   string             `json:"token_hash"`
+	Scopes      []string           `json:"scopes"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type Artifact struct {
+	ID             pgtype.UUID        `json:"id"`
+	MessageID      pgtype.UUID        `json:"message_id"`
+	Title          string             `json:"title"`
+	Type           string             `json:"type"`
+	Language       pgtype.Text        `json:"language"`
+	Content        pgtype.Text        `json:"content"`
+	ContentHash    pgtype.Text        `json:"content_hash"`
+	Size           pgtype.Int8        `json:"size"`
+	IsPublic       pgtype.Bool        `json:"is_public"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Version        int32              `json:"version"`
+	RootArtifactID pgtype.UUID        `json:"root_artifact_id"`
+}
+
+type AuditEvent struct {
+	ID           pgtype.UUID        `json:"id"`
+	Action       string             `json:"action"`
+	ActorID      pgtype.UUID        `json:"actor_id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	ResourceType pgtype.Text        `json:"resource_type"`
+	ResourceID   pgtype.UUID        `json:"resource_id"`
+	IpAddress    *netip.Addr        `json:"ip_address"`
+	UserAgent    pgtype.Text        `json:"user_agent"`
+	Metadata     []byte             `json:"metadata"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type BrokerConnection struct {
+	ID                   pgtype.UUID        `json:"id"`
+	UserID               pgtype.UUID        `json:"user_id"`
+	Name                 string             `json:"name"`
+	Kind                 string             `json:"kind"`
+	EncryptedCredentials pgtype.Text        `json:"encrypted_credentials"`
+	ApiBaseOverride      pgtype.Text        `json:"api_base_override"`
+	Settings             []byte             `json:"settings"`
+	IsActive             bool               `json:"is_active"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+}
+
+type CalendarEvent struct {
 	ID          pgtype.UUID        `json:"id"`
-	MessageID   pgtype.UUID        `json:"message_id"`
-	Title       string             `json:"title"`
+	UserID      pgtype.UUID        `json:"user_id"`
 	Type        string             `json:"type"`
-	Language    pgtype.Text        `json:"language"`
-	Content     pgtype.Text        `json:"content"`
-	ContentHash pgtype.Text        `json:"content_hash"`
-	Size        pgtype.Int8        `json:"size"`
-	IsPublic    pgtype.Bool        `json:"is_public"`
+	Symbol      pgtype.Text        `json:"symbol"`
+	Title       string             `json:"title"`
+	Description pgtype.Text        `json:"description"`
+	ScheduledAt pgtype.Timestamptz `json:"scheduled_at"`
+	AllDay      bool               `json:"all_day"`
+	Importance  string             `json:"importance"`
+	Amount      pgtype.Float8      `json:"amount"`
+	Ratio       pgtype.Float8      `json:"ratio"`
+	Source      string             `json:"source"`
+	ExternalID  string             `json:"external_id"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
+type Candle struct {
+	ID        pgtype.UUID        `json:"id"`
+	Symbol    string             `json:"symbol"`
+	Timeframe string             `json:"timeframe"`
+	OpenTime  pgtype.Timestamptz `json:"open_time"`
+	Open      float64            `json:"open"`
+	High      float64            `json:"high"`
+	Low       float64            `json:"low"`
+	Close     float64            `json:"close"`
+	Volume    float64            `json:"volume"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type Conversation struct {
-	ID            pgtype.UUID        `json:"id"`
-	UserID        pgtype.UUID        `json:"user_id"`
-	Title         string             `json:"title"`
-	ModelID       pgtype.UUID        `json:"model_id"`
-	SystemPrompt  pgtype.Text        `json:"system_prompt"`
-	Settings      []byte             `json:"settings"`
-	IsArchived    pgtype.Bool        `json:"is_archived"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
-	LastMessageAt pgtype.Timestamptz `json:"last_message_at"`
+	ID                     pgtype.UUID        `json:"id"`
+	UserID                 pgtype.UUID        `json:"user_id"`
+	Title                  string             `json:"title"`
+	ModelID                pgtype.UUID        `json:"model_id"`
+	SystemPrompt           pgtype.Text        `json:"system_prompt"`
+	Settings               []byte             `json:"settings"`
+	IsArchived             pgtype.Bool        `json:"is_archived"`
+	CreatedAt              pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt              pgtype.Timestamptz `json:"updated_at"`
+	LastMessageAt          pgtype.Timestamptz `json:"last_message_at"`
+	OrganizationID         pgtype.UUID        `json:"organization_id"`
+	OrganizationPermission string             `json:"organization_permission"`
+}
+
+type ConversationKnowledgeBasis struct {
+	ConversationID  pgtype.UUID        `json:"conversation_id"`
+	KnowledgeBaseID pgtype.UUID        `json:"knowledge_base_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type Document struct {
+	ID             pgtype.UUID        `json:"id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Title          string             `json:"title"`
+	Filename       string             `json:"filename"`
+	Format         string             `json:"format"`
+	Size           int64              `json:"size"`
+	ContentHash    string             `json:"content_hash"`
+	Status         string             `json:"status"`
+	Error          pgtype.Text        `json:"error"`
+	ChunkCount     int32              `json:"chunk_count"`
+	EmbeddingModel pgtype.Text        `json:"embedding_model"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type DocumentChunk struct {
+	ID         pgtype.UUID        `json:"id"`
+	DocumentID pgtype.UUID        `json:"document_id"`
+	ChunkIndex int32              `json:"chunk_index"`
+	Content    string             `json:"content"`
+	Page       pgtype.Int4        `json:"page"`
+	Heading    pgtype.Text        `json:"heading"`
+	Embedding  []float32          `json:"embedding"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type EmbeddingCache struct {
+	Model       string             `json:"model"`
+	ContentHash string             `json:"content_hash"`
+	Embedding   []float32          `json:"embedding"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type Identity struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Provider    string             `json:"provider"`
+	Subject     string             `json:"subject"`
+	Email       pgtype.Text        `json:"email"`
+	LastLoginAt pgtype.Timestamptz `json:"last_login_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type JournalEntry struct {
+	ID             pgtype.UUID        `json:"id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Symbol         string             `json:"symbol"`
+	Side           string             `json:"side"`
+	Quantity       float64            `json:"quantity"`
+	EntryPrice     float64            `json:"entry_price"`
+	EntryTime      pgtype.Timestamptz `json:"entry_time"`
+	ExitPrice      pgtype.Float8      `json:"exit_price"`
+	ExitTime       pgtype.Timestamptz `json:"exit_time"`
+	Fees           float64            `json:"fees"`
+	Setup          pgtype.Text        `json:"setup"`
+	Thesis         pgtype.Text        `json:"thesis"`
+	Notes          pgtype.Text        `json:"notes"`
+	Tags           []string           `json:"tags"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type JournalScreenshot struct {
+	EntryID    pgtype.UUID `json:"entry_id"`
+	ArtifactID pgtype.UUID `json:"artifact_id"`
+	Position   int32       `json:"position"`
+}
+
+type KnowledgeBase struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Name        string             `json:"name"`
+	Description pgtype.Text        `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type KnowledgeBaseDocument struct {
+	KnowledgeBaseID pgtype.UUID        `json:"knowledge_base_id"`
+	DocumentID      pgtype.UUID        `json:"document_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type KnowledgeBaseMember struct {
+	KnowledgeBaseID pgtype.UUID        `json:"knowledge_base_id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	Role            string             `json:"role"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 }
 
 type MagicLink struct {
@@ -64,6 +285,15 @@ type Message struct {
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
+type MessageEmbedding struct {
+	MessageID      pgtype.UUID        `json:"message_id"`
+	ConversationID pgtype.UUID        `json:"conversation_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	EmbeddingModel string             `json:"embedding_model"`
+	Embedding      []float32          `json:"embedding"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
 type MessageTool struct {
 	ID         pgtype.UUID        `json:"id"`
 	MessageID  pgtype.UUID        `json:"message_id"`
@@ -77,15 +307,142 @@ type MessageTool struct {
 }
 
 type Model struct {
-	ID                pgtype.UUID        `json:"id"`
-	ProviderID        pgtype.UUID        `json:"provider_id"`
-	Name              string             `json:"name"`
-	DisplayName       string             `json:"display_name"`
-	SupportsFunctions pgtype.Bool        `json:"supports_functions"`
-	SupportsVision    pgtype.Bool        `json:"supports_vision"`
-	IsActive          pgtype.Bool        `json:"is_active"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+type Notification struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Body      string             `json:"body"`
+	Data      []byte             `json:"data"`
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type OidcLoginRequest struct {
+	ID           pgtype.UUID        `json:"id"`
+	Provider     string             `json:"provider"`
+	StateHash    string             `json:"state_hash"`
+	Nonce        string             `json:"nonce"`
+	CodeVerifier string             `json:"code_verifier"`
+	RedirectUrl  string             `json:"redirect_url"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type Organization struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OrganizationInvitation struct {
+	ID             pgtype.UUID        `json:"id"`
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Role           string             `json:"role"`
+	InvitedBy      pgtype.UUID        `json:"invited_by"`
+	MagicLinkID    pgtype.UUID        `json:"magic_link_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Role           string             `json:"role"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type PaperAccount struct {
+	ID             pgtype.UUID        `json:"id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Name           string             `json:"name"`
+	Timeframe      string             `json:"timeframe"`
+	InitialCash    float64            `json:"initial_cash"`
+	Cash           float64            `json:"cash"`
+	CommissionRate float64            `json:"commission_rate"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PaperOrder struct {
+	ID           pgtype.UUID        `json:"id"`
+	AccountID    pgtype.UUID        `json:"account_id"`
+	Symbol       string             `json:"symbol"`
+	Side         string             `json:"side"`
+	Type         string             `json:"type"`
+	Quantity     float64            `json:"quantity"`
+	LimitPrice   pgtype.Float8      `json:"limit_price"`
+	StopPrice    pgtype.Float8      `json:"stop_price"`
+	Status       string             `json:"status"`
+	FillPrice    pgtype.Float8      `json:"fill_price"`
+	Commission   float64            `json:"commission"`
+	RejectReason pgtype.Text        `json:"reject_reason"`
+	FilledAt     pgtype.Timestamptz `json:"filled_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PaperPosition struct {
+	ID           pgtype.UUID        `json:"id"`
+	AccountID    pgtype.UUID        `json:"account_id"`
+	Symbol       string             `json:"symbol"`
+	Quantity     float64            `json:"quantity"`
+	AveragePrice float64            `json:"average_price"`
+	RealizedPnl  float64            `json:"realized_pnl"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Portfolio struct {
+	ID              pgtype.UUID        `json:"id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	Name            string             `json:"name"`
+	BaseCurrency    string             `json:"base_currency"`
+	BenchmarkSymbol pgtype.Text        `json:"benchmark_symbol"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PortfolioAsset struct {
+	ID          pgtype.UUID        `json:"id"`
+	PortfolioID pgtype.UUID        `json:"portfolio_id"`
+	Symbol      string             `json:"symbol"`
+	Name        pgtype.Text        `json:"name"`
+	AssetClass  pgtype.Text        `json:"asset_class"`
+	Sector      pgtype.Text        `json:"sector"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PortfolioTransaction struct {
+	ID          pgtype.UUID        `json:"id"`
+	PortfolioID pgtype.UUID        `json:"portfolio_id"`
+	Type        string             `json:"type"`
+	Symbol      string             `json:"symbol"`
+	Quantity    float64            `json:"quantity"`
+	Price       float64            `json:"price"`
+	Fee         float64            `json:"fee"`
+	Amount      float64            `json:"amount"`
+	ExecutedAt  pgtype.Timestamptz `json:"executed_at"`
+	Notes       pgtype.Text        `json:"notes"`
+	Source      string             `json:"source"`
+	ExternalID  pgtype.Text        `json:"external_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type Provider struct {
@@ -97,6 +454,38 @@ type Provider struct {
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
+type RateLimitBucket struct {
+	Key       string             `json:"key"`
+	Tokens    float64            `json:"tokens"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type RecoveryCode struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Session struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	FamilyID         pgtype.UUID        `json:"family_id"`
+	RefreshTokenHash string             `json:"refresh_token_hash"`
+	DeviceName       pgtype.Text        `json:"device_name"`
+	IpAddress        *netip.Addr        `json:"ip_address"`
+	UserAgent        pgtype.Text        `json:"user_agent"`
+	AuthenticatedAt  pgtype.Timestamptz `json:"authenticated_at"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	LastSeenAt       pgtype.Timestamptz `json:"last_seen_at"`
+	RotatedAt        pgtype.Timestamptz `json:"rotated_at"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	SecondFactorAt   pgtype.Timestamptz `json:"second_factor_at"`
+}
+
 type Tool struct {
 	ID          pgtype.UUID        `json:"id"`
 	Name        string             `json:"name"`
@@ -108,6 +497,29 @@ type Tool struct {
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
+type TotpSecret struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	EncryptedSecret string             `json:"encrypted_secret"`
+	ConfirmedAt     pgtype.Timestamptz `json:"confirmed_at"`
+	LastUsedStep    int64              `json:"last_used_step"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+type TwoFactorChallenge struct {
+	ID                pgtype.UUID        `json:"id"`
+	UserID            pgtype.UUID        `json:"user_id"`
+	Purpose           string             `json:"purpose"`
+	TokenHash         string             `json:"token_hash"`
+	WebauthnChallenge []byte             `json:"webauthn_challenge"`
+	SessionID         pgtype.UUID        `json:"session_id"`
+	DeviceName        pgtype.Text        `json:"device_name"`
+	IpAddress         *netip.Addr        `json:"ip_address"`
+	UserAgent         pgtype.Text        `json:"user_agent"`
+	Attempts          int32              `json:"attempts"`
+	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
 type User struct {
 	ID            pgtype.UUID        `json:"id"`
 	Email         string             `json:"email"`
@@ -118,6 +530,18 @@ type User struct {
 	IsActive      pgtype.Bool        `json:"is_active"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	MemoryEnabled pgtype.Bool        `json:"memory_enabled"`
+	Role          string             `json:"role"`
+}
+
+type UserMemory struct {
+	ID                   pgtype.UUID        `json:"id"`
+	UserID               pgtype.UUID        `json:"user_id"`
+	Category             string             `json:"category"`
+	Content              string             `json:"content"`
+	SourceConversationID pgtype.UUID        `json:"source_conversation_id"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
 }
 
 type UserProviderSetting struct {
@@ -129,4 +553,34 @@ type UserProviderSetting struct {
 	IsActive        pgtype.Bool        `json:"is_active"`
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	OrganizationID  pgtype.UUID        `json:"organization_id"`
+}
+
+type Watchlist struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Name        string             `json:"name"`
+	Description pgtype.Text        `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WatchlistItem struct {
+	ID          pgtype.UUID        `json:"id"`
+	WatchlistID pgtype.UUID        `json:"watchlist_id"`
+	Symbol      string             `json:"symbol"`
+	Notes       pgtype.Text        `json:"notes"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebauthnCredential struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	Name         string             `json:"name"`
+	CredentialID []byte             `json:"credential_id"`
+	PublicKey    []byte             `json:"public_key"`
+	SignCount    int64              `json:"sign_count"`
+	Transports   []string           `json:"transports"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
@@ -13,31 +13,48 @@ import (
 
 const createModel = `-- name: CreateModel :one
 INSERT INTO models (
-    provider_id, name, display_name, supports_functions, supports_vision, is_active
+    provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million
 `
 
 type CreateModelParams struct {
-	ProviderID        pgtype.UUID `json:"provider_id"`
-	Name              string      `json:"name"`
-	DisplayName       string      `json:"display_name"`
-	SupportsFunctions pgtype.Bool `json:"supports_functions"`
-	SupportsVision    pgtype.Bool `json:"supports_vision"`
-	IsActive          pgtype.Bool `json:"is_active"`
+	ProviderID         pgtype.UUID `json:"provider_id"`
+	Name               string      `json:"name"`
+	DisplayName        string      `json:"display_name"`
+	SupportsFunctions  pgtype.Bool `json:"supports_functions"`
+	SupportsVision     pgtype.Bool `json:"supports_vision"`
+	SupportsEmbeddings pgtype.Bool `json:"supports_embeddings"`
+	IsActive           pgtype.Bool `json:"is_active"`
 }
 
-func (q *Queries) CreateModel(ctx context.Context, arg CreateModelParams) (Model, error) {
+type CreateModelRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+func (q *Queries) CreateModel(ctx context.Context, arg CreateModelParams) (CreateModelRow, error) {
 	row := q.db.QueryRow(ctx, createModel,
 		arg.ProviderID,
 		arg.Name,
 		arg.DisplayName,
 		arg.SupportsFunctions,
 		arg.SupportsVision,
+		arg.SupportsEmbeddings,
 		arg.IsActive,
 	)
-	var i Model
+	var i CreateModelRow
 	err := row.Scan(
 		&i.ID,
 		&i.ProviderID,
@@ -45,9 +62,12 @@ func (q *Queries) CreateModel(ctx context.Context, arg CreateModelParams) (Model
 		&i.DisplayName,
 		&i.SupportsFunctions,
 		&i.SupportsVision,
+		&i.SupportsEmbeddings,
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputPricePerMillion,
+		&i.OutputPricePerMillion,
 	)
 	return i, err
 }
@@ -63,20 +83,88 @@ func (q *Queries) DeleteModel(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getActiveModelsByProviderID = `-- name: GetActiveModelsByProviderID :many
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE provider_id = $1 AND is_active = TRUE
 ORDER BY name
 `
 
-func (q *Queries) GetActiveModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]Model, error) {
+type GetActiveModelsByProviderIDRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+func (q *Queries) GetActiveModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]GetActiveModelsByProviderIDRow, error) {
 	rows, err := q.db.Query(ctx, getActiveModelsByProviderID, providerID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Model{}
+	items := []GetActiveModelsByProviderIDRow{}
+	for rows.Next() {
+		var i GetActiveModelsByProviderIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProviderID,
+			&i.Name,
+			&i.DisplayName,
+			&i.SupportsFunctions,
+			&i.SupportsVision,
+			&i.SupportsEmbeddings,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.InputPricePerMillion,
+			&i.OutputPricePerMillion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllModels = `-- name: GetAllModels :many
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
+ORDER BY provider_id, display_name
+`
+
+type GetAllModelsRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+func (q *Queries) GetAllModels(ctx context.Context) ([]GetAllModelsRow, error) {
+	rows, err := q.db.Query(ctx, getAllModels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllModelsRow{}
 	for rows.Next() {
-		var i Model
+		var i GetAllModelsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ProviderID,
@@ -84,9 +172,12 @@ func (q *Queries) GetActiveModelsByProviderID(ctx context.Context, providerID pg
 			&i.DisplayName,
 			&i.SupportsFunctions,
 			&i.SupportsVision,
+			&i.SupportsEmbeddings,
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.InputPricePerMillion,
+			&i.OutputPricePerMillion,
 		); err != nil {
 			return nil, err
 		}
@@ -99,14 +190,29 @@ func (q *Queries) GetActiveModelsByProviderID(ctx context.Context, providerID pg
 }
 
 const getModelByID = `-- name: GetModelByID :one
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE id = $1
 LIMIT 1
 `
 
-func (q *Queries) GetModelByID(ctx context.Context, id pgtype.UUID) (Model, error) {
+type GetModelByIDRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+func (q *Queries) GetModelByID(ctx context.Context, id pgtype.UUID) (GetModelByIDRow, error) {
 	row := q.db.QueryRow(ctx, getModelByID, id)
-	var i Model
+	var i GetModelByIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.ProviderID,
@@ -114,15 +220,18 @@ func (q *Queries) GetModelByID(ctx context.Context, id pgtype.UUID) (Model, erro
 		&i.DisplayName,
 		&i.SupportsFunctions,
 		&i.SupportsVision,
+		&i.SupportsEmbeddings,
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputPricePerMillion,
+		&i.OutputPricePerMillion,
 	)
 	return i, err
 }
 
 const getModelByName = `-- name: GetModelByName :one
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE provider_id = $1 AND name = $2
 LIMIT 1
 `
@@ -132,9 +241,24 @@ type GetModelByNameParams struct {
 	Name       string      `json:"name"`
 }
 
-func (q *Queries) GetModelByName(ctx context.Context, arg GetModelByNameParams) (Model, error) {
+type GetModelByNameRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+func (q *Queries) GetModelByName(ctx context.Context, arg GetModelByNameParams) (GetModelByNameRow, error) {
 	row := q.db.QueryRow(ctx, getModelByName, arg.ProviderID, arg.Name)
-	var i Model
+	var i GetModelByNameRow
 	err := row.Scan(
 		&i.ID,
 		&i.ProviderID,
@@ -142,28 +266,46 @@ func (q *Queries) GetModelByName(ctx context.Context, arg GetModelByNameParams)
 		&i.DisplayName,
 		&i.SupportsFunctions,
 		&i.SupportsVision,
+		&i.SupportsEmbeddings,
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputPricePerMillion,
+		&i.OutputPricePerMillion,
 	)
 	return i, err
 }
 
 const getModelsByProviderID = `-- name: GetModelsByProviderID :many
-SELECT id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at FROM models
+SELECT id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million FROM models
 WHERE provider_id = $1
 ORDER BY display_name
 `
 
-func (q *Queries) GetModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]Model, error) {
+type GetModelsByProviderIDRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
+}
+
+func (q *Queries) GetModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]GetModelsByProviderIDRow, error) {
 	rows, err := q.db.Query(ctx, getModelsByProviderID, providerID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Model{}
+	items := []GetModelsByProviderIDRow{}
 	for rows.Next() {
-		var i Model
+		var i GetModelsByProviderIDRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.ProviderID,
@@ -171,9 +313,12 @@ func (q *Queries) GetModelsByProviderID(ctx context.Context, providerID pgtype.U
 			&i.DisplayName,
 			&i.SupportsFunctions,
 			&i.SupportsVision,
+			&i.SupportsEmbeddings,
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.InputPricePerMillion,
+			&i.OutputPricePerMillion,
 		); err != nil {
 			return nil, err
 		}
@@ -191,28 +336,52 @@ SET
     display_name = $2,
     supports_functions = $3,
     supports_vision = $4,
-    is_active = $5
+    supports_embeddings = $5,
+    is_active = $6,
+    input_price_per_million = $7,
+    output_price_per_million = $8
 WHERE id = $1
-RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, is_active, created_at, updated_at
+RETURNING id, provider_id, name, display_name, supports_functions, supports_vision, supports_embeddings, is_active, created_at, updated_at, input_price_per_million, output_price_per_million
 `
 
 type UpdateModelParams struct {
-	ID                pgtype.UUID `json:"id"`
-	DisplayName       string      `json:"display_name"`
-	SupportsFunctions pgtype.Bool `json:"supports_functions"`
-	SupportsVision    pgtype.Bool `json:"supports_vision"`
-	IsActive          pgtype.Bool `json:"is_active"`
+	ID                    pgtype.UUID    `json:"id"`
+	DisplayName           string         `json:"display_name"`
+	SupportsFunctions     pgtype.Bool    `json:"supports_functions"`
+	SupportsVision        pgtype.Bool    `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool    `json:"supports_embeddings"`
+	IsActive              pgtype.Bool    `json:"is_active"`
+	InputPricePerMillion  pgtype.Numeric `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric `json:"output_price_per_million"`
+}
+
+type UpdateModelRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	ProviderID            pgtype.UUID        `json:"provider_id"`
+	Name                  string             `json:"name"`
+	DisplayName           string             `json:"display_name"`
+	SupportsFunctions     pgtype.Bool        `json:"supports_functions"`
+	SupportsVision        pgtype.Bool        `json:"supports_vision"`
+	SupportsEmbeddings    pgtype.Bool        `json:"supports_embeddings"`
+	IsActive              pgtype.Bool        `json:"is_active"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	InputPricePerMillion  pgtype.Numeric     `json:"input_price_per_million"`
+	OutputPricePerMillion pgtype.Numeric     `json:"output_price_per_million"`
 }
 
-func (q *Queries) UpdateModel(ctx context.Context, arg UpdateModelParams) (Model, error) {
+func (q *Queries) UpdateModel(ctx context.Context, arg UpdateModelParams) (UpdateModelRow, error) {
 	row := q.db.QueryRow(ctx, updateModel,
 		arg.ID,
 		arg.DisplayName,
 		arg.SupportsFunctions,
 		arg.SupportsVision,
+		arg.SupportsEmbeddings,
 		arg.IsActive,
+		arg.InputPricePerMillion,
+		arg.OutputPricePerMillion,
 	)
-	var i Model
+	var i UpdateModelRow
 	err := row.Scan(
 		&i.ID,
 		&i.ProviderID,
@@ -220,9 +389,12 @@ func (q *Queries) UpdateModel(ctx context.Context, arg UpdateModelParams) (Model
 		&i.DisplayName,
 		&i.SupportsFunctions,
 		&i.SupportsVision,
+		&i.SupportsEmbeddings,
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.InputPricePerMillion,
+		&i.OutputPricePerMillion,
 	)
 	return i, err
 }
@@ -124,6 +124,7 @@ SELECT
     m.display_name as model_display_name,
     m.supports_functions as model_supports_functions,
     m.supports_vision as model_supports_vision,
+    m.supports_embeddings as model_supports_embeddings,
     ups.id as setting_id,
     ups.encrypted_api_key as has_api_key,
     ups.is_active as setting_is_active
@@ -138,17 +139,18 @@ ORDER BY
 `
 
 type GetAvailableModelsForUserRow struct {
-	ProviderID             pgtype.UUID `json:"provider_id"`
-	ProviderName           string      `json:"provider_name"`
-	ProviderDisplayName    string      `json:"provider_display_name"`
-	ModelID                pgtype.UUID `json:"model_id"`
-	ModelName              string      `json:"model_name"`
-	ModelDisplayName       string      `json:"model_display_name"`
-	ModelSupportsFunctions pgtype.Bool `json:"model_supports_functions"`
-	ModelSupportsVision    pgtype.Bool `json:"model_supports_vision"`
-	SettingID              pgtype.UUID `json:"setting_id"`
-	HasApiKey              pgtype.Text `json:"has_api_key"`
-	SettingIsActive        pgtype.Bool `json:"setting_is_active"`
+	ProviderID              pgtype.UUID `json:"provider_id"`
+	ProviderName            string      `json:"provider_name"`
+	ProviderDisplayName     string      `json:"provider_display_name"`
+	ModelID                 pgtype.UUID `json:"model_id"`
+	ModelName               string      `json:"model_name"`
+	ModelDisplayName        string      `json:"model_display_name"`
+	ModelSupportsFunctions  pgtype.Bool `json:"model_supports_functions"`
+	ModelSupportsVision     pgtype.Bool `json:"model_supports_vision"`
+	ModelSupportsEmbeddings pgtype.Bool `json:"model_supports_embeddings"`
+	SettingID               pgtype.UUID `json:"setting_id"`
+	HasApiKey               pgtype.Text `json:"has_api_key"`
+	SettingIsActive         pgtype.Bool `json:"setting_is_active"`
 }
 
 func (q *Queries) GetAvailableModelsForUser(ctx context.Context, userID pgtype.UUID) ([]GetAvailableModelsForUserRow, error) {
@@ -169,6 +171,7 @@ func (q *Queries) GetAvailableModelsForUser(ctx context.Context, userID pgtype.U
 			&i.ModelDisplayName,
 			&i.ModelSupportsFunctions,
 			&i.ModelSupportsVision,
+			&i.ModelSupportsEmbeddings,
 			&i.SettingID,
 			&i.HasApiKey,
 			&i.SettingIsActive,
@@ -238,6 +241,7 @@ SELECT
     m.display_name as model_display_name,
     m.supports_functions as model_supports_functions,
     m.supports_vision as model_supports_vision,
+    m.supports_embeddings as model_supports_embeddings,
     m.is_active as model_is_active,
     m.created_at as model_created_at,
     m.updated_at as model_updated_at
@@ -248,20 +252,21 @@ ORDER BY p.display_name, m.display_name
 `
 
 type GetProvidersWithModelsRow struct {
-	ProviderID             pgtype.UUID        `json:"provider_id"`
-	ProviderName           string             `json:"provider_name"`
-	ProviderDisplayName    string             `json:"provider_display_name"`
-	ProviderIsActive       pgtype.Bool        `json:"provider_is_active"`
-	ProviderCreatedAt      pgtype.Timestamptz `json:"provider_created_at"`
-	ProviderUpdatedAt      pgtype.Timestamptz `json:"provider_updated_at"`
-	ModelID                pgtype.UUID        `json:"model_id"`
-	ModelName              pgtype.Text        `json:"model_name"`
-	ModelDisplayName       pgtype.Text        `json:"model_display_name"`
-	ModelSupportsFunctions pgtype.Bool        `json:"model_supports_functions"`
-	ModelSupportsVision    pgtype.Bool        `json:"model_supports_vision"`
-	ModelIsActive          pgtype.Bool        `json:"model_is_active"`
-	ModelCreatedAt         pgtype.Timestamptz `json:"model_created_at"`
-	ModelUpdatedAt         pgtype.Timestamptz `json:"model_updated_at"`
+	ProviderID              pgtype.UUID        `json:"provider_id"`
+	ProviderName            string             `json:"provider_name"`
+	ProviderDisplayName     string             `json:"provider_display_name"`
+	ProviderIsActive        pgtype.Bool        `json:"provider_is_active"`
+	ProviderCreatedAt       pgtype.Timestamptz `json:"provider_created_at"`
+	ProviderUpdatedAt       pgtype.Timestamptz `json:"provider_updated_at"`
+	ModelID                 pgtype.UUID        `json:"model_id"`
+	ModelName               pgtype.Text        `json:"model_name"`
+	ModelDisplayName        pgtype.Text        `json:"model_display_name"`
+	ModelSupportsFunctions  pgtype.Bool        `json:"model_supports_functions"`
+	ModelSupportsVision     pgtype.Bool        `json:"model_supports_vision"`
+	ModelSupportsEmbeddings pgtype.Bool        `json:"model_supports_embeddings"`
+	ModelIsActive           pgtype.Bool        `json:"model_is_active"`
+	ModelCreatedAt          pgtype.Timestamptz `json:"model_created_at"`
+	ModelUpdatedAt          pgtype.Timestamptz `json:"model_updated_at"`
 }
 
 func (q *Queries) GetProvidersWithModels(ctx context.Context) ([]GetProvidersWithModelsRow, error) {
@@ -285,6 +290,7 @@ func (q *Queries) GetProvidersWithModels(ctx context.Context) ([]GetProvidersWit
 			&i.ModelDisplayName,
 			&i.ModelSupportsFunctions,
 			&i.ModelSupportsVision,
+			&i.ModelSupportsEmbeddings,
 			&i.ModelIsActive,
 			&i.ModelCreatedAt,
 			&i.ModelUpdatedAt,
@@ -11,66 +11,273 @@ import (
 )
 
 type Querier interface {
+	AddJournalScreenshot(ctx context.Context, arg AddJournalScreenshotParams) error
+	AddKnowledgeBaseDocument(ctx context.Context, arg AddKnowledgeBaseDocumentParams) error
 	ArchiveConversation(ctx context.Context, id pgtype.UUID) error
+	AttachKnowledgeBaseToConversation(ctx context.Context, arg AttachKnowledgeBaseToConversationParams) error
+	CancelAccountDeletion(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CleanupExpiredMagicLinks(ctx context.Context) error
+	CleanupExpiredOIDCLoginRequests(ctx context.Context) error
+	CleanupExpiredSessions(ctx context.Context) error
+	CleanupExpiredTwoFactorChallenges(ctx context.Context) error
+	ConfirmTOTPSecret(ctx context.Context, userID pgtype.UUID) error
+	ConsumeOIDCLoginRequest(ctx context.Context, stateHash string) (OidcLoginRequest, error)
+	CountAPITokensByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountAuditEvents(ctx context.Context, arg CountAuditEventsParams) (int64, error)
 	CountMessagesByConversationID(ctx context.Context, conversationID pgtype.UUID) (int64, error)
+	CountUnreadNotifications(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUnusedRecoveryCodesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUserConversationsSince(ctx context.Context, arg CountUserConversationsSinceParams) (int64, error)
+	CountUserMemoriesByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CountUsers(ctx context.Context) (int64, error)
+	CountVectorExtensions(ctx context.Context) (int64, error)
+	CountWebAuthnCredentialsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error)
+	CreateAlertEvent(ctx context.Context, arg CreateAlertEventParams) (AlertEvent, error)
+	CreateAlertRule(ctx context.Context, arg CreateAlertRuleParams) (AlertRule, error)
 	CreateArtifact(ctx context.Context, arg CreateArtifactParams) (Artifact, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
+	CreateBrokerConnection(ctx context.Context, arg CreateBrokerConnectionParams) (BrokerConnection, error)
 	CreateConversation(ctx context.Context, arg CreateConversationParams) (Conversation, error)
+	CreateDocument(ctx context.Context, arg CreateDocumentParams) (Document, error)
+	CreateDocumentChunk(ctx context.Context, arg CreateDocumentChunkParams) error
+	CreateIdentity(ctx context.Context, arg CreateIdentityParams) (Identity, error)
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error)
+	CreateKnowledgeBase(ctx context.Context, arg CreateKnowledgeBaseParams) (KnowledgeBase, error)
 	CreateMagicLink(ctx context.Context, arg CreateMagicLinkParams) (MagicLink, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
-	CreateModel(ctx context.Context, arg CreateModelParams) (Model, error)
+	CreateMessageEmbedding(ctx context.Context, arg CreateMessageEmbeddingParams) error
+	CreateModel(ctx context.Context, arg CreateModelParams) (CreateModelRow, error)
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreateOIDCLoginRequest(ctx context.Context, arg CreateOIDCLoginRequestParams) (OidcLoginRequest, error)
+	CreateOrganization(ctx context.Context, name string) (Organization, error)
+	CreatePaperAccount(ctx context.Context, arg CreatePaperAccountParams) (PaperAccount, error)
+	CreatePaperOrder(ctx context.Context, arg CreatePaperOrderParams) (PaperOrder, error)
+	CreatePortfolio(ctx context.Context, arg CreatePortfolioParams) (Portfolio, error)
+	CreatePortfolioTransaction(ctx context.Context, arg CreatePortfolioTransactionParams) (PortfolioTransaction, error)
 	CreateProvider(ctx context.Context, arg CreateProviderParams) (Provider, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTool(ctx context.Context, arg CreateToolParams) (Tool, error)
+	CreateTwoFactorChallenge(ctx context.Context, arg CreateTwoFactorChallengeParams) (TwoFactorChallenge, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserMemory(ctx context.Context, arg CreateUserMemoryParams) (UserMemory, error)
 	CreateUserProviderSetting(ctx context.Context, arg CreateUserProviderSettingParams) (UserProviderSetting, error)
+	CreateWatchlist(ctx context.Context, arg CreateWatchlistParams) (Watchlist, error)
+	CreateWebAuthnCredential(ctx context.Context, arg CreateWebAuthnCredentialParams) (WebauthnCredential, error)
 	DeactivateUser(ctx context.Context, id pgtype.UUID) error
+	DeleteAPIToken(ctx context.Context, id pgtype.UUID) error
+	DeleteAlertRule(ctx context.Context, id pgtype.UUID) error
+	DeleteAlertRuleStates(ctx context.Context, ruleID pgtype.UUID) error
 	DeleteArtifact(ctx context.Context, id pgtype.UUID) error
+	DeleteBrokerConnection(ctx context.Context, id pgtype.UUID) error
+	DeleteCachedEmbeddings(ctx context.Context, contentHashes []string) error
+	DeleteCalendarEvent(ctx context.Context, id pgtype.UUID) error
 	DeleteConversation(ctx context.Context, id pgtype.UUID) error
+	DeleteDocument(ctx context.Context, id pgtype.UUID) error
+	DeleteDocumentChunksByDocumentID(ctx context.Context, documentID pgtype.UUID) error
+	DeleteExpiredRateLimitBuckets(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+	DeleteJournalEntry(ctx context.Context, id pgtype.UUID) error
+	DeleteJournalScreenshots(ctx context.Context, entryID pgtype.UUID) error
+	DeleteKnowledgeBase(ctx context.Context, id pgtype.UUID) error
+	DeleteKnowledgeBaseDocument(ctx context.Context, arg DeleteKnowledgeBaseDocumentParams) error
+	DeleteKnowledgeBaseMember(ctx context.Context, arg DeleteKnowledgeBaseMemberParams) error
 	DeleteMessage(ctx context.Context, id pgtype.UUID) error
 	DeleteModel(ctx context.Context, id pgtype.UUID) error
+	DeleteNotification(ctx context.Context, id pgtype.UUID) error
+	DeleteOrganization(ctx context.Context, id pgtype.UUID) error
+	DeleteOrganizationInvitation(ctx context.Context, id pgtype.UUID) error
+	DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) error
+	DeletePaperAccount(ctx context.Context, id pgtype.UUID) error
+	DeletePortfolio(ctx context.Context, id pgtype.UUID) error
+	DeletePortfolioTransaction(ctx context.Context, id pgtype.UUID) error
 	DeleteProvider(ctx context.Context, id pgtype.UUID) error
+	DeleteRecoveryCodesByUserID(ctx context.Context, userID pgtype.UUID) error
+	DeleteTOTPSecret(ctx context.Context, userID pgtype.UUID) error
 	DeleteTool(ctx context.Context, id pgtype.UUID) error
+	DeleteTwoFactorChallenge(ctx context.Context, id pgtype.UUID) error
+	DeleteUser(ctx context.Context, id pgtype.UUID) error
+	DeleteUserMemory(ctx context.Context, id pgtype.UUID) error
 	DeleteUserProviderSetting(ctx context.Context, id pgtype.UUID) error
-	GetActiveModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]Model, error)
+	DeleteWatchlist(ctx context.Context, id pgtype.UUID) error
+	DeleteWatchlistItem(ctx context.Context, arg DeleteWatchlistItemParams) error
+	DeleteWebAuthnCredential(ctx context.Context, id pgtype.UUID) error
+	DetachKnowledgeBaseFromConversation(ctx context.Context, arg DetachKnowledgeBaseFromConversationParams) error
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (ApiToken, error)
+	GetAPITokenByID(ctx context.Context, id pgtype.UUID) (ApiToken, error)
+	GetAccountDeletion(ctx context.Context, userID pgtype.UUID) (AccountDeletion, error)
+	GetActiveModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]GetActiveModelsByProviderIDRow, error)
 	GetActiveProviders(ctx context.Context) ([]Provider, error)
+	GetAlertEventsByRuleID(ctx context.Context, arg GetAlertEventsByRuleIDParams) ([]AlertEvent, error)
+	GetAlertEventsByUserID(ctx context.Context, arg GetAlertEventsByUserIDParams) ([]AlertEvent, error)
+	GetAlertRuleByID(ctx context.Context, id pgtype.UUID) (AlertRule, error)
+	GetAlertRuleState(ctx context.Context, arg GetAlertRuleStateParams) (AlertRuleState, error)
+	GetAlertRulesByUserID(ctx context.Context, userID pgtype.UUID) ([]AlertRule, error)
+	GetAllModels(ctx context.Context) ([]GetAllModelsRow, error)
 	GetAllProviders(ctx context.Context) ([]Provider, error)
+	GetAllTools(ctx context.Context) ([]Tool, error)
 	GetArtifactByID(ctx context.Context, id pgtype.UUID) (Artifact, error)
+	GetArtifactOwnerID(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
+	GetArtifactVersions(ctx context.Context, id pgtype.UUID) ([]Artifact, error)
 	GetArtifactsByMessageID(ctx context.Context, messageID pgtype.UUID) ([]Artifact, error)
 	GetAvailableModelsForUser(ctx context.Context, userID pgtype.UUID) ([]GetAvailableModelsForUserRow, error)
 	GetAvailableTools(ctx context.Context, providerID pgtype.UUID) ([]Tool, error)
+	GetBrokerConnectionByID(ctx context.Context, id pgtype.UUID) (BrokerConnection, error)
+	GetBrokerConnectionsByUserID(ctx context.Context, userID pgtype.UUID) ([]BrokerConnection, error)
+	GetCachedEmbeddings(ctx context.Context, arg GetCachedEmbeddingsParams) ([]EmbeddingCache, error)
+	GetCalendarEventByID(ctx context.Context, id pgtype.UUID) (CalendarEvent, error)
+	GetCandlesInRange(ctx context.Context, arg GetCandlesInRangeParams) ([]Candle, error)
 	GetConversationByID(ctx context.Context, id pgtype.UUID) (Conversation, error)
 	GetConversationsByUserID(ctx context.Context, arg GetConversationsByUserIDParams) ([]Conversation, error)
+	GetDocumentByID(ctx context.Context, id pgtype.UUID) (Document, error)
+	GetEnabledAlertRules(ctx context.Context) ([]AlertRule, error)
+	GetIdentityByProviderSubject(ctx context.Context, arg GetIdentityByProviderSubjectParams) (Identity, error)
+	GetJournalEntryByID(ctx context.Context, id pgtype.UUID) (JournalEntry, error)
+	GetJournalScreenshotsByEntryIDs(ctx context.Context, entryIds []pgtype.UUID) ([]JournalScreenshot, error)
+	GetKnowledgeBaseForUser(ctx context.Context, arg GetKnowledgeBaseForUserParams) (GetKnowledgeBaseForUserRow, error)
+	GetLatestCandles(ctx context.Context, arg GetLatestCandlesParams) ([]Candle, error)
 	GetMagicLinkByToken(ctx context.Context, token string) (GetMagicLinkByTokenRow, error)
 	GetMessageByID(ctx context.Context, id pgtype.UUID) (Message, error)
 	GetMessageThread(ctx context.Context, parentID pgtype.UUID) ([]Message, error)
 	GetMessagesByConversationID(ctx context.Context, arg GetMessagesByConversationIDParams) ([]Message, error)
 	GetMessagesByConversationIDWithCursor(ctx context.Context, arg GetMessagesByConversationIDWithCursorParams) ([]Message, error)
-	GetModelByID(ctx context.Context, id pgtype.UUID) (Model, error)
-	GetModelByName(ctx context.Context, arg GetModelByNameParams) (Model, error)
-	GetModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]Model, error)
+	GetModelByID(ctx context.Context, id pgtype.UUID) (GetModelByIDRow, error)
+	GetModelByName(ctx context.Context, arg GetModelByNameParams) (GetModelByNameRow, error)
+	GetModelsByProviderID(ctx context.Context, providerID pgtype.UUID) ([]GetModelsByProviderIDRow, error)
+	GetNotificationByID(ctx context.Context, id pgtype.UUID) (Notification, error)
+	GetNotificationsByUserID(ctx context.Context, arg GetNotificationsByUserIDParams) ([]Notification, error)
+	GetOpenPaperOrdersByAccountID(ctx context.Context, accountID pgtype.UUID) ([]PaperOrder, error)
+	GetOrganizationForUser(ctx context.Context, arg GetOrganizationForUserParams) (GetOrganizationForUserRow, error)
+	GetOrganizationInvitationByID(ctx context.Context, id pgtype.UUID) (GetOrganizationInvitationByIDRow, error)
+	GetOrganizationInvitationByMagicLinkID(ctx context.Context, magicLinkID pgtype.UUID) (GetOrganizationInvitationByMagicLinkIDRow, error)
+	GetOrganizationProviderSetting(ctx context.Context, arg GetOrganizationProviderSettingParams) (UserProviderSetting, error)
+	GetPaperAccountByID(ctx context.Context, id pgtype.UUID) (PaperAccount, error)
+	GetPaperAccountByIDForUpdate(ctx context.Context, id pgtype.UUID) (PaperAccount, error)
+	GetPaperAccountsByUserID(ctx context.Context, userID pgtype.UUID) ([]PaperAccount, error)
+	GetPaperOrderByID(ctx context.Context, id pgtype.UUID) (PaperOrder, error)
+	GetPaperOrdersByAccountID(ctx context.Context, arg GetPaperOrdersByAccountIDParams) ([]PaperOrder, error)
+	GetPaperPosition(ctx context.Context, arg GetPaperPositionParams) (PaperPosition, error)
+	GetPaperPositionsByAccountID(ctx context.Context, accountID pgtype.UUID) ([]PaperPosition, error)
+	GetPortfolioAssets(ctx context.Context, portfolioID pgtype.UUID) ([]PortfolioAsset, error)
+	GetPortfolioByID(ctx context.Context, id pgtype.UUID) (Portfolio, error)
+	GetPortfolioTransactionByID(ctx context.Context, id pgtype.UUID) (PortfolioTransaction, error)
+	GetPortfolioTransactions(ctx context.Context, portfolioID pgtype.UUID) ([]PortfolioTransaction, error)
+	GetPortfoliosByUserID(ctx context.Context, userID pgtype.UUID) ([]Portfolio, error)
 	GetProviderByID(ctx context.Context, id pgtype.UUID) (Provider, error)
 	GetProviderByName(ctx context.Context, name string) (Provider, error)
+	GetProviderSettingForUser(ctx context.Context, arg GetProviderSettingForUserParams) (UserProviderSetting, error)
 	GetProvidersWithModels(ctx context.Context) ([]GetProvidersWithModelsRow, error)
 	GetPublicArtifacts(ctx context.Context, arg GetPublicArtifactsParams) ([]Artifact, error)
+	GetSessionByID(ctx context.Context, id pgtype.UUID) (Session, error)
+	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (Session, error)
+	GetTOTPSecretByUserID(ctx context.Context, userID pgtype.UUID) (TotpSecret, error)
 	GetToolByID(ctx context.Context, id pgtype.UUID) (Tool, error)
 	GetToolByName(ctx context.Context, name string) (Tool, error)
+	GetToolMessageByCallID(ctx context.Context, arg GetToolMessageByCallIDParams) (Message, error)
+	GetTwoFactorChallengeByTokenHash(ctx context.Context, tokenHash string) (TwoFactorChallenge, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserByIDIncludingInactive(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserMemoryByID(ctx context.Context, id pgtype.UUID) (UserMemory, error)
 	GetUserProviderSetting(ctx context.Context, arg GetUserProviderSettingParams) (UserProviderSetting, error)
+	GetUserUsageByModel(ctx context.Context, arg GetUserUsageByModelParams) ([]GetUserUsageByModelRow, error)
+	GetWatchlistByID(ctx context.Context, id pgtype.UUID) (Watchlist, error)
+	GetWatchlistItems(ctx context.Context, watchlistID pgtype.UUID) ([]WatchlistItem, error)
+	GetWatchlistsByUserID(ctx context.Context, userID pgtype.UUID) ([]Watchlist, error)
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID []byte) (WebauthnCredential, error)
+	GetWebAuthnCredentialByID(ctx context.Context, id pgtype.UUID) (WebauthnCredential, error)
+	IncrementTwoFactorChallengeAttempts(ctx context.Context, id pgtype.UUID) error
 	InvalidateUserMagicLinks(ctx context.Context, arg InvalidateUserMagicLinksParams) error
+	ListAPITokensByUserID(ctx context.Context, userID pgtype.UUID) ([]ApiToken, error)
+	ListAccessibleConversations(ctx context.Context, arg ListAccessibleConversationsParams) ([]Conversation, error)
+	ListActiveSessionsByUserID(ctx context.Context, userID pgtype.UUID) ([]Session, error)
+	ListAllConversationsByUserID(ctx context.Context, arg ListAllConversationsByUserIDParams) ([]Conversation, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListCalendarEvents(ctx context.Context, arg ListCalendarEventsParams) ([]CalendarEvent, error)
+	ListDocumentChunksByDocumentID(ctx context.Context, documentID pgtype.UUID) ([]DocumentChunk, error)
+	ListDocumentsByKnowledgeBaseID(ctx context.Context, knowledgeBaseID pgtype.UUID) ([]Document, error)
+	ListDocumentsByUserID(ctx context.Context, arg ListDocumentsByUserIDParams) ([]Document, error)
+	ListDueAccountDeletions(ctx context.Context, arg ListDueAccountDeletionsParams) ([]AccountDeletion, error)
+	ListEmbeddedDocumentChunks(ctx context.Context, arg ListEmbeddedDocumentChunksParams) ([]DocumentChunk, error)
+	ListEncryptedBrokerCredentials(ctx context.Context, arg ListEncryptedBrokerCredentialsParams) ([]ListEncryptedBrokerCredentialsRow, error)
+	ListEncryptedProviderAPIKeys(ctx context.Context, arg ListEncryptedProviderAPIKeysParams) ([]ListEncryptedProviderAPIKeysRow, error)
+	ListEncryptedTOTPSecrets(ctx context.Context, arg ListEncryptedTOTPSecretsParams) ([]ListEncryptedTOTPSecretsRow, error)
+	ListIdentitiesByUserID(ctx context.Context, userID pgtype.UUID) ([]Identity, error)
+	ListJournalEntries(ctx context.Context, arg ListJournalEntriesParams) ([]JournalEntry, error)
+	ListKnowledgeBaseMembers(ctx context.Context, knowledgeBaseID pgtype.UUID) ([]ListKnowledgeBaseMembersRow, error)
+	ListKnowledgeBasesByConversationID(ctx context.Context, arg ListKnowledgeBasesByConversationIDParams) ([]ListKnowledgeBasesByConversationIDRow, error)
+	ListKnowledgeBasesForUser(ctx context.Context, userID pgtype.UUID) ([]ListKnowledgeBasesForUserRow, error)
+	ListLatestArtifactsByUserAndType(ctx context.Context, arg ListLatestArtifactsByUserAndTypeParams) ([]Artifact, error)
+	ListMessageEmbeddingsByUserID(ctx context.Context, arg ListMessageEmbeddingsByUserIDParams) ([]MessageEmbedding, error)
+	ListMessageMatchesByIDs(ctx context.Context, messageIds []pgtype.UUID) ([]ListMessageMatchesByIDsRow, error)
+	ListOrganizationInvitations(ctx context.Context, organizationID pgtype.UUID) ([]ListOrganizationInvitationsRow, error)
+	ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]ListOrganizationMembersRow, error)
+	ListOrganizationProviderSettings(ctx context.Context, organizationID pgtype.UUID) ([]UserProviderSetting, error)
+	ListOrganizationsForUser(ctx context.Context, userID pgtype.UUID) ([]ListOrganizationsForUserRow, error)
+	ListReadyDocumentsByConversationID(ctx context.Context, arg ListReadyDocumentsByConversationIDParams) ([]Document, error)
+	ListReadyDocumentsByUserID(ctx context.Context, arg ListReadyDocumentsByUserIDParams) ([]Document, error)
+	ListUserMemoriesByUserID(ctx context.Context, arg ListUserMemoriesByUserIDParams) ([]UserMemory, error)
 	ListUserProviderSettings(ctx context.Context, userID pgtype.UUID) ([]UserProviderSetting, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListWebAuthnCredentialsByUserID(ctx context.Context, userID pgtype.UUID) ([]WebauthnCredential, error)
+	LockRateLimitBucket(ctx context.Context, arg LockRateLimitBucketParams) (RateLimitBucket, error)
 	LogToolUsage(ctx context.Context, arg LogToolUsageParams) (MessageTool, error)
+	MarkAllNotificationsRead(ctx context.Context, userID pgtype.UUID) error
+	MarkNotificationRead(ctx context.Context, id pgtype.UUID) error
+	MarkSessionSecondFactor(ctx context.Context, id pgtype.UUID) error
+	PutCachedEmbedding(ctx context.Context, arg PutCachedEmbeddingParams) error
+	RevokeSessionFamily(ctx context.Context, familyID pgtype.UUID) error
+	RotateSession(ctx context.Context, id pgtype.UUID) error
+	ScheduleAccountDeletion(ctx context.Context, arg ScheduleAccountDeletionParams) (AccountDeletion, error)
+	SearchDocumentChunks(ctx context.Context, arg SearchDocumentChunksParams) ([]SearchDocumentChunksRow, error)
+	SearchMessageEmbeddings(ctx context.Context, arg SearchMessageEmbeddingsParams) ([]SearchMessageEmbeddingsRow, error)
+	SetConversationOrganization(ctx context.Context, arg SetConversationOrganizationParams) error
+	SetDocumentChunkEmbedding(ctx context.Context, arg SetDocumentChunkEmbeddingParams) error
+	TouchAPIToken(ctx context.Context, id pgtype.UUID) error
+	TouchSession(ctx context.Context, id pgtype.UUID) error
+	UpdateAlertEventEmailStatus(ctx context.Context, arg UpdateAlertEventEmailStatusParams) error
+	UpdateAlertRule(ctx context.Context, arg UpdateAlertRuleParams) (AlertRule, error)
 	UpdateArtifact(ctx context.Context, arg UpdateArtifactParams) (Artifact, error)
+	UpdateBrokerConnection(ctx context.Context, arg UpdateBrokerConnectionParams) (BrokerConnection, error)
 	UpdateConversation(ctx context.Context, arg UpdateConversationParams) (Conversation, error)
 	UpdateConversationLastMessageAt(ctx context.Context, arg UpdateConversationLastMessageAtParams) error
 	UpdateConversationTitle(ctx context.Context, arg UpdateConversationTitleParams) error
+	UpdateDocument(ctx context.Context, arg UpdateDocumentParams) (Document, error)
+	UpdateDocumentStatus(ctx context.Context, arg UpdateDocumentStatusParams) (Document, error)
+	UpdateEncryptedBrokerCredentials(ctx context.Context, arg UpdateEncryptedBrokerCredentialsParams) error
+	UpdateEncryptedProviderAPIKey(ctx context.Context, arg UpdateEncryptedProviderAPIKeyParams) error
+	UpdateEncryptedTOTPSecret(ctx context.Context, arg UpdateEncryptedTOTPSecretParams) error
+	UpdateIdentityLogin(ctx context.Context, arg UpdateIdentityLoginParams) error
+	UpdateJournalEntry(ctx context.Context, arg UpdateJournalEntryParams) (JournalEntry, error)
+	UpdateKnowledgeBase(ctx context.Context, arg UpdateKnowledgeBaseParams) (KnowledgeBase, error)
 	UpdateMessage(ctx context.Context, arg UpdateMessageParams) (Message, error)
-	UpdateModel(ctx context.Context, arg UpdateModelParams) (Model, error)
+	UpdateModel(ctx context.Context, arg UpdateModelParams) (UpdateModelRow, error)
+	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
+	UpdatePaperAccountCash(ctx context.Context, arg UpdatePaperAccountCashParams) error
+	UpdatePaperOrderStatus(ctx context.Context, arg UpdatePaperOrderStatusParams) (PaperOrder, error)
+	UpdatePortfolio(ctx context.Context, arg UpdatePortfolioParams) (Portfolio, error)
 	UpdateProvider(ctx context.Context, arg UpdateProviderParams) (Provider, error)
+	UpdateRateLimitBucket(ctx context.Context, arg UpdateRateLimitBucketParams) error
+	UpdateTOTPLastUsedStep(ctx context.Context, arg UpdateTOTPLastUsedStepParams) error
 	UpdateTool(ctx context.Context, arg UpdateToolParams) (Tool, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserMemory(ctx context.Context, arg UpdateUserMemoryParams) (UserMemory, error)
 	UpdateUserProviderSetting(ctx context.Context, arg UpdateUserProviderSettingParams) (UserProviderSetting, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateWatchlist(ctx context.Context, arg UpdateWatchlistParams) (Watchlist, error)
+	UpdateWebAuthnCredentialUsage(ctx context.Context, arg UpdateWebAuthnCredentialUsageParams) error
+	UpsertAlertRuleState(ctx context.Context, arg UpsertAlertRuleStateParams) (AlertRuleState, error)
+	UpsertCalendarEvent(ctx context.Context, arg UpsertCalendarEventParams) (CalendarEvent, error)
+	UpsertCandle(ctx context.Context, arg UpsertCandleParams) (Candle, error)
+	UpsertKnowledgeBaseMember(ctx context.Context, arg UpsertKnowledgeBaseMemberParams) error
+	UpsertOrganizationInvitation(ctx context.Context, arg UpsertOrganizationInvitationParams) (OrganizationInvitation, error)
+	UpsertOrganizationMember(ctx context.Context, arg UpsertOrganizationMemberParams) error
+	UpsertPaperPosition(ctx context.Context, arg UpsertPaperPositionParams) (PaperPosition, error)
+	UpsertPortfolioAsset(ctx context.Context, arg UpsertPortfolioAssetParams) (PortfolioAsset, error)
+	UpsertTOTPSecret(ctx context.Context, arg UpsertTOTPSecretParams) (TotpSecret, error)
+	UpsertWatchlistItem(ctx context.Context, arg UpsertWatchlistItemParams) (WatchlistItem, error)
 	UseMagicLink(ctx context.Context, id pgtype.UUID) (MagicLink, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
 	VerifyUserEmail(ctx context.Context, id pgtype.UUID) (User, error)
 }
 
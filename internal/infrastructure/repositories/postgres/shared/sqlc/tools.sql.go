@@ -57,6 +57,40 @@ func (q *Queries) DeleteTool(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const getAllTools = `-- name: GetAllTools :many
+SELECT id, name, description, schema, provider_id, is_active, created_at, updated_at FROM tools
+ORDER BY name
+`
+
+func (q *Queries) GetAllTools(ctx context.Context) ([]Tool, error) {
+	rows, err := q.db.Query(ctx, getAllTools)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tool{}
+	for rows.Next() {
+		var i Tool
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Schema,
+			&i.ProviderID,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAvailableTools = `-- name: GetAvailableTools :many
 SELECT id, name, description, schema, provider_id, is_active, created_at, updated_at FROM tools
 WHERE is_active = true AND (provider_id IS NULL OR provider_id = $1)
@@ -12,13 +12,14 @@ import (
 )
 
 const createUserProviderSetting = `-- name: CreateUserProviderSetting :one
-INSERT INTO user_provider_settings (user_id, provider_id, encrypted_api_key, api_base_override, is_active)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at
+INSERT INTO user_provider_settings (user_id, organization_id, provider_id, encrypted_api_key, api_base_override, is_active)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id
 `
 
 type CreateUserProviderSettingParams struct {
 	UserID          pgtype.UUID `json:"user_id"`
+	OrganizationID  pgtype.UUID `json:"organization_id"`
 	ProviderID      pgtype.UUID `json:"provider_id"`
 	EncryptedApiKey pgtype.Text `json:"encrypted_api_key"`
 	ApiBaseOverride pgtype.Text `json:"api_base_override"`
@@ -28,6 +29,7 @@ type CreateUserProviderSettingParams struct {
 func (q *Queries) CreateUserProviderSetting(ctx context.Context, arg CreateUserProviderSettingParams) (UserProviderSetting, error) {
 	row := q.db.QueryRow(ctx, createUserProviderSetting,
 		arg.UserID,
+		arg.OrganizationID,
 		arg.ProviderID,
 		arg.EncryptedApiKey,
 		arg.ApiBaseOverride,
@@ -43,6 +45,7 @@ func (q *Queries) CreateUserProviderSetting(ctx context.Context, arg CreateUserP
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -57,8 +60,74 @@ func (q *Queries) DeleteUserProviderSetting(ctx context.Context, id pgtype.UUID)
 	return err
 }
 
+const getOrganizationProviderSetting = `-- name: GetOrganizationProviderSetting :one
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
+WHERE organization_id = $1 AND provider_id = $2
+`
+
+type GetOrganizationProviderSettingParams struct {
+	OrganizationID pgtype.UUID `json:"organization_id"`
+	ProviderID     pgtype.UUID `json:"provider_id"`
+}
+
+func (q *Queries) GetOrganizationProviderSetting(ctx context.Context, arg GetOrganizationProviderSettingParams) (UserProviderSetting, error) {
+	row := q.db.QueryRow(ctx, getOrganizationProviderSetting, arg.OrganizationID, arg.ProviderID)
+	var i UserProviderSetting
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProviderID,
+		&i.EncryptedApiKey,
+		&i.ApiBaseOverride,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
+const getProviderSettingForUser = `-- name: GetProviderSettingForUser :one
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
+WHERE provider_id = $1
+  AND (user_id = $2 OR organization_id IN (
+      SELECT m.organization_id FROM organization_members m WHERE m.user_id = $2))
+ORDER BY
+    (COALESCE(is_active, false) AND COALESCE(encrypted_api_key, '') <> '') DESC,
+    CASE
+        WHEN organization_id = $3::uuid THEN 0
+        WHEN user_id = $2 THEN 1
+        ELSE 2
+    END,
+    created_at ASC
+LIMIT 1
+`
+
+type GetProviderSettingForUserParams struct {
+	ProviderID     pgtype.UUID `json:"provider_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+	OrganizationID pgtype.UUID `json:"organization_id"`
+}
+
+func (q *Queries) GetProviderSettingForUser(ctx context.Context, arg GetProviderSettingForUserParams) (UserProviderSetting, error) {
+	row := q.db.QueryRow(ctx, getProviderSettingForUser, arg.ProviderID, arg.UserID, arg.OrganizationID)
+	var i UserProviderSetting
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProviderID,
+		&i.EncryptedApiKey,
+		&i.ApiBaseOverride,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.OrganizationID,
+	)
+	return i, err
+}
+
 const getUserProviderSetting = `-- name: GetUserProviderSetting :one
-SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at FROM user_provider_settings
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
 WHERE user_id = $1 AND provider_id = $2
 `
 
@@ -79,12 +148,49 @@ func (q *Queries) GetUserProviderSetting(ctx context.Context, arg GetUserProvide
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
+const listOrganizationProviderSettings = `-- name: ListOrganizationProviderSettings :many
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
+WHERE organization_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListOrganizationProviderSettings(ctx context.Context, organizationID pgtype.UUID) ([]UserProviderSetting, error) {
+	rows, err := q.db.Query(ctx, listOrganizationProviderSettings, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserProviderSetting{}
+	for rows.Next() {
+		var i UserProviderSetting
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProviderID,
+			&i.EncryptedApiKey,
+			&i.ApiBaseOverride,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrganizationID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserProviderSettings = `-- name: ListUserProviderSettings :many
-SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at FROM user_provider_settings
+SELECT id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id FROM user_provider_settings
 WHERE user_id = $1 AND is_active = true
 ORDER BY created_at DESC
 `
@@ -107,6 +213,7 @@ func (q *Queries) ListUserProviderSettings(ctx context.Context, userID pgtype.UU
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -125,7 +232,7 @@ SET
     api_base_override = $3,
     is_active = $4
 WHERE id = $1
-RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at
+RETURNING id, user_id, provider_id, encrypted_api_key, api_base_override, is_active, created_at, updated_at, organization_id
 `
 
 type UpdateUserProviderSettingParams struct {
@@ -152,6 +259,7 @@ func (q *Queries) UpdateUserProviderSetting(ctx context.Context, arg UpdateUserP
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -11,10 +11,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+`
+
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, first_name, last_name, email_verified)
 VALUES ($1, $2, $3, $4)
-RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role
 `
 
 type CreateUserParams struct {
@@ -42,6 +53,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
 	)
 	return i, err
 }
@@ -57,8 +70,18 @@ func (q *Queries) DeactivateUser(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUser, id)
+	return err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at FROM users 
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users 
 WHERE email = $1 AND is_active = true
 `
 
@@ -75,12 +98,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at FROM users 
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users 
 WHERE id = $1 AND is_active = true
 `
 
@@ -97,25 +122,131 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
+	)
+	return i, err
+}
+
+const getUserByIDIncludingInactive = `-- name: GetUserByIDIncludingInactive :one
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserByIDIncludingInactive(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByIDIncludingInactive, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.EmailVerified,
+		&i.FirstName,
+		&i.LastName,
+		&i.AvatarUrl,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
 	)
 	return i, err
 }
 
+const listUsers = `-- name: ListUsers :many
+SELECT id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role FROM users
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListUsersParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.EmailVerified,
+			&i.FirstName,
+			&i.LastName,
+			&i.AvatarUrl,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.MemoryEnabled,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users 
-SET first_name = $2, last_name = $3, updated_at = NOW()
+SET first_name = $2, last_name = $3, memory_enabled = $4, updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role
 `
 
 type UpdateUserParams struct {
-	ID        pgtype.UUID `json:"id"`
-	FirstName pgtype.Text `json:"first_name"`
-	LastName  pgtype.Text `json:"last_name"`
+	ID            pgtype.UUID `json:"id"`
+	FirstName     pgtype.Text `json:"first_name"`
+	LastName      pgtype.Text `json:"last_name"`
+	MemoryEnabled pgtype.Bool `json:"memory_enabled"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, updateUser, arg.ID, arg.FirstName, arg.LastName)
+	row := q.db.QueryRow(ctx, updateUser,
+		arg.ID,
+		arg.FirstName,
+		arg.LastName,
+		arg.MemoryEnabled,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.EmailVerified,
+		&i.FirstName,
+		&i.LastName,
+		&i.AvatarUrl,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET role = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role
+`
+
+type UpdateUserRoleParams struct {
+	ID   pgtype.UUID `json:"id"`
+	Role string      `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, updateUserRole, arg.ID, arg.Role)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -127,6 +258,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
 	)
 	return i, err
 }
@@ -135,7 +268,7 @@ const verifyUserEmail = `-- name: VerifyUserEmail :one
 UPDATE users 
 SET email_verified = true, updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at
+RETURNING id, email, email_verified, first_name, last_name, avatar_url, is_active, created_at, updated_at, memory_enabled, role
 `
 
 func (q *Queries) VerifyUserEmail(ctx context.Context, id pgtype.UUID) (User, error) {
@@ -151,6 +284,8 @@ func (q *Queries) VerifyUserEmail(ctx context.Context, id pgtype.UUID) (User, er
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MemoryEnabled,
+		&i.Role,
 	)
 	return i, err
 }
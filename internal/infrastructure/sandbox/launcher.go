@@ -0,0 +1,49 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// launcherArg is the first argument that makes the server binary act as the
+// sandbox launcher instead of starting the server.
+const launcherArg = "__sandbox-exec"
+
+// setupErrorFD is the descriptor on which the launcher reports setup failures.
+const setupErrorFD = 3
+
+// launchSpec tells the launcher how to confine the interpreter.
+type launchSpec struct {
+	Workspace   string   `json:"workspace"`
+	Isolate     bool     `json:"isolate"`
+	HiddenPaths []string `json:"hidden_paths,omitempty"`
+	CPUSeconds  uint64   `json:"cpu_seconds"`
+	MemoryBytes uint64   `json:"memory_bytes"`
+	FileBytes   uint64   `json:"file_bytes"`
+	OpenFiles   uint64   `json:"open_files"`
+}
+
+// RunLauncherIfRequested turns the process into the sandbox launcher when it
+// was started as one and never returns in that case. It must be called at the
+// very beginning of main, before any other initialization.
+func RunLauncherIfRequested() {
+	if len(os.Args) < 4 || os.Args[1] != launcherArg {
+		return
+	}
+
+	setupErrors := os.NewFile(setupErrorFD, "setup-errors")
+	var spec launchSpec
+	if err := json.Unmarshal([]byte(os.Args[2]), &spec); err != nil {
+		failLaunch(setupErrors, fmt.Errorf("invalid spec: %w", err))
+	}
+	// execInSandbox only returns on failure.
+	failLaunch(setupErrors, execInSandbox(&spec, os.Args[3:]))
+}
+
+func failLaunch(setupErrors *os.File, err error) {
+	if setupErrors != nil {
+		fmt.Fprintln(setupErrors, err)
+	}
+	os.Exit(127)
+}
@@ -0,0 +1,215 @@
+//go:build linux
+
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+
+	"trading-alchemist/internal/domain/services"
+
+	"golang.org/x/sys/unix"
+)
+
+// Securebits from linux/securebits.h.
+const (
+	secbitNoRoot              = 1 << 0
+	secbitNoRootLocked        = 1 << 1
+	secbitNoSetuidFixup       = 1 << 2
+	secbitNoSetuidFixupLocked = 1 << 3
+)
+
+// sysProcAttr starts the launcher in its own process group, so a timeout kills
+// everything the script started, and with isolation in new namespaces. Root in
+// the user namespace is the server's user outside of it; it only has the
+// capabilities the launcher needs for its mounts, which it drops before exec.
+func sysProcAttr(isolate bool) *syscall.SysProcAttr {
+	attr := &syscall.SysProcAttr{
+		Setpgid:   true,
+		Pdeathsig: syscall.SIGKILL,
+	}
+	if isolate {
+		attr.Cloneflags = syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET |
+			syscall.CLONE_NEWPID | syscall.CLONE_NEWIPC | syscall.CLONE_NEWUTS
+		attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}}
+		attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}}
+	}
+	return attr
+}
+
+func killProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGKILL)
+}
+
+// execInSandbox confines the launcher and replaces it with the interpreter.
+func execInSandbox(spec *launchSpec, argv []string) error {
+	// Capabilities and securebits are per thread and survive exec only on the
+	// thread that calls it.
+	runtime.LockOSThread()
+
+	if spec.Isolate {
+		if err := isolateFilesystem(spec); err != nil {
+			return err
+		}
+	}
+	if err := os.Chdir(spec.Workspace); err != nil {
+		return fmt.Errorf("chdir to workspace: %w", err)
+	}
+	if err := setLimits(spec); err != nil {
+		return err
+	}
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("set no_new_privs: %w", err)
+	}
+	if spec.Isolate {
+		if err := dropCapabilities(); err != nil {
+			return err
+		}
+	}
+
+	unix.CloseOnExec(setupErrorFD)
+	return syscall.Exec(argv[0], argv, os.Environ())
+}
+
+// isolateFilesystem rearranges the mounts of the new mount namespace: other
+// workspaces and the hidden paths are covered by empty read-only file systems,
+// the inputs are mounted read-only and /proc only shows the sandbox's processes.
+func isolateFilesystem(spec *launchSpec) error {
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("make mounts private: %w", err)
+	}
+
+	// Cover the parent of the workspace and mount the workspace back into it.
+	workspace, err := unix.Open(spec.Workspace, unix.O_PATH|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("open workspace: %w", err)
+	}
+	parent := filepath.Dir(spec.Workspace)
+	if err := unix.Mount("tmpfs", parent, "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "size=64k,mode=0755"); err != nil {
+		return fmt.Errorf("cover %s: %w", parent, err)
+	}
+	if err := os.Mkdir(spec.Workspace, 0o700); err != nil {
+		return fmt.Errorf("recreate workspace: %w", err)
+	}
+	if err := unix.Mount(fmt.Sprintf("/proc/self/fd/%d", workspace), spec.Workspace, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("mount workspace: %w", err)
+	}
+	unix.Close(workspace)
+	if err := remountReadOnly(parent); err != nil {
+		return err
+	}
+
+	for _, path := range spec.HiddenPaths {
+		if err := hidePath(path); err != nil {
+			return err
+		}
+	}
+
+	dataDir := filepath.Join(spec.Workspace, services.CodeRunDataDir)
+	if err := unix.Mount(dataDir, dataDir, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind inputs: %w", err)
+	}
+	if err := remountReadOnly(dataDir); err != nil {
+		return err
+	}
+
+	// A proc mount for the new PID namespace is refused when parts of the
+	// current one are masked, as in most containers; then /proc is left empty.
+	if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		if err := unix.Mount("tmpfs", "/proc", "tmpfs", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "size=0"); err != nil {
+			return fmt.Errorf("hide /proc: %w", err)
+		}
+	}
+	return nil
+}
+
+// hidePath covers a directory with an empty read-only tmpfs and a file with
+// /dev/null. Paths that do not exist are ignored.
+func hidePath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	if info.IsDir() {
+		if err := unix.Mount("tmpfs", path, "tmpfs", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "size=0"); err != nil {
+			return fmt.Errorf("hide %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := unix.Mount("/dev/null", path, "", unix.MS_BIND, ""); err != nil {
+		return fmt.Errorf("hide %s: %w", path, err)
+	}
+	return remountReadOnly(path)
+}
+
+// remountReadOnly makes a mount point read-only. The flags of the existing
+// mount are carried over, since an unprivileged remount may not clear them.
+func remountReadOnly(path string) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return fmt.Errorf("statfs %s: %w", path, err)
+	}
+	flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY)
+	for stFlag, msFlag := range map[int64]uintptr{
+		unix.ST_NOSUID:      unix.MS_NOSUID,
+		unix.ST_NODEV:       unix.MS_NODEV,
+		unix.ST_NOEXEC:      unix.MS_NOEXEC,
+		unix.ST_NOATIME:     unix.MS_NOATIME,
+		unix.ST_NODIRATIME:  unix.MS_NODIRATIME,
+		unix.ST_RELATIME:    unix.MS_RELATIME,
+		unix.ST_SYNCHRONOUS: unix.MS_SYNCHRONOUS,
+	} {
+		if int64(st.Flags)&stFlag != 0 {
+			flags |= msFlag
+		}
+	}
+	if err := unix.Mount("", path, "", flags, ""); err != nil {
+		return fmt.Errorf("remount %s read-only: %w", path, err)
+	}
+	return nil
+}
+
+// setLimits applies the resource limits, which the interpreter inherits.
+func setLimits(spec *launchSpec) error {
+	limits := []struct {
+		resource int
+		value    uint64
+	}{
+		{unix.RLIMIT_CPU, spec.CPUSeconds},
+		{unix.RLIMIT_AS, spec.MemoryBytes},
+		{unix.RLIMIT_FSIZE, spec.FileBytes},
+		{unix.RLIMIT_NOFILE, spec.OpenFiles},
+		{unix.RLIMIT_CORE, 0},
+	}
+	for _, limit := range limits {
+		rlimit := unix.Rlimit{Cur: limit.value, Max: limit.value}
+		if err := unix.Setrlimit(limit.resource, &rlimit); err != nil {
+			return fmt.Errorf("setrlimit %d: %w", limit.resource, err)
+		}
+	}
+	return nil
+}
+
+// dropCapabilities makes sure the interpreter runs without capabilities even
+// though it runs as root in the user namespace.
+func dropCapabilities() error {
+	const securebits = secbitNoRoot | secbitNoRootLocked | secbitNoSetuidFixup | secbitNoSetuidFixupLocked
+	if err := unix.Prctl(unix.PR_SET_SECUREBITS, securebits, 0, 0, 0); err != nil {
+		return fmt.Errorf("set securebits: %w", err)
+	}
+	if err := unix.Prctl(unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0, 0, 0); err != nil && err != unix.EINVAL {
+		return fmt.Errorf("clear ambient capabilities: %w", err)
+	}
+	for capability := uintptr(0); capability <= 63; capability++ {
+		if err := unix.Prctl(unix.PR_CAPBSET_DROP, capability, 0, 0, 0); err != nil {
+			if err == unix.EINVAL {
+				break
+			}
+			return fmt.Errorf("drop capability %d: %w", capability, err)
+		}
+	}
+	return nil
+}
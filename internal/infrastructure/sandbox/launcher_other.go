@@ -0,0 +1,26 @@
+//go:build !linux
+
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+func sysProcAttr(isolate bool) *syscall.SysProcAttr {
+	return nil
+}
+
+func killProcessGroup(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
+
+func execInSandbox(spec *launchSpec, argv []string) error {
+	return fmt.Errorf("the code sandbox is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,345 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/services"
+)
+
+const (
+	// scriptName is the file the script is written to in the workspace.
+	scriptName = "main.py"
+	// tmpDir is the script's TMPDIR inside the workspace.
+	tmpDir = "tmp"
+	// maxOpenFiles caps the file descriptors of a script.
+	maxOpenFiles = 256
+	// launcherWaitDelay is how long to wait for output after the script was killed.
+	launcherWaitDelay = 2 * time.Second
+)
+
+// Sandbox runs Python scripts in a subprocess with resource limits and, on
+// Linux, in new user, network, mount and PID namespaces: the script has no
+// network, sees only its own processes, reads its inputs from a read-only
+// mount and cannot see the hidden paths, by default the server's working
+// directory with its configuration files.
+//
+// The subprocess is the server binary itself, started as a launcher that sets
+// up the isolation and rlimits before it executes the interpreter, so the
+// limits are in place before the first line of the script runs.
+type Sandbox struct {
+	cfg         config.SandboxConfig
+	interpreter string
+	launcher    string
+	workDir     string
+	hiddenPaths []string
+}
+
+// NewSandbox resolves the interpreter and the launcher and checks that the
+// hidden paths cover neither the interpreter nor the workspaces.
+func NewSandbox(cfg config.SandboxConfig) (*Sandbox, error) {
+	interpreter, err := exec.LookPath(cfg.Interpreter)
+	if err != nil {
+		return nil, fmt.Errorf("sandbox interpreter %q not found: %w", cfg.Interpreter, err)
+	}
+	if interpreter, err = filepath.EvalSymlinks(interpreter); err != nil {
+		return nil, fmt.Errorf("failed to resolve sandbox interpreter: %w", err)
+	}
+	if interpreter, err = filepath.Abs(interpreter); err != nil {
+		return nil, fmt.Errorf("failed to resolve sandbox interpreter: %w", err)
+	}
+	launcher, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate sandbox launcher: %w", err)
+	}
+
+	workDir := cfg.WorkDir
+	if workDir == "" {
+		workDir = os.TempDir()
+	}
+	if workDir, err = filepath.Abs(workDir); err != nil {
+		return nil, fmt.Errorf("invalid sandbox work directory: %w", err)
+	}
+	if workDir == "/" {
+		// The work directory is covered inside the sandbox to hide other runs.
+		return nil, fmt.Errorf("the root directory cannot be the sandbox work directory")
+	}
+
+	hidden := cfg.HiddenPaths
+	if len(hidden) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		hidden = []string{wd}
+	}
+	var hiddenPaths []string
+	for _, p := range hidden {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sandbox hidden path %q: %w", p, err)
+		}
+		if abs == "/" {
+			return nil, fmt.Errorf("the root directory cannot be hidden from the sandbox")
+		}
+		for _, needed := range []string{interpreter, workDir} {
+			if isWithin(needed, abs) {
+				return nil, fmt.Errorf("sandbox hidden path %s contains %s", abs, needed)
+			}
+		}
+		hiddenPaths = append(hiddenPaths, abs)
+	}
+
+	return &Sandbox{
+		cfg:         cfg,
+		interpreter: interpreter,
+		launcher:    launcher,
+		workDir:     workDir,
+		hiddenPaths: hiddenPaths,
+	}, nil
+}
+
+// Language returns the language of the scripts the sandbox runs.
+func (s *Sandbox) Language() string {
+	return "python"
+}
+
+// Run executes the script in a fresh workspace that is removed afterwards.
+func (s *Sandbox) Run(ctx context.Context, run *services.CodeRun) (*services.CodeRunResult, error) {
+	timeout := s.cfg.Timeout
+	if run.Timeout > 0 && run.Timeout < timeout {
+		timeout = run.Timeout
+	}
+
+	workspace, err := os.MkdirTemp(s.workDir, "run-code-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox workspace: %w", err)
+	}
+	defer removeWorkspace(workspace)
+	if err := prepareWorkspace(workspace, run); err != nil {
+		return nil, err
+	}
+
+	spec, err := json.Marshal(&launchSpec{
+		Workspace:   workspace,
+		Isolate:     s.cfg.Isolate,
+		HiddenPaths: s.hiddenPaths,
+		CPUSeconds:  uint64(timeout.Seconds()) + 1,
+		MemoryBytes: uint64(s.cfg.MemoryMB) << 20,
+		FileBytes:   uint64(s.cfg.MaxFileMB) << 20,
+		OpenFiles:   maxOpenFiles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sandbox spec: %w", err)
+	}
+
+	// Setup failures of the launcher are reported on a pipe that is closed
+	// when the interpreter starts, so they cannot be confused with script output.
+	setupErrors, setupWriter, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox pipe: %w", err)
+	}
+	defer setupErrors.Close()
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, s.launcher, launcherArg, string(spec), s.interpreter, "-I", scriptName)
+	cmd.Dir = workspace
+	cmd.Env = []string{
+		"PATH=/usr/local/bin:/usr/bin:/bin",
+		"HOME=" + workspace,
+		"TMPDIR=" + filepath.Join(workspace, tmpDir),
+		"LANG=C.UTF-8",
+		"PYTHONDONTWRITEBYTECODE=1",
+		"PYTHONUNBUFFERED=1",
+		"MPLBACKEND=Agg",
+		// Thread pools of numerical libraries reserve address space per thread.
+		"OPENBLAS_NUM_THREADS=1",
+		"OMP_NUM_THREADS=1",
+	}
+	cmd.ExtraFiles = []*os.File{setupWriter}
+	cmd.SysProcAttr = sysProcAttr(s.cfg.Isolate)
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd.Process.Pid)
+	}
+	cmd.WaitDelay = launcherWaitDelay
+	maxOutput := s.cfg.MaxOutputKB << 10
+	stdout := &limitedBuffer{limit: maxOutput}
+	stderr := &limitedBuffer{limit: maxOutput}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	startedAt := time.Now()
+	if err := cmd.Start(); err != nil {
+		setupWriter.Close()
+		return nil, fmt.Errorf("failed to start sandbox: %w", err)
+	}
+	setupWriter.Close()
+	waitErr := cmd.Wait()
+	duration := time.Since(startedAt)
+
+	if setupErr, _ := io.ReadAll(setupErrors); len(setupErr) > 0 {
+		return nil, fmt.Errorf("sandbox setup failed: %s", strings.TrimSpace(string(setupErr)))
+	}
+
+	result := &services.CodeRunResult{
+		TimedOut:        runCtx.Err() == context.DeadlineExceeded,
+		Duration:        duration,
+		Stdout:          stdout.String(),
+		Stderr:          stderr.String(),
+		StdoutTruncated: stdout.truncated,
+		StderrTruncated: stderr.truncated,
+	}
+	if waitErr != nil {
+		exitErr, ok := waitErr.(*exec.ExitError)
+		if !ok && !result.TimedOut {
+			return nil, fmt.Errorf("failed to run sandbox: %w", waitErr)
+		}
+		result.ExitCode = -1
+		if ok {
+			result.ExitCode = exitErr.ExitCode()
+			if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() && !result.TimedOut {
+				// Exceeding the CPU time limit ends the script with a signal.
+				result.Stderr += fmt.Sprintf("\n[killed by signal: %s]", status.Signal())
+			}
+		}
+	}
+
+	result.Outputs, result.SkippedOutputs, err = s.collectOutputs(workspace)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// prepareWorkspace writes the script and the read-only inputs.
+func prepareWorkspace(workspace string, run *services.CodeRun) error {
+	dataDir := filepath.Join(workspace, services.CodeRunDataDir)
+	for _, dir := range []string{dataDir, filepath.Join(workspace, services.CodeRunOutputDir), filepath.Join(workspace, tmpDir)} {
+		if err := os.Mkdir(dir, 0o700); err != nil {
+			return fmt.Errorf("failed to create sandbox directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(workspace, scriptName), []byte(run.Code), 0o400); err != nil {
+		return fmt.Errorf("failed to write sandbox script: %w", err)
+	}
+	for _, input := range run.Inputs {
+		if input.Name == "" || input.Name != filepath.Base(input.Name) || strings.HasPrefix(input.Name, ".") {
+			return fmt.Errorf("invalid sandbox input name %q", input.Name)
+		}
+		// O_EXCL rejects duplicate names.
+		f, err := os.OpenFile(filepath.Join(dataDir, input.Name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o400)
+		if err != nil {
+			return fmt.Errorf("failed to write sandbox input %s: %w", input.Name, err)
+		}
+		_, err = f.Write(input.Content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write sandbox input %s: %w", input.Name, err)
+		}
+	}
+	// The inputs are copies; in isolation they are also mounted read-only, without
+	// it the permissions keep scripts from changing them by accident.
+	if err := os.Chmod(dataDir, 0o500); err != nil {
+		return fmt.Errorf("failed to protect sandbox inputs: %w", err)
+	}
+	return nil
+}
+
+// collectOutputs reads the regular files the script wrote to the output
+// directory, in lexical order, up to the configured count.
+func (s *Sandbox) collectOutputs(workspace string) ([]services.CodeFile, []string, error) {
+	outputDir := filepath.Join(workspace, services.CodeRunOutputDir)
+	maxFileBytes := int64(s.cfg.MaxFileMB) << 20
+	var outputs []services.CodeFile
+	var skipped []string
+	err := filepath.WalkDir(outputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Directories the script made unreadable are skipped.
+			if path == outputDir {
+				return err
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(outputDir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if len(outputs) >= s.cfg.MaxOutputFiles || info.Size() > maxFileBytes {
+			skipped = append(skipped, name)
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			skipped = append(skipped, name)
+			return nil
+		}
+		outputs = append(outputs, services.CodeFile{Name: filepath.ToSlash(name), Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to collect sandbox outputs: %w", err)
+	}
+	return outputs, skipped, nil
+}
+
+// removeWorkspace deletes the workspace, first restoring write access to
+// directories the script or the input protection made read-only.
+func removeWorkspace(workspace string) {
+	filepath.WalkDir(workspace, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			os.Chmod(path, 0o700)
+		}
+		return nil
+	})
+	os.RemoveAll(workspace)
+}
+
+// isWithin reports whether path is dir or lies below it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest,
+// so a chatty script cannot exhaust the server's memory.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return strings.ToValidUTF8(b.buf.String(), "�")
+}
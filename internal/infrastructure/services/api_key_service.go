@@ -22,9 +22,10 @@ func NewAPIKeyService(userProviderRepo chat.UserProviderSettingRepository) servi
 	}
 }
 
-// IsProviderConfigured checks if a user has an active API key for a provider
+// IsProviderConfigured checks if a user has an active API key for a provider,
+// their own or one of their organizations'
 func (s *APIKeyServiceImpl) IsProviderConfigured(ctx context.Context, userID, providerID uuid.UUID) (bool, error) {
-	setting, err := s.userProviderRepo.GetByUserIDAndProviderID(ctx, userID, providerID)
+	setting, err := s.userProviderRepo.GetForUser(ctx, userID, providerID, nil)
 	if err != nil {
 		if err == errors.ErrUserProviderSettingNotFound {
 			return false, nil
@@ -40,9 +41,9 @@ func (s *APIKeyServiceImpl) IsProviderConfigured(ctx context.Context, userID, pr
 	return isConfigured, nil
 }
 
-// GetUserProviderConfig returns the configuration for a user-provider combination
+// GetUserProviderConfig returns the configuration a user's requests to a provider run with
 func (s *APIKeyServiceImpl) GetUserProviderConfig(ctx context.Context, userID, providerID uuid.UUID) (*chat.UserProviderSetting, error) {
-	setting, err := s.userProviderRepo.GetByUserIDAndProviderID(ctx, userID, providerID)
+	setting, err := s.userProviderRepo.GetForUser(ctx, userID, providerID, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get provider configuration: %w", err)
 	}
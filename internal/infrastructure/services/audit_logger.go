@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"trading-alchemist/internal/domain/audit"
+	"trading-alchemist/internal/domain/services"
+	"trading-alchemist/internal/infrastructure/database"
+)
+
+// AuditLoggerImpl writes audit events to the audit_events table.
+type AuditLoggerImpl struct {
+	dbService *database.Service
+}
+
+// NewAuditLogger creates a new audit logger
+func NewAuditLogger(dbService *database.Service) services.AuditLogger {
+	return &AuditLoggerImpl{dbService: dbService}
+}
+
+// Log records an event in its own transaction. The client IP and user agent
+// are taken from the request info of ctx when the event has none.
+func (l *AuditLoggerImpl) Log(ctx context.Context, event *audit.Event) {
+	if info := audit.RequestInfoFromContext(ctx); info != nil {
+		if event.IPAddress == nil && info.IPAddress != "" {
+			event.IPAddress = &info.IPAddress
+		}
+		if event.UserAgent == nil && info.UserAgent != "" {
+			event.UserAgent = &info.UserAgent
+		}
+	}
+
+	err := l.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		_, err := provider.AuditEvent().Create(ctx, event)
+		return err
+	})
+	if err != nil {
+		log.Printf("Failed to record audit event %s: %v", event.Action, err)
+	}
+}
@@ -0,0 +1,414 @@
+package vectorindex
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"trading-alchemist/internal/domain/document"
+
+	"github.com/google/uuid"
+)
+
+// HNSW parameters. See Malkov and Yashunin, "Efficient and robust approximate
+// nearest neighbor search using Hierarchical Navigable Small World graphs".
+const (
+	hnswM              = 16  // Links per node on the upper layers
+	hnswMaxLinks0      = 32  // Links per node on the bottom layer
+	hnswEfConstruction = 64  // Candidates considered when linking a new node
+	hnswMinEfSearch    = 100 // Minimum candidates considered by a search
+
+	// Searches over at most this many chunks, or over less than a tenth of
+	// the graph, compare every chunk instead of walking the graph.
+	bruteForceLimit    = 2000
+	bruteForceFraction = 10
+)
+
+// MemoryIndex is an in-process vector index. It keeps one HNSW graph per
+// embedding dimension, so chunks embedded with different models never mix.
+// Removed chunks are marked deleted and the graph is rebuilt once most of it
+// is deleted.
+type MemoryIndex struct {
+	mu     sync.RWMutex
+	graphs map[int]*hnswGraph
+	rng    *rand.Rand
+}
+
+// NewMemoryIndex creates an empty MemoryIndex.
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		graphs: make(map[int]*hnswGraph),
+		rng:    rand.New(rand.NewSource(1)),
+	}
+}
+
+func (i *MemoryIndex) Add(ctx context.Context, chunks []*document.Chunk) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, chunk := range chunks {
+		if len(chunk.Embedding) == 0 {
+			continue
+		}
+		dims := len(chunk.Embedding)
+		graph, ok := i.graphs[dims]
+		if !ok {
+			graph = newHNSWGraph(i.rng)
+			i.graphs[dims] = graph
+		}
+		graph.insert(chunk)
+	}
+	return nil
+}
+
+func (i *MemoryIndex) Remove(ctx context.Context, documentID uuid.UUID) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for dims, graph := range i.graphs {
+		graph.removeDocument(documentID)
+		if graph.live == 0 {
+			delete(i.graphs, dims)
+		} else if graph.deleted > graph.live && graph.deleted > bruteForceLimit {
+			i.graphs[dims] = graph.rebuild(i.rng)
+		}
+	}
+	return nil
+}
+
+func (i *MemoryIndex) Search(ctx context.Context, query []float32, documentIDs []uuid.UUID, limit int) ([]*document.ChunkMatch, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	graph, ok := i.graphs[len(query)]
+	if !ok || limit <= 0 {
+		return nil, nil
+	}
+	return graph.search(normalize(query), documentIDs, limit), nil
+}
+
+// --- HNSW graph ---
+
+type hnswNode struct {
+	chunk   *document.Chunk
+	vector  []float32 // Normalized embedding
+	links   [][]int32 // Neighbors per layer, from the bottom
+	deleted bool
+}
+
+type hnswGraph struct {
+	nodes      []*hnswNode
+	byDocument map[uuid.UUID][]int32 // Live nodes per document
+	byChunk    map[uuid.UUID]int32
+	entry      int32
+	maxLayer   int
+	levelMult  float64
+	rng        *rand.Rand
+	live       int
+	deleted    int
+}
+
+func newHNSWGraph(rng *rand.Rand) *hnswGraph {
+	return &hnswGraph{
+		byDocument: make(map[uuid.UUID][]int32),
+		byChunk:    make(map[uuid.UUID]int32),
+		entry:      -1,
+		levelMult:  1 / math.Log(hnswM),
+		rng:        rng,
+	}
+}
+
+// insert links a chunk into the graph, replacing an earlier copy of it.
+func (g *hnswGraph) insert(chunk *document.Chunk) {
+	if old, ok := g.byChunk[chunk.ID]; ok {
+		g.markDeleted(old)
+		ids := g.byDocument[g.nodes[old].chunk.DocumentID]
+		for i, other := range ids {
+			if other == old {
+				g.byDocument[g.nodes[old].chunk.DocumentID] = append(ids[:i:i], ids[i+1:]...)
+				break
+			}
+		}
+	}
+
+	stored := *chunk
+	stored.Embedding = nil
+	node := &hnswNode{chunk: &stored, vector: normalize(chunk.Embedding)}
+	id := int32(len(g.nodes))
+	layer := int(-math.Log(1-g.rng.Float64()) * g.levelMult)
+	node.links = make([][]int32, layer+1)
+	g.nodes = append(g.nodes, node)
+	g.byChunk[chunk.ID] = id
+	g.byDocument[chunk.DocumentID] = append(g.byDocument[chunk.DocumentID], id)
+	g.live++
+
+	if g.entry < 0 {
+		g.entry = id
+		g.maxLayer = layer
+		return
+	}
+
+	entry := g.entry
+	for l := g.maxLayer; l > layer; l-- {
+		entry = g.greedy(node.vector, entry, l)
+	}
+	for l := min(layer, g.maxLayer); l >= 0; l-- {
+		candidates := g.searchLayer(node.vector, entry, hnswEfConstruction, l)
+		maxLinks := hnswM
+		if l == 0 {
+			maxLinks = hnswMaxLinks0
+		}
+		node.links[l] = g.selectNeighbors(candidates, hnswM)
+		for _, neighbor := range node.links[l] {
+			links := append(g.nodes[neighbor].links[l], id)
+			if len(links) > maxLinks {
+				links = g.prune(neighbor, links, maxLinks)
+			}
+			g.nodes[neighbor].links[l] = links
+		}
+		entry = candidates[0].id
+	}
+	if layer > g.maxLayer {
+		g.maxLayer = layer
+		g.entry = id
+	}
+}
+
+func (g *hnswGraph) removeDocument(documentID uuid.UUID) {
+	for _, id := range g.byDocument[documentID] {
+		g.markDeleted(id)
+	}
+	delete(g.byDocument, documentID)
+}
+
+func (g *hnswGraph) markDeleted(id int32) {
+	node := g.nodes[id]
+	if node.deleted {
+		return
+	}
+	node.deleted = true
+	g.live--
+	g.deleted++
+	delete(g.byChunk, node.chunk.ID)
+}
+
+// rebuild returns a graph of the live nodes only.
+func (g *hnswGraph) rebuild(rng *rand.Rand) *hnswGraph {
+	rebuilt := newHNSWGraph(rng)
+	for _, node := range g.nodes {
+		if !node.deleted {
+			chunk := *node.chunk
+			chunk.Embedding = node.vector
+			rebuilt.insert(&chunk)
+		}
+	}
+	return rebuilt
+}
+
+// search returns the chunks of the given documents nearest to the normalized query.
+func (g *hnswGraph) search(query []float32, documentIDs []uuid.UUID, limit int) []*document.ChunkMatch {
+	allowed := make(map[uuid.UUID]bool, len(documentIDs))
+	candidates := 0
+	for _, documentID := range documentIDs {
+		if !allowed[documentID] {
+			allowed[documentID] = true
+			candidates += len(g.byDocument[documentID])
+		}
+	}
+	if candidates == 0 {
+		return nil
+	}
+	if candidates <= bruteForceLimit || candidates*bruteForceFraction < g.live {
+		return g.bruteForce(query, documentIDs, limit)
+	}
+
+	entry := g.entry
+	for l := g.maxLayer; l > 0; l-- {
+		entry = g.greedy(query, entry, l)
+	}
+	var matches []*document.ChunkMatch
+	for _, c := range g.searchLayer(query, entry, max(hnswMinEfSearch, limit*10), 0) {
+		node := g.nodes[c.id]
+		if node.deleted || !allowed[node.chunk.DocumentID] {
+			continue
+		}
+		matches = append(matches, &document.ChunkMatch{Chunk: node.chunk, Similarity: c.similarity})
+		if len(matches) == limit {
+			return matches
+		}
+	}
+	// Too few of the nearest nodes belong to the documents.
+	return g.bruteForce(query, documentIDs, limit)
+}
+
+func (g *hnswGraph) bruteForce(query []float32, documentIDs []uuid.UUID, limit int) []*document.ChunkMatch {
+	var matches []*document.ChunkMatch
+	seen := make(map[uuid.UUID]bool, len(documentIDs))
+	for _, documentID := range documentIDs {
+		if seen[documentID] {
+			continue
+		}
+		seen[documentID] = true
+		for _, id := range g.byDocument[documentID] {
+			node := g.nodes[id]
+			matches = append(matches, &document.ChunkMatch{Chunk: node.chunk, Similarity: dot(query, node.vector)})
+		}
+	}
+	sort.Slice(matches, func(a, b int) bool { return matches[a].Similarity > matches[b].Similarity })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// greedy walks a layer toward the query and returns the nearest node found.
+func (g *hnswGraph) greedy(query []float32, entry int32, layer int) int32 {
+	best := dot(query, g.nodes[entry].vector)
+	for changed := true; changed; {
+		changed = false
+		for _, neighbor := range g.nodes[entry].links[layer] {
+			if s := dot(query, g.nodes[neighbor].vector); s > best {
+				best, entry, changed = s, neighbor, true
+			}
+		}
+	}
+	return entry
+}
+
+type candidate struct {
+	id         int32
+	similarity float64
+}
+
+// searchLayer returns up to ef nodes of a layer nearest to the query, most
+// similar first. Deleted nodes are traversed and returned; callers skip them.
+func (g *hnswGraph) searchLayer(query []float32, entry int32, ef int, layer int) []candidate {
+	first := candidate{id: entry, similarity: dot(query, g.nodes[entry].vector)}
+	visited := make([]uint64, (len(g.nodes)+63)/64)
+	visited[entry/64] |= 1 << (entry % 64)
+	frontier := &candidateHeap{best: true, items: []candidate{first}}
+	results := &candidateHeap{items: []candidate{first}}
+
+	for frontier.Len() > 0 {
+		current := heap.Pop(frontier).(candidate)
+		if results.Len() >= ef && current.similarity < results.items[0].similarity {
+			break
+		}
+		for _, neighbor := range g.nodes[current.id].links[layer] {
+			if visited[neighbor/64]&(1<<(neighbor%64)) != 0 {
+				continue
+			}
+			visited[neighbor/64] |= 1 << (neighbor % 64)
+			c := candidate{id: neighbor, similarity: dot(query, g.nodes[neighbor].vector)}
+			if results.Len() < ef || c.similarity > results.items[0].similarity {
+				heap.Push(frontier, c)
+				heap.Push(results, c)
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := results.items
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].similarity > sorted[b].similarity })
+	return sorted
+}
+
+// selectNeighbors picks up to m candidates, preferring ones that are closer to
+// the new node than to an already selected neighbor so links spread out.
+func (g *hnswGraph) selectNeighbors(candidates []candidate, m int) []int32 {
+	selected := make([]int32, 0, m)
+	var skipped []int32
+	for _, c := range candidates {
+		if len(selected) == m {
+			break
+		}
+		diverse := true
+		for _, s := range selected {
+			if dot(g.nodes[c.id].vector, g.nodes[s].vector) > c.similarity {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			selected = append(selected, c.id)
+		} else {
+			skipped = append(skipped, c.id)
+		}
+	}
+	for _, id := range skipped {
+		if len(selected) == m {
+			break
+		}
+		selected = append(selected, id)
+	}
+	return selected
+}
+
+// prune keeps the links of a node that point to its nearest neighbors.
+func (g *hnswGraph) prune(id int32, links []int32, maxLinks int) []int32 {
+	vector := g.nodes[id].vector
+	candidates := make([]candidate, len(links))
+	for i, link := range links {
+		candidates[i] = candidate{id: link, similarity: dot(vector, g.nodes[link].vector)}
+	}
+	sort.Slice(candidates, func(a, b int) bool { return candidates[a].similarity > candidates[b].similarity })
+	return g.selectNeighbors(candidates, maxLinks)
+}
+
+// candidateHeap is a heap of candidates ordered by similarity: the most similar
+// first when best is set, the least similar first otherwise.
+type candidateHeap struct {
+	best  bool
+	items []candidate
+}
+
+func (h *candidateHeap) Len() int { return len(h.items) }
+func (h *candidateHeap) Less(a, b int) bool {
+	if h.best {
+		return h.items[a].similarity > h.items[b].similarity
+	}
+	return h.items[a].similarity < h.items[b].similarity
+}
+func (h *candidateHeap) Swap(a, b int) { h.items[a], h.items[b] = h.items[b], h.items[a] }
+func (h *candidateHeap) Push(x any)    { h.items = append(h.items, x.(candidate)) }
+func (h *candidateHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// normalize returns the vector scaled to unit length, so that the dot product
+// of two normalized vectors is their cosine similarity.
+func normalize(v []float32) []float32 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	out := make([]float32, len(v))
+	if sum == 0 {
+		return out
+	}
+	norm := math.Sqrt(sum)
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
+
+func dot(a, b []float32) float64 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= len(a); i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return float64(s0 + s1 + s2 + s3)
+}
@@ -0,0 +1,44 @@
+package vectorindex
+
+import (
+	"context"
+
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+)
+
+// PostgresIndex searches the embeddings stored with the chunks using pgvector.
+// Embeddings are written by the chunk repository, so Add and Remove have
+// nothing to do.
+type PostgresIndex struct {
+	dbService *database.Service
+}
+
+// NewPostgresIndex creates a new PostgresIndex.
+func NewPostgresIndex(dbService *database.Service) *PostgresIndex {
+	return &PostgresIndex{dbService: dbService}
+}
+
+func (i *PostgresIndex) Add(ctx context.Context, chunks []*document.Chunk) error {
+	return nil
+}
+
+func (i *PostgresIndex) Remove(ctx context.Context, documentID uuid.UUID) error {
+	return nil
+}
+
+func (i *PostgresIndex) Search(ctx context.Context, query []float32, documentIDs []uuid.UUID, limit int) ([]*document.ChunkMatch, error) {
+	if len(documentIDs) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	var matches []*document.ChunkMatch
+	err := i.dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+		var err error
+		matches, err = provider.DocumentChunk().Search(ctx, query, documentIDs, limit)
+		return err
+	})
+	return matches, err
+}
@@ -0,0 +1,80 @@
+package vectorindex
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"trading-alchemist/internal/domain/document"
+	"trading-alchemist/internal/infrastructure/database"
+
+	"github.com/google/uuid"
+)
+
+// Index kinds selectable with RAG_VECTOR_INDEX.
+const (
+	KindAuto     = "auto"     // pgvector when the extension is installed, memory otherwise
+	KindPgvector = "pgvector" // Search in PostgreSQL
+	KindMemory   = "memory"   // In-process HNSW graph, loaded at startup
+)
+
+// loadPageSize is the number of chunks read per query when loading the memory index.
+const loadPageSize = 1000
+
+// New creates the vector index of the given kind. A memory index is loaded with
+// the embedded chunks of all ready documents.
+func New(ctx context.Context, kind string, dbService *database.Service) (document.VectorIndex, error) {
+	if kind == KindAuto || kind == KindPgvector {
+		var available bool
+		err := dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+			var err error
+			available, err = provider.DocumentChunk().VectorSearchAvailable(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case available:
+			return NewPostgresIndex(dbService), nil
+		case kind == KindPgvector:
+			return nil, fmt.Errorf("the pgvector extension is not installed")
+		}
+	}
+	if kind != KindAuto && kind != KindMemory {
+		return nil, fmt.Errorf("unknown vector index %q", kind)
+	}
+
+	index := NewMemoryIndex()
+	count, err := load(ctx, index, dbService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the vector index: %w", err)
+	}
+	log.Printf("Loaded %d document chunks into the in-memory vector index", count)
+	return index, nil
+}
+
+// load adds the embedded chunks of ready documents to the index, a page at a time.
+func load(ctx context.Context, index document.VectorIndex, dbService *database.Service) (int, error) {
+	count := 0
+	after := uuid.Nil
+	for {
+		var chunks []*document.Chunk
+		err := dbService.ExecuteInTx(ctx, func(provider database.RepositoryProvider) error {
+			var err error
+			chunks, err = provider.DocumentChunk().ListEmbedded(ctx, after, loadPageSize)
+			return err
+		})
+		if err != nil {
+			return count, err
+		}
+		if err := index.Add(ctx, chunks); err != nil {
+			return count, err
+		}
+		count += len(chunks)
+		if len(chunks) < loadPageSize {
+			return count, nil
+		}
+		after = chunks[len(chunks)-1].ID
+	}
+}
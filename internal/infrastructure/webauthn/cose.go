@@ -0,0 +1,142 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers (RFC 8152) that credentials may use.
+const (
+	algES256 = -7
+	algEdDSA = -8
+	algES384 = -35
+	algES512 = -36
+	algRS256 = -257
+)
+
+// supportedAlgorithms is offered to authenticators, in order of preference.
+var supportedAlgorithms = []int64{algES256, algEdDSA, algES384, algES512, algRS256}
+
+// COSE key parameters
+const (
+	coseKeyType   = 1
+	coseAlgorithm = 3
+	coseCurve     = -1 // Also the RSA modulus
+	coseX         = -2 // Also the RSA exponent
+	coseY         = -3
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+)
+
+// publicKey is a credential public key decoded from its COSE form.
+type publicKey struct {
+	algorithm int64
+	key       crypto.PublicKey
+}
+
+// parsePublicKey decodes a COSE_Key as stored with the credential.
+func parsePublicKey(data []byte) (*publicKey, error) {
+	v, n, err := decodeCBOR(data)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(data) {
+		return nil, errors.New("trailing data after public key")
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("public key is not a map")
+	}
+
+	kty, _ := m[int64(coseKeyType)].(int64)
+	alg, _ := m[int64(coseAlgorithm)].(int64)
+	pk := &publicKey{algorithm: alg}
+
+	switch kty {
+	case coseKeyTypeEC2:
+		crv, _ := m[int64(coseCurve)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		y, _ := m[int64(coseY)].([]byte)
+		var curve elliptic.Curve
+		switch {
+		case crv == 1 && alg == algES256:
+			curve = elliptic.P256()
+		case crv == 2 && alg == algES384:
+			curve = elliptic.P384()
+		case crv == 3 && alg == algES512:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC2 key: curve %d, algorithm %d", crv, alg)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC2 key coordinates")
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("EC2 key is not on its curve")
+		}
+		pk.key = key
+	case coseKeyTypeOKP:
+		crv, _ := m[int64(coseCurve)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		if alg != algEdDSA || crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("unsupported OKP key: curve %d, algorithm %d", crv, alg)
+		}
+		pk.key = ed25519.PublicKey(x)
+	case coseKeyTypeRSA:
+		modulus, _ := m[int64(coseCurve)].([]byte)
+		exponent, _ := m[int64(coseX)].([]byte)
+		if alg != algRS256 || len(modulus) < 256 || len(exponent) == 0 || len(exponent) > 4 {
+			return nil, fmt.Errorf("unsupported RSA key: algorithm %d", alg)
+		}
+		e := new(big.Int).SetBytes(exponent)
+		pk.key = &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(e.Int64())}
+	default:
+		return nil, fmt.Errorf("unsupported key type %d", kty)
+	}
+	return pk, nil
+}
+
+// verify checks a signature made by the credential over data.
+func (pk *publicKey) verify(data, signature []byte) error {
+	switch key := pk.key.(type) {
+	case *ecdsa.PublicKey:
+		var digest []byte
+		switch pk.algorithm {
+		case algES256:
+			sum := sha256.Sum256(data)
+			digest = sum[:]
+		case algES384:
+			sum := sha512.Sum384(data)
+			digest = sum[:]
+		default:
+			sum := sha512.Sum512(data)
+			digest = sum[:]
+		}
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		sum := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], signature)
+	default:
+		return errors.New("unsupported public key")
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"trading-alchemist/internal/application/account"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AccountHandler handles data export and account deletion requests.
+type AccountHandler struct {
+	accountUseCase *account.AccountUseCase
+}
+
+// NewAccountHandler creates a new AccountHandler.
+func NewAccountHandler(accountUseCase *account.AccountUseCase) *AccountHandler {
+	return &AccountHandler{accountUseCase: accountUseCase}
+}
+
+// ExportData downloads the current user's data
+// @Summary Export account data
+// @Description Downloads a zip archive of the current user's profile, conversations, messages, artifacts, settings and audit events as JSON files. API keys are never included. Cannot be called with an API token; users with 2FA enabled need a recent second factor check.
+// @Tags Users
+// @Produce application/zip
+// @Security Bearer
+// @Success 200 {file} file "Zip archive of the account data"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/me/export [get]
+func (h *AccountHandler) ExportData(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	archive, err := h.accountUseCase.ExportData(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	c.Attachment(fmt.Sprintf("account-export-%s.zip", time.Now().UTC().Format("2006-01-02")))
+	c.Type("zip")
+	return c.Send(archive)
+}
+
+// DeleteAccount schedules the deletion of the current user's account
+// @Summary Delete account
+// @Description Schedules the permanent deletion of the current user's account and all of its data after a grace period, and emails a confirmation. The deletion can be cancelled until then; requesting it again keeps the original date. Last owners of organizations with other members must hand them over first. Cannot be called with an API token; users with 2FA enabled need a recent second factor check.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 202 {object} responses.SuccessResponse{data=account.AccountDeletionResponse} "Account deletion scheduled"
+// @Failure 400 {object} responses.ErrorResponse "Last owner of an organization with other members"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/me [delete]
+func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	deletion, err := h.accountUseCase.ScheduleDeletion(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendAccepted(c, deletion, "Account deletion scheduled")
+}
+
+// GetDeletion returns the current user's pending account deletion
+// @Summary Get scheduled account deletion
+// @Description Returns when the current user's account is scheduled to be deleted.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=account.AccountDeletionResponse} "Account deletion retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "No account deletion is scheduled"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/me/deletion [get]
+func (h *AccountHandler) GetDeletion(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	deletion, err := h.accountUseCase.GetDeletion(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, deletion, "Account deletion retrieved successfully")
+}
+
+// CancelDeletion cancels the current user's pending account deletion
+// @Summary Cancel account deletion
+// @Description Cancels the scheduled deletion of the current user's account during the grace period.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse "Account deletion cancelled"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "No account deletion is scheduled"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/me/deletion [delete]
+func (h *AccountHandler) CancelDeletion(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	if err := h.accountUseCase.CancelDeletion(c.Context(), userID); err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, nil, "Account deletion cancelled")
+}
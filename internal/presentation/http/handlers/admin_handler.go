@@ -0,0 +1,545 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"trading-alchemist/internal/application/admin"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+)
+
+// AdminHandler handles the administration of providers, models, tools and users
+type AdminHandler struct {
+	adminUseCase *admin.AdminUseCase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminUseCase *admin.AdminUseCase) *AdminHandler {
+	return &AdminHandler{
+		adminUseCase: adminUseCase,
+	}
+}
+
+// ListProviders lists all providers and their models
+// @Summary List providers
+// @Description Lists all providers with all their models, including inactive ones, with model capabilities and pricing. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]admin.ProviderResponse} "Providers retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/providers [get]
+func (h *AdminHandler) ListProviders(c *fiber.Ctx) error {
+	providers, err := h.adminUseCase.ListProviders(c.Context())
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, providers, "Providers retrieved successfully")
+}
+
+// CreateProvider adds a provider
+// @Summary Create a provider
+// @Description Adds a provider. Its name is what the LLM service dispatches on (e.g. openai, google, openai_compatible), so it must be a lowercase identifier. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body admin.CreateProviderRequest true "Provider"
+// @Success 201 {object} responses.SuccessResponse{data=admin.ProviderResponse} "Provider created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 409 {object} responses.ErrorResponse "A provider with this name already exists"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/providers [post]
+func (h *AdminHandler) CreateProvider(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	var req admin.CreateProviderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	provider, err := h.adminUseCase.CreateProvider(c.Context(), adminID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendCreated(c, provider, "Provider created successfully")
+}
+
+// UpdateProvider changes a provider
+// @Summary Update a provider
+// @Description Renames, activates or deactivates a provider; omitted fields are left unchanged. Users cannot chat with the models of inactive providers. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Provider ID"
+// @Param request body admin.UpdateProviderRequest true "Changes"
+// @Success 200 {object} responses.SuccessResponse{data=admin.ProviderResponse} "Provider updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "Provider not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/providers/{id} [put]
+func (h *AdminHandler) UpdateProvider(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	providerID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid provider ID format")
+	}
+
+	var req admin.UpdateProviderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	provider, err := h.adminUseCase.UpdateProvider(c.Context(), adminID, providerID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, provider, "Provider updated successfully")
+}
+
+// CreateModel adds a model to a provider
+// @Summary Create a model
+// @Description Adds a model to a provider, optionally with its prices in USD per million tokens. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Provider ID"
+// @Param request body admin.CreateModelRequest true "Model"
+// @Success 201 {object} responses.SuccessResponse{data=admin.ModelResponse} "Model created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "Provider not found"
+// @Failure 409 {object} responses.ErrorResponse "The provider already has a model with this name"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/providers/{id}/models [post]
+func (h *AdminHandler) CreateModel(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	providerID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid provider ID format")
+	}
+
+	var req admin.CreateModelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	model, err := h.adminUseCase.CreateModel(c.Context(), adminID, providerID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendCreated(c, model, "Model created successfully")
+}
+
+// UpdateModel changes a model
+// @Summary Update a model
+// @Description Activates or deactivates a model, or changes its display name, capabilities or prices in USD per million tokens; omitted fields are left unchanged and clear_pricing removes both prices. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Model ID"
+// @Param request body admin.UpdateModelRequest true "Changes"
+// @Success 200 {object} responses.SuccessResponse{data=admin.ModelResponse} "Model updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "Model not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/models/{id} [put]
+func (h *AdminHandler) UpdateModel(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	modelID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid model ID format")
+	}
+
+	var req admin.UpdateModelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	model, err := h.adminUseCase.UpdateModel(c.Context(), adminID, modelID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, model, "Model updated successfully")
+}
+
+// ListTools lists all tools
+// @Summary List tools
+// @Description Lists all tools the assistant can call, including disabled ones. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]admin.ToolResponse} "Tools retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/tools [get]
+func (h *AdminHandler) ListTools(c *fiber.Ctx) error {
+	tools, err := h.adminUseCase.ListTools(c.Context())
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, tools, "Tools retrieved successfully")
+}
+
+// UpdateTool enables or disables a tool
+// @Summary Enable or disable a tool
+// @Description Enables or disables a tool for all users. Tools are defined in code, so descriptions and schemas cannot be changed. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tool ID"
+// @Param request body admin.UpdateToolRequest true "Changes"
+// @Success 200 {object} responses.SuccessResponse{data=admin.ToolResponse} "Tool updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "Tool not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/tools/{id} [put]
+func (h *AdminHandler) UpdateTool(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	toolID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid tool ID format")
+	}
+
+	var req admin.UpdateToolRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	tool, err := h.adminUseCase.UpdateTool(c.Context(), adminID, toolID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, tool, "Tool updated successfully")
+}
+
+// ListUsers lists all users
+// @Summary List users
+// @Description Lists all users, including deactivated ones, newest first. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Number of users to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=admin.UserListResponse} "Users retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	users, err := h.adminUseCase.ListUsers(c.Context(), limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, users, "Users retrieved successfully")
+}
+
+// GetUser returns a user
+// @Summary Get a user
+// @Description Returns a user, even if deactivated. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Success 200 {object} responses.SuccessResponse{data=auth.UserResponse} "User retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	user, err := h.adminUseCase.GetUser(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, user, "User retrieved successfully")
+}
+
+// GetUserUsage returns a user's chat usage
+// @Summary Get a user's usage
+// @Description Sums up the conversations a user started and the assistant messages they received over the last days, per model. Tokens and cost only include messages that recorded them. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param days query int false "Number of days to look back (at most 365)" default(30)
+// @Success 200 {object} responses.SuccessResponse{data=admin.UserUsageResponse} "Usage retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/usage [get]
+func (h *AdminHandler) GetUserUsage(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	usage, err := h.adminUseCase.GetUserUsage(c.Context(), userID, c.QueryInt("days", 30))
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, usage, "Usage retrieved successfully")
+}
+
+// VerifyUserEmail marks a user's email as verified
+// @Summary Verify a user's email
+// @Description Marks a user's email address as verified. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Success 200 {object} responses.SuccessResponse{data=auth.UserResponse} "Email verified successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/verify-email [post]
+func (h *AdminHandler) VerifyUserEmail(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	user, err := h.adminUseCase.VerifyUserEmail(c.Context(), adminID, userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, user, "Email verified successfully")
+}
+
+// DeactivateUser deactivates a user account
+// @Summary Deactivate a user
+// @Description Deactivates a user account; the user's access tokens, refresh tokens and API tokens stop working immediately. Admins cannot deactivate themselves. Requires the admin role and, with 2FA enabled, a recent second factor check.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Success 200 {object} responses.SuccessResponse "User deactivated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user ID or own account"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin, called with an API token or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 409 {object} responses.ErrorResponse "User is already deactivated"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/deactivate [post]
+func (h *AdminHandler) DeactivateUser(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	if err := h.adminUseCase.DeactivateUser(c.Context(), adminID, userID); err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, nil, "User deactivated successfully")
+}
+
+// UpdateUserRole changes a user's role
+// @Summary Change a user's role
+// @Description Grants or revokes the admin role; the change applies to the user's next request. Admins cannot change their own role. Requires the admin role and, with 2FA enabled, a recent second factor check.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "User ID"
+// @Param request body admin.UpdateUserRoleRequest true "Role"
+// @Success 200 {object} responses.SuccessResponse{data=auth.UserResponse} "Role updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body, unknown role or own account"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin, called with an API token or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/role [put]
+func (h *AdminHandler) UpdateUserRole(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	adminID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	var req admin.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	user, err := h.adminUseCase.UpdateUserRole(c.Context(), adminID, userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, user, "Role updated successfully")
+}
+
+// ListAuditEvents queries the audit log
+// @Summary List audit events
+// @Description Lists the audit log of all users, newest first, optionally filtered. Requires the admin role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param user_id query string false "Only events concerning this user"
+// @Param actor_id query string false "Only events performed by this user"
+// @Param action query string false "Only events with this action, e.g. user.login"
+// @Param resource_type query string false "Only events on this resource type, e.g. api_token"
+// @Param since query string false "Only events at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param until query string false "Only events before this time (RFC 3339 or YYYY-MM-DD)"
+// @Param limit query int false "Number of events to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=auth.AuditEventListResponse} "Audit events retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid filter"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin or called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /admin/audit [get]
+func (h *AdminHandler) ListAuditEvents(c *fiber.Ctx) error {
+	query := admin.AuditEventQuery{
+		Action:       c.Query("action"),
+		ResourceType: c.Query("resource_type"),
+		Limit:        c.QueryInt("limit", 50),
+		Offset:       c.QueryInt("offset", 0),
+	}
+	for param, target := range map[string]**uuid.UUID{"user_id": &query.UserID, "actor_id": &query.ActorID} {
+		if value := c.Query(param); value != "" {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid "+param+" format")
+			}
+			*target = &id
+		}
+	}
+	var err error
+	if query.Since, err = parseDateQuery(c.Query("since")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid since, use RFC 3339 or YYYY-MM-DD")
+	}
+	if query.Until, err = parseDateQuery(c.Query("until")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid until, use RFC 3339 or YYYY-MM-DD")
+	}
+
+	events, err := h.adminUseCase.ListAuditEvents(c.Context(), &query)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, events, "Audit events retrieved successfully")
+}
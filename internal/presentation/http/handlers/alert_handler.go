@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/alert"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AlertHandler handles watchlist, alert rule and alert history requests.
+type AlertHandler struct {
+	alertUseCase *alert.AlertUseCase
+}
+
+// NewAlertHandler creates a new AlertHandler.
+func NewAlertHandler(alertUseCase *alert.AlertUseCase) *AlertHandler {
+	return &AlertHandler{alertUseCase: alertUseCase}
+}
+
+// CreateWatchlist creates a new watchlist.
+// @Summary Create a watchlist
+// @Description Creates a named list of symbols, optionally with initial symbols.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body alert.CreateWatchlistRequest true "Watchlist creation request"
+// @Success 201 {object} responses.SuccessResponse{data=alert.WatchlistResponse} "Watchlist created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 409 {object} responses.ErrorResponse "Watchlist name already in use"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists [post]
+func (h *AlertHandler) CreateWatchlist(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req alert.CreateWatchlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	created, err := h.alertUseCase.CreateWatchlist(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, created, "Watchlist created successfully")
+}
+
+// ListWatchlists lists the user's watchlists.
+// @Summary List watchlists
+// @Description Retrieves the authenticated user's watchlists with their symbols.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]alert.WatchlistResponse} "Watchlists retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists [get]
+func (h *AlertHandler) ListWatchlists(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	watchlists, err := h.alertUseCase.ListWatchlists(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, watchlists, "Watchlists retrieved successfully")
+}
+
+// GetWatchlist retrieves a watchlist.
+// @Summary Get a watchlist
+// @Description Retrieves a watchlist owned by the authenticated user with its symbols.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Watchlist ID"
+// @Success 200 {object} responses.SuccessResponse{data=alert.WatchlistResponse} "Watchlist retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid watchlist ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Watchlist not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists/{id} [get]
+func (h *AlertHandler) GetWatchlist(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	watchlistID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid watchlist ID format")
+	}
+
+	watchlist, err := h.alertUseCase.GetWatchlist(c.Context(), userID, watchlistID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, watchlist, "Watchlist retrieved successfully")
+}
+
+// UpdateWatchlist updates a watchlist.
+// @Summary Update a watchlist
+// @Description Renames a watchlist or changes its description. An empty description clears it.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Watchlist ID"
+// @Param request body alert.UpdateWatchlistRequest true "Watchlist update request"
+// @Success 200 {object} responses.SuccessResponse{data=alert.WatchlistResponse} "Watchlist updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Watchlist not found"
+// @Failure 409 {object} responses.ErrorResponse "Watchlist name already in use"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists/{id} [put]
+func (h *AlertHandler) UpdateWatchlist(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	watchlistID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid watchlist ID format")
+	}
+
+	var req alert.UpdateWatchlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	updated, err := h.alertUseCase.UpdateWatchlist(c.Context(), userID, watchlistID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, updated, "Watchlist updated successfully")
+}
+
+// DeleteWatchlist deletes a watchlist.
+// @Summary Delete a watchlist
+// @Description Deletes a watchlist together with the alert rules that watch it.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Watchlist ID"
+// @Success 200 {object} responses.SuccessResponse "Watchlist deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid watchlist ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Watchlist not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists/{id} [delete]
+func (h *AlertHandler) DeleteWatchlist(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	watchlistID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid watchlist ID format")
+	}
+
+	if err := h.alertUseCase.DeleteWatchlist(c.Context(), userID, watchlistID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Watchlist deleted successfully")
+}
+
+// AddWatchlistItem adds a symbol to a watchlist.
+// @Summary Add a symbol to a watchlist
+// @Description Adds a symbol to a watchlist, or updates its notes if it is already on the list.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Watchlist ID"
+// @Param request body alert.AddWatchlistItemRequest true "Watchlist item"
+// @Success 200 {object} responses.SuccessResponse{data=alert.WatchlistResponse} "Symbol added successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Watchlist not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists/{id}/items [post]
+func (h *AlertHandler) AddWatchlistItem(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	watchlistID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid watchlist ID format")
+	}
+
+	var req alert.AddWatchlistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	watchlist, err := h.alertUseCase.AddWatchlistItem(c.Context(), userID, watchlistID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, watchlist, "Symbol added successfully")
+}
+
+// RemoveWatchlistItem removes a symbol from a watchlist.
+// @Summary Remove a symbol from a watchlist
+// @Description Removes a symbol from a watchlist.
+// @Tags Watchlists
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Watchlist ID"
+// @Param symbol path string true "Symbol"
+// @Success 200 {object} responses.SuccessResponse "Symbol removed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid watchlist ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Watchlist or symbol not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /watchlists/{id}/items/{symbol} [delete]
+func (h *AlertHandler) RemoveWatchlistItem(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	watchlistID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid watchlist ID format")
+	}
+
+	if err := h.alertUseCase.RemoveWatchlistItem(c.Context(), userID, watchlistID, c.Params("symbol")); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Symbol removed successfully")
+}
+
+// CreateRule creates a new alert rule.
+// @Summary Create an alert rule
+// @Description Creates an alert on a symbol or on every symbol of a watchlist. Rules are evaluated against closed candles and fire when their condition starts to hold: price_cross when the close crosses level, percent_move when the close moved by percent within window_seconds, indicator when a strategy DSL condition such as RSI below 30 becomes true. A rule does not fire again for the same symbol within cooldown_seconds.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body alert.AlertRuleRequest true "Alert rule"
+// @Success 201 {object} responses.SuccessResponse{data=alert.AlertRuleResponse} "Alert rule created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Watchlist not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts [post]
+func (h *AlertHandler) CreateRule(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req alert.AlertRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	created, err := h.alertUseCase.CreateRule(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, created, "Alert rule created successfully")
+}
+
+// ListRules lists the user's alert rules.
+// @Summary List alert rules
+// @Description Retrieves the authenticated user's alert rules, newest first.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]alert.AlertRuleResponse} "Alert rules retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts [get]
+func (h *AlertHandler) ListRules(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	rules, err := h.alertUseCase.ListRules(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, rules, "Alert rules retrieved successfully")
+}
+
+// GetRule retrieves an alert rule.
+// @Summary Get an alert rule
+// @Description Retrieves an alert rule owned by the authenticated user.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Alert rule ID"
+// @Success 200 {object} responses.SuccessResponse{data=alert.AlertRuleResponse} "Alert rule retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid alert rule ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Alert rule not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts/{id} [get]
+func (h *AlertHandler) GetRule(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid alert rule ID format")
+	}
+
+	rule, err := h.alertUseCase.GetRule(c.Context(), userID, ruleID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, rule, "Alert rule retrieved successfully")
+}
+
+// UpdateRule replaces an alert rule.
+// @Summary Update an alert rule
+// @Description Replaces an alert rule. Like a new rule, the updated rule starts with the next closed candle.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Alert rule ID"
+// @Param request body alert.AlertRuleRequest true "Alert rule"
+// @Success 200 {object} responses.SuccessResponse{data=alert.AlertRuleResponse} "Alert rule updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Alert rule or watchlist not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts/{id} [put]
+func (h *AlertHandler) UpdateRule(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid alert rule ID format")
+	}
+
+	var req alert.AlertRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	updated, err := h.alertUseCase.UpdateRule(c.Context(), userID, ruleID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, updated, "Alert rule updated successfully")
+}
+
+// DeleteRule deletes an alert rule.
+// @Summary Delete an alert rule
+// @Description Deletes an alert rule together with its history.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Alert rule ID"
+// @Success 200 {object} responses.SuccessResponse "Alert rule deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid alert rule ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Alert rule not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts/{id} [delete]
+func (h *AlertHandler) DeleteRule(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid alert rule ID format")
+	}
+
+	if err := h.alertUseCase.DeleteRule(c.Context(), userID, ruleID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Alert rule deleted successfully")
+}
+
+// ListEvents lists the user's alert history.
+// @Summary List alert history
+// @Description Retrieves the alerts triggered for the authenticated user, newest first.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Number of alerts to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]alert.AlertEventResponse} "Alert history retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts/events [get]
+func (h *AlertHandler) ListEvents(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	events, err := h.alertUseCase.ListEvents(c.Context(), userID, limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, events, "Alert history retrieved successfully")
+}
+
+// ListRuleEvents lists the history of an alert rule.
+// @Summary List alert rule history
+// @Description Retrieves the alerts triggered by a rule, newest first.
+// @Tags Alerts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Alert rule ID"
+// @Param limit query int false "Number of alerts to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]alert.AlertEventResponse} "Alert history retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid alert rule ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Alert rule not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /alerts/{id}/events [get]
+func (h *AlertHandler) ListRuleEvents(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid alert rule ID format")
+	}
+
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	events, err := h.alertUseCase.ListRuleEvents(c.Context(), userID, ruleID, limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, events, "Alert history retrieved successfully")
+}
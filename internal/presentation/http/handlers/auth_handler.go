@@ -47,6 +47,9 @@ func (h *AuthHandler) SendMagicLink(c *fiber.Ctx) error {
 	response, err := h.authUseCase.SendMagicLink(c.Context(), &req)
 	if err != nil {
 		// Handle different error types
+		if rateLimitErr, ok := err.(*errors.RateLimitError); ok {
+			return responses.SendRateLimited(c, rateLimitErr.RetryAfter, rateLimitErr.Message)
+		}
 		if appErr, ok := err.(*errors.AppError); ok {
 			switch appErr.Code {
 			case errors.CodeValidation:
@@ -65,7 +68,7 @@ func (h *AuthHandler) SendMagicLink(c *fiber.Ctx) error {
 
 // VerifyMagicLink verifies a magic link token and returns JWT tokens
 // @Summary Verify magic link
-// @Description Verifies a magic link token from an email link and returns a JWT access token if valid. The magic link token is consumed and cannot be used again.
+// @Description Verifies a magic link token from an email link and, if valid, starts a session: it returns a short-lived JWT access token and a refresh token for POST /auth/refresh. Users with two-factor authentication enabled get a challenge to answer at POST /auth/2fa/verify instead of the tokens. The magic link token is consumed and cannot be used again.
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -74,6 +77,7 @@ func (h *AuthHandler) SendMagicLink(c *fiber.Ctx) error {
 // @Failure 400 {object} responses.ErrorResponse "Invalid token format or token missing"
 // @Failure 401 {object} responses.ErrorResponse "Invalid, expired, or already used token"
 // @Failure 404 {object} responses.ErrorResponse "Token not found"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /auth/verify [post]
 func (h *AuthHandler) VerifyMagicLink(c *fiber.Ctx) error {
@@ -86,6 +90,10 @@ func (h *AuthHandler) VerifyMagicLink(c *fiber.Ctx) error {
 		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Token is required")
 	}
 
+	// Set IP address and user agent from request
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get("User-Agent")
+
 	// Call use case
 	response, err := h.authUseCase.VerifyMagicLink(c.Context(), &req)
 	if err != nil {
@@ -108,4 +116,165 @@ func (h *AuthHandler) VerifyMagicLink(c *fiber.Ctx) error {
 	}
 
 	return responses.SendSuccess(c, response, "Authentication successful")
-} 
\ No newline at end of file
+}
+
+// RefreshToken exchanges a refresh token for new tokens
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access token and a new refresh token. Refresh tokens rotate: each one can be used only once. Presenting a refresh token that was already used revokes every session descending from the same sign-in, since the token must have leaked.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body auth.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} responses.SuccessResponse{data=auth.RefreshTokenResponse} "Tokens refreshed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Refresh token missing"
+// @Failure 401 {object} responses.ErrorResponse "Invalid, expired, revoked or reused refresh token"
+// @Failure 403 {object} responses.ErrorResponse "Account is inactive"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
+	var req auth.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	// Set IP address and user agent from request
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get("User-Agent")
+
+	response, err := h.authUseCase.RefreshToken(c.Context(), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, response, "Tokens refreshed successfully")
+}
+
+// Logout signs out the session of a refresh token
+// @Summary Log out
+// @Description Revokes the session of the refresh token. The refresh token and the access tokens issued for the session stop working immediately.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body auth.LogoutRequest true "Refresh token of the session"
+// @Success 200 {object} responses.SuccessResponse{data=auth.LogoutResponse} "Logged out successfully"
+// @Failure 400 {object} responses.ErrorResponse "Refresh token missing"
+// @Failure 401 {object} responses.ErrorResponse "Invalid refresh token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	var req auth.LogoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	response, err := h.authUseCase.Logout(c.Context(), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, response, "Logged out successfully")
+}
+
+// ListOIDCProviders lists the external sign-in providers
+// @Summary List sign-in providers
+// @Description Lists the external OIDC/OAuth providers users can sign in with, in addition to magic links.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Success 200 {object} responses.SuccessResponse{data=[]auth.OIDCProviderResponse} "Sign-in providers retrieved successfully"
+// @Router /auth/oidc/providers [get]
+func (h *AuthHandler) ListOIDCProviders(c *fiber.Ctx) error {
+	return responses.SendSuccess(c, h.authUseCase.ListOIDCProviders(), "Sign-in providers retrieved successfully")
+}
+
+// StartOIDCLogin starts signing in with an external provider
+// @Summary Start provider sign-in
+// @Description Starts the authorization code flow with PKCE for a provider and returns the URL of its sign-in page. After signing in, the provider redirects the user to the configured frontend callback with a code and the state, which the frontend sends to POST /auth/oidc/callback.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name, e.g. google or github"
+// @Success 200 {object} responses.SuccessResponse{data=auth.StartOIDCLoginResponse} "Sign-in started successfully"
+// @Failure 404 {object} responses.ErrorResponse "Provider not found"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Provider unavailable or internal server error"
+// @Router /auth/oidc/{provider}/authorize [post]
+func (h *AuthHandler) StartOIDCLogin(c *fiber.Ctx) error {
+	req := auth.StartOIDCLoginRequest{Provider: c.Params("provider")}
+
+	response, err := h.authUseCase.StartOIDCLogin(c.Context(), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, response, "Sign-in started successfully")
+}
+
+// CompleteOIDCLogin finishes signing in with an external provider
+// @Summary Complete provider sign-in
+// @Description Exchanges the code and state the provider sent to the frontend callback for the same response as POST /auth/verify, including the two-factor challenge for users with 2FA enabled. The provider's identity is linked to the account with the same verified email, which is created on first sign-in. Each state can be used once.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body auth.CompleteOIDCLoginRequest true "Code and state from the callback"
+// @Success 200 {object} responses.SuccessResponse{data=auth.VerifyMagicLinkResponse} "Authentication successful"
+// @Failure 400 {object} responses.ErrorResponse "Code or state missing"
+// @Failure 401 {object} responses.ErrorResponse "Invalid or expired state, rejected code, or no verified email"
+// @Failure 403 {object} responses.ErrorResponse "Account is inactive"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /auth/oidc/callback [post]
+func (h *AuthHandler) CompleteOIDCLogin(c *fiber.Ctx) error {
+	var req auth.CompleteOIDCLoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	// Set IP address and user agent from request
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get("User-Agent")
+
+	response, err := h.authUseCase.CompleteOIDCLogin(c.Context(), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, response, "Authentication successful")
+}
+
+// VerifyTwoFactor completes a sign-in with a second factor
+// @Summary Verify second factor
+// @Description Answers the two-factor challenge returned by POST /auth/verify or POST /auth/oidc/callback for users with 2FA enabled, and returns the tokens of the new session. Use a code from the authenticator app (totp), a recovery code (recovery_code), or the result of navigator.credentials.get() with the challenge's webauthn options (webauthn). A challenge allows 5 wrong answers.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body auth.VerifyTwoFactorRequest true "Challenge token and second factor"
+// @Success 200 {object} responses.SuccessResponse{data=auth.VerifyMagicLinkResponse} "Authentication successful"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request"
+// @Failure 401 {object} responses.ErrorResponse "Invalid or expired challenge, or wrong second factor"
+// @Failure 403 {object} responses.ErrorResponse "Account is inactive"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /auth/2fa/verify [post]
+func (h *AuthHandler) VerifyTwoFactor(c *fiber.Ctx) error {
+	var req auth.VerifyTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	if req.ChallengeToken == "" || req.Method == "" {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Challenge token and method are required")
+	}
+
+	// Set IP address and user agent from request
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get("User-Agent")
+
+	response, err := h.authUseCase.VerifyTwoFactor(c.Context(), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, response, "Authentication successful")
+}
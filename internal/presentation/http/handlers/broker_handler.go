@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"trading-alchemist/internal/application/broker"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// orderStreamKeepAlive is how often a comment is sent on an idle order stream,
+// which also detects clients that have gone away.
+const orderStreamKeepAlive = 15 * time.Second
+
+// BrokerHandler handles broker connection and order routing requests.
+type BrokerHandler struct {
+	brokerUseCase *broker.BrokerUseCase
+}
+
+// NewBrokerHandler creates a new BrokerHandler.
+func NewBrokerHandler(brokerUseCase *broker.BrokerUseCase) *BrokerHandler {
+	return &BrokerHandler{brokerUseCase: brokerUseCase}
+}
+
+// CreateConnection connects a broker account.
+// @Summary Create a broker connection
+// @Description Connects a broker. Kind simulator runs a local simulator priced from stored candles; kind rest calls a broker API at api_base_override. Credentials are stored encrypted and never returned. Users with 2FA enabled need a recent second factor check.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body broker.CreateConnectionRequest true "Broker connection request"
+// @Success 201 {object} responses.SuccessResponse{data=broker.ConnectionResponse} "Broker connection created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "A recent second factor check is required"
+// @Failure 409 {object} responses.ErrorResponse "A connection with this name already exists"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers [post]
+func (h *BrokerHandler) CreateConnection(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req broker.CreateConnectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	conn, err := h.brokerUseCase.CreateConnection(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, conn, "Broker connection created successfully")
+}
+
+// ListConnections lists the user's broker connections.
+// @Summary List broker connections
+// @Description Retrieves the authenticated user's broker connections.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]broker.ConnectionResponse} "Broker connections retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers [get]
+func (h *BrokerHandler) ListConnections(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	conns, err := h.brokerUseCase.ListConnections(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, conns, "Broker connections retrieved successfully")
+}
+
+// GetConnection retrieves a broker connection.
+// @Summary Get a broker connection
+// @Description Retrieves a broker connection. Credentials are never returned.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Success 200 {object} responses.SuccessResponse{data=broker.ConnectionResponse} "Broker connection retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id} [get]
+func (h *BrokerHandler) GetConnection(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	conn, err := h.brokerUseCase.GetConnection(c.Context(), userID, connectionID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, conn, "Broker connection retrieved successfully")
+}
+
+// UpdateConnection updates a broker connection.
+// @Summary Update a broker connection
+// @Description Updates a broker connection. Credentials are only replaced when a new api_key is given. Any change resets the state of a simulator connection. Users with 2FA enabled need a recent second factor check.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Param request body broker.UpdateConnectionRequest true "Broker connection update"
+// @Success 200 {object} responses.SuccessResponse{data=broker.ConnectionResponse} "Broker connection updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 409 {object} responses.ErrorResponse "A connection with this name already exists"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id} [put]
+func (h *BrokerHandler) UpdateConnection(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	var req broker.UpdateConnectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	conn, err := h.brokerUseCase.UpdateConnection(c.Context(), userID, connectionID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, conn, "Broker connection updated successfully")
+}
+
+// DeleteConnection deletes a broker connection.
+// @Summary Delete a broker connection
+// @Description Deletes a broker connection. Orders already working at a real broker are not cancelled.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Success 200 {object} responses.SuccessResponse "Broker connection deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id} [delete]
+func (h *BrokerHandler) DeleteConnection(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	if err := h.brokerUseCase.DeleteConnection(c.Context(), userID, connectionID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Broker connection deleted successfully")
+}
+
+// GetAccount retrieves the account balance at the broker.
+// @Summary Get broker account
+// @Description Retrieves cash, equity and buying power from the broker.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Success 200 {object} responses.SuccessResponse{data=broker.AccountResponse} "Broker account retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID or inactive connection"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/account [get]
+func (h *BrokerHandler) GetAccount(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	account, err := h.brokerUseCase.GetAccount(c.Context(), userID, connectionID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, account, "Broker account retrieved successfully")
+}
+
+// GetPositions lists the positions held at the broker.
+// @Summary List broker positions
+// @Description Retrieves the positions held at the broker with their market value and unrealized P&L.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Success 200 {object} responses.SuccessResponse{data=[]broker.PositionResponse} "Broker positions retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID or inactive connection"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/positions [get]
+func (h *BrokerHandler) GetPositions(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	positions, err := h.brokerUseCase.GetPositions(c.Context(), userID, connectionID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, positions, "Broker positions retrieved successfully")
+}
+
+// ListOrders lists orders at the broker.
+// @Summary List broker orders
+// @Description Retrieves orders from the broker, newest first.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Param scope query string false "open, closed or all" default(all)
+// @Param symbol query string false "Only orders for this symbol"
+// @Param limit query int false "Maximum number of orders" default(50)
+// @Success 200 {object} responses.SuccessResponse{data=[]broker.OrderResponse} "Broker orders retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID or query"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/orders [get]
+func (h *BrokerHandler) ListOrders(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	orders, err := h.brokerUseCase.ListOrders(c.Context(), userID, connectionID, c.Query("scope"), c.Query("symbol"), c.QueryInt("limit", 50))
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, orders, "Broker orders retrieved successfully")
+}
+
+// PlaceOrder submits an order to the broker.
+// @Summary Place a broker order
+// @Description Submits a market, limit or stop order. Resubmitting with the same client_order_id returns the original order. Orders the broker refuses are returned with status rejected.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Param request body broker.PlaceOrderRequest true "Order request"
+// @Success 201 {object} responses.SuccessResponse{data=broker.OrderResponse} "Order placed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/orders [post]
+func (h *BrokerHandler) PlaceOrder(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	var req broker.PlaceOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	order, err := h.brokerUseCase.PlaceOrder(c.Context(), userID, connectionID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, order, "Order placed successfully")
+}
+
+// GetOrder retrieves an order from the broker.
+// @Summary Get a broker order
+// @Description Retrieves the current state of an order from the broker.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Param orderId path string true "Broker order ID"
+// @Success 200 {object} responses.SuccessResponse{data=broker.OrderResponse} "Order retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection or order not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/orders/{orderId} [get]
+func (h *BrokerHandler) GetOrder(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	order, err := h.brokerUseCase.GetOrder(c.Context(), userID, connectionID, c.Params("orderId"))
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, order, "Order retrieved successfully")
+}
+
+// CancelOrder cancels an open order at the broker.
+// @Summary Cancel a broker order
+// @Description Cancels an order that is still working at the broker.
+// @Tags Brokers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Param orderId path string true "Broker order ID"
+// @Success 200 {object} responses.SuccessResponse{data=broker.OrderResponse} "Order cancelled successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection or order not found"
+// @Failure 409 {object} responses.ErrorResponse "Order is no longer open"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/orders/{orderId} [delete]
+func (h *BrokerHandler) CancelOrder(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	order, err := h.brokerUseCase.CancelOrder(c.Context(), userID, connectionID, c.Params("orderId"))
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, order, "Order cancelled successfully")
+}
+
+// StreamOrderUpdates streams order status changes.
+// @Summary Stream broker order updates
+// @Description Streams order status changes, fills and cancellations using Server-Sent Events (SSE) until the client disconnects.
+// @Tags Brokers
+// @Produce plain
+// @Security Bearer
+// @Param id path string true "Broker connection ID"
+// @Success 200 {string} string "text/event-stream response"
+// @Failure 400 {object} responses.ErrorResponse "Invalid connection ID or inactive connection"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Broker connection not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /brokers/{id}/orders/stream [get]
+func (h *BrokerHandler) StreamOrderUpdates(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	connectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid connection ID format")
+	}
+
+	// The stream outlives the request context, so it is cancelled when a write fails
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := h.brokerUseCase.StreamOrderUpdates(ctx, userID, connectionID)
+	if err != nil {
+		cancel()
+		return responses.HandleError(c, err)
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		keepAlive := time.NewTicker(orderStreamKeepAlive)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				jsonEvent, err := json.Marshal(update)
+				if err != nil {
+					log.Printf("Error marshaling order update: %v", err)
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "data: %s\n\n", jsonEvent); err != nil {
+					return
+				}
+			case <-keepAlive.C:
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				log.Printf("Order stream for broker connection %s closed: %v", connectionID, err)
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"trading-alchemist/internal/application/calendar"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CalendarHandler handles events calendar requests.
+type CalendarHandler struct {
+	calendarUseCase *calendar.CalendarUseCase
+}
+
+// NewCalendarHandler creates a new CalendarHandler.
+func NewCalendarHandler(calendarUseCase *calendar.CalendarUseCase) *CalendarHandler {
+	return &CalendarHandler{calendarUseCase: calendarUseCase}
+}
+
+// ListEvents lists calendar events.
+// @Summary List calendar events
+// @Description Retrieves the shared calendar and the user's own events in chronological order, optionally filtered by period, symbols and types. Macro events have no symbol and are included whenever symbols are given, unless excluded by type.
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param start query string false "Earliest scheduled time (YYYY-MM-DD or RFC 3339)"
+// @Param end query string false "Scheduled time upper bound, exclusive (YYYY-MM-DD or RFC 3339)"
+// @Param symbols query string false "Comma-separated symbols"
+// @Param types query string false "Comma-separated event types: earnings, macro, dividend, split"
+// @Param limit query int false "Maximum number of events" default(100)
+// @Param offset query int false "Number of events to skip" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]calendar.EventResponse} "Calendar events retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /calendar/events [get]
+func (h *CalendarHandler) ListEvents(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	req := calendar.ListEventsRequest{
+		Limit:  c.QueryInt("limit", 100),
+		Offset: c.QueryInt("offset", 0),
+	}
+	if value := c.Query("symbols"); value != "" {
+		req.Symbols = strings.Split(value, ",")
+	}
+	if value := c.Query("types"); value != "" {
+		req.Types = strings.Split(value, ",")
+	}
+	if req.Start, err = parseDateQuery(c.Query("start")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid start date")
+	}
+	if req.End, err = parseDateQuery(c.Query("end")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid end date")
+	}
+
+	events, err := h.calendarUseCase.ListEvents(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, events, "Calendar events retrieved successfully")
+}
+
+// CreateEvent adds an event to the user's calendar.
+// @Summary Create a calendar event
+// @Description Adds an event to the user's own calendar. Earnings, dividend and split events need a symbol; dividends need the amount per share and splits the ratio of new to old shares. For corporate actions scheduled_at is the ex-date.
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body calendar.CreateEventRequest true "Calendar event"
+// @Success 201 {object} responses.SuccessResponse{data=calendar.EventResponse} "Calendar event created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /calendar/events [post]
+func (h *CalendarHandler) CreateEvent(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req calendar.CreateEventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	event, err := h.calendarUseCase.CreateEvent(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, event, "Calendar event created successfully")
+}
+
+// ImportEvents imports an ICS or CSV calendar.
+// @Summary Import calendar events
+// @Description Imports an iCalendar (ICS) or CSV file into the user's own calendar, sent as the multipart field "file" or as the request body; the format is detected from the content. ICS events may carry X-EVENT-TYPE, X-SYMBOL, X-AMOUNT and X-RATIO properties; otherwise the type is inferred from CATEGORIES or SUMMARY. CSV files need a date column and a type or title column, plus symbol, time, description, importance, amount and ratio as needed. Events already imported are updated in place, and events that cannot be parsed are reported.
+// @Tags Calendar
+// @Accept multipart/form-data,text/calendar,text/csv
+// @Produce json
+// @Security Bearer
+// @Param file formData file false "ICS or CSV file"
+// @Success 200 {object} responses.SuccessResponse{data=calendar.ImportResponse} "Calendar imported successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid file"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /calendar/events/import [post]
+func (h *CalendarHandler) ImportEvents(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var file io.Reader = bytes.NewReader(c.Body())
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		upload, err := fileHeader.Open()
+		if err != nil {
+			return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Could not read uploaded file")
+		}
+		defer upload.Close()
+		file = upload
+	} else if len(c.Body()) == 0 {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "An ICS or CSV file is required")
+	}
+
+	result, err := h.calendarUseCase.ImportEvents(c.Context(), userID, file)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, result, "Calendar imported successfully")
+}
+
+// DeleteEvent deletes one of the user's calendar events.
+// @Summary Delete a calendar event
+// @Description Deletes an event from the user's own calendar. Shared events cannot be deleted.
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Calendar event ID"
+// @Success 200 {object} responses.SuccessResponse "Calendar event deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid event ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Shared event or another user's event"
+// @Failure 404 {object} responses.ErrorResponse "Calendar event not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /calendar/events/{id} [delete]
+func (h *CalendarHandler) DeleteEvent(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	eventID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid event ID format")
+	}
+
+	if err := h.calendarUseCase.DeleteEvent(c.Context(), userID, eventID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Calendar event deleted successfully")
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/presentation/responses"
 	"trading-alchemist/pkg/utils"
 
@@ -30,7 +31,7 @@ func NewChatHandler(chatUseCase *chat.ChatUseCase, conversationUseCase *chat.Con
 
 // CreateConversation creates a new chat conversation.
 // @Summary Create a new conversation
-// @Description Creates a new chat session for the authenticated user.
+// @Description Creates a new chat session for the authenticated user, optionally in one of the user's organizations, whose members then get read or write access to it.
 // @Tags Chat
 // @Accept json
 // @Produce json
@@ -39,6 +40,7 @@ func NewChatHandler(chatUseCase *chat.ChatUseCase, conversationUseCase *chat.Con
 // @Success 201 {object} responses.SuccessResponse{data=chat.ConversationDetailResponse} "Conversation created successfully"
 // @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not a member of the organization"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /conversations [post]
 func (h *ChatHandler) CreateConversation(c *fiber.Ctx) error {
@@ -70,15 +72,19 @@ func (h *ChatHandler) CreateConversation(c *fiber.Ctx) error {
 
 // GetConversations retrieves a paginated list of conversations for the current user.
 // @Summary Get user's conversations
-// @Description Retrieves a paginated list of active conversations for the authenticated user.
+// @Description Retrieves a paginated list of the active conversations the authenticated user started or can access through an organization, with the user's permission on each. With an organization ID, only that organization's conversations are returned.
 // @Tags Chat
 // @Accept json
 // @Produce json
 // @Security Bearer
+// @Param organization_id query string false "Only conversations of this organization"
 // @Param limit query int false "Number of conversations to return" default(20)
 // @Param offset query int false "Offset for pagination" default(0)
 // @Success 200 {object} responses.SuccessResponse{data=[]chat.ConversationSummaryResponse} "Conversations retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization ID"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not a member of the organization"
+// @Failure 404 {object} responses.ErrorResponse "Organization not found"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /conversations [get]
 func (h *ChatHandler) GetConversations(c *fiber.Ctx) error {
@@ -93,12 +99,21 @@ func (h *ChatHandler) GetConversations(c *fiber.Ctx) error {
 		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
 	}
 
+	var organizationID *uuid.UUID
+	if raw := c.Query("organization_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+		}
+		organizationID = &id
+	}
+
 	// Get pagination parameters
 	limit := c.QueryInt("limit", 20)
 	offset := c.QueryInt("offset", 0)
 
 	// Call conversation use case
-	conversations, err := h.conversationUseCase.GetUserConversations(c.Context(), userID, limit, offset)
+	conversations, err := h.conversationUseCase.GetUserConversations(c.Context(), userID, organizationID, limit, offset)
 	if err != nil {
 		return responses.HandleError(c, err)
 	}
@@ -106,6 +121,38 @@ func (h *ChatHandler) GetConversations(c *fiber.Ctx) error {
 	return responses.SendSuccess(c, conversations)
 }
 
+// SemanticSearch finds past messages similar in meaning to a query.
+// @Summary Search past messages by meaning
+// @Description Embeds the query and returns the user's past messages most similar to it, best first, across conversations that are not archived. Requires document retrieval to be enabled and an API key for the embedding provider.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of messages, at most 50" default(10)
+// @Success 200 {object} responses.SuccessResponse{data=[]chat.MessageSearchResultResponse} "Messages retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Missing query or embedding provider not configured"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /conversations/semantic-search [get]
+func (h *ChatHandler) SemanticSearch(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	req := chat.SemanticSearchRequest{
+		Query: c.Query("q"),
+		Limit: c.QueryInt("limit", 10),
+	}
+	results, err := h.chatUseCase.SemanticSearch(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, results, "Messages retrieved successfully")
+}
+
 // GetConversation retrieves the details of a single conversation.
 // @Summary Get conversation details
 // @Description Retrieves the full details of a single conversation, including its messages, for the authenticated user.
@@ -117,7 +164,7 @@ func (h *ChatHandler) GetConversations(c *fiber.Ctx) error {
 // @Success 200 {object} responses.SuccessResponse{data=chat.ConversationDetailResponse} "Conversation details retrieved successfully"
 // @Failure 400 {object} responses.ErrorResponse "Invalid conversation ID format"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - User does not own this conversation"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot read this conversation"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /conversations/{id} [get]
@@ -160,8 +207,9 @@ func (h *ChatHandler) GetConversation(c *fiber.Ctx) error {
 // @Success 200 {string} string "text/event-stream response"
 // @Failure 400 {object} responses.ErrorResponse "Invalid request body or ID format"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - User does not own this conversation"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot write to this conversation"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /conversations/{id}/messages [post]
 func (h *ChatHandler) PostMessage(c *fiber.Ctx) error {
@@ -186,11 +234,73 @@ func (h *ChatHandler) PostMessage(c *fiber.Ctx) error {
 	}
 
 	// Call use case to get the stream
-	eventChannel, err := h.chatUseCase.PostMessage(c.Context(), conversationID, userID, &req)
+	eventChannel, err := h.chatUseCase.PostMessage(c.Context(), conversationID, userID, tokenScopes(userClaims), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return streamEvents(c, conversationID, eventChannel)
+}
+
+// ConfirmToolCall approves or declines a tool call that is awaiting user confirmation.
+// @Summary Confirm or decline a tool call
+// @Description Approves or declines a tool call the model requested that requires user confirmation. Once all calls of the turn are answered, the model's continued response is streamed back using Server-Sent Events (SSE).
+// @Tags Chat
+// @Accept json
+// @Produce plain
+// @Security Bearer
+// @Param id path string true "Conversation ID"
+// @Param toolCallId path string true "Tool call ID"
+// @Param request body chat.ConfirmToolCallRequest true "Decision"
+// @Success 200 {string} string "text/event-stream response"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or ID format"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot write to this conversation or the API token lacks the tool's scope"
+// @Failure 404 {object} responses.ErrorResponse "Tool call not found"
+// @Failure 409 {object} responses.ErrorResponse "Tool call is not awaiting confirmation"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /conversations/{id}/tool-calls/{toolCallId}/confirm [post]
+func (h *ChatHandler) ConfirmToolCall(c *fiber.Ctx) error {
+	var req chat.ConfirmToolCallRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	conversationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+	}
+
+	eventChannel, err := h.chatUseCase.ConfirmToolCall(c.Context(), conversationID, userID, tokenScopes(userClaims), c.Params("toolCallId"), &req)
 	if err != nil {
 		return responses.HandleError(c, err)
 	}
 
+	return streamEvents(c, conversationID, eventChannel)
+}
+
+// tokenScopes returns the scopes of the API token a request was made with, or
+// nil for user sessions.
+func tokenScopes(claims *utils.Claims) []string {
+	if claims.APITokenID == "" {
+		return nil
+	}
+	return claims.Scopes
+}
+
+// streamEvents writes chat stream events to the client as Server-Sent Events.
+func streamEvents(c *fiber.Ctx, conversationID uuid.UUID, eventChannel <-chan services.ChatStreamEvent) error {
 	// Set headers for SSE
 	c.Set("Content-Type", "text/event-stream")
 	c.Set("Cache-Control", "no-cache")
@@ -270,7 +380,7 @@ func (h *ChatHandler) GetAvailableTools(c *fiber.Ctx) error {
 // @Success 200 {object} responses.SuccessResponse "Conversation title updated successfully"
 // @Failure 400 {object} responses.ErrorResponse "Invalid request body or ID format"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - User does not own this conversation"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot write to this conversation"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /conversations/{id}/title [put]
@@ -306,6 +416,53 @@ func (h *ChatHandler) UpdateConversationTitle(c *fiber.Ctx) error {
 	return responses.SendSuccess(c, nil)
 }
 
+// UpdateConversationSettings sets the retrieval settings of a conversation.
+// @Summary Update conversation settings
+// @Description Sets how many document passages are given to the model for each message of the conversation and how similar to the message they must be. Omitted settings fall back to the server defaults.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Conversation ID"
+// @Param request body chat.UpdateConversationSettingsRequest true "Retrieval settings"
+// @Success 200 {object} responses.SuccessResponse{data=chat.JSONB} "Conversation settings updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body, settings or ID format"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot manage this conversation"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /conversations/{id}/settings [put]
+func (h *ChatHandler) UpdateConversationSettings(c *fiber.Ctx) error {
+	var req chat.UpdateConversationSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	// Extract user from context
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	// Get conversation ID from URL
+	conversationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+	}
+
+	settings, err := h.conversationUseCase.UpdateConversationSettings(c.Context(), conversationID, userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, settings)
+}
+
 // ArchiveConversation archives (soft deletes) a conversation.
 // @Summary Archive conversation
 // @Description Archives a conversation for the authenticated user.
@@ -317,7 +474,7 @@ func (h *ChatHandler) UpdateConversationTitle(c *fiber.Ctx) error {
 // @Success 200 {object} responses.SuccessResponse "Conversation archived successfully"
 // @Failure 400 {object} responses.ErrorResponse "Invalid conversation ID format"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - User does not own this conversation"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot manage this conversation"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /conversations/{id} [delete]
@@ -348,5 +505,52 @@ func (h *ChatHandler) ArchiveConversation(c *fiber.Ctx) error {
 	return responses.SendSuccess(c, nil)
 }
 
+// ShareConversation moves a conversation into an organization or back out of it.
+// @Summary Share conversation with an organization
+// @Description Moves a conversation into one of the user's organizations, whose members can then read it or also write to it, or back out of it when no organization ID is given. Organization admins and the user who started the conversation can manage it.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Conversation ID"
+// @Param request body chat.ShareConversationRequest true "Organization and permission"
+// @Success 200 {object} responses.SuccessResponse{data=chat.ConversationSummaryResponse} "Conversation shared successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body, permission or ID format"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot manage this conversation or is not a member of the organization"
+// @Failure 404 {object} responses.ErrorResponse "Conversation or organization not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /conversations/{id}/organization [put]
+func (h *ChatHandler) ShareConversation(c *fiber.Ctx) error {
+	var req chat.ShareConversationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	// Extract user from context
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	// Get conversation ID from URL
+	conversationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+	}
+
+	conversation, err := h.conversationUseCase.ShareConversation(c.Context(), conversationID, userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, conversation, "Conversation shared successfully")
+}
+
 // TODO: Implement handler methods:
 // - PostMessage(c *fiber.Ctx) error 
\ No newline at end of file
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"io"
+
+	"trading-alchemist/internal/application/document"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// DocumentHandler handles document upload and management requests.
+type DocumentHandler struct {
+	documentUseCase *document.DocumentUseCase
+}
+
+// NewDocumentHandler creates a new DocumentHandler.
+func NewDocumentHandler(documentUseCase *document.DocumentUseCase) *DocumentHandler {
+	return &DocumentHandler{documentUseCase: documentUseCase}
+}
+
+// UploadDocument uploads a document for retrieval.
+// @Summary Upload a document
+// @Description Uploads a PDF, Markdown, HTML, CSV or plain text file, sent as the multipart field "file" or as the request body with the file name in the "filename" query parameter. The text is extracted and split into chunks right away; the chunks are then embedded in the background, and the document's status changes from processing to ready or failed. Ready documents are searched for passages relevant to each chat message, which are cited in the answer. Requires an API key for the embedding provider. Scanned PDFs without a text layer are not supported.
+// @Tags Documents
+// @Accept multipart/form-data,application/pdf,text/markdown,text/html,text/csv,text/plain
+// @Produce json
+// @Security Bearer
+// @Param file formData file false "Document file"
+// @Param title formData string false "Document title; defaults to the title found in the file, then to the file name"
+// @Param filename query string false "File name, when the file is sent as the request body"
+// @Success 201 {object} responses.SuccessResponse{data=document.DocumentResponse} "Document uploaded successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid or unsupported file"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error or no API key for the embedding provider"
+// @Router /documents [post]
+func (h *DocumentHandler) UploadDocument(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	req, message := parseUpload(c)
+	if message != "" {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", message)
+	}
+
+	doc, err := h.documentUseCase.UploadDocument(c.Context(), userID, req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, doc, "Document uploaded successfully")
+}
+
+// ListDocuments lists the user's documents.
+// @Summary List documents
+// @Description Retrieves the user's uploaded documents, newest first, with their indexing status.
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Maximum number of documents" default(50)
+// @Param offset query int false "Number of documents to skip" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]document.DocumentResponse} "Documents retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /documents [get]
+func (h *DocumentHandler) ListDocuments(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	req := document.ListDocumentsRequest{
+		Limit:  c.QueryInt("limit", 50),
+		Offset: c.QueryInt("offset", 0),
+	}
+	docs, err := h.documentUseCase.ListDocuments(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, docs, "Documents retrieved successfully")
+}
+
+// GetDocument retrieves a document with its chunks.
+// @Summary Get a document
+// @Description Retrieves a document with the chunks its text was split into.
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Document ID"
+// @Success 200 {object} responses.SuccessResponse{data=document.DocumentDetailResponse} "Document retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid document ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the user's document"
+// @Failure 404 {object} responses.ErrorResponse "Document not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /documents/{id} [get]
+func (h *DocumentHandler) GetDocument(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	documentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid document ID format")
+	}
+
+	doc, err := h.documentUseCase.GetDocument(c.Context(), userID, documentID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, doc, "Document retrieved successfully")
+}
+
+// ReplaceDocument replaces the file of a document.
+// @Summary Replace a document
+// @Description Replaces the file of a document, sent like an upload. When its text changed, the document is chunked and embedded again and its status returns to processing; it stays in its knowledge bases. The title is kept unless a new one is given.
+// @Tags Documents
+// @Accept multipart/form-data,application/pdf,text/markdown,text/html,text/csv,text/plain
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Document ID"
+// @Param file formData file false "Document file"
+// @Param title formData string false "New document title"
+// @Param filename query string false "File name, when the file is sent as the request body"
+// @Success 200 {object} responses.SuccessResponse{data=document.DocumentResponse} "Document replaced successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid document ID or file"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the user's document"
+// @Failure 404 {object} responses.ErrorResponse "Document not found"
+// @Failure 409 {object} responses.ErrorResponse "Document is still being processed"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error or no API key for the embedding provider"
+// @Router /documents/{id} [put]
+func (h *DocumentHandler) ReplaceDocument(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	documentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid document ID format")
+	}
+
+	req, message := parseUpload(c)
+	if message != "" {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", message)
+	}
+
+	doc, err := h.documentUseCase.ReplaceDocument(c.Context(), userID, documentID, req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, doc, "Document replaced successfully")
+}
+
+// DeleteDocument deletes a document.
+// @Summary Delete a document
+// @Description Deletes a document and its chunks. Citations already stored on messages are kept.
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Document ID"
+// @Success 200 {object} responses.SuccessResponse "Document deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid document ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the user's document"
+// @Failure 404 {object} responses.ErrorResponse "Document not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /documents/{id} [delete]
+func (h *DocumentHandler) DeleteDocument(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	documentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid document ID format")
+	}
+
+	if err := h.documentUseCase.DeleteDocument(c.Context(), userID, documentID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Document deleted successfully")
+}
+
+// parseUpload reads a file sent as the multipart field "file" or as the request
+// body. It returns a message for the client when no file could be read.
+func parseUpload(c *fiber.Ctx) (*document.UploadDocumentRequest, string) {
+	req := &document.UploadDocumentRequest{
+		Title:       c.FormValue("title", c.Query("title")),
+		Filename:    c.Query("filename"),
+		ContentType: c.Get(fiber.HeaderContentType),
+		Data:        c.Body(),
+	}
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		upload, err := fileHeader.Open()
+		if err != nil {
+			return nil, "Could not read uploaded file"
+		}
+		defer upload.Close()
+		if req.Data, err = io.ReadAll(upload); err != nil {
+			return nil, "Could not read uploaded file"
+		}
+		req.Filename = fileHeader.Filename
+		req.ContentType = fileHeader.Header.Get(fiber.HeaderContentType)
+	} else if len(c.Body()) == 0 {
+		return nil, "A file is required"
+	}
+	return req, ""
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/journal"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// JournalHandler handles trading journal requests.
+type JournalHandler struct {
+	journalUseCase *journal.JournalUseCase
+}
+
+// NewJournalHandler creates a new JournalHandler.
+func NewJournalHandler(journalUseCase *journal.JournalUseCase) *JournalHandler {
+	return &JournalHandler{journalUseCase: journalUseCase}
+}
+
+// CreateEntry records a trade in the journal.
+// @Summary Create a journal entry
+// @Description Records a trade with its thesis, setup and tags. Leave exit_price and exit_time empty for an open trade. Screenshots are image artifacts uploaded in one of the user's conversations.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body journal.CreateEntryRequest true "Journal entry"
+// @Success 201 {object} responses.SuccessResponse{data=journal.EntryResponse} "Journal entry created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot write to the conversation or screenshot belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/entries [post]
+func (h *JournalHandler) CreateEntry(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req journal.CreateEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	entry, err := h.journalUseCase.CreateEntry(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, entry, "Journal entry created successfully")
+}
+
+// ListEntries lists the user's journal entries.
+// @Summary List journal entries
+// @Description Retrieves journal entries, newest first, optionally filtered by period, symbol, tag, setup or linked conversation.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param start query string false "Earliest entry time (YYYY-MM-DD or RFC 3339)"
+// @Param end query string false "Entry time upper bound, exclusive (YYYY-MM-DD or RFC 3339)"
+// @Param symbol query string false "Only entries for this symbol"
+// @Param tag query string false "Only entries with this tag"
+// @Param setup query string false "Only entries with this setup"
+// @Param conversation_id query string false "Only entries linked to this conversation"
+// @Param limit query int false "Maximum number of entries" default(50)
+// @Param offset query int false "Number of entries to skip" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]journal.EntryResponse} "Journal entries retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/entries [get]
+func (h *JournalHandler) ListEntries(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	req := journal.ListEntriesRequest{
+		Symbol: c.Query("symbol"),
+		Tag:    c.Query("tag"),
+		Setup:  c.Query("setup"),
+		Limit:  c.QueryInt("limit", 50),
+		Offset: c.QueryInt("offset", 0),
+	}
+	if req.Start, err = parseDateQuery(c.Query("start")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid start date")
+	}
+	if req.End, err = parseDateQuery(c.Query("end")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid end date")
+	}
+	if value := c.Query("conversation_id"); value != "" {
+		conversationID, err := uuid.Parse(value)
+		if err != nil {
+			return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+		}
+		req.ConversationID = &conversationID
+	}
+
+	entries, err := h.journalUseCase.ListEntries(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, entries, "Journal entries retrieved successfully")
+}
+
+// GetEntry retrieves a journal entry.
+// @Summary Get a journal entry
+// @Description Retrieves a journal entry with its realized P&L.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Journal entry ID"
+// @Success 200 {object} responses.SuccessResponse{data=journal.EntryResponse} "Journal entry retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid entry ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Journal entry not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/entries/{id} [get]
+func (h *JournalHandler) GetEntry(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid entry ID format")
+	}
+
+	entry, err := h.journalUseCase.GetEntry(c.Context(), userID, entryID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, entry, "Journal entry retrieved successfully")
+}
+
+// UpdateEntry updates a journal entry.
+// @Summary Update a journal entry
+// @Description Updates a journal entry, for example to record the exit of an open trade. Omitted fields are left unchanged; tags and screenshot_ids replace the current ones when given.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Journal entry ID"
+// @Param request body journal.UpdateEntryRequest true "Journal entry update"
+// @Success 200 {object} responses.SuccessResponse{data=journal.EntryResponse} "Journal entry updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Journal entry not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/entries/{id} [put]
+func (h *JournalHandler) UpdateEntry(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid entry ID format")
+	}
+
+	var req journal.UpdateEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	entry, err := h.journalUseCase.UpdateEntry(c.Context(), userID, entryID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, entry, "Journal entry updated successfully")
+}
+
+// DeleteEntry deletes a journal entry.
+// @Summary Delete a journal entry
+// @Description Deletes a journal entry. Its screenshots remain in their conversation.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Journal entry ID"
+// @Success 200 {object} responses.SuccessResponse "Journal entry deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid entry ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Journal entry not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/entries/{id} [delete]
+func (h *JournalHandler) DeleteEntry(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	entryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid entry ID format")
+	}
+
+	if err := h.journalUseCase.DeleteEntry(c.Context(), userID, entryID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Journal entry deleted successfully")
+}
+
+// GetStats aggregates the journal.
+// @Summary Get journal statistics
+// @Description Aggregates the journal entries of a period: win rate, P&L, profit factor and more, overall and per tag or setup.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param start query string false "Earliest entry time (YYYY-MM-DD or RFC 3339)"
+// @Param end query string false "Entry time upper bound, exclusive (YYYY-MM-DD or RFC 3339)"
+// @Param group_by query string false "tag or setup" default(tag)
+// @Success 200 {object} responses.SuccessResponse{data=journal.StatsResponse} "Journal statistics retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid period or grouping"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/stats [get]
+func (h *JournalHandler) GetStats(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	req := journal.StatsRequest{GroupBy: c.Query("group_by")}
+	if req.Start, err = parseDateQuery(c.Query("start")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid start date")
+	}
+	if req.End, err = parseDateQuery(c.Query("end")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid end date")
+	}
+
+	stats, err := h.journalUseCase.GetStats(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, stats, "Journal statistics retrieved successfully")
+}
+
+// ReviewTrades asks the model to critique a period of the journal.
+// @Summary Review trades
+// @Description Sends the journal entries of a period to the conversation's model using the review_trades prompt. The prompt and the review are added to the conversation, and the review is attached as a document artifact.
+// @Tags Journal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body journal.ReviewTradesRequest true "Review request"
+// @Success 201 {object} responses.SuccessResponse{data=journal.ReviewTradesResponse} "Trade review created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body, empty period or missing API key"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot read this conversation"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /journal/review [post]
+func (h *JournalHandler) ReviewTrades(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req journal.ReviewTradesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	review, err := h.journalUseCase.ReviewTrades(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, review, "Trade review created successfully")
+}
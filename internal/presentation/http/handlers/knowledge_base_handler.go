@@ -0,0 +1,464 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/document"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// KnowledgeBaseHandler handles knowledge base requests.
+type KnowledgeBaseHandler struct {
+	knowledgeBaseUseCase *document.KnowledgeBaseUseCase
+}
+
+// NewKnowledgeBaseHandler creates a new KnowledgeBaseHandler.
+func NewKnowledgeBaseHandler(knowledgeBaseUseCase *document.KnowledgeBaseUseCase) *KnowledgeBaseHandler {
+	return &KnowledgeBaseHandler{knowledgeBaseUseCase: knowledgeBaseUseCase}
+}
+
+// CreateKnowledgeBase creates a knowledge base.
+// @Summary Create a knowledge base
+// @Description Creates an empty knowledge base owned by the user. Documents are added to it afterwards, and it can be shared with other users and attached to conversations.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body document.CreateKnowledgeBaseRequest true "Knowledge base"
+// @Success 201 {object} responses.SuccessResponse{data=document.KnowledgeBaseResponse} "Knowledge base created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases [post]
+func (h *KnowledgeBaseHandler) CreateKnowledgeBase(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req document.CreateKnowledgeBaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	kb, err := h.knowledgeBaseUseCase.CreateKnowledgeBase(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, kb, "Knowledge base created successfully")
+}
+
+// ListKnowledgeBases lists the user's knowledge bases.
+// @Summary List knowledge bases
+// @Description Retrieves the knowledge bases the user owns or is a member of, with the user's role in each. With a conversation ID, retrieves the knowledge bases attached to that conversation instead; those the user has lost access to have an empty role and are not searched.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param conversation_id query string false "Only knowledge bases attached to this conversation"
+// @Success 200 {object} responses.SuccessResponse{data=[]document.KnowledgeBaseResponse} "Knowledge bases retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid conversation ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot read this conversation"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases [get]
+func (h *KnowledgeBaseHandler) ListKnowledgeBases(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var conversationID *uuid.UUID
+	if raw := c.Query("conversation_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+		}
+		conversationID = &id
+	}
+
+	knowledgeBases, err := h.knowledgeBaseUseCase.ListKnowledgeBases(c.Context(), userID, conversationID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, knowledgeBases, "Knowledge bases retrieved successfully")
+}
+
+// GetKnowledgeBase retrieves a knowledge base.
+// @Summary Get a knowledge base
+// @Description Retrieves a knowledge base the user can access, with its documents and members.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Success 200 {object} responses.SuccessResponse{data=document.KnowledgeBaseDetailResponse} "Knowledge base retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid knowledge base ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - No access to the knowledge base"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id} [get]
+func (h *KnowledgeBaseHandler) GetKnowledgeBase(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+
+	kb, err := h.knowledgeBaseUseCase.GetKnowledgeBase(c.Context(), userID, knowledgeBaseID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, kb, "Knowledge base retrieved successfully")
+}
+
+// UpdateKnowledgeBase renames a knowledge base.
+// @Summary Update a knowledge base
+// @Description Changes the name and description of a knowledge base. Only its owner can update it.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param request body document.UpdateKnowledgeBaseRequest true "Knowledge base"
+// @Success 200 {object} responses.SuccessResponse{data=document.KnowledgeBaseResponse} "Knowledge base updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid knowledge base ID or request body"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the owner"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id} [put]
+func (h *KnowledgeBaseHandler) UpdateKnowledgeBase(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+
+	var req document.UpdateKnowledgeBaseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	kb, err := h.knowledgeBaseUseCase.UpdateKnowledgeBase(c.Context(), userID, knowledgeBaseID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, kb, "Knowledge base updated successfully")
+}
+
+// DeleteKnowledgeBase deletes a knowledge base.
+// @Summary Delete a knowledge base
+// @Description Deletes a knowledge base and detaches it from all conversations. Its documents are kept. Only its owner can delete it.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Success 200 {object} responses.SuccessResponse "Knowledge base deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid knowledge base ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the owner"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id} [delete]
+func (h *KnowledgeBaseHandler) DeleteKnowledgeBase(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+
+	if err := h.knowledgeBaseUseCase.DeleteKnowledgeBase(c.Context(), userID, knowledgeBaseID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Knowledge base deleted successfully")
+}
+
+// SetMember shares a knowledge base with a user.
+// @Summary Share a knowledge base
+// @Description Shares a knowledge base with the user with the given email, or changes their role. Viewers can search it and attach it to their conversations; editors can also add, remove and re-index documents. Only the owner can share it.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param request body document.SetKnowledgeBaseMemberRequest true "Member"
+// @Success 200 {object} responses.SuccessResponse{data=document.KnowledgeBaseMemberResponse} "Member saved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid knowledge base ID, email or role"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the owner"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base or user not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id}/members [put]
+func (h *KnowledgeBaseHandler) SetMember(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+
+	var req document.SetKnowledgeBaseMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	member, err := h.knowledgeBaseUseCase.SetMember(c.Context(), userID, knowledgeBaseID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, member, "Member saved successfully")
+}
+
+// RemoveMember stops sharing a knowledge base with a user.
+// @Summary Remove a knowledge base member
+// @Description Stops sharing a knowledge base with a user. The owner can remove any member; members can remove themselves to leave it.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param userId path string true "Member user ID"
+// @Success 200 {object} responses.SuccessResponse "Member removed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not the owner"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id}/members/{userId} [delete]
+func (h *KnowledgeBaseHandler) RemoveMember(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	if err := h.knowledgeBaseUseCase.RemoveMember(c.Context(), userID, knowledgeBaseID, memberID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Member removed successfully")
+}
+
+// AddDocument adds a document to a knowledge base.
+// @Summary Add a document to a knowledge base
+// @Description Adds one of the user's documents to a knowledge base the user can edit. A document can be in several knowledge bases.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param request body document.AddKnowledgeBaseDocumentRequest true "Document"
+// @Success 200 {object} responses.SuccessResponse{data=document.DocumentResponse} "Document added successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid knowledge base ID or request body"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an editor, or not the user's document"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base or document not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id}/documents [post]
+func (h *KnowledgeBaseHandler) AddDocument(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+
+	var req document.AddKnowledgeBaseDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	doc, err := h.knowledgeBaseUseCase.AddDocument(c.Context(), userID, knowledgeBaseID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, doc, "Document added successfully")
+}
+
+// RemoveDocument removes a document from a knowledge base.
+// @Summary Remove a document from a knowledge base
+// @Description Removes a document from a knowledge base the user can edit. The document itself is kept.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param documentId path string true "Document ID"
+// @Success 200 {object} responses.SuccessResponse "Document removed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an editor"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id}/documents/{documentId} [delete]
+func (h *KnowledgeBaseHandler) RemoveDocument(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+	documentID, err := uuid.Parse(c.Params("documentId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid document ID format")
+	}
+
+	if err := h.knowledgeBaseUseCase.RemoveDocument(c.Context(), userID, knowledgeBaseID, documentID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Document removed successfully")
+}
+
+// ReindexKnowledgeBase embeds the documents of a knowledge base again.
+// @Summary Re-index a knowledge base
+// @Description Embeds the documents of a knowledge base again in the background, with the configured embedding model and the user's API key. Only documents that failed or were embedded with another model are re-indexed, unless forced. Requires the editor role.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param force query bool false "Re-index all documents" default(false)
+// @Success 200 {object} responses.SuccessResponse{data=document.ReindexKnowledgeBaseResponse} "Re-indexing started"
+// @Failure 400 {object} responses.ErrorResponse "Invalid knowledge base ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an editor"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error or no API key for the embedding provider"
+// @Router /knowledge-bases/{id}/reindex [post]
+func (h *KnowledgeBaseHandler) ReindexKnowledgeBase(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+
+	result, err := h.knowledgeBaseUseCase.ReindexKnowledgeBase(c.Context(), userID, knowledgeBaseID, c.QueryBool("force", false))
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, result, "Re-indexing started")
+}
+
+// AttachToConversation attaches a knowledge base to a conversation.
+// @Summary Attach a knowledge base to a conversation
+// @Description Attaches a knowledge base the user can access to a conversation the user may write to. Messages in a conversation with knowledge bases attached are answered from their documents instead of the user's own documents.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param conversationId path string true "Conversation ID"
+// @Success 200 {object} responses.SuccessResponse "Knowledge base attached successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - No access to the knowledge base or user cannot write to the conversation"
+// @Failure 404 {object} responses.ErrorResponse "Knowledge base or conversation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id}/conversations/{conversationId} [put]
+func (h *KnowledgeBaseHandler) AttachToConversation(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+	}
+
+	if err := h.knowledgeBaseUseCase.AttachToConversation(c.Context(), userID, knowledgeBaseID, conversationID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Knowledge base attached successfully")
+}
+
+// DetachFromConversation detaches a knowledge base from a conversation.
+// @Summary Detach a knowledge base from a conversation
+// @Description Detaches a knowledge base from a conversation the user may write to, also when the user has lost access to it.
+// @Tags Knowledge Bases
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Knowledge base ID"
+// @Param conversationId path string true "Conversation ID"
+// @Success 200 {object} responses.SuccessResponse "Knowledge base detached successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - User cannot write to this conversation"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /knowledge-bases/{id}/conversations/{conversationId} [delete]
+func (h *KnowledgeBaseHandler) DetachFromConversation(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	knowledgeBaseID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid knowledge base ID format")
+	}
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid conversation ID format")
+	}
+
+	if err := h.knowledgeBaseUseCase.DetachFromConversation(c.Context(), userID, knowledgeBaseID, conversationID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Knowledge base detached successfully")
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// MemoryHandler handles requests about what the assistant remembers about the user.
+type MemoryHandler struct {
+	memoryUseCase *chat.MemoryUseCase
+}
+
+// NewMemoryHandler creates a new MemoryHandler.
+func NewMemoryHandler(memoryUseCase *chat.MemoryUseCase) *MemoryHandler {
+	return &MemoryHandler{memoryUseCase: memoryUseCase}
+}
+
+// ListMemories lists what the assistant remembers about the user.
+// @Summary List memories
+// @Description Retrieves the facts the assistant has extracted from the user's conversations, such as risk tolerance, markets traded and time zone, most recently updated first. Relevant memories are added to the system prompt of new messages unless memory is turned off in the user profile.
+// @Tags Memories
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]chat.MemoryResponse} "Memories retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /memories [get]
+func (h *MemoryHandler) ListMemories(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	memories, err := h.memoryUseCase.ListMemories(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, memories, "Memories retrieved successfully")
+}
+
+// UpdateMemory edits one of the user's memories.
+// @Summary Update a memory
+// @Description Corrects the category or content of something the assistant remembers about the user.
+// @Tags Memories
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Memory ID"
+// @Param request body chat.UpdateMemoryRequest true "Memory"
+// @Success 200 {object} responses.SuccessResponse{data=chat.MemoryResponse} "Memory updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Another user's memory"
+// @Failure 404 {object} responses.ErrorResponse "Memory not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /memories/{id} [put]
+func (h *MemoryHandler) UpdateMemory(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	memoryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid memory ID format")
+	}
+
+	var req chat.UpdateMemoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	memory, err := h.memoryUseCase.UpdateMemory(c.Context(), userID, memoryID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, memory, "Memory updated successfully")
+}
+
+// DeleteMemory deletes one of the user's memories.
+// @Summary Delete a memory
+// @Description Makes the assistant forget something it remembers about the user.
+// @Tags Memories
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Memory ID"
+// @Success 200 {object} responses.SuccessResponse "Memory deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid memory ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Another user's memory"
+// @Failure 404 {object} responses.ErrorResponse "Memory not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /memories/{id} [delete]
+func (h *MemoryHandler) DeleteMemory(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	memoryID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid memory ID format")
+	}
+
+	if err := h.memoryUseCase.DeleteMemory(c.Context(), userID, memoryID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Memory deleted successfully")
+}
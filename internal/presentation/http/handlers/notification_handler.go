@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/notification"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// NotificationHandler handles in-app notification feed requests.
+type NotificationHandler struct {
+	notificationUseCase *notification.NotificationUseCase
+}
+
+// NewNotificationHandler creates a new NotificationHandler.
+func NewNotificationHandler(notificationUseCase *notification.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{notificationUseCase: notificationUseCase}
+}
+
+// ListNotifications lists the user's notifications.
+// @Summary List notifications
+// @Description Retrieves the authenticated user's in-app notifications, newest first, together with the number of unread notifications.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param unread_only query bool false "Only return unread notifications" default(false)
+// @Param limit query int false "Number of notifications to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=notification.NotificationFeedResponse} "Notifications retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	unreadOnly := c.QueryBool("unread_only", false)
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	feed, err := h.notificationUseCase.ListNotifications(c.Context(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, feed, "Notifications retrieved successfully")
+}
+
+// CountUnread returns the number of unread notifications.
+// @Summary Count unread notifications
+// @Description Returns how many of the authenticated user's notifications have not been read.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=notification.UnreadCountResponse} "Unread count retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) CountUnread(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	count, err := h.notificationUseCase.CountUnread(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, count, "Unread count retrieved successfully")
+}
+
+// MarkRead marks a notification as read.
+// @Summary Mark a notification as read
+// @Description Marks a notification of the authenticated user as read.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Notification ID"
+// @Success 200 {object} responses.SuccessResponse{data=notification.NotificationResponse} "Notification marked as read"
+// @Failure 400 {object} responses.ErrorResponse "Invalid notification ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Notification not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	notificationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid notification ID format")
+	}
+
+	n, err := h.notificationUseCase.MarkRead(c.Context(), userID, notificationID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, n, "Notification marked as read")
+}
+
+// MarkAllRead marks all notifications as read.
+// @Summary Mark all notifications as read
+// @Description Marks every notification of the authenticated user as read.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse "Notifications marked as read"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	if err := h.notificationUseCase.MarkAllRead(c.Context(), userID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Notifications marked as read")
+}
+
+// DeleteNotification deletes a notification.
+// @Summary Delete a notification
+// @Description Removes a notification from the authenticated user's feed.
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Notification ID"
+// @Success 200 {object} responses.SuccessResponse "Notification deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid notification ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Notification not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /notifications/{id} [delete]
+func (h *NotificationHandler) DeleteNotification(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	notificationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid notification ID format")
+	}
+
+	if err := h.notificationUseCase.DeleteNotification(c.Context(), userID, notificationID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Notification deleted successfully")
+}
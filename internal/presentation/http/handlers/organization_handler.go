@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/organization"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// OrganizationHandler handles organization requests.
+type OrganizationHandler struct {
+	organizationUseCase *organization.OrganizationUseCase
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler.
+func NewOrganizationHandler(organizationUseCase *organization.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{organizationUseCase: organizationUseCase}
+}
+
+// CreateOrganization creates an organization.
+// @Summary Create an organization
+// @Description Creates a team workspace with the user as its owner. Members are added by invitation and share the organization's provider API keys and conversations.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body organization.CreateOrganizationRequest true "Organization"
+// @Success 201 {object} responses.SuccessResponse{data=organization.OrganizationResponse} "Organization created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req organization.CreateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	org, err := h.organizationUseCase.CreateOrganization(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, org, "Organization created successfully")
+}
+
+// ListOrganizations lists the user's organizations.
+// @Summary List organizations
+// @Description Retrieves the organizations the user is a member of, with the user's role in each.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]organization.OrganizationResponse} "Organizations retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizations, err := h.organizationUseCase.ListOrganizations(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, organizations, "Organizations retrieved successfully")
+}
+
+// GetOrganization retrieves an organization.
+// @Summary Get an organization
+// @Description Retrieves an organization the user is a member of, with its members. Admins and owners also see its pending invitations.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} responses.SuccessResponse{data=organization.OrganizationDetailResponse} "Organization retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not a member"
+// @Failure 404 {object} responses.ErrorResponse "Organization not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+
+	org, err := h.organizationUseCase.GetOrganization(c.Context(), userID, organizationID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, org, "Organization retrieved successfully")
+}
+
+// UpdateOrganization renames an organization.
+// @Summary Update an organization
+// @Description Renames an organization. Only its owners can update it.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param request body organization.UpdateOrganizationRequest true "Organization"
+// @Success 200 {object} responses.SuccessResponse{data=organization.OrganizationResponse} "Organization updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization ID or request body"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an owner"
+// @Failure 404 {object} responses.ErrorResponse "Organization not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+
+	var req organization.UpdateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	org, err := h.organizationUseCase.UpdateOrganization(c.Context(), userID, organizationID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, org, "Organization updated successfully")
+}
+
+// DeleteOrganization deletes an organization.
+// @Summary Delete an organization
+// @Description Deletes an organization with its provider settings and invitations. Its conversations go back to the members who started them. Only its owners can delete it, and users with 2FA enabled need a recent second factor check.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} responses.SuccessResponse "Organization deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an owner, or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "Organization not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+
+	if err := h.organizationUseCase.DeleteOrganization(c.Context(), userID, organizationID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Organization deleted successfully")
+}
+
+// InviteMember invites a user to an organization.
+// @Summary Invite a member
+// @Description Emails a user an invitation to join the organization with the given role. The invitation is accepted by following its magic link, which also signs the user in; users without an account get one. Inviting the same user again replaces the earlier invitation. Admins can invite members and admins; only owners can invite owners.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param request body organization.InviteMemberRequest true "Invitation"
+// @Success 201 {object} responses.SuccessResponse{data=organization.InvitationResponse} "Invitation sent successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization ID, email or role"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin, or not an owner when inviting an owner"
+// @Failure 404 {object} responses.ErrorResponse "Organization not found"
+// @Failure 409 {object} responses.ErrorResponse "User is already a member"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/invitations [post]
+func (h *OrganizationHandler) InviteMember(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+
+	var req organization.InviteMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	invitation, err := h.organizationUseCase.InviteMember(c.Context(), userID, organizationID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, invitation, "Invitation sent successfully")
+}
+
+// RevokeInvitation withdraws a pending invitation.
+// @Summary Revoke an invitation
+// @Description Withdraws a pending invitation; its magic link stops working. Only admins and owners can revoke invitations, and only owners can revoke an invitation as owner.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 200 {object} responses.SuccessResponse "Invitation revoked successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization or invitation ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin"
+// @Failure 404 {object} responses.ErrorResponse "Organization or invitation not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/invitations/{invitationId} [delete]
+func (h *OrganizationHandler) RevokeInvitation(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+	invitationID, err := uuid.Parse(c.Params("invitationId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid invitation ID format")
+	}
+
+	if err := h.organizationUseCase.RevokeInvitation(c.Context(), userID, organizationID, invitationID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Invitation revoked successfully")
+}
+
+// UpdateMember changes the role of a member.
+// @Summary Change a member's role
+// @Description Changes the role of a member. Admins can change the roles of members and admins; only owners can make or demote owners, and the last owner cannot be demoted.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param userId path string true "Member user ID"
+// @Param request body organization.UpdateMemberRequest true "Role"
+// @Success 200 {object} responses.SuccessResponse{data=organization.MemberResponse} "Member updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID, role, or the last owner would be demoted"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not allowed to change this role"
+// @Failure 404 {object} responses.ErrorResponse "Organization or member not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/members/{userId} [put]
+func (h *OrganizationHandler) UpdateMember(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid member ID format")
+	}
+
+	var req organization.UpdateMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	member, err := h.organizationUseCase.UpdateMember(c.Context(), userID, organizationID, memberID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, member, "Member updated successfully")
+}
+
+// RemoveMember removes a member from an organization.
+// @Summary Remove a member
+// @Description Removes a member from an organization. Admins can remove members and admins, owners can remove anyone, and every member can leave. The last owner cannot be removed.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param userId path string true "Member user ID"
+// @Success 200 {object} responses.SuccessResponse "Member removed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID, or the member is the last owner"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not allowed to remove this member"
+// @Failure 404 {object} responses.ErrorResponse "Organization or member not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/members/{userId} [delete]
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid member ID format")
+	}
+
+	if err := h.organizationUseCase.RemoveMember(c.Context(), userID, organizationID, memberID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Member removed successfully")
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/paper"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// PaperHandler handles paper trading requests.
+type PaperHandler struct {
+	paperUseCase *paper.PaperTradingUseCase
+}
+
+// NewPaperHandler creates a new PaperHandler.
+func NewPaperHandler(paperUseCase *paper.PaperTradingUseCase) *PaperHandler {
+	return &PaperHandler{paperUseCase: paperUseCase}
+}
+
+// CreateAccount opens a new paper trading account.
+// @Summary Create a paper account
+// @Description Opens a simulated account funded with the given initial cash.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body paper.CreateAccountRequest true "Account creation request"
+// @Success 201 {object} responses.SuccessResponse{data=paper.AccountResponse} "Paper account created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts [post]
+func (h *PaperHandler) CreateAccount(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req paper.CreateAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	account, err := h.paperUseCase.CreateAccount(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, account, "Paper account created successfully")
+}
+
+// ListAccounts lists the user's paper trading accounts.
+// @Summary List paper accounts
+// @Description Retrieves the authenticated user's paper accounts valued at the latest candle closes.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]paper.AccountResponse} "Paper accounts retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts [get]
+func (h *PaperHandler) ListAccounts(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accounts, err := h.paperUseCase.ListAccounts(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, accounts, "Paper accounts retrieved successfully")
+}
+
+// GetAccount retrieves a paper account with its positions.
+// @Summary Get a paper account
+// @Description Retrieves a paper account with cash, equity, positions and P&L. Resting orders are matched against the latest candles first.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Paper account ID"
+// @Success 200 {object} responses.SuccessResponse{data=paper.AccountResponse} "Paper account retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid account ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Paper account not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts/{id} [get]
+func (h *PaperHandler) GetAccount(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid account ID format")
+	}
+
+	account, err := h.paperUseCase.GetAccount(c.Context(), userID, accountID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, account, "Paper account retrieved successfully")
+}
+
+// DeleteAccount deletes a paper account.
+// @Summary Delete a paper account
+// @Description Deletes a paper account together with its orders and positions.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Paper account ID"
+// @Success 200 {object} responses.SuccessResponse "Paper account deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid account ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Paper account not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts/{id} [delete]
+func (h *PaperHandler) DeleteAccount(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid account ID format")
+	}
+
+	if err := h.paperUseCase.DeleteAccount(c.Context(), userID, accountID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Paper account deleted successfully")
+}
+
+// ListPositions lists the positions of a paper account.
+// @Summary List paper positions
+// @Description Retrieves the positions of a paper account, including closed positions with realized P&L.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Paper account ID"
+// @Success 200 {object} responses.SuccessResponse{data=[]paper.PositionResponse} "Paper positions retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid account ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Paper account not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts/{id}/positions [get]
+func (h *PaperHandler) ListPositions(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid account ID format")
+	}
+
+	positions, err := h.paperUseCase.ListPositions(c.Context(), userID, accountID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, positions, "Paper positions retrieved successfully")
+}
+
+// ListOrders lists the orders of a paper account.
+// @Summary List paper orders
+// @Description Retrieves the orders of a paper account, newest first.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Paper account ID"
+// @Param limit query int false "Number of orders to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]paper.OrderResponse} "Paper orders retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid account ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Paper account not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts/{id}/orders [get]
+func (h *PaperHandler) ListOrders(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid account ID format")
+	}
+
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	orders, err := h.paperUseCase.ListOrders(c.Context(), userID, accountID, limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, orders, "Paper orders retrieved successfully")
+}
+
+// PlaceOrder submits a paper order.
+// @Summary Place a paper order
+// @Description Submits a market, limit or stop order. Marketable orders fill immediately at the latest close; orders the account cannot cover are returned with status rejected.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Paper account ID"
+// @Param request body paper.PlaceOrderRequest true "Order request"
+// @Success 201 {object} responses.SuccessResponse{data=paper.OrderResponse} "Paper order placed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Paper account not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts/{id}/orders [post]
+func (h *PaperHandler) PlaceOrder(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid account ID format")
+	}
+
+	var req paper.PlaceOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	order, err := h.paperUseCase.PlaceOrder(c.Context(), userID, accountID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, order, "Paper order placed successfully")
+}
+
+// CancelOrder cancels an open paper order.
+// @Summary Cancel a paper order
+// @Description Cancels an order that has not been filled yet.
+// @Tags Paper Trading
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Paper account ID"
+// @Param orderId path string true "Order ID"
+// @Success 200 {object} responses.SuccessResponse{data=paper.OrderResponse} "Paper order cancelled successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID format"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Order not found"
+// @Failure 409 {object} responses.ErrorResponse "Order is no longer open"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /paper/accounts/{id}/orders/{orderId} [delete]
+func (h *PaperHandler) CancelOrder(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid account ID format")
+	}
+	orderID, err := uuid.Parse(c.Params("orderId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid order ID format")
+	}
+
+	order, err := h.paperUseCase.CancelOrder(c.Context(), userID, accountID, orderID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, order, "Paper order cancelled successfully")
+}
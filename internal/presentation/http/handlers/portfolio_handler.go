@@ -0,0 +1,491 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"trading-alchemist/internal/application/portfolio"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// PortfolioHandler handles portfolio tracking requests.
+type PortfolioHandler struct {
+	portfolioUseCase *portfolio.PortfolioUseCase
+}
+
+// NewPortfolioHandler creates a new PortfolioHandler.
+func NewPortfolioHandler(portfolioUseCase *portfolio.PortfolioUseCase) *PortfolioHandler {
+	return &PortfolioHandler{portfolioUseCase: portfolioUseCase}
+}
+
+// CreatePortfolio creates a new portfolio.
+// @Summary Create a portfolio
+// @Description Creates an empty portfolio to record real holdings in.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body portfolio.CreatePortfolioRequest true "Portfolio creation request"
+// @Success 201 {object} responses.SuccessResponse{data=portfolio.PortfolioResponse} "Portfolio created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios [post]
+func (h *PortfolioHandler) CreatePortfolio(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req portfolio.CreatePortfolioRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	created, err := h.portfolioUseCase.CreatePortfolio(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, created, "Portfolio created successfully")
+}
+
+// ListPortfolios lists the user's portfolios.
+// @Summary List portfolios
+// @Description Retrieves the authenticated user's portfolios.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]portfolio.PortfolioResponse} "Portfolios retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios [get]
+func (h *PortfolioHandler) ListPortfolios(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolios, err := h.portfolioUseCase.ListPortfolios(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, portfolios, "Portfolios retrieved successfully")
+}
+
+// GetPortfolio retrieves a portfolio.
+// @Summary Get a portfolio
+// @Description Retrieves a portfolio owned by the authenticated user.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Success 200 {object} responses.SuccessResponse{data=portfolio.PortfolioResponse} "Portfolio retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid portfolio ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id} [get]
+func (h *PortfolioHandler) GetPortfolio(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	p, err := h.portfolioUseCase.GetPortfolio(c.Context(), userID, portfolioID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, p, "Portfolio retrieved successfully")
+}
+
+// UpdatePortfolio updates a portfolio.
+// @Summary Update a portfolio
+// @Description Renames a portfolio or changes its base currency or benchmark. An empty benchmark_symbol clears the benchmark.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param request body portfolio.UpdatePortfolioRequest true "Portfolio update request"
+// @Success 200 {object} responses.SuccessResponse{data=portfolio.PortfolioResponse} "Portfolio updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id} [put]
+func (h *PortfolioHandler) UpdatePortfolio(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	var req portfolio.UpdatePortfolioRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	updated, err := h.portfolioUseCase.UpdatePortfolio(c.Context(), userID, portfolioID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, updated, "Portfolio updated successfully")
+}
+
+// DeletePortfolio deletes a portfolio.
+// @Summary Delete a portfolio
+// @Description Deletes a portfolio together with its transactions and asset classifications.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Success 200 {object} responses.SuccessResponse "Portfolio deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid portfolio ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id} [delete]
+func (h *PortfolioHandler) DeletePortfolio(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	if err := h.portfolioUseCase.DeletePortfolio(c.Context(), userID, portfolioID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Portfolio deleted successfully")
+}
+
+// ListTransactions lists the transactions of a portfolio.
+// @Summary List portfolio transactions
+// @Description Retrieves the portfolio's transaction ledger, oldest first.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Success 200 {object} responses.SuccessResponse{data=[]portfolio.TransactionResponse} "Transactions retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid portfolio ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/transactions [get]
+func (h *PortfolioHandler) ListTransactions(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	transactions, err := h.portfolioUseCase.ListTransactions(c.Context(), userID, portfolioID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, transactions, "Transactions retrieved successfully")
+}
+
+// AddTransaction records a transaction.
+// @Summary Add a portfolio transaction
+// @Description Records a buy, sell, dividend, deposit, withdrawal or fee. Sells exceeding the quantity held at that time are rejected.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param request body portfolio.AddTransactionRequest true "Transaction"
+// @Success 201 {object} responses.SuccessResponse{data=portfolio.TransactionResponse} "Transaction recorded successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/transactions [post]
+func (h *PortfolioHandler) AddTransaction(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	var req portfolio.AddTransactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	transaction, err := h.portfolioUseCase.AddTransaction(c.Context(), userID, portfolioID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, transaction, "Transaction recorded successfully")
+}
+
+// DeleteTransaction removes a transaction.
+// @Summary Delete a portfolio transaction
+// @Description Removes a transaction from the ledger, unless later sells depend on it.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param transactionId path string true "Transaction ID"
+// @Success 200 {object} responses.SuccessResponse "Transaction deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid ID or the ledger would become inconsistent"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio or transaction not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/transactions/{transactionId} [delete]
+func (h *PortfolioHandler) DeleteTransaction(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+	transactionID, err := uuid.Parse(c.Params("transactionId"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid transaction ID format")
+	}
+
+	if err := h.portfolioUseCase.DeleteTransaction(c.Context(), userID, portfolioID, transactionID); err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, nil, "Transaction deleted successfully")
+}
+
+// AddHolding records an existing holding.
+// @Summary Add a holding
+// @Description Records an existing holding as a buy at its average cost, optionally classifying the asset.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param request body portfolio.AddHoldingRequest true "Holding"
+// @Success 201 {object} responses.SuccessResponse{data=portfolio.TransactionResponse} "Holding recorded successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/holdings [post]
+func (h *PortfolioHandler) AddHolding(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	var req portfolio.AddHoldingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	transaction, err := h.portfolioUseCase.AddHolding(c.Context(), userID, portfolioID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, transaction, "Holding recorded successfully")
+}
+
+// UpdateAsset classifies an asset.
+// @Summary Classify an asset
+// @Description Sets the name, asset class or sector of a symbol, used for the allocation breakdown. Omitted fields keep their stored value.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param symbol path string true "Asset symbol"
+// @Param request body portfolio.UpdateAssetRequest true "Asset classification"
+// @Success 200 {object} responses.SuccessResponse{data=portfolio.AssetResponse} "Asset updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/assets/{symbol} [put]
+func (h *PortfolioHandler) UpdateAsset(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	var req portfolio.UpdateAssetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	asset, err := h.portfolioUseCase.UpdateAsset(c.Context(), userID, portfolioID, c.Params("symbol"), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, asset, "Asset updated successfully")
+}
+
+// ImportTransactions imports a broker statement.
+// @Summary Import transactions from CSV
+// @Description Imports a broker statement in CSV format, sent as the multipart field "file" or as a text/csv body. The header must name date and type columns plus symbol, quantity, price, fee or amount as needed; common broker column names are recognized. Rows already imported are skipped, and rows that cannot be parsed are reported.
+// @Tags Portfolios
+// @Accept multipart/form-data,text/csv
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param file formData file false "CSV statement"
+// @Param dry_run query bool false "Parse and check the statement without storing it"
+// @Success 200 {object} responses.SuccessResponse{data=portfolio.ImportResponse} "Statement imported successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid CSV or the ledger would become inconsistent"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/import [post]
+func (h *PortfolioHandler) ImportTransactions(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	var statement io.Reader = bytes.NewReader(c.Body())
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Could not read uploaded file")
+		}
+		defer file.Close()
+		statement = file
+	} else if len(c.Body()) == 0 {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "A CSV file is required")
+	}
+
+	result, err := h.portfolioUseCase.ImportTransactions(c.Context(), userID, portfolioID, statement, c.QueryBool("dry_run", false))
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, result, "Statement imported successfully")
+}
+
+// GetSummary retrieves the portfolio summary.
+// @Summary Get portfolio summary
+// @Description Values the portfolio at the latest stored daily closes and reports holdings, allocation by asset, sector and asset class, time- and money-weighted returns, volatility, beta and historical VaR over the period.
+// @Tags Portfolios
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Portfolio ID"
+// @Param start query string false "Start of the period (YYYY-MM-DD or RFC 3339), defaults to the first transaction"
+// @Param end query string false "End of the period (YYYY-MM-DD or RFC 3339), defaults to now"
+// @Param benchmark query string false "Benchmark symbol for beta, defaults to the portfolio's benchmark"
+// @Success 200 {object} responses.SuccessResponse{data=portfolio.SummaryResponse} "Portfolio summary retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid portfolio ID or period"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Portfolio not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /portfolios/{id}/summary [get]
+func (h *PortfolioHandler) GetSummary(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	portfolioID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid portfolio ID format")
+	}
+
+	var req portfolio.SummaryRequest
+	if req.Start, err = parseDateQuery(c.Query("start")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid start date")
+	}
+	if req.End, err = parseDateQuery(c.Query("end")); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid end date")
+	}
+	if benchmark := c.Query("benchmark"); benchmark != "" {
+		req.Benchmark = &benchmark
+	}
+
+	summary, err := h.portfolioUseCase.GetSummary(c.Context(), userID, portfolioID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, summary, "Portfolio summary retrieved successfully")
+}
+
+// parseDateQuery parses an optional YYYY-MM-DD or RFC 3339 query value.
+func parseDateQuery(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
@@ -73,7 +73,7 @@ func (h *ProviderHandler) ListUserSettings(c *fiber.Ctx) error {
 
 // UpsertUserSetting creates or updates a provider setting for the current user.
 // @Summary Create or update a provider setting
-// @Description Creates or updates a provider setting (API key, base URL) for the authenticated user.
+// @Description Creates or updates a provider setting (API key, base URL) for the authenticated user. Users with 2FA enabled need a recent second factor check.
 // @Tags Providers
 // @Accept json
 // @Produce json
@@ -82,6 +82,7 @@ func (h *ProviderHandler) ListUserSettings(c *fiber.Ctx) error {
 // @Success 200 {object} responses.SuccessResponse{data=chat.UserProviderSettingResponse} "Provider setting saved successfully"
 // @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "A recent second factor check is required"
 // @Failure 404 {object} responses.ErrorResponse "Provider not found"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /providers/settings [post]
@@ -120,6 +121,83 @@ func (h *ProviderHandler) UpsertUserSetting(c *fiber.Ctx) error {
 	return responses.SendSuccess(c, setting, "Provider setting saved successfully")
 }
 
+// ListOrganizationSettings retrieves the provider settings of an organization.
+// @Summary List an organization's provider settings
+// @Description Retrieves the provider settings of an organization the user is a member of. Their API keys serve all members whose own keys are missing or inactive, and are used first in the organization's conversations.
+// @Tags Providers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} responses.SuccessResponse{data=[]chat.UserProviderSettingResponse} "Organization provider settings retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid organization ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not a member of the organization"
+// @Failure 404 {object} responses.ErrorResponse "Organization not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/provider-settings [get]
+func (h *ProviderHandler) ListOrganizationSettings(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+
+	settings, err := h.providerUseCase.ListOrganizationSettings(c.Context(), userID, organizationID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, settings, "Organization provider settings retrieved successfully")
+}
+
+// UpsertOrganizationSetting creates or updates a provider setting of an organization.
+// @Summary Create or update an organization's provider setting
+// @Description Creates or updates a provider setting (API key, base URL) of an organization, whose key then serves all its members. Only organization admins and owners can change it. Users with 2FA enabled need a recent second factor check.
+// @Tags Providers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param request body chat.UpsertUserProviderSettingRequest true "Provider setting information"
+// @Success 200 {object} responses.SuccessResponse{data=chat.UserProviderSettingResponse} "Organization provider setting saved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not an admin of the organization, or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "Organization or provider not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/provider-settings [post]
+func (h *ProviderHandler) UpsertOrganizationSetting(c *fiber.Ctx) error {
+	var req chat.UpsertUserProviderSettingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+	if req.ProviderID == uuid.Nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "VALIDATION_ERROR", "Provider ID is required")
+	}
+
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	organizationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid organization ID format")
+	}
+
+	setting, err := h.providerUseCase.UpsertOrganizationProviderSetting(c.Context(), userID, organizationID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, setting, "Organization provider setting saved successfully")
+}
+
 // GetAvailableModels retrieves available models with API key status for the user
 // @Summary Get available models with API key status
 // @Description Retrieves all available models with their API key configuration status in a single optimized call
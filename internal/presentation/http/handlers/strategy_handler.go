@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"trading-alchemist/internal/application/backtest"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// StrategyHandler handles strategy and backtest requests.
+type StrategyHandler struct {
+	strategyUseCase *backtest.StrategyUseCase
+	backtestUseCase *backtest.BacktestUseCase
+}
+
+// NewStrategyHandler creates a new StrategyHandler.
+func NewStrategyHandler(strategyUseCase *backtest.StrategyUseCase, backtestUseCase *backtest.BacktestUseCase) *StrategyHandler {
+	return &StrategyHandler{
+		strategyUseCase: strategyUseCase,
+		backtestUseCase: backtestUseCase,
+	}
+}
+
+// ValidateStrategy checks a strategy definition without storing it.
+// @Summary Validate a strategy definition
+// @Description Parses a JSON or YAML strategy definition and reports every validation error with its location.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body backtest.ValidateStrategyRequest true "Strategy source"
+// @Success 200 {object} responses.SuccessResponse{data=backtest.ValidateStrategyResponse} "Strategy validated"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /strategies/validate [post]
+func (h *StrategyHandler) ValidateStrategy(c *fiber.Ctx) error {
+	var req backtest.ValidateStrategyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	result, err := h.strategyUseCase.ValidateStrategy(c.Context(), &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, result, "Strategy validated")
+}
+
+// ListStrategies lists the latest version of each of the user's strategies.
+// @Summary List strategies
+// @Description Retrieves the latest version of every strategy saved by the authenticated user.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Number of strategies to return" default(20)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=[]backtest.StrategyResponse} "Strategies retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /strategies [get]
+func (h *StrategyHandler) ListStrategies(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	limit := c.QueryInt("limit", 20)
+	offset := c.QueryInt("offset", 0)
+
+	strategies, err := h.strategyUseCase.ListStrategies(c.Context(), userID, limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, strategies, "Strategies retrieved successfully")
+}
+
+// GetStrategy retrieves a single strategy version.
+// @Summary Get a strategy
+// @Description Retrieves a strategy version by its ID.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Strategy ID"
+// @Success 200 {object} responses.SuccessResponse{data=backtest.StrategyResponse} "Strategy retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid strategy ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Strategy not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /strategies/{id} [get]
+func (h *StrategyHandler) GetStrategy(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	strategyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid strategy ID format")
+	}
+
+	strategy, err := h.strategyUseCase.GetStrategy(c.Context(), userID, strategyID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, strategy, "Strategy retrieved successfully")
+}
+
+// GetStrategyVersions lists every version of a strategy.
+// @Summary List strategy versions
+// @Description Retrieves all versions of the strategy the given ID belongs to, oldest first.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Strategy ID"
+// @Success 200 {object} responses.SuccessResponse{data=[]backtest.StrategyResponse} "Strategy versions retrieved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid strategy ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Strategy not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /strategies/{id}/versions [get]
+func (h *StrategyHandler) GetStrategyVersions(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	strategyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid strategy ID format")
+	}
+
+	versions, err := h.strategyUseCase.GetStrategyVersions(c.Context(), userID, strategyID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, versions, "Strategy versions retrieved successfully")
+}
+
+// UpdateStrategy stores a new version of a strategy.
+// @Summary Update a strategy
+// @Description Validates the new definition and saves it as the next version. Previous versions are kept.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Strategy ID"
+// @Param request body backtest.UpdateStrategyRequest true "New strategy source"
+// @Success 201 {object} responses.SuccessResponse{data=backtest.StrategyResponse} "Strategy version created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Strategy not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /strategies/{id} [put]
+func (h *StrategyHandler) UpdateStrategy(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	strategyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid strategy ID format")
+	}
+
+	var req backtest.UpdateStrategyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	strategy, err := h.strategyUseCase.UpdateStrategy(c.Context(), userID, strategyID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendCreated(c, strategy, "Strategy version created successfully")
+}
+
+// RunStrategyBacktest backtests a stored strategy.
+// @Summary Backtest a strategy
+// @Description Runs the given strategy version over stored candles and returns trades, equity curve and statistics.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Strategy ID"
+// @Param request body backtest.BacktestWindowRequest true "Backtest window and execution settings"
+// @Success 200 {object} responses.SuccessResponse "Backtest completed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden"
+// @Failure 404 {object} responses.ErrorResponse "Strategy not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /strategies/{id}/backtest [post]
+func (h *StrategyHandler) RunStrategyBacktest(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	strategyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid strategy ID format")
+	}
+
+	var req backtest.BacktestWindowRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	result, err := h.strategyUseCase.RunStrategy(c.Context(), userID, strategyID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, result, "Backtest completed successfully")
+}
+
+// RunBacktest runs a backtest with a built-in, stored or inline strategy.
+// @Summary Run a backtest
+// @Description Runs a backtest over stored candles. Exactly one of strategy, strategy_id and strategy_definition must be set.
+// @Tags Strategies
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body backtest.RunBacktestRequest true "Backtest request"
+// @Success 200 {object} responses.SuccessResponse "Backtest completed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request body or validation error"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /backtests [post]
+func (h *StrategyHandler) RunBacktest(c *fiber.Ctx) error {
+	userClaims := c.Locals("user").(*utils.Claims)
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format in token")
+	}
+
+	var req backtest.RunBacktestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	result, err := h.backtestUseCase.RunBacktest(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+	return responses.SendSuccess(c, result, "Backtest completed successfully")
+}
@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"trading-alchemist/internal/application/auth"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+)
+
+// TwoFactorHandler handles the management of second factors and step-up checks
+type TwoFactorHandler struct {
+	authUseCase *auth.AuthUseCase
+}
+
+// NewTwoFactorHandler creates a new two-factor handler
+func NewTwoFactorHandler(authUseCase *auth.AuthUseCase) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		authUseCase: authUseCase,
+	}
+}
+
+// GetStatus returns the current user's second factors
+// @Summary Get two-factor status
+// @Description Returns whether two-factor authentication is enabled, which authenticator app and security keys or passkeys are set up, and how many recovery codes are left.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=auth.TwoFactorStatusResponse} "Two-factor status retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa [get]
+func (h *TwoFactorHandler) GetStatus(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	status, err := h.authUseCase.GetTwoFactorStatus(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, status, "Two-factor status retrieved successfully")
+}
+
+// EnrollTOTP starts setting up an authenticator app
+// @Summary Set up an authenticator app
+// @Description Generates a TOTP secret and its otpauth:// URL for an authenticator app. It takes effect once confirmed with a first code at POST /users/2fa/totp/confirm. Once 2FA is enabled, requires a recent second factor check.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=auth.EnrollTOTPResponse} "Authenticator app enrollment started"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 409 {object} responses.ErrorResponse "An authenticator app is already set up"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/totp [post]
+func (h *TwoFactorHandler) EnrollTOTP(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	enrollment, err := h.authUseCase.EnrollTOTP(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, enrollment, "Authenticator app enrollment started")
+}
+
+// ConfirmTOTP activates the authenticator app
+// @Summary Confirm the authenticator app
+// @Description Activates the authenticator app with a first code. If it is the first second factor, two-factor authentication is enabled and 10 single-use recovery codes are returned; they are shown only once.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body auth.ConfirmTOTPRequest true "Code from the authenticator app"
+// @Success 200 {object} responses.SuccessResponse{data=auth.RecoveryCodesResponse} "Authenticator app confirmed"
+// @Failure 400 {object} responses.ErrorResponse "Invalid code"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "No authenticator app is being set up"
+// @Failure 409 {object} responses.ErrorResponse "The authenticator app is already confirmed"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/totp/confirm [post]
+func (h *TwoFactorHandler) ConfirmTOTP(c *fiber.Ctx) error {
+	var req auth.ConfirmTOTPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	codes, err := h.authUseCase.ConfirmTOTP(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, codes, "Authenticator app confirmed")
+}
+
+// DisableTOTP removes the authenticator app
+// @Summary Remove the authenticator app
+// @Description Removes the authenticator app. Removing the last second factor disables two-factor authentication and deletes the recovery codes. Requires a recent second factor check.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse "Authenticator app removed"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "No authenticator app is set up"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/totp [delete]
+func (h *TwoFactorHandler) DisableTOTP(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	if err := h.authUseCase.DisableTOTP(c.Context(), userID); err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, nil, "Authenticator app removed")
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes
+// @Summary Regenerate recovery codes
+// @Description Replaces the recovery codes with 10 new single-use codes; the previous ones stop working. The codes are shown only once. Requires a recent second factor check.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=auth.RecoveryCodesResponse} "Recovery codes regenerated"
+// @Failure 400 {object} responses.ErrorResponse "Two-factor authentication is not enabled"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	codes, err := h.authUseCase.RegenerateRecoveryCodes(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, codes, "Recovery codes regenerated")
+}
+
+// StartWebAuthnRegistration starts registering a security key or passkey
+// @Summary Start security key registration
+// @Description Returns the options to pass to navigator.credentials.create() and a token to send back with the new credential to POST /users/2fa/webauthn. Once 2FA is enabled, requires a recent second factor check.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=auth.WebAuthnRegistrationOptionsResponse} "Registration options created"
+// @Failure 400 {object} responses.ErrorResponse "Too many credentials"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/webauthn/options [post]
+func (h *TwoFactorHandler) StartWebAuthnRegistration(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	options, err := h.authUseCase.StartWebAuthnRegistration(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, options, "Registration options created")
+}
+
+// RegisterWebAuthn registers a security key or passkey
+// @Summary Register a security key
+// @Description Verifies the credential returned by navigator.credentials.create() and registers it. If it is the first second factor, two-factor authentication is enabled and 10 single-use recovery codes are returned; they are shown only once.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body auth.RegisterWebAuthnRequest true "Registration token, name and credential"
+// @Success 201 {object} responses.SuccessResponse{data=auth.RegisterWebAuthnResponse} "Security key registered"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request or credential"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token, or expired registration"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 409 {object} responses.ErrorResponse "Credential already registered"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/webauthn [post]
+func (h *TwoFactorHandler) RegisterWebAuthn(c *fiber.Ctx) error {
+	var req auth.RegisterWebAuthnRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	registration, err := h.authUseCase.RegisterWebAuthn(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendCreated(c, registration, "Security key registered")
+}
+
+// DeleteWebAuthnCredential removes a security key or passkey
+// @Summary Remove a security key
+// @Description Removes a security key or passkey. Removing the last second factor disables two-factor authentication and deletes the recovery codes. Requires a recent second factor check.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Credential ID"
+// @Success 200 {object} responses.SuccessResponse "Security key removed"
+// @Failure 400 {object} responses.ErrorResponse "Invalid credential ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Another user's credential, called with an API token, or a recent second factor check is required"
+// @Failure 404 {object} responses.ErrorResponse "Credential not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/webauthn/{id} [delete]
+func (h *TwoFactorHandler) DeleteWebAuthnCredential(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	credentialID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid credential ID format")
+	}
+
+	if err := h.authUseCase.DeleteWebAuthnCredential(c.Context(), userID, credentialID); err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, nil, "Security key removed")
+}
+
+// StartStepUp creates a challenge to re-verify the current session
+// @Summary Start a step-up check
+// @Description Creates a second factor challenge for the current session. Sensitive actions, such as saving provider API keys, broker connections or API tokens, respond 403 SECOND_FACTOR_REQUIRED until the session answers one at POST /users/2fa/step-up/verify.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=auth.TwoFactorChallengeResponse} "Step-up challenge created"
+// @Failure 400 {object} responses.ErrorResponse "Two-factor authentication is not enabled"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/step-up [post]
+func (h *TwoFactorHandler) StartStepUp(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+	// Tokens issued before sessions existed have no session ID
+	sessionID, _ := uuid.Parse(userClaims.SessionID)
+
+	challenge, err := h.authUseCase.StartStepUp(c.Context(), userID, sessionID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, challenge, "Step-up challenge created")
+}
+
+// VerifyStepUp answers a step-up challenge
+// @Summary Verify a step-up check
+// @Description Answers the step-up challenge of the current session with a second factor, as in POST /auth/2fa/verify. Sensitive actions are then allowed in this session until valid_until.
+// @Tags Two-Factor Authentication
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body auth.VerifyTwoFactorRequest true "Challenge token and second factor"
+// @Success 200 {object} responses.SuccessResponse{data=auth.StepUpResponse} "Second factor verified"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request"
+// @Failure 401 {object} responses.ErrorResponse "Invalid or expired challenge, or wrong second factor"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 429 {object} responses.ErrorResponse "Too many requests - rate limit exceeded"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/2fa/step-up/verify [post]
+func (h *TwoFactorHandler) VerifyStepUp(c *fiber.Ctx) error {
+	var req auth.VerifyTwoFactorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	if req.ChallengeToken == "" || req.Method == "" {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Challenge token and method are required")
+	}
+
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+	sessionID, _ := uuid.Parse(userClaims.SessionID)
+
+	response, err := h.authUseCase.VerifyStepUp(c.Context(), sessionID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, response, "Second factor verified")
+}
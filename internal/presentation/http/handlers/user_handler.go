@@ -113,6 +113,219 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	return responses.SendSuccess(c, response, "Profile updated successfully")
 }
 
+// ListSessions lists the current user's signed-in devices
+// @Summary List sessions
+// @Description Lists the devices signed in to the current user's account, most recently seen first. The session of the access token making the request is marked as current.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]auth.SessionResponse} "Sessions retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/sessions [get]
+func (h *UserHandler) ListSessions(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+	// Tokens issued before sessions existed have no session ID
+	currentSessionID, _ := uuid.Parse(userClaims.SessionID)
+
+	sessions, err := h.authUseCase.ListSessions(c.Context(), userID, currentSessionID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, sessions, "Sessions retrieved successfully")
+}
+
+// RevokeSession signs out one of the current user's devices
+// @Summary Revoke a session
+// @Description Signs out a device: its refresh token and access tokens stop working immediately. Revoking the current session logs the caller out.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Session ID"
+// @Success 200 {object} responses.SuccessResponse "Session revoked successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid session ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Another user's session"
+// @Failure 404 {object} responses.ErrorResponse "Session not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid session ID format")
+	}
+
+	if err := h.authUseCase.RevokeSession(c.Context(), userID, sessionID); err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, nil, "Session revoked successfully")
+}
+
+// CreateAPIToken creates a personal access token for the current user
+// @Summary Create an API token
+// @Description Creates a named personal access token for scripts and integrations, limited to the given scopes (e.g. chat:read, chat:write, providers:write, market:read; write implies read). The token is returned only once. Cannot be called with an API token; users with 2FA enabled need a recent second factor check.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body auth.CreateAPITokenRequest true "Token name, scopes and expiry"
+// @Success 201 {object} responses.SuccessResponse{data=auth.CreateAPITokenResponse} "API token created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request or scopes"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token or a recent second factor check is required"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/tokens [post]
+func (h *UserHandler) CreateAPIToken(c *fiber.Ctx) error {
+	var req auth.CreateAPITokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+	}
+
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	token, err := h.authUseCase.CreateAPIToken(c.Context(), userID, &req)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendCreated(c, token, "API token created successfully")
+}
+
+// ListAPITokens lists the current user's personal access tokens
+// @Summary List API tokens
+// @Description Lists the current user's personal access tokens, newest first. Token values are never returned, only their first characters.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} responses.SuccessResponse{data=[]auth.APITokenResponse} "API tokens retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Called with an API token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/tokens [get]
+func (h *UserHandler) ListAPITokens(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	tokens, err := h.authUseCase.ListAPITokens(c.Context(), userID)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, tokens, "API tokens retrieved successfully")
+}
+
+// DeleteAPIToken revokes one of the current user's personal access tokens
+// @Summary Delete an API token
+// @Description Revokes a personal access token; requests made with it fail immediately.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "API token ID"
+// @Success 200 {object} responses.SuccessResponse "API token deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid API token ID"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - Another user's token or called with an API token"
+// @Failure 404 {object} responses.ErrorResponse "API token not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/tokens/{id} [delete]
+func (h *UserHandler) DeleteAPIToken(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid API token ID format")
+	}
+
+	if err := h.authUseCase.DeleteAPIToken(c.Context(), userID, tokenID); err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, nil, "API token deleted successfully")
+}
+
+// ListAuditEvents lists the audit log of the current user's account
+// @Summary List audit events
+// @Description Lists the security-relevant actions the current user performed or that concern their account (sign-ins, magic links, API keys, sharing and admin changes), newest first.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Number of events to return" default(50)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} responses.SuccessResponse{data=auth.AuditEventListResponse} "Audit events retrieved successfully"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 403 {object} responses.ErrorResponse "Forbidden - API token lacks the profile scope"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /users/audit [get]
+func (h *UserHandler) ListAuditEvents(c *fiber.Ctx) error {
+	userClaims, ok := c.Locals("user").(*utils.Claims)
+	if !ok || userClaims == nil {
+		return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+	}
+
+	userID, err := uuid.Parse(userClaims.Subject)
+	if err != nil {
+		return responses.SendError(c, fiber.StatusBadRequest, "INVALID_REQUEST", "Invalid user ID format")
+	}
+
+	limit := c.QueryInt("limit", 50)
+	offset := c.QueryInt("offset", 0)
+
+	events, err := h.userUseCase.ListAuditEvents(c.Context(), userID, limit, offset)
+	if err != nil {
+		return responses.HandleError(c, err)
+	}
+
+	return responses.SendSuccess(c, events, "Audit events retrieved successfully")
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
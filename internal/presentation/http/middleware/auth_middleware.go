@@ -3,11 +3,15 @@ package middleware
 import (
 	"strings"
 	"trading-alchemist/internal/application/auth"
+	domainAuth "trading-alchemist/internal/domain/auth"
 	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// NewAuthMiddleware authenticates requests with either a JWT access token or a
+// personal access token in the Authorization header.
 func NewAuthMiddleware(authUseCase *auth.AuthUseCase) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
@@ -21,7 +25,13 @@ func NewAuthMiddleware(authUseCase *auth.AuthUseCase) fiber.Handler {
 		}
 
 		token := parts[1]
-		claims, err := authUseCase.ValidateToken(c.Context(), token)
+		var claims *utils.Claims
+		var err error
+		if auth.IsAPIToken(token) {
+			claims, err = authUseCase.ValidateAPIToken(c.Context(), token)
+		} else {
+			claims, err = authUseCase.ValidateToken(c.Context(), token)
+		}
 		if err != nil {
 			return responses.HandleError(c, err)
 		}
@@ -29,4 +39,79 @@ func NewAuthMiddleware(authUseCase *auth.AuthUseCase) fiber.Handler {
 		c.Locals("user", claims)
 		return c.Next()
 	}
-} 
\ No newline at end of file
+}
+
+// RequireScope rejects requests made with a personal access token that lacks
+// access to the resource: "<resource>:read" for GET and HEAD requests,
+// "<resource>:write" for the others. It must run after the auth middleware.
+func RequireScope(resource string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("user").(*utils.Claims)
+		if !ok || claims == nil {
+			return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		}
+
+		write := c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead
+		if !claims.AllowsScope(resource, write) {
+			scope := resource + ":read"
+			if write {
+				scope = resource + ":write"
+			}
+			return responses.SendError(c, fiber.StatusForbidden, "INSUFFICIENT_SCOPE", "API token lacks the "+scope+" scope")
+		}
+		return c.Next()
+	}
+}
+
+// RequireUserSession rejects requests made with a personal access token, for
+// routes that manage credentials. It must run after the auth middleware.
+func RequireUserSession() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("user").(*utils.Claims)
+		if !ok || claims == nil {
+			return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		}
+		if claims.APITokenID != "" {
+			return responses.SendError(c, fiber.StatusForbidden, "FORBIDDEN", "This endpoint cannot be used with an API token")
+		}
+		return c.Next()
+	}
+}
+
+// RequireRole rejects requests from users that have none of the given roles.
+// It must run after the auth middleware.
+func RequireRole(roles ...domainAuth.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("user").(*utils.Claims)
+		if !ok || claims == nil {
+			return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		}
+		for _, role := range roles {
+			if claims.Role == role {
+				return c.Next()
+			}
+		}
+		return responses.SendError(c, fiber.StatusForbidden, "FORBIDDEN", "You do not have permission to access this resource")
+	}
+}
+
+// RequireRecentSecondFactor rejects sensitive requests from users with 2FA
+// enabled unless their session verified a second factor recently; clients
+// answer a step-up challenge and retry. It must run after the auth middleware.
+func RequireRecentSecondFactor(authUseCase *auth.AuthUseCase) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("user").(*utils.Claims)
+		if !ok || claims == nil {
+			return responses.SendError(c, fiber.StatusUnauthorized, "UNAUTHORIZED", "User not authenticated")
+		}
+
+		allowed, err := authUseCase.HasRecentSecondFactor(c.Context(), claims)
+		if err != nil {
+			return responses.HandleError(c, err)
+		}
+		if !allowed {
+			return responses.SendError(c, fiber.StatusForbidden, "SECOND_FACTOR_REQUIRED", "A recent second factor check is required; complete a step-up challenge and retry")
+		}
+		return c.Next()
+	}
+}
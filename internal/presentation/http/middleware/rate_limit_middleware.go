@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"log"
+	"strconv"
+
+	"trading-alchemist/internal/domain/ratelimit"
+	"trading-alchemist/internal/presentation/responses"
+	"trading-alchemist/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimitKey picks the client a request is counted against.
+type RateLimitKey func(c *fiber.Ctx) string
+
+// ByIP counts requests against the client IP.
+func ByIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// ByUser counts requests against the authenticated user, or the client IP
+// before authentication. It must run after the auth middleware.
+func ByUser(c *fiber.Ctx) string {
+	if claims, ok := c.Locals("user").(*utils.Claims); ok && claims != nil {
+		return "user:" + claims.Subject
+	}
+	return ByIP(c)
+}
+
+// RateLimit rejects requests with 429 and a Retry-After header once the client
+// has used up its limit for scope. Requests are let through if the limiter
+// fails, so an outage of its store does not take the API down with it.
+func RateLimit(limiter ratelimit.Limiter, scope string, limit ratelimit.Limit, key RateLimitKey) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !limit.Enabled() {
+			return c.Next()
+		}
+
+		decision, err := limiter.Allow(c.Context(), scope+":"+key(c), limit)
+		if err != nil {
+			log.Printf("Rate limiter failed for %s, allowing the request: %v", scope, err)
+			return c.Next()
+		}
+		c.Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		if !decision.Allowed {
+			return responses.SendRateLimited(c, decision.RetryAfter, "Too many requests, please try again later")
+		}
+		return c.Next()
+	}
+}
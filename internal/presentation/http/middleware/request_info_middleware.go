@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"trading-alchemist/internal/domain/audit"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestInfo makes the client IP and user agent available to the audit
+// logger through the context handlers pass to use cases.
+func RequestInfo() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(audit.RequestInfoKey, &audit.RequestInfo{
+			IPAddress: c.IP(),
+			UserAgent: c.Get(fiber.HeaderUserAgent),
+		})
+		return c.Next()
+	}
+}
@@ -3,23 +3,57 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 
+	"trading-alchemist/internal/application/account"
+	"trading-alchemist/internal/application/admin"
+	"trading-alchemist/internal/application/alert"
 	"trading-alchemist/internal/application/auth"
+	"trading-alchemist/internal/application/backtest"
+	"trading-alchemist/internal/application/broker"
+	"trading-alchemist/internal/application/calendar"
 	"trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/application/document"
+	"trading-alchemist/internal/application/journal"
+	"trading-alchemist/internal/application/notification"
+	"trading-alchemist/internal/application/organization"
+	"trading-alchemist/internal/application/paper"
+	"trading-alchemist/internal/application/portfolio"
 	"trading-alchemist/internal/config"
+	domainAuth "trading-alchemist/internal/domain/auth"
+	"trading-alchemist/internal/domain/ratelimit"
 	"trading-alchemist/internal/presentation/http/handlers"
 	"trading-alchemist/internal/presentation/http/middleware"
 )
 
 // SetupRoutes configures all application routes
-func SetupRoutes(app *fiber.App, cfg *config.Config, authUseCase *auth.AuthUseCase, userUseCase *auth.UserUseCase, chatUseCase *chat.ChatUseCase, conversationUseCase *chat.ConversationUseCase, providerUseCase *chat.UserProviderSettingUseCase, modelAvailabilityUseCase *chat.ModelAvailabilityUseCase) {
+func SetupRoutes(app *fiber.App, cfg *config.Config, authUseCase *auth.AuthUseCase, userUseCase *auth.UserUseCase, chatUseCase *chat.ChatUseCase, conversationUseCase *chat.ConversationUseCase, providerUseCase *chat.UserProviderSettingUseCase, modelAvailabilityUseCase *chat.ModelAvailabilityUseCase, backtestUseCase *backtest.BacktestUseCase, strategyUseCase *backtest.StrategyUseCase, paperUseCase *paper.PaperTradingUseCase, portfolioUseCase *portfolio.PortfolioUseCase, alertUseCase *alert.AlertUseCase, notificationUseCase *notification.NotificationUseCase, brokerUseCase *broker.BrokerUseCase, journalUseCase *journal.JournalUseCase, calendarUseCase *calendar.CalendarUseCase, memoryUseCase *chat.MemoryUseCase, documentUseCase *document.DocumentUseCase, knowledgeBaseUseCase *document.KnowledgeBaseUseCase, adminUseCase *admin.AdminUseCase, organizationUseCase *organization.OrganizationUseCase, accountUseCase *account.AccountUseCase, rateLimiter ratelimit.Limiter) {
 	// Create handlers
 	authHandler := handlers.NewAuthHandler(authUseCase)
 	userHandler := handlers.NewUserHandler(userUseCase, authUseCase)
+	accountHandler := handlers.NewAccountHandler(accountUseCase)
+	twoFactorHandler := handlers.NewTwoFactorHandler(authUseCase)
 	chatHandler := handlers.NewChatHandler(chatUseCase, conversationUseCase)
 	providerHandler := handlers.NewProviderHandler(providerUseCase, modelAvailabilityUseCase)
+	strategyHandler := handlers.NewStrategyHandler(strategyUseCase, backtestUseCase)
+	paperHandler := handlers.NewPaperHandler(paperUseCase)
+	portfolioHandler := handlers.NewPortfolioHandler(portfolioUseCase)
+	alertHandler := handlers.NewAlertHandler(alertUseCase)
+	notificationHandler := handlers.NewNotificationHandler(notificationUseCase)
+	brokerHandler := handlers.NewBrokerHandler(brokerUseCase)
+	journalHandler := handlers.NewJournalHandler(journalUseCase)
+	calendarHandler := handlers.NewCalendarHandler(calendarUseCase)
+	memoryHandler := handlers.NewMemoryHandler(memoryUseCase)
+	adminHandler := handlers.NewAdminHandler(adminUseCase)
+	organizationHandler := handlers.NewOrganizationHandler(organizationUseCase)
 
 	// Create auth middleware
 	authMiddleware := middleware.NewAuthMiddleware(authUseCase)
+	// Sensitive actions need a recent second factor from users with 2FA enabled
+	stepUpMiddleware := middleware.RequireRecentSecondFactor(authUseCase)
+
+	// Throttle magic link emails, sign-in attempts and chat messages
+	magicLinkLimit := middleware.RateLimit(rateLimiter, "magic_link", ratelimit.Limit(cfg.RateLimit.MagicLinkPerIP), middleware.ByIP)
+	authLimit := middleware.RateLimit(rateLimiter, "auth", ratelimit.Limit(cfg.RateLimit.AuthPerIP), middleware.ByIP)
+	chatLimit := middleware.RateLimit(rateLimiter, "chat", ratelimit.Limit(cfg.RateLimit.ChatPerUser), middleware.ByUser)
 
 	// Health check endpoints (outside API versioning for monitoring)
 	app.Get("/health", handlers.CheckHealth)
@@ -33,10 +67,28 @@ func SetupRoutes(app *fiber.App, cfg *config.Config, authUseCase *auth.AuthUseCa
 
 	// Setup routes for each handler
 	setupHealthRoutes(v1)
-	setupV1AuthRoutes(v1, authHandler)
-	setupV1UserRoutes(v1, userHandler, authMiddleware)
-	setupV1ChatRoutes(v1, chatHandler, authMiddleware)
-	setupV1ProviderRoutes(v1, providerHandler, authMiddleware)
+	setupV1AuthRoutes(v1, authHandler, magicLinkLimit, authLimit)
+	setupV1UserRoutes(v1, userHandler, accountHandler, twoFactorHandler, authMiddleware, stepUpMiddleware, authLimit)
+	setupV1ChatRoutes(v1, chatHandler, authMiddleware, chatLimit)
+	setupV1ProviderRoutes(v1, providerHandler, authMiddleware, stepUpMiddleware)
+	setupV1StrategyRoutes(v1, strategyHandler, authMiddleware)
+	setupV1PaperRoutes(v1, paperHandler, authMiddleware)
+	setupV1PortfolioRoutes(v1, portfolioHandler, authMiddleware)
+	setupV1WatchlistRoutes(v1, alertHandler, authMiddleware)
+	setupV1AlertRoutes(v1, alertHandler, authMiddleware)
+	setupV1NotificationRoutes(v1, notificationHandler, authMiddleware)
+	setupV1BrokerRoutes(v1, brokerHandler, authMiddleware, stepUpMiddleware)
+	setupV1JournalRoutes(v1, journalHandler, authMiddleware)
+	setupV1CalendarRoutes(v1, calendarHandler, authMiddleware)
+	setupV1MemoryRoutes(v1, memoryHandler, authMiddleware)
+	setupV1AdminRoutes(v1, adminHandler, authMiddleware, stepUpMiddleware)
+	setupV1OrganizationRoutes(v1, organizationHandler, providerHandler, authMiddleware, stepUpMiddleware)
+
+	// Document retrieval is optional
+	if documentUseCase != nil {
+		setupV1DocumentRoutes(v1, handlers.NewDocumentHandler(documentUseCase), authMiddleware)
+		setupV1KnowledgeBaseRoutes(v1, handlers.NewKnowledgeBaseHandler(knowledgeBaseUseCase), authMiddleware)
+	}
 }
 
 // setupDocumentationRoutes sets up Swagger documentation routes
@@ -81,53 +133,316 @@ func setupHealthRoutes(v1 fiber.Router) {
 }
 
 // setupV1AuthRoutes configures v1 authentication routes
-func setupV1AuthRoutes(v1 fiber.Router, authHandler *handlers.AuthHandler) {
+func setupV1AuthRoutes(v1 fiber.Router, authHandler *handlers.AuthHandler, magicLinkLimit, authLimit fiber.Handler) {
 	auth := v1.Group("/auth")
 
 	// Authentication endpoints
-	auth.Post("/magic-link", authHandler.SendMagicLink)      // POST /api/v1/auth/magic-link
+	auth.Post("/magic-link", magicLinkLimit, authHandler.SendMagicLink) // POST /api/v1/auth/magic-link
 
 	// NOTE: This endpoint is POST to allow the frontend to securely send
 	// the token in the request body after extracting it from the URL.
-	auth.Post("/verify", authHandler.VerifyMagicLink)        // POST /api/v1/auth/verify
+	auth.Post("/verify", authLimit, authHandler.VerifyMagicLink) // POST /api/v1/auth/verify
+	auth.Post("/refresh", authLimit, authHandler.RefreshToken)   // POST /api/v1/auth/refresh
+	auth.Post("/logout", authHandler.Logout)                 // POST /api/v1/auth/logout
+
+	// Sign-in with external OIDC/OAuth providers
+	auth.Get("/oidc/providers", authHandler.ListOIDCProviders)            // GET /api/v1/auth/oidc/providers
+	auth.Post("/oidc/callback", authLimit, authHandler.CompleteOIDCLogin)         // POST /api/v1/auth/oidc/callback
+	auth.Post("/oidc/:provider/authorize", authLimit, authHandler.StartOIDCLogin) // POST /api/v1/auth/oidc/:provider/authorize
+
+	// Second step of a sign-in for users with 2FA enabled
+	auth.Post("/2fa/verify", authLimit, authHandler.VerifyTwoFactor) // POST /api/v1/auth/2fa/verify
 
 }
 
 // setupV1UserRoutes configures v1 user routes
-func setupV1UserRoutes(v1 fiber.Router, userHandler *handlers.UserHandler, authMiddleware fiber.Handler) {
+func setupV1UserRoutes(v1 fiber.Router, userHandler *handlers.UserHandler, accountHandler *handlers.AccountHandler, twoFactorHandler *handlers.TwoFactorHandler, authMiddleware, stepUpMiddleware, authLimit fiber.Handler) {
 	users := v1.Group("/users")
 
 	// Protected user routes
 	users.Use(authMiddleware)
-	users.Get("/profile", userHandler.GetProfile)
-	users.Put("/profile", userHandler.UpdateProfile)
+	users.Get("/profile", middleware.RequireScope(domainAuth.ScopeResourceProfile), userHandler.GetProfile)
+	users.Put("/profile", middleware.RequireScope(domainAuth.ScopeResourceProfile), userHandler.UpdateProfile)
+	users.Get("/audit", middleware.RequireScope(domainAuth.ScopeResourceProfile), userHandler.ListAuditEvents)
+
+	// Credential management is not available to API tokens
+	users.Get("/sessions", middleware.RequireUserSession(), userHandler.ListSessions)
+	users.Delete("/sessions/:id", middleware.RequireUserSession(), userHandler.RevokeSession)
+	users.Post("/tokens", middleware.RequireUserSession(), stepUpMiddleware, userHandler.CreateAPIToken)
+	users.Get("/tokens", middleware.RequireUserSession(), userHandler.ListAPITokens)
+	users.Delete("/tokens/:id", middleware.RequireUserSession(), userHandler.DeleteAPIToken)
+
+	// Data export and account deletion; deletions can be cancelled during the grace period
+	users.Get("/me/export", middleware.RequireUserSession(), stepUpMiddleware, accountHandler.ExportData)
+	users.Delete("/me", middleware.RequireUserSession(), stepUpMiddleware, accountHandler.DeleteAccount)
+	users.Get("/me/deletion", middleware.RequireUserSession(), accountHandler.GetDeletion)
+	users.Delete("/me/deletion", middleware.RequireUserSession(), accountHandler.CancelDeletion)
+
+	// Second factors; adding or removing one once 2FA is enabled needs a recent check
+	twoFactor := users.Group("/2fa", middleware.RequireUserSession())
+	twoFactor.Get("/", twoFactorHandler.GetStatus)
+	twoFactor.Post("/totp", stepUpMiddleware, twoFactorHandler.EnrollTOTP)
+	twoFactor.Post("/totp/confirm", authLimit, twoFactorHandler.ConfirmTOTP)
+	twoFactor.Delete("/totp", stepUpMiddleware, twoFactorHandler.DisableTOTP)
+	twoFactor.Post("/recovery-codes", stepUpMiddleware, twoFactorHandler.RegenerateRecoveryCodes)
+	twoFactor.Post("/webauthn/options", stepUpMiddleware, twoFactorHandler.StartWebAuthnRegistration)
+	twoFactor.Post("/webauthn", twoFactorHandler.RegisterWebAuthn)
+	twoFactor.Delete("/webauthn/:id", stepUpMiddleware, twoFactorHandler.DeleteWebAuthnCredential)
+	twoFactor.Post("/step-up", twoFactorHandler.StartStepUp)
+	twoFactor.Post("/step-up/verify", authLimit, twoFactorHandler.VerifyStepUp)
 }
 
 // setupV1ChatRoutes configures v1 chat routes
-func setupV1ChatRoutes(v1 fiber.Router, chatHandler *handlers.ChatHandler, authMiddleware fiber.Handler) {
+func setupV1ChatRoutes(v1 fiber.Router, chatHandler *handlers.ChatHandler, authMiddleware, chatLimit fiber.Handler) {
 	conversations := v1.Group("/conversations")
-	conversations.Use(authMiddleware)
+	conversations.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceChat))
 
 	conversations.Get("/", chatHandler.GetConversations)
 	conversations.Post("/", chatHandler.CreateConversation)
+	conversations.Get("/semantic-search", chatHandler.SemanticSearch)
 	conversations.Get("/:id", chatHandler.GetConversation)
 	conversations.Put("/:id/title", chatHandler.UpdateConversationTitle)
+	conversations.Put("/:id/settings", chatHandler.UpdateConversationSettings)
+	conversations.Put("/:id/organization", chatHandler.ShareConversation)
 	conversations.Delete("/:id", chatHandler.ArchiveConversation)
-	conversations.Post("/:id/messages", chatHandler.PostMessage)
+	conversations.Post("/:id/messages", chatLimit, chatHandler.PostMessage)
+	conversations.Post("/:id/tool-calls/:toolCallId/confirm", chatLimit, chatHandler.ConfirmToolCall)
 
 	// Tool routes
 	tools := v1.Group("/tools")
-	tools.Use(authMiddleware)
+	tools.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceChat))
 	tools.Get("/", chatHandler.GetAvailableTools)
 }
 
 // setupV1ProviderRoutes configures v1 provider routes
-func setupV1ProviderRoutes(v1 fiber.Router, providerHandler *handlers.ProviderHandler, authMiddleware fiber.Handler) {
+func setupV1ProviderRoutes(v1 fiber.Router, providerHandler *handlers.ProviderHandler, authMiddleware, stepUpMiddleware fiber.Handler) {
 	providers := v1.Group("/providers")
-	providers.Use(authMiddleware)
+	providers.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceProviders))
 
 	providers.Get("/", providerHandler.ListProviders)
 	providers.Get("/settings", providerHandler.ListUserSettings)
-	providers.Post("/settings", providerHandler.UpsertUserSetting)
+	providers.Post("/settings", stepUpMiddleware, providerHandler.UpsertUserSetting)
+}
+
+
+// setupV1StrategyRoutes configures v1 strategy and backtest routes
+func setupV1StrategyRoutes(v1 fiber.Router, strategyHandler *handlers.StrategyHandler, authMiddleware fiber.Handler) {
+	strategies := v1.Group("/strategies")
+	strategies.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceStrategy))
+
+	strategies.Get("/", strategyHandler.ListStrategies)
+	strategies.Post("/validate", strategyHandler.ValidateStrategy)
+	strategies.Get("/:id", strategyHandler.GetStrategy)
+	strategies.Put("/:id", strategyHandler.UpdateStrategy)
+	strategies.Get("/:id/versions", strategyHandler.GetStrategyVersions)
+	strategies.Post("/:id/backtest", strategyHandler.RunStrategyBacktest)
+
+	backtests := v1.Group("/backtests")
+	backtests.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceStrategy))
+	backtests.Post("/", strategyHandler.RunBacktest)
+}
+
+// setupV1PaperRoutes configures v1 paper trading routes
+func setupV1PaperRoutes(v1 fiber.Router, paperHandler *handlers.PaperHandler, authMiddleware fiber.Handler) {
+	accounts := v1.Group("/paper/accounts")
+	accounts.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourcePaper))
+
+	accounts.Get("/", paperHandler.ListAccounts)
+	accounts.Post("/", paperHandler.CreateAccount)
+	accounts.Get("/:id", paperHandler.GetAccount)
+	accounts.Delete("/:id", paperHandler.DeleteAccount)
+	accounts.Get("/:id/positions", paperHandler.ListPositions)
+	accounts.Get("/:id/orders", paperHandler.ListOrders)
+	accounts.Post("/:id/orders", paperHandler.PlaceOrder)
+	accounts.Delete("/:id/orders/:orderId", paperHandler.CancelOrder)
+}
+
+// setupV1PortfolioRoutes configures v1 portfolio tracking routes
+func setupV1PortfolioRoutes(v1 fiber.Router, portfolioHandler *handlers.PortfolioHandler, authMiddleware fiber.Handler) {
+	portfolios := v1.Group("/portfolios")
+	portfolios.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourcePortfolio))
+
+	portfolios.Get("/", portfolioHandler.ListPortfolios)
+	portfolios.Post("/", portfolioHandler.CreatePortfolio)
+	portfolios.Get("/:id", portfolioHandler.GetPortfolio)
+	portfolios.Put("/:id", portfolioHandler.UpdatePortfolio)
+	portfolios.Delete("/:id", portfolioHandler.DeletePortfolio)
+	portfolios.Get("/:id/summary", portfolioHandler.GetSummary)
+	portfolios.Get("/:id/transactions", portfolioHandler.ListTransactions)
+	portfolios.Post("/:id/transactions", portfolioHandler.AddTransaction)
+	portfolios.Delete("/:id/transactions/:transactionId", portfolioHandler.DeleteTransaction)
+	portfolios.Post("/:id/holdings", portfolioHandler.AddHolding)
+	portfolios.Put("/:id/assets/:symbol", portfolioHandler.UpdateAsset)
+	portfolios.Post("/:id/import", portfolioHandler.ImportTransactions)
+}
+
+// setupV1WatchlistRoutes configures v1 watchlist routes
+func setupV1WatchlistRoutes(v1 fiber.Router, alertHandler *handlers.AlertHandler, authMiddleware fiber.Handler) {
+	watchlists := v1.Group("/watchlists")
+	watchlists.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceAlerts))
+
+	watchlists.Get("/", alertHandler.ListWatchlists)
+	watchlists.Post("/", alertHandler.CreateWatchlist)
+	watchlists.Get("/:id", alertHandler.GetWatchlist)
+	watchlists.Put("/:id", alertHandler.UpdateWatchlist)
+	watchlists.Delete("/:id", alertHandler.DeleteWatchlist)
+	watchlists.Post("/:id/items", alertHandler.AddWatchlistItem)
+	watchlists.Delete("/:id/items/:symbol", alertHandler.RemoveWatchlistItem)
+}
+
+// setupV1AlertRoutes configures v1 alert rule and alert history routes
+func setupV1AlertRoutes(v1 fiber.Router, alertHandler *handlers.AlertHandler, authMiddleware fiber.Handler) {
+	alerts := v1.Group("/alerts")
+	alerts.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceAlerts))
+
+	alerts.Get("/", alertHandler.ListRules)
+	alerts.Post("/", alertHandler.CreateRule)
+	alerts.Get("/events", alertHandler.ListEvents)
+	alerts.Get("/:id", alertHandler.GetRule)
+	alerts.Put("/:id", alertHandler.UpdateRule)
+	alerts.Delete("/:id", alertHandler.DeleteRule)
+	alerts.Get("/:id/events", alertHandler.ListRuleEvents)
+}
+
+// setupV1NotificationRoutes configures v1 in-app notification routes
+func setupV1NotificationRoutes(v1 fiber.Router, notificationHandler *handlers.NotificationHandler, authMiddleware fiber.Handler) {
+	notifications := v1.Group("/notifications")
+	notifications.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceAlerts))
+
+	notifications.Get("/", notificationHandler.ListNotifications)
+	notifications.Get("/unread-count", notificationHandler.CountUnread)
+	notifications.Post("/read-all", notificationHandler.MarkAllRead)
+	notifications.Post("/:id/read", notificationHandler.MarkRead)
+	notifications.Delete("/:id", notificationHandler.DeleteNotification)
 }
 
+// setupV1BrokerRoutes configures v1 broker connection and order routing routes
+func setupV1BrokerRoutes(v1 fiber.Router, brokerHandler *handlers.BrokerHandler, authMiddleware, stepUpMiddleware fiber.Handler) {
+	brokers := v1.Group("/brokers")
+	brokers.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceBroker))
+
+	brokers.Get("/", brokerHandler.ListConnections)
+	brokers.Post("/", stepUpMiddleware, brokerHandler.CreateConnection)
+	brokers.Get("/:id", brokerHandler.GetConnection)
+	brokers.Put("/:id", stepUpMiddleware, brokerHandler.UpdateConnection)
+	brokers.Delete("/:id", brokerHandler.DeleteConnection)
+	brokers.Get("/:id/account", brokerHandler.GetAccount)
+	brokers.Get("/:id/positions", brokerHandler.GetPositions)
+	brokers.Get("/:id/orders", brokerHandler.ListOrders)
+	brokers.Post("/:id/orders", brokerHandler.PlaceOrder)
+	brokers.Get("/:id/orders/stream", brokerHandler.StreamOrderUpdates)
+	brokers.Get("/:id/orders/:orderId", brokerHandler.GetOrder)
+	brokers.Delete("/:id/orders/:orderId", brokerHandler.CancelOrder)
+}
+
+// setupV1JournalRoutes configures v1 trading journal routes
+func setupV1JournalRoutes(v1 fiber.Router, journalHandler *handlers.JournalHandler, authMiddleware fiber.Handler) {
+	journal := v1.Group("/journal")
+	journal.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceJournal))
+
+	journal.Get("/entries", journalHandler.ListEntries)
+	journal.Post("/entries", journalHandler.CreateEntry)
+	journal.Get("/entries/:id", journalHandler.GetEntry)
+	journal.Put("/entries/:id", journalHandler.UpdateEntry)
+	journal.Delete("/entries/:id", journalHandler.DeleteEntry)
+	journal.Get("/stats", journalHandler.GetStats)
+	journal.Post("/review", journalHandler.ReviewTrades)
+}
+
+// setupV1CalendarRoutes configures v1 events calendar routes
+func setupV1CalendarRoutes(v1 fiber.Router, calendarHandler *handlers.CalendarHandler, authMiddleware fiber.Handler) {
+	calendar := v1.Group("/calendar")
+	calendar.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceMarket))
+
+	calendar.Get("/events", calendarHandler.ListEvents)
+	calendar.Post("/events", calendarHandler.CreateEvent)
+	calendar.Post("/events/import", calendarHandler.ImportEvents)
+	calendar.Delete("/events/:id", calendarHandler.DeleteEvent)
+}
+
+// setupV1MemoryRoutes configures v1 user memory routes
+func setupV1MemoryRoutes(v1 fiber.Router, memoryHandler *handlers.MemoryHandler, authMiddleware fiber.Handler) {
+	memories := v1.Group("/memories")
+	memories.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceChat))
+
+	memories.Get("/", memoryHandler.ListMemories)
+	memories.Put("/:id", memoryHandler.UpdateMemory)
+	memories.Delete("/:id", memoryHandler.DeleteMemory)
+}
+
+// setupV1AdminRoutes configures v1 administration routes, available to admins
+// signed in with a session
+func setupV1AdminRoutes(v1 fiber.Router, adminHandler *handlers.AdminHandler, authMiddleware, stepUpMiddleware fiber.Handler) {
+	adminGroup := v1.Group("/admin")
+	adminGroup.Use(authMiddleware, middleware.RequireUserSession(), middleware.RequireRole(domainAuth.RoleAdmin))
+
+	adminGroup.Get("/providers", adminHandler.ListProviders)
+	adminGroup.Post("/providers", adminHandler.CreateProvider)
+	adminGroup.Put("/providers/:id", adminHandler.UpdateProvider)
+	adminGroup.Post("/providers/:id/models", adminHandler.CreateModel)
+	adminGroup.Put("/models/:id", adminHandler.UpdateModel)
+
+	adminGroup.Get("/tools", adminHandler.ListTools)
+	adminGroup.Put("/tools/:id", adminHandler.UpdateTool)
+
+	adminGroup.Get("/users", adminHandler.ListUsers)
+	adminGroup.Get("/users/:id", adminHandler.GetUser)
+	adminGroup.Get("/users/:id/usage", adminHandler.GetUserUsage)
+	adminGroup.Post("/users/:id/verify-email", adminHandler.VerifyUserEmail)
+	adminGroup.Post("/users/:id/deactivate", stepUpMiddleware, adminHandler.DeactivateUser)
+	adminGroup.Put("/users/:id/role", stepUpMiddleware, adminHandler.UpdateUserRole)
+
+	adminGroup.Get("/audit", adminHandler.ListAuditEvents)
+}
+
+// setupV1OrganizationRoutes configures v1 organization routes
+func setupV1OrganizationRoutes(v1 fiber.Router, organizationHandler *handlers.OrganizationHandler, providerHandler *handlers.ProviderHandler, authMiddleware, stepUpMiddleware fiber.Handler) {
+	organizations := v1.Group("/organizations")
+	organizations.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceOrganizations))
+
+	organizations.Get("/", organizationHandler.ListOrganizations)
+	organizations.Post("/", organizationHandler.CreateOrganization)
+	organizations.Get("/:id", organizationHandler.GetOrganization)
+	organizations.Put("/:id", organizationHandler.UpdateOrganization)
+	organizations.Delete("/:id", stepUpMiddleware, organizationHandler.DeleteOrganization)
+	organizations.Post("/:id/invitations", organizationHandler.InviteMember)
+	organizations.Delete("/:id/invitations/:invitationId", organizationHandler.RevokeInvitation)
+	organizations.Put("/:id/members/:userId", organizationHandler.UpdateMember)
+	organizations.Delete("/:id/members/:userId", organizationHandler.RemoveMember)
+
+	// Organization API keys also need the providers scope
+	providerScope := middleware.RequireScope(domainAuth.ScopeResourceProviders)
+	organizations.Get("/:id/provider-settings", providerScope, providerHandler.ListOrganizationSettings)
+	organizations.Post("/:id/provider-settings", providerScope, stepUpMiddleware, providerHandler.UpsertOrganizationSetting)
+}
+
+// setupV1DocumentRoutes configures v1 document routes
+func setupV1DocumentRoutes(v1 fiber.Router, documentHandler *handlers.DocumentHandler, authMiddleware fiber.Handler) {
+	documents := v1.Group("/documents")
+	documents.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceDocuments))
+
+	documents.Post("/", documentHandler.UploadDocument)
+	documents.Get("/", documentHandler.ListDocuments)
+	documents.Get("/:id", documentHandler.GetDocument)
+	documents.Put("/:id", documentHandler.ReplaceDocument)
+	documents.Delete("/:id", documentHandler.DeleteDocument)
+}
+
+// setupV1KnowledgeBaseRoutes configures v1 knowledge base routes
+func setupV1KnowledgeBaseRoutes(v1 fiber.Router, knowledgeBaseHandler *handlers.KnowledgeBaseHandler, authMiddleware fiber.Handler) {
+	knowledgeBases := v1.Group("/knowledge-bases")
+	knowledgeBases.Use(authMiddleware, middleware.RequireScope(domainAuth.ScopeResourceDocuments))
+
+	knowledgeBases.Post("/", knowledgeBaseHandler.CreateKnowledgeBase)
+	knowledgeBases.Get("/", knowledgeBaseHandler.ListKnowledgeBases)
+	knowledgeBases.Get("/:id", knowledgeBaseHandler.GetKnowledgeBase)
+	knowledgeBases.Put("/:id", knowledgeBaseHandler.UpdateKnowledgeBase)
+	knowledgeBases.Delete("/:id", knowledgeBaseHandler.DeleteKnowledgeBase)
+	knowledgeBases.Put("/:id/members", knowledgeBaseHandler.SetMember)
+	knowledgeBases.Delete("/:id/members/:userId", knowledgeBaseHandler.RemoveMember)
+	knowledgeBases.Post("/:id/documents", knowledgeBaseHandler.AddDocument)
+	knowledgeBases.Delete("/:id/documents/:documentId", knowledgeBaseHandler.RemoveDocument)
+	knowledgeBases.Post("/:id/reindex", knowledgeBaseHandler.ReindexKnowledgeBase)
+	knowledgeBases.Put("/:id/conversations/:conversationId", knowledgeBaseHandler.AttachToConversation)
+	knowledgeBases.Delete("/:id/conversations/:conversationId", knowledgeBaseHandler.DetachFromConversation)
+}
@@ -2,34 +2,64 @@ package server
 
 import (
 	"context"
+	"log"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 
+	"trading-alchemist/internal/application/account"
+	"trading-alchemist/internal/application/admin"
+	"trading-alchemist/internal/application/alert"
+	"trading-alchemist/internal/application/analysis"
 	"trading-alchemist/internal/application/auth"
+	"trading-alchemist/internal/application/backtest"
+	"trading-alchemist/internal/application/broker"
+	"trading-alchemist/internal/application/calendar"
+	"trading-alchemist/internal/application/chart"
 	"trading-alchemist/internal/application/chat"
+	"trading-alchemist/internal/application/document"
+	"trading-alchemist/internal/application/journal"
+	"trading-alchemist/internal/application/notification"
+	"trading-alchemist/internal/application/organization"
+	"trading-alchemist/internal/application/paper"
+	"trading-alchemist/internal/application/portfolio"
 	"trading-alchemist/internal/config"
+	"trading-alchemist/internal/domain/ratelimit"
 	"trading-alchemist/internal/domain/services"
 	"trading-alchemist/internal/infrastructure/database"
+	"trading-alchemist/internal/infrastructure/sandbox"
 	infraServices "trading-alchemist/internal/infrastructure/services"
+	"trading-alchemist/internal/infrastructure/vectorindex"
+	"trading-alchemist/internal/presentation/http/middleware"
 	"trading-alchemist/internal/presentation/http/routes"
 	"trading-alchemist/internal/presentation/responses"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	app    *fiber.App
-	config *config.Config
+	app            *fiber.App
+	config         *config.Config
+	deletionWorker *account.Worker // nil when the account deletion worker is disabled
 }
 
 // NewServer creates a new HTTP server with all dependencies
-func NewServer(cfg *config.Config, authUseCase *auth.AuthUseCase, dbService *database.Service, llmService services.LLMService) *Server {
+func NewServer(cfg *config.Config, authUseCase *auth.AuthUseCase, organizationUseCase *organization.OrganizationUseCase, rateLimiter ratelimit.Limiter, auditLogger services.AuditLogger, emailService services.EmailService, dbService *database.Service, llmService services.LLMService) *Server {
+	// Uploaded documents can be larger than Fiber's default body limit
+	bodyLimit := fiber.DefaultBodyLimit
+	if cfg.RAG.Enabled && (cfg.RAG.MaxUploadMB+1)<<20 > bodyLimit {
+		bodyLimit = (cfg.RAG.MaxUploadMB + 1) << 20
+	}
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
+		BodyLimit:      bodyLimit,
 		ReadTimeout:    cfg.Server.ReadTimeout,
 		WriteTimeout:   cfg.Server.WriteTimeout,
+		ProxyHeader:    cfg.Server.ProxyHeader,
+		// Take the first valid address from proxy headers listing several
+		EnableIPValidation: cfg.Server.ProxyHeader != "",
 		StrictRouting:  false,
 		CaseSensitive:  false,
 		ServerHeader:   "Trading Alchemist",
@@ -47,12 +77,69 @@ func NewServer(cfg *config.Config, authUseCase *auth.AuthUseCase, dbService *dat
 		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
 		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
 	}))
+	app.Use(middleware.RequestInfo())
 
 	// Create use cases
 	userUseCase := auth.NewUserUseCase(dbService)
-	conversationUseCase := chat.NewConversationUseCase(dbService, cfg, llmService)
-	chatUseCase := chat.NewChatUseCase(dbService, cfg, llmService, conversationUseCase)
-	providerUseCase := chat.NewUserProviderSettingUseCase(dbService, cfg)
+	conversationUseCase := chat.NewConversationUseCase(dbService, cfg, llmService, auditLogger)
+	backtestUseCase := backtest.NewBacktestUseCase(dbService)
+	strategyUseCase := backtest.NewStrategyUseCase(dbService, backtestUseCase)
+	paperUseCase := paper.NewPaperTradingUseCase(dbService)
+	portfolioUseCase := portfolio.NewPortfolioUseCase(dbService)
+	alertUseCase := alert.NewAlertUseCase(dbService)
+	notificationUseCase := notification.NewNotificationUseCase(dbService)
+	chartUseCase := chart.NewChartUseCase(dbService)
+	brokerUseCase := broker.NewBrokerUseCase(dbService, cfg)
+	journalUseCase := journal.NewJournalUseCase(dbService, cfg, llmService, conversationUseCase)
+	calendarUseCase := calendar.NewCalendarUseCase(dbService)
+	adminUseCase := admin.NewAdminUseCase(dbService, auditLogger)
+
+	// Documents are searched for passages relevant to each chat message
+	var documentUseCase *document.DocumentUseCase
+	var knowledgeBaseUseCase *document.KnowledgeBaseUseCase
+	var retriever services.Retriever
+	var messageSearcher services.MessageSearcher
+	var messageSearchUseCase *document.MessageSearchUseCase
+	if cfg.RAG.Enabled {
+		index, err := vectorindex.New(context.Background(), cfg.RAG.VectorIndex, dbService)
+		if err != nil {
+			panic("Failed to create vector index: " + err.Error())
+		}
+		documentUseCase = document.NewDocumentUseCase(dbService, cfg, llmService, index)
+		knowledgeBaseUseCase = document.NewKnowledgeBaseUseCase(dbService, documentUseCase, conversationUseCase, auditLogger)
+		retriever = documentUseCase
+		// Chat messages are embedded with the same model so past conversations can be searched
+		messageSearchUseCase = document.NewMessageSearchUseCase(dbService, documentUseCase)
+		messageSearcher = messageSearchUseCase
+	}
+
+	// Register the tools the LLM can call and make sure they exist in the tools table
+	toolRegistry := chat.NewToolRegistry(
+		backtest.NewRunBacktestTool(backtestUseCase),
+		backtest.NewSaveStrategyTool(dbService),
+		paper.NewPlaceOrderTool(paperUseCase),
+		paper.NewGetPositionsTool(paperUseCase),
+		portfolio.NewGetPortfolioSummaryTool(portfolioUseCase),
+		chart.NewRenderChartTool(chartUseCase),
+		calendar.NewGetUpcomingEventsTool(calendarUseCase),
+	)
+	if messageSearchUseCase != nil {
+		toolRegistry.Register(document.NewRecallPastConversationsTool(messageSearchUseCase))
+	}
+	if cfg.Sandbox.Enabled {
+		codeSandbox, err := sandbox.NewSandbox(cfg.Sandbox)
+		if err != nil {
+			panic("Failed to create code sandbox: " + err.Error())
+		}
+		toolRegistry.Register(analysis.NewRunCodeTool(analysis.NewAnalysisUseCase(dbService, codeSandbox)))
+	}
+	if err := toolRegistry.SyncDefinitions(context.Background(), dbService); err != nil {
+		panic("Failed to sync tool definitions: " + err.Error())
+	}
+
+	memoryUseCase := chat.NewMemoryUseCase(dbService, llmService)
+	chatUseCase := chat.NewChatUseCase(dbService, cfg, llmService, conversationUseCase, toolRegistry, retriever, messageSearcher, memoryUseCase)
+	providerUseCase := chat.NewUserProviderSettingUseCase(dbService, cfg, auditLogger)
 	
 	// Create API key service and model availability use case
 	// We create a temporary repository provider to access the user provider setting repository
@@ -66,12 +153,29 @@ func NewServer(cfg *config.Config, authUseCase *auth.AuthUseCase, dbService *dat
 		panic("Failed to create model availability use case: " + err.Error())
 	}
 
+	// Deleted accounts are purged along with their documents' vector index entries
+	accountUseCase := account.NewAccountUseCase(dbService, emailService, auditLogger, cfg, documentUseCase)
+	var deletionWorker *account.Worker
+	if cfg.Account.DeletionWorkerEnabled {
+		deletionWorker = account.NewWorker(accountUseCase, cfg.Account.DeletionWorkerInterval)
+	}
+
 	// Setup all routes with use cases
-	routes.SetupRoutes(app, cfg, authUseCase, userUseCase, chatUseCase, conversationUseCase, providerUseCase, modelAvailabilityUseCase)
+	routes.SetupRoutes(app, cfg, authUseCase, userUseCase, chatUseCase, conversationUseCase, providerUseCase, modelAvailabilityUseCase, backtestUseCase, strategyUseCase, paperUseCase, portfolioUseCase, alertUseCase, notificationUseCase, brokerUseCase, journalUseCase, calendarUseCase, memoryUseCase, documentUseCase, knowledgeBaseUseCase, adminUseCase, organizationUseCase, accountUseCase, rateLimiter)
 
 	return &Server{
-		app:    app,
-		config: cfg,
+		app:            app,
+		config:         cfg,
+		deletionWorker: deletionWorker,
+	}
+}
+
+// StartWorkers starts the background workers that depend on the server's use
+// cases; they stop when the context is cancelled.
+func (s *Server) StartWorkers(ctx context.Context) {
+	if s.deletionWorker != nil {
+		go s.deletionWorker.Run(ctx)
+		log.Printf("Account deletion worker started, purging accounts every %s", s.config.Account.DeletionWorkerInterval)
 	}
 }
 
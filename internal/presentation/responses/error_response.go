@@ -1,7 +1,10 @@
 package responses
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"trading-alchemist/pkg/errors"
 
@@ -57,8 +60,22 @@ func SendError(c *fiber.Ctx, statusCode int, code, message string, details ...st
 	return c.Status(statusCode).JSON(response)
 }
 
+// SendRateLimited sends a 429 response telling the client when it may retry
+func SendRateLimited(c *fiber.Ctx, retryAfter time.Duration, message string) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return SendError(c, http.StatusTooManyRequests, errors.CodeRateLimited, message)
+}
+
 // HandleError handles application errors and sends appropriate responses
 func HandleError(c *fiber.Ctx, err error) error {
+	if rateLimitErr, ok := err.(*errors.RateLimitError); ok {
+		return SendRateLimited(c, rateLimitErr.RetryAfter, rateLimitErr.Message)
+	}
+
 	// Check if it's an AppError
 	if appErr, ok := err.(*errors.AppError); ok {
 		statusCode := getHTTPStatusFromErrorCode(appErr.Code)
@@ -111,6 +128,8 @@ func getHTTPStatusFromErrorCode(code string) int {
 		return http.StatusNotFound
 	case errors.CodeConflict:
 		return http.StatusConflict
+	case errors.CodeRateLimited:
+		return http.StatusTooManyRequests
 	case errors.CodeInternalServer:
 		return http.StatusInternalServerError
 	default:
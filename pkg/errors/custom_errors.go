@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Common application errors
@@ -15,11 +16,39 @@ var (
 	ErrConversationNotFound  = errors.New("conversation not found")
 	ErrMessageNotFound       = errors.New("message not found")
 	ErrArtifactNotFound      = errors.New("artifact not found")
+	ErrToolNotFound          = errors.New("tool not found")
+	ErrPaperAccountNotFound  = errors.New("paper account not found")
+	ErrPaperOrderNotFound    = errors.New("paper order not found")
+	ErrPaperPositionNotFound = errors.New("paper position not found")
+	ErrPortfolioNotFound     = errors.New("portfolio not found")
+	ErrPortfolioTransactionNotFound = errors.New("portfolio transaction not found")
+	ErrDuplicatePortfolioTransaction = errors.New("portfolio transaction already recorded")
+	ErrWatchlistNotFound     = errors.New("watchlist not found")
+	ErrAlertRuleNotFound     = errors.New("alert rule not found")
+	ErrAlertRuleStateNotFound = errors.New("alert rule state not found")
+	ErrDuplicateAlertEvent   = errors.New("alert already triggered for this candle")
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrBrokerConnectionNotFound = errors.New("broker connection not found")
+	ErrJournalEntryNotFound  = errors.New("journal entry not found")
+	ErrCalendarEventNotFound = errors.New("calendar event not found")
+	ErrDocumentNotFound      = errors.New("document not found")
+	ErrKnowledgeBaseNotFound = errors.New("knowledge base not found")
+	ErrUserMemoryNotFound    = errors.New("user memory not found")
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrOrganizationInvitationNotFound = errors.New("organization invitation not found")
 	ErrInvalidEmail          = errors.New("invalid email address")
 	ErrInvalidCredentials    = errors.New("invalid credentials")
 	ErrMagicLinkNotFound     = errors.New("magic link not found")
 	ErrMagicLinkExpired      = errors.New("magic link has expired")
 	ErrMagicLinkAlreadyUsed  = errors.New("magic link has already been used")
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrAPITokenNotFound      = errors.New("API token not found")
+	ErrIdentityNotFound      = errors.New("identity not found")
+	ErrOIDCLoginRequestNotFound = errors.New("OIDC login request not found")
+	ErrTOTPSecretNotFound    = errors.New("TOTP secret not found")
+	ErrWebAuthnCredentialNotFound = errors.New("WebAuthn credential not found")
+	ErrTwoFactorChallengeNotFound = errors.New("two-factor challenge not found")
+	ErrAccountDeletionNotFound = errors.New("account deletion not found")
 	ErrInvalidToken          = errors.New("invalid token")
 	ErrTokenExpired          = errors.New("token has expired")
 	ErrUnauthorized          = errors.New("unauthorized")
@@ -66,8 +95,28 @@ const (
 	CodeInternalServer = "INTERNAL_SERVER_ERROR"
 	CodeBadRequest     = "BAD_REQUEST"
 	CodeConfiguration  = "CONFIGURATION_ERROR"
+	CodeRateLimited    = "RATE_LIMITED"
 )
 
+// RateLimitError reports that a client made too many requests. It may try
+// again after RetryAfter.
+type RateLimitError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: %s (retry after %s)", CodeRateLimited, e.Message, e.RetryAfter)
+}
+
+// NewRateLimitError creates a new RateLimitError
+func NewRateLimitError(message string, retryAfter time.Duration) *RateLimitError {
+	return &RateLimitError{
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
 // Standard application errors
 var (
 	ErrBadRequest        = errors.New("bad request")
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"strings"
 )
 
 // Encrypt encrypts a plaintext string using AES-GCM with the provided key.
@@ -61,4 +62,86 @@ func Decrypt(ciphertextHex string, key []byte) (string, error) {
 	}
 
 	return string(plaintext), nil
-} 
\ No newline at end of file
+}
+
+// LegacyKeyID identifies the key of ciphertexts written before keys were
+// versioned, which carry no key ID.
+const LegacyKeyID = "0"
+
+// Keyring holds versioned encryption keys. Ciphertexts are prefixed with the
+// ID of the key that encrypted them, so they can be decrypted with any key of
+// the keyring while new ones are encrypted with the current key.
+type Keyring struct {
+	currentID string
+	keys      map[string][]byte
+}
+
+// NewKeyring creates a keyring that encrypts with the key of currentID. Keys
+// must be 32 bytes long for AES-256.
+func NewKeyring(currentID string, keys map[string][]byte) (*Keyring, error) {
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid encryption key ID %q", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("encryption key %s must be exactly 32 bytes for AES-256, got %d bytes", id, len(key))
+		}
+	}
+	if _, ok := keys[currentID]; !ok {
+		return nil, fmt.Errorf("current encryption key %q is not configured", currentID)
+	}
+	return &Keyring{currentID: currentID, keys: keys}, nil
+}
+
+// CurrentKeyID returns the ID of the key new ciphertexts are encrypted with.
+func (k *Keyring) CurrentKeyID() string {
+	return k.currentID
+}
+
+// Encrypt encrypts a plaintext string with the current key.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	ciphertext, err := Encrypt(plaintext, k.keys[k.currentID])
+	if err != nil {
+		return "", err
+	}
+	return k.currentID + ":" + ciphertext, nil
+}
+
+// Decrypt decrypts a ciphertext string with the key it was encrypted with.
+func (k *Keyring) Decrypt(ciphertext string) (string, error) {
+	keyID, ciphertextHex := splitKeyID(ciphertext)
+	key, ok := k.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("encryption key %q is not configured", keyID)
+	}
+	return Decrypt(ciphertextHex, key)
+}
+
+// Reencrypt encrypts a ciphertext string again with the current key. It
+// reports false, and returns the ciphertext unchanged, if the current key
+// already encrypted it.
+func (k *Keyring) Reencrypt(ciphertext string) (string, bool, error) {
+	if strings.HasPrefix(ciphertext, k.currentID+":") {
+		return ciphertext, false, nil
+	}
+	plaintext, err := k.Decrypt(ciphertext)
+	if err != nil {
+		return "", false, err
+	}
+	reencrypted, err := k.Encrypt(plaintext)
+	if err != nil {
+		return "", false, err
+	}
+	return reencrypted, true, nil
+}
+
+// splitKeyID splits a ciphertext into the ID of the key it was encrypted with
+// and the hex-encoded ciphertext. Hex never contains a colon, so ciphertexts
+// without a prefix are legacy ones.
+func splitKeyID(ciphertext string) (string, string) {
+	keyID, ciphertextHex, found := strings.Cut(ciphertext, ":")
+	if !found {
+		return LegacyKeyID, ciphertext
+	}
+	return keyID, ciphertextHex
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 )
@@ -25,4 +26,10 @@ func HashToken(token string) string {
 // VerifyTokenHash verifies if a plain token matches its hash
 func VerifyTokenHash(plainToken, hashedToken string) bool {
 	return HashToken(plainToken) == hashedToken
-} 
\ No newline at end of file
+}
+
+// PKCEChallenge derives the S256 code challenge of a PKCE code verifier
+func PKCEChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -6,21 +6,41 @@ import (
 	"trading-alchemist/internal/domain/auth"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type Claims struct {
-	Email string `json:"email"`
+	Email     string `json:"email"`
+	SessionID string `json:"sid,omitempty"` // Session the token was issued for
 	jwt.RegisteredClaims
+
+	// Set instead of a session when the request is authenticated with a personal access token
+	APITokenID string   `json:"-"`
+	Scopes     []string `json:"-"`
+
+	// Loaded from the database when the token is validated, so role changes apply immediately
+	Role auth.Role `json:"-"`
+}
+
+// AllowsScope reports whether the request may access the resource. Requests
+// authenticated with a JWT may access everything; personal access tokens need
+// a matching scope.
+func (c *Claims) AllowsScope(resource string, write bool) bool {
+	if c.APITokenID == "" {
+		return true
+	}
+	return auth.AllowsScope(c.Scopes, resource, write)
 }
 
-// GenerateJWT creates a new JWT token
-func GenerateJWT(user *auth.User, secret string, ttl time.Duration, issuer string) (string, error) {
+// GenerateJWT creates a new JWT token for a session of the user
+func GenerateJWT(user *auth.User, sessionID uuid.UUID, secret string, ttl time.Duration, issuer string) (string, error) {
 	if user == nil {
 		return "", fmt.Errorf("user cannot be nil")
 	}
 
 	claims := &Claims{
-		Email: user.Email,
+		Email:     user.Email,
+		SessionID: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),